@@ -0,0 +1,39 @@
+// Code generated by "make api"; DO NOT EDIT.
+package credentials
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+type TlsCertificateAttributes struct {
+	Certificate    string `json:"certificate,omitempty"`
+	PrivateKey     string `json:"private_key,omitempty"`
+	PrivateKeyHmac string `json:"private_key_hmac,omitempty"`
+}
+
+func AttributesMapToTlsCertificateAttributes(in map[string]interface{}) (*TlsCertificateAttributes, error) {
+	if in == nil {
+		return nil, fmt.Errorf("nil input map")
+	}
+	var out TlsCertificateAttributes
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:  &out,
+		TagName: "json",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating mapstructure decoder: %w", err)
+	}
+	if err := dec.Decode(in); err != nil {
+		return nil, fmt.Errorf("error decoding: %w", err)
+	}
+	return &out, nil
+}
+
+func (pt *Credential) GetTlsCertificateAttributes() (*TlsCertificateAttributes, error) {
+	if pt.Type != "tlscertificate" {
+		return nil, fmt.Errorf("asked to fetch %s-type attributes but credential is of type %s", "tlscertificate", pt.Type)
+	}
+	return AttributesMapToTlsCertificateAttributes(pt.Attributes)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package groups
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/roles"
+)
+
+// HistoryListResult is the result of listing the history of a group.
+type HistoryListResult struct {
+	Items    []*roles.HistoryEntry
+	response *api.Response
+}
+
+func (n HistoryListResult) GetItems() []*roles.HistoryEntry {
+	return n.Items
+}
+
+func (n HistoryListResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// ListHistory returns the changes made to the group, its members and its
+// assignment to roles, oldest first.
+func (c *Client) ListHistory(ctx context.Context, groupId string, opt ...Option) (*HistoryListResult, error) {
+	if groupId == "" {
+		return nil, fmt.Errorf("empty groupId value passed into ListHistory request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	// The controller returns the history a page at a time, so read pages
+	// until an empty one.
+	target := new(HistoryListResult)
+	var afterId uint32
+	for {
+		req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("groups/%s:list-history", url.PathEscape(groupId)), nil, apiOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating ListHistory request: %w", err)
+		}
+		if afterId != 0 {
+			q := req.URL.Query()
+			q.Set("after_id", strconv.FormatUint(uint64(afterId), 10))
+			req.URL.RawQuery = q.Encode()
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error performing client request during ListHistory call: %w", err)
+		}
+
+		page := new(HistoryListResult)
+		apiErr, err := resp.Decode(page)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding ListHistory response: %w", err)
+		}
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		target.response = resp
+		if len(page.Items) == 0 {
+			return target, nil
+		}
+		target.Items = append(target.Items, page.Items...)
+		afterId = page.Items[len(page.Items)-1].Id
+	}
+}
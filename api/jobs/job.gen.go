@@ -0,0 +1,151 @@
+// Code generated by "make api"; DO NOT EDIT.
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/scopes"
+)
+
+type Job struct {
+	Id                  string            `json:"id,omitempty"`
+	Scope               *scopes.ScopeInfo `json:"scope,omitempty"`
+	Description         string            `json:"description,omitempty"`
+	NextScheduledRun    time.Time         `json:"next_scheduled_run,omitempty"`
+	CurrentRun          *JobRun           `json:"current_run,omitempty"`
+	LastRun             *JobRun           `json:"last_run,omitempty"`
+	ConsecutiveFailures uint32            `json:"consecutive_failures,omitempty"`
+	AuthorizedActions   []string          `json:"authorized_actions,omitempty"`
+
+	response *api.Response
+}
+
+type JobReadResult struct {
+	Item     *Job
+	response *api.Response
+}
+
+func (n JobReadResult) GetItem() *Job {
+	return n.Item
+}
+
+func (n JobReadResult) GetResponse() *api.Response {
+	return n.response
+}
+
+type JobListResult struct {
+	Items    []*Job
+	response *api.Response
+}
+
+func (n JobListResult) GetItems() []*Job {
+	return n.Items
+}
+
+func (n JobListResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// Client is a client for this collection
+type Client struct {
+	client *api.Client
+}
+
+// Creates a new client for this collection. The submitted API client is cloned;
+// modifications to it after generating this client will not have effect. If you
+// need to make changes to the underlying API client, use ApiClient() to access
+// it.
+func NewClient(c *api.Client) *Client {
+	return &Client{client: c.Clone()}
+}
+
+// ApiClient returns the underlying API client
+func (c *Client) ApiClient() *api.Client {
+	return c.client
+}
+
+func (c *Client) Read(ctx context.Context, id string, opt ...Option) (*JobReadResult, error) {
+	if id == "" {
+		return nil, fmt.Errorf("empty id value passed into Read request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("jobs/%s", url.PathEscape(id)), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Read request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Read call: %w", err)
+	}
+
+	target := new(JobReadResult)
+	target.Item = new(Job)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Read response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+func (c *Client) List(ctx context.Context, scopeId string, opt ...Option) (*JobListResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into List request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.queryMap["scope_id"] = scopeId
+
+	req, err := c.client.NewRequest(ctx, "GET", "jobs", nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating List request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during List call: %w", err)
+	}
+
+	target := new(JobListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding List response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
@@ -0,0 +1,17 @@
+// Code generated by "make api"; DO NOT EDIT.
+package jobs
+
+import (
+	"time"
+)
+
+type JobRun struct {
+	Status         string    `json:"status,omitempty"`
+	ControllerId   string    `json:"controller_id,omitempty"`
+	StartTime      time.Time `json:"start_time,omitempty"`
+	UpdatedTime    time.Time `json:"updated_time,omitempty"`
+	EndTime        time.Time `json:"end_time,omitempty"`
+	CompletedCount uint32    `json:"completed_count,omitempty"`
+	TotalCount     uint32    `json:"total_count,omitempty"`
+	ErrorMessage   string    `json:"error_message,omitempty"`
+}
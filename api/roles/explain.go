@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package roles
+
+import (
+	"context"
+	"fmt"
+)
+
+// Explain evaluates whether the principal is authorized to perform the action
+// on the resource and returns the grants that decide it. An empty principalId
+// evaluates the permissions of the caller. If resourceType is set, the action is
+// evaluated against the collection of that type within the scope or parent
+// resource identified by resourceId.
+func (c *Client) Explain(ctx context.Context, principalId, resourceId, resourceType, actionName string, opt ...Option) (*ExplanationReadResult, error) {
+	if resourceId == "" {
+		return nil, fmt.Errorf("empty resourceId value passed into Explain request")
+	}
+	if actionName == "" {
+		return nil, fmt.Errorf("empty actionName value passed into Explain request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts := map[string]any{
+		"resource_id": resourceId,
+		"action":      actionName,
+	}
+	if principalId != "" {
+		opts["principal_id"] = principalId
+	}
+	if resourceType != "" {
+		opts["type"] = resourceType
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", "roles:explain", opts, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Explain request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Explain call: %w", err)
+	}
+
+	target := new(ExplanationReadResult)
+	target.Item = new(Explanation)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Explain response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
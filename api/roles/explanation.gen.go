@@ -0,0 +1,33 @@
+// Code generated by "make api"; DO NOT EDIT.
+package roles
+
+import (
+	"github.com/hashicorp/boundary/api"
+)
+
+type Explanation struct {
+	PrincipalId   string              `json:"principal_id,omitempty"`
+	ResourceId    string              `json:"resource_id,omitempty"`
+	Type          string              `json:"type,omitempty"`
+	ScopeId       string              `json:"scope_id,omitempty"`
+	Action        string              `json:"action,omitempty"`
+	Authorized    bool                `json:"authorized,omitempty"`
+	AllowedBy     []*ExplanationGrant `json:"allowed_by,omitempty"`
+	DeniedBy      *ExplanationGrant   `json:"denied_by,omitempty"`
+	RequiredGrant string              `json:"required_grant,omitempty"`
+
+	response *api.Response
+}
+
+type ExplanationReadResult struct {
+	Item     *Explanation
+	response *api.Response
+}
+
+func (n ExplanationReadResult) GetItem() *Explanation {
+	return n.Item
+}
+
+func (n ExplanationReadResult) GetResponse() *api.Response {
+	return n.response
+}
@@ -0,0 +1,8 @@
+// Code generated by "make api"; DO NOT EDIT.
+package roles
+
+type ExplanationGrant struct {
+	RoleId       string `json:"role_id,omitempty"`
+	GrantScopeId string `json:"grant_scope_id,omitempty"`
+	Grant        string `json:"grant,omitempty"`
+}
@@ -5,4 +5,5 @@ type GrantJson struct {
 	Id      string   `json:"id,omitempty"`
 	Type    string   `json:"type,omitempty"`
 	Actions []string `json:"actions,omitempty"`
+	Effect  string   `json:"effect,omitempty"`
 }
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package roles
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ListHistory returns the changes made to the role, its grants, grant scopes
+// and principals, oldest first.
+func (c *Client) ListHistory(ctx context.Context, roleId string, opt ...Option) (*HistoryEntryListResult, error) {
+	if roleId == "" {
+		return nil, fmt.Errorf("empty roleId value passed into ListHistory request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	// The controller returns the history a page at a time, so read pages
+	// until an empty one.
+	target := new(HistoryEntryListResult)
+	var afterId uint32
+	for {
+		req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("roles/%s:list-history", url.PathEscape(roleId)), nil, apiOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating ListHistory request: %w", err)
+		}
+		if afterId != 0 {
+			q := req.URL.Query()
+			q.Set("after_id", strconv.FormatUint(uint64(afterId), 10))
+			req.URL.RawQuery = q.Encode()
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error performing client request during ListHistory call: %w", err)
+		}
+
+		page := new(HistoryEntryListResult)
+		apiErr, err := resp.Decode(page)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding ListHistory response: %w", err)
+		}
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		target.response = resp
+		if len(page.Items) == 0 {
+			return target, nil
+		}
+		target.Items = append(target.Items, page.Items...)
+		afterId = page.Items[len(page.Items)-1].Id
+	}
+}
@@ -6,6 +6,7 @@ package roles
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/api"
 )
@@ -125,3 +126,15 @@ func DefaultName() Option {
 		o.postMap["name"] = nil
 	}
 }
+
+func WithNotAfter(inNotAfter time.Time) Option {
+	return func(o *options) {
+		o.postMap["not_after"] = inNotAfter
+	}
+}
+
+func WithNotBefore(inNotBefore time.Time) Option {
+	return func(o *options) {
+		o.postMap["not_before"] = inNotBefore
+	}
+}
@@ -54,6 +54,19 @@ func (n KeyVersionDestructionResult) GetResponse() *api.Response {
 	return n.response
 }
 
+type KeyRotationPolicyResult struct {
+	Item     *KeyRotationPolicy
+	response *api.Response
+}
+
+func (n KeyRotationPolicyResult) GetItem() *KeyRotationPolicy {
+	return n.Item
+}
+
+func (n KeyRotationPolicyResult) GetResponse() *api.Response {
+	return n.response
+}
+
 func (c *Client) ListKeys(ctx context.Context, scopeId string, opt ...Option) (*KeyListResult, error) {
 	if scopeId == "" {
 		return nil, fmt.Errorf("empty scopeId value passed into ListKeys request")
@@ -215,3 +228,145 @@ func (c *Client) DestroyKeyVersion(ctx context.Context, scopeId string, keyVersi
 	target.response = resp
 	return target, nil
 }
+
+// SetQuotas replaces the resource quotas of the org or project scope. An empty
+// quotas removes all quotas from the scope.
+func (c *Client) SetQuotas(ctx context.Context, scopeId string, version uint32, quotas []*Quota, opt ...Option) (*ScopeUpdateResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into SetQuotas request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if version == 0 {
+		if !opts.withAutomaticVersioning {
+			return nil, fmt.Errorf("zero version number passed into SetQuotas request")
+		}
+		existingTarget, existingErr := c.Read(ctx, scopeId, append([]Option{WithSkipCurlOutput(true)}, opt...)...)
+		if existingErr != nil {
+			if api.AsServerError(existingErr) != nil {
+				return nil, fmt.Errorf("error from controller when performing initial check-and-set read: %w", existingErr)
+			}
+			return nil, fmt.Errorf("error performing initial check-and-set read: %w", existingErr)
+		}
+		if existingTarget == nil || existingTarget.Item == nil {
+			return nil, fmt.Errorf("nil resource found when performing initial check-and-set read")
+		}
+		version = existingTarget.Item.Version
+	}
+
+	if quotas == nil {
+		quotas = []*Quota{}
+	}
+	opts.postMap["version"] = version
+	opts.postMap["quotas"] = quotas
+
+	req, err := c.client.NewRequest(ctx, "POST", "scopes/"+url.PathEscape(scopeId)+":set-quotas", opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating SetQuotas request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during SetQuotas call: %w", err)
+	}
+
+	target := new(ScopeUpdateResult)
+	target.Item = new(Scope)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding SetQuotas response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// ReadKeyRotationPolicy returns the key rotation policy of the scope, with the
+// time of its next scheduled rotation and the history of the rotations of the
+// keys in the scope.
+func (c *Client) ReadKeyRotationPolicy(ctx context.Context, scopeId string, opt ...Option) (*KeyRotationPolicyResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ReadKeyRotationPolicy request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", "scopes/"+url.PathEscape(scopeId)+":read-key-rotation-policy", nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ReadKeyRotationPolicy request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ReadKeyRotationPolicy call: %w", err)
+	}
+
+	target := new(KeyRotationPolicyResult)
+	target.Item = new(KeyRotationPolicy)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ReadKeyRotationPolicy response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// SetKeyRotationPolicy sets the interval, in seconds, at which the keys in the
+// scope are rotated by the controller, and whether the previously active key
+// versions are destroyed after each scheduled rotation. An interval of zero
+// removes the policy.
+func (c *Client) SetKeyRotationPolicy(ctx context.Context, scopeId string, rotationIntervalSeconds uint32, destroyPreviousVersions bool, opt ...Option) (*KeyRotationPolicyResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into SetKeyRotationPolicy request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.postMap["scope_id"] = scopeId
+	opts.postMap["rotation_interval_seconds"] = rotationIntervalSeconds
+	opts.postMap["destroy_previous_versions"] = destroyPreviousVersions
+
+	req, err := c.client.NewRequest(ctx, "POST", "scopes:set-key-rotation-policy", opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating SetKeyRotationPolicy request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during SetKeyRotationPolicy call: %w", err)
+	}
+
+	target := new(KeyRotationPolicyResult)
+	target.Item = new(KeyRotationPolicy)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding SetKeyRotationPolicy response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
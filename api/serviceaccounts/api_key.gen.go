@@ -0,0 +1,15 @@
+// Code generated by "make api"; DO NOT EDIT.
+package serviceaccounts
+
+import (
+	"time"
+)
+
+type ApiKey struct {
+	Id             string    `json:"id,omitempty"`
+	ScopeId        string    `json:"scope_id,omitempty"`
+	CreatedTime    time.Time `json:"created_time,omitempty"`
+	ExpirationTime time.Time `json:"expiration_time,omitempty"`
+	LastUsedTime   time.Time `json:"last_used_time,omitempty"`
+	Token          string    `json:"token,omitempty"`
+}
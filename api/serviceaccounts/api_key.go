@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceaccounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// AddApiKey adds an API key usable within the given org or project scope to
+// the service account. If expirationTime is zero the controller's default
+// expiration is used. The token of the new key is only returned by this call,
+// in the Token field of the matching entry of the returned item's ApiKeys.
+func (c *Client) AddApiKey(ctx context.Context, id string, version uint32, apiKeyScopeId string, expirationTime time.Time, opt ...Option) (*ServiceAccountUpdateResult, error) {
+	if apiKeyScopeId == "" {
+		return nil, fmt.Errorf("empty apiKeyScopeId value passed into AddApiKey request")
+	}
+	body := map[string]any{
+		"api_key_scope_id": apiKeyScopeId,
+	}
+	if !expirationTime.IsZero() {
+		body["api_key_expiration_time"] = expirationTime.UTC().Format(time.RFC3339Nano)
+	}
+	return c.apiKeyAction(ctx, "add-api-key", "AddApiKey", id, version, body, opt...)
+}
+
+// RemoveApiKey removes the API key with the given id from the service account.
+// The key can no longer be used once this returns.
+func (c *Client) RemoveApiKey(ctx context.Context, id string, version uint32, apiKeyId string, opt ...Option) (*ServiceAccountUpdateResult, error) {
+	if apiKeyId == "" {
+		return nil, fmt.Errorf("empty apiKeyId value passed into RemoveApiKey request")
+	}
+	body := map[string]any{
+		"api_key_id": apiKeyId,
+	}
+	return c.apiKeyAction(ctx, "remove-api-key", "RemoveApiKey", id, version, body, opt...)
+}
+
+func (c *Client) apiKeyAction(ctx context.Context, action, callName, id string, version uint32, body map[string]any, opt ...Option) (*ServiceAccountUpdateResult, error) {
+	if id == "" {
+		return nil, fmt.Errorf("empty id value passed into %s request", callName)
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if version == 0 {
+		if !opts.withAutomaticVersioning {
+			return nil, fmt.Errorf("zero version number passed into %s request and automatic versioning not specified", callName)
+		}
+		existingTarget, existingErr := c.Read(ctx, id, append([]Option{WithSkipCurlOutput(true)}, opt...)...)
+		if existingErr != nil {
+			if api.AsServerError(existingErr) != nil {
+				return nil, fmt.Errorf("error from controller when performing initial check-and-set read: %w", existingErr)
+			}
+			return nil, fmt.Errorf("error performing initial check-and-set read: %w", existingErr)
+		}
+		if existingTarget == nil {
+			return nil, errors.New("nil resource response found when performing initial check-and-set read")
+		}
+		if existingTarget.Item == nil {
+			return nil, errors.New("nil resource found when performing initial check-and-set read")
+		}
+		version = existingTarget.Item.Version
+	}
+
+	body["version"] = version
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("service-accounts/%s:%s", url.PathEscape(id), action), body, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s request: %w", callName, err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during %s call: %w", callName, err)
+	}
+
+	target := new(ServiceAccountUpdateResult)
+	target.Item = new(ServiceAccount)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s response: %w", callName, err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
@@ -138,6 +138,30 @@ func DefaultBrokeredCredentialSourceIds() Option {
 	}
 }
 
+func WithConnectionIdleTimeoutSeconds(inConnectionIdleTimeoutSeconds uint32) Option {
+	return func(o *options) {
+		o.postMap["connection_idle_timeout_seconds"] = inConnectionIdleTimeoutSeconds
+	}
+}
+
+func DefaultConnectionIdleTimeoutSeconds() Option {
+	return func(o *options) {
+		o.postMap["connection_idle_timeout_seconds"] = nil
+	}
+}
+
+func WithConnectionMaxBytesPerSecond(inConnectionMaxBytesPerSecond int64) Option {
+	return func(o *options) {
+		o.postMap["connection_max_bytes_per_second"] = inConnectionMaxBytesPerSecond
+	}
+}
+
+func DefaultConnectionMaxBytesPerSecond() Option {
+	return func(o *options) {
+		o.postMap["connection_max_bytes_per_second"] = nil
+	}
+}
+
 func WithSshTargetDefaultPort(inDefaultPort uint32) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
@@ -210,6 +234,42 @@ func DefaultEgressWorkerFilter() Option {
 	}
 }
 
+func WithEndpointTlsCaCert(inEndpointTlsCaCert string) Option {
+	return func(o *options) {
+		o.postMap["endpoint_tls_ca_cert"] = inEndpointTlsCaCert
+	}
+}
+
+func DefaultEndpointTlsCaCert() Option {
+	return func(o *options) {
+		o.postMap["endpoint_tls_ca_cert"] = nil
+	}
+}
+
+func WithEndpointTlsEnabled(inEndpointTlsEnabled bool) Option {
+	return func(o *options) {
+		o.postMap["endpoint_tls_enabled"] = inEndpointTlsEnabled
+	}
+}
+
+func DefaultEndpointTlsEnabled() Option {
+	return func(o *options) {
+		o.postMap["endpoint_tls_enabled"] = nil
+	}
+}
+
+func WithEndpointTlsServerName(inEndpointTlsServerName string) Option {
+	return func(o *options) {
+		o.postMap["endpoint_tls_server_name"] = inEndpointTlsServerName
+	}
+}
+
+func DefaultEndpointTlsServerName() Option {
+	return func(o *options) {
+		o.postMap["endpoint_tls_server_name"] = nil
+	}
+}
+
 func WithHostId(inHostId string) Option {
 	return func(o *options) {
 		o.postMap["host_id"] = inHostId
@@ -276,6 +336,18 @@ func DefaultSessionConnectionLimit() Option {
 	}
 }
 
+func WithSessionMaxBytes(inSessionMaxBytes int64) Option {
+	return func(o *options) {
+		o.postMap["session_max_bytes"] = inSessionMaxBytes
+	}
+}
+
+func DefaultSessionMaxBytes() Option {
+	return func(o *options) {
+		o.postMap["session_max_bytes"] = nil
+	}
+}
+
 func WithSessionMaxSeconds(inSessionMaxSeconds uint32) Option {
 	return func(o *options) {
 		o.postMap["session_max_seconds"] = inSessionMaxSeconds
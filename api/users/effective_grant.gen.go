@@ -0,0 +1,28 @@
+// Code generated by "make api"; DO NOT EDIT.
+package users
+
+import (
+	"github.com/hashicorp/boundary/api"
+)
+
+type EffectiveGrant struct {
+	RoleId       string `json:"role_id,omitempty"`
+	RoleScopeId  string `json:"role_scope_id,omitempty"`
+	GrantScopeId string `json:"grant_scope_id,omitempty"`
+	Grant        string `json:"grant,omitempty"`
+
+	response *api.Response
+}
+
+type EffectiveGrantListResult struct {
+	Items    []*EffectiveGrant
+	response *api.Response
+}
+
+func (n EffectiveGrantListResult) GetItems() []*EffectiveGrant {
+	return n.Items
+}
+
+func (n EffectiveGrantListResult) GetResponse() *api.Response {
+	return n.response
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/roles"
+)
+
+// HistoryListResult is the result of listing the history of a user.
+type HistoryListResult struct {
+	Items    []*roles.HistoryEntry
+	response *api.Response
+}
+
+func (n HistoryListResult) GetItems() []*roles.HistoryEntry {
+	return n.Items
+}
+
+func (n HistoryListResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// ListHistory returns the changes made to the user, its accounts, its group
+// memberships and its assignment to roles, oldest first.
+func (c *Client) ListHistory(ctx context.Context, userId string, opt ...Option) (*HistoryListResult, error) {
+	if userId == "" {
+		return nil, fmt.Errorf("empty userId value passed into ListHistory request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	// The controller returns the history a page at a time, so read pages
+	// until an empty one.
+	target := new(HistoryListResult)
+	var afterId uint32
+	for {
+		req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("users/%s:list-history", url.PathEscape(userId)), nil, apiOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating ListHistory request: %w", err)
+		}
+		if afterId != 0 {
+			q := req.URL.Query()
+			q.Set("after_id", strconv.FormatUint(uint64(afterId), 10))
+			req.URL.RawQuery = q.Encode()
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error performing client request during ListHistory call: %w", err)
+		}
+
+		page := new(HistoryListResult)
+		apiErr, err := resp.Decode(page)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding ListHistory response: %w", err)
+		}
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		target.response = resp
+		if len(page.Items) == 0 {
+			return target, nil
+		}
+		target.Items = append(target.Items, page.Items...)
+		afterId = page.Items[len(page.Items)-1].Id
+	}
+}
+
+// ListEffectiveGrants returns the grants that apply to the user. If at is not
+// the zero time, the grants that applied at that time are returned instead.
+func (c *Client) ListEffectiveGrants(ctx context.Context, userId string, at time.Time, opt ...Option) (*EffectiveGrantListResult, error) {
+	if userId == "" {
+		return nil, fmt.Errorf("empty userId value passed into ListEffectiveGrants request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("users/%s:list-effective-grants", url.PathEscape(userId)), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ListEffectiveGrants request: %w", err)
+	}
+	if !at.IsZero() {
+		q := url.Values{}
+		q.Add("at", at.UTC().Format(time.RFC3339Nano))
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ListEffectiveGrants call: %w", err)
+	}
+
+	target := new(EffectiveGrantListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListEffectiveGrants response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
@@ -24,6 +24,7 @@ const (
 	ApproximateLastUsedTimeField                = "approximate_last_used_time"
 	MembersField                                = "members"
 	MemberIdsField                              = "member_ids"
+	ApiKeysField                                = "api_keys"
 	HostCatalogIdField                          = "host_catalog_id"
 	HostSetIdsField                             = "host_set_ids"
 	HostSourceIdsField                          = "host_source_ids"
@@ -31,9 +32,11 @@ const (
 	PrincipalIdsField                           = "principal_ids"
 	PrincipalsField                             = "principals"
 	GrantScopeIdField                           = "grant_scope_id"
+	GrantScopeIdsField                          = "grant_scope_ids"
 	GrantsField                                 = "grants"
 	GrantStringsField                           = "grant_strings"
 	PrimaryAuthMethodIdField                    = "primary_auth_method_id"
+	QuotasField                                 = "quotas"
 	TargetIdField                               = "target_id"
 	HostIdField                                 = "host_id"
 	HostSetIdField                              = "host_set_id"
@@ -47,6 +50,12 @@ const (
 	StatesField                                 = "states"
 	SessionConnectionLimitField                 = "session_connection_limit"
 	SessionMaxSecondsField                      = "session_max_seconds"
+	SessionMaxBytesField                        = "session_max_bytes"
+	ConnectionMaxBytesPerSecondField            = "connection_max_bytes_per_second"
+	ConnectionIdleTimeoutSecondsField           = "connection_idle_timeout_seconds"
+	EndpointTlsEnabledField                     = "endpoint_tls_enabled"
+	EndpointTlsCaCertField                      = "endpoint_tls_ca_cert"
+	EndpointTlsServerNameField                  = "endpoint_tls_server_name"
 	WorkerFilterField                           = "worker_filter"
 	EgressWorkerFilterField                     = "egress_worker_filter"
 	IngressWorkerFilterField                    = "ingress_worker_filter"
@@ -83,6 +92,7 @@ const (
 	AddressField                                = "address"
 	CanonicalAddressField                       = "canonical_address"
 	TagsField                                   = "tags"
+	ResourceTagsField                           = "resource_tags"
 	CanonicalTagsField                          = "canonical_tags"
 	ConfigTagsField                             = "config_tags"
 	ApiTagsField                                = "api_tags"
@@ -97,6 +107,14 @@ const (
 	KeyVersionIdField                           = "key_version_id"
 	CompletedCountField                         = "completed_count"
 	TotalCountField                             = "total_count"
+	RotationIntervalSecondsField                = "rotation_interval_seconds"
+	DestroyPreviousVersionsField                = "destroy_previous_versions"
+	NextRotationTimeField                       = "next_rotation_time"
+	RotationsField                              = "rotations"
+	NextScheduledRunField                       = "next_scheduled_run"
+	CurrentRunField                             = "current_run"
+	LastRunField                                = "last_run"
+	ConsecutiveFailuresField                    = "consecutive_failures"
 	DirectlyConnectedDownstreamWorkersField     = "directly_connected_downstream_workers"
 	AttributesAddressField                      = "attributes.address"
 )
@@ -12,6 +12,13 @@ const (
 	AnyAuthenticatedUserId = "u_auth"
 	AnonymousUserId        = "u_anon"
 	RecoveryUserId         = "u_recovery"
+
+	// GrantScopeThis, GrantScopeChildren, and GrantScopeDescendants are the
+	// special values that can be used in place of a scope ID when setting the
+	// grant scopes of a role
+	GrantScopeThis        = "this"
+	GrantScopeChildren    = "children"
+	GrantScopeDescendants = "descendants"
 )
 
 type (
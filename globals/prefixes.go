@@ -54,6 +54,10 @@ const (
 	GroupPrefix = "g"
 	// RolePrefix is the prefix for roles
 	RolePrefix = "r"
+	// ServiceAccountPrefix is the prefix for service accounts
+	ServiceAccountPrefix = "sa"
+	// ServiceAccountApiKeyPrefix is the prefix for service account API keys
+	ServiceAccountApiKeyPrefix = "sak"
 
 	// StaticCredentialStorePrefix is the prefix for static credential stores
 	StaticCredentialStorePrefix = "csst"
@@ -79,6 +83,9 @@ const (
 	SshPrivateKeyCredentialPrefix = "credspk"
 	// JsonCredentialPrefix is the prefix for generic JSON creds
 	JsonCredentialPrefix = "credjson"
+	// TlsCertificateCredentialPrefix is the prefix for TLS client certificate
+	// creds
+	TlsCertificateCredentialPrefix = "credtls"
 
 	// StaticHostCatalogPrefix is the prefix for static host catalogs
 	StaticHostCatalogPrefix = "hcst"
@@ -127,6 +134,7 @@ var prefixToResourceType = map[string]resource.Type{
 	UserPrefix:                                 resource.User,
 	GroupPrefix:                                resource.Group,
 	RolePrefix:                                 resource.Role,
+	ServiceAccountPrefix:                       resource.ServiceAccount,
 	StaticCredentialStorePrefix:                resource.CredentialStore,
 	StaticCredentialStorePreviousPrefix:        resource.CredentialStore,
 	VaultCredentialStorePrefix:                 resource.CredentialStore,
@@ -136,6 +144,7 @@ var prefixToResourceType = map[string]resource.Type{
 	UsernamePasswordCredentialPreviousPrefix:   resource.Credential,
 	SshPrivateKeyCredentialPrefix:              resource.Credential,
 	JsonCredentialPrefix:                       resource.Credential,
+	TlsCertificateCredentialPrefix:             resource.Credential,
 	StaticHostCatalogPrefix:                    resource.HostCatalog,
 	StaticHostSetPrefix:                        resource.HostSet,
 	StaticHostPrefix:                           resource.Host,
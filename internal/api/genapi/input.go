@@ -17,10 +17,12 @@ import (
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hostcatalogs"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hosts"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hostsets"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/jobs"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/managedgroups"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/plugins"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/roles"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/serviceaccounts"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/sessions"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/users"
@@ -182,6 +184,21 @@ var inputStructs = []*structInfo{
 			{Name: "TotalCount", JsonTags: []string{"string"}},
 		},
 	},
+	{
+		inProto:     &scopes.Quota{},
+		outFile:     "scopes/quota.gen.go",
+		skipOptions: true,
+	},
+	{
+		inProto:     &scopes.KeyRotation{},
+		outFile:     "scopes/key_rotation.gen.go",
+		skipOptions: true,
+	},
+	{
+		inProto:     &scopes.KeyRotationPolicy{},
+		outFile:     "scopes/key_rotation_policy.gen.go",
+		skipOptions: true,
+	},
 	{
 		inProto: &scopes.Scope{},
 		outFile: "scopes/scope.gen.go",
@@ -218,6 +235,12 @@ var inputStructs = []*structInfo{
 		outFile:     "users/account.gen.go",
 		skipOptions: true,
 	},
+	{
+		inProto:             &users.EffectiveGrant{},
+		outFile:             "users/effective_grant.gen.go",
+		skipOptions:         true,
+		createResponseTypes: []string{ListResponseType},
+	},
 	{
 		inProto: &users.User{},
 		outFile: "users/user.gen.go",
@@ -268,6 +291,28 @@ var inputStructs = []*structInfo{
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
 		recursiveListing:    true,
 	},
+	// Service account related resources
+	{
+		inProto:     &serviceaccounts.ApiKey{},
+		outFile:     "serviceaccounts/api_key.gen.go",
+		skipOptions: true,
+	},
+	{
+		inProto: &serviceaccounts.ServiceAccount{},
+		outFile: "serviceaccounts/service_account.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			commonCreateTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "service-accounts",
+		versionEnabled:      true,
+		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
+		recursiveListing:    true,
+	},
 	// Role related resources
 	{
 		inProto:     &roles.Grant{},
@@ -284,6 +329,28 @@ var inputStructs = []*structInfo{
 		outFile:     "roles/grant_json.gen.go",
 		skipOptions: true,
 	},
+	{
+		inProto:     &roles.HistoryChange{},
+		outFile:     "roles/history_change.gen.go",
+		skipOptions: true,
+	},
+	{
+		inProto:             &roles.HistoryEntry{},
+		outFile:             "roles/history_entry.gen.go",
+		skipOptions:         true,
+		createResponseTypes: []string{ListResponseType},
+	},
+	{
+		inProto:     &roles.ExplanationGrant{},
+		outFile:     "roles/explanation_grant.gen.go",
+		skipOptions: true,
+	},
+	{
+		inProto:             &roles.Explanation{},
+		outFile:             "roles/explanation.gen.go",
+		skipOptions:         true,
+		createResponseTypes: []string{ReadResponseType},
+	},
 	{
 		inProto: &roles.Role{},
 		outFile: "roles/role.gen.go",
@@ -304,6 +371,24 @@ var inputStructs = []*structInfo{
 				SliceType: "[]string",
 				VarName:   "grantStrings",
 			},
+			"GrantScopes": {
+				SliceType: "[]string",
+				VarName:   "grantScopeIds",
+			},
+		},
+		extraFields: []fieldInfo{
+			{
+				Name:        "NotBefore",
+				ProtoName:   "not_before",
+				FieldType:   "time.Time",
+				SkipDefault: true,
+			},
+			{
+				Name:        "NotAfter",
+				ProtoName:   "not_after",
+				FieldType:   "time.Time",
+				SkipDefault: true,
+			},
 		},
 		pluralResourceName:  "roles",
 		versionEnabled:      true,
@@ -523,7 +608,13 @@ var inputStructs = []*structInfo{
 			deleteTemplate,
 			listTemplate,
 		},
-		pluralResourceName:  "credential-stores",
+		pluralResourceName: "credential-stores",
+		sliceSubtypes: map[string]sliceSubtypeInfo{
+			"Tags": {
+				SliceType: "map[string][]string",
+				VarName:   "tags",
+			},
+		},
 		parentTypeName:      "scope",
 		versionEnabled:      true,
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
@@ -669,6 +760,25 @@ var inputStructs = []*structInfo{
 			mapstructureConversionTemplate,
 		},
 	},
+	{
+		inProto:     &credentials.TlsCertificateAttributes{},
+		outFile:     "credentials/tls_certificate_attributes.gen.go",
+		subtypeName: "TlsCertificateCredential",
+		fieldOverrides: []fieldInfo{
+			{
+				Name:        "Certificate",
+				SkipDefault: true,
+			},
+			{
+				Name:        "PrivateKey",
+				SkipDefault: true,
+			},
+		},
+		parentTypeName: "Credential",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
 	{
 		inProto: &credentials.Credential{},
 		outFile: "credentials/credential.gen.go",
@@ -751,7 +861,13 @@ var inputStructs = []*structInfo{
 				SkipDefault: true,
 			},
 		},
-		pluralResourceName:  "host-catalogs",
+		pluralResourceName: "host-catalogs",
+		sliceSubtypes: map[string]sliceSubtypeInfo{
+			"Tags": {
+				SliceType: "map[string][]string",
+				VarName:   "tags",
+			},
+		},
 		versionEnabled:      true,
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
 		recursiveListing:    true,
@@ -890,6 +1006,10 @@ var inputStructs = []*structInfo{
 				VarName:   "hostSourceIds",
 			},
 			"CredentialSources": {},
+			"Tags": {
+				SliceType: "map[string][]string",
+				VarName:   "tags",
+			},
 		},
 		extraFields: []fieldInfo{
 			{
@@ -926,6 +1046,13 @@ var inputStructs = []*structInfo{
 				FieldType: "[]string",
 			},
 		},
+		fieldOverrides: []fieldInfo{
+			// int64 fields get marshalled by protobuf as strings, so we have
+			// to tell the json parser that their json representation is a
+			// string but they go into Go int64 types.
+			{Name: "SessionMaxBytes", JsonTags: []string{"string"}},
+			{Name: "ConnectionMaxBytesPerSecond", JsonTags: []string{"string"}},
+		},
 		versionEnabled:      true,
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
 		recursiveListing:    true,
@@ -1030,4 +1157,21 @@ var inputStructs = []*structInfo{
 		recursiveListing:    true,
 		versionEnabled:      true,
 	},
+	{
+		inProto:     &jobs.JobRun{},
+		outFile:     "jobs/job_run.gen.go",
+		skipOptions: true,
+	},
+	{
+		inProto: &jobs.Job{},
+		outFile: "jobs/job.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			readTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "jobs",
+		createResponseTypes: []string{ReadResponseType, ListResponseType},
+		recursiveListing:    true,
+	},
 }
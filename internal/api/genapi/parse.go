@@ -73,7 +73,7 @@ func parsePBs() {
 					name = fmt.Sprintf("%s.%s", pkg, name)
 				}
 				switch name {
-				case "v1.AuthorizedCollectionActionsEntry", "v1.CanonicalTagsEntry", "v1.TagsEntry", "v1.ConfigTagsEntry", "v1.ApiTagsEntry":
+				case "v1.AuthorizedCollectionActionsEntry", "v1.CanonicalTagsEntry", "v1.TagsEntry", "v1.ConfigTagsEntry", "v1.ApiTagsEntry", "v1.ResourceTagsEntry":
 					fi.FieldType = "map[string][]string"
 				default:
 					fi.FieldType = sliceText + ptr + name
@@ -104,6 +104,7 @@ var (
 	boolValueName   = (&wrapperspb.BoolValue{}).ProtoReflect().Descriptor().FullName()
 	uInt32ValueName = (&wrapperspb.UInt32Value{}).ProtoReflect().Descriptor().FullName()
 	int32ValueName  = (&wrapperspb.Int32Value{}).ProtoReflect().Descriptor().FullName()
+	int64ValueName  = (&wrapperspb.Int64Value{}).ProtoReflect().Descriptor().FullName()
 	structValueName = (&_struct.Struct{}).ProtoReflect().Descriptor().FullName()
 	timestampName   = (&timestamppb.Timestamp{}).ProtoReflect().Descriptor().FullName()
 	valueName       = (&_struct.Value{}).ProtoReflect().Descriptor().FullName()
@@ -119,6 +120,8 @@ func messageKind(fd protoreflect.FieldDescriptor) (ptr, pkg, name string) {
 		return "", "", "uint32"
 	case int32ValueName:
 		return "", "", "int32"
+	case int64ValueName:
+		return "", "", "int64"
 	case structValueName:
 		return "", "", "map[string]interface{}"
 	case valueName:
@@ -25,6 +25,7 @@ import (
 // unique within a.AuthMethodId.
 func (r *Repository) CreateAccount(ctx context.Context, a *Account, _ ...Option) (*Account, error) {
 	const op = "ldap.(Repository).CreateAccount"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case a == nil:
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing account")
@@ -80,6 +81,7 @@ func (r *Repository) CreateAccount(ctx context.Context, a *Account, _ ...Option)
 // found, it will return nil, nil.  All options are ignored.
 func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, _ ...Option) (*Account, error) {
 	const op = "ldap.(Repository).LookupAccount"
+	ctx = db.NewOpContext(ctx, op)
 	if withPublicId == "" {
 		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
@@ -99,6 +101,7 @@ func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, _ .
 // ListAccounts in an auth method and supports WithLimit option.
 func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*Account, error) {
 	const op = "ldap.(Repository).ListAccounts"
+	ctx = db.NewOpContext(ctx, op)
 	if withAuthMethodId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
 	}
@@ -123,6 +126,7 @@ func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string,
 // number of records deleted.  All options are ignored.
 func (r *Repository) DeleteAccount(ctx context.Context, withPublicId string, _ ...Option) (int, error) {
 	const op = "ldap.(Repository).DeleteAccount"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case withPublicId == "":
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
@@ -180,6 +184,7 @@ func (r *Repository) DeleteAccount(ctx context.Context, withPublicId string, _ .
 // in a is the zero value and it is included in fieldMaskPaths.
 func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Account, version uint32, fieldMaskPaths []string, opt ...Option) (*Account, int, error) {
 	const op = "ldap.(Repository).UpdateAccount"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case a == nil:
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing Account")
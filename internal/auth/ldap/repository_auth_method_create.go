@@ -23,6 +23,7 @@ import (
 // All options are ignored.
 func (r *Repository) CreateAuthMethod(ctx context.Context, am *AuthMethod, _ ...Option) (*AuthMethod, error) {
 	const op = "ldap.(Repository).CreateAuthMethod"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case am == nil:
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
@@ -78,6 +78,7 @@ func (r *Repository) lookupAuthMethod(ctx context.Context, authMethodId string,
 // When no record is found it returns nil, nil
 func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, scopeIds []string, opt ...Option) ([]*AuthMethod, error) {
 	const op = "ldap.(Repository).getAuthMethods"
+	ctx = db.NewOpContext(ctx, op)
 	if authMethodId == "" && len(scopeIds) == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing search criteria: both auth method id and scope ids are empty")
 	}
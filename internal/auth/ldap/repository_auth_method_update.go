@@ -80,6 +80,7 @@ func isEmpty(args ...any) bool {
 // No Options are currently supported.
 func (r *Repository) UpdateAuthMethod(ctx context.Context, am *AuthMethod, version uint32, fieldMaskPaths []string, _ ...Option) (*AuthMethod, int, error) {
 	const op = "ldap.(AuthMethod).Update"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case am == nil:
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
@@ -36,6 +36,7 @@ const (
 // scope id parameter.
 func (r *Repository) Authenticate(ctx context.Context, authMethodId, loginName, password string) (*Account, error) {
 	const op = "ldap.(Repository).Authenticate"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case authMethodId == "":
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id", errors.WithoutEvent())
@@ -24,6 +24,7 @@ import (
 // unique within mg.AuthMethodId.
 func (r *Repository) CreateManagedGroup(ctx context.Context, scopeId string, mg *ManagedGroup, _ ...Option) (*ManagedGroup, error) {
 	const op = "ldap.(Repository).CreateManagedGroup"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case mg == nil:
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing managed group")
@@ -83,6 +84,7 @@ func (r *Repository) CreateManagedGroup(ctx context.Context, scopeId string, mg
 // found, it will return nil, nil. All options are ignored.
 func (r *Repository) LookupManagedGroup(ctx context.Context, withPublicId string, _ ...Option) (*ManagedGroup, error) {
 	const op = "ldap.(Repository).LookupManagedGroup"
+	ctx = db.NewOpContext(ctx, op)
 	if withPublicId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
 	}
@@ -100,6 +102,7 @@ func (r *Repository) LookupManagedGroup(ctx context.Context, withPublicId string
 // ListManagedGroups in an auth method and supports WithLimit option.
 func (r *Repository) ListManagedGroups(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*ManagedGroup, error) {
 	const op = "ldap.(Repository).ListManagedGroups"
+	ctx = db.NewOpContext(ctx, op)
 	if withAuthMethodId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
 	}
@@ -125,6 +128,7 @@ func (r *Repository) ListManagedGroups(ctx context.Context, withAuthMethodId str
 // are ignored.
 func (r *Repository) DeleteManagedGroup(ctx context.Context, scopeId, withPublicId string, opt ...Option) (int, error) {
 	const op = "ldap.(Repository).DeleteManagedGroup"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case withPublicId == "":
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
@@ -184,6 +188,7 @@ func (r *Repository) DeleteManagedGroup(ctx context.Context, scopeId, withPublic
 // is the zero value and it is included in fieldMaskPaths.
 func (r *Repository) UpdateManagedGroup(ctx context.Context, scopeId string, mg *ManagedGroup, version uint32, fieldMaskPaths []string, opt ...Option) (*ManagedGroup, int, error) {
 	const op = "ldap.(Repository).UpdateManagedGroup"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case mg == nil:
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing ManagedGroup")
@@ -38,6 +38,7 @@ func (mg *ManagedGroupMemberAccount) SetTableName(n string) {
 // member (account) ID and supports WithLimit option.
 func (r *Repository) ListManagedGroupMembershipsByMember(ctx context.Context, withAcctId string, opt ...Option) ([]*ManagedGroupMemberAccount, error) {
 	const op = "ldap.(Repository).ListManagedGroupMembershipsByMember"
+	ctx = db.NewOpContext(ctx, op)
 	if withAcctId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing account id")
 	}
@@ -62,6 +63,7 @@ func (r *Repository) ListManagedGroupMembershipsByMember(ctx context.Context, wi
 // group ID and supports WithLimit option.
 func (r *Repository) ListManagedGroupMembershipsByGroup(ctx context.Context, withGroupId string, opt ...Option) ([]*ManagedGroupMemberAccount, error) {
 	const op = "ldap.(Repository).ListManagedGroupMembershipsByGroup"
+	ctx = db.NewOpContext(ctx, op)
 	if withGroupId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing managed group id")
 	}
@@ -5,6 +5,7 @@ package oidc
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
 
 	"github.com/hashicorp/boundary/internal/auth/oidc/store"
@@ -121,6 +122,28 @@ func (a *Account) GetLoginName() string {
 	return ""
 }
 
+// StringClaims returns the claims of the account's ID token and userinfo that
+// have string values, keyed by claim name. A claim in both takes its value
+// from the ID token. Claims that can't be decoded are left out.
+func (a *Account) StringClaims() map[string]string {
+	claims := make(map[string]string)
+	for _, encoded := range []string{a.GetUserinfoClaims(), a.GetTokenClaims()} {
+		if encoded == "" {
+			continue
+		}
+		var m map[string]any
+		if err := json.Unmarshal([]byte(encoded), &m); err != nil {
+			continue
+		}
+		for k, v := range m {
+			if s, ok := v.(string); ok {
+				claims[k] = s
+			}
+		}
+	}
+	return claims
+}
+
 // oplog will create oplog metadata for the Account.
 func (c *Account) oplog(op oplog.OpType, authMethodScopeId string) oplog.Metadata {
 	metadata := oplog.Metadata{
@@ -375,3 +375,18 @@ func TestAccount_SetTableName(t *testing.T) {
 		})
 	}
 }
+
+func TestAccount_StringClaims(t *testing.T) {
+	t.Parallel()
+	a := AllocAccount()
+	a.UserinfoClaims = `{"team":"web","department":"eng","groups":["a","b"]}`
+	a.TokenClaims = `{"sub":"alice","team":"db","email_verified":true}`
+	assert.Equal(t, map[string]string{
+		"sub":        "alice",
+		"team":       "db",
+		"department": "eng",
+	}, a.StringClaims())
+
+	a.TokenClaims = "not json"
+	assert.Equal(t, map[string]string{"team": "web", "department": "eng"}, a.StringClaims())
+}
@@ -32,6 +32,7 @@ import (
 // WithPublicId is currently the only valid option.
 func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Account, opt ...Option) (*Account, error) {
 	const op = "oidc.(Repository).CreateAccount"
+	ctx = db.NewOpContext(ctx, op)
 	if a == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing Account")
 	}
@@ -119,6 +120,7 @@ func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Accou
 // found, it will return nil, nil.  All options are ignored.
 func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt ...Option) (*Account, error) {
 	const op = "oidc.(Repository).LookupAccount"
+	ctx = db.NewOpContext(ctx, op)
 	if withPublicId == "" {
 		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
@@ -136,6 +138,7 @@ func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt
 // ListAccounts in an auth method and supports WithLimit option.
 func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*Account, error) {
 	const op = "oidc.(Repository).ListAccounts"
+	ctx = db.NewOpContext(ctx, op)
 	if withAuthMethodId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
 	}
@@ -157,6 +160,7 @@ func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string,
 // number of records deleted.  All options are ignored.
 func (r *Repository) DeleteAccount(ctx context.Context, scopeId, withPublicId string, opt ...Option) (int, error) {
 	const op = "oidc.(Repository).DeleteAccount"
+	ctx = db.NewOpContext(ctx, op)
 	if withPublicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
@@ -210,6 +214,7 @@ func (r *Repository) DeleteAccount(ctx context.Context, scopeId, withPublicId st
 // in a is the zero value and it is included in fieldMaskPaths.
 func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Account, version uint32, fieldMaskPaths []string, opt ...Option) (*Account, int, error) {
 	const op = "oidc.(Repository).UpdateAccount"
+	ctx = db.NewOpContext(ctx, op)
 	if a == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing Account")
 	}
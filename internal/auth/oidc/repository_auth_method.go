@@ -28,6 +28,7 @@ var _ proto.Message = (*Account)(nil)
 // upsertAccount will create/update account using claims from the user's ID and Access Tokens.
 func (r *Repository) upsertAccount(ctx context.Context, am *AuthMethod, IdTokenClaims, AccessTokenClaims map[string]any) (*Account, error) {
 	const op = "oidc.(Repository).upsertAccount"
+	ctx = db.NewOpContext(ctx, op)
 	if am == nil || am.AuthMethod == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
 	}
@@ -24,6 +24,7 @@ import (
 // All options are ignored.
 func (r *Repository) CreateAuthMethod(ctx context.Context, am *AuthMethod, opt ...Option) (*AuthMethod, error) {
 	const op = "oidc.(Repository).CreateAuthMethod"
+	ctx = db.NewOpContext(ctx, op)
 	if am == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
 	}
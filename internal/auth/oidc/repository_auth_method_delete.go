@@ -18,6 +18,7 @@ import (
 // and nil.  No options are currently supported.
 func (r *Repository) DeleteAuthMethod(ctx context.Context, publicId string, _ ...Option) (int, error) {
 	const op = "oidc.(Repository).DeleteAuthMethod"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
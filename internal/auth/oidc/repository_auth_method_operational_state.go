@@ -57,6 +57,7 @@ func (r *Repository) MakePublic(ctx context.Context, authMethodId string, versio
 
 func (r *Repository) transitionAuthMethodTo(ctx context.Context, authMethodId string, desiredState AuthMethodState, version uint32, opt ...Option) (*AuthMethod, error) {
 	const op = "oidc.(Repository).transitionAuthMethodTo"
+	ctx = db.NewOpContext(ctx, op)
 	if authMethodId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
 	}
@@ -75,6 +75,7 @@ func (r *Repository) lookupAuthMethod(ctx context.Context, authMethodId string,
 // When no record is found it returns nil, nil
 func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, scopeIds []string, opt ...Option) ([]*AuthMethod, error) {
 	const op = "oidc.(Repository).getAuthMethods"
+	ctx = db.NewOpContext(ctx, op)
 	if authMethodId == "" && len(scopeIds) == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing search criteria: both auth method id and Scope IDs are empty")
 	}
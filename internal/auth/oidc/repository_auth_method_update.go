@@ -81,6 +81,7 @@ const (
 // cache of the oidc.Provider for the AuthMethod.
 func (r *Repository) UpdateAuthMethod(ctx context.Context, am *AuthMethod, version uint32, fieldMaskPaths []string, opt ...Option) (*AuthMethod, int, error) {
 	const op = "oidc.(Repository).UpdateAuthMethod"
+	ctx = db.NewOpContext(ctx, op)
 	if am == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
 	}
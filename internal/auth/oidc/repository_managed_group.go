@@ -24,6 +24,7 @@ import (
 // unique within mg.AuthMethodId.
 func (r *Repository) CreateManagedGroup(ctx context.Context, scopeId string, mg *ManagedGroup, opt ...Option) (*ManagedGroup, error) {
 	const op = "oidc.(Repository).CreateManagedGroup"
+	ctx = db.NewOpContext(ctx, op)
 	if mg == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing ManagedGroup")
 	}
@@ -82,6 +83,7 @@ func (r *Repository) CreateManagedGroup(ctx context.Context, scopeId string, mg
 // found, it will return nil, nil. All options are ignored.
 func (r *Repository) LookupManagedGroup(ctx context.Context, withPublicId string, opt ...Option) (*ManagedGroup, error) {
 	const op = "oidc.(Repository).LookupManagedGroup"
+	ctx = db.NewOpContext(ctx, op)
 	if withPublicId == "" {
 		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
@@ -99,6 +101,7 @@ func (r *Repository) LookupManagedGroup(ctx context.Context, withPublicId string
 // ListManagedGroups in an auth method and supports WithLimit option.
 func (r *Repository) ListManagedGroups(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*ManagedGroup, error) {
 	const op = "oidc.(Repository).ListManagedGroups"
+	ctx = db.NewOpContext(ctx, op)
 	if withAuthMethodId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
 	}
@@ -121,6 +124,7 @@ func (r *Repository) ListManagedGroups(ctx context.Context, withAuthMethodId str
 // are ignored.
 func (r *Repository) DeleteManagedGroup(ctx context.Context, scopeId, withPublicId string, opt ...Option) (int, error) {
 	const op = "oidc.(Repository).DeleteManagedGroup"
+	ctx = db.NewOpContext(ctx, op)
 	if withPublicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
@@ -174,6 +178,7 @@ func (r *Repository) DeleteManagedGroup(ctx context.Context, scopeId, withPublic
 // is the zero value and it is included in fieldMaskPaths.
 func (r *Repository) UpdateManagedGroup(ctx context.Context, scopeId string, mg *ManagedGroup, version uint32, fieldMaskPaths []string, opt ...Option) (*ManagedGroup, int, error) {
 	const op = "oidc.(Repository).UpdateManagedGroup"
+	ctx = db.NewOpContext(ctx, op)
 	if mg == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing ManagedGroup")
 	}
@@ -23,6 +23,7 @@ import (
 // the account to the group.
 func (r *Repository) SetManagedGroupMemberships(ctx context.Context, am *AuthMethod, acct *Account, mgs []*ManagedGroup, _ ...Option) ([]*ManagedGroupMemberAccount, int, error) {
 	const op = "oidc.(Repository).SetManagedGroupMemberships"
+	ctx = db.NewOpContext(ctx, op)
 	if am == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
 	}
@@ -197,6 +198,7 @@ func (r *Repository) SetManagedGroupMemberships(ctx context.Context, am *AuthMet
 // member (account) ID and supports WithLimit option.
 func (r *Repository) ListManagedGroupMembershipsByMember(ctx context.Context, withAcctId string, opt ...Option) ([]*ManagedGroupMemberAccount, error) {
 	const op = "oidc.(Repository).ListManagedGroupMembershipsByMember"
+	ctx = db.NewOpContext(ctx, op)
 	if withAcctId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing account id")
 	}
@@ -222,6 +224,7 @@ func (r *Repository) ListManagedGroupMembershipsByMember(ctx context.Context, wi
 // group ID and supports WithLimit option.
 func (r *Repository) ListManagedGroupMembershipsByGroup(ctx context.Context, withGroupId string, opt ...Option) ([]*ManagedGroupMemberAccount, error) {
 	const op = "oidc.(Repository).ListManagedGroupMembershipsByGroup"
+	ctx = db.NewOpContext(ctx, op)
 	if withGroupId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing managed group id")
 	}
@@ -32,6 +32,7 @@ import (
 // unique within a.AuthMethodId.
 func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Account, opt ...Option) (*Account, error) {
 	const op = "password.(Repository).CreateAccount"
+	ctx = db.NewOpContext(ctx, op)
 	if a == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing Account")
 	}
@@ -131,6 +132,7 @@ func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Accou
 // found, it will return nil, nil.  All options are ignored.
 func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt ...Option) (*Account, error) {
 	const op = "password.(Repository).LookupAccount"
+	ctx = db.NewOpContext(ctx, op)
 	if withPublicId == "" {
 		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
@@ -148,6 +150,7 @@ func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt
 // ListAccounts in an auth method and supports WithLimit option.
 func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*Account, error) {
 	const op = "password.(Repository).ListAccounts"
+	ctx = db.NewOpContext(ctx, op)
 	if withAuthMethodId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
 	}
@@ -169,6 +172,7 @@ func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string,
 // number of records deleted.  All options are ignored.
 func (r *Repository) DeleteAccount(ctx context.Context, scopeId, withPublicId string, opt ...Option) (int, error) {
 	const op = "password.(Repository).DeleteAccount"
+	ctx = db.NewOpContext(ctx, op)
 	if withPublicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
@@ -233,6 +237,7 @@ func validLoginName(u string) bool {
 // cannot be set to NULL.
 func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Account, version uint32, fieldMaskPaths []string, opt ...Option) (*Account, int, error) {
 	const op = "password.(Repository).UpdateAccount"
+	ctx = db.NewOpContext(ctx, op)
 	if a == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing Account")
 	}
@@ -28,6 +28,7 @@ import (
 // unique within m.ScopeId.
 func (r *Repository) CreateAuthMethod(ctx context.Context, m *AuthMethod, opt ...Option) (*AuthMethod, error) {
 	const op = "password.(Repository).CreateAuthMethod"
+	ctx = db.NewOpContext(ctx, op)
 	if m == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing AuthMethod")
 	}
@@ -130,6 +131,7 @@ func (r *Repository) ListAuthMethods(ctx context.Context, scopeIds []string, opt
 // number of records deleted.  All options are ignored.
 func (r *Repository) DeleteAuthMethod(ctx context.Context, scopeId, publicId string, opt ...Option) (int, error) {
 	const op = "password.(Repository).DeleteAuthMethod"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
@@ -182,6 +184,7 @@ func (r *Repository) DeleteAuthMethod(ctx context.Context, scopeId, publicId str
 // are included in the fieldMaskPaths, then an error is returned.
 func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMethod, version uint32, fieldMaskPaths []string, opt ...Option) (*AuthMethod, int, error) {
 	const op = "password.(Repository).UpdateAuthMethod"
+	ctx = db.NewOpContext(ctx, op)
 	if authMethod == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing authMethod")
 	}
@@ -303,6 +306,7 @@ func (r *Repository) lookupAuthMethod(ctx context.Context, authMethodId string,
 // When no record is found it returns nil, nil
 func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, scopeIds []string, opt ...Option) ([]*AuthMethod, error) {
 	const op = "password.(Repository).getAuthMethods"
+	ctx = db.NewOpContext(ctx, op)
 	if authMethodId == "" && len(scopeIds) == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing search criteria: both auth method id and Scope IDs are empty")
 	}
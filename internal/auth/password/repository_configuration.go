@@ -71,6 +71,7 @@ func (r *Repository) SetConfiguration(ctx context.Context, scopeId string, c Con
 
 func (r *Repository) setArgon2Conf(ctx context.Context, scopeId string, c *Argon2Configuration) (*Argon2Configuration, error) {
 	const op = "password.(Repository).setArgon2Conf"
+	ctx = db.NewOpContext(ctx, op)
 	c = c.clone()
 
 	id, err := newArgon2ConfigurationId()
@@ -136,6 +137,7 @@ func (c *currentConfig) TableName() string {
 
 func (r *Repository) currentConfig(ctx context.Context, authMethodId string) (*currentConfig, error) {
 	const op = "password.(Repository).currentConfig"
+	ctx = db.NewOpContext(ctx, op)
 	var cc currentConfig
 	if err := r.reader.LookupWhere(ctx, &cc, "password_method_id = ?", []any{authMethodId}); err != nil {
 		return nil, errors.Wrap(ctx, err, op)
@@ -145,6 +147,7 @@ func (r *Repository) currentConfig(ctx context.Context, authMethodId string) (*c
 
 func (r *Repository) currentConfigForAccount(ctx context.Context, accountId string) (*currentConfig, error) {
 	const op = "password.(Repository).currentConfigForAccount"
+	ctx = db.NewOpContext(ctx, op)
 	var confs []currentConfig
 
 	rows, err := r.reader.Query(ctx, currentConfigForAccountQuery, []any{sql.Named("public_id", accountId)})
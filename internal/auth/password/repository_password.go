@@ -37,6 +37,7 @@ type authAccount struct {
 // the stored values are not using the current password settings.
 func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, loginName, password string) (*Account, error) {
 	const op = "password.(Repository).Authenticate"
+	ctx = db.NewOpContext(ctx, op)
 	if authMethodId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing authMethodId", errors.WithoutEvent())
 	}
@@ -115,6 +116,7 @@ func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, lo
 // Returns nil, error with code PasswordsEqual if old and new are equal.
 func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old, new string, version uint32) (*Account, error) {
 	const op = "password.(Repository).ChangePassword"
+	ctx = db.NewOpContext(ctx, op)
 	if accountId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing account id")
 	}
@@ -215,6 +217,7 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 
 func (r *Repository) authenticate(ctx context.Context, scopeId, authMethodId, loginName, password string) (*authAccount, error) {
 	const op = "password.(Repository).authenticate"
+	ctx = db.NewOpContext(ctx, op)
 	var accts []authAccount
 
 	rows, err := r.reader.Query(ctx, authenticateQuery, []any{sql.Named("auth_method_id", authMethodId), sql.Named("login_name", loginName)})
@@ -263,6 +266,7 @@ func (r *Repository) authenticate(ctx context.Context, scopeId, authMethodId, lo
 // contains an empty string, the password for accountId will be deleted.
 func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, password string, version uint32) (*Account, error) {
 	const op = "password.(Repository).SetPassword"
+	ctx = db.NewOpContext(ctx, op)
 	if accountId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing accountId")
 	}
@@ -65,6 +65,7 @@ func NewRepository(r db.Reader, w db.Writer, kms *kms.Kms, opt ...Option) (*Repo
 // are ignored.
 func (r *Repository) CreateAuthToken(ctx context.Context, withIamUser *iam.User, withAuthAccountId string, opt ...Option) (*AuthToken, error) {
 	const op = "authtoken.(Repository).CreateAuthToken"
+	ctx = db.NewOpContext(ctx, op)
 	if withIamUser == nil || withIamUser.User == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user")
 	}
@@ -153,6 +154,7 @@ func (r *Repository) CreateAuthToken(ctx context.Context, withIamUser *iam.User,
 // All exported options are ignored.
 func (r *Repository) LookupAuthToken(ctx context.Context, id string, opt ...Option) (*AuthToken, error) {
 	const op = "authtoken.(Repository).LookupAuthToken"
+	ctx = db.NewOpContext(ctx, op)
 	if id == "" {
 		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
@@ -194,6 +196,7 @@ func (r *Repository) LookupAuthToken(ctx context.Context, id string, opt ...Opti
 // NOTE: Do not log or add the token string to any errors to avoid leaking it as it is a secret.
 func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ...Option) (*AuthToken, error) {
 	const op = "authtoken.(Repository).ValidateToken"
+	ctx = db.NewOpContext(ctx, op)
 	if token == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing token")
 	}
@@ -294,6 +297,7 @@ func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ..
 // WithLimit option.
 func (r *Repository) ListAuthTokens(ctx context.Context, withScopeIds []string, opt ...Option) ([]*AuthToken, error) {
 	const op = "authtoken.(Repository).ListAuthTokens"
+	ctx = db.NewOpContext(ctx, op)
 	if len(withScopeIds) == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
 	}
@@ -302,7 +306,7 @@ func (r *Repository) ListAuthTokens(ctx context.Context, withScopeIds []string,
 	// use the view, to bring in the required account columns. Just don't forget
 	// to convert them before returning them
 	var atvs []*authTokenView
-	if err := r.reader.SearchWhere(ctx, &atvs, "auth_account_id in (select public_id from auth_account where scope_id in (?))", []any{withScopeIds}, db.WithLimit(opts.withLimit)); err != nil {
+	if err := r.reader.SearchWhere(ctx, &atvs, "auth_account_id in (select public_id from auth_account where scope_id in (?))", []any{withScopeIds}, db.WithLimit(opts.withLimit), db.WithReplicaRead(true)); err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	authTokens := make([]*AuthToken, 0, len(atvs))
@@ -319,6 +323,7 @@ func (r *Repository) ListAuthTokens(ctx context.Context, withScopeIds []string,
 // number of records deleted.  All options are ignored.
 func (r *Repository) DeleteAuthToken(ctx context.Context, id string, opt ...Option) (int, error) {
 	const op = "authtoken.(Repository).DeleteAuthToken"
+	ctx = db.NewOpContext(ctx, op)
 	if id == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
@@ -368,6 +373,7 @@ func (r *Repository) DeleteAuthToken(ctx context.Context, id string, opt ...Opti
 // Note: no oplog entries are created for auth token operations (this is intentional).
 func (r *Repository) IssueAuthToken(ctx context.Context, tokenRequestId string) (*AuthToken, error) {
 	const op = "authtoken.(Repository).IssueAuthToken"
+	ctx = db.NewOpContext(ctx, op)
 	if tokenRequestId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing token request id")
 	}
@@ -424,6 +430,7 @@ func (r *Repository) IssueAuthToken(ctx context.Context, tokenRequestId string)
 // "ticker" pattern.
 func (r *Repository) CloseExpiredPendingTokens(ctx context.Context) (int, error) {
 	const op = "authtoken.(Repository).CloseExpiredPendingTokens"
+	ctx = db.NewOpContext(ctx, op)
 
 	args := []any{string(FailedStatus), string(PendingStatus)}
 	const sql = `update auth_token set status = ? where status = ? and now() > expiration_time`
@@ -9,11 +9,13 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/plugins"
+	"github.com/hashicorp/boundary/api/roles"
 	"github.com/hashicorp/boundary/api/scopes"
 	"github.com/hashicorp/boundary/version"
 	"github.com/mitchellh/cli"
@@ -50,6 +52,51 @@ func PluginInfoForOutput(plg *plugins.PluginInfo, maxLength int) string {
 	return WrapMap(4, maxLength, vals)
 }
 
+// HistoryForOutput formats the history of a role, group or user for table
+// output.
+func HistoryForOutput(entries []*roles.HistoryEntry) string {
+	if len(entries) == 0 {
+		return "No changes found"
+	}
+	output := []string{"", "History:"}
+	for i, e := range entries {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  Created Time:          %s", e.CreatedTime.Local().Format(time.RFC1123)),
+		)
+		if e.ActorId != "" {
+			output = append(output,
+				fmt.Sprintf("  Actor ID:              %s", e.ActorId),
+			)
+		}
+		for _, c := range e.Changes {
+			output = append(output,
+				fmt.Sprintf("    %s %s:", c.Operation, c.Type),
+			)
+			if len(c.UpdatedFields) > 0 {
+				output = append(output,
+					fmt.Sprintf("      Updated Fields:      %s", strings.Join(c.UpdatedFields, ", ")),
+				)
+			}
+			if len(c.PreviousValues) > 0 {
+				output = append(output,
+					"      Previous Values:",
+					WrapMap(8, 0, c.PreviousValues),
+				)
+			}
+			if len(c.Values) > 0 {
+				output = append(output,
+					"      Values:",
+					WrapMap(8, 0, c.Values),
+				)
+			}
+		}
+	}
+	return WrapForHelpText(output)
+}
+
 func MaxAttributesLength(nonAttributesMap, attributesMap map[string]any, keySubstMap map[string]string) int {
 	// We always print a scope ID and in some cases this particular key ends up
 	// being the longest key, so start with it as a baseline. It's always
@@ -134,6 +134,9 @@ type Server struct {
 	DatabaseMaxOpenConnections      int
 	DatabaseMaxIdleConnections      *int
 	DatabaseConnMaxIdleTimeDuration *time.Duration
+	DatabaseReplicaUrls             []string
+	DatabaseMaxReplicaLag           time.Duration
+	DatabaseSlowQueryThreshold      time.Duration
 
 	DevDatabaseCleanupFunc func() error
 
@@ -728,10 +731,48 @@ func (b *Server) OpenAndSetServerDatabase(ctx context.Context, dialect string) e
 	if err != nil {
 		return err
 	}
+	if len(b.DatabaseReplicaUrls) > 0 {
+		replicas, err := b.OpenDatabaseReplicas(ctx, dialect, b.DatabaseReplicaUrls, b.DatabaseMaxReplicaLag)
+		if err != nil {
+			_ = dbase.Close(ctx)
+			return err
+		}
+		if _, err := dbase.SwapReplicas(ctx, replicas); err != nil {
+			_ = replicas.Close(ctx)
+			_ = dbase.Close(ctx)
+			return fmt.Errorf("unable to set database replicas: %w", err)
+		}
+	}
 	b.Database = dbase
 	return nil
 }
 
+// OpenDatabaseReplicas creates connections to the read replicas with the given
+// URLs, using the same options as OpenDatabase, and returns them to the
+// caller. If maxLag is zero, db.DefaultMaxReplicaLag is used.
+func (b *Server) OpenDatabaseReplicas(ctx context.Context, dialect string, urls []string, maxLag time.Duration) (*db.Replicas, error) {
+	dbs := make([]*db.DB, 0, len(urls))
+	closeAll := func() {
+		for _, d := range dbs {
+			_ = d.Close(ctx)
+		}
+	}
+	for i, url := range urls {
+		d, err := b.OpenDatabase(ctx, dialect, url)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("unable to open database replica %d: %w", i, err)
+		}
+		dbs = append(dbs, d)
+	}
+	replicas, err := db.NewReplicas(ctx, dbs, db.WithMaxLag(maxLag))
+	if err != nil {
+		closeAll()
+		return nil, fmt.Errorf("unable to create database replicas: %w", err)
+	}
+	return replicas, nil
+}
+
 // OpenDatabase creates a database connection with the given URL and returns it to the caller.
 // It supports various configuration options - The values must be set on the Server object
 // beforehand.
@@ -745,6 +786,7 @@ func (b *Server) OpenDatabase(ctx context.Context, dialect, url string) (*db.DB,
 		db.WithMaxOpenConnections(b.DatabaseMaxOpenConnections),
 		db.WithMaxIdleConnections(b.DatabaseMaxIdleConnections),
 		db.WithConnMaxIdleTimeDuration(b.DatabaseConnMaxIdleTimeDuration),
+		db.WithSlowQueryThreshold(b.DatabaseSlowQueryThreshold),
 	}
 	if os.Getenv("BOUNDARY_DISABLE_GORM_FORMATTER") == "" {
 		opts = append(opts, db.WithGormFormatter(b.Logger))
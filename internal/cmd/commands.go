@@ -20,11 +20,15 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostcatalogscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostsetscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/importexport"
+	"github.com/hashicorp/boundary/internal/cmd/commands/jobscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/logout"
 	"github.com/hashicorp/boundary/internal/cmd/commands/managedgroupscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/oplogcmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/rolescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/scopescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/server"
+	"github.com/hashicorp/boundary/internal/cmd/commands/serviceaccountscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/sessionscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/targetscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/userscmd"
@@ -326,12 +330,22 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "kube",
 			}, nil
 		},
+		"connect multi": func() (cli.Command, error) {
+			return &connect.MultiCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 		"connect postgres": func() (cli.Command, error) {
 			return &connect.Command{
 				Command: base.NewCommand(ui),
 				Func:    "postgres",
 			}, nil
 		},
+		"connect proxy": func() (cli.Command, error) {
+			return &connect.ProxyCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 		"connect rdp": func() (cli.Command, error) {
 			return &connect.Command{
 				Command: base.NewCommand(ui),
@@ -360,6 +374,21 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Command: base.NewCommand(ui),
 			}, nil
 		},
+		"database backup": func() (cli.Command, error) {
+			return &database.BackupCommand{
+				Server: base.NewServer(base.NewCommand(ui)),
+			}, nil
+		},
+		"database restore": func() (cli.Command, error) {
+			return &database.RestoreCommand{
+				Server: base.NewServer(base.NewCommand(ui)),
+			}, nil
+		},
+		"database rekey-root": func() (cli.Command, error) {
+			return &database.RekeyRootCommand{
+				Server: base.NewServer(base.NewCommand(ui)),
+			}, nil
+		},
 
 		"credential-libraries": func() (cli.Command, error) {
 			return &credentiallibrariescmd.Command{
@@ -540,6 +569,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"credentials create tls-certificate": func() (cli.Command, error) {
+			return &credentialscmd.TlsCertificateCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"credentials update": func() (cli.Command, error) {
 			return &credentialscmd.Command{
 				Command: base.NewCommand(ui),
@@ -564,6 +599,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"credentials update tls-certificate": func() (cli.Command, error) {
+			return &credentialscmd.TlsCertificateCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+
+		"export": func() (cli.Command, error) {
+			return &importexport.ExportCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 
 		"groups": func() (cli.Command, error) {
 			return &groupscmd.Command{
@@ -618,6 +665,9 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "remove-members",
 			}, nil
 		},
+		"groups list-history": func() (cli.Command, error) {
+			return groupscmd.NewListHistoryCommand(base.NewCommand(ui)), nil
+		},
 
 		"host-catalogs": func() (cli.Command, error) {
 			return &hostcatalogscmd.Command{
@@ -805,6 +855,36 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"import": func() (cli.Command, error) {
+			return &importexport.ImportCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+
+		"jobs": func() (cli.Command, error) {
+			return &jobscmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"jobs read": func() (cli.Command, error) {
+			return &jobscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"jobs list": func() (cli.Command, error) {
+			return &jobscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"jobs run": func() (cli.Command, error) {
+			return &jobscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "run",
+			}, nil
+		},
+
 		"logout": func() (cli.Command, error) {
 			return &logout.LogoutCommand{
 				Command: base.NewCommand(ui),
@@ -871,6 +951,22 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"oplog": func() (cli.Command, error) {
+			return &oplogcmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"oplog export": func() (cli.Command, error) {
+			return &oplogcmd.ExportCommand{
+				Server: base.NewServer(base.NewCommand(ui)),
+			}, nil
+		},
+		"oplog verify": func() (cli.Command, error) {
+			return &oplogcmd.VerifyCommand{
+				Server: base.NewServer(base.NewCommand(ui)),
+			}, nil
+		},
+
 		"roles": func() (cli.Command, error) {
 			return &rolescmd.Command{
 				Command: base.NewCommand(ui),
@@ -942,6 +1038,32 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "remove-grants",
 			}, nil
 		},
+		"roles add-grant-scopes": func() (cli.Command, error) {
+			return &rolescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "add-grant-scopes",
+			}, nil
+		},
+		"roles set-grant-scopes": func() (cli.Command, error) {
+			return &rolescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "set-grant-scopes",
+			}, nil
+		},
+		"roles remove-grant-scopes": func() (cli.Command, error) {
+			return &rolescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "remove-grant-scopes",
+			}, nil
+		},
+		"roles explain": func() (cli.Command, error) {
+			return &rolescmd.ExplainCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"roles list-history": func() (cli.Command, error) {
+			return rolescmd.NewListHistoryCommand(base.NewCommand(ui)), nil
+		},
 
 		"scopes": func() (cli.Command, error) {
 			return &scopescmd.Command{
@@ -998,6 +1120,69 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Command: base.NewCommand(ui),
 			}, nil
 		},
+		"scopes set-quotas": func() (cli.Command, error) {
+			return &scopescmd.SetQuotasCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"scopes read-key-rotation-policy": func() (cli.Command, error) {
+			return &scopescmd.ReadKeyRotationPolicyCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"scopes set-key-rotation-policy": func() (cli.Command, error) {
+			return &scopescmd.SetKeyRotationPolicyCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+
+		"service-accounts": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"service-accounts create": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"service-accounts update": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"service-accounts read": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"service-accounts delete": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete",
+			}, nil
+		},
+		"service-accounts list": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"service-accounts add-api-key": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "add-api-key",
+			}, nil
+		},
+		"service-accounts remove-api-key": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "remove-api-key",
+			}, nil
+		},
 
 		"sessions": func() (cli.Command, error) {
 			return &sessionscmd.Command{
@@ -1178,6 +1363,14 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "remove-accounts",
 			}, nil
 		},
+		"users list-history": func() (cli.Command, error) {
+			return userscmd.NewListHistoryCommand(base.NewCommand(ui)), nil
+		},
+		"users list-effective-grants": func() (cli.Command, error) {
+			return &userscmd.ListEffectiveGrantsCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 
 		"workers": func() (cli.Command, error) {
 			return &workerscmd.Command{
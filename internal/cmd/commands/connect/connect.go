@@ -40,6 +40,10 @@ import (
 
 const sessionCancelTimeout = 10 * time.Second
 
+// connectionIdleTimeoutReason is the websocket close reason sent by the
+// worker when it closes a connection that has been idle for too long.
+const connectionIdleTimeoutReason = "connection idle timeout"
+
 type SessionInfo struct {
 	Address         string                       `json:"address"`
 	Port            int                          `json:"port"`
@@ -52,6 +56,13 @@ type SessionInfo struct {
 
 type ConnectionInfo struct {
 	ConnectionsLeft int32 `json:"connections_left"`
+	// IdleTimeoutSeconds is how long the connection may go without
+	// proxying data before the worker closes it. It is omitted when the target
+	// does not define an idle timeout.
+	IdleTimeoutSeconds uint32 `json:"idle_timeout_seconds,omitempty"`
+	// IdleTimeRemainingSeconds is how much longer the connection may go
+	// without proxying data before the worker closes it.
+	IdleTimeRemainingSeconds uint32 `json:"idle_time_remaining_seconds,omitempty"`
 }
 
 type TerminationInfo struct {
@@ -102,6 +113,7 @@ type Command struct {
 	listenerAddr       *net.TCPAddr
 	connsLeftCh        chan int32
 	connectionsLeft    *atomic.Int32
+	connIdleTimeout    *atomic.Uint32
 	expiration         time.Time
 	execCmdReturnValue *atomic.Int32
 	proxyCtx           context.Context
@@ -322,6 +334,7 @@ func (c *Command) Run(args []string) (retCode int) {
 	}
 
 	c.connectionsLeft = atomic.NewInt32(0)
+	c.connIdleTimeout = atomic.NewUint32(0)
 	c.connsLeftCh = make(chan int32)
 
 	if c.flagListenAddr == "" {
@@ -405,45 +418,19 @@ func (c *Command) Run(args []string) (retCode int) {
 		authzString = c.sessionAuthz.AuthorizationToken
 	}
 
-	marshaled, err := base58.FastBase58Decoding(authzString)
+	c.sessionAuthzData, err = decodeSessionAuthzData(authzString)
 	if err != nil {
-		c.PrintCliError(fmt.Errorf("Unable to base58-decode authorization data: %w", err))
-		return base.CommandUserError
-	}
-	if len(marshaled) == 0 {
-		c.PrintCliError(errors.New("Zero length authorization information after decoding"))
-		return base.CommandUserError
-	}
-
-	c.sessionAuthzData = new(targetspb.SessionAuthorizationData)
-	if err := proto.Unmarshal(marshaled, c.sessionAuthzData); err != nil {
-		c.PrintCliError(fmt.Errorf("Unable to proto-decode authorization data: %w", err))
-		return base.CommandUserError
-	}
-
-	if len(c.sessionAuthzData.GetWorkerInfo()) == 0 {
-		c.PrintCliError(errors.New("No workers found in authorization string"))
+		c.PrintCliError(err)
 		return base.CommandUserError
 	}
 
 	c.connectionsLeft.Store(c.sessionAuthzData.ConnectionLimit)
-	workerAddr := c.sessionAuthzData.GetWorkerInfo()[0].GetAddress()
-	workerHost, _, err := net.SplitHostPort(workerAddr)
-	if err != nil {
-		if strings.Contains(err.Error(), "missing port") {
-			workerHost = workerAddr
-		} else {
-			c.PrintCliError(fmt.Errorf("Error splitting worker adddress host/port: %w", err))
-			return base.CommandUserError
-		}
-	}
-
-	tlsConf, err := ClientTlsConfig(c.sessionAuthzData, workerHost)
+	workerAddr, transport, expiration, err := workerTransport(c.sessionAuthzData)
 	if err != nil {
-		c.PrintCliError(fmt.Errorf("Error creating TLS configuration: %w", err))
+		c.PrintCliError(err)
 		return base.CommandCliError
 	}
-	c.expiration = tlsConf.Certificates[0].Leaf.NotAfter
+	c.expiration = expiration
 
 	// We don't _rely_ on client-side timeout verification but this prevents us
 	// seeming to be ready for a connection that will immediately fail when we
@@ -451,16 +438,6 @@ func (c *Command) Run(args []string) (retCode int) {
 	c.proxyCtx, c.proxyCancel = context.WithDeadline(c.Context, c.expiration)
 	defer c.proxyCancel()
 
-	transport := cleanhttp.DefaultTransport()
-	transport.DisableKeepAlives = false
-	// This isn't/shouldn't used anyways really because the connection is
-	// hijacked, just setting for completeness
-	transport.IdleConnTimeout = 0
-	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
-		dialer := &tls.Dialer{Config: tlsConf}
-		return dialer.DialContext(ctx, network, addr)
-	}
-
 	c.listener, err = net.ListenTCP("tcp", &net.TCPAddr{
 		IP:   listenAddr,
 		Port: c.flagListenPort,
@@ -545,7 +522,7 @@ func (c *Command) Run(args []string) (retCode int) {
 			go func() {
 				defer listeningConn.Close()
 				defer c.connWg.Done()
-				wsConn, err := c.getWsConn(
+				wsConn, err := getWsConn(
 					c.proxyCtx,
 					workerAddr,
 					transport)
@@ -619,11 +596,11 @@ func (c *Command) Run(args []string) (retCode int) {
 
 	if sendSessionCancel {
 		ctx, cancel := context.WithTimeout(context.Background(), sessionCancelTimeout)
-		wsConn, err := c.getWsConn(ctx, workerAddr, transport)
+		wsConn, err := getWsConn(ctx, workerAddr, transport)
 		if err != nil {
 			c.PrintCliError(fmt.Errorf("error fetching connection to send session teardown request to worker: %w", err))
 		} else {
-			if err := c.sendSessionTeardown(ctx, wsConn, tofuToken); err != nil {
+			if err := sendSessionTeardown(ctx, wsConn, tofuToken); err != nil {
 				c.PrintCliError(fmt.Errorf("error sending session teardown request to worker: %w", err))
 			}
 		}
@@ -674,7 +651,9 @@ func (c *Command) printCredentials(creds []*targets.SessionCredential) error {
 	return nil
 }
 
-func (c *Command) getWsConn(
+// getWsConn dials the worker at workerAddr using the session's mTLS transport
+// and negotiates the tcp proxy v1 websocket subprotocol.
+func getWsConn(
 	ctx context.Context,
 	workerAddr string,
 	transport *http.Transport,
@@ -713,7 +692,9 @@ func (c *Command) getWsConn(
 	return conn, nil
 }
 
-func (c *Command) sendSessionTeardown(
+// sendSessionTeardown asks the worker to cancel the session identified by the
+// mTLS connection wsConn was created over.
+func sendSessionTeardown(
 	ctx context.Context,
 	wsConn *websocket.Conn,
 	tofuToken string,
@@ -731,64 +712,66 @@ func (c *Command) sendSessionTeardown(
 
 func (c *Command) runTcpProxyV1(
 	wsConn *websocket.Conn,
-	listeningConn *net.TCPConn,
+	listeningConn net.Conn,
 	tofuToken string,
 ) error {
-	handshake := proxy.ClientHandshake{TofuToken: tofuToken}
-	if err := wspb.Write(c.proxyCtx, wsConn, &handshake); err != nil {
-		return fmt.Errorf("error sending handshake to worker: %w", err)
-	}
-	var handshakeResult proxy.HandshakeResult
-	if err := wspb.Read(c.proxyCtx, wsConn, &handshakeResult); err != nil {
-		switch {
-		case strings.Contains(err.Error(), "unable to authorize connection"):
-			// There's no reason to think we'd be able to authorize any more
-			// connections after the first has failed
-			c.connsLeftCh <- 0
-			return errors.New("Unable to authorize connection")
-		}
-		switch {
-		case strings.Contains(err.Error(), "tofu token not allowed"):
-			// Nothing will be able to be done here, so cancel the context too
-			c.proxyCancel()
-			return errors.New("Session is already in use")
-		default:
-			return fmt.Errorf("error reading handshake result: %w", err)
-		}
+	handshakeResult, err := tcpProxyV1Handshake(c.proxyCtx, wsConn, tofuToken)
+	switch {
+	case errors.Is(err, errConnectionUnauthorized):
+		// There's no reason to think we'd be able to authorize any more
+		// connections after the first has failed
+		c.connsLeftCh <- 0
+		return err
+	case errors.Is(err, errSessionInUse):
+		// Nothing will be able to be done here, so cancel the context too
+		c.proxyCancel()
+		return err
+	case err != nil:
+		return err
 	}
 
-	if handshakeResult.GetConnectionsLeft() != -1 {
+	c.connIdleTimeout.Store(handshakeResult.GetConnectionIdleTimeoutSeconds())
+	idleTimeout := time.Duration(handshakeResult.GetConnectionIdleTimeoutSeconds()) * time.Second
+	switch {
+	case handshakeResult.GetConnectionsLeft() != -1:
 		c.connsLeftCh <- handshakeResult.GetConnectionsLeft()
+	case idleTimeout > 0:
+		// Connections are unlimited so nothing is waiting on the count, but
+		// the idle time still needs to be reported.
+		c.outputConnectionInfo(-1, idleTimeout)
 	}
 
-	// Get a wrapped net.Conn so we can use io.Copy
-	netConn := websocket.NetConn(c.proxyCtx, wsConn, websocket.MessageBinary)
-
-	localWg := new(sync.WaitGroup)
-	localWg.Add(2)
+	var idle *connIdleTracker
+	if idleTimeout > 0 {
+		idle = newConnIdleTracker(idleTimeout)
+		watchCtx, watchCancel := context.WithCancel(c.proxyCtx)
+		defer watchCancel()
+		go idle.watch(watchCtx, func(remaining time.Duration) {
+			c.outputConnectionInfo(c.connectionsLeft.Load(), remaining)
+		})
+	}
 
-	go func() {
-		defer localWg.Done()
-		io.Copy(netConn, listeningConn)
-		netConn.Close()
-		listeningConn.Close()
-	}()
-	go func() {
-		defer localWg.Done()
-		io.Copy(listeningConn, netConn)
-		listeningConn.Close()
-		netConn.Close()
-	}()
-	localWg.Wait()
+	if err := tcpProxyV1Copy(c.proxyCtx, wsConn, listeningConn, idle); isIdleTimeoutClose(err) {
+		c.PrintCliError(fmt.Errorf("Connection closed by worker after being idle for %s", idleTimeout))
+	}
 
 	return nil
 }
 
 func (c *Command) updateConnsLeft(connsLeft int32) {
 	c.connectionsLeft.Store(connsLeft)
+	c.outputConnectionInfo(connsLeft, time.Duration(c.connIdleTimeout.Load())*time.Second)
+}
 
+// outputConnectionInfo prints the information about a connection, when it is
+// established and when it has been idle long enough that the worker will
+// soon close it. A connsLeft of -1 means the session allows unlimited
+// connections.
+func (c *Command) outputConnectionInfo(connsLeft int32, idleRemaining time.Duration) {
 	connInfo := ConnectionInfo{
-		ConnectionsLeft: connsLeft,
+		ConnectionsLeft:          connsLeft,
+		IdleTimeoutSeconds:       c.connIdleTimeout.Load(),
+		IdleTimeRemainingSeconds: uint32(idleRemaining.Round(time.Second) / time.Second),
 	}
 
 	if c.flagExec == "" {
@@ -939,3 +922,144 @@ func (c *Command) handleExec(passthroughArgs []string) {
 	}
 	c.execCmdReturnValue.Store(0)
 }
+
+var (
+	errConnectionUnauthorized = errors.New("Unable to authorize connection")
+	errSessionInUse           = errors.New("Session is already in use")
+)
+
+// tcpProxyV1Handshake sends the client handshake over wsConn and returns the
+// worker's result. If the worker refuses to authorize the connection or the
+// tofu token has already been used for the session, errConnectionUnauthorized
+// or errSessionInUse is returned respectively.
+func tcpProxyV1Handshake(
+	ctx context.Context,
+	wsConn *websocket.Conn,
+	tofuToken string,
+) (*proxy.HandshakeResult, error) {
+	handshake := proxy.ClientHandshake{TofuToken: tofuToken}
+	if err := wspb.Write(ctx, wsConn, &handshake); err != nil {
+		return nil, fmt.Errorf("error sending handshake to worker: %w", err)
+	}
+	var handshakeResult proxy.HandshakeResult
+	if err := wspb.Read(ctx, wsConn, &handshakeResult); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "unable to authorize connection"):
+			return nil, errConnectionUnauthorized
+		case strings.Contains(err.Error(), "tofu token not allowed"):
+			return nil, errSessionInUse
+		default:
+			return nil, fmt.Errorf("error reading handshake result: %w", err)
+		}
+	}
+	return &handshakeResult, nil
+}
+
+// tcpProxyV1Copy copies data between listeningConn and the worker until
+// either side closes, at which point both are closed. While copying, the
+// worker is sent keepalives, and if idle is not nil it records the data
+// copied. The returned error is the one encountered reading from the worker,
+// which carries the websocket close reason if the worker closed the
+// connection.
+func tcpProxyV1Copy(
+	ctx context.Context,
+	wsConn *websocket.Conn,
+	listeningConn net.Conn,
+	idle *connIdleTracker,
+) error {
+	// Get a wrapped net.Conn so we can use io.Copy
+	netConn := websocket.NetConn(ctx, wsConn, websocket.MessageBinary)
+	if idle != nil {
+		listeningConn = &idleTrackingConn{Conn: listeningConn, tracker: idle}
+	}
+
+	keepaliveCtx, keepaliveCancel := context.WithCancel(ctx)
+	keepaliveTicker := time.NewTicker(tcpProxyV1KeepaliveInterval)
+	defer keepaliveTicker.Stop()
+
+	localWg := new(sync.WaitGroup)
+	localWg.Add(3)
+
+	go func() {
+		defer localWg.Done()
+		_ = keepAlive(keepaliveCtx, wsConn, keepaliveTicker.C)
+	}()
+	go func() {
+		defer localWg.Done()
+		io.Copy(netConn, listeningConn)
+		netConn.Close()
+		listeningConn.Close()
+	}()
+	var workerErr error
+	go func() {
+		defer localWg.Done()
+		defer keepaliveCancel()
+		_, workerErr = io.Copy(listeningConn, netConn)
+		listeningConn.Close()
+		netConn.Close()
+	}()
+	localWg.Wait()
+
+	return workerErr
+}
+
+// isIdleTimeoutClose reports whether err is the worker closing the
+// connection for having been idle for too long.
+func isIdleTimeoutClose(err error) bool {
+	var closeErr websocket.CloseError
+	return errors.As(err, &closeErr) && closeErr.Code == websocket.StatusPolicyViolation && closeErr.Reason == connectionIdleTimeoutReason
+}
+
+// decodeSessionAuthzData decodes the authorization token returned by an
+// authorize-session call into the session authorization data.
+func decodeSessionAuthzData(authzString string) (*targetspb.SessionAuthorizationData, error) {
+	marshaled, err := base58.FastBase58Decoding(authzString)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to base58-decode authorization data: %w", err)
+	}
+	if len(marshaled) == 0 {
+		return nil, errors.New("Zero length authorization information after decoding")
+	}
+
+	data := new(targetspb.SessionAuthorizationData)
+	if err := proto.Unmarshal(marshaled, data); err != nil {
+		return nil, fmt.Errorf("Unable to proto-decode authorization data: %w", err)
+	}
+
+	if len(data.GetWorkerInfo()) == 0 {
+		return nil, errors.New("No workers found in authorization string")
+	}
+	return data, nil
+}
+
+// workerTransport returns the address of the worker to proxy through along
+// with an HTTP transport that dials it using the session's mTLS credentials.
+// The returned expiration is when the session's certificate, and therefore the
+// session, expires.
+func workerTransport(data *targetspb.SessionAuthorizationData) (string, *http.Transport, time.Time, error) {
+	workerAddr := data.GetWorkerInfo()[0].GetAddress()
+	workerHost, _, err := net.SplitHostPort(workerAddr)
+	if err != nil {
+		if strings.Contains(err.Error(), "missing port") {
+			workerHost = workerAddr
+		} else {
+			return "", nil, time.Time{}, fmt.Errorf("Error splitting worker adddress host/port: %w", err)
+		}
+	}
+
+	tlsConf, err := ClientTlsConfig(data, workerHost)
+	if err != nil {
+		return "", nil, time.Time{}, fmt.Errorf("Error creating TLS configuration: %w", err)
+	}
+
+	transport := cleanhttp.DefaultTransport()
+	transport.DisableKeepAlives = false
+	// This isn't/shouldn't used anyways really because the connection is
+	// hijacked, just setting for completeness
+	transport.IdleConnTimeout = 0
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialer := &tls.Dialer{Config: tlsConf}
+		return dialer.DialContext(ctx, network, addr)
+	}
+	return workerAddr, transport, tlsConf.Certificates[0].Leaf.NotAfter, nil
+}
@@ -113,8 +113,15 @@ func fmtSecretForTable(indent int, sc *targets.SessionCredential) []string {
 func generateConnectionInfoTableOutput(in ConnectionInfo) string {
 	var ret []string
 
-	nonAttributeMap := map[string]any{
-		"Connections Left": in.ConnectionsLeft,
+	nonAttributeMap := map[string]any{}
+	if in.ConnectionsLeft != -1 {
+		nonAttributeMap["Connections Left"] = in.ConnectionsLeft
+	}
+	if in.IdleTimeoutSeconds > 0 {
+		nonAttributeMap["Idle Timeout"] = (time.Duration(in.IdleTimeoutSeconds) * time.Second).String()
+	}
+	if in.IdleTimeRemainingSeconds > 0 {
+		nonAttributeMap["Idle Time Remaining"] = (time.Duration(in.IdleTimeRemainingSeconds) * time.Second).String()
 	}
 
 	maxLength := 0
@@ -157,3 +164,83 @@ func generateTerminationInfoTableOutput(in TerminationInfo) string {
 
 	return base.WrapForHelpText(ret)
 }
+
+func generateTargetSessionInfoTableOutput(in TargetSessionInfo) string {
+	nonAttributeMap := map[string]any{
+		"Target":           in.Name,
+		"Session ID":       in.SessionId,
+		"Protocol":         in.Protocol,
+		"Address":          in.Address,
+		"Expiration":       in.Expiration.Local().Format(time.RFC1123),
+		"Connection Limit": in.ConnectionLimit,
+	}
+	if in.Network == "tcp" {
+		nonAttributeMap["Port"] = in.Port
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Proxy listening information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+	if len(in.Credentials) > 0 {
+		ret = append(ret,
+			"")
+		ret = append(ret,
+			generateCredentialTableOutputSlice(2, in.Credentials)...)
+	}
+
+	return base.WrapForHelpText(ret)
+}
+
+func generateMultiStatusTableOutput(in MultiStatus) string {
+	ret := []string{"", "Target status:"}
+	for _, t := range in.Targets {
+		nonAttributeMap := map[string]any{
+			"Name":               t.Name,
+			"Network":            t.Network,
+			"Address":            t.Address,
+			"Active Connections": t.ActiveConnections,
+			"Authorizations":     t.Authorizations,
+		}
+		if t.TargetId != "" {
+			nonAttributeMap["Target ID"] = t.TargetId
+		}
+		if t.SessionId != "" {
+			nonAttributeMap["Session ID"] = t.SessionId
+			nonAttributeMap["Expiration"] = t.Expiration.Local().Format(time.RFC1123)
+			if t.ConnectionsLeft != -1 {
+				nonAttributeMap["Connections Left"] = t.ConnectionsLeft
+			}
+		}
+		if t.LastError != "" {
+			nonAttributeMap["Last Error"] = t.LastError
+		}
+
+		maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+		ret = append(ret,
+			base.WrapMap(2, maxLength+2, nonAttributeMap),
+			"",
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
+
+func generateProxyInfoTableOutput(in ProxyInfo) string {
+	nonAttributeMap := map[string]any{
+		"Address":   in.Address,
+		"Port":      in.Port,
+		"Protocols": strings.Join(in.Protocols, ", "),
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	return base.WrapForHelpText([]string{
+		"",
+		"Proxy listening information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	})
+}
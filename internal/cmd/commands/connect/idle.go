@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package connect
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"go.uber.org/atomic"
+	"nhooyr.io/websocket"
+)
+
+const (
+	// tcpProxyV1KeepaliveInterval is how often a websocket ping is sent to the
+	// worker while a connection is proxied. Pings are control frames, not
+	// proxied data, so they keep the connection open through intermediaries
+	// without resetting the worker's idle timer.
+	tcpProxyV1KeepaliveInterval = 30 * time.Second
+
+	// maxIdleWarningWindow is the most remaining idle time at which the user
+	// is warned that the worker will soon close an idle connection.
+	maxIdleWarningWindow = time.Minute
+)
+
+// connIdleTracker records when data last went across a proxied connection so
+// the time left before the worker closes it for being idle can be reported.
+// The worker measures idle time the same way, from data proxied in either
+// direction.
+type connIdleTracker struct {
+	timeout time.Duration
+	// now and after are time.Now and a stoppable time.After, replaced in
+	// tests.
+	now   func() time.Time
+	after func(time.Duration) (<-chan time.Time, func() bool)
+
+	// lastActivity is the time of the last read or write in unix nanoseconds.
+	lastActivity *atomic.Int64
+}
+
+func newConnIdleTracker(timeout time.Duration) *connIdleTracker {
+	t := &connIdleTracker{
+		timeout: timeout,
+		now:     time.Now,
+		after: func(d time.Duration) (<-chan time.Time, func() bool) {
+			timer := time.NewTimer(d)
+			return timer.C, timer.Stop
+		},
+		lastActivity: atomic.NewInt64(0),
+	}
+	t.touch()
+	return t
+}
+
+// touch records activity on the connection.
+func (t *connIdleTracker) touch() {
+	t.lastActivity.Store(t.now().UnixNano())
+}
+
+// remaining returns how long the connection can go without activity before
+// the worker closes it.
+func (t *connIdleTracker) remaining() time.Duration {
+	rem := t.timeout - t.now().Sub(time.Unix(0, t.lastActivity.Load()))
+	if rem < 0 {
+		return 0
+	}
+	return rem
+}
+
+// warningWindow returns how much remaining idle time warn is called at by
+// watch: a quarter of the timeout, up to maxIdleWarningWindow.
+func (t *connIdleTracker) warningWindow() time.Duration {
+	if w := t.timeout / 4; w < maxIdleWarningWindow {
+		return w
+	}
+	return maxIdleWarningWindow
+}
+
+// watch calls warn with the remaining idle time once the connection has been
+// idle long enough that no more than the warning window remains, and again
+// each time it goes idle that long after further activity. It returns when
+// ctx is done or no idle time remains.
+func (t *connIdleTracker) watch(ctx context.Context, warn func(remaining time.Duration)) {
+	window := t.warningWindow()
+	var warned bool
+	for {
+		rem := t.remaining()
+		if rem == 0 {
+			return
+		}
+		wait := rem
+		switch {
+		case rem > window:
+			warned = false
+			wait = rem - window
+		case !warned:
+			warn(rem)
+			warned = true
+		}
+		ch, stop := t.after(wait)
+		select {
+		case <-ctx.Done():
+			stop()
+			return
+		case <-ch:
+		}
+	}
+}
+
+// idleTrackingConn is a `net.Conn` implementation that records data going
+// across Read() or Write() with its tracker. All other `net.Conn` function
+// calls are a pass-through to the underlying `net.Conn`.
+type idleTrackingConn struct {
+	net.Conn
+	tracker *connIdleTracker
+}
+
+// Read wraps the embedded conn's Read() and records the activity.
+func (c *idleTrackingConn) Read(in []byte) (int, error) {
+	n, err := c.Conn.Read(in)
+	if n > 0 {
+		c.tracker.touch()
+	}
+	return n, err
+}
+
+// Write wraps the embedded conn's Write() and records the activity.
+func (c *idleTrackingConn) Write(in []byte) (int, error) {
+	n, err := c.Conn.Write(in)
+	if n > 0 {
+		c.tracker.touch()
+	}
+	return n, err
+}
+
+// keepAlive pings the worker over wsConn each time ticks fires until ctx is
+// done or a ping fails. A reader must be running on wsConn for the pongs to
+// be read.
+func keepAlive(ctx context.Context, wsConn *websocket.Conn, ticks <-chan time.Time) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticks:
+		}
+		if err := wsConn.Ping(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package connect
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+)
+
+// testClock is a clock that only moves when advanced. Each timer started on
+// it is sent on timers, and fires when the test sends on its channel.
+type testClock struct {
+	mu     sync.Mutex
+	t      time.Time
+	timers chan testTimer
+}
+
+type testTimer struct {
+	d time.Duration
+	c chan time.Time
+}
+
+func newTestClock() *testClock {
+	return &testClock{
+		t:      time.Unix(1_700_000_000, 0),
+		timers: make(chan testTimer),
+	}
+}
+
+func (c *testClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *testClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func (c *testClock) after(d time.Duration) (<-chan time.Time, func() bool) {
+	ch := make(chan time.Time, 1)
+	c.timers <- testTimer{d: d, c: ch}
+	return ch, func() bool { return true }
+}
+
+// nextTimer waits for the next timer to be started and returns it.
+func (c *testClock) nextTimer(t *testing.T) testTimer {
+	t.Helper()
+	select {
+	case tm := <-c.timers:
+		return tm
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no timer started")
+		return testTimer{}
+	}
+}
+
+func newTestConnIdleTracker(timeout time.Duration, clk *testClock) *connIdleTracker {
+	t := newConnIdleTracker(timeout)
+	t.now = clk.now
+	t.after = clk.after
+	t.touch()
+	return t
+}
+
+func TestConnIdleTracker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("remaining", func(t *testing.T) {
+		clk := newTestClock()
+		tr := newTestConnIdleTracker(time.Minute, clk)
+		assert.Equal(t, time.Minute, tr.remaining())
+
+		clk.advance(45 * time.Second)
+		assert.Equal(t, 15*time.Second, tr.remaining())
+
+		// Data in either direction resets the idle time.
+		server, client := net.Pipe()
+		defer server.Close()
+		conn := &idleTrackingConn{Conn: client, tracker: tr}
+		go func() { _, _ = server.Write([]byte("hello")) }()
+		_, err := conn.Read(make([]byte, 5))
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, tr.remaining())
+
+		clk.advance(30 * time.Second)
+		go func() { _, _ = server.Read(make([]byte, 5)) }()
+		_, err = conn.Write([]byte("hello"))
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, tr.remaining())
+
+		clk.advance(2 * time.Minute)
+		assert.Equal(t, time.Duration(0), tr.remaining())
+	})
+
+	t.Run("warning-window", func(t *testing.T) {
+		assert.Equal(t, 15*time.Second, (&connIdleTracker{timeout: time.Minute}).warningWindow())
+		assert.Equal(t, maxIdleWarningWindow, (&connIdleTracker{timeout: time.Hour}).warningWindow())
+	})
+
+	t.Run("watch", func(t *testing.T) {
+		clk := newTestClock()
+		tr := newTestConnIdleTracker(10*time.Minute, clk)
+		warnings := make(chan time.Duration, 1)
+		done := make(chan struct{})
+		go func() {
+			tr.watch(context.Background(), func(rem time.Duration) { warnings <- rem })
+			close(done)
+		}()
+
+		// The first wait is until only the warning window remains.
+		tm := clk.nextTimer(t)
+		assert.Equal(t, 9*time.Minute, tm.d)
+		clk.advance(9 * time.Minute)
+		tm.c <- clk.now()
+		assert.Equal(t, time.Minute, <-warnings)
+
+		// Activity before the timeout pushes the next warning back.
+		tm = clk.nextTimer(t)
+		assert.Equal(t, time.Minute, tm.d)
+		clk.advance(30 * time.Second)
+		tr.touch()
+		clk.advance(30 * time.Second)
+		tm.c <- clk.now()
+		tm = clk.nextTimer(t)
+		assert.Equal(t, 8*time.Minute+30*time.Second, tm.d)
+		clk.advance(tm.d)
+		tm.c <- clk.now()
+		assert.Equal(t, time.Minute, <-warnings)
+
+		// Without activity the watch ends once no idle time remains, without
+		// warning again.
+		tm = clk.nextTimer(t)
+		clk.advance(tm.d)
+		tm.c <- clk.now()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "watch did not return")
+		}
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("watch-canceled", func(t *testing.T) {
+		clk := newTestClock()
+		tr := newTestConnIdleTracker(time.Minute, clk)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			tr.watch(ctx, func(time.Duration) { t.Error("unexpected warning") })
+			close(done)
+		}()
+		clk.nextTimer(t)
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "watch did not return")
+		}
+	})
+}
+
+func TestKeepAlive(t *testing.T) {
+	t.Parallel()
+
+	// The server tracks data read from the client the way the worker does,
+	// so it can check that keepalives are not counted as activity.
+	clk := newTestClock()
+	serverIdle := newTestConnIdleTracker(time.Minute, clk)
+	serverDone := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(serverDone)
+		conn, err := websocket.Accept(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		netConn := &idleTrackingConn{Conn: websocket.NetConn(r.Context(), conn, websocket.MessageBinary), tracker: serverIdle}
+		// Reading answers pings until the client closes the connection.
+		_, _ = netConn.Read(make([]byte, 1))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	wsConn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	// A reader is needed on the client for pongs to be read.
+	go func() { _, _, _ = wsConn.Reader(ctx) }()
+
+	ticks := make(chan time.Time)
+	errs := make(chan error, 1)
+	go func() { errs <- keepAlive(ctx, wsConn, ticks) }()
+
+	// keepAlive only takes the next tick once the previous ping was
+	// answered.
+	for i := 0; i < 3; i++ {
+		clk.advance(10 * time.Second)
+		select {
+		case ticks <- clk.now():
+		case err := <-errs:
+			require.FailNow(t, "keepalive stopped", err)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "ping was not answered")
+		}
+	}
+	assert.Equal(t, 30*time.Second, serverIdle.remaining())
+
+	cancel()
+	require.NoError(t, <-errs)
+	wsConn.Close(websocket.StatusNormalClosure, "")
+	<-serverDone
+}
@@ -0,0 +1,901 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package connect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/proxy"
+	"github.com/hashicorp/go-secure-stdlib/base62"
+	"github.com/hashicorp/hcl"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"go.uber.org/atomic"
+	"nhooyr.io/websocket"
+)
+
+const (
+	// multiAuthorizeRetryInterval is how long to wait before trying to
+	// authorize a session for a target again after an attempt failed.
+	multiAuthorizeRetryInterval = 10 * time.Second
+
+	// multiMaxReauthorizeWindow is the most time before a session expires that
+	// its replacement is authorized. Sessions with short lifetimes are replaced
+	// once 90% of their lifetime has passed instead.
+	multiMaxReauthorizeWindow = time.Minute
+
+	// multiStatusPath is the path the control socket serves status on.
+	multiStatusPath = "/v1/status"
+)
+
+// TargetSessionInfo is the information output when "connect multi" authorizes
+// a session for one of its targets.
+type TargetSessionInfo struct {
+	Name    string `json:"name"`
+	Network string `json:"network"`
+	SessionInfo
+}
+
+// TargetStatus is the state of a single target proxied by "connect multi".
+type TargetStatus struct {
+	Name              string    `json:"name"`
+	TargetId          string    `json:"target_id,omitempty"`
+	Network           string    `json:"network"`
+	Address           string    `json:"address"`
+	SessionId         string    `json:"session_id,omitempty"`
+	Expiration        time.Time `json:"expiration"`
+	ConnectionsLeft   int32     `json:"connections_left"`
+	ActiveConnections int32     `json:"active_connections"`
+	Authorizations    int       `json:"authorizations"`
+	LastError         string    `json:"last_error,omitempty"`
+}
+
+// MultiStatus is the status reported over the "connect multi" control socket.
+type MultiStatus struct {
+	Targets []TargetStatus `json:"targets"`
+}
+
+// multiConfig is the configuration file read by "connect multi".
+type multiConfig struct {
+	// ControlSocket is the path of the Unix socket status is served on. If
+	// empty no control socket is created.
+	ControlSocket string               `hcl:"control_socket"`
+	Targets       []*multiTargetConfig `hcl:"target"`
+}
+
+// multiTargetConfig is a single target block in the "connect multi"
+// configuration file. The target is identified either by ID or by name and
+// scope, and is listened for either on a TCP address or on a Unix socket.
+type multiTargetConfig struct {
+	Name       string `hcl:",key"`
+	TargetId   string `hcl:"target_id"`
+	TargetName string `hcl:"target_name"`
+	ScopeId    string `hcl:"scope_id"`
+	ScopeName  string `hcl:"scope_name"`
+	HostId     string `hcl:"host_id"`
+	ListenAddr string `hcl:"listen_addr"`
+	ListenPort int    `hcl:"listen_port"`
+	UnixSocket string `hcl:"unix_socket"`
+}
+
+// parseMultiConfig parses and validates a "connect multi" configuration in
+// HCL or JSON format.
+func parseMultiConfig(d string) (*multiConfig, error) {
+	cfg := new(multiConfig)
+	if err := hcl.Decode(cfg, d); err != nil {
+		return nil, fmt.Errorf("Error parsing configuration: %w", err)
+	}
+	if len(cfg.Targets) == 0 {
+		return nil, errors.New("No targets defined in configuration")
+	}
+
+	names := make(map[string]bool, len(cfg.Targets))
+	endpoints := make(map[string]string, len(cfg.Targets))
+	for _, t := range cfg.Targets {
+		switch {
+		case t.Name == "":
+			return nil, errors.New("Target block is missing a name")
+		case names[t.Name]:
+			return nil, fmt.Errorf("Target %q is defined more than once", t.Name)
+		}
+		names[t.Name] = true
+
+		switch {
+		case t.TargetId != "" && (t.TargetName != "" || t.ScopeId != "" || t.ScopeName != ""):
+			return nil, fmt.Errorf("Target %q: cannot specify a target ID and also other lookup parameters", t.Name)
+		case t.TargetId == "" && (t.TargetName == "" || (t.ScopeId == "" && t.ScopeName == "")):
+			return nil, fmt.Errorf("Target %q: either target_id or target_name and one of scope_id or scope_name must be set", t.Name)
+		case t.ScopeId != "" && t.ScopeName != "":
+			return nil, fmt.Errorf("Target %q: scope_id and scope_name cannot both be set", t.Name)
+		}
+
+		var endpoint string
+		switch {
+		case t.UnixSocket != "":
+			if t.ListenAddr != "" || t.ListenPort != 0 {
+				return nil, fmt.Errorf("Target %q: unix_socket cannot be used with listen_addr or listen_port", t.Name)
+			}
+			endpoint = "unix:" + t.UnixSocket
+		default:
+			if t.ListenAddr == "" {
+				t.ListenAddr = "127.0.0.1"
+			}
+			if net.ParseIP(t.ListenAddr) == nil {
+				return nil, fmt.Errorf("Target %q: could not parse listen address of %s", t.Name, t.ListenAddr)
+			}
+			if t.ListenPort < 0 || t.ListenPort > 65535 {
+				return nil, fmt.Errorf("Target %q: invalid listen port %d", t.Name, t.ListenPort)
+			}
+			if t.ListenPort != 0 {
+				endpoint = "tcp:" + net.JoinHostPort(t.ListenAddr, fmt.Sprint(t.ListenPort))
+			}
+		}
+		if endpoint == "" {
+			continue
+		}
+		if other, ok := endpoints[endpoint]; ok {
+			return nil, fmt.Errorf("Targets %q and %q cannot listen on the same address", other, t.Name)
+		}
+		endpoints[endpoint] = t.Name
+	}
+	return cfg, nil
+}
+
+var (
+	_ cli.Command             = (*MultiCommand)(nil)
+	_ cli.CommandAutocomplete = (*MultiCommand)(nil)
+)
+
+// MultiCommand is "connect multi", which proxies several targets at once from
+// a configuration file, authorizing new sessions as old ones expire.
+type MultiCommand struct {
+	*base.Command
+
+	flagConfig        string
+	flagControlSocket string
+	flagStatus        bool
+}
+
+func (c *MultiCommand) Synopsis() string {
+	return "Proxy connections to multiple targets through Boundary workers"
+}
+
+func (c *MultiCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary connect multi [options]",
+		"",
+		`  This command reads a list of targets from a configuration file and proxies connections to each of them, on its own TCP port or Unix socket, until it is interrupted. Sessions are authorized again automatically before they expire or once their connections are exhausted.`,
+		"",
+		"  Example configuration:",
+		"",
+		`      control_socket = "/tmp/boundary-connect.sock"`,
+		"",
+		`      target "db" {`,
+		`        target_id   = "ttcp_1234567890"`,
+		`        listen_port = 5432`,
+		`      }`,
+		"",
+		`      target "cache" {`,
+		`        target_name = "redis"`,
+		`        scope_name  = "dev"`,
+		`        unix_socket = "/tmp/redis.sock"`,
+		`      }`,
+		"",
+		"  Start proxying:",
+		"",
+		`      $ boundary connect multi -config connect.hcl`,
+		"",
+		"  Show the status of the running process:",
+		"",
+		`      $ boundary connect multi -config connect.hcl -status`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *MultiCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Connect Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		EnvVar: "BOUNDARY_CONNECT_CONFIG",
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file listing the targets to proxy.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "control-socket",
+		Target:     &c.flagControlSocket,
+		EnvVar:     "BOUNDARY_CONNECT_CONTROL_SOCKET",
+		Completion: complete.PredictFiles("*"),
+		Usage:      "Path of the Unix socket used to report status. Overrides control_socket in the configuration file.",
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "status",
+		Target: &c.flagStatus,
+		Usage:  "If set, instead of proxying, print the status of the process listening on the control socket and exit.",
+	})
+
+	return set
+}
+
+func (c *MultiCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *MultiCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *MultiCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	var cfg *multiConfig
+	if c.flagConfig != "" {
+		raw, err := os.ReadFile(c.flagConfig)
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("Error reading configuration file: %w", err))
+			return base.CommandUserError
+		}
+		if cfg, err = parseMultiConfig(string(raw)); err != nil {
+			c.PrintCliError(err)
+			return base.CommandUserError
+		}
+	}
+
+	controlSocket := c.flagControlSocket
+	if controlSocket == "" && cfg != nil {
+		controlSocket = cfg.ControlSocket
+	}
+
+	if c.flagStatus {
+		if controlSocket == "" {
+			c.PrintCliError(errors.New("A control socket must be given with -control-socket or in the configuration file to fetch status"))
+			return base.CommandUserError
+		}
+		return c.printStatus(controlSocket)
+	}
+
+	if cfg == nil {
+		c.PrintCliError(errors.New("Configuration file must be specified with -config"))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %s", err))
+		return base.CommandCliError
+	}
+	targetClient := targets.NewClient(client)
+
+	ctx, cancel := context.WithCancel(c.Context)
+	defer cancel()
+
+	mts := make([]*multiTarget, 0, len(cfg.Targets))
+	defer func() {
+		for _, t := range mts {
+			t.close()
+		}
+	}()
+	for _, tc := range cfg.Targets {
+		t, err := newMultiTarget(c, targetClient, tc)
+		if err != nil {
+			c.PrintCliError(err)
+			return base.CommandCliError
+		}
+		mts = append(mts, t)
+	}
+
+	// Authorize every target up front so that mistakes in the configuration
+	// are reported immediately rather than on the first connection.
+	for _, t := range mts {
+		if _, err := t.acquire(ctx); err != nil {
+			if apiErr := api.AsServerError(err); apiErr != nil {
+				c.PrintApiError(apiErr, fmt.Sprintf("Error from controller when performing authorize-session action against target %q", t.cfg.Name))
+				return base.CommandApiError
+			}
+			c.PrintCliError(err)
+			return base.CommandCliError
+		}
+		t.release()
+	}
+
+	wg := new(sync.WaitGroup)
+	if controlSocket != "" {
+		srv, err := c.serveStatus(controlSocket, mts)
+		if err != nil {
+			c.PrintCliError(err)
+			return base.CommandCliError
+		}
+		defer srv.Close()
+	}
+
+	for _, t := range mts {
+		wg.Add(2)
+		go t.acceptLoop(ctx, wg)
+		go t.renewLoop(ctx, wg)
+	}
+
+	<-ctx.Done()
+	for _, t := range mts {
+		t.close()
+	}
+	wg.Wait()
+
+	return base.CommandSuccess
+}
+
+// serveStatus serves the status of mts as JSON over HTTP on a Unix socket at
+// path. Only the current user can connect to the socket as the status
+// includes session IDs.
+func (c *MultiCommand) serveStatus(path string, mts []*multiTarget) (*http.Server, error) {
+	ln, err := listenUnix(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error starting control socket: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(multiStatusPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		status := MultiStatus{Targets: make([]TargetStatus, 0, len(mts))}
+		for _, t := range mts {
+			status.Targets = append(status.Targets, t.status())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(&status); err != nil {
+			c.PrintCliError(fmt.Errorf("Error writing status to control socket: %w", err))
+		}
+	})
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			c.PrintCliError(fmt.Errorf("Error serving control socket: %w", err))
+		}
+	}()
+	return srv, nil
+}
+
+// printStatus fetches the status of a running "connect multi" from the control
+// socket at path and prints it.
+func (c *MultiCommand) printStatus(path string) int {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		},
+	}
+	resp, err := client.Get("http://boundary-connect" + multiStatusPath)
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error fetching status from control socket: %w", err))
+		return base.CommandCliError
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		c.PrintCliError(fmt.Errorf("Unexpected response from control socket: %s", resp.Status))
+		return base.CommandCliError
+	}
+	var status MultiStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		c.PrintCliError(fmt.Errorf("Error decoding status from control socket: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateMultiStatusTableOutput(status))
+	case "json":
+		out, err := json.Marshal(&status)
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("error marshaling status information: %w", err))
+			return base.CommandCliError
+		}
+		c.UI.Output(string(out))
+	}
+	return base.CommandSuccess
+}
+
+// listenUnix listens on a Unix socket at path that only the current user can
+// connect to. A socket left behind by a previous process is removed first.
+func listenUnix(path string) (net.Listener, error) {
+	if fi, err := os.Lstat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("unable to remove existing socket %s: %w", path, err)
+		}
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("unable to set permissions on socket %s: %w", path, err)
+	}
+	return ln, nil
+}
+
+// multiTarget proxies connections to a single target for "connect multi". It
+// holds the target's current session, replacing it before it expires or once
+// it can no longer be used.
+type multiTarget struct {
+	cmd      *MultiCommand
+	client   *targets.Client
+	cfg      *multiTargetConfig
+	listener net.Listener
+	network  string
+
+	// renewed is signalled when a new session is authorized so that the
+	// renewal can be rescheduled.
+	renewed chan struct{}
+	active  atomic.Int32
+
+	closeOnce sync.Once
+
+	mu             sync.Mutex
+	sess           *multiSession
+	authorizations int
+	lastErr        string
+}
+
+func newMultiTarget(cmd *MultiCommand, client *targets.Client, cfg *multiTargetConfig) (*multiTarget, error) {
+	t := &multiTarget{
+		cmd:     cmd,
+		client:  client,
+		cfg:     cfg,
+		renewed: make(chan struct{}, 1),
+	}
+	var err error
+	switch {
+	case cfg.UnixSocket != "":
+		t.network = "unix"
+		t.listener, err = listenUnix(cfg.UnixSocket)
+	default:
+		t.network = "tcp"
+		t.listener, err = net.ListenTCP("tcp", &net.TCPAddr{
+			IP:   net.ParseIP(cfg.ListenAddr),
+			Port: cfg.ListenPort,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error starting listener for target %q: %w", cfg.Name, err)
+	}
+	return t, nil
+}
+
+// acquire implements sessionSource.
+func (t *multiTarget) acquire(ctx context.Context) (*multiSession, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.sess == nil || !t.sess.usable() {
+		if err := t.authorizeLocked(ctx); err != nil {
+			return nil, err
+		}
+	}
+	t.sess.active.Inc()
+	return t.sess, nil
+}
+
+// release is the counterpart to acquire when the session was only needed to
+// check that the target can be authorized.
+func (t *multiTarget) release() {
+	t.mu.Lock()
+	sess := t.sess
+	t.mu.Unlock()
+	sess.release()
+}
+
+// renew replaces the current session with a newly authorized one.
+func (t *multiTarget) renew(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.authorizeLocked(ctx)
+}
+
+// retire implements sessionSource. It is a no-op if sess has already been
+// replaced.
+func (t *multiTarget) retire(sess *multiSession) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.sess == sess {
+		sess.retire()
+	}
+}
+
+// authorizeLocked authorizes a new session for the target and makes it the
+// current session, retiring the previous one. t.mu must be held.
+func (t *multiTarget) authorizeLocked(ctx context.Context) error {
+	sess, sa, err := t.authorize(ctx)
+	if err != nil {
+		t.lastErr = err.Error()
+		return err
+	}
+	if t.sess != nil {
+		t.sess.retire()
+	}
+	t.sess = sess
+	t.authorizations++
+	t.lastErr = ""
+	select {
+	case t.renewed <- struct{}{}:
+	default:
+	}
+	t.outputSessionInfo(sess, sa)
+	return nil
+}
+
+func (t *multiTarget) authorize(ctx context.Context) (*multiSession, *targets.SessionAuthorization, error) {
+	var opts []targets.Option
+	if t.cfg.HostId != "" {
+		opts = append(opts, targets.WithHostId(t.cfg.HostId))
+	}
+	if t.cfg.TargetName != "" {
+		opts = append(opts, targets.WithName(t.cfg.TargetName))
+	}
+	if t.cfg.ScopeId != "" {
+		opts = append(opts, targets.WithScopeId(t.cfg.ScopeId))
+	}
+	if t.cfg.ScopeName != "" {
+		opts = append(opts, targets.WithScopeName(t.cfg.ScopeName))
+	}
+	sess, sa, err := authorizeSession(ctx, t.client, t.cfg.TargetId, opts...)
+	if err != nil {
+		if api.AsServerError(err) != nil {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("Error trying to authorize a session against target %q: %w", t.cfg.Name, err)
+	}
+	return sess, sa, nil
+}
+
+func (t *multiTarget) outputSessionInfo(sess *multiSession, sa *targets.SessionAuthorization) {
+	info := TargetSessionInfo{
+		Name:    t.cfg.Name,
+		Network: t.network,
+		SessionInfo: SessionInfo{
+			Protocol:        sa.Type,
+			Address:         t.listener.Addr().String(),
+			Expiration:      sess.expiration,
+			ConnectionLimit: sess.connsLeft.Load(),
+			SessionId:       sess.id,
+			Credentials:     sa.Credentials,
+		},
+	}
+	if addr, ok := t.listener.Addr().(*net.TCPAddr); ok {
+		info.Address = addr.IP.String()
+		info.Port = addr.Port
+	}
+	switch base.Format(t.cmd.UI) {
+	case "table":
+		t.cmd.UI.Output(generateTargetSessionInfoTableOutput(info))
+	case "json":
+		out, err := json.Marshal(&info)
+		if err != nil {
+			t.cmd.PrintCliError(fmt.Errorf("error marshaling session information: %w", err))
+			return
+		}
+		t.cmd.UI.Output(string(out))
+	}
+}
+
+// untilRenewal returns how long until the current session should be
+// replaced.
+func (t *multiTarget) untilRenewal() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.sess == nil {
+		return multiAuthorizeRetryInterval
+	}
+	window := t.sess.expiration.Sub(t.sess.authorizedAt) / 10
+	if window > multiMaxReauthorizeWindow {
+		window = multiMaxReauthorizeWindow
+	}
+	return time.Until(t.sess.expiration.Add(-window))
+}
+
+// renewLoop authorizes a replacement for the current session shortly before
+// it expires until ctx is done.
+func (t *multiTarget) renewLoop(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	var failed bool
+	for {
+		wait := t.untilRenewal()
+		if failed && wait < multiAuthorizeRetryInterval {
+			wait = multiAuthorizeRetryInterval
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-t.renewed:
+			// Another session was authorized in the meantime, reschedule
+			failed = false
+		case <-timer.C:
+			failed = false
+			if err := t.renew(ctx); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				failed = true
+				t.cmd.PrintCliError(fmt.Errorf("Error reauthorizing session for target %q: %w", t.cfg.Name, err))
+			}
+		}
+		timer.Stop()
+	}
+}
+
+// acceptLoop proxies each connection to the listener until it is closed.
+func (t *multiTarget) acceptLoop(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return
+			}
+			t.cmd.PrintCliError(fmt.Errorf("Error accepting connection for target %q: %w", t.cfg.Name, err))
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer conn.Close()
+			t.active.Inc()
+			defer t.active.Dec()
+			if err := t.handleConn(ctx, conn); err != nil {
+				t.mu.Lock()
+				t.lastErr = err.Error()
+				t.mu.Unlock()
+				t.cmd.PrintCliError(fmt.Errorf("Error proxying connection for target %q: %w", t.cfg.Name, err))
+			}
+		}()
+	}
+}
+
+// handleConn proxies conn over the target's current session.
+func (t *multiTarget) handleConn(ctx context.Context, conn net.Conn) error {
+	return proxyConn(ctx, t, conn, func() error { return nil })
+}
+
+func (t *multiTarget) status() TargetStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	st := TargetStatus{
+		Name:              t.cfg.Name,
+		TargetId:          t.cfg.TargetId,
+		Network:           t.network,
+		Address:           t.listener.Addr().String(),
+		ActiveConnections: t.active.Load(),
+		Authorizations:    t.authorizations,
+		LastError:         t.lastErr,
+	}
+	if t.sess != nil {
+		st.SessionId = t.sess.id
+		st.Expiration = t.sess.expiration
+		st.ConnectionsLeft = t.sess.connsLeft.Load()
+	}
+	return st
+}
+
+// close stops accepting connections and retires the current session, which
+// tears it down once its connections are done.
+func (t *multiTarget) close() {
+	t.closeOnce.Do(func() {
+		if err := t.listener.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			t.cmd.PrintCliError(fmt.Errorf("Error closing listener for target %q: %w", t.cfg.Name, err))
+		}
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if t.sess != nil {
+			t.sess.retire()
+		}
+	})
+}
+
+// authorizeSession authorizes a session against the target and prepares it
+// for proxying. Errors returned by the controller are returned as *api.Error.
+func authorizeSession(ctx context.Context, client *targets.Client, targetId string, opt ...targets.Option) (*multiSession, *targets.SessionAuthorization, error) {
+	sar, err := client.AuthorizeSession(ctx, targetId, opt...)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			return nil, nil, apiErr
+		}
+		return nil, nil, err
+	}
+	sa := sar.GetItem().(*targets.SessionAuthorization)
+
+	data, err := decodeSessionAuthzData(sa.AuthorizationToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	workerAddr, transport, expiration, err := workerTransport(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	tofuToken, err := base62.Random(20)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Could not derive random bytes for tofu token: %w", err)
+	}
+
+	sess := &multiSession{
+		id:           data.GetSessionId(),
+		workerAddr:   workerAddr,
+		transport:    transport,
+		tofuToken:    tofuToken,
+		authorizedAt: time.Now(),
+		expiration:   expiration,
+	}
+	sess.connsLeft.Store(data.GetConnectionLimit())
+	// The session's connections are closed once it is torn down, it expires or
+	// ctx is done.
+	sess.ctx, sess.cancel = context.WithDeadline(ctx, expiration)
+	return sess, sa, nil
+}
+
+// sessionSource hands out sessions to proxy new connections to a single
+// target over.
+type sessionSource interface {
+	// acquire returns a usable session, authorizing one if needed. The caller
+	// must release the session when the connection is done.
+	acquire(context.Context) (*multiSession, error)
+	// retire stops the session from being handed out to new connections.
+	retire(*multiSession)
+}
+
+// proxyConn proxies conn over a session from src until either side closes.
+// ready is called once the worker has accepted the connection and before any
+// data is copied; if it returns an error the connection is abandoned. If the
+// worker refuses the session, which happens when it was canceled or its
+// connections were used up elsewhere, a new session is authorized and tried
+// once.
+func proxyConn(ctx context.Context, src sessionSource, conn net.Conn, ready func() error) error {
+	for attempt := 0; ; attempt++ {
+		sess, err := src.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		err = proxySessionConn(sess, conn, func(handshakeResult *proxy.HandshakeResult) error {
+			if handshakeResult.GetConnectionsLeft() == 0 {
+				// This was the last connection, authorize a new session for
+				// the next
+				src.retire(sess)
+			}
+			return ready()
+		})
+		sess.release()
+		if errors.Is(err, errConnectionUnauthorized) || errors.Is(err, errSessionInUse) {
+			src.retire(sess)
+			if attempt == 0 {
+				continue
+			}
+		}
+		return err
+	}
+}
+
+// proxySessionConn proxies conn over sess. ready is called with the worker's
+// handshake result before any data is copied. If the worker refuses the
+// session it is marked invalid and errConnectionUnauthorized or
+// errSessionInUse is returned.
+func proxySessionConn(sess *multiSession, conn net.Conn, ready func(*proxy.HandshakeResult) error) error {
+	wsConn, err := getWsConn(sess.ctx, sess.workerAddr, sess.transport)
+	if err != nil {
+		return err
+	}
+	handshakeResult, err := tcpProxyV1Handshake(sess.ctx, wsConn, sess.tofuToken)
+	if err != nil {
+		wsConn.Close(websocket.StatusNormalClosure, "")
+		if errors.Is(err, errConnectionUnauthorized) || errors.Is(err, errSessionInUse) {
+			sess.invalid.Store(true)
+		}
+		return err
+	}
+	sess.connsLeft.Store(handshakeResult.GetConnectionsLeft())
+	if err := ready(handshakeResult); err != nil {
+		wsConn.Close(websocket.StatusNormalClosure, "")
+		return err
+	}
+
+	if err := tcpProxyV1Copy(sess.ctx, wsConn, conn, nil); isIdleTimeoutClose(err) {
+		return fmt.Errorf("Connection closed by worker after being idle for %s", time.Duration(handshakeResult.GetConnectionIdleTimeoutSeconds())*time.Second)
+	}
+	return nil
+}
+
+// multiSession is a session authorized by "connect multi" or "connect proxy"
+// that is shared by connections until it is retired. Once it is retired and its
+// last connection is released it is torn down on the worker.
+type multiSession struct {
+	id           string
+	workerAddr   string
+	transport    *http.Transport
+	tofuToken    string
+	authorizedAt time.Time
+	expiration   time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// connsLeft is -1 when the session allows unlimited connections.
+	connsLeft atomic.Int32
+	active    atomic.Int32
+	retired   atomic.Bool
+	// invalid is set when the worker refused the session, in which case there
+	// is nothing to tear down.
+	invalid   atomic.Bool
+	closeOnce sync.Once
+}
+
+// usable reports whether new connections can be made with the session.
+func (s *multiSession) usable() bool {
+	return !s.retired.Load() && s.connsLeft.Load() != 0 && time.Now().Before(s.expiration)
+}
+
+func (s *multiSession) retire() {
+	s.retired.Store(true)
+	if s.active.Load() == 0 {
+		s.close()
+	}
+}
+
+func (s *multiSession) release() {
+	if s.active.Dec() == 0 && s.retired.Load() {
+		s.close()
+	}
+}
+
+// close tears down the session on the worker, unless it already expired or
+// was refused, and closes any remaining connections.
+func (s *multiSession) close() {
+	s.closeOnce.Do(func() {
+		defer s.cancel()
+		if s.invalid.Load() || !time.Now().Before(s.expiration) {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), sessionCancelTimeout)
+		defer cancel()
+		wsConn, err := getWsConn(ctx, s.workerAddr, s.transport)
+		if err != nil {
+			return
+		}
+		defer wsConn.Close(websocket.StatusNormalClosure, "")
+		_ = sendSessionTeardown(ctx, wsConn, s.tofuToken)
+	})
+}
@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package connect
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMultiConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  string
+		want    *multiConfig
+		wantErr string
+	}{
+		{
+			name: "hcl",
+			config: `
+control_socket = "/tmp/connect.sock"
+
+target "db" {
+  target_id   = "ttcp_1234567890"
+  listen_port = 5432
+}
+
+target "cache" {
+  target_name = "redis"
+  scope_name  = "dev"
+  host_id     = "hst_1234567890"
+  unix_socket = "/tmp/redis.sock"
+}
+`,
+			want: &multiConfig{
+				ControlSocket: "/tmp/connect.sock",
+				Targets: []*multiTargetConfig{
+					{Name: "db", TargetId: "ttcp_1234567890", ListenAddr: "127.0.0.1", ListenPort: 5432},
+					{Name: "cache", TargetName: "redis", ScopeName: "dev", HostId: "hst_1234567890", UnixSocket: "/tmp/redis.sock"},
+				},
+			},
+		},
+		{
+			name:   "json",
+			config: `{"target": {"db": {"target_name": "postgres", "scope_id": "p_1234567890", "listen_addr": "::1"}}}`,
+			want: &multiConfig{
+				Targets: []*multiTargetConfig{
+					{Name: "db", TargetName: "postgres", ScopeId: "p_1234567890", ListenAddr: "::1"},
+				},
+			},
+		},
+		{
+			name:    "no-targets",
+			config:  `control_socket = "/tmp/connect.sock"`,
+			wantErr: "No targets defined",
+		},
+		{
+			name: "duplicate-name",
+			config: `
+target "db" { target_id = "ttcp_1" }
+target "db" { target_id = "ttcp_2" }
+`,
+			wantErr: `Target "db" is defined more than once`,
+		},
+		{
+			name:    "no-target-identifier",
+			config:  `target "db" { target_name = "postgres" }`,
+			wantErr: "either target_id or target_name",
+		},
+		{
+			name:    "id-and-name",
+			config:  `target "db" { target_id = "ttcp_1", target_name = "postgres" }`,
+			wantErr: "cannot specify a target ID and also other lookup parameters",
+		},
+		{
+			name:    "scope-id-and-name",
+			config:  `target "db" { target_name = "postgres", scope_id = "p_1", scope_name = "dev" }`,
+			wantErr: "scope_id and scope_name cannot both be set",
+		},
+		{
+			name:    "socket-and-port",
+			config:  `target "db" { target_id = "ttcp_1", unix_socket = "/tmp/db.sock", listen_port = 5432 }`,
+			wantErr: "unix_socket cannot be used with listen_addr or listen_port",
+		},
+		{
+			name:    "bad-listen-addr",
+			config:  `target "db" { target_id = "ttcp_1", listen_addr = "localhost" }`,
+			wantErr: "could not parse listen address",
+		},
+		{
+			name: "same-port",
+			config: `
+target "db" { target_id = "ttcp_1", listen_port = 5432 }
+target "db2" { target_id = "ttcp_2", listen_port = 5432 }
+`,
+			wantErr: `Targets "db" and "db2" cannot listen on the same address`,
+		},
+		{
+			name: "same-socket",
+			config: `
+target "db" { target_id = "ttcp_1", unix_socket = "/tmp/db.sock" }
+target "db2" { target_id = "ttcp_2", unix_socket = "/tmp/db.sock" }
+`,
+			wantErr: `Targets "db" and "db2" cannot listen on the same address`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseMultiConfig(tt.config)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMultiSession_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	newSession := func(connsLeft int32) *multiSession {
+		s := &multiSession{
+			authorizedAt: time.Now(),
+			expiration:   time.Now().Add(time.Hour),
+			// Teardown is skipped for sessions the worker refused, which
+			// keeps this test from needing a worker.
+		}
+		s.invalid.Store(true)
+		s.connsLeft.Store(connsLeft)
+		return s
+	}
+
+	t.Run("unlimited", func(t *testing.T) {
+		s := newSession(-1)
+		assert.True(t, s.usable())
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		s := newSession(0)
+		assert.False(t, s.usable())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		s := newSession(-1)
+		s.expiration = time.Now().Add(-time.Second)
+		assert.False(t, s.usable())
+	})
+
+	t.Run("retired-after-release", func(t *testing.T) {
+		s := newSession(-1)
+		var canceled bool
+		s.cancel = func() { canceled = true }
+		s.active.Inc()
+
+		s.retire()
+		assert.False(t, s.usable())
+		assert.False(t, canceled, "session closed while a connection was active")
+
+		s.release()
+		assert.True(t, canceled)
+	})
+}
+
+func TestListenUnix(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "connect.sock")
+
+	ln, err := listenUnix(path)
+	require.NoError(t, err)
+	// Simulate a socket left behind by a process that did not clean up
+	ln.(interface{ SetUnlinkOnClose(bool) }).SetUnlinkOnClose(false)
+	require.NoError(t, ln.Close())
+
+	ln, err = listenUnix(path)
+	require.NoError(t, err)
+	defer ln.Close()
+}
@@ -0,0 +1,865 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package connect
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/hosts"
+	"github.com/hashicorp/boundary/api/hostsets"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// proxyIndexMaxAge is how long the index of targets and hosts used to
+	// resolve requested hostnames is used before it is rebuilt.
+	proxyIndexMaxAge = 5 * time.Minute
+
+	// proxyIndexMinRefreshInterval limits how often a hostname that cannot be
+	// resolved causes the index to be rebuilt.
+	proxyIndexMinRefreshInterval = 30 * time.Second
+
+	// proxyRequestTimeout is how long a client has to send its SOCKS5 or HTTP
+	// CONNECT request after connecting.
+	proxyRequestTimeout = 30 * time.Second
+)
+
+// SOCKS5 protocol values, see RFC 1928.
+const (
+	socks5Version             = 0x05
+	socks5NoAuth              = 0x00
+	socks5NoAcceptableMethods = 0xff
+	socks5CmdConnect          = 0x01
+	socks5AtypIPv4            = 0x01
+	socks5AtypDomain          = 0x03
+	socks5AtypIPv6            = 0x04
+
+	socks5Succeeded           = 0x00
+	socks5GeneralFailure      = 0x01
+	socks5NotAllowed          = 0x02
+	socks5HostUnreachable     = 0x04
+	socks5ConnectionRefused   = 0x05
+	socks5CommandNotSupported = 0x07
+	socks5AddressNotSupported = 0x08
+)
+
+var (
+	errNoTargetForHost  = errors.New("No target found for requested host")
+	errAmbiguousHost    = errors.New("Requested host matches more than one target")
+	errPortMismatch     = errors.New("Requested port is not the target's port")
+	errNotConnectMethod = errors.New("Only the CONNECT method is supported")
+
+	// errSocks5NoAcceptableMethods is returned when a SOCKS5 client does not
+	// offer to connect without authentication. The client has already been
+	// told so no further reply is sent.
+	errSocks5NoAcceptableMethods = errors.New("SOCKS5 client does not support connecting without authentication")
+)
+
+// ProxyInfo is the information output when "connect proxy" starts listening.
+type ProxyInfo struct {
+	Address   string   `json:"address"`
+	Port      int      `json:"port"`
+	Protocols []string `json:"protocols"`
+}
+
+var (
+	_ cli.Command             = (*ProxyCommand)(nil)
+	_ cli.CommandAutocomplete = (*ProxyCommand)(nil)
+)
+
+// ProxyCommand is "connect proxy", which runs a local SOCKS5 and HTTP CONNECT
+// proxy that resolves requested hostnames to targets and proxies connections
+// to them through Boundary workers.
+type ProxyCommand struct {
+	*base.Command
+
+	flagListenAddr string
+	flagListenPort int
+	flagScopeId    string
+
+	targetClient *targets.Client
+	resolver     *targetResolver
+	listenerAddr *net.TCPAddr
+
+	targetsLock sync.Mutex
+	targets     map[proxyCandidate]*proxyTarget
+}
+
+func (c *ProxyCommand) Synopsis() string {
+	return "Run a local SOCKS5 and HTTP CONNECT proxy to Boundary targets"
+}
+
+func (c *ProxyCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary connect proxy [options]",
+		"",
+		`  This command runs a local proxy speaking both SOCKS5 and HTTP CONNECT on a single port. Requested hostnames are resolved to a target by target ID, target name, target address, or the address of one of the target's hosts; when several targets match, the one whose default port matches the requested port is used. Sessions are always made to a target's own port, so requests for any other port of a target with a default port are rejected. Sessions are authorized on demand with the current token and shared by connections to the same target and host.`,
+		"",
+		"  Example:",
+		"",
+		`      $ boundary connect proxy -listen-port 1080`,
+		"",
+		`      $ curl --proxy socks5h://127.0.0.1:1080 http://internal-app:8080`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ProxyCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Connect Options")
+
+	f.StringVar(&base.StringVar{
+		Name:       "listen-addr",
+		Target:     &c.flagListenAddr,
+		EnvVar:     "BOUNDARY_CONNECT_LISTEN_ADDR",
+		Default:    "127.0.0.1",
+		Completion: complete.PredictAnything,
+		Usage:      `The IP address the proxy listens on. Any process able to connect to it can use the proxy with the current token, so it should not be reachable from other machines.`,
+	})
+
+	f.IntVar(&base.IntVar{
+		Name:       "listen-port",
+		Target:     &c.flagListenPort,
+		EnvVar:     "BOUNDARY_CONNECT_LISTEN_PORT",
+		Default:    1080,
+		Completion: complete.PredictAnything,
+		Usage:      `The port the proxy listens on.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "scope-id",
+		Target:     &c.flagScopeId,
+		EnvVar:     "BOUNDARY_CONNECT_PROXY_SCOPE_ID",
+		Default:    "global",
+		Completion: complete.PredictAnything,
+		Usage:      `The scope, along with its child scopes, whose targets requested hostnames are resolved against.`,
+	})
+
+	return set
+}
+
+func (c *ProxyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ProxyCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ProxyCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	listenAddr := net.ParseIP(c.flagListenAddr)
+	if listenAddr == nil {
+		c.PrintCliError(fmt.Errorf("Could not successfully parse listen address of %s", c.flagListenAddr))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %s", err))
+		return base.CommandCliError
+	}
+	c.targetClient = targets.NewClient(client)
+	c.targets = make(map[proxyCandidate]*proxyTarget)
+	c.resolver = &targetResolver{
+		build: func(ctx context.Context) (*targetIndex, error) {
+			return buildTargetIndex(ctx, client, c.flagScopeId)
+		},
+	}
+
+	ctx, cancel := context.WithCancel(c.Context)
+	defer cancel()
+
+	// Build the index up front so problems listing targets are reported
+	// immediately rather than on the first connection.
+	if err := c.resolver.refresh(ctx); err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when listing targets")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error listing targets: %w", err))
+		return base.CommandCliError
+	}
+
+	listener, err := net.ListenTCP("tcp", &net.TCPAddr{
+		IP:   listenAddr,
+		Port: c.flagListenPort,
+	})
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error starting listening port: %w", err))
+		return base.CommandCliError
+	}
+	c.listenerAddr = listener.Addr().(*net.TCPAddr)
+
+	info := ProxyInfo{
+		Address:   c.listenerAddr.IP.String(),
+		Port:      c.listenerAddr.Port,
+		Protocols: []string{"socks5", "http-connect"},
+	}
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateProxyInfoTableOutput(info))
+	case "json":
+		out, err := json.Marshal(&info)
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("error marshaling proxy information: %w", err))
+			return base.CommandCliError
+		}
+		c.UI.Output(string(out))
+	}
+
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+					return
+				}
+				c.PrintCliError(fmt.Errorf("Error accepting connection: %w", err))
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer conn.Close()
+				c.handleConn(ctx, conn)
+			}()
+		}
+	}()
+
+	<-ctx.Done()
+	if err := listener.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		c.PrintCliError(fmt.Errorf("Error closing listener on shutdown: %w", err))
+	}
+	c.targetsLock.Lock()
+	for _, t := range c.targets {
+		t.close()
+	}
+	c.targetsLock.Unlock()
+	wg.Wait()
+
+	return base.CommandSuccess
+}
+
+// handleConn reads a SOCKS5 or HTTP CONNECT request from conn, depending on
+// its first byte, and proxies the connection to the target it resolves to.
+func (c *ProxyCommand) handleConn(ctx context.Context, conn net.Conn) {
+	if err := conn.SetReadDeadline(time.Now().Add(proxyRequestTimeout)); err != nil {
+		c.PrintCliError(fmt.Errorf("Error setting deadline for proxy request: %w", err))
+		return
+	}
+	br := bufio.NewReader(conn)
+	first, err := br.Peek(1)
+	if err != nil {
+		return
+	}
+	// Reads must drain anything buffered while reading the request before
+	// reading from the connection itself.
+	bc := &bufferedConn{Conn: conn, r: br}
+
+	var host string
+	var port int
+	var reply func(error) error
+	switch first[0] {
+	case socks5Version:
+		host, port, err = readSocks5Request(bc)
+		reply = func(err error) error {
+			if errors.Is(err, errSocks5NoAcceptableMethods) {
+				return nil
+			}
+			return writeSocks5Reply(bc, socks5ReplyCode(err))
+		}
+	default:
+		host, port, err = readConnectRequest(br)
+		reply = func(err error) error {
+			return writeConnectResponse(bc, connectStatusCode(err))
+		}
+	}
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			c.PrintCliError(fmt.Errorf("Error reading proxy request: %w", err))
+			_ = reply(err)
+		}
+		return
+	}
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		c.PrintCliError(fmt.Errorf("Error clearing deadline for proxy request: %w", err))
+		return
+	}
+
+	requested := net.JoinHostPort(host, strconv.Itoa(port))
+	candidate, err := c.resolver.resolve(ctx, host, port)
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error resolving %s: %w", requested, err))
+		_ = reply(err)
+		return
+	}
+
+	var replied bool
+	err = proxyConn(ctx, c.proxyTarget(candidate, requested), bc, func() error {
+		replied = true
+		return reply(nil)
+	})
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error proxying connection to %s: %w", requested, err))
+		if !replied {
+			_ = reply(err)
+		}
+	}
+}
+
+// proxyTarget returns the proxyTarget shared by connections that resolved to
+// candidate, creating it if needed.
+func (c *ProxyCommand) proxyTarget(candidate proxyCandidate, requested string) *proxyTarget {
+	c.targetsLock.Lock()
+	defer c.targetsLock.Unlock()
+	t, ok := c.targets[candidate]
+	if !ok {
+		t = &proxyTarget{
+			cmd:       c,
+			candidate: candidate,
+			requested: requested,
+		}
+		c.targets[candidate] = t
+	}
+	return t
+}
+
+// proxyTarget holds the session shared by "connect proxy" connections to a
+// single target and host.
+type proxyTarget struct {
+	cmd       *ProxyCommand
+	candidate proxyCandidate
+	// requested is the address first requested for the target, used when
+	// reporting sessions.
+	requested string
+
+	mu   sync.Mutex
+	sess *multiSession
+}
+
+// acquire implements sessionSource.
+func (t *proxyTarget) acquire(ctx context.Context) (*multiSession, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.sess == nil || !t.sess.usable() {
+		var opts []targets.Option
+		if t.candidate.hostId != "" {
+			opts = append(opts, targets.WithHostId(t.candidate.hostId))
+		}
+		sess, sa, err := authorizeSession(ctx, t.cmd.targetClient, t.candidate.targetId, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if t.sess != nil {
+			t.sess.retire()
+		}
+		t.sess = sess
+		t.outputSessionInfo(sa)
+	}
+	t.sess.active.Inc()
+	return t.sess, nil
+}
+
+// retire implements sessionSource. It is a no-op if sess has already been
+// replaced.
+func (t *proxyTarget) retire(sess *multiSession) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.sess == sess {
+		sess.retire()
+	}
+}
+
+// close retires the current session, which tears it down once its
+// connections are done.
+func (t *proxyTarget) close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.sess != nil {
+		t.sess.retire()
+	}
+}
+
+func (t *proxyTarget) outputSessionInfo(sa *targets.SessionAuthorization) {
+	info := TargetSessionInfo{
+		Name:    t.requested,
+		Network: "tcp",
+		SessionInfo: SessionInfo{
+			Protocol:        sa.Type,
+			Address:         t.cmd.listenerAddr.IP.String(),
+			Port:            t.cmd.listenerAddr.Port,
+			Expiration:      t.sess.expiration,
+			ConnectionLimit: t.sess.connsLeft.Load(),
+			SessionId:       t.sess.id,
+			Credentials:     sa.Credentials,
+		},
+	}
+	switch base.Format(t.cmd.UI) {
+	case "table":
+		t.cmd.UI.Output(generateTargetSessionInfoTableOutput(info))
+	case "json":
+		out, err := json.Marshal(&info)
+		if err != nil {
+			t.cmd.PrintCliError(fmt.Errorf("error marshaling session information: %w", err))
+			return
+		}
+		t.cmd.UI.Output(string(out))
+	}
+}
+
+// proxyCandidate is a target, and optionally one of its hosts, that a
+// requested hostname can be proxied to.
+type proxyCandidate struct {
+	targetId string
+	hostId   string
+	// port is the target's default port, zero if it has none.
+	port int
+}
+
+// checkPort returns an error if the candidate's target has a default port
+// other than the requested one, as sessions cannot be authorized for any
+// other port.
+func (c proxyCandidate) checkPort(host string, port int) error {
+	if c.port != 0 && c.port != port {
+		return fmt.Errorf("%w: %s is %s which only accepts port %d", errPortMismatch, net.JoinHostPort(host, strconv.Itoa(port)), c.targetId, c.port)
+	}
+	return nil
+}
+
+// targetIndex maps the hostnames a client may request to the targets they
+// identify.
+type targetIndex struct {
+	// ids maps lower cased target IDs to targets, as clients such as browsers
+	// lower case hostnames.
+	ids map[string]proxyCandidate
+	// names maps lower cased target names to targets.
+	names map[string][]proxyCandidate
+	// addresses maps lower cased target and host addresses to targets.
+	addresses map[string][]proxyCandidate
+	// builtAt is when the targets and hosts were read.
+	builtAt time.Time
+}
+
+func newTargetIndex() *targetIndex {
+	return &targetIndex{
+		ids:       make(map[string]proxyCandidate),
+		names:     make(map[string][]proxyCandidate),
+		addresses: make(map[string][]proxyCandidate),
+	}
+}
+
+func (idx *targetIndex) add(m map[string][]proxyCandidate, key string, c proxyCandidate) {
+	key = strings.ToLower(key)
+	for _, existing := range m[key] {
+		if existing == c {
+			return
+		}
+	}
+	m[key] = append(m[key], c)
+}
+
+// lookup resolves a requested host and port to a target. Target IDs and
+// names that match a single target identify it, while names and addresses
+// that match several targets are narrowed down to those whose default port is
+// the requested port. A target with a default port is only returned for that
+// port.
+func (idx *targetIndex) lookup(host string, port int) (proxyCandidate, error) {
+	key := strings.ToLower(strings.TrimSuffix(host, "."))
+	if globals.ResourceTypeFromPrefix(key) == resource.Target {
+		if c, ok := idx.ids[key]; ok {
+			return c, c.checkPort(host, port)
+		}
+		return proxyCandidate{}, fmt.Errorf("%w: %s", errNoTargetForHost, host)
+	}
+	if cs := idx.names[key]; len(cs) > 0 {
+		if len(cs) == 1 {
+			return cs[0], cs[0].checkPort(host, port)
+		}
+		return pickCandidate(host, port, cs)
+	}
+	if cs := idx.addresses[key]; len(cs) > 0 {
+		return pickCandidate(host, port, cs)
+	}
+	return proxyCandidate{}, fmt.Errorf("%w: %s", errNoTargetForHost, host)
+}
+
+// pickCandidate returns the only candidate whose port matches port, or failing
+// that the only candidate without a default port.
+func pickCandidate(host string, port int, cs []proxyCandidate) (proxyCandidate, error) {
+	for _, want := range []int{port, 0} {
+		var matches []proxyCandidate
+		for _, c := range cs {
+			if c.port == want {
+				matches = append(matches, c)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return matches[0], nil
+		default:
+			ids := make([]string, 0, len(matches))
+			for _, m := range matches {
+				ids = append(ids, m.targetId)
+			}
+			return proxyCandidate{}, fmt.Errorf("%w: %s matches %s", errAmbiguousHost, net.JoinHostPort(host, strconv.Itoa(port)), strings.Join(ids, ", "))
+		}
+	}
+	return proxyCandidate{}, fmt.Errorf("%w: no target for %s accepts port %d", errPortMismatch, host, port)
+}
+
+// targetResolver resolves requested hostnames to targets using an index of
+// the targets, and their hosts, in a scope and its children.
+type targetResolver struct {
+	// build reads the targets and hosts into a new index.
+	build func(context.Context) (*targetIndex, error)
+
+	// builds ensures a single index is built at a time, shared by every
+	// caller that needs it.
+	builds singleflight.Group
+
+	mu    sync.Mutex
+	index *targetIndex
+}
+
+// resolve returns the target host and port should be proxied to, rebuilding
+// the index if it is stale or the host is not found in it.
+func (r *targetResolver) resolve(ctx context.Context, host string, port int) (proxyCandidate, error) {
+	r.mu.Lock()
+	idx := r.index
+	r.mu.Unlock()
+
+	var err error
+	if idx == nil || time.Since(idx.builtAt) > proxyIndexMaxAge {
+		if idx, err = r.rebuild(ctx); err != nil {
+			return proxyCandidate{}, err
+		}
+	}
+	c, err := idx.lookup(host, port)
+	if (errors.Is(err, errNoTargetForHost) || errors.Is(err, errPortMismatch)) && time.Since(idx.builtAt) > proxyIndexMinRefreshInterval {
+		if idx, err = r.rebuild(ctx); err != nil {
+			return proxyCandidate{}, err
+		}
+		c, err = idx.lookup(host, port)
+	}
+	return c, err
+}
+
+func (r *targetResolver) refresh(ctx context.Context) error {
+	_, err := r.rebuild(ctx)
+	return err
+}
+
+// rebuild builds a new index without holding r.mu, so lookups against the
+// current index are not blocked while targets and hosts are read, and swaps
+// it in. Callers that ask for a rebuild while one is running share its
+// result.
+func (r *targetResolver) rebuild(ctx context.Context) (*targetIndex, error) {
+	v, err, _ := r.builds.Do("", func() (any, error) {
+		idx, err := r.build(ctx)
+		if err != nil {
+			return nil, err
+		}
+		r.mu.Lock()
+		r.index = idx
+		r.mu.Unlock()
+		return idx, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*targetIndex), nil
+}
+
+// buildTargetIndex reads the targets in a scope and its children, and their
+// hosts, into a new index. Host sets and hosts that cannot be read are
+// skipped, as the caller may be allowed to connect to a target without being
+// allowed to read its hosts.
+func buildTargetIndex(ctx context.Context, client *api.Client, scopeId string) (*targetIndex, error) {
+	builtAt := time.Now()
+	tl, err := targets.NewClient(client).List(ctx, scopeId, targets.WithRecursive(true))
+	if err != nil {
+		return nil, err
+	}
+	hsClient := hostsets.NewClient(client)
+	hClient := hosts.NewClient(client)
+	hostSetHosts := make(map[string][]string)
+	hostAddrs := make(map[string][]string)
+
+	idx := newTargetIndex()
+	idx.builtAt = builtAt
+	for _, t := range tl.GetItems() {
+		c := proxyCandidate{targetId: t.Id, port: targetDefaultPort(t)}
+		idx.ids[strings.ToLower(t.Id)] = c
+		if t.Name != "" {
+			idx.add(idx.names, t.Name, c)
+		}
+		if t.Address != "" {
+			idx.add(idx.addresses, t.Address, c)
+		}
+		for _, hsId := range t.HostSourceIds {
+			hostIds, ok := hostSetHosts[hsId]
+			if !ok {
+				if hsr, err := hsClient.Read(ctx, hsId); err == nil {
+					hostIds = hsr.GetItem().HostIds
+				}
+				hostSetHosts[hsId] = hostIds
+			}
+			for _, hId := range hostIds {
+				addrs, ok := hostAddrs[hId]
+				if !ok {
+					if hr, err := hClient.Read(ctx, hId); err == nil {
+						addrs = hostAddresses(hr.GetItem())
+					}
+					hostAddrs[hId] = addrs
+				}
+				for _, a := range addrs {
+					idx.add(idx.addresses, a, proxyCandidate{targetId: t.Id, hostId: hId, port: c.port})
+				}
+			}
+		}
+	}
+	return idx, nil
+}
+
+// targetDefaultPort returns the default port of a tcp or ssh target, or zero
+// if it has none.
+func targetDefaultPort(t *targets.Target) int {
+	switch t.Type {
+	case "tcp":
+		if attrs, err := t.GetTcpTargetAttributes(); err == nil {
+			return int(attrs.DefaultPort)
+		}
+	case "ssh":
+		if attrs, err := t.GetSshTargetAttributes(); err == nil {
+			return int(attrs.DefaultPort)
+		}
+	}
+	return 0
+}
+
+// hostAddresses returns every address a host can be reached at.
+func hostAddresses(h *hosts.Host) []string {
+	var addrs []string
+	if h.Type == "static" {
+		if attrs, err := h.GetStaticHostAttributes(); err == nil && attrs.Address != "" {
+			addrs = append(addrs, attrs.Address)
+		}
+	}
+	addrs = append(addrs, h.IpAddresses...)
+	addrs = append(addrs, h.DnsNames...)
+	return addrs
+}
+
+// bufferedConn is a net.Conn whose reads are served from r, which buffers
+// the underlying connection.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+// socks5Error rejects a SOCKS5 request with the given reply code.
+type socks5Error struct {
+	code byte
+	msg  string
+}
+
+func (e *socks5Error) Error() string {
+	return e.msg
+}
+
+// readSocks5Request performs the SOCKS5 method negotiation, accepting only
+// unauthenticated clients, and reads the client's CONNECT request.
+func readSocks5Request(rw io.ReadWriter) (string, int, error) {
+	hdr := make([]byte, 2)
+	if _, err := io.ReadFull(rw, hdr); err != nil {
+		return "", 0, err
+	}
+	if hdr[0] != socks5Version {
+		return "", 0, fmt.Errorf("unsupported SOCKS version %d", hdr[0])
+	}
+	methods := make([]byte, hdr[1])
+	if _, err := io.ReadFull(rw, methods); err != nil {
+		return "", 0, err
+	}
+	if !bytes.Contains(methods, []byte{socks5NoAuth}) {
+		if _, err := rw.Write([]byte{socks5Version, socks5NoAcceptableMethods}); err != nil {
+			return "", 0, err
+		}
+		return "", 0, errSocks5NoAcceptableMethods
+	}
+	if _, err := rw.Write([]byte{socks5Version, socks5NoAuth}); err != nil {
+		return "", 0, err
+	}
+
+	req := make([]byte, 4)
+	if _, err := io.ReadFull(rw, req); err != nil {
+		return "", 0, err
+	}
+	if req[0] != socks5Version {
+		return "", 0, fmt.Errorf("unsupported SOCKS version %d", req[0])
+	}
+	if req[1] != socks5CmdConnect {
+		return "", 0, &socks5Error{code: socks5CommandNotSupported, msg: fmt.Sprintf("unsupported SOCKS command %d", req[1])}
+	}
+
+	var host string
+	switch req[3] {
+	case socks5AtypIPv4, socks5AtypIPv6:
+		ip := make(net.IP, net.IPv4len)
+		if req[3] == socks5AtypIPv6 {
+			ip = make(net.IP, net.IPv6len)
+		}
+		if _, err := io.ReadFull(rw, ip); err != nil {
+			return "", 0, err
+		}
+		host = ip.String()
+	case socks5AtypDomain:
+		l := make([]byte, 1)
+		if _, err := io.ReadFull(rw, l); err != nil {
+			return "", 0, err
+		}
+		name := make([]byte, l[0])
+		if _, err := io.ReadFull(rw, name); err != nil {
+			return "", 0, err
+		}
+		host = string(name)
+	default:
+		return "", 0, &socks5Error{code: socks5AddressNotSupported, msg: fmt.Sprintf("unsupported SOCKS address type %d", req[3])}
+	}
+
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(rw, port); err != nil {
+		return "", 0, err
+	}
+	return host, int(binary.BigEndian.Uint16(port)), nil
+}
+
+// writeSocks5Reply writes a SOCKS5 reply with the given code. The bound
+// address is not meaningful for a proxied connection so it is left empty.
+func writeSocks5Reply(w io.Writer, code byte) error {
+	_, err := w.Write([]byte{socks5Version, code, 0x00, socks5AtypIPv4, 0, 0, 0, 0, 0, 0})
+	return err
+}
+
+// socks5ReplyCode returns the SOCKS5 reply code for the result of handling a
+// request.
+func socks5ReplyCode(err error) byte {
+	var serr *socks5Error
+	switch {
+	case err == nil:
+		return socks5Succeeded
+	case errors.As(err, &serr):
+		return serr.code
+	case errors.Is(err, errNoTargetForHost), errors.Is(err, errAmbiguousHost):
+		return socks5HostUnreachable
+	case errors.Is(err, errPortMismatch):
+		return socks5ConnectionRefused
+	case errors.Is(err, api.ErrPermissionDenied), errors.Is(err, api.ErrUnauthorized):
+		return socks5NotAllowed
+	default:
+		return socks5GeneralFailure
+	}
+}
+
+// readConnectRequest reads an HTTP CONNECT request from br.
+func readConnectRequest(br *bufio.Reader) (string, int, error) {
+	req, err := http.ReadRequest(br)
+	if err != nil {
+		return "", 0, err
+	}
+	if req.Method != http.MethodConnect {
+		return "", 0, errNotConnectMethod
+	}
+	host, portStr, err := net.SplitHostPort(req.Host)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid CONNECT address %q: %w", req.Host, err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port <= 0 || port > 65535 {
+		return "", 0, fmt.Errorf("invalid CONNECT port %q", portStr)
+	}
+	return host, port, nil
+}
+
+// writeConnectResponse writes the response to an HTTP CONNECT request. Any
+// status other than 200 closes the connection.
+func writeConnectResponse(w io.Writer, code int) error {
+	var err error
+	switch code {
+	case http.StatusOK:
+		_, err = fmt.Fprintf(w, "HTTP/1.1 %d %s\r\n\r\n", code, http.StatusText(code))
+	default:
+		_, err = fmt.Fprintf(w, "HTTP/1.1 %d %s\r\nContent-Length: 0\r\nConnection: close\r\n\r\n", code, http.StatusText(code))
+	}
+	return err
+}
+
+// connectStatusCode returns the HTTP status for the result of handling a
+// CONNECT request.
+func connectStatusCode(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, errNotConnectMethod):
+		return http.StatusMethodNotAllowed
+	case errors.Is(err, errNoTargetForHost):
+		return http.StatusNotFound
+	case errors.Is(err, errAmbiguousHost):
+		return http.StatusConflict
+	case errors.Is(err, errPortMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, api.ErrPermissionDenied), errors.Is(err, api.ErrUnauthorized):
+		return http.StatusForbidden
+	default:
+		var perr *http.ProtocolError
+		if errors.As(err, &perr) {
+			return http.StatusBadRequest
+		}
+		return http.StatusBadGateway
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package connect
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testReadWriter reads from in and records everything written to out.
+type testReadWriter struct {
+	in  io.Reader
+	out bytes.Buffer
+}
+
+func (rw *testReadWriter) Read(p []byte) (int, error)  { return rw.in.Read(p) }
+func (rw *testReadWriter) Write(p []byte) (int, error) { return rw.out.Write(p) }
+
+func TestReadSocks5Request(t *testing.T) {
+	t.Parallel()
+
+	greeting := []byte{socks5Version, 2, 0x02, socks5NoAuth}
+	tests := []struct {
+		name     string
+		in       []byte
+		wantHost string
+		wantPort int
+		wantOut  []byte
+		wantCode byte
+		wantErr  error
+	}{
+		{
+			name:     "domain",
+			in:       append(greeting, socks5Version, socks5CmdConnect, 0, socks5AtypDomain, 5, 'd', 'b', '.', 'i', 'o', 0x15, 0x38),
+			wantHost: "db.io",
+			wantPort: 5432,
+			wantOut:  []byte{socks5Version, socks5NoAuth},
+		},
+		{
+			name:     "ipv4",
+			in:       append(greeting, socks5Version, socks5CmdConnect, 0, socks5AtypIPv4, 10, 0, 0, 5, 0x00, 0x16),
+			wantHost: "10.0.0.5",
+			wantPort: 22,
+			wantOut:  []byte{socks5Version, socks5NoAuth},
+		},
+		{
+			name:     "ipv6",
+			in:       append(greeting, socks5Version, socks5CmdConnect, 0, socks5AtypIPv6, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0x01, 0xbb),
+			wantHost: "::1",
+			wantPort: 443,
+			wantOut:  []byte{socks5Version, socks5NoAuth},
+		},
+		{
+			name:    "no-acceptable-methods",
+			in:      []byte{socks5Version, 1, 0x02},
+			wantOut: []byte{socks5Version, socks5NoAcceptableMethods},
+			wantErr: errSocks5NoAcceptableMethods,
+		},
+		{
+			name:     "bind-not-supported",
+			in:       append(greeting, socks5Version, 0x02, 0, socks5AtypIPv4, 10, 0, 0, 5, 0x00, 0x16),
+			wantOut:  []byte{socks5Version, socks5NoAuth},
+			wantCode: socks5CommandNotSupported,
+		},
+		{
+			name:     "bad-address-type",
+			in:       append(greeting, socks5Version, socks5CmdConnect, 0, 0x09),
+			wantOut:  []byte{socks5Version, socks5NoAuth},
+			wantCode: socks5AddressNotSupported,
+		},
+		{
+			name:    "truncated",
+			in:      append(greeting, socks5Version, socks5CmdConnect, 0, socks5AtypDomain, 5, 'd'),
+			wantOut: []byte{socks5Version, socks5NoAuth},
+			wantErr: io.ErrUnexpectedEOF,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rw := &testReadWriter{in: bytes.NewReader(tt.in)}
+			host, port, err := readSocks5Request(rw)
+			assert.Equal(t, tt.wantOut, rw.out.Bytes())
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			case tt.wantCode != 0:
+				require.Error(t, err)
+				assert.Equal(t, tt.wantCode, socks5ReplyCode(err))
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantHost, host)
+				assert.Equal(t, tt.wantPort, port)
+			}
+		})
+	}
+}
+
+func TestReadConnectRequest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("connect", func(t *testing.T) {
+		// Data sent right after the request must still be readable.
+		br := bufio.NewReader(strings.NewReader("CONNECT db.internal:5432 HTTP/1.1\r\nHost: db.internal:5432\r\n\r\nhello"))
+		host, port, err := readConnectRequest(br)
+		require.NoError(t, err)
+		assert.Equal(t, "db.internal", host)
+		assert.Equal(t, 5432, port)
+		rest, err := io.ReadAll(br)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(rest))
+	})
+
+	t.Run("ipv6", func(t *testing.T) {
+		br := bufio.NewReader(strings.NewReader("CONNECT [::1]:443 HTTP/1.1\r\n\r\n"))
+		host, port, err := readConnectRequest(br)
+		require.NoError(t, err)
+		assert.Equal(t, "::1", host)
+		assert.Equal(t, 443, port)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		br := bufio.NewReader(strings.NewReader("GET http://db.internal/ HTTP/1.1\r\nHost: db.internal\r\n\r\n"))
+		_, _, err := readConnectRequest(br)
+		require.ErrorIs(t, err, errNotConnectMethod)
+		assert.Equal(t, http.StatusMethodNotAllowed, connectStatusCode(err))
+	})
+
+	t.Run("missing-port", func(t *testing.T) {
+		br := bufio.NewReader(strings.NewReader("CONNECT db.internal HTTP/1.1\r\n\r\n"))
+		_, _, err := readConnectRequest(br)
+		require.Error(t, err)
+	})
+}
+
+func TestWriteConnectResponse(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	require.NoError(t, writeConnectResponse(&buf, http.StatusOK))
+	resp, err := http.ReadResponse(bufio.NewReader(&buf), nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	buf.Reset()
+	require.NoError(t, writeConnectResponse(&buf, connectStatusCode(errNoTargetForHost)))
+	resp, err = http.ReadResponse(bufio.NewReader(&buf), nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.True(t, resp.Close)
+}
+
+func TestTargetIndex_Lookup(t *testing.T) {
+	t.Parallel()
+
+	idx := newTargetIndex()
+	db := proxyCandidate{targetId: "ttcp_DbTarget1", port: 5432}
+	ssh := proxyCandidate{targetId: "ttcp_SshTarget", port: 22}
+	dbHost := proxyCandidate{targetId: "ttcp_DbTarget1", hostId: "hst_1234567890", port: 5432}
+	sshHost := proxyCandidate{targetId: "ttcp_SshTarget", hostId: "hst_1234567890", port: 22}
+	anyPort := proxyCandidate{targetId: "ttcp_NoDefault", hostId: "hst_0987654321"}
+	noPort := proxyCandidate{targetId: "ttcp_NoDefault"}
+	idx.ids["ttcp_dbtarget1"] = db
+	idx.ids["ttcp_nodefault"] = noPort
+	idx.add(idx.names, "Postgres", db)
+	idx.add(idx.names, "dup", db)
+	idx.add(idx.names, "dup", ssh)
+	idx.add(idx.addresses, "10.0.0.5", dbHost)
+	idx.add(idx.addresses, "10.0.0.5", sshHost)
+	idx.add(idx.addresses, "10.0.0.5", sshHost)
+	idx.add(idx.addresses, "web.internal", anyPort)
+
+	tests := []struct {
+		name    string
+		host    string
+		port    int
+		want    proxyCandidate
+		wantErr error
+	}{
+		{name: "id", host: "ttcp_DbTarget1", port: 5432, want: db},
+		{name: "lower-cased-id", host: "ttcp_dbtarget1", port: 5432, want: db},
+		{name: "id-wrong-port", host: "ttcp_DbTarget1", port: 22, wantErr: errPortMismatch},
+		{name: "id-no-default-port", host: "ttcp_NoDefault", port: 8080, want: noPort},
+		{name: "unknown-id", host: "tssh_1234567890", port: 1, wantErr: errNoTargetForHost},
+		{name: "name", host: "postgres.", port: 5432, want: db},
+		{name: "name-wrong-port", host: "postgres", port: 80, wantErr: errPortMismatch},
+		{name: "duplicate-name-by-port", host: "dup", port: 22, want: ssh},
+		{name: "duplicate-name-wrong-port", host: "dup", port: 80, wantErr: errPortMismatch},
+		{name: "address-by-port", host: "10.0.0.5", port: 5432, want: dbHost},
+		{name: "address-other-port", host: "10.0.0.5", port: 22, want: sshHost},
+		{name: "address-wrong-port", host: "10.0.0.5", port: 80, wantErr: errPortMismatch},
+		{name: "address-no-default-port", host: "WEB.internal", port: 8080, want: anyPort},
+		{name: "unknown", host: "example.com", port: 443, wantErr: errNoTargetForHost},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := idx.lookup(tt.host, tt.port)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("ambiguous", func(t *testing.T) {
+		amb := newTargetIndex()
+		amb.add(amb.addresses, "10.0.0.5", dbHost)
+		amb.add(amb.addresses, "10.0.0.5", proxyCandidate{targetId: "ttcp_Other", hostId: "hst_1234567890", port: 5432})
+		_, err := amb.lookup("10.0.0.5", 5432)
+		require.ErrorIs(t, err, errAmbiguousHost)
+		assert.Equal(t, byte(socks5HostUnreachable), socks5ReplyCode(err))
+		assert.Equal(t, http.StatusConflict, connectStatusCode(err))
+	})
+
+	t.Run("port-mismatch-codes", func(t *testing.T) {
+		_, err := idx.lookup("postgres", 80)
+		require.ErrorIs(t, err, errPortMismatch)
+		assert.Equal(t, byte(socks5ConnectionRefused), socks5ReplyCode(err))
+		assert.Equal(t, http.StatusBadRequest, connectStatusCode(err))
+	})
+}
+
+func TestTargetResolver_Resolve(t *testing.T) {
+	t.Parallel()
+
+	db := proxyCandidate{targetId: "ttcp_DbTarget1", port: 5432}
+	indexWith := func(names ...string) *targetIndex {
+		idx := newTargetIndex()
+		idx.builtAt = time.Now()
+		for _, n := range names {
+			idx.add(idx.names, n, db)
+		}
+		return idx
+	}
+
+	t.Run("concurrent-builds-are-shared", func(t *testing.T) {
+		var builds, inFlight, maxInFlight atomic.Int32
+		release := make(chan struct{})
+		r := &targetResolver{
+			build: func(context.Context) (*targetIndex, error) {
+				builds.Add(1)
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					m := maxInFlight.Load()
+					if n <= m || maxInFlight.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				<-release
+				return indexWith("postgres"), nil
+			},
+		}
+		const callers = 5
+		var done sync.WaitGroup
+		done.Add(callers)
+		for i := 0; i < callers; i++ {
+			go func() {
+				defer done.Done()
+				c, err := r.resolve(context.Background(), "postgres", 5432)
+				assert.NoError(t, err)
+				assert.Equal(t, db, c)
+			}()
+		}
+		// Hold the first build until it has started so the other callers
+		// find it running.
+		require.Eventually(t, func() bool { return builds.Load() > 0 }, 5*time.Second, time.Millisecond)
+		close(release)
+		done.Wait()
+		assert.Equal(t, int32(1), maxInFlight.Load())
+		r.mu.Lock()
+		assert.NotNil(t, r.index)
+		r.mu.Unlock()
+	})
+
+	t.Run("lookups-not-blocked-by-rebuild", func(t *testing.T) {
+		building := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+		r := &targetResolver{
+			build: func(context.Context) (*targetIndex, error) {
+				close(building)
+				<-release
+				return indexWith("postgres", "mysql"), nil
+			},
+		}
+		stale := indexWith("postgres")
+		stale.builtAt = time.Now().Add(-proxyIndexMinRefreshInterval - time.Second)
+		r.index = stale
+
+		// An unknown name on a stale enough index starts a rebuild, which
+		// blocks until released.
+		go func() { _, _ = r.resolve(context.Background(), "mysql", 5432) }()
+		<-building
+
+		// Names in the current index still resolve while it runs.
+		c, err := r.resolve(context.Background(), "postgres", 5432)
+		require.NoError(t, err)
+		assert.Equal(t, db, c)
+	})
+
+	t.Run("build-error", func(t *testing.T) {
+		buildErr := errors.New("list failed")
+		r := &targetResolver{
+			build: func(context.Context) (*targetIndex, error) {
+				return nil, buildErr
+			},
+		}
+		_, err := r.resolve(context.Background(), "postgres", 5432)
+		require.ErrorIs(t, err, buildErr)
+		assert.Nil(t, r.index)
+	})
+}
@@ -18,6 +18,7 @@ const (
 	privateKeyFlagName           = "private-key"
 	privateKeyPassphraseFlagName = "private-key-passphrase"
 	secretFlagName               = "secret"
+	certificateFlagName          = "certificate"
 )
 
 func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
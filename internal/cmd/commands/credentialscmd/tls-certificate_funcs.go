@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package credentialscmd
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api/credentials"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+)
+
+func init() {
+	extraTlsCertificateFlagsFunc = extraTlsCertificateFlagsFuncImpl
+	extraTlsCertificateActionsFlagsMapFunc = extraTlsCertificateActionsFlagsMapFuncImpl
+	extraTlsCertificateFlagsHandlingFunc = extraTlsCertificateFlagHandlingFuncImpl
+}
+
+type extraTlsCertificateCmdVars struct {
+	flagCertificate string
+	flagPrivateKey  string
+}
+
+func extraTlsCertificateActionsFlagsMapFuncImpl() map[string][]string {
+	flags := map[string][]string{
+		"create": {
+			certificateFlagName,
+			privateKeyFlagName,
+		},
+	}
+	flags["update"] = flags["create"]
+	return flags
+}
+
+func extraTlsCertificateFlagsFuncImpl(c *TlsCertificateCommand, set *base.FlagSets, _ *base.FlagSet) {
+	f := set.NewFlagSet("TLS Certificate Credential Options")
+
+	for _, name := range flagsTlsCertificateMap[c.Func] {
+		switch name {
+		case certificateFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   certificateFlagName,
+				Target: &c.flagCertificate,
+				Usage:  "The PEM encoded client certificate chain associated with the credential. This can refer to a file on disk (file://) from which the value will be read or an env var (env://) from which the value will be read.",
+			})
+		case privateKeyFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   privateKeyFlagName,
+				Target: &c.flagPrivateKey,
+				Usage:  "The PEM encoded private key of the client certificate. This can refer to a file on disk (file://) from which the value will be read or an env var (env://) from which the value will be read.",
+			})
+		}
+	}
+}
+
+func extraTlsCertificateFlagHandlingFuncImpl(c *TlsCertificateCommand, _ *base.FlagSets, opts *[]credentials.Option) bool {
+	// The certificate and private key must form a valid pair, so if neither is
+	// set (e.g. just a name update) there is nothing to check
+	if c.flagCertificate == "" && c.flagPrivateKey == "" {
+		return true
+	}
+	if c.flagCertificate == "" || c.flagPrivateKey == "" {
+		c.UI.Error("Certificate and private key flags must be used together")
+		return false
+	}
+
+	certificate, err := parseutil.MustParsePath(c.flagCertificate)
+	switch {
+	case err == nil:
+	case errors.Is(err, parseutil.ErrNotParsed):
+		c.UI.Error("Certificate flag must be used with env:// or file:// syntax")
+		return false
+	default:
+		c.UI.Error(fmt.Sprintf("Error parsing certificate flag: %v", err))
+		return false
+	}
+
+	privateKey, err := parseutil.MustParsePath(c.flagPrivateKey)
+	switch {
+	case err == nil:
+	case errors.Is(err, parseutil.ErrNotParsed):
+		c.UI.Error("Private key flag must be used with env:// or file:// syntax")
+		return false
+	default:
+		c.UI.Error(fmt.Sprintf("Error parsing private key flag: %v", err))
+		return false
+	}
+
+	if _, err := tls.X509KeyPair([]byte(certificate), []byte(privateKey)); err != nil {
+		c.UI.Error(fmt.Sprintf("Error parsing certificate and private key: %v", err))
+		return false
+	}
+
+	*opts = append(*opts,
+		credentials.WithTlsCertificateCredentialCertificate(certificate),
+		credentials.WithTlsCertificateCredentialPrivateKey(privateKey),
+	)
+
+	return true
+}
+
+func (c *TlsCertificateCommand) extraTlsCertificateHelpFunc(_ map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary credentials create tls-certificate -credential-store-id [options] [args]",
+			"",
+			"  Create a TLS certificate credential. Example:",
+			"",
+			`    $ boundary credentials create tls-certificate -credential-store-id csst_1234567890 -certificate file:///home/user/client.pem -private-key file:///home/user/client-key.pem`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary credentials update tls-certificate [options] [args]",
+			"",
+			"  Update a TLS certificate credential given its ID. Example:",
+			"",
+			`    $ boundary credentials update tls-certificate -id credtls_1234567890 -name devops -description "For DevOps usage"`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
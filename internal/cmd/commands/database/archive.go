@@ -0,0 +1,272 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package database
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-kms-wrapping/v2/aead"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// archiveFormatVersion is the version of the layout of backup archives.
+const archiveFormatVersion = 1
+
+const (
+	manifestName = "manifest.json"
+	tablesPrefix = "tables/"
+	tableSuffix  = ".jsonl"
+)
+
+// manifest describes the contents of a backup archive. It is the first entry
+// of the archive so that an archive can be validated before any of its data is
+// loaded.
+type manifest struct {
+	FormatVersion   int              `json:"format_version"`
+	CreateTime      time.Time        `json:"create_time"`
+	BoundaryVersion string           `json:"boundary_version,omitempty"`
+	Schema          []editionVersion `json:"schema"`
+	RootKeys        []*rootKey       `json:"root_keys"`
+	DataKeys        []*dataKey       `json:"data_keys"`
+	Tables          []*tableInfo     `json:"tables"`
+	Sequences       []*sequence      `json:"sequences,omitempty"`
+}
+
+// editionVersion is the schema version of an edition of the database.
+type editionVersion struct {
+	Edition string `json:"edition"`
+	Version int    `json:"version"`
+}
+
+// rootKey is the root key of a scope.
+type rootKey struct {
+	Id       string        `json:"id"`
+	ScopeId  string        `json:"scope_id"`
+	Versions []*keyVersion `json:"versions"`
+}
+
+// dataKey is a data key of a root key.
+type dataKey struct {
+	Id        string        `json:"id"`
+	RootKeyId string        `json:"root_key_id"`
+	Purpose   string        `json:"purpose"`
+	Versions  []*keyVersion `json:"versions"`
+}
+
+// keyVersion is a version of a root or a data key. Key is wrapped by the root
+// KMS for root key versions, whose KmsKeyId is the ID of the root KMS key that
+// wrapped it, and by RootKeyVersionId for data key versions.
+type keyVersion struct {
+	Id               string `json:"id"`
+	Version          uint32 `json:"version"`
+	KmsKeyId         string `json:"kms_key_id,omitempty"`
+	RootKeyVersionId string `json:"root_key_version_id,omitempty"`
+	Key              []byte `json:"key"`
+}
+
+// tableInfo is a table in the archive.
+type tableInfo struct {
+	Name string `json:"name"`
+	Rows int64  `json:"rows"`
+}
+
+// sequence is the last value returned by a sequence.
+type sequence struct {
+	Name  string `json:"name"`
+	Value int64  `json:"value"`
+}
+
+// kmsKeyIds returns the IDs of the root KMS keys that wrapped the root keys.
+func (m *manifest) kmsKeyIds() []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, rk := range m.RootKeys {
+		for _, v := range rk.Versions {
+			if v.KmsKeyId != "" && !seen[v.KmsKeyId] {
+				seen[v.KmsKeyId] = true
+				ids = append(ids, v.KmsKeyId)
+			}
+		}
+	}
+	return ids
+}
+
+// wrappingKeyId returns the ID of the key that wrapped a key, or an empty
+// string if it cannot be determined.
+func wrappingKeyId(wrapped []byte) string {
+	var blob wrapping.BlobInfo
+	if err := proto.Unmarshal(wrapped, &blob); err != nil {
+		return ""
+	}
+	return blob.GetKeyInfo().GetKeyId()
+}
+
+// wrappedKey is a key version as stored in the database.
+type wrappedKey struct {
+	Key   []byte `wrapping:"pt,key_data"`
+	CtKey []byte `wrapping:"ct,key_data"`
+}
+
+// verifyKeys checks that every root key version in the manifest can be
+// unwrapped by root and that every data key version can be unwrapped by its
+// root key version.
+func verifyKeys(ctx context.Context, root wrapping.Wrapper, m *manifest) error {
+	rootVersions := make(map[string][]byte)
+	for _, rk := range m.RootKeys {
+		for _, v := range rk.Versions {
+			k := &wrappedKey{CtKey: v.Key}
+			if err := structwrapping.UnwrapStruct(ctx, root, k, nil); err != nil {
+				return fmt.Errorf("unable to unwrap version %d of the root key of scope %q with the root KMS: %w", v.Version, rk.ScopeId, err)
+			}
+			rootVersions[v.Id] = k.Key
+		}
+	}
+	for _, dk := range m.DataKeys {
+		for _, v := range dk.Versions {
+			rootKey, ok := rootVersions[v.RootKeyVersionId]
+			if !ok {
+				return fmt.Errorf("version %d of the %s data key %q is wrapped by root key version %q, which is not in the backup", v.Version, dk.Purpose, dk.Id, v.RootKeyVersionId)
+			}
+			w := aead.NewWrapper()
+			if _, err := w.SetConfig(ctx, wrapping.WithKeyId(v.RootKeyVersionId)); err != nil {
+				return fmt.Errorf("unable to configure root key version %q: %w", v.RootKeyVersionId, err)
+			}
+			if err := w.SetAesGcmKeyBytes(rootKey); err != nil {
+				return fmt.Errorf("unable to configure root key version %q: %w", v.RootKeyVersionId, err)
+			}
+			if err := structwrapping.UnwrapStruct(ctx, w, &wrappedKey{CtKey: v.Key}, nil); err != nil {
+				return fmt.Errorf("unable to unwrap version %d of the %s data key %q: %w", v.Version, dk.Purpose, dk.Id, err)
+			}
+		}
+	}
+	return nil
+}
+
+// writeArchive writes a gzipped tar archive of the manifest followed by the
+// rows of each of its tables, read from the files in dir written by
+// dumpDatabase.
+func writeArchive(w io.Writer, m *manifest, dir string) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	js, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode manifest: %w", err)
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    manifestName,
+		Mode:    0o600,
+		Size:    int64(len(js)),
+		ModTime: m.CreateTime,
+	}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(js); err != nil {
+		return err
+	}
+
+	for _, t := range m.Tables {
+		if err := writeArchiveFile(tw, filepath.Join(dir, t.Name+tableSuffix), tablesPrefix+t.Name+tableSuffix, m.CreateTime); err != nil {
+			return fmt.Errorf("unable to write table %q: %w", t.Name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func writeArchiveFile(tw *tar.Writer, path, name string, modTime time.Time) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    fi.Size(),
+		ModTime: modTime,
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// archiveReader reads an archive written by writeArchive.
+type archiveReader struct {
+	gr       *gzip.Reader
+	tr       *tar.Reader
+	manifest *manifest
+}
+
+// readArchive reads and validates the manifest of an archive. The rows of its
+// tables are then read with next.
+func readArchive(r io.Reader) (*archiveReader, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	a := &archiveReader{
+		gr: gr,
+		tr: tar.NewReader(gr),
+	}
+	hdr, err := a.tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	if hdr.Name != manifestName {
+		return nil, fmt.Errorf("not a backup archive: the first entry is %q rather than %q", hdr.Name, manifestName)
+	}
+	m := new(manifest)
+	if err := json.NewDecoder(a.tr).Decode(m); err != nil {
+		return nil, fmt.Errorf("unable to decode manifest: %w", err)
+	}
+	if m.FormatVersion != archiveFormatVersion {
+		return nil, fmt.Errorf("unsupported backup archive format version %d; this version of Boundary supports version %d", m.FormatVersion, archiveFormatVersion)
+	}
+	a.manifest = m
+	return a, nil
+}
+
+// next returns the name of the next table in the archive and a reader of its
+// rows, one JSON object per line. It returns io.EOF once all tables have been
+// read.
+func (a *archiveReader) next() (string, io.Reader, error) {
+	hdr, err := a.tr.Next()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return "", nil, io.EOF
+		}
+		return "", nil, fmt.Errorf("unable to read backup archive: %w", err)
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(hdr.Name, tablesPrefix), tableSuffix)
+	if name == hdr.Name || name == "" || strings.Contains(name, "/") {
+		return "", nil, fmt.Errorf("unexpected entry %q in backup archive", hdr.Name)
+	}
+	return name, a.tr, nil
+}
+
+// Close closes the archive. It does not close the underlying reader.
+func (a *archiveReader) Close() error {
+	return a.gr.Close()
+}
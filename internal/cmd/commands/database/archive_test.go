@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package database
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/schema"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-kms-wrapping/v2/aead"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testManifest returns a manifest with a root key wrapped by root and a data
+// key wrapped by the root key.
+func testManifest(t *testing.T, root wrapping.Wrapper) *manifest {
+	t.Helper()
+	ctx := context.Background()
+
+	wrap := func(w wrapping.Wrapper) ([]byte, []byte) {
+		k := &wrappedKey{Key: make([]byte, 32)}
+		_, err := rand.Read(k.Key)
+		require.NoError(t, err)
+		require.NoError(t, structwrapping.WrapStruct(ctx, w, k, nil))
+		return k.Key, k.CtKey
+	}
+	rootKeyBytes, rootCt := wrap(root)
+	rootVersion := aead.NewWrapper()
+	_, err := rootVersion.SetConfig(ctx, wrapping.WithKeyId("krkv_1234567890"))
+	require.NoError(t, err)
+	require.NoError(t, rootVersion.SetAesGcmKeyBytes(rootKeyBytes))
+	_, dataCt := wrap(rootVersion)
+
+	rootKeyId, err := root.KeyId(ctx)
+	require.NoError(t, err)
+	return &manifest{
+		FormatVersion: archiveFormatVersion,
+		CreateTime:    time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC),
+		Schema:        []editionVersion{{Edition: "oss", Version: 66001}},
+		RootKeys: []*rootKey{{
+			Id:       "krk_1234567890",
+			ScopeId:  "global",
+			Versions: []*keyVersion{{Id: "krkv_1234567890", Version: 1, KmsKeyId: rootKeyId, Key: rootCt}},
+		}},
+		DataKeys: []*dataKey{{
+			Id:        "kdk_1234567890",
+			RootKeyId: "krk_1234567890",
+			Purpose:   "database",
+			Versions:  []*keyVersion{{Id: "kdkv_1234567890", Version: 1, RootKeyVersionId: "krkv_1234567890", Key: dataCt}},
+		}},
+		Tables: []*tableInfo{
+			{Name: "iam_scope", Rows: 2},
+			{Name: "iam_user", Rows: 0},
+		},
+		Sequences: []*sequence{{Name: "oplog_entry_id_seq", Value: 42}},
+	}
+}
+
+func TestVerifyKeys(t *testing.T) {
+	ctx := context.Background()
+	root := db.TestWrapper(t)
+
+	t.Run("valid", func(t *testing.T) {
+		m := testManifest(t, root)
+		require.NoError(t, verifyKeys(ctx, root, m))
+		rootKeyId, err := root.KeyId(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, rootKeyId, wrappingKeyId(m.RootKeys[0].Versions[0].Key))
+		assert.Equal(t, []string{rootKeyId}, m.kmsKeyIds())
+	})
+	t.Run("wrong-root-kms", func(t *testing.T) {
+		m := testManifest(t, root)
+		err := verifyKeys(ctx, db.TestWrapper(t), m)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unable to unwrap version 1 of the root key of scope "global"`)
+	})
+	t.Run("missing-root-key-version", func(t *testing.T) {
+		m := testManifest(t, root)
+		m.DataKeys[0].Versions[0].RootKeyVersionId = "krkv_0987654321"
+		err := verifyKeys(ctx, root, m)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `root key version "krkv_0987654321", which is not in the backup`)
+	})
+	t.Run("corrupt-data-key", func(t *testing.T) {
+		m := testManifest(t, root)
+		other := testManifest(t, root)
+		m.DataKeys[0].Versions[0].Key = other.DataKeys[0].Versions[0].Key
+		err := verifyKeys(ctx, root, m)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unable to unwrap version 1 of the database data key "kdk_1234567890"`)
+	})
+}
+
+func TestArchive(t *testing.T) {
+	m := testManifest(t, db.TestWrapper(t))
+	dir := t.TempDir()
+	scopes := `{"public_id":"global","name":"Global"}` + "\n" + `{"public_id":"o_1234567890","name":"org"}` + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "iam_scope"+tableSuffix), []byte(scopes), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "iam_user"+tableSuffix), nil, 0o600))
+
+	var buf bytes.Buffer
+	require.NoError(t, writeArchive(&buf, m, dir))
+
+	a, err := readArchive(&buf)
+	require.NoError(t, err)
+	defer a.Close()
+	assert.Equal(t, m, a.manifest)
+
+	got := make(map[string]string)
+	for {
+		name, r, err := a.next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		got[name] = string(b)
+	}
+	assert.Equal(t, map[string]string{"iam_scope": scopes, "iam_user": ""}, got)
+}
+
+func TestReadArchive_Errors(t *testing.T) {
+	t.Run("not-gzip", func(t *testing.T) {
+		_, err := readArchive(bytes.NewReader([]byte("not an archive")))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a backup archive")
+	})
+	t.Run("unsupported-format", func(t *testing.T) {
+		m := testManifest(t, db.TestWrapper(t))
+		m.FormatVersion = archiveFormatVersion + 1
+		m.Tables = nil
+		var buf bytes.Buffer
+		require.NoError(t, writeArchive(&buf, m, t.TempDir()))
+		_, err := readArchive(&buf)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported backup archive format version 2")
+	})
+}
+
+func TestCheckSchemaVersions(t *testing.T) {
+	st := &schema.State{
+		Editions: []schema.EditionState{{Name: "oss", BinarySchemaVersion: 66001}},
+	}
+	tests := []struct {
+		name    string
+		schema  []editionVersion
+		wantErr string
+	}{
+		{
+			name:   "match",
+			schema: []editionVersion{{Edition: "oss", Version: 66001}},
+		},
+		{
+			name:    "older",
+			schema:  []editionVersion{{Edition: "oss", Version: 65001}},
+			wantErr: `The archive has version 65001 of the "oss" schema but this version of Boundary uses version 66001.`,
+		},
+		{
+			name:    "missing-edition",
+			wantErr: `The archive does not contain the "oss" schema edition.`,
+		},
+		{
+			name:    "unknown-edition",
+			schema:  []editionVersion{{Edition: "oss", Version: 66001}, {Edition: "ent", Version: 1}},
+			wantErr: `The archive contains the "ent" schema edition, which this version of Boundary does not support.`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSchemaVersions(&manifest{Schema: tt.schema}, st)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,464 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package database
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/db/schema"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/version"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+	"github.com/jackc/pgx/v4"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*BackupCommand)(nil)
+	_ cli.CommandAutocomplete = (*BackupCommand)(nil)
+)
+
+// schemaManagerTables are the tables of the schema manager. They are not
+// backed up since restoring runs the migrations, which create them.
+var schemaManagerTables = map[string]bool{
+	"boundary_schema_version": true,
+	"log_migration":           true,
+	"schema_migrations":       true,
+}
+
+type BackupCommand struct {
+	*base.Server
+
+	Config *config.Config
+
+	flagConfig    []string
+	flagConfigKms string
+	flagLogLevel  string
+	flagLogFormat string
+	flagFile      string
+}
+
+func (c *BackupCommand) Synopsis() string {
+	return "Back up Boundary's database to an archive"
+}
+
+func (c *BackupCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database backup [options]",
+		"",
+		"  Back up Boundary's database to an archive:",
+		"",
+		"    $ boundary database backup -config=/etc/boundary/controller.hcl -file=boundary-backup.tar.gz",
+		"",
+		"  The archive contains the data of every table as of a single point in time, the schema version of the database, and a manifest of the root and data key versions of each scope. The keys in the archive are wrapped, so restoring it requires the root KMS that wrapped them; the IDs of the root KMS keys used are reported when the backup completes. The database is not locked against writes while the backup is taken, but migrations cannot run until it completes.",
+		"",
+		"  Use \"boundary database restore\" to load an archive into an empty database.",
+	}) + c.Flags().Help()
+}
+
+func (c *BackupCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP)
+
+	f := set.NewFlagSet("Command options")
+
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file, which has some drawbacks; see the help output for "boundary config encrypt -h" for details.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-level",
+		Target:     &c.flagLogLevel,
+		EnvVar:     "BOUNDARY_LOG_LEVEL",
+		Completion: complete.PredictSet("trace", "debug", "info", "warn", "err"),
+		Usage: "Log verbosity level. Supported values (in order of more detail to less) are " +
+			"\"trace\", \"debug\", \"info\", \"warn\", and \"err\".",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-format",
+		Target:     &c.flagLogFormat,
+		Completion: complete.PredictSet("standard", "json"),
+		Usage:      `Log format. Supported values are "standard" and "json".`,
+	})
+
+	f = set.NewFlagSet("Backup options")
+
+	f.StringVar(&base.StringVar{
+		Name:       "file",
+		Target:     &c.flagFile,
+		Completion: complete.PredictFiles("*.tar.gz"),
+		Usage:      "The file to write the archive to. It must not already exist.",
+	})
+
+	return set
+}
+
+func (c *BackupCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *BackupCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *BackupCommand) Run(args []string) (retCode int) {
+	if result := c.ParseFlagsAndConfig(args); result > 0 {
+		return result
+	}
+
+	dialect := "postgres"
+
+	if err := c.SetupLogging(c.flagLogLevel, c.flagLogFormat, c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	serverName, err := os.Hostname()
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Unable to determine hostname: %w", err).Error())
+		return base.CommandCliError
+	}
+	serverName = fmt.Sprintf("%s/boundary-database-backup", serverName)
+	if err := c.SetupEventing(c.Logger, c.StderrLock, serverName, base.WithEventerConfig(c.Config.Eventing)); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	if c.Config.Controller == nil {
+		c.UI.Error(`"controller" config block not found`)
+		return base.CommandUserError
+	}
+
+	if c.Config.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return base.CommandUserError
+	}
+
+	urlToParse := c.Config.Controller.Database.Url
+	if urlToParse == "" {
+		c.UI.Error(`"url" not specified in "database" config block`)
+		return base.CommandUserError
+	}
+	dbUrl, err := parseutil.ParsePath(urlToParse)
+	if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+		c.UI.Error(fmt.Errorf("Error parsing database url: %w", err).Error())
+		return base.CommandUserError
+	}
+
+	dBase, err := common.SqlOpen(dialect, dbUrl)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error establishing db connection: %w", err).Error())
+		return base.CommandCliError
+	}
+	defer dBase.Close()
+	if err := dBase.PingContext(c.Context); err != nil {
+		c.UI.Error("Unable to connect to the database")
+		return base.CommandCliError
+	}
+	man, err := schema.NewManager(c.Context, schema.Dialect(dialect), dBase)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error setting up schema manager: %w", err).Error())
+		return base.CommandCliError
+	}
+	// The shared lock keeps migrations from changing the schema while the
+	// backup is taken.
+	if err := man.SharedLock(c.Context); err != nil {
+		c.UI.Error("Unable to capture a lock on the database.")
+		return base.CommandCliError
+	}
+	defer func() {
+		// We don't report anything since this should resolve itself anyways.
+		_ = man.SharedUnlock(c.Context)
+	}()
+	st, err := man.CurrentState(c.Context)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error getting database state: %w", err).Error())
+		return base.CommandCliError
+	}
+	if !st.Initialized {
+		c.UI.Error(base.WrapAtLength("Database has not been initialized, so there is nothing to back up."))
+		return base.CommandUserError
+	}
+
+	dir, err := os.MkdirTemp("", "boundary-backup")
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating temporary directory: %w", err).Error())
+		return base.CommandCliError
+	}
+	defer os.RemoveAll(dir)
+
+	m, err := dumpDatabase(c.Context, dBase, st, dir)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error reading database: %w", err).Error())
+		return base.CommandCliError
+	}
+
+	f, err := os.OpenFile(c.flagFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating archive: %w", err).Error())
+		return base.CommandUserError
+	}
+	if err := writeArchive(f, m, dir); err != nil {
+		f.Close()
+		os.Remove(c.flagFile)
+		c.UI.Error(fmt.Errorf("Error writing archive: %w", err).Error())
+		return base.CommandCliError
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(c.flagFile)
+		c.UI.Error(fmt.Errorf("Error writing archive: %w", err).Error())
+		return base.CommandCliError
+	}
+
+	var rows int64
+	for _, t := range m.Tables {
+		rows += t.Rows
+	}
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(map[string]any{
+			"file":        c.flagFile,
+			"create_time": m.CreateTime,
+			"schema":      m.Schema,
+			"tables":      len(m.Tables),
+			"rows":        rows,
+			"kms_key_ids": m.kmsKeyIds(),
+		})
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return base.CommandCliError
+		}
+		c.UI.Output(string(b))
+	default:
+		c.UI.Info(fmt.Sprintf("Backed up %d rows from %d tables to %s.", rows, len(m.Tables), c.flagFile))
+		if ids := m.kmsKeyIds(); len(ids) > 0 {
+			c.UI.Info(base.WrapAtLength(fmt.Sprintf("Restoring this backup requires the root KMS key(s): %s", strings.Join(ids, ", "))))
+		}
+	}
+	return base.CommandSuccess
+}
+
+func (c *BackupCommand) ParseFlagsAndConfig(args []string) int {
+	var err error
+
+	f := c.Flags()
+
+	if err = f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	// Validation
+	switch {
+	case len(c.flagConfig) == 0:
+		c.UI.Error("Must specify a config file using -config")
+		return base.CommandUserError
+	case c.flagFile == "":
+		c.UI.Error("Must specify the file to write the archive to using -file")
+		return base.CommandUserError
+	}
+
+	c.Config, err = config.Load(c.Context, c.flagConfig, c.flagConfigKms)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return base.CommandUserError
+	}
+
+	return base.CommandSuccess
+}
+
+// dumpDatabase writes the rows of every table as of a single snapshot to
+// files in dir, one JSON object per row, and returns the manifest describing
+// them.
+func dumpDatabase(ctx context.Context, d *sql.DB, st *schema.State, dir string) (*manifest, error) {
+	const op = "database.dumpDatabase"
+	tx, err := d.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer tx.Rollback()
+
+	m := &manifest{
+		FormatVersion:   archiveFormatVersion,
+		CreateTime:      time.Now().UTC(),
+		BoundaryVersion: version.Get().FullVersionNumber(false),
+	}
+	for _, e := range st.Editions {
+		m.Schema = append(m.Schema, editionVersion{Edition: e.Name, Version: e.DatabaseSchemaVersion})
+	}
+
+	tables, err := listTables(ctx, tx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	for _, t := range tables {
+		n, err := dumpTable(ctx, tx, t, filepath.Join(dir, t+tableSuffix))
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to read table %q", t)))
+		}
+		m.Tables = append(m.Tables, &tableInfo{Name: t, Rows: n})
+	}
+	if err := readKeys(ctx, tx, m); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := readSequences(ctx, tx, m); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return m, nil
+}
+
+// listTables returns the tables of the public schema other than those of the
+// schema manager.
+func listTables(ctx context.Context, tx *sql.Tx) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, "select tablename from pg_tables where schemaname = 'public' order by tablename")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var tables []string
+	for rows.Next() {
+		var t string
+		if err := rows.Scan(&t); err != nil {
+			return nil, err
+		}
+		if !schemaManagerTables[t] {
+			tables = append(tables, t)
+		}
+	}
+	return tables, rows.Err()
+}
+
+func dumpTable(ctx context.Context, tx *sql.Tx, table, path string) (int64, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("select row_to_json(t)::text from %s t", pgx.Identifier{table}.Sanitize()))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	w := bufio.NewWriter(f)
+	var n int64
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return 0, err
+		}
+		if _, err := w.WriteString(row); err != nil {
+			return 0, err
+		}
+		if err := w.WriteByte('\n'); err != nil {
+			return 0, err
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if err := w.Flush(); err != nil {
+		return 0, err
+	}
+	return n, f.Close()
+}
+
+func readKeys(ctx context.Context, tx *sql.Tx, m *manifest) error {
+	rows, err := tx.QueryContext(ctx, `
+select rk.private_id, rk.scope_id, rkv.private_id, rkv.version, rkv.key
+  from kms_root_key rk
+  join kms_root_key_version rkv on rkv.root_key_id = rk.private_id
+ order by rk.scope_id, rkv.version`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	var rk *rootKey
+	for rows.Next() {
+		var id, scopeId string
+		v := new(keyVersion)
+		if err := rows.Scan(&id, &scopeId, &v.Id, &v.Version, &v.Key); err != nil {
+			return err
+		}
+		v.KmsKeyId = wrappingKeyId(v.Key)
+		if rk == nil || rk.Id != id {
+			rk = &rootKey{Id: id, ScopeId: scopeId}
+			m.RootKeys = append(m.RootKeys, rk)
+		}
+		rk.Versions = append(rk.Versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = tx.QueryContext(ctx, `
+select dk.private_id, dk.root_key_id, dk.purpose, dkv.private_id, dkv.version, dkv.root_key_version_id, dkv.key
+  from kms_data_key dk
+  join kms_data_key_version dkv on dkv.data_key_id = dk.private_id
+ order by dk.root_key_id, dk.purpose, dkv.version`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	var dk *dataKey
+	for rows.Next() {
+		var id, rootKeyId, purpose string
+		v := new(keyVersion)
+		if err := rows.Scan(&id, &rootKeyId, &purpose, &v.Id, &v.Version, &v.RootKeyVersionId, &v.Key); err != nil {
+			return err
+		}
+		if dk == nil || dk.Id != id {
+			dk = &dataKey{Id: id, RootKeyId: rootKeyId, Purpose: purpose}
+			m.DataKeys = append(m.DataKeys, dk)
+		}
+		dk.Versions = append(dk.Versions, v)
+	}
+	return rows.Err()
+}
+
+func readSequences(ctx context.Context, tx *sql.Tx, m *manifest) error {
+	rows, err := tx.QueryContext(ctx, "select sequencename, last_value from pg_sequences where schemaname = 'public' and last_value is not null order by sequencename")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		s := new(sequence)
+		if err := rows.Scan(&s.Name, &s.Value); err != nil {
+			return err
+		}
+		m.Sequences = append(m.Sequences, s)
+	}
+	return rows.Err()
+}
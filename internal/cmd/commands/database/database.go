@@ -32,6 +32,14 @@ func (c *Command) Help() string {
 		"",
 		`      $ boundary database init`,
 		"",
+		"    Back up the database:",
+		"",
+		`      $ boundary database backup -file boundary-backup.tar.gz`,
+		"",
+		"    Rewrap the root keys with a new root KMS:",
+		"",
+		`      $ boundary database rekey-root -new-root-kms new-root-kms.hcl`,
+		"",
 		"  Please see the database subcommand help for detailed usage information.",
 	})
 }
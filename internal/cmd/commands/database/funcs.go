@@ -18,9 +18,11 @@ import (
 // migrateDatabase updates the schema to the most recent version known by the binary.
 // It owns the reporting to the UI any errors.
 // We expect the database already to be initialized iff initialized is set to true.
+// If online is set, only online migrations are applied, without taking the
+// exclusive lock, so that running controllers can keep using the database.
 // Returns a cleanup function which must be called even if an error is returned and
 // an error code where a non-zero value indicates an error happened.
-func migrateDatabase(ctx context.Context, ui cli.Ui, dialect, u string, initialized bool, maxOpenConns int, selectedRepairs schema.RepairMigrations) (func(), int) {
+func migrateDatabase(ctx context.Context, ui cli.Ui, dialect, u string, initialized, online bool, maxOpenConns int, selectedRepairs schema.RepairMigrations) (func(), int) {
 	noop := func() {}
 	// This database is used to keep an exclusive lock on the database for the
 	// remainder of the command
@@ -43,14 +45,19 @@ func migrateDatabase(ctx context.Context, ui cli.Ui, dialect, u string, initiali
 		}
 		return noop, 2
 	}
-	// This is an advisory lock on the DB which is released when the DB session ends.
-	if err := man.ExclusiveLock(ctx); err != nil {
-		ui.Error("Unable to capture a lock on the database.")
-		return noop, 2
-	}
-	unlock := func() {
-		// We don't report anything since this should resolve itself anyways.
-		_ = man.ExclusiveUnlock(ctx)
+	unlock := noop
+	// Online migrations take their own locks, which leave the shared locks of
+	// running controllers in place.
+	if !online {
+		// This is an advisory lock on the DB which is released when the DB session ends.
+		if err := man.ExclusiveLock(ctx); err != nil {
+			ui.Error("Unable to capture a lock on the database.")
+			return noop, 2
+		}
+		unlock = func() {
+			// We don't report anything since this should resolve itself anyways.
+			_ = man.ExclusiveUnlock(ctx)
+		}
 	}
 
 	st, err := man.CurrentState(ctx)
@@ -66,8 +73,20 @@ func migrateDatabase(ctx context.Context, ui cli.Ui, dialect, u string, initiali
 		ui.Output(base.WrapAtLength("Database has already been initialized. Please use 'boundary database migrate' for any upgrade needs."))
 		return unlock, -1
 	}
-	repairLogs, err := man.ApplyMigrations(ctx)
+	apply := man.ApplyMigrations
+	if online {
+		apply = man.ApplyOnlineMigrations
+	}
+	repairLogs, err := apply(ctx)
 	if err != nil {
+		if offlineErr, ok := err.(schema.OfflineMigrationError); ok {
+			ui.Error(base.WrapAtLength(fmt.Sprintf("Migration %s:%d can't be applied while controllers are using the database. Stop all controllers and run 'boundary database migrate' without -online.", offlineErr.Edition, offlineErr.Version)))
+			return unlock, 2
+		}
+		if errors.Match(errors.T(errors.MigrationLock), err) {
+			ui.Error("Unable to capture a lock on the database.")
+			return unlock, 2
+		}
 		ui.Error(fmt.Errorf("Error running database migrations: %w", err).Error())
 		if checkErr, ok := err.(schema.MigrationCheckError); ok {
 			ui.Error(fmt.Errorf("%s", strings.Join(checkErr.Problems, "\n")).Error())
@@ -102,6 +121,143 @@ func migrateDatabase(ctx context.Context, ui cli.Ui, dialect, u string, initiali
 	return unlock, 0
 }
 
+// planMigrations reports the migrations migrateDatabase would run, without
+// locking or changing the database. It owns the reporting to the UI of any
+// errors. Returns a non-zero code if an error happened or if a check of a
+// pending migration found problems that none of the selected repairs resolve.
+func planMigrations(ctx context.Context, ui cli.Ui, dialect, u string, selectedRepairs schema.RepairMigrations) int {
+	dBase, err := common.SqlOpen(dialect, u)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error establishing db connection: %w", err).Error())
+		return 2
+	}
+	defer dBase.Close()
+	if err := dBase.PingContext(ctx); err != nil {
+		ui.Error(fmt.Sprintf("Unable to connect to the database at %q", u))
+		return 2
+	}
+	man, err := schema.NewManager(ctx, schema.Dialect(dialect), dBase, schema.WithRepairMigrations(selectedRepairs))
+	if err != nil {
+		ui.Error(fmt.Errorf("Error setting up schema manager: %w", err).Error())
+		return 2
+	}
+	st, err := man.CurrentState(ctx)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error getting database state: %w", err).Error())
+		return 2
+	}
+	if !st.Initialized {
+		ui.Output(base.WrapAtLength("Database has not been initialized. Please use 'boundary database init' to initialize the boundary database."))
+		return -1
+	}
+	plan, err := man.PlanMigrations(ctx)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error planning database migrations: %w", err).Error())
+		return 2
+	}
+
+	var fails bool
+	for _, m := range plan {
+		if m.Check != nil && m.Check.Fails() {
+			fails = true
+		}
+	}
+	switch base.Format(ui) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(planJson(plan))
+		if err != nil {
+			ui.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 2
+		}
+		ui.Output(string(b))
+	default:
+		ui.Output(generatePlanTableOutput(plan))
+	}
+	if fails {
+		return 2
+	}
+	return 0
+}
+
+func planJson(plan []schema.PlannedMigration) []map[string]any {
+	ret := make([]map[string]any, 0, len(plan))
+	for _, m := range plan {
+		tables := make([]map[string]any, 0, len(m.Tables))
+		for _, t := range m.Tables {
+			tables = append(tables, map[string]any{
+				"name":           t.Name,
+				"lock":           t.Lock.String(),
+				"estimated_rows": t.EstimatedRows,
+			})
+		}
+		pm := map[string]any{
+			"edition":    m.Edition,
+			"version":    m.Version,
+			"online":     m.Online,
+			"tables":     tables,
+			"statements": m.Statements,
+		}
+		if c := m.Check; c != nil {
+			check := map[string]any{
+				"problems":           c.Problems,
+				"repair_description": c.RepairDescription,
+				"repair_selected":    c.RepairSelected,
+				"fails":              c.Fails(),
+			}
+			if c.Err != nil {
+				check["error"] = c.Err.Error()
+			}
+			pm["check"] = check
+		}
+		ret = append(ret, pm)
+	}
+	return ret
+}
+
+func generatePlanTableOutput(plan []schema.PlannedMigration) string {
+	if len(plan) == 0 {
+		return "No pending migrations. The database schema is up to date."
+	}
+	ret := []string{fmt.Sprintf("%d pending migrations, in the order they would run:", len(plan))}
+	for _, m := range plan {
+		name := fmt.Sprintf("  %s:%d", m.Edition, m.Version)
+		if m.Online {
+			name += " (online)"
+		}
+		ret = append(ret, "", name)
+		if len(m.Tables) > 0 {
+			ret = append(ret, "    Tables:")
+			for _, t := range m.Tables {
+				rows := "new table"
+				if t.EstimatedRows >= 0 {
+					rows = fmt.Sprintf("~%d rows", t.EstimatedRows)
+				}
+				ret = append(ret, fmt.Sprintf("      %s: %s lock, %s", t.Name, t.Lock, rows))
+			}
+		}
+		if c := m.Check; c != nil {
+			switch {
+			case c.Err != nil:
+				ret = append(ret, fmt.Sprintf("    Check: could not be run against the current schema: %v", c.Err))
+			case len(c.Problems) == 0:
+				ret = append(ret, "    Check: passed")
+			case c.RepairSelected:
+				ret = append(ret, fmt.Sprintf("    Check: found problems, which the selected repair will resolve. The repair will: %s", c.RepairDescription))
+			default:
+				ret = append(ret, fmt.Sprintf("    Check: found problems, so migrating will fail. To automatically repair, use 'boundary database migrate -repair=%s:%d'. This will: %s", m.Edition, m.Version, c.RepairDescription))
+			}
+			for _, p := range c.Problems {
+				ret = append(ret, "      "+p)
+			}
+		}
+		ret = append(ret, "    SQL:")
+		for _, l := range strings.Split(m.Statements, "\n") {
+			ret = append(ret, "      "+l)
+		}
+	}
+	return strings.Join(ret, "\n")
+}
+
 type RoleInfo struct {
 	RoleId string `json:"scope_id"`
 	Name   string `json:"name"`
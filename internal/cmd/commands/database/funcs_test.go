@@ -198,7 +198,7 @@ func TestMigrateDatabase(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			u := tc.urlProvider()
 			ui := cli.NewMockUi()
-			clean, errCode := migrateDatabase(ctx, ui, dialect, u, tc.initialized, 10, nil)
+			clean, errCode := migrateDatabase(ctx, ui, dialect, u, tc.initialized, false, 10, nil)
 			clean()
 			assert.EqualValues(t, tc.expectedCode, errCode)
 			assert.Equal(t, tc.expectedOutput, ui.OutputWriter.String())
@@ -233,3 +233,43 @@ func TestVerifyOplogIsEmpty(t *testing.T) {
 
 	assert.NoError(t, cmd.verifyOplogIsEmpty(ctx))
 }
+
+func TestGeneratePlanTableOutput(t *testing.T) {
+	assert.Equal(t, "No pending migrations. The database schema is up to date.", generatePlanTableOutput(nil))
+
+	out := generatePlanTableOutput([]schema.PlannedMigration{
+		{
+			Edition:    "oss",
+			Version:    68001,
+			Online:     true,
+			Statements: "alter table session\n  add column bytes_up bigint;",
+			Tables:     []schema.TableImpact{{Name: "session", Lock: schema.AccessExclusive, EstimatedRows: 1200}},
+		},
+		{
+			Edition:    "oss",
+			Version:    68002,
+			Statements: "create trigger t before insert on foo for each row execute procedure f();",
+			Tables:     []schema.TableImpact{{Name: "foo", Lock: schema.ShareRowExclusive, EstimatedRows: -1}},
+			Check: &schema.PlannedCheck{
+				Problems:          []string{"session s_1234567890 has no target"},
+				RepairDescription: "delete the sessions",
+			},
+		},
+	})
+	assert.Equal(t, `2 pending migrations, in the order they would run:
+
+  oss:68001 (online)
+    Tables:
+      session: access exclusive lock, ~1200 rows
+    SQL:
+      alter table session
+        add column bytes_up bigint;
+
+  oss:68002
+    Tables:
+      foo: share row exclusive lock, new table
+    Check: found problems, so migrating will fail. To automatically repair, use 'boundary database migrate -repair=oss:68002'. This will: delete the sessions
+      session s_1234567890 has no target
+    SQL:
+      create trigger t before insert on foo for each row execute procedure f();`, out)
+}
@@ -262,7 +262,7 @@ func (c *InitCommand) Run(args []string) (retCode int) {
 		return base.CommandUserError
 	}
 
-	clean, errCode := migrateDatabase(c.Context, c.UI, dialect, migrationUrl, false, c.DatabaseMaxOpenConnections, nil)
+	clean, errCode := migrateDatabase(c.Context, c.UI, dialect, migrationUrl, false, false, c.DatabaseMaxOpenConnections, nil)
 	defer clean()
 	switch errCode {
 	case 0:
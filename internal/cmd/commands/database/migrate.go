@@ -52,6 +52,8 @@ type MigrateCommand struct {
 	flagMigrationUrl       string
 	flagRepairMigrations   []string
 	flagAllowDevMigrations bool
+	flagDryRun             bool
+	flagOnline             bool
 }
 
 func (c *MigrateCommand) Synopsis() string {
@@ -66,6 +68,14 @@ func (c *MigrateCommand) Help() string {
 		"",
 		"    $ boundary database migrate -config=/etc/boundary/controller.hcl",
 		"",
+		"  Show the migrations that would be run, without running them:",
+		"",
+		"    $ boundary database migrate -config=/etc/boundary/controller.hcl -dry-run",
+		"",
+		"  Apply online migrations while controllers of the previous version keep running:",
+		"",
+		"    $ boundary database migrate -config=/etc/boundary/controller.hcl -online",
+		"",
 		"  For a full list of examples, please see the documentation.",
 	}) + c.Flags().Help()
 }
@@ -125,6 +135,18 @@ func (c *MigrateCommand) Flags() *base.FlagSets {
 		Usage:  `Run the repair function for the provided migration version.`,
 	})
 
+	f.BoolVar(&base.BoolVar{
+		Name:   "dry-run",
+		Target: &c.flagDryRun,
+		Usage:  `If set, the pending migrations of each edition are listed with their SQL, the tables they change with the lock taken on each and an estimate of its rows, and the results of their checks, without locking the database or changing it.`,
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "online",
+		Target: &c.flagOnline,
+		Usage:  `If set, the pending migrations are applied without taking the exclusive lock on the database, so that controllers of the previous version can keep running. Each pending migration must be marked as online; if any is not, no migrations are applied.`,
+	})
+
 	return set
 }
 
@@ -264,12 +286,23 @@ plugins {
 		return base.CommandUserError
 	}
 
+	if c.flagDryRun {
+		return planMigrations(
+			c.Context,
+			c.UI,
+			dialect,
+			migrationUrl,
+			c.selectedRepairs,
+		)
+	}
+
 	clean, errCode := migrateDatabase(
 		c.Context,
 		c.UI,
 		dialect,
 		migrationUrl,
 		true,
+		c.flagOnline,
 		c.Config.Controller.Database.MaxOpenConnections,
 		c.selectedRepairs,
 	)
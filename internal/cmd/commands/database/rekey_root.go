@@ -0,0 +1,450 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/db/schema"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	kms_plugin_assets "github.com/hashicorp/boundary/plugins/kms"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	"github.com/hashicorp/go-hclog"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
+	"github.com/hashicorp/go-secure-stdlib/configutil/v2"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+	"github.com/hashicorp/go-secure-stdlib/pluginutil/v2"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*RekeyRootCommand)(nil)
+	_ cli.CommandAutocomplete = (*RekeyRootCommand)(nil)
+)
+
+type RekeyRootCommand struct {
+	*base.Server
+
+	Config *config.Config
+
+	flagConfig       []string
+	flagConfigKms    string
+	flagLogLevel     string
+	flagLogFormat    string
+	flagMigrationUrl string
+	flagOldRootKms   string
+	flagNewRootKms   string
+}
+
+func (c *RekeyRootCommand) Synopsis() string {
+	return "Rewrap Boundary's root keys with a new root KMS"
+}
+
+func (c *RekeyRootCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database rekey-root [options]",
+		"",
+		"  Rewrap the root keys stored in Boundary's database, which are wrapped by the root KMS, with a new root KMS:",
+		"",
+		"    $ boundary database rekey-root -config=/etc/boundary/controller.hcl -new-root-kms=/etc/boundary/new-root-kms.hcl",
+		"",
+		"  The root key versions are unwrapped with the root KMS in the configuration, or with the \"kms\" block in the file given by -old-root-kms, and rewrapped with the \"kms\" block marked for \"root\" purpose in the file given by -new-root-kms, in a single transaction. The data keys are then checked to be unwrappable with the rewrapped root keys before the transaction is committed.",
+		"",
+		"  Root key versions already wrapped by the new root KMS are left as they are, so the command can be run again if it is interrupted. Stop all controllers before running it and replace the root KMS in their configuration with the new one once it succeeds.",
+	}) + c.Flags().Help()
+}
+
+func (c *RekeyRootCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP)
+
+	f := set.NewFlagSet("Command options")
+
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file, which has some drawbacks; see the help output for "boundary config encrypt -h" for details.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-level",
+		Target:     &c.flagLogLevel,
+		EnvVar:     "BOUNDARY_LOG_LEVEL",
+		Completion: complete.PredictSet("trace", "debug", "info", "warn", "err"),
+		Usage: "Log verbosity level. Supported values (in order of more detail to less) are " +
+			"\"trace\", \"debug\", \"info\", \"warn\", and \"err\".",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-format",
+		Target:     &c.flagLogFormat,
+		Completion: complete.PredictSet("standard", "json"),
+		Usage:      `Log format. Supported values are "standard" and "json".`,
+	})
+
+	f = set.NewFlagSet("Rekey options")
+
+	f.StringVar(&base.StringVar{
+		Name:       "old-root-kms",
+		Target:     &c.flagOldRootKms,
+		Completion: complete.PredictFiles("*.hcl"),
+		Usage:      `Path to a file containing the "kms" block marked for "root" purpose that currently wraps the root keys. If not set, the root KMS in the configuration file is used.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "new-root-kms",
+		Target:     &c.flagNewRootKms,
+		Completion: complete.PredictFiles("*.hcl"),
+		Usage:      `Path to a file containing the "kms" block marked for "root" purpose to rewrap the root keys with.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "migration-url",
+		Target: &c.flagMigrationUrl,
+		Usage:  `If set, overrides a migration URL set in config, and specifies the URL used to connect to the database for the rekey. This can allow different permissions for the user running the rekey vs. normal operation. This can refer to a file on disk (file://) from which a URL will be read; an env var (env://) from which the URL will be read; or a direct database URL.`,
+	})
+
+	return set
+}
+
+func (c *RekeyRootCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RekeyRootCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RekeyRootCommand) Run(args []string) (retCode int) {
+	if result := c.ParseFlagsAndConfig(args); result > 0 {
+		return result
+	}
+
+	defer func() {
+		if err := c.RunShutdownFuncs(); err != nil {
+			c.UI.Error(fmt.Errorf("Error running shutdown tasks: %w", err).Error())
+		}
+	}()
+
+	dialect := "postgres"
+
+	if err := c.SetupLogging(c.flagLogLevel, c.flagLogFormat, c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	serverName, err := os.Hostname()
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Unable to determine hostname: %w", err).Error())
+		return base.CommandCliError
+	}
+	serverName = fmt.Sprintf("%s/boundary-database-rekey-root", serverName)
+	if err := c.SetupEventing(c.Logger, c.StderrLock, serverName, base.WithEventerConfig(c.Config.Eventing)); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	var oldRoot wrapping.Wrapper
+	switch c.flagOldRootKms {
+	case "":
+		if err := c.SetupKMSes(c.Context, c.UI, c.Config); err != nil {
+			c.UI.Error(err.Error())
+			return base.CommandCliError
+		}
+		if c.RootKms == nil {
+			c.UI.Error("Root KMS not found after parsing KMS blocks")
+			return base.CommandCliError
+		}
+		oldRoot = c.RootKms
+	default:
+		oldRoot, err = c.rootKmsFromPath(c.flagOldRootKms)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error configuring old root KMS: %w", err).Error())
+			return base.CommandUserError
+		}
+	}
+	newRoot, err := c.rootKmsFromPath(c.flagNewRootKms)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error configuring new root KMS: %w", err).Error())
+		return base.CommandUserError
+	}
+
+	if c.Config.Controller == nil {
+		c.UI.Error(`"controller" config block not found`)
+		return base.CommandUserError
+	}
+
+	if c.Config.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return base.CommandUserError
+	}
+
+	var migrationUrlToParse string
+	if c.Config.Controller.Database.MigrationUrl != "" {
+		migrationUrlToParse = c.Config.Controller.Database.MigrationUrl
+	}
+	if c.flagMigrationUrl != "" {
+		migrationUrlToParse = c.flagMigrationUrl
+	}
+	// Fallback to using database URL for everything
+	if migrationUrlToParse == "" {
+		migrationUrlToParse = c.Config.Controller.Database.Url
+	}
+
+	if migrationUrlToParse == "" {
+		c.UI.Error(base.WrapAtLength(`neither "url" nor "migration_url" correctly set in "database" config block nor was the "migration-url" flag used`))
+		return base.CommandUserError
+	}
+
+	migrationUrl, err := parseutil.ParsePath(migrationUrlToParse)
+	if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+		c.UI.Error(fmt.Errorf("Error parsing migration url: %w", err).Error())
+		return base.CommandUserError
+	}
+
+	dBase, err := common.SqlOpen(dialect, migrationUrl)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error establishing db connection: %w", err).Error())
+		return base.CommandCliError
+	}
+	defer dBase.Close()
+	if err := dBase.PingContext(c.Context); err != nil {
+		c.UI.Error("Unable to connect to the database")
+		return base.CommandCliError
+	}
+	man, err := schema.NewManager(c.Context, schema.Dialect(dialect), dBase)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error setting up schema manager: %w", err).Error())
+		return base.CommandCliError
+	}
+	// This is an advisory lock on the DB which is released when the DB session ends.
+	if err := man.ExclusiveLock(c.Context); err != nil {
+		c.UI.Error("Unable to capture a lock on the database.")
+		return base.CommandCliError
+	}
+	defer func() {
+		// We don't report anything since this should resolve itself anyways.
+		_ = man.ExclusiveUnlock(c.Context)
+	}()
+	st, err := man.CurrentState(c.Context)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error getting database state: %w", err).Error())
+		return base.CommandCliError
+	}
+	if !st.Initialized {
+		c.UI.Error(base.WrapAtLength("The database has not been initialized. Run \"boundary database init\" first."))
+		return base.CommandUserError
+	}
+	if !st.MigrationsApplied() {
+		c.UI.Error(base.WrapAtLength("The database schema is not at the version supported by this binary. Run \"boundary database migrate\" first."))
+		return base.CommandUserError
+	}
+
+	rekeyed, total, err := rekeyDatabase(c.Context, dBase, oldRoot, newRoot)
+	if err != nil {
+		c.UI.Error(base.WrapAtLength(fmt.Sprintf("Error rewrapping the root keys: %v", err)))
+		c.UI.Error(base.WrapAtLength("No root keys were changed."))
+		return base.CommandCliError
+	}
+
+	if err := c.verifyGlobalRoot(dialect, migrationUrl, newRoot); err != nil {
+		c.UI.Error(base.WrapAtLength(fmt.Sprintf("The root keys were rewrapped but the global root key could not be verified with the new root KMS: %v", err)))
+		return base.CommandCliError
+	}
+
+	if base.Format(c.UI) == "table" {
+		c.UI.Info(fmt.Sprintf("Rewrapped %d of %d root key versions with the new root KMS.", rekeyed, total))
+		c.UI.Info(base.WrapAtLength("Replace the root KMS in the configuration of each controller with the new one before starting them."))
+	}
+	return base.CommandSuccess
+}
+
+func (c *RekeyRootCommand) ParseFlagsAndConfig(args []string) int {
+	var err error
+
+	f := c.Flags()
+
+	if err = f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	// Validation
+	switch {
+	case len(c.flagConfig) == 0:
+		c.UI.Error("Must specify a config file using -config")
+		return base.CommandUserError
+	case c.flagNewRootKms == "":
+		c.UI.Error("Must specify the new root KMS using -new-root-kms")
+		return base.CommandUserError
+	}
+
+	c.Config, err = config.Load(c.Context, c.flagConfig, c.flagConfigKms)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return base.CommandUserError
+	}
+
+	return base.CommandSuccess
+}
+
+// rootKmsFromPath returns an initialized wrapper for the kms block marked for
+// root purpose in the file at path. It is finalized by the shutdown funcs.
+func (c *RekeyRootCommand) rootKmsFromPath(path string) (wrapping.Wrapper, error) {
+	w, cleanupFunc, err := wrapper.GetWrapperFromPath(
+		c.Context,
+		path,
+		globals.KmsPurposeRoot,
+		configutil.WithPluginOptions(
+			pluginutil.WithPluginsMap(kms_plugin_assets.BuiltinKmsPlugins()),
+			pluginutil.WithPluginsFilesystem(kms_plugin_assets.KmsPluginPrefix, kms_plugin_assets.FileSystem()),
+			pluginutil.WithPluginExecutionDirectory(c.Config.Plugins.ExecutionDir),
+		),
+		configutil.WithLogger(hclog.NewNullLogger()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if cleanupFunc != nil {
+		c.ShutdownFuncs = append(c.ShutdownFuncs, cleanupFunc)
+	}
+	if w == nil {
+		return nil, fmt.Errorf(`no "kms" block marked for %q purpose found in %q`, globals.KmsPurposeRoot, path)
+	}
+	if ifWrapper, ok := w.(wrapping.InitFinalizer); ok {
+		if err := ifWrapper.Init(c.Context); err != nil && !errors.Is(err, wrapping.ErrFunctionNotImplemented) {
+			return nil, fmt.Errorf("unable to initialize KMS: %w", err)
+		}
+		c.ShutdownFuncs = append(c.ShutdownFuncs, func() error {
+			if err := ifWrapper.Finalize(context.Background()); err != nil && !errors.Is(err, wrapping.ErrFunctionNotImplemented) {
+				return fmt.Errorf("Error finalizing kms from %s: %w", path, err)
+			}
+			return nil
+		})
+	}
+	return w, nil
+}
+
+// verifyGlobalRoot checks, through the kms package as a controller would, that
+// the global root key exists and that its database key can be unwrapped with
+// root.
+func (c *RekeyRootCommand) verifyGlobalRoot(dialect, url string, root wrapping.Wrapper) error {
+	dbase, err := c.OpenDatabase(c.Context, dialect, url)
+	if err != nil {
+		return err
+	}
+	defer dbase.Close(c.Context)
+	rw := db.New(dbase)
+	kmsCache, err := kms.New(c.Context, rw, rw)
+	if err != nil {
+		return err
+	}
+	if err := kmsCache.AddExternalWrappers(c.Context, kms.WithRootWrapper(root)); err != nil {
+		return err
+	}
+	if err := kmsCache.VerifyGlobalRoot(c.Context); err != nil {
+		return err
+	}
+	_, err = kmsCache.GetWrapper(c.Context, scope.Global.String(), kms.KeyPurposeDatabase)
+	return err
+}
+
+// rekeyDatabase rewraps the root key versions in d with newRoot in a single
+// transaction, checking that every data key version can be unwrapped with the
+// rewrapped root key versions before committing. It returns the number of root
+// key versions rewrapped and the total number of root key versions.
+func rekeyDatabase(ctx context.Context, d *sql.DB, oldRoot, newRoot wrapping.Wrapper) (int, int, error) {
+	const op = "database.rekeyDatabase"
+	tx, err := d.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, errors.Wrap(ctx, err, op)
+	}
+	defer tx.Rollback()
+
+	// Block new root key versions, wrapped by the old root KMS, from being
+	// written until the transaction ends.
+	if _, err := tx.ExecContext(ctx, "lock table kms_root_key_version in exclusive mode"); err != nil {
+		return 0, 0, errors.Wrap(ctx, err, op)
+	}
+	m := new(manifest)
+	if err := readKeys(ctx, tx, m); err != nil {
+		return 0, 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to read keys"))
+	}
+	rekeyed, err := rekeyRootKeys(ctx, oldRoot, newRoot, m)
+	if err != nil {
+		return 0, 0, errors.Wrap(ctx, err, op)
+	}
+	for _, v := range rekeyed {
+		if _, err := tx.ExecContext(ctx, "update kms_root_key_version set key = $1 where private_id = $2", v.Key, v.Id); err != nil {
+			return 0, 0, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to update root key version %q", v.Id)))
+		}
+	}
+	if err := verifyKeys(ctx, newRoot, m); err != nil {
+		return 0, 0, errors.Wrap(ctx, err, op)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, 0, errors.Wrap(ctx, err, op)
+	}
+	var total int
+	for _, rk := range m.RootKeys {
+		total += len(rk.Versions)
+	}
+	return len(rekeyed), total, nil
+}
+
+// rekeyRootKeys rewraps the root key versions in m with newRoot and returns
+// those that were rewrapped. Versions that can already be unwrapped by newRoot
+// are left as they are; every other version must be unwrappable by oldRoot.
+func rekeyRootKeys(ctx context.Context, oldRoot, newRoot wrapping.Wrapper, m *manifest) ([]*keyVersion, error) {
+	newKeyId, err := newRoot.KeyId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the key id of the new root KMS: %w", err)
+	}
+	var rekeyed []*keyVersion
+	for _, rk := range m.RootKeys {
+		for _, v := range rk.Versions {
+			if v.KmsKeyId == newKeyId {
+				if err := structwrapping.UnwrapStruct(ctx, newRoot, &wrappedKey{CtKey: v.Key}, nil); err == nil {
+					continue
+				}
+			}
+			k := &wrappedKey{CtKey: v.Key}
+			if err := structwrapping.UnwrapStruct(ctx, oldRoot, k, nil); err != nil {
+				return nil, fmt.Errorf("unable to unwrap version %d of the root key of scope %q with the old root KMS: %w", v.Version, rk.ScopeId, err)
+			}
+			if err := structwrapping.WrapStruct(ctx, newRoot, k, nil); err != nil {
+				return nil, fmt.Errorf("unable to wrap version %d of the root key of scope %q with the new root KMS: %w", v.Version, rk.ScopeId, err)
+			}
+			v.Key = k.CtKey
+			v.KmsKeyId = wrappingKeyId(k.CtKey)
+			rekeyed = append(rekeyed, v)
+		}
+	}
+	return rekeyed, nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRekeyRootKeys(t *testing.T) {
+	ctx := context.Background()
+	oldRoot := db.TestWrapper(t)
+	newRoot := db.TestWrapper(t)
+	newKeyId, err := newRoot.KeyId(ctx)
+	require.NoError(t, err)
+
+	t.Run("rekey", func(t *testing.T) {
+		m := testManifest(t, oldRoot)
+		rekeyed, err := rekeyRootKeys(ctx, oldRoot, newRoot, m)
+		require.NoError(t, err)
+		require.Len(t, rekeyed, 1)
+		assert.Equal(t, "krkv_1234567890", rekeyed[0].Id)
+		assert.Equal(t, newKeyId, rekeyed[0].KmsKeyId)
+		assert.Equal(t, newKeyId, wrappingKeyId(rekeyed[0].Key))
+		require.NoError(t, verifyKeys(ctx, newRoot, m))
+		assert.Error(t, verifyKeys(ctx, oldRoot, m))
+	})
+	t.Run("resume", func(t *testing.T) {
+		m := testManifest(t, oldRoot)
+		partial := testManifest(t, newRoot)
+		m.RootKeys = append(m.RootKeys, partial.RootKeys...)
+		m.RootKeys[1].ScopeId = "o_1234567890"
+		rekeyed, err := rekeyRootKeys(ctx, oldRoot, newRoot, m)
+		require.NoError(t, err)
+		require.Len(t, rekeyed, 1)
+		assert.Equal(t, newKeyId, m.RootKeys[1].Versions[0].KmsKeyId)
+
+		rekeyed, err = rekeyRootKeys(ctx, oldRoot, newRoot, m)
+		require.NoError(t, err)
+		assert.Empty(t, rekeyed)
+	})
+	t.Run("wrong-old-root-kms", func(t *testing.T) {
+		m := testManifest(t, oldRoot)
+		_, err := rekeyRootKeys(ctx, db.TestWrapper(t), newRoot, m)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unable to unwrap version 1 of the root key of scope "global" with the old root KMS`)
+	})
+}
@@ -0,0 +1,509 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package database
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/db/schema"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+	"github.com/jackc/pgx/v4"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	_ cli.Command             = (*RestoreCommand)(nil)
+	_ cli.CommandAutocomplete = (*RestoreCommand)(nil)
+)
+
+// restoreBatchSize is the number of rows inserted per statement when
+// restoring a table.
+const restoreBatchSize = 500
+
+type RestoreCommand struct {
+	*base.Server
+
+	Config *config.Config
+
+	flagConfig       []string
+	flagConfigKms    string
+	flagLogLevel     string
+	flagLogFormat    string
+	flagMigrationUrl string
+	flagFile         string
+}
+
+func (c *RestoreCommand) Synopsis() string {
+	return "Restore Boundary's database from an archive"
+}
+
+func (c *RestoreCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database restore [options]",
+		"",
+		"  Restore Boundary's database from an archive written by \"boundary database backup\":",
+		"",
+		"    $ boundary database restore -config=/etc/boundary/controller.hcl -file=boundary-backup.tar.gz",
+		"",
+		"  The database must be empty. Before anything is written, the schema version of the archive is checked against the version supported by this binary, and the root and data keys in the archive are checked to be unwrappable by the root KMS in the configuration. The schema is then created by running the migrations and the data is loaded in a single transaction.",
+		"",
+		"  Triggers are disabled while the data is loaded, which requires the database user to be a superuser or to otherwise be allowed to set \"session_replication_role\".",
+	}) + c.Flags().Help()
+}
+
+func (c *RestoreCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP)
+
+	f := set.NewFlagSet("Command options")
+
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file, which has some drawbacks; see the help output for "boundary config encrypt -h" for details.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-level",
+		Target:     &c.flagLogLevel,
+		EnvVar:     "BOUNDARY_LOG_LEVEL",
+		Completion: complete.PredictSet("trace", "debug", "info", "warn", "err"),
+		Usage: "Log verbosity level. Supported values (in order of more detail to less) are " +
+			"\"trace\", \"debug\", \"info\", \"warn\", and \"err\".",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-format",
+		Target:     &c.flagLogFormat,
+		Completion: complete.PredictSet("standard", "json"),
+		Usage:      `Log format. Supported values are "standard" and "json".`,
+	})
+
+	f = set.NewFlagSet("Restore options")
+
+	f.StringVar(&base.StringVar{
+		Name:       "file",
+		Target:     &c.flagFile,
+		Completion: complete.PredictFiles("*.tar.gz"),
+		Usage:      "The archive to restore.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "migration-url",
+		Target: &c.flagMigrationUrl,
+		Usage:  `If set, overrides a migration URL set in config, and specifies the URL used to connect to the database for the restore. This can allow different permissions for the user running the restore vs. normal operation. This can refer to a file on disk (file://) from which a URL will be read; an env var (env://) from which the URL will be read; or a direct database URL.`,
+	})
+
+	return set
+}
+
+func (c *RestoreCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RestoreCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RestoreCommand) Run(args []string) (retCode int) {
+	if result := c.ParseFlagsAndConfig(args); result > 0 {
+		return result
+	}
+
+	defer func() {
+		if err := c.RunShutdownFuncs(); err != nil {
+			c.UI.Error(fmt.Errorf("Error running shutdown tasks: %w", err).Error())
+		}
+	}()
+
+	dialect := "postgres"
+
+	if err := c.SetupLogging(c.flagLogLevel, c.flagLogFormat, c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	serverName, err := os.Hostname()
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Unable to determine hostname: %w", err).Error())
+		return base.CommandCliError
+	}
+	serverName = fmt.Sprintf("%s/boundary-database-restore", serverName)
+	if err := c.SetupEventing(c.Logger, c.StderrLock, serverName, base.WithEventerConfig(c.Config.Eventing)); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	if err := c.SetupKMSes(c.Context, c.UI, c.Config); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	if c.RootKms == nil {
+		c.UI.Error("Root KMS not found after parsing KMS blocks")
+		return base.CommandCliError
+	}
+
+	if c.Config.Controller == nil {
+		c.UI.Error(`"controller" config block not found`)
+		return base.CommandUserError
+	}
+
+	if c.Config.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return base.CommandUserError
+	}
+
+	var migrationUrlToParse string
+	if c.Config.Controller.Database.MigrationUrl != "" {
+		migrationUrlToParse = c.Config.Controller.Database.MigrationUrl
+	}
+	if c.flagMigrationUrl != "" {
+		migrationUrlToParse = c.flagMigrationUrl
+	}
+	// Fallback to using database URL for everything
+	if migrationUrlToParse == "" {
+		migrationUrlToParse = c.Config.Controller.Database.Url
+	}
+
+	if migrationUrlToParse == "" {
+		c.UI.Error(base.WrapAtLength(`neither "url" nor "migration_url" correctly set in "database" config block nor was the "migration-url" flag used`))
+		return base.CommandUserError
+	}
+
+	migrationUrl, err := parseutil.ParsePath(migrationUrlToParse)
+	if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+		c.UI.Error(fmt.Errorf("Error parsing migration url: %w", err).Error())
+		return base.CommandUserError
+	}
+
+	f, err := os.Open(c.flagFile)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error opening archive: %w", err).Error())
+		return base.CommandUserError
+	}
+	defer f.Close()
+	archive, err := readArchive(bufio.NewReader(f))
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error reading archive: %w", err).Error())
+		return base.CommandUserError
+	}
+	defer archive.Close()
+	m := archive.manifest
+
+	dBase, err := common.SqlOpen(dialect, migrationUrl)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error establishing db connection: %w", err).Error())
+		return base.CommandCliError
+	}
+	defer dBase.Close()
+	if err := dBase.PingContext(c.Context); err != nil {
+		c.UI.Error("Unable to connect to the database")
+		return base.CommandCliError
+	}
+	man, err := schema.NewManager(c.Context, schema.Dialect(dialect), dBase)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error setting up schema manager: %w", err).Error())
+		return base.CommandCliError
+	}
+	// This is an advisory lock on the DB which is released when the DB session ends.
+	if err := man.ExclusiveLock(c.Context); err != nil {
+		c.UI.Error("Unable to capture a lock on the database.")
+		return base.CommandCliError
+	}
+	defer func() {
+		// We don't report anything since this should resolve itself anyways.
+		_ = man.ExclusiveUnlock(c.Context)
+	}()
+	st, err := man.CurrentState(c.Context)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error getting database state: %w", err).Error())
+		return base.CommandCliError
+	}
+
+	if err := checkSchemaVersions(m, st); err != nil {
+		c.UI.Error(base.WrapAtLength(err.Error()))
+		return base.CommandUserError
+	}
+	if err := verifyKeys(c.Context, c.RootKms, m); err != nil {
+		c.UI.Error(base.WrapAtLength(fmt.Sprintf("The keys in the archive cannot be unwrapped with the configured root KMS: %v", err)))
+		if ids := m.kmsKeyIds(); len(ids) > 0 {
+			c.UI.Error(base.WrapAtLength(fmt.Sprintf("The archive requires the root KMS key(s): %s", strings.Join(ids, ", "))))
+		}
+		return base.CommandUserError
+	}
+	empty, err := databaseIsEmpty(c.Context, dBase, st)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error checking database contents: %w", err).Error())
+		return base.CommandCliError
+	}
+	if !empty {
+		c.UI.Error(base.WrapAtLength("The database is not empty. Archives can only be restored into an empty database."))
+		return base.CommandUserError
+	}
+
+	if _, err := man.ApplyMigrations(c.Context); err != nil {
+		c.UI.Error(fmt.Errorf("Error running database migrations: %w", err).Error())
+		return base.CommandCliError
+	}
+	if err := restoreDatabase(c.Context, dBase, archive); err != nil {
+		c.UI.Error(fmt.Errorf("Error loading archive: %w", err).Error())
+		c.UI.Error(base.WrapAtLength("The schema has been created but no data was loaded. Drop and recreate the database before trying again."))
+		return base.CommandCliError
+	}
+
+	if base.Format(c.UI) == "table" {
+		c.UI.Info(fmt.Sprintf("Database restored from the backup taken at %s.", m.CreateTime.Format("2006-01-02 15:04:05 MST")))
+	}
+	return base.CommandSuccess
+}
+
+func (c *RestoreCommand) ParseFlagsAndConfig(args []string) int {
+	var err error
+
+	f := c.Flags()
+
+	if err = f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	// Validation
+	switch {
+	case len(c.flagConfig) == 0:
+		c.UI.Error("Must specify a config file using -config")
+		return base.CommandUserError
+	case c.flagFile == "":
+		c.UI.Error("Must specify the archive to restore using -file")
+		return base.CommandUserError
+	}
+
+	c.Config, err = config.Load(c.Context, c.flagConfig, c.flagConfigKms)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return base.CommandUserError
+	}
+
+	return base.CommandSuccess
+}
+
+// checkSchemaVersions checks that the archive was taken from a database with
+// the schema version this binary migrates to, for every edition.
+func checkSchemaVersions(m *manifest, st *schema.State) error {
+	archived := make(map[string]int, len(m.Schema))
+	for _, e := range m.Schema {
+		archived[e.Edition] = e.Version
+	}
+	for _, e := range st.Editions {
+		v, ok := archived[e.Name]
+		switch {
+		case !ok:
+			return fmt.Errorf("The archive does not contain the %q schema edition.", e.Name)
+		case v != e.BinarySchemaVersion:
+			return fmt.Errorf("The archive has version %d of the %q schema but this version of Boundary uses version %d. Restore the archive with the version of Boundary it was taken with.", v, e.Name, e.BinarySchemaVersion)
+		}
+	}
+	for _, e := range m.Schema {
+		if !slices.ContainsFunc(st.Editions, func(s schema.EditionState) bool { return s.Name == e.Edition }) {
+			return fmt.Errorf("The archive contains the %q schema edition, which this version of Boundary does not support.", e.Edition)
+		}
+	}
+	return nil
+}
+
+// databaseIsEmpty reports whether the database has not been initialized and
+// has no tables.
+func databaseIsEmpty(ctx context.Context, d *sql.DB, st *schema.State) (bool, error) {
+	if st.Initialized {
+		return false, nil
+	}
+	var exists bool
+	if err := d.QueryRowContext(ctx, "select exists(select 1 from pg_tables where schemaname = 'public')").Scan(&exists); err != nil {
+		return false, err
+	}
+	return !exists, nil
+}
+
+// restoreDatabase loads the tables and sequences of the archive into d, whose
+// schema must be at the version of the archive, in a single transaction.
+func restoreDatabase(ctx context.Context, d *sql.DB, a *archiveReader) error {
+	const op = "database.restoreDatabase"
+	tx, err := d.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	defer tx.Rollback()
+
+	// Disable triggers, including those enforcing foreign keys, so that rows
+	// are loaded as they were backed up and tables can be loaded in any order.
+	if _, err := tx.ExecContext(ctx, "set local session_replication_role = replica"); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to disable triggers"))
+	}
+
+	tables, err := listTables(ctx, tx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	exists := make(map[string]bool, len(tables))
+	idents := make([]string, 0, len(tables))
+	for _, t := range tables {
+		exists[t] = true
+		idents = append(idents, pgx.Identifier{t}.Sanitize())
+	}
+	// The migrations insert rows, such as the global scope, which are also in
+	// the archive.
+	if len(idents) > 0 {
+		if _, err := tx.ExecContext(ctx, "truncate table "+strings.Join(idents, ", ")); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	}
+
+	want := make(map[string]int64, len(a.manifest.Tables))
+	for _, t := range a.manifest.Tables {
+		want[t.Name] = t.Rows
+	}
+	for {
+		name, r, err := a.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		rows, ok := want[name]
+		switch {
+		case !ok:
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("table %q is not in the manifest", name))
+		case !exists[name]:
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("table %q does not exist", name))
+		}
+		n, err := loadTable(ctx, tx, name, r)
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to load table %q", name)))
+		}
+		if n != rows {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("table %q has %d rows but the manifest lists %d", name, n, rows))
+		}
+		delete(want, name)
+	}
+	for _, t := range a.manifest.Tables {
+		if _, ok := want[t.Name]; ok {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("the archive does not contain table %q", t.Name))
+		}
+	}
+
+	for _, s := range a.manifest.Sequences {
+		if _, err := tx.ExecContext(ctx, "select setval($1::regclass, $2)", pgx.Identifier{s.Name}.Sanitize(), s.Value); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to set sequence %q", s.Name)))
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// loadTable inserts the rows read from r, one JSON object per line, into
+// table and returns the number of rows inserted.
+func loadTable(ctx context.Context, tx *sql.Tx, table string, r io.Reader) (int64, error) {
+	cols, err := insertableColumns(ctx, tx, table)
+	if err != nil {
+		return 0, err
+	}
+	ident := pgx.Identifier{table}.Sanitize()
+	// Identity columns are loaded as they were backed up.
+	query := fmt.Sprintf("insert into %s (%s) overriding system value select %s from json_populate_recordset(null::%s, $1::json)", ident, cols, cols, ident)
+
+	var n int64
+	batch := make([]string, 0, restoreBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if _, err := tx.ExecContext(ctx, query, "["+strings.Join(batch, ",")+"]"); err != nil {
+			return err
+		}
+		n += int64(len(batch))
+		batch = batch[:0]
+		return nil
+	}
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if line = strings.TrimSpace(line); line != "" {
+			batch = append(batch, line)
+			if len(batch) == restoreBatchSize {
+				if err := flush(); err != nil {
+					return 0, err
+				}
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	if err := flush(); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// insertableColumns returns the quoted, comma separated columns of table that
+// can be inserted into, which excludes generated columns.
+func insertableColumns(ctx context.Context, tx *sql.Tx, table string) (string, error) {
+	rows, err := tx.QueryContext(ctx, `
+select column_name
+  from information_schema.columns
+ where table_schema = 'public'
+   and table_name = $1
+   and is_generated = 'NEVER'
+ order by ordinal_position`, table)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	var cols []string
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return "", err
+		}
+		cols = append(cols, pgx.Identifier{c}.Sanitize())
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	if len(cols) == 0 {
+		return "", fmt.Errorf("table %q has no columns", table)
+	}
+	return strings.Join(cols, ", "), nil
+}
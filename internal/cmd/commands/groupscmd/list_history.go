@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package groupscmd
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/groups"
+	"github.com/hashicorp/boundary/api/roles"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+)
+
+// NewListHistoryCommand returns the command listing the change history of a
+// group.
+func NewListHistoryCommand(c *base.Command) *common.ListHistoryCommand {
+	return &common.ListHistoryCommand{
+		Command:      c,
+		ResourceType: "group",
+		Changes:      "a group, its members, and its assignment to roles",
+		ExampleId:    "g_1234567890",
+		ListFn: func(ctx context.Context, client *api.Client, id string) ([]*roles.HistoryEntry, *api.Response, error) {
+			result, err := groups.NewClient(client).ListHistory(ctx, id)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result.GetItems(), result.GetResponse(), nil
+		},
+	}
+}
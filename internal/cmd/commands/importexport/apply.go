@@ -0,0 +1,482 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package importexport
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/api/credentialstores"
+	"github.com/hashicorp/boundary/api/groups"
+	"github.com/hashicorp/boundary/api/hostcatalogs"
+	"github.com/hashicorp/boundary/api/roles"
+	"github.com/hashicorp/boundary/api/scopes"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/api/users"
+	"github.com/hashicorp/boundary/globals"
+)
+
+// applier makes the changes of a plan using the API. It resolves the scope
+// paths and names in the document to IDs, starting with the IDs of the
+// resources in the cluster and adding those of the resources it creates.
+type applier struct {
+	client *api.Client
+	// scopeIds maps scope paths to IDs
+	scopeIds map[string]string
+	// principalIds maps the refKeys of users and groups to IDs
+	principalIds map[string]string
+	// authMethodIds maps "<path>/<name>" of auth methods to IDs
+	authMethodIds map[string]string
+}
+
+func newApplier(client *api.Client, current *state) *applier {
+	a := &applier{
+		client:        client,
+		scopeIds:      make(map[string]string),
+		principalIds:  make(map[string]string),
+		authMethodIds: make(map[string]string),
+	}
+	var index func(s *Scope)
+	index = func(s *Scope) {
+		a.scopeIds[s.path] = s.id
+		for _, am := range s.AuthMethods {
+			a.authMethodIds[s.path+"/"+am.Name] = am.id
+		}
+		for _, u := range s.Users {
+			a.principalIds[refKey(kindUser, s.path, u.Name)] = u.id
+		}
+		for _, g := range s.Groups {
+			a.principalIds[refKey(kindGroup, s.path, g.Name)] = g.id
+		}
+		for _, c := range s.Scopes {
+			index(c)
+		}
+	}
+	index(current.doc.Global)
+	return a
+}
+
+// apply makes the changes in order, stopping at the first error.
+func (a *applier) apply(ctx context.Context, changes []*change) error {
+	for _, c := range changes {
+		var err error
+		switch d := c.desired.(type) {
+		case *Scope:
+			err = a.applyScope(ctx, c, d)
+		case *AuthMethod:
+			err = a.applyAuthMethod(ctx, c, d)
+		case *User:
+			err = a.applyUser(ctx, c, d)
+		case *Group:
+			err = a.applyGroup(ctx, c, d)
+		case *Role:
+			err = a.applyRole(ctx, c, d)
+		case *HostCatalog:
+			err = a.applyHostCatalog(ctx, c, d)
+		case *CredentialStore:
+			err = a.applyCredentialStore(ctx, c, d)
+		case *Target:
+			err = a.applyTarget(ctx, c, d)
+		default:
+			err = fmt.Errorf("unknown resource type %T", c.desired)
+		}
+		if err != nil {
+			return fmt.Errorf("error applying change %q: %w", c.String(), err)
+		}
+	}
+	return nil
+}
+
+// changedFields returns the fields to set for a change. All fields set in the
+// document are set when creating a resource.
+func changedFields(c *change) map[string]bool {
+	fields := c.fields
+	if c.op == opCreate {
+		fields = diffFields(c.desired, nil)
+	}
+	out := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		out[f] = true
+	}
+	return out
+}
+
+// changedAttributes returns the attributes to set for a change, or nil if
+// none changed.
+func changedAttributes(fields map[string]bool, attrs map[string]any) map[string]any {
+	var out map[string]any
+	for k, v := range attrs {
+		if !fields["attributes."+k] {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]any)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+func (a *applier) applyScope(ctx context.Context, c *change, d *Scope) error {
+	sc := scopes.NewClient(a.client)
+	fields := changedFields(c)
+	switch {
+	case c.op == opCreate:
+		opts := []scopes.Option{
+			scopes.WithName(d.Name),
+			scopes.WithSkipAdminRoleCreation(true),
+			scopes.WithSkipDefaultRoleCreation(true),
+		}
+		if d.Description != "" {
+			opts = append(opts, scopes.WithDescription(d.Description))
+		}
+		res, err := sc.Create(ctx, a.scopeIds[c.parent.path], opts...)
+		if err != nil {
+			return err
+		}
+		d.id, d.version = res.GetItem().Id, res.GetItem().Version
+		a.scopeIds[d.path] = d.id
+
+	case fields["primary_auth_method"]:
+		id, ok := a.authMethodIds[d.path+"/"+d.PrimaryAuthMethod]
+		if !ok {
+			return fmt.Errorf("auth method %q not found", d.PrimaryAuthMethod)
+		}
+		res, err := sc.Update(ctx, d.id, d.version, scopes.WithPrimaryAuthMethodId(id))
+		if err != nil {
+			return err
+		}
+		d.version = res.GetItem().Version
+		return nil
+
+	case fields["name"] || fields["description"]:
+		var opts []scopes.Option
+		if fields["name"] {
+			opts = append(opts, scopes.WithName(d.Name))
+		}
+		if fields["description"] {
+			opts = append(opts, scopes.WithDescription(d.Description))
+		}
+		res, err := sc.Update(ctx, d.id, d.version, opts...)
+		if err != nil {
+			return err
+		}
+		d.version = res.GetItem().Version
+	}
+
+	if fields["quotas"] {
+		quotas := make([]*scopes.Quota, 0, len(d.Quotas))
+		for t, l := range d.Quotas {
+			quotas = append(quotas, &scopes.Quota{ResourceType: t, Limit: l})
+		}
+		sort.Slice(quotas, func(i, j int) bool { return quotas[i].ResourceType < quotas[j].ResourceType })
+		res, err := sc.SetQuotas(ctx, d.id, d.version, quotas)
+		if err != nil {
+			return err
+		}
+		d.version = res.GetItem().Version
+	}
+	return nil
+}
+
+func (a *applier) applyAuthMethod(ctx context.Context, c *change, d *AuthMethod) error {
+	amc := authmethods.NewClient(a.client)
+	fields := changedFields(c)
+	var opts []authmethods.Option
+	if fields["description"] {
+		opts = append(opts, authmethods.WithDescription(d.Description))
+	}
+	if attrs := changedAttributes(fields, d.Attributes); attrs != nil {
+		opts = append(opts, authmethods.WithAttributes(attrs))
+	}
+	if c.op == opCreate {
+		res, err := amc.Create(ctx, d.Type, a.scopeIds[c.scope.path], append(opts, authmethods.WithName(d.Name))...)
+		if err != nil {
+			return err
+		}
+		d.id, d.version = res.GetItem().Id, res.GetItem().Version
+		a.authMethodIds[c.scope.path+"/"+d.Name] = d.id
+		return nil
+	}
+	res, err := amc.Update(ctx, d.id, d.version, opts...)
+	if err != nil {
+		return err
+	}
+	d.version = res.GetItem().Version
+	return nil
+}
+
+func (a *applier) applyUser(ctx context.Context, c *change, d *User) error {
+	uc := users.NewClient(a.client)
+	var opts []users.Option
+	if d.Description != "" {
+		opts = append(opts, users.WithDescription(d.Description))
+	}
+	if c.op == opCreate {
+		res, err := uc.Create(ctx, a.scopeIds[c.scope.path], append(opts, users.WithName(d.Name))...)
+		if err != nil {
+			return err
+		}
+		d.id, d.version = res.GetItem().Id, res.GetItem().Version
+		a.principalIds[refKey(kindUser, c.scope.path, d.Name)] = d.id
+		return nil
+	}
+	res, err := uc.Update(ctx, d.id, d.version, opts...)
+	if err != nil {
+		return err
+	}
+	d.version = res.GetItem().Version
+	return nil
+}
+
+func (a *applier) applyGroup(ctx context.Context, c *change, d *Group) error {
+	gc := groups.NewClient(a.client)
+	fields := changedFields(c)
+	var opts []groups.Option
+	if fields["description"] {
+		opts = append(opts, groups.WithDescription(d.Description))
+	}
+	switch {
+	case c.op == opCreate:
+		res, err := gc.Create(ctx, a.scopeIds[c.scope.path], append(opts, groups.WithName(d.Name))...)
+		if err != nil {
+			return err
+		}
+		d.id, d.version = res.GetItem().Id, res.GetItem().Version
+		a.principalIds[refKey(kindGroup, c.scope.path, d.Name)] = d.id
+	case len(opts) > 0:
+		res, err := gc.Update(ctx, d.id, d.version, opts...)
+		if err != nil {
+			return err
+		}
+		d.version = res.GetItem().Version
+	}
+	if fields["member"] {
+		ids, err := a.resolveRefs(d.Members)
+		if err != nil {
+			return err
+		}
+		res, err := gc.SetMembers(ctx, d.id, d.version, ids)
+		if err != nil {
+			return err
+		}
+		d.version = res.GetItem().Version
+	}
+	return nil
+}
+
+func (a *applier) applyRole(ctx context.Context, c *change, d *Role) error {
+	rc := roles.NewClient(a.client)
+	fields := changedFields(c)
+	var opts []roles.Option
+	if fields["description"] {
+		opts = append(opts, roles.WithDescription(d.Description))
+	}
+	switch {
+	case c.op == opCreate:
+		res, err := rc.Create(ctx, a.scopeIds[c.scope.path], append(opts, roles.WithName(d.Name))...)
+		if err != nil {
+			return err
+		}
+		d.id, d.version = res.GetItem().Id, res.GetItem().Version
+	case len(opts) > 0:
+		res, err := rc.Update(ctx, d.id, d.version, opts...)
+		if err != nil {
+			return err
+		}
+		d.version = res.GetItem().Version
+	}
+	if fields["grant_scopes"] {
+		ids := make([]string, 0, len(d.GrantScopes))
+		for _, gs := range d.GrantScopes {
+			switch gs {
+			case globals.GrantScopeThis, globals.GrantScopeChildren, globals.GrantScopeDescendants:
+				ids = append(ids, gs)
+			default:
+				id, ok := a.scopeIds[gs]
+				if !ok {
+					return fmt.Errorf("scope %q not found", gs)
+				}
+				ids = append(ids, id)
+			}
+		}
+		res, err := rc.SetGrantScopes(ctx, d.id, d.version, ids)
+		if err != nil {
+			return err
+		}
+		d.version = res.GetItem().Version
+	}
+	if fields["grants"] {
+		res, err := rc.SetGrants(ctx, d.id, d.version, d.Grants)
+		if err != nil {
+			return err
+		}
+		d.version = res.GetItem().Version
+	}
+	if fields["principal"] {
+		ids, err := a.resolveRefs(d.Principals)
+		if err != nil {
+			return err
+		}
+		res, err := rc.SetPrincipals(ctx, d.id, d.version, ids)
+		if err != nil {
+			return err
+		}
+		d.version = res.GetItem().Version
+	}
+	return nil
+}
+
+func (a *applier) applyHostCatalog(ctx context.Context, c *change, d *HostCatalog) error {
+	hcc := hostcatalogs.NewClient(a.client)
+	fields := changedFields(c)
+	var opts []hostcatalogs.Option
+	if fields["description"] {
+		opts = append(opts, hostcatalogs.WithDescription(d.Description))
+	}
+	if attrs := changedAttributes(fields, d.Attributes); attrs != nil {
+		opts = append(opts, hostcatalogs.WithAttributes(attrs))
+	}
+	switch {
+	case c.op == opCreate:
+		opts = append(opts, hostcatalogs.WithName(d.Name))
+		if d.Plugin != "" {
+			opts = append(opts, hostcatalogs.WithPluginName(d.Plugin))
+		}
+		res, err := hcc.Create(ctx, d.Type, a.scopeIds[c.scope.path], opts...)
+		if err != nil {
+			return err
+		}
+		d.id, d.version = res.GetItem().Id, res.GetItem().Version
+	case len(opts) > 0:
+		res, err := hcc.Update(ctx, d.id, d.version, opts...)
+		if err != nil {
+			return err
+		}
+		d.version = res.GetItem().Version
+	}
+	if fields["tags"] {
+		res, err := hcc.SetTags(ctx, d.id, d.version, d.Tags)
+		if err != nil {
+			return err
+		}
+		d.version = res.GetItem().Version
+	}
+	return nil
+}
+
+func (a *applier) applyCredentialStore(ctx context.Context, c *change, d *CredentialStore) error {
+	csc := credentialstores.NewClient(a.client)
+	fields := changedFields(c)
+	var opts []credentialstores.Option
+	if fields["description"] {
+		opts = append(opts, credentialstores.WithDescription(d.Description))
+	}
+	if attrs := changedAttributes(fields, d.Attributes); attrs != nil {
+		opts = append(opts, credentialstores.WithAttributes(attrs))
+	}
+	switch {
+	case c.op == opCreate:
+		res, err := csc.Create(ctx, d.Type, a.scopeIds[c.scope.path], append(opts, credentialstores.WithName(d.Name))...)
+		if err != nil {
+			return err
+		}
+		d.id, d.version = res.GetItem().Id, res.GetItem().Version
+	case len(opts) > 0:
+		res, err := csc.Update(ctx, d.id, d.version, opts...)
+		if err != nil {
+			return err
+		}
+		d.version = res.GetItem().Version
+	}
+	if fields["tags"] {
+		res, err := csc.SetTags(ctx, d.id, d.version, d.Tags)
+		if err != nil {
+			return err
+		}
+		d.version = res.GetItem().Version
+	}
+	return nil
+}
+
+func (a *applier) applyTarget(ctx context.Context, c *change, d *Target) error {
+	tc := targets.NewClient(a.client)
+	fields := changedFields(c)
+	var opts []targets.Option
+	for f := range fields {
+		switch f {
+		case "description":
+			opts = append(opts, targets.WithDescription(d.Description))
+		case "address":
+			opts = append(opts, targets.WithAddress(d.Address))
+		case "session_max_seconds":
+			opts = append(opts, targets.WithSessionMaxSeconds(d.SessionMaxSeconds))
+		case "session_connection_limit":
+			opts = append(opts, targets.WithSessionConnectionLimit(d.SessionConnectionLimit))
+		case "session_max_bytes":
+			opts = append(opts, targets.WithSessionMaxBytes(d.SessionMaxBytes))
+		case "connection_max_bytes_per_second":
+			opts = append(opts, targets.WithConnectionMaxBytesPerSecond(d.ConnectionMaxBytesPerSecond))
+		case "connection_idle_timeout_seconds":
+			opts = append(opts, targets.WithConnectionIdleTimeoutSeconds(d.ConnectionIdleTimeoutSeconds))
+		case "worker_filter":
+			opts = append(opts, targets.WithWorkerFilter(d.WorkerFilter))
+		case "egress_worker_filter":
+			opts = append(opts, targets.WithEgressWorkerFilter(d.EgressWorkerFilter))
+		case "ingress_worker_filter":
+			opts = append(opts, targets.WithIngressWorkerFilter(d.IngressWorkerFilter))
+		}
+	}
+	if attrs := changedAttributes(fields, d.Attributes); attrs != nil {
+		opts = append(opts, targets.WithAttributes(attrs))
+	}
+	switch {
+	case c.op == opCreate:
+		res, err := tc.Create(ctx, d.Type, a.scopeIds[c.scope.path], append(opts, targets.WithName(d.Name))...)
+		if err != nil {
+			return err
+		}
+		d.id, d.version = res.GetItem().Id, res.GetItem().Version
+	case len(opts) > 0:
+		res, err := tc.Update(ctx, d.id, d.version, opts...)
+		if err != nil {
+			return err
+		}
+		d.version = res.GetItem().Version
+	}
+	if fields["tags"] {
+		res, err := tc.SetTags(ctx, d.id, d.version, d.Tags)
+		if err != nil {
+			return err
+		}
+		d.version = res.GetItem().Version
+	}
+	return nil
+}
+
+// resolveRefs returns the IDs of the users or groups referred to.
+func (a *applier) resolveRefs(refs []*Ref) ([]string, error) {
+	ids := make([]string, 0, len(refs))
+	var missing []string
+	for _, r := range refs {
+		if r.Id != "" {
+			ids = append(ids, r.Id)
+			continue
+		}
+		id, ok := a.principalIds[refKey(r.Type, r.Scope, r.Name)]
+		if !ok {
+			missing = append(missing, r.String())
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%s not found", strings.Join(missing, ", "))
+	}
+	return ids, nil
+}
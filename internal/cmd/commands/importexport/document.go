@@ -0,0 +1,366 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package importexport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/hcl"
+)
+
+// globalScopePath is the path of the global scope in a document. Orgs are
+// referred to by their name and projects by "<org name>/<project name>".
+const globalScopePath = "global"
+
+// Document is the declarative representation of the configuration of a
+// Boundary cluster written by "boundary export" and applied by "boundary
+// import". Resources are identified by their name within their scope rather
+// than by ID so that a document can be applied to another cluster. The key
+// names follow HCL block conventions so that a document can be written as
+// either HCL or JSON; see ParseDocument.
+type Document struct {
+	Global *Scope `json:"global"`
+}
+
+// Scope is a scope and the resources within it. The global scope contains
+// orgs and orgs contain projects.
+type Scope struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// PrimaryAuthMethod is the name of the scope's primary auth method.
+	PrimaryAuthMethod string `json:"primary_auth_method,omitempty"`
+	// Quotas maps resource types to the quota set for them on the scope.
+	Quotas map[string]uint32 `json:"quotas,omitempty"`
+
+	AuthMethods      []*AuthMethod      `json:"auth_method,omitempty"`
+	Users            []*User            `json:"user,omitempty"`
+	Groups           []*Group           `json:"group,omitempty"`
+	Roles            []*Role            `json:"role,omitempty"`
+	HostCatalogs     []*HostCatalog     `json:"host_catalog,omitempty"`
+	CredentialStores []*CredentialStore `json:"credential_store,omitempty"`
+	Targets          []*Target          `json:"target,omitempty"`
+	Scopes           []*Scope           `json:"scope,omitempty"`
+
+	id      string
+	version uint32
+	path    string
+}
+
+// User is a user. Accounts are not part of the document.
+type User struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	id      string
+	version uint32
+}
+
+// Group is a group and its members, which must be users.
+type Group struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Members     []*Ref `json:"member,omitempty"`
+
+	id      string
+	version uint32
+}
+
+// Role is a role, the scopes its grants apply to, its grants and its
+// principals. Grant scopes are scope paths or one of "this", "children" or
+// "descendants". Grants are kept as written, so grants naming resources by ID
+// need to be updated when the document is applied to another cluster.
+type Role struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	GrantScopes []string `json:"grant_scopes,omitempty"`
+	Grants      []string `json:"grants,omitempty"`
+	Principals  []*Ref   `json:"principal,omitempty"`
+
+	id      string
+	version uint32
+}
+
+// Ref refers to a user or group by its scope path and name. The built-in
+// users, which are the same in every cluster, are referred to by ID instead.
+type Ref struct {
+	Type  string `json:"type,omitempty"`
+	Scope string `json:"scope,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Id    string `json:"id,omitempty"`
+}
+
+// AuthMethod is an auth method. Secrets, such as an OIDC client secret, are
+// not exported and need to be set after the auth method is imported.
+type AuthMethod struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Type        string         `json:"type"`
+	Attributes  map[string]any `json:"attributes,omitempty"`
+
+	id      string
+	version uint32
+}
+
+// HostCatalog is a host catalog. Plugin secrets are not exported.
+type HostCatalog struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Type        string              `json:"type"`
+	Plugin      string              `json:"plugin,omitempty"`
+	Attributes  map[string]any      `json:"attributes,omitempty"`
+	Tags        map[string][]string `json:"tags,omitempty"`
+
+	id      string
+	version uint32
+}
+
+// CredentialStore is a credential store. Secrets, such as a Vault token, are
+// not exported and need to be set after the credential store is imported.
+type CredentialStore struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Type        string              `json:"type"`
+	Attributes  map[string]any      `json:"attributes,omitempty"`
+	Tags        map[string][]string `json:"tags,omitempty"`
+
+	id      string
+	version uint32
+}
+
+// Target is a target. Host sources and credential sources are not part of
+// the document.
+type Target struct {
+	Name                         string              `json:"name"`
+	Description                  string              `json:"description,omitempty"`
+	Type                         string              `json:"type"`
+	Address                      string              `json:"address,omitempty"`
+	Attributes                   map[string]any      `json:"attributes,omitempty"`
+	SessionMaxSeconds            uint32              `json:"session_max_seconds,omitempty"`
+	SessionConnectionLimit       int32               `json:"session_connection_limit,omitempty"`
+	SessionMaxBytes              int64               `json:"session_max_bytes,omitempty"`
+	ConnectionMaxBytesPerSecond  int64               `json:"connection_max_bytes_per_second,omitempty"`
+	ConnectionIdleTimeoutSeconds uint32              `json:"connection_idle_timeout_seconds,omitempty"`
+	WorkerFilter                 string              `json:"worker_filter,omitempty"`
+	EgressWorkerFilter           string              `json:"egress_worker_filter,omitempty"`
+	IngressWorkerFilter          string              `json:"ingress_worker_filter,omitempty"`
+	Tags                         map[string][]string `json:"tags,omitempty"`
+
+	id      string
+	version uint32
+}
+
+// outputOnlyAttributes are the attributes returned by the API which are
+// either read only or HMACs of secrets, and so are left out of documents.
+var outputOnlyAttributes = map[string]bool{
+	"callback_url": true,
+	"state":        true,
+	"token_status": true,
+}
+
+// exportAttributes returns a copy of the attributes of a resource without
+// the attributes that cannot be written.
+func exportAttributes(in map[string]any) map[string]any {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]any, len(in))
+	for k, v := range in {
+		if outputOnlyAttributes[k] || strings.HasSuffix(k, "_hmac") {
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// listBlocks are the keys of the blocks that can be repeated.
+var listBlocks = map[string]bool{
+	"auth_method":      true,
+	"user":             true,
+	"group":            true,
+	"role":             true,
+	"host_catalog":     true,
+	"credential_store": true,
+	"target":           true,
+	"scope":            true,
+	"member":           true,
+	"principal":        true,
+}
+
+// ParseDocument parses a document in HCL or JSON format and validates it.
+// HCL is decoded generically and converted to JSON, as HCL represents every
+// block as a list, so that both formats are decoded the same way and unknown
+// keys are rejected in either.
+func ParseDocument(d string) (*Document, error) {
+	raw := []byte(d)
+	if !json.Valid(raw) {
+		var m map[string]any
+		if err := hcl.Decode(&m, d); err != nil {
+			return nil, fmt.Errorf("Error parsing document: %w", err)
+		}
+		var err error
+		if raw, err = json.Marshal(unwrapBlocks("", m, false)); err != nil {
+			return nil, fmt.Errorf("Error parsing document: %w", err)
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	doc := new(Document)
+	if err := dec.Decode(doc); err != nil {
+		return nil, fmt.Errorf("Error parsing document: %w", err)
+	}
+	if doc.Global == nil {
+		return nil, errors.New("Document does not contain the global scope")
+	}
+	if err := doc.Global.prepare(globalScopePath, 0); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// unwrapBlocks replaces the single element lists HCL decodes blocks and
+// objects into with the element, except for blocks that can be repeated.
+// Within attributes, all single element lists of objects are unwrapped.
+func unwrapBlocks(key string, v any, inAttributes bool) any {
+	switch t := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, e := range t {
+			out[k] = unwrapBlocks(k, e, inAttributes || k == "attributes")
+		}
+		return out
+	case []map[string]any:
+		if len(t) == 1 && (inAttributes || !listBlocks[key]) {
+			return unwrapBlocks(key, t[0], inAttributes)
+		}
+		out := make([]any, 0, len(t))
+		for _, e := range t {
+			out = append(out, unwrapBlocks(key, e, inAttributes))
+		}
+		return out
+	case []any:
+		out := make([]any, 0, len(t))
+		for _, e := range t {
+			out = append(out, unwrapBlocks(key, e, inAttributes))
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// prepare sets the paths of the scope and its children and checks that the names within it are set
+// and unique. depth is 0 for the global scope, 1 for orgs and 2 for projects.
+func (s *Scope) prepare(path string, depth int) error {
+	s.path = path
+	names := func(kind string, n int, name func(int) string) error {
+		seen := make(map[string]bool, n)
+		for i := 0; i < n; i++ {
+			nm := name(i)
+			switch {
+			case nm == "":
+				return fmt.Errorf("A %s in scope %q is missing a name", kind, path)
+			case seen[nm]:
+				return fmt.Errorf("The %s %q is defined more than once in scope %q", kind, nm, path)
+			}
+			seen[nm] = true
+		}
+		return nil
+	}
+
+	if depth == 2 && (len(s.AuthMethods) > 0 || len(s.Users) > 0) {
+		return fmt.Errorf("Project %q cannot contain auth methods or users", path)
+	}
+	if depth < 2 && (len(s.HostCatalogs) > 0 || len(s.CredentialStores) > 0 || len(s.Targets) > 0) {
+		return fmt.Errorf("Scope %q cannot contain host catalogs, credential stores or targets; only projects can", path)
+	}
+	if depth == 2 && len(s.Scopes) > 0 {
+		return fmt.Errorf("Project %q cannot contain scopes", path)
+	}
+
+	for _, err := range []error{
+		names("auth method", len(s.AuthMethods), func(i int) string { return s.AuthMethods[i].Name }),
+		names("user", len(s.Users), func(i int) string { return s.Users[i].Name }),
+		names("group", len(s.Groups), func(i int) string { return s.Groups[i].Name }),
+		names("role", len(s.Roles), func(i int) string { return s.Roles[i].Name }),
+		names("host catalog", len(s.HostCatalogs), func(i int) string { return s.HostCatalogs[i].Name }),
+		names("credential store", len(s.CredentialStores), func(i int) string { return s.CredentialStores[i].Name }),
+		names("target", len(s.Targets), func(i int) string { return s.Targets[i].Name }),
+		names("scope", len(s.Scopes), func(i int) string { return s.Scopes[i].Name }),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, g := range s.Groups {
+		for _, m := range g.Members {
+			if m.Type == "" {
+				m.Type = "user"
+			}
+			if err := m.validate("user"); err != nil {
+				return fmt.Errorf("Group %q in scope %q: %w", g.Name, path, err)
+			}
+		}
+	}
+	for _, r := range s.Roles {
+		for _, p := range r.Principals {
+			if err := p.validate("user", "group"); err != nil {
+				return fmt.Errorf("Role %q in scope %q: %w", r.Name, path, err)
+			}
+		}
+	}
+
+	for _, c := range s.Scopes {
+		if strings.Contains(c.Name, "/") {
+			return fmt.Errorf("Scope name %q cannot contain a \"/\"", c.Name)
+		}
+		childPath := c.Name
+		if depth == 1 {
+			childPath = path + "/" + c.Name
+		}
+		if err := c.prepare(childPath, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Ref) validate(types ...string) error {
+	valid := false
+	for _, t := range types {
+		if r.Type == t {
+			valid = true
+		}
+	}
+	switch {
+	case !valid:
+		return fmt.Errorf("principal type must be one of %s, got %q", strings.Join(types, ", "), r.Type)
+	case r.Id != "":
+		if r.Scope != "" || r.Name != "" {
+			return fmt.Errorf("reference to %q cannot also have a scope or name", r.Id)
+		}
+		switch r.Id {
+		case globals.AnonymousUserId, globals.AnyAuthenticatedUserId, globals.RecoveryUserId:
+		default:
+			return fmt.Errorf("only built-in users can be referred to by ID, got %q", r.Id)
+		}
+	case r.Scope == "" || r.Name == "":
+		return errors.New("references must have a scope and a name")
+	}
+	return nil
+}
+
+// String returns the form a reference is shown in when printing a plan.
+func (r *Ref) String() string {
+	if r.Id != "" {
+		return r.Id
+	}
+	return fmt.Sprintf("%s %s/%s", r.Type, r.Scope, r.Name)
+}
@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package importexport
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHclDocument = `
+global {
+  name                = "Global"
+  primary_auth_method = "password"
+
+  auth_method {
+    name = "password"
+    type = "password"
+    attributes {
+      min_login_name_length = 5
+    }
+  }
+
+  user {
+    name = "admin"
+  }
+
+  role {
+    name         = "admins"
+    grant_scopes = ["this", "descendants"]
+    grants       = ["ids=*;type=*;actions=*"]
+    principal {
+      type  = "user"
+      scope = "global"
+      name  = "admin"
+    }
+    principal {
+      type = "user"
+      id   = "u_anon"
+    }
+  }
+
+  scope {
+    name = "engineering"
+    quotas = {
+      target = 10
+    }
+
+    group {
+      name = "developers"
+      member {
+        scope = "global"
+        name  = "admin"
+      }
+    }
+
+    scope {
+      name = "prod"
+
+      target {
+        name                = "db"
+        type                = "tcp"
+        address             = "10.0.0.1"
+        session_max_seconds = 3600
+        attributes {
+          default_port = 5432
+        }
+        tags = {
+          env = ["prod"]
+        }
+      }
+    }
+  }
+}
+`
+
+func TestParseDocument(t *testing.T) {
+	t.Parallel()
+
+	doc, err := ParseDocument(testHclDocument)
+	require.NoError(t, err)
+
+	g := doc.Global
+	assert.Equal(t, "global", g.path)
+	assert.Equal(t, "password", g.PrimaryAuthMethod)
+	require.Len(t, g.AuthMethods, 1)
+	assert.Equal(t, map[string]any{"min_login_name_length": float64(5)}, g.AuthMethods[0].Attributes)
+	require.Len(t, g.Roles, 1)
+	assert.Equal(t, []*Ref{
+		{Type: "user", Scope: "global", Name: "admin"},
+		{Type: "user", Id: "u_anon"},
+	}, g.Roles[0].Principals)
+
+	require.Len(t, g.Scopes, 1)
+	org := g.Scopes[0]
+	assert.Equal(t, "engineering", org.path)
+	assert.Equal(t, map[string]uint32{"target": 10}, org.Quotas)
+	require.Len(t, org.Groups, 1)
+	assert.Equal(t, []*Ref{{Type: "user", Scope: "global", Name: "admin"}}, org.Groups[0].Members)
+
+	require.Len(t, org.Scopes, 1)
+	proj := org.Scopes[0]
+	assert.Equal(t, "engineering/prod", proj.path)
+	require.Len(t, proj.Targets, 1)
+	tgt := proj.Targets[0]
+	assert.Equal(t, "10.0.0.1", tgt.Address)
+	assert.Equal(t, uint32(3600), tgt.SessionMaxSeconds)
+	assert.Equal(t, map[string]any{"default_port": float64(5432)}, tgt.Attributes)
+	assert.Equal(t, map[string][]string{"env": {"prod"}}, tgt.Tags)
+
+	// A document written as JSON parses to the same document
+	js, err := json.Marshal(doc)
+	require.NoError(t, err)
+	jsDoc, err := ParseDocument(string(js))
+	require.NoError(t, err)
+	assert.Equal(t, toMap(doc), toMap(jsDoc))
+	assert.Equal(t, "engineering/prod", jsDoc.Global.Scopes[0].Scopes[0].path)
+}
+
+func TestParseDocument_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{
+			name:    "no-global",
+			doc:     `{}`,
+			wantErr: "Document does not contain the global scope",
+		},
+		{
+			name:    "unknown-key",
+			doc:     `global { user { name = "u" email = "u@example.com" } }`,
+			wantErr: `Error parsing document: json: unknown field "email"`,
+		},
+		{
+			name:    "missing-name",
+			doc:     `global { user { description = "x" } }`,
+			wantErr: `A user in scope "global" is missing a name`,
+		},
+		{
+			name:    "duplicate-name",
+			doc:     `global { role { name = "r" } role { name = "r" } }`,
+			wantErr: `The role "r" is defined more than once in scope "global"`,
+		},
+		{
+			name:    "target-in-org",
+			doc:     `global { scope { name = "o" target { name = "t" type = "tcp" } } }`,
+			wantErr: `Scope "o" cannot contain host catalogs, credential stores or targets; only projects can`,
+		},
+		{
+			name:    "user-in-project",
+			doc:     `global { scope { name = "o" scope { name = "p" user { name = "u" } } } }`,
+			wantErr: `Project "o/p" cannot contain auth methods or users`,
+		},
+		{
+			name:    "slash-in-scope-name",
+			doc:     `global { scope { name = "a/b" } }`,
+			wantErr: `Scope name "a/b" cannot contain a "/"`,
+		},
+		{
+			name:    "group-member-group",
+			doc:     `global { group { name = "g" member { type = "group" scope = "global" name = "h" } } }`,
+			wantErr: `Group "g" in scope "global": principal type must be one of user, got "group"`,
+		},
+		{
+			name:    "non-builtin-id",
+			doc:     `global { role { name = "r" principal { type = "user" id = "u_1234567890" } } }`,
+			wantErr: `Role "r" in scope "global": only built-in users can be referred to by ID, got "u_1234567890"`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseDocument(tt.doc)
+			require.Error(t, err)
+			assert.Equal(t, tt.wantErr, err.Error())
+		})
+	}
+}
@@ -0,0 +1,448 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package importexport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/api/credentialstores"
+	"github.com/hashicorp/boundary/api/groups"
+	"github.com/hashicorp/boundary/api/hostcatalogs"
+	"github.com/hashicorp/boundary/api/roles"
+	"github.com/hashicorp/boundary/api/scopes"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/api/users"
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ExportCommand)(nil)
+	_ cli.CommandAutocomplete = (*ExportCommand)(nil)
+)
+
+// ExportCommand writes the configuration of a cluster as a document.
+type ExportCommand struct {
+	*base.Command
+
+	flagOutput string
+}
+
+func (c *ExportCommand) Synopsis() string {
+	return wordwrap.WrapString("Export scopes, IAM resources and targets as a declarative document", base.TermWidth)
+}
+
+func (c *ExportCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary export [options]",
+		"",
+		"  Export the scopes, users, groups, roles, auth methods, host catalogs, credential stores and targets visible to the caller as a JSON document that can be applied to a cluster with \"boundary import\". Resources are identified by name, so resources without a name are skipped. Secrets, accounts, hosts, credential libraries and the host and credential sources of targets are not exported. Example:",
+		"",
+		`    $ boundary export -output boundary.json`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ExportCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:       "output",
+		Target:     &c.flagOutput,
+		Completion: complete.PredictFiles("*"),
+		Usage:      "The file to write the document to. If not set, the document is written to standard output.",
+	})
+
+	return set
+}
+
+func (c *ExportCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ExportCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ExportCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	st, err := readState(c.Context, client)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when reading configuration")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error reading configuration: %w", err))
+		return base.CommandCliError
+	}
+	for _, w := range st.warnings {
+		c.UI.Warn(w)
+	}
+
+	out, err := json.MarshalIndent(st.doc, "", "  ")
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error encoding document: %w", err))
+		return base.CommandCliError
+	}
+	if c.flagOutput == "" {
+		c.UI.Output(string(out))
+		return base.CommandSuccess
+	}
+	if err := os.WriteFile(c.flagOutput, append(out, '\n'), 0o600); err != nil {
+		c.PrintCliError(fmt.Errorf("Error writing document: %w", err))
+		return base.CommandCliError
+	}
+	return base.CommandSuccess
+}
+
+// state is the configuration read from a cluster. Besides the document, it
+// indexes scopes, users and groups by ID so that references can be resolved
+// in either direction.
+type state struct {
+	doc      *Document
+	scopes   map[string]*Scope
+	users    map[string]*Ref
+	groups   map[string]*Ref
+	warnings []string
+}
+
+func (s *state) warnf(format string, a ...any) {
+	s.warnings = append(s.warnings, fmt.Sprintf(format, a...))
+}
+
+// readState reads the configuration visible to the client, recursively from
+// the global scope.
+func readState(ctx context.Context, client *api.Client) (*state, error) {
+	st := &state{
+		scopes: make(map[string]*Scope),
+		users:  make(map[string]*Ref),
+		groups: make(map[string]*Ref),
+	}
+	recursive := scopes.WithRecursive(true)
+
+	// Scopes
+	sc := scopes.NewClient(client)
+	gr, err := sc.Read(ctx, globals.GlobalPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("error reading global scope: %w", err)
+	}
+	global := scopeFromApi(gr.GetItem(), globalScopePath)
+	st.doc = &Document{Global: global}
+	st.scopes[globals.GlobalPrefix] = global
+	sl, err := sc.List(ctx, globals.GlobalPrefix, recursive)
+	if err != nil {
+		return nil, fmt.Errorf("error listing scopes: %w", err)
+	}
+	primaryAuthMethodIds := map[*Scope]string{global: gr.GetItem().PrimaryAuthMethodId}
+	// Orgs are listed before their projects are placed
+	items := sl.GetItems()
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].ScopeId == globals.GlobalPrefix && items[j].ScopeId != globals.GlobalPrefix
+	})
+	for _, item := range items {
+		parent, ok := st.scopes[item.ScopeId]
+		if !ok {
+			continue
+		}
+		if item.Name == "" {
+			st.warnf("Skipping scope %s and the resources in it as it has no name", item.Id)
+			continue
+		}
+		path := item.Name
+		if parent != global {
+			path = parent.path + "/" + item.Name
+		}
+		s := scopeFromApi(item, path)
+		parent.Scopes = append(parent.Scopes, s)
+		st.scopes[item.Id] = s
+		primaryAuthMethodIds[s] = item.PrimaryAuthMethodId
+	}
+
+	// Auth methods
+	aml, err := authmethods.NewClient(client).List(ctx, globals.GlobalPrefix, authmethods.WithRecursive(true))
+	if err != nil {
+		return nil, fmt.Errorf("error listing auth methods: %w", err)
+	}
+	authMethodNames := make(map[string]string)
+	for _, item := range aml.GetItems() {
+		s, ok := st.scopeFor(item.ScopeId, "auth method", item.Id, item.Name)
+		if !ok {
+			continue
+		}
+		authMethodNames[item.Id] = item.Name
+		s.AuthMethods = append(s.AuthMethods, &AuthMethod{
+			Name:        item.Name,
+			Description: item.Description,
+			Type:        item.Type,
+			Attributes:  exportAttributes(item.Attributes),
+			id:          item.Id,
+			version:     item.Version,
+		})
+	}
+	for s, id := range primaryAuthMethodIds {
+		s.PrimaryAuthMethod = authMethodNames[id]
+	}
+
+	// Users
+	ul, err := users.NewClient(client).List(ctx, globals.GlobalPrefix, users.WithRecursive(true))
+	if err != nil {
+		return nil, fmt.Errorf("error listing users: %w", err)
+	}
+	for _, item := range ul.GetItems() {
+		switch item.Id {
+		case globals.AnonymousUserId, globals.AnyAuthenticatedUserId, globals.RecoveryUserId:
+			st.users[item.Id] = &Ref{Type: "user", Id: item.Id}
+			continue
+		}
+		s, ok := st.scopeFor(item.ScopeId, "user", item.Id, item.Name)
+		if !ok {
+			continue
+		}
+		st.users[item.Id] = &Ref{Type: "user", Scope: s.path, Name: item.Name}
+		s.Users = append(s.Users, &User{
+			Name:        item.Name,
+			Description: item.Description,
+			id:          item.Id,
+			version:     item.Version,
+		})
+	}
+
+	// Groups
+	gc := groups.NewClient(client)
+	gl, err := gc.List(ctx, globals.GlobalPrefix, groups.WithRecursive(true))
+	if err != nil {
+		return nil, fmt.Errorf("error listing groups: %w", err)
+	}
+	for _, item := range gl.GetItems() {
+		s, ok := st.scopeFor(item.ScopeId, "group", item.Id, item.Name)
+		if !ok {
+			continue
+		}
+		r, err := gc.Read(ctx, item.Id)
+		if err != nil {
+			return nil, fmt.Errorf("error reading group %s: %w", item.Id, err)
+		}
+		st.groups[item.Id] = &Ref{Type: "group", Scope: s.path, Name: item.Name}
+		g := &Group{
+			Name:        item.Name,
+			Description: item.Description,
+			id:          item.Id,
+			version:     r.GetItem().Version,
+		}
+		for _, id := range r.GetItem().MemberIds {
+			ref, ok := st.users[id]
+			if !ok {
+				st.warnf("Skipping member %s of group %s as the user is not exported", id, item.Id)
+				continue
+			}
+			g.Members = append(g.Members, ref)
+		}
+		s.Groups = append(s.Groups, g)
+	}
+
+	// Roles
+	rc := roles.NewClient(client)
+	rl, err := rc.List(ctx, globals.GlobalPrefix, roles.WithRecursive(true))
+	if err != nil {
+		return nil, fmt.Errorf("error listing roles: %w", err)
+	}
+	for _, item := range rl.GetItems() {
+		s, ok := st.scopeFor(item.ScopeId, "role", item.Id, item.Name)
+		if !ok {
+			continue
+		}
+		r, err := rc.Read(ctx, item.Id)
+		if err != nil {
+			return nil, fmt.Errorf("error reading role %s: %w", item.Id, err)
+		}
+		role := &Role{
+			Name:        item.Name,
+			Description: item.Description,
+			Grants:      r.GetItem().GrantStrings,
+			id:          item.Id,
+			version:     r.GetItem().Version,
+		}
+		for _, id := range r.GetItem().GrantScopeIds {
+			switch id {
+			case globals.GrantScopeThis, globals.GrantScopeChildren, globals.GrantScopeDescendants:
+				role.GrantScopes = append(role.GrantScopes, id)
+			default:
+				gs, ok := st.scopes[id]
+				if !ok {
+					st.warnf("Skipping grant scope %s of role %s as the scope is not exported", id, item.Id)
+					continue
+				}
+				role.GrantScopes = append(role.GrantScopes, gs.path)
+			}
+		}
+		for _, p := range r.GetItem().Principals {
+			ref, ok := st.users[p.Id]
+			if !ok {
+				ref, ok = st.groups[p.Id]
+			}
+			if !ok {
+				st.warnf("Skipping principal %s of role %s as only exported users and groups can be principals in a document", p.Id, item.Id)
+				continue
+			}
+			role.Principals = append(role.Principals, ref)
+		}
+		s.Roles = append(s.Roles, role)
+	}
+
+	// Host catalogs
+	hcList, err := hostcatalogs.NewClient(client).List(ctx, globals.GlobalPrefix, hostcatalogs.WithRecursive(true))
+	if err != nil {
+		return nil, fmt.Errorf("error listing host catalogs: %w", err)
+	}
+	for _, item := range hcList.GetItems() {
+		s, ok := st.scopeFor(item.ScopeId, "host catalog", item.Id, item.Name)
+		if !ok {
+			continue
+		}
+		hc := &HostCatalog{
+			Name:        item.Name,
+			Description: item.Description,
+			Type:        item.Type,
+			Attributes:  exportAttributes(item.Attributes),
+			Tags:        item.ResourceTags,
+			id:          item.Id,
+			version:     item.Version,
+		}
+		if item.Plugin != nil {
+			hc.Plugin = item.Plugin.Name
+		}
+		s.HostCatalogs = append(s.HostCatalogs, hc)
+	}
+
+	// Credential stores
+	csl, err := credentialstores.NewClient(client).List(ctx, globals.GlobalPrefix, credentialstores.WithRecursive(true))
+	if err != nil {
+		return nil, fmt.Errorf("error listing credential stores: %w", err)
+	}
+	for _, item := range csl.GetItems() {
+		s, ok := st.scopeFor(item.ScopeId, "credential store", item.Id, item.Name)
+		if !ok {
+			continue
+		}
+		s.CredentialStores = append(s.CredentialStores, &CredentialStore{
+			Name:        item.Name,
+			Description: item.Description,
+			Type:        item.Type,
+			Attributes:  exportAttributes(item.Attributes),
+			Tags:        item.ResourceTags,
+			id:          item.Id,
+			version:     item.Version,
+		})
+	}
+
+	// Targets
+	tl, err := targets.NewClient(client).List(ctx, globals.GlobalPrefix, targets.WithRecursive(true))
+	if err != nil {
+		return nil, fmt.Errorf("error listing targets: %w", err)
+	}
+	for _, item := range tl.GetItems() {
+		s, ok := st.scopeFor(item.ScopeId, "target", item.Id, item.Name)
+		if !ok {
+			continue
+		}
+		s.Targets = append(s.Targets, &Target{
+			Name:                         item.Name,
+			Description:                  item.Description,
+			Type:                         item.Type,
+			Address:                      item.Address,
+			Attributes:                   exportAttributes(item.Attributes),
+			SessionMaxSeconds:            item.SessionMaxSeconds,
+			SessionConnectionLimit:       item.SessionConnectionLimit,
+			SessionMaxBytes:              item.SessionMaxBytes,
+			ConnectionMaxBytesPerSecond:  item.ConnectionMaxBytesPerSecond,
+			ConnectionIdleTimeoutSeconds: item.ConnectionIdleTimeoutSeconds,
+			WorkerFilter:                 item.WorkerFilter,
+			EgressWorkerFilter:           item.EgressWorkerFilter,
+			IngressWorkerFilter:          item.IngressWorkerFilter,
+			Tags:                         item.ResourceTags,
+			id:                           item.Id,
+			version:                      item.Version,
+		})
+	}
+
+	global.sort()
+	return st, nil
+}
+
+// scopeFor returns the exported scope with the given ID if a resource in it
+// can be exported.
+func (s *state) scopeFor(scopeId, kind, id, name string) (*Scope, bool) {
+	sc, ok := s.scopes[scopeId]
+	if !ok {
+		return nil, false
+	}
+	if name == "" {
+		s.warnf("Skipping %s %s as it has no name", kind, id)
+		return nil, false
+	}
+	return sc, true
+}
+
+func scopeFromApi(in *scopes.Scope, path string) *Scope {
+	s := &Scope{
+		Name:        in.Name,
+		Description: in.Description,
+		id:          in.Id,
+		version:     in.Version,
+		path:        path,
+	}
+	for _, q := range in.Quotas {
+		if s.Quotas == nil {
+			s.Quotas = make(map[string]uint32, len(in.Quotas))
+		}
+		s.Quotas[q.ResourceType] = q.Limit
+	}
+	return s
+}
+
+// sort orders the contents of the scope and its children by name so that
+// exports of the same configuration are identical.
+func (s *Scope) sort() {
+	sort.Slice(s.AuthMethods, func(i, j int) bool { return s.AuthMethods[i].Name < s.AuthMethods[j].Name })
+	sort.Slice(s.Users, func(i, j int) bool { return s.Users[i].Name < s.Users[j].Name })
+	sort.Slice(s.Groups, func(i, j int) bool { return s.Groups[i].Name < s.Groups[j].Name })
+	sort.Slice(s.Roles, func(i, j int) bool { return s.Roles[i].Name < s.Roles[j].Name })
+	sort.Slice(s.HostCatalogs, func(i, j int) bool { return s.HostCatalogs[i].Name < s.HostCatalogs[j].Name })
+	sort.Slice(s.CredentialStores, func(i, j int) bool { return s.CredentialStores[i].Name < s.CredentialStores[j].Name })
+	sort.Slice(s.Targets, func(i, j int) bool { return s.Targets[i].Name < s.Targets[j].Name })
+	sort.Slice(s.Scopes, func(i, j int) bool { return s.Scopes[i].Name < s.Scopes[j].Name })
+	for _, c := range s.Scopes {
+		c.sort()
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package importexport
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ImportCommand)(nil)
+	_ cli.CommandAutocomplete = (*ImportCommand)(nil)
+)
+
+// ImportCommand applies a document to a cluster.
+type ImportCommand struct {
+	*base.Command
+
+	flagFile   string
+	flagDryRun bool
+}
+
+func (c *ImportCommand) Synopsis() string {
+	return wordwrap.WrapString("Apply a declarative document of scopes, IAM resources and targets", base.TermWidth)
+}
+
+func (c *ImportCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary import [options]",
+		"",
+		"  Apply a document in the format written by \"boundary export\", in HCL or JSON, to a cluster. Resources are matched by name within their scope. Resources in the document that do not exist are created and those that differ are updated; fields not set in the document and resources not in it are left unchanged, so importing the same document again makes no changes. Updates are made against the versions of the resources read when planning, so they fail if a resource is changed concurrently. Use -dry-run to only show the changes that would be made. Example:",
+		"",
+		`    $ boundary import -file boundary.hcl -dry-run`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ImportCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:       "file",
+		Target:     &c.flagFile,
+		Completion: complete.PredictFiles("*"),
+		Usage:      "The file containing the document to apply.",
+	})
+	f.BoolVar(&base.BoolVar{
+		Name:   "dry-run",
+		Target: &c.flagDryRun,
+		Usage:  "If set, the changes needed to apply the document are shown but not made.",
+	})
+
+	return set
+}
+
+func (c *ImportCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ImportCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ImportCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	if c.flagFile == "" {
+		c.PrintCliError(fmt.Errorf("A document must be supplied via -file"))
+		return base.CommandUserError
+	}
+	raw, err := os.ReadFile(c.flagFile)
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error reading document: %w", err))
+		return base.CommandUserError
+	}
+	doc, err := ParseDocument(string(raw))
+	if err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	st, err := readState(c.Context, client)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when reading configuration")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error reading configuration: %w", err))
+		return base.CommandCliError
+	}
+	for _, w := range st.warnings {
+		c.UI.Warn(w)
+	}
+
+	changes, err := plan(doc, st)
+	if err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	c.UI.Output(formatPlan(changes))
+	if c.flagDryRun || len(changes) == 0 {
+		return base.CommandSuccess
+	}
+
+	if err := newApplier(client, st).apply(c.Context, changes); err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when applying document")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error applying document: %w", err))
+		return base.CommandCliError
+	}
+	c.UI.Output("Document applied.")
+	return base.CommandSuccess
+}
+
+// formatPlan returns the changes in a plan, one per line, followed by a
+// summary.
+func formatPlan(changes []*change) string {
+	if len(changes) == 0 {
+		return "No changes. The cluster matches the document."
+	}
+	var creates, updates int
+	lines := make([]string, 0, len(changes)+2)
+	for _, c := range changes {
+		switch c.op {
+		case opCreate:
+			creates++
+		default:
+			updates++
+		}
+		lines = append(lines, c.String())
+	}
+	lines = append(lines, "", fmt.Sprintf("Plan: %d to create, %d to update.", creates, updates))
+	return strings.Join(lines, "\n")
+}
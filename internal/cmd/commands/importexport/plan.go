@@ -0,0 +1,405 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package importexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+)
+
+type changeOp string
+
+const (
+	opCreate changeOp = "create"
+	opUpdate changeOp = "update"
+)
+
+// Kinds of resources in a plan.
+const (
+	kindScope           = "scope"
+	kindAuthMethod      = "auth method"
+	kindUser            = "user"
+	kindGroup           = "group"
+	kindRole            = "role"
+	kindHostCatalog     = "host catalog"
+	kindCredentialStore = "credential store"
+	kindTarget          = "target"
+)
+
+// change is a single create or update of a resource needed to make a cluster
+// match a document.
+type change struct {
+	op   changeOp
+	kind string
+	// scope is the desired scope the resource is in. For scopes, it is the
+	// scope itself.
+	scope *Scope
+	// parent is the desired parent of a scope to create.
+	parent *Scope
+	name   string
+	// fields are the names of the fields to update.
+	fields []string
+	// desired is the resource in the document and current is the resource in
+	// the cluster, which is nil for creates.
+	desired any
+	current any
+}
+
+func (c *change) String() string {
+	var sb strings.Builder
+	switch c.op {
+	case opCreate:
+		sb.WriteString("+ ")
+	default:
+		sb.WriteString("~ ")
+	}
+	if c.kind == kindScope {
+		fmt.Fprintf(&sb, "scope %q", c.scope.path)
+	} else {
+		fmt.Fprintf(&sb, "%s %q in scope %q", c.kind, c.name, c.scope.path)
+	}
+	if len(c.fields) > 0 {
+		fmt.Fprintf(&sb, ": %s", strings.Join(c.fields, ", "))
+	}
+	return sb.String()
+}
+
+// planner compares a document with the state of a cluster.
+type planner struct {
+	// scopes are the current scopes by path
+	scopes map[string]*Scope
+	// known holds the paths of all scopes and the "<path>/<name>" keys of all
+	// users and groups in either the document or the cluster, so that
+	// references can be checked.
+	known map[string]bool
+	// passes group the changes so that resources are created before they
+	// are referred to.
+	passes [5][]*change
+}
+
+// plan returns the changes needed to make the cluster described by current
+// match the desired document, in the order they must be applied. Resources
+// in the cluster that are not in the document are left as they are, as are
+// fields not set in the document. Resources in the document that are in the
+// cluster are given the ID and version of the cluster's resource, so that
+// updates fail if the resource changes before they are applied.
+func plan(desired *Document, current *state) ([]*change, error) {
+	p := &planner{
+		scopes: make(map[string]*Scope),
+		known:  make(map[string]bool),
+	}
+	var index func(s *Scope, current bool)
+	index = func(s *Scope, current bool) {
+		if current {
+			p.scopes[s.path] = s
+		}
+		p.known[s.path] = true
+		for _, u := range s.Users {
+			p.known[refKey(kindUser, s.path, u.Name)] = true
+		}
+		for _, g := range s.Groups {
+			p.known[refKey(kindGroup, s.path, g.Name)] = true
+		}
+		for _, c := range s.Scopes {
+			index(c, current)
+		}
+	}
+	index(current.doc.Global, true)
+	index(desired.Global, false)
+
+	if err := p.planScope(desired.Global, nil); err != nil {
+		return nil, err
+	}
+	var changes []*change
+	for _, pass := range p.passes {
+		changes = append(changes, pass...)
+	}
+	return changes, nil
+}
+
+func refKey(kind, scopePath, name string) string {
+	return kind + ":" + scopePath + "/" + name
+}
+
+func (p *planner) add(pass int, c *change) {
+	p.passes[pass] = append(p.passes[pass], c)
+}
+
+func (p *planner) planScope(d *Scope, parent *Scope) error {
+	cur := p.scopes[d.path]
+	switch {
+	case cur == nil:
+		p.add(0, &change{op: opCreate, kind: kindScope, scope: d, parent: parent, desired: d})
+	default:
+		d.id, d.version = cur.id, cur.version
+		want := scopeFields{Description: d.Description, Quotas: d.Quotas}
+		have := scopeFields{Description: cur.Description, Quotas: cur.Quotas}
+		if parent == nil {
+			// The global scope is the only one whose name can change as it
+			// is not matched by name
+			want.Name, have.Name = d.Name, cur.Name
+		}
+		if fields := diffFields(want, have); len(fields) > 0 {
+			p.add(0, &change{op: opUpdate, kind: kindScope, scope: d, fields: fields, desired: d, current: cur})
+		}
+	}
+	if d.PrimaryAuthMethod != "" && (cur == nil || cur.PrimaryAuthMethod != d.PrimaryAuthMethod) {
+		found := false
+		for _, am := range d.AuthMethods {
+			found = found || am.Name == d.PrimaryAuthMethod
+		}
+		if cur != nil {
+			for _, am := range cur.AuthMethods {
+				found = found || am.Name == d.PrimaryAuthMethod
+			}
+		}
+		if !found {
+			return fmt.Errorf("The primary auth method %q of scope %q is not defined", d.PrimaryAuthMethod, d.path)
+		}
+		p.add(4, &change{op: opUpdate, kind: kindScope, scope: d, fields: []string{"primary_auth_method"}, desired: d, current: cur})
+	}
+
+	var curScope Scope
+	if cur != nil {
+		curScope = *cur
+	}
+
+	for _, am := range d.AuthMethods {
+		c := findByName(curScope.AuthMethods, am.Name, func(x *AuthMethod) string { return x.Name })
+		if c != nil {
+			am.id, am.version = c.id, c.version
+		}
+		if err := p.planResource(1, kindAuthMethod, d, am.Name, am, c, am.Type, typeOf(c)); err != nil {
+			return err
+		}
+	}
+	for _, u := range d.Users {
+		c := findByName(curScope.Users, u.Name, func(x *User) string { return x.Name })
+		if c != nil {
+			u.id, u.version = c.id, c.version
+		}
+		if err := p.planResource(1, kindUser, d, u.Name, u, c, "", ""); err != nil {
+			return err
+		}
+	}
+	for _, hc := range d.HostCatalogs {
+		c := findByName(curScope.HostCatalogs, hc.Name, func(x *HostCatalog) string { return x.Name })
+		if c != nil {
+			hc.id, hc.version = c.id, c.version
+		}
+		if c != nil && c.Plugin != hc.Plugin {
+			return fmt.Errorf("The plugin of host catalog %q in scope %q cannot be changed", hc.Name, d.path)
+		}
+		if err := p.planResource(1, kindHostCatalog, d, hc.Name, hc, c, hc.Type, typeOf(c)); err != nil {
+			return err
+		}
+	}
+	for _, cs := range d.CredentialStores {
+		c := findByName(curScope.CredentialStores, cs.Name, func(x *CredentialStore) string { return x.Name })
+		if c != nil {
+			cs.id, cs.version = c.id, c.version
+		}
+		if err := p.planResource(1, kindCredentialStore, d, cs.Name, cs, c, cs.Type, typeOf(c)); err != nil {
+			return err
+		}
+	}
+	for _, t := range d.Targets {
+		c := findByName(curScope.Targets, t.Name, func(x *Target) string { return x.Name })
+		if c != nil {
+			t.id, t.version = c.id, c.version
+		}
+		if err := p.planResource(1, kindTarget, d, t.Name, t, c, t.Type, typeOf(c)); err != nil {
+			return err
+		}
+	}
+	for _, g := range d.Groups {
+		for _, m := range g.Members {
+			if err := p.checkRef(m); err != nil {
+				return fmt.Errorf("Group %q in scope %q: %w", g.Name, d.path, err)
+			}
+		}
+		c := findByName(curScope.Groups, g.Name, func(x *Group) string { return x.Name })
+		if c != nil {
+			g.id, g.version = c.id, c.version
+		}
+		if err := p.planResource(2, kindGroup, d, g.Name, g, c, "", ""); err != nil {
+			return err
+		}
+	}
+	for _, r := range d.Roles {
+		for _, pr := range r.Principals {
+			if err := p.checkRef(pr); err != nil {
+				return fmt.Errorf("Role %q in scope %q: %w", r.Name, d.path, err)
+			}
+		}
+		for _, gs := range r.GrantScopes {
+			switch gs {
+			case globals.GrantScopeThis, globals.GrantScopeChildren, globals.GrantScopeDescendants:
+			default:
+				if !p.known[gs] {
+					return fmt.Errorf("Role %q in scope %q: grant scope %q is not defined", r.Name, d.path, gs)
+				}
+			}
+		}
+		c := findByName(curScope.Roles, r.Name, func(x *Role) string { return x.Name })
+		if c != nil {
+			r.id, r.version = c.id, c.version
+		}
+		if err := p.planResource(3, kindRole, d, r.Name, r, c, "", ""); err != nil {
+			return err
+		}
+	}
+
+	for _, child := range d.Scopes {
+		if err := p.planScope(child, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planResource adds a change for a resource that is not in the cluster or
+// differs from it. Resources of a type cannot be changed into another.
+func (p *planner) planResource(pass int, kind string, scope *Scope, name string, desired, current any, desiredType, currentType string) error {
+	if reflect.ValueOf(current).IsNil() {
+		p.add(pass, &change{op: opCreate, kind: kind, scope: scope, name: name, desired: desired})
+		return nil
+	}
+	if desiredType != currentType {
+		return fmt.Errorf("The type of %s %q in scope %q cannot be changed from %q to %q", kind, name, scope.path, currentType, desiredType)
+	}
+	if fields := diffFields(desired, current); len(fields) > 0 {
+		p.add(pass, &change{op: opUpdate, kind: kind, scope: scope, name: name, fields: fields, desired: desired, current: current})
+	}
+	return nil
+}
+
+func (p *planner) checkRef(r *Ref) error {
+	if r.Id != "" {
+		return nil
+	}
+	if !p.known[refKey(r.Type, r.Scope, r.Name)] {
+		return fmt.Errorf("%s is not defined", r)
+	}
+	return nil
+}
+
+// scopeFields are the fields of a scope that are compared with diffFields.
+type scopeFields struct {
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Quotas      map[string]uint32 `json:"quotas,omitempty"`
+}
+
+func findByName[T any](items []*T, name string, nameOf func(*T) string) *T {
+	for _, i := range items {
+		if nameOf(i) == name {
+			return i
+		}
+	}
+	return nil
+}
+
+func typeOf(r any) string {
+	switch t := r.(type) {
+	case *AuthMethod:
+		if t != nil {
+			return t.Type
+		}
+	case *HostCatalog:
+		if t != nil {
+			return t.Type
+		}
+	case *CredentialStore:
+		if t != nil {
+			return t.Type
+		}
+	case *Target:
+		if t != nil {
+			return t.Type
+		}
+	}
+	return ""
+}
+
+// unorderedFields are fields whose values are sets, so the order of their
+// elements is not significant.
+var unorderedFields = map[string]bool{
+	"member":       true,
+	"principal":    true,
+	"grants":       true,
+	"grant_scopes": true,
+}
+
+// diffFields returns the names of the fields set in desired that have a
+// different value in current. Attributes are compared individually and
+// reported as "attributes.<name>". The name and type are not compared as
+// resources are matched by name and their types cannot change.
+func diffFields(desired, current any) []string {
+	want, have := toMap(desired), toMap(current)
+	var fields []string
+	for k, v := range want {
+		switch k {
+		case "name":
+			if _, ok := desired.(scopeFields); !ok {
+				continue
+			}
+		case "type", "plugin":
+			continue
+		case "attributes":
+			wantAttrs, _ := v.(map[string]any)
+			haveAttrs, _ := have[k].(map[string]any)
+			for ak, av := range wantAttrs {
+				if !reflect.DeepEqual(av, haveAttrs[ak]) {
+					fields = append(fields, "attributes."+ak)
+				}
+			}
+			continue
+		}
+		if unorderedFields[k] {
+			if !equalSets(v, have[k]) {
+				fields = append(fields, k)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(v, have[k]) {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// toMap converts a resource to its JSON object form, so that values decoded
+// from documents and returned by the API compare equal.
+func toMap(in any) map[string]any {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil
+	}
+	var out map[string]any
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil
+	}
+	return out
+}
+
+func equalSets(a, b any) bool {
+	keys := func(v any) []string {
+		l, _ := v.([]any)
+		out := make([]string, 0, len(l))
+		for _, e := range l {
+			b, _ := json.Marshal(e)
+			out = append(out, string(b))
+		}
+		sort.Strings(out)
+		return out
+	}
+	return reflect.DeepEqual(keys(a), keys(b))
+}
@@ -0,0 +1,264 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package importexport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testState returns the state of a cluster with an org containing a user, a
+// group and a project with a target.
+func testState(t *testing.T) *state {
+	t.Helper()
+	doc, err := ParseDocument(`
+global {
+  name = "Global"
+  scope {
+    name = "eng"
+    user {
+      name = "alice"
+    }
+    user {
+      name = "bob"
+    }
+    group {
+      name = "devs"
+      member {
+        scope = "eng"
+        name  = "alice"
+      }
+    }
+    scope {
+      name = "prod"
+      target {
+        name    = "db"
+        type    = "tcp"
+        address = "10.0.0.1"
+        attributes {
+          default_port = 5432
+        }
+      }
+    }
+  }
+}
+`)
+	require.NoError(t, err)
+	org := doc.Global.Scopes[0]
+	org.id, org.version = "o_1234567890", 1
+	org.Users[0].id, org.Users[0].version = "u_1234567890", 1
+	org.Users[1].id, org.Users[1].version = "u_0987654321", 1
+	org.Groups[0].id, org.Groups[0].version = "g_1234567890", 3
+	org.Scopes[0].id, org.Scopes[0].version = "p_1234567890", 1
+	org.Scopes[0].Targets[0].id, org.Scopes[0].Targets[0].version = "ttcp_1234567890", 7
+	doc.Global.id = "global"
+	return &state{doc: doc}
+}
+
+func TestPlan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		doc     string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "no-changes",
+			doc: `
+global {
+  scope {
+    name = "eng"
+    group {
+      name = "devs"
+      member {
+        scope = "eng"
+        name  = "alice"
+      }
+    }
+    scope {
+      name = "prod"
+      target {
+        name = "db"
+        type = "tcp"
+        attributes {
+          default_port = 5432
+        }
+      }
+    }
+  }
+}
+`,
+		},
+		{
+			name: "updates",
+			doc: `
+global {
+  name = "Boundary"
+  scope {
+    name = "eng"
+    group {
+      name = "devs"
+      member {
+        scope = "eng"
+        name  = "bob"
+      }
+      member {
+        scope = "eng"
+        name  = "alice"
+      }
+    }
+    scope {
+      name = "prod"
+      target {
+        name                = "db"
+        type                = "tcp"
+        session_max_seconds = 600
+        attributes {
+          default_port = 5433
+        }
+      }
+    }
+  }
+}
+`,
+			want: []string{
+				`~ scope "global": name`,
+				`~ target "db" in scope "eng/prod": attributes.default_port, session_max_seconds`,
+				`~ group "devs" in scope "eng": member`,
+			},
+		},
+		{
+			name: "creates-in-dependency-order",
+			doc: `
+global {
+  primary_auth_method = "password"
+  auth_method {
+    name = "password"
+    type = "password"
+  }
+  role {
+    name         = "eng-admins"
+    grant_scopes = ["ops"]
+    principal {
+      type  = "group"
+      scope = "ops"
+      name  = "admins"
+    }
+  }
+  scope {
+    name = "ops"
+    group {
+      name = "admins"
+      member {
+        scope = "eng"
+        name  = "bob"
+      }
+    }
+  }
+}
+`,
+			want: []string{
+				`+ scope "ops"`,
+				`+ auth method "password" in scope "global"`,
+				`+ group "admins" in scope "ops"`,
+				`+ role "eng-admins" in scope "global"`,
+				`~ scope "global": primary_auth_method`,
+			},
+		},
+		{
+			name: "undefined-member",
+			doc: `
+global {
+  group {
+    name = "admins"
+    member {
+      scope = "eng"
+      name  = "carol"
+    }
+  }
+}
+`,
+			wantErr: `Group "admins" in scope "global": user eng/carol is not defined`,
+		},
+		{
+			name: "undefined-primary-auth-method",
+			doc: `
+global {
+  primary_auth_method = "oidc"
+}
+`,
+			wantErr: `The primary auth method "oidc" of scope "global" is not defined`,
+		},
+		{
+			name: "type-change",
+			doc: `
+global {
+  scope {
+    name = "eng"
+    scope {
+      name = "prod"
+      target {
+        name = "db"
+        type = "ssh"
+      }
+    }
+  }
+}
+`,
+			wantErr: `The type of target "db" in scope "eng/prod" cannot be changed from "tcp" to "ssh"`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			doc, err := ParseDocument(tt.doc)
+			require.NoError(t, err)
+			changes, err := plan(doc, testState(t))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			var got []string
+			for _, c := range changes {
+				got = append(got, c.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPlan_BindsVersions(t *testing.T) {
+	t.Parallel()
+
+	doc, err := ParseDocument(`
+global {
+  scope {
+    name = "eng"
+    scope {
+      name = "prod"
+      target {
+        name    = "db"
+        type    = "tcp"
+        address = "10.0.0.2"
+      }
+    }
+  }
+}
+`)
+	require.NoError(t, err)
+	changes, err := plan(doc, testState(t))
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	tgt := changes[0].desired.(*Target)
+	assert.Equal(t, "ttcp_1234567890", tgt.id)
+	assert.Equal(t, uint32(7), tgt.version)
+	assert.Equal(t, []string{"address"}, changes[0].fields)
+}
@@ -0,0 +1,253 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jobscmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/jobs"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"run": {"id"},
+	}
+}
+
+func (c *Command) extraHelpFunc(_ map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary jobs [sub command] [options] [args]",
+			"",
+			"  This command allows operations on the jobs run by Boundary's controllers. Example:",
+			"",
+			"    List the jobs:",
+			"",
+			`      $ boundary jobs list -scope-id global`,
+			"",
+			"  Please see the jobs subcommand help for detailed usage information.",
+		})
+
+	case "read":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary jobs read [options] [args]",
+			"",
+			"  Read a job given its name. Example:",
+			"",
+			`    $ boundary jobs read -id job_run_cleaner`,
+			"",
+			"",
+		})
+
+	case "list":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary jobs list [options] [args]",
+			"",
+			"  List the jobs registered by the controllers, with their next scheduled run, the outcome of their last run and the progress of their current run. Jobs are only in the global scope. Example:",
+			"",
+			`    $ boundary jobs list -scope-id global`,
+			"",
+			"",
+		})
+
+	case "run":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary jobs run [options] [args]",
+			"",
+			"  Run a job given its name now, instead of at its next scheduled run. The job is run by whichever controller picks it up first. A job that is already running can't be run again until its current run ends. Example:",
+			"",
+			`    $ boundary jobs run -id job_run_cleaner`,
+			"",
+			"",
+		})
+	}
+
+	return helpStr + c.Flags().Help()
+}
+
+func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *jobs.Job, origItems []*jobs.Job, origError error, jobClient *jobs.Client, _ uint32, opts []jobs.Option) (*api.Response, *jobs.Job, []*jobs.Job, error) {
+	switch c.Func {
+	case "run":
+		result, err := jobClient.Run(c.Context, c.FlagId, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
+	}
+	return origResp, origItem, origItems, origError
+}
+
+func (c *Command) printListTable(items []*jobs.Job) string {
+	if len(items) == 0 {
+		return "No jobs found"
+	}
+	var output []string
+	output = []string{
+		"",
+		"Job information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if item.Id != "" {
+			output = append(output,
+				fmt.Sprintf("  ID:                      %s", item.Id),
+			)
+		} else {
+			output = append(output,
+				fmt.Sprintf("  ID:                      %s", "(not available)"),
+			)
+		}
+		if item.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:           %s", item.Description),
+			)
+		}
+		if !item.NextScheduledRun.IsZero() {
+			output = append(output,
+				fmt.Sprintf("    Next Scheduled Run:    %s", item.NextScheduledRun.Local().Format(time.RFC1123)),
+			)
+		}
+		if item.ConsecutiveFailures > 0 {
+			output = append(output,
+				fmt.Sprintf("    Consecutive Failures:  %d", item.ConsecutiveFailures),
+			)
+		}
+		if run := item.CurrentRun; run != nil {
+			output = append(output,
+				fmt.Sprintf("    Current Run Progress:  %d/%d", run.CompletedCount, run.TotalCount),
+			)
+		}
+		if run := item.LastRun; run != nil {
+			output = append(output,
+				fmt.Sprintf("    Last Run Status:       %s", run.Status),
+			)
+			if !run.EndTime.IsZero() {
+				output = append(output,
+					fmt.Sprintf("    Last Run End Time:     %s", run.EndTime.Local().Format(time.RFC1123)),
+					fmt.Sprintf("    Last Run Duration:     %s", runDuration(run)),
+				)
+			}
+		}
+		if len(item.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, item.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(item *jobs.Job, resp *api.Response) string {
+	nonAttributeMap := map[string]any{}
+	if item.Id != "" {
+		nonAttributeMap["ID"] = item.Id
+	}
+	if item.Description != "" {
+		nonAttributeMap["Description"] = item.Description
+	}
+	if !item.NextScheduledRun.IsZero() {
+		nonAttributeMap["Next Scheduled Run"] = item.NextScheduledRun.Local().Format(time.RFC1123)
+	}
+	nonAttributeMap["Consecutive Failures"] = item.ConsecutiveFailures
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	var currentRunMap, lastRunMap map[string]any
+	if item.CurrentRun != nil {
+		currentRunMap = runMap(item.CurrentRun)
+		if l := base.MaxAttributesLength(currentRunMap, nil, nil); l > maxLength {
+			maxLength = l
+		}
+	}
+	if item.LastRun != nil {
+		lastRunMap = runMap(item.LastRun)
+		if l := base.MaxAttributesLength(lastRunMap, nil, nil); l > maxLength {
+			maxLength = l
+		}
+	}
+
+	ret := []string{
+		"",
+		"Job information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if item.Scope != nil {
+		ret = append(ret,
+			"",
+			"  Scope:",
+			base.ScopeInfoForOutput(item.Scope, maxLength),
+		)
+	}
+
+	if len(item.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, item.AuthorizedActions),
+		)
+	}
+
+	if currentRunMap != nil {
+		ret = append(ret,
+			"",
+			"  Current Run:",
+			base.WrapMap(4, maxLength, currentRunMap),
+		)
+	}
+
+	if lastRunMap != nil {
+		ret = append(ret,
+			"",
+			"  Last Run:",
+			base.WrapMap(4, maxLength, lastRunMap),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
+
+func runMap(run *jobs.JobRun) map[string]any {
+	m := map[string]any{
+		"Status":          run.Status,
+		"Completed Count": run.CompletedCount,
+		"Total Count":     run.TotalCount,
+	}
+	if run.ControllerId != "" {
+		m["Controller ID"] = run.ControllerId
+	}
+	if !run.StartTime.IsZero() {
+		m["Start Time"] = run.StartTime.Local().Format(time.RFC1123)
+	}
+	if !run.UpdatedTime.IsZero() {
+		m["Updated Time"] = run.UpdatedTime.Local().Format(time.RFC1123)
+	}
+	if !run.EndTime.IsZero() {
+		m["End Time"] = run.EndTime.Local().Format(time.RFC1123)
+		m["Duration"] = runDuration(run)
+	}
+	if run.ErrorMessage != "" {
+		m["Error Message"] = run.ErrorMessage
+	}
+	return m
+}
+
+// runDuration returns how long a run that has ended took.
+func runDuration(run *jobs.JobRun) string {
+	return run.EndTime.Sub(run.StartTime).Round(time.Millisecond).String()
+}
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oplogcmd
+
+import (
+	"context"
+
+	ldapstore "github.com/hashicorp/boundary/internal/auth/ldap/store"
+	oidcstore "github.com/hashicorp/boundary/internal/auth/oidc/store"
+	passwordstore "github.com/hashicorp/boundary/internal/auth/password/store"
+	authstore "github.com/hashicorp/boundary/internal/auth/store"
+	staticcredstore "github.com/hashicorp/boundary/internal/credential/static/store"
+	vaultstore "github.com/hashicorp/boundary/internal/credential/vault/store"
+	pluginhoststore "github.com/hashicorp/boundary/internal/host/plugin/store"
+	statichoststore "github.com/hashicorp/boundary/internal/host/static/store"
+	hoststore "github.com/hashicorp/boundary/internal/host/store"
+	iamstore "github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	pluginstore "github.com/hashicorp/boundary/internal/plugin/store"
+	targetstore "github.com/hashicorp/boundary/internal/target/store"
+	tcpstore "github.com/hashicorp/boundary/internal/target/tcp/store"
+)
+
+// newTypeCatalog returns a catalog of the items written in oplog entries,
+// keyed by the name of the table they are stored in, which is the type name
+// they are written with.
+func newTypeCatalog(ctx context.Context) (*oplog.TypeCatalog, error) {
+	return oplog.NewTypeCatalog(ctx,
+		oplog.Type{Interface: new(authstore.Account), Name: "auth_account"},
+
+		oplog.Type{Interface: new(ldapstore.Account), Name: "auth_ldap_account"},
+		oplog.Type{Interface: new(ldapstore.AccountAttributeMap), Name: "auth_ldap_account_attribute_map"},
+		oplog.Type{Interface: new(ldapstore.BindCredential), Name: "auth_ldap_bind_credential"},
+		oplog.Type{Interface: new(ldapstore.Certificate), Name: "auth_ldap_certificate"},
+		oplog.Type{Interface: new(ldapstore.ClientCertificate), Name: "auth_ldap_client_certificate"},
+		oplog.Type{Interface: new(ldapstore.GroupEntrySearchConf), Name: "auth_ldap_group_entry_search"},
+		oplog.Type{Interface: new(ldapstore.ManagedGroup), Name: "auth_ldap_managed_group"},
+		oplog.Type{Interface: new(ldapstore.AuthMethod), Name: "auth_ldap_method"},
+		oplog.Type{Interface: new(ldapstore.Url), Name: "auth_ldap_url"},
+		oplog.Type{Interface: new(ldapstore.UserEntrySearchConf), Name: "auth_ldap_user_entry_search"},
+
+		oplog.Type{Interface: new(oidcstore.Account), Name: "auth_oidc_account"},
+		oplog.Type{Interface: new(oidcstore.AccountClaimMap), Name: "auth_oidc_account_claim_map"},
+		oplog.Type{Interface: new(oidcstore.AudClaim), Name: "auth_oidc_aud_claim"},
+		oplog.Type{Interface: new(oidcstore.Certificate), Name: "auth_oidc_certificate"},
+		oplog.Type{Interface: new(oidcstore.ManagedGroup), Name: "auth_oidc_managed_group"},
+		oplog.Type{Interface: new(oidcstore.ManagedGroupMemberAccount), Name: "auth_oidc_managed_group_member_account"},
+		oplog.Type{Interface: new(oidcstore.AuthMethod), Name: "auth_oidc_method"},
+		oplog.Type{Interface: new(oidcstore.ClaimsScope), Name: "auth_oidc_scope"},
+		oplog.Type{Interface: new(oidcstore.SigningAlg), Name: "auth_oidc_signing_alg"},
+
+		oplog.Type{Interface: new(passwordstore.Account), Name: "auth_password_account"},
+		oplog.Type{Interface: new(passwordstore.Argon2Configuration), Name: "auth_password_argon2_conf"},
+		oplog.Type{Interface: new(passwordstore.Argon2Credential), Name: "auth_password_argon2_cred"},
+		oplog.Type{Interface: new(passwordstore.AuthMethod), Name: "auth_password_method"},
+
+		oplog.Type{Interface: new(staticcredstore.JsonCredential), Name: "credential_static_json_credential"},
+		oplog.Type{Interface: new(staticcredstore.SshPrivateKeyCredential), Name: "credential_static_ssh_private_key_credential"},
+		oplog.Type{Interface: new(staticcredstore.CredentialStore), Name: "credential_static_store"},
+		oplog.Type{Interface: new(staticcredstore.TlsCertificateCredential), Name: "credential_static_tls_certificate_credential"},
+		oplog.Type{Interface: new(staticcredstore.UsernamePasswordCredential), Name: "credential_static_username_password_credential"},
+
+		oplog.Type{Interface: new(vaultstore.ClientCertificate), Name: "credential_vault_client_certificate"},
+		oplog.Type{Interface: new(vaultstore.Credential), Name: "credential_vault_credential"},
+		oplog.Type{Interface: new(vaultstore.CredentialLibrary), Name: "credential_vault_library"},
+		oplog.Type{Interface: new(vaultstore.SshPrivateKeyOverride), Name: "credential_vault_library_ssh_private_key_mapping_override"},
+		oplog.Type{Interface: new(vaultstore.UsernamePasswordOverride), Name: "credential_vault_library_username_password_mapping_override"},
+		oplog.Type{Interface: new(vaultstore.SSHCertificateCredentialLibrary), Name: "credential_vault_ssh_cert_library"},
+		oplog.Type{Interface: new(vaultstore.CredentialStore), Name: "credential_vault_store"},
+		oplog.Type{Interface: new(vaultstore.Token), Name: "credential_vault_token"},
+
+		oplog.Type{Interface: new(hoststore.DnsName), Name: "host_dns_name"},
+		oplog.Type{Interface: new(hoststore.IpAddress), Name: "host_ip_address"},
+		oplog.Type{Interface: new(hoststore.PreferredEndpoint), Name: "host_set_preferred_endpoint"},
+
+		oplog.Type{Interface: new(pluginhoststore.HostCatalog), Name: "host_plugin_catalog"},
+		oplog.Type{Interface: new(pluginhoststore.HostCatalogSecret), Name: "host_plugin_catalog_secret"},
+		oplog.Type{Interface: new(pluginhoststore.Host), Name: "host_plugin_host"},
+		oplog.Type{Interface: new(pluginhoststore.HostSet), Name: "host_plugin_set"},
+		oplog.Type{Interface: new(pluginhoststore.HostSetMember), Name: "host_plugin_set_member"},
+
+		oplog.Type{Interface: new(statichoststore.Host), Name: "static_host"},
+		oplog.Type{Interface: new(statichoststore.HostCatalog), Name: "static_host_catalog"},
+		oplog.Type{Interface: new(statichoststore.HostSet), Name: "static_host_set"},
+		oplog.Type{Interface: new(statichoststore.HostSetMember), Name: "static_host_set_member"},
+
+		oplog.Type{Interface: new(iamstore.Group), Name: "iam_group"},
+		oplog.Type{Interface: new(iamstore.GroupMemberUser), Name: "iam_group_member_user"},
+		oplog.Type{Interface: new(iamstore.GroupRole), Name: "iam_group_role"},
+		oplog.Type{Interface: new(iamstore.ManagedGroupRole), Name: "iam_managed_group_role"},
+		oplog.Type{Interface: new(iamstore.Role), Name: "iam_role"},
+		oplog.Type{Interface: new(iamstore.RoleGrant), Name: "iam_role_grant"},
+		oplog.Type{Interface: new(iamstore.RoleGrantScope), Name: "iam_role_grant_scope"},
+		oplog.Type{Interface: new(iamstore.Scope), Name: "iam_scope"},
+		oplog.Type{Interface: new(iamstore.ScopeQuota), Name: "iam_scope_quota"},
+		oplog.Type{Interface: new(iamstore.ServiceAccount), Name: "iam_service_account"},
+		oplog.Type{Interface: new(iamstore.ServiceAccountApiKey), Name: "iam_service_account_api_key"},
+		oplog.Type{Interface: new(iamstore.ServiceAccountRole), Name: "iam_service_account_role"},
+		oplog.Type{Interface: new(iamstore.User), Name: "iam_user"},
+		oplog.Type{Interface: new(iamstore.UserRole), Name: "iam_user_role"},
+		oplog.Type{Interface: new(iamstore.ResourceTag), Name: "resource_tag"},
+
+		oplog.Type{Interface: new(pluginstore.Plugin), Name: "plugin_host"},
+
+		oplog.Type{Interface: new(targetstore.TargetAddress), Name: "target_address"},
+		oplog.Type{Interface: new(targetstore.CredentialLibrary), Name: "target_credential_library"},
+		oplog.Type{Interface: new(targetstore.TargetHostSet), Name: "target_host_set"},
+		oplog.Type{Interface: new(targetstore.StaticCredential), Name: "target_static_credential"},
+		oplog.Type{Interface: new(tcpstore.Target), Name: "target_tcp"},
+	)
+}
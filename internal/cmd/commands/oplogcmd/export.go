@@ -0,0 +1,201 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oplogcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	_ cli.Command             = (*ExportCommand)(nil)
+	_ cli.CommandAutocomplete = (*ExportCommand)(nil)
+)
+
+type ExportCommand struct {
+	*base.Server
+
+	Config *config.Config
+
+	commonFlags
+	flagAggregateName string
+}
+
+func (c *ExportCommand) Synopsis() string {
+	return "Export decrypted oplog entries as JSON lines"
+}
+
+func (c *ExportCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog export [options]",
+		"",
+		"  Export the entries of the operation log (oplog) in Boundary's database, decrypted with the oplog keys of their scopes and decoded, as one JSON object per line:",
+		"",
+		`    $ boundary oplog export -config=/etc/boundary/controller.hcl -start-time=2023-04-01T00:00:00Z -aggregate-name=iam_role`,
+		"",
+		"  Entries are written in the order they were written to the oplog. Entries that can't be decrypted or decoded are written with an error instead of their messages. The exported messages include the values written to the database, which can include secrets, so the output should be protected accordingly.",
+	}) + c.Flags().Help()
+}
+
+func (c *ExportCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetNone)
+
+	f := c.commonFlags.addFlags(set)
+
+	f.StringVar(&base.StringVar{
+		Name:   "aggregate-name",
+		Target: &c.flagAggregateName,
+		Usage:  `If set, only entries of this aggregate are exported. Aggregates are named after the table of the resource they change, e.g. "iam_role".`,
+	})
+
+	return set
+}
+
+func (c *ExportCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ExportCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ExportCommand) Run(args []string) (retCode int) {
+	if result := c.ParseFlagsAndConfig(args); result > 0 {
+		return result
+	}
+
+	defer func() {
+		if err := c.RunShutdownFuncs(); err != nil {
+			c.UI.Error(fmt.Errorf("Error running shutdown tasks: %w", err).Error())
+		}
+	}()
+
+	f, err := c.commonFlags.filter()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+	f.aggregateName = c.flagAggregateName
+
+	r, ret := openReader(c.Server, c.Config, &c.commonFlags, "boundary-oplog-export")
+	if r == nil {
+		return ret
+	}
+
+	if err := r.forEach(c.Context, f, true, func(e *entry) error {
+		line, err := json.Marshal(exportEntry(e))
+		if err != nil {
+			return fmt.Errorf("unable to encode entry %d: %w", e.GetId(), err)
+		}
+		c.UI.Output(string(line))
+		return nil
+	}); err != nil {
+		c.UI.Error(fmt.Errorf("Error exporting oplog entries: %w", err).Error())
+		return base.CommandCliError
+	}
+	return base.CommandSuccess
+}
+
+func (c *ExportCommand) ParseFlagsAndConfig(args []string) int {
+	var err error
+
+	f := c.Flags()
+
+	if err = f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	if len(c.flagConfig) == 0 {
+		c.UI.Error("Must specify a config file using -config")
+		return base.CommandUserError
+	}
+
+	c.Config, err = config.Load(c.Context, c.flagConfig, c.flagConfigKms)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return base.CommandUserError
+	}
+
+	return base.CommandSuccess
+}
+
+// exportedEntry is the JSON representation of an exported oplog entry.
+type exportedEntry struct {
+	Id            uint32              `json:"id"`
+	CreateTime    time.Time           `json:"create_time"`
+	Version       string              `json:"version"`
+	AggregateName string              `json:"aggregate_name"`
+	ScopeId       string              `json:"scope_id"`
+	KeyId         string              `json:"key_id"`
+	TicketName    string              `json:"ticket_name,omitempty"`
+	TicketVersion uint32              `json:"ticket_version,omitempty"`
+	Metadata      map[string][]string `json:"metadata,omitempty"`
+	Messages      []*exportedMessage  `json:"messages,omitempty"`
+	Error         string              `json:"error,omitempty"`
+}
+
+// exportedMessage is the JSON representation of a message of an exported
+// oplog entry.
+type exportedMessage struct {
+	TypeName       string          `json:"type_name"`
+	Operation      string          `json:"operation"`
+	FieldMaskPaths []string        `json:"field_mask_paths,omitempty"`
+	SetToNullPaths []string        `json:"set_to_null_paths,omitempty"`
+	Value          json.RawMessage `json:"value"`
+}
+
+// exportEntry returns the JSON representation of e.
+func exportEntry(e *entry) *exportedEntry {
+	ret := &exportedEntry{
+		Id:            e.GetId(),
+		CreateTime:    e.GetCreateTime().AsTime(),
+		Version:       e.GetVersion(),
+		AggregateName: e.GetAggregateName(),
+		ScopeId:       e.GetScopeId(),
+		KeyId:         e.GetKeyId(),
+		TicketName:    e.GetTicketName(),
+		TicketVersion: e.GetTicketVersion(),
+		Metadata:      e.metadata,
+	}
+	switch {
+	case e.decryptErr != nil:
+		ret.Error = e.decryptErr.Error()
+		return ret
+	case e.decodeErr != nil:
+		ret.Error = e.decodeErr.Error()
+		return ret
+	}
+	for _, m := range e.msgs {
+		value, err := protojson.Marshal(m.Message)
+		if err != nil {
+			ret.Messages = nil
+			ret.Error = fmt.Sprintf("unable to encode %s message: %v", m.TypeName, err)
+			return ret
+		}
+		ret.Messages = append(ret.Messages, &exportedMessage{
+			TypeName:       m.TypeName,
+			Operation:      operation(m.OpType),
+			FieldMaskPaths: m.FieldMaskPaths,
+			SetToNullPaths: m.SetToNullPaths,
+			Value:          value,
+		})
+	}
+	return ret
+}
+
+// operation returns the name of an oplog operation type, e.g. "create" for
+// OP_TYPE_CREATE.
+func operation(t oplog.OpType) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), "OP_TYPE_"))
+}
@@ -0,0 +1,361 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oplogcmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/schema"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+	"github.com/posener/complete"
+)
+
+// pageSize is the number of oplog entries read from the database at a time.
+const pageSize = 1000
+
+// commonFlags are the flags shared by the oplog commands.
+type commonFlags struct {
+	flagConfig    []string
+	flagConfigKms string
+	flagLogLevel  string
+	flagLogFormat string
+	flagStartTime string
+	flagEndTime   string
+}
+
+// addFlags adds the common flags to set and returns the flag set of the
+// options selecting the oplog entries, so commands can add their own.
+func (f *commonFlags) addFlags(set *base.FlagSets) *base.FlagSet {
+	fs := set.NewFlagSet("Command options")
+
+	fs.StringSliceVar(&base.StringSliceVar{
+		Name:   "config",
+		Target: &f.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file of a controller.",
+	})
+
+	fs.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &f.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file, which has some drawbacks; see the help output for "boundary config encrypt -h" for details.`,
+	})
+
+	fs.StringVar(&base.StringVar{
+		Name:       "log-level",
+		Target:     &f.flagLogLevel,
+		EnvVar:     "BOUNDARY_LOG_LEVEL",
+		Completion: complete.PredictSet("trace", "debug", "info", "warn", "err"),
+		Usage: "Log verbosity level. Supported values (in order of more detail to less) are " +
+			"\"trace\", \"debug\", \"info\", \"warn\", and \"err\".",
+	})
+
+	fs.StringVar(&base.StringVar{
+		Name:       "log-format",
+		Target:     &f.flagLogFormat,
+		Completion: complete.PredictSet("standard", "json"),
+		Usage:      `Log format. Supported values are "standard" and "json".`,
+	})
+
+	fs = set.NewFlagSet("Oplog options")
+
+	fs.StringVar(&base.StringVar{
+		Name:   "start-time",
+		Target: &f.flagStartTime,
+		Usage:  `If set, only entries written at or after this time are read. The time is in RFC 3339 format, e.g. "2023-04-01T00:00:00Z".`,
+	})
+
+	fs.StringVar(&base.StringVar{
+		Name:   "end-time",
+		Target: &f.flagEndTime,
+		Usage:  `If set, only entries written before this time are read. The time is in RFC 3339 format, e.g. "2023-04-02T00:00:00Z".`,
+	})
+
+	return fs
+}
+
+// filter returns the filter of the oplog entries selected by the time flags.
+func (f *commonFlags) filter() (filter, error) {
+	var ret filter
+	var err error
+	if f.flagStartTime != "" {
+		if ret.startTime, err = time.Parse(time.RFC3339, f.flagStartTime); err != nil {
+			return filter{}, fmt.Errorf("Error parsing -start-time: %w", err)
+		}
+	}
+	if f.flagEndTime != "" {
+		if ret.endTime, err = time.Parse(time.RFC3339, f.flagEndTime); err != nil {
+			return filter{}, fmt.Errorf("Error parsing -end-time: %w", err)
+		}
+	}
+	if !ret.startTime.IsZero() && !ret.endTime.IsZero() && !ret.startTime.Before(ret.endTime) {
+		return filter{}, fmt.Errorf("-start-time must be before -end-time")
+	}
+	return ret, nil
+}
+
+// filter selects oplog entries.
+type filter struct {
+	startTime     time.Time
+	endTime       time.Time
+	aggregateName string
+	ticketName    string
+}
+
+// where returns the where clause and args of the entries selected by the
+// filter whose id is greater than afterId.
+func (f filter) where(afterId uint32) (string, []any) {
+	conds := []string{"id > ?"}
+	args := []any{afterId}
+	if !f.startTime.IsZero() {
+		conds = append(conds, "create_time >= ?")
+		args = append(args, f.startTime)
+	}
+	if !f.endTime.IsZero() {
+		conds = append(conds, "create_time < ?")
+		args = append(args, f.endTime)
+	}
+	if f.aggregateName != "" {
+		conds = append(conds, "aggregate_name = ?")
+		args = append(args, f.aggregateName)
+	}
+	if f.ticketName != "" {
+		conds = append(conds, "ticket_name = ?")
+		args = append(args, f.ticketName)
+	}
+	return strings.Join(conds, " and "), args
+}
+
+// entry is an oplog entry read by a reader.
+type entry struct {
+	*store.Entry
+	metadata oplog.Metadata
+	msgs     []oplog.Message
+	// decryptErr is set when the data of the entry could not be decrypted.
+	decryptErr error
+	// decodeErr is set when the decrypted data of the entry could not be
+	// decoded.
+	decodeErr error
+}
+
+// reader reads oplog entries from a controller's database and decrypts them
+// with the oplog keys of their scopes.
+type reader struct {
+	rw       *db.Db
+	kms      *kms.Kms
+	catalog  *oplog.TypeCatalog
+	wrappers map[string]wrapping.Wrapper
+}
+
+// openReader sets up logging, eventing and the root KMS of the controller
+// configured in conf, and returns a reader of the oplog entries in its
+// database. Errors are written to the UI of s, and the returned code is the
+// one the command should exit with if the reader is nil. The database is
+// closed by the shutdown funcs of s.
+func openReader(s *base.Server, conf *config.Config, f *commonFlags, serverName string) (*reader, int) {
+	const dialect = "postgres"
+
+	if err := s.SetupLogging(f.flagLogLevel, f.flagLogFormat, conf.LogLevel, conf.LogFormat); err != nil {
+		s.UI.Error(err.Error())
+		return nil, base.CommandCliError
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		s.UI.Error(fmt.Errorf("Unable to determine hostname: %w", err).Error())
+		return nil, base.CommandCliError
+	}
+	if err := s.SetupEventing(s.Logger, s.StderrLock, fmt.Sprintf("%s/%s", hostname, serverName), base.WithEventerConfig(conf.Eventing)); err != nil {
+		s.UI.Error(err.Error())
+		return nil, base.CommandCliError
+	}
+
+	if err := s.SetupKMSes(s.Context, s.UI, conf); err != nil {
+		s.UI.Error(err.Error())
+		return nil, base.CommandCliError
+	}
+	if s.RootKms == nil {
+		s.UI.Error("Root KMS not found after parsing KMS blocks")
+		return nil, base.CommandCliError
+	}
+
+	if conf.Controller == nil {
+		s.UI.Error(`"controller" config block not found`)
+		return nil, base.CommandUserError
+	}
+	if conf.Controller.Database == nil {
+		s.UI.Error(`"controller.database" config block not found`)
+		return nil, base.CommandUserError
+	}
+	if conf.Controller.Database.Url == "" {
+		s.UI.Error(`"url" not set in "controller.database" config block`)
+		return nil, base.CommandUserError
+	}
+	dbaseUrl, err := parseutil.ParsePath(conf.Controller.Database.Url)
+	if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+		s.UI.Error(fmt.Errorf("Error parsing database url: %w", err).Error())
+		return nil, base.CommandUserError
+	}
+
+	dbase, err := s.OpenDatabase(s.Context, dialect, dbaseUrl)
+	if err != nil {
+		s.UI.Error(fmt.Errorf("Error establishing db connection: %w", err).Error())
+		return nil, base.CommandCliError
+	}
+	s.ShutdownFuncs = append(s.ShutdownFuncs, func() error {
+		return dbase.Close(context.Background())
+	})
+	sqlDb, err := dbase.SqlDB(s.Context)
+	if err != nil {
+		s.UI.Error(fmt.Errorf("Error establishing db connection: %w", err).Error())
+		return nil, base.CommandCliError
+	}
+	man, err := schema.NewManager(s.Context, schema.Dialect(dialect), sqlDb)
+	if err != nil {
+		s.UI.Error(fmt.Errorf("Error setting up schema manager: %w", err).Error())
+		return nil, base.CommandCliError
+	}
+	st, err := man.CurrentState(s.Context)
+	if err != nil {
+		s.UI.Error(fmt.Errorf("Error getting database state: %w", err).Error())
+		return nil, base.CommandCliError
+	}
+	if !st.Initialized {
+		s.UI.Error(base.WrapAtLength("The database has not been initialized."))
+		return nil, base.CommandUserError
+	}
+	if !st.MigrationsApplied() {
+		s.UI.Error(base.WrapAtLength("The database schema is not at the version supported by this binary. Run \"boundary database migrate\" first."))
+		return nil, base.CommandUserError
+	}
+
+	rw := db.New(dbase)
+	kmsCache, err := kms.New(s.Context, rw, rw)
+	if err != nil {
+		s.UI.Error(fmt.Errorf("Error creating kms cache: %w", err).Error())
+		return nil, base.CommandCliError
+	}
+	if err := kmsCache.AddExternalWrappers(s.Context, kms.WithRootWrapper(s.RootKms)); err != nil {
+		s.UI.Error(fmt.Errorf("Error adding config keys to kms: %w", err).Error())
+		return nil, base.CommandCliError
+	}
+	catalog, err := newTypeCatalog(s.Context)
+	if err != nil {
+		s.UI.Error(fmt.Errorf("Error creating oplog type catalog: %w", err).Error())
+		return nil, base.CommandCliError
+	}
+
+	return &reader{
+		rw:       rw,
+		kms:      kmsCache,
+		catalog:  catalog,
+		wrappers: make(map[string]wrapping.Wrapper),
+	}, base.CommandSuccess
+}
+
+// forEach calls fn with each entry selected by f, in the order they were
+// written, reading them from the database a page at a time. The metadata of
+// the entries is only read if withMetadata is set. Entries that can't be
+// decrypted or decoded are passed to fn with the error that prevented it.
+func (r *reader) forEach(ctx context.Context, f filter, withMetadata bool, fn func(*entry) error) error {
+	const op = "oplogcmd.(reader).forEach"
+	var lastId uint32
+	for {
+		where, args := f.where(lastId)
+		var page []*store.Entry
+		if err := r.rw.SearchWhere(ctx, &page, where, args, db.WithOrder("id asc"), db.WithLimit(pageSize)); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to search oplog entries"))
+		}
+		if len(page) == 0 {
+			return nil
+		}
+		metadata := make(map[uint32]oplog.Metadata)
+		if withMetadata {
+			ids := make([]uint32, 0, len(page))
+			for _, se := range page {
+				ids = append(ids, se.GetId())
+			}
+			var md []*store.Metadata
+			if err := r.rw.SearchWhere(ctx, &md, "entry_id in (?)", []any{ids}, db.WithOrder("id asc"), db.WithLimit(-1)); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to search oplog metadata"))
+			}
+			for _, m := range md {
+				if metadata[m.GetEntryId()] == nil {
+					metadata[m.GetEntryId()] = make(oplog.Metadata)
+				}
+				metadata[m.GetEntryId()][m.GetKey()] = append(metadata[m.GetEntryId()][m.GetKey()], m.GetValue())
+			}
+		}
+		for _, se := range page {
+			e := r.decode(ctx, se)
+			e.metadata = metadata[se.GetId()]
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+		lastId = page[len(page)-1].GetId()
+	}
+}
+
+// decode decrypts the data of se and decodes its messages.
+func (r *reader) decode(ctx context.Context, se *store.Entry) *entry {
+	const op = "oplogcmd.(reader).decode"
+	e := &entry{Entry: se}
+	w, ok := r.wrappers[se.GetKeyId()]
+	if !ok {
+		var err error
+		w, err = r.kms.GetWrapper(ctx, se.GetScopeId(), kms.KeyPurposeOplog, kms.WithKeyId(se.GetKeyId()))
+		if err != nil {
+			e.decryptErr = errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+			return e
+		}
+		r.wrappers[se.GetKeyId()] = w
+	}
+	oe := &oplog.Entry{Entry: se, Wrapper: w}
+	if err := oe.DecryptData(ctx); err != nil {
+		e.decryptErr = err
+		return e
+	}
+	e.msgs, e.decodeErr = oe.UnmarshalData(ctx, r.catalog)
+	return e
+}
+
+// tickets returns the current version of the tickets, keyed by name. Only
+// the ticket named name is returned if it is set.
+func (r *reader) tickets(ctx context.Context, name string) (map[string]uint32, error) {
+	const op = "oplogcmd.(reader).tickets"
+	var where string
+	var args []any
+	if name != "" {
+		where, args = "name = ?", []any{name}
+	}
+	var tickets []*store.Ticket
+	if err := r.rw.SearchWhere(ctx, &tickets, where, args, db.WithLimit(-1)); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to search oplog tickets"))
+	}
+	versions := make(map[string]uint32, len(tickets))
+	for _, t := range tickets {
+		versions[t.GetName()] = t.GetVersion()
+	}
+	return versions, nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oplogcmd
+
+import (
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*Command)(nil)
+	_ cli.CommandAutocomplete = (*Command)(nil)
+)
+
+type Command struct {
+	*base.Command
+}
+
+func (c *Command) Synopsis() string {
+	return "Export and verify Boundary's operation log"
+}
+
+func (c *Command) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog [sub command] [options] [args]",
+		"",
+		"  This command allows operations on the operation log (oplog) in Boundary's database. It uses the configuration of a controller to connect to the database and decrypt the entries. Example:",
+		"",
+		"    Export the entries written in a time range:",
+		"",
+		`      $ boundary oplog export -config controller.hcl -start-time 2023-04-01T00:00:00Z -end-time 2023-04-02T00:00:00Z`,
+		"",
+		"    Verify the entries:",
+		"",
+		`      $ boundary oplog verify -config controller.hcl`,
+		"",
+		"  Please see the oplog subcommand help for detailed usage information.",
+	})
+}
+
+func (c *Command) Flags() *base.FlagSets {
+	return nil
+}
+
+func (c *Command) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *Command) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *Command) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,292 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oplogcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*VerifyCommand)(nil)
+	_ cli.CommandAutocomplete = (*VerifyCommand)(nil)
+)
+
+type VerifyCommand struct {
+	*base.Server
+
+	Config *config.Config
+
+	commonFlags
+	flagTicketName string
+}
+
+func (c *VerifyCommand) Synopsis() string {
+	return "Verify the integrity of the oplog entries"
+}
+
+func (c *VerifyCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog verify [options]",
+		"",
+		"  Verify the entries of the operation log (oplog) in Boundary's database:",
+		"",
+		`    $ boundary oplog verify -config=/etc/boundary/controller.hcl`,
+		"",
+		"  Each entry is decrypted with the oplog key of its scope, which fails if its data was modified, and decoded. Every entry redeems the next version of a ticket, named after an aggregate, when it is written, so the versions recorded by the entries of each ticket are checked to be consecutive. Unless -end-time is set, the last entry of each ticket is also checked to have redeemed the version before the current one, which detects entries missing at the end.",
+		"",
+		"  Each problem found is written as one JSON object per line, and the command exits with an error if any were found. Deleting a scope deletes the oplog entries encrypted with its keys, which is reported as a gap in the versions of the tickets they redeemed. Entries written before ticket versions were recorded are decrypted and decoded but not checked for gaps.",
+	}) + c.Flags().Help()
+}
+
+func (c *VerifyCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetNone)
+
+	f := c.commonFlags.addFlags(set)
+
+	f.StringVar(&base.StringVar{
+		Name:   "ticket-name",
+		Target: &c.flagTicketName,
+		Usage:  `If set, only entries that redeemed the ticket with this name are verified. Tickets are named after the aggregate they serialize writes to, e.g. "iam_role".`,
+	})
+
+	return set
+}
+
+func (c *VerifyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *VerifyCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *VerifyCommand) Run(args []string) (retCode int) {
+	if result := c.ParseFlagsAndConfig(args); result > 0 {
+		return result
+	}
+
+	defer func() {
+		if err := c.RunShutdownFuncs(); err != nil {
+			c.UI.Error(fmt.Errorf("Error running shutdown tasks: %w", err).Error())
+		}
+	}()
+
+	f, err := c.commonFlags.filter()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+	f.ticketName = c.flagTicketName
+
+	r, ret := openReader(c.Server, c.Config, &c.commonFlags, "boundary-oplog-verify")
+	if r == nil {
+		return ret
+	}
+
+	v := newVerifier()
+	// The current versions of the tickets are read before the entries, so
+	// entries written while verifying can't be reported as missing.
+	if f.endTime.IsZero() {
+		if v.tickets, err = r.tickets(c.Context, f.ticketName); err != nil {
+			c.UI.Error(fmt.Errorf("Error reading oplog tickets: %w", err).Error())
+			return base.CommandCliError
+		}
+	}
+
+	var problems int
+	output := func(p *problem) error {
+		line, err := json.Marshal(p)
+		if err != nil {
+			return fmt.Errorf("unable to encode problem: %w", err)
+		}
+		c.UI.Output(string(line))
+		problems++
+		return nil
+	}
+	if err := r.forEach(c.Context, f, false, func(e *entry) error {
+		for _, p := range v.check(e) {
+			if err := output(p); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		c.UI.Error(fmt.Errorf("Error verifying oplog entries: %w", err).Error())
+		return base.CommandCliError
+	}
+	for _, p := range v.finish() {
+		if err := output(p); err != nil {
+			c.UI.Error(fmt.Errorf("Error verifying oplog entries: %w", err).Error())
+			return base.CommandCliError
+		}
+	}
+
+	if problems > 0 {
+		c.UI.Error(fmt.Sprintf("Found %d problems in %d oplog entries.", problems, v.entries))
+		return base.CommandCliError
+	}
+	c.UI.Warn(fmt.Sprintf("Verified %d oplog entries, %d of which were written before ticket versions were recorded.", v.entries, v.unversioned))
+	return base.CommandSuccess
+}
+
+func (c *VerifyCommand) ParseFlagsAndConfig(args []string) int {
+	var err error
+
+	f := c.Flags()
+
+	if err = f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	if len(c.flagConfig) == 0 {
+		c.UI.Error("Must specify a config file using -config")
+		return base.CommandUserError
+	}
+
+	c.Config, err = config.Load(c.Context, c.flagConfig, c.flagConfigKms)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return base.CommandUserError
+	}
+
+	return base.CommandSuccess
+}
+
+// The kinds of problems found by the verifier.
+const (
+	problemDecrypt     = "decrypt"
+	problemDecode      = "decode"
+	problemGap         = "gap"
+	problemOutOfOrder  = "out_of_order"
+	problemMissingTail = "missing_tail"
+)
+
+// problem is a problem found by the verifier.
+type problem struct {
+	Problem         string `json:"problem"`
+	EntryId         uint32 `json:"entry_id,omitempty"`
+	TicketName      string `json:"ticket_name,omitempty"`
+	TicketVersion   uint32 `json:"ticket_version,omitempty"`
+	ExpectedVersion uint32 `json:"expected_ticket_version,omitempty"`
+	Message         string `json:"message"`
+}
+
+// verifier checks oplog entries, passed to it in the order they were
+// written, for data that can't be decrypted or decoded and for ticket
+// versions that aren't consecutive.
+type verifier struct {
+	// tickets are the current versions of the tickets, keyed by name. The
+	// last entry of each ticket is only checked when it is set.
+	tickets map[string]uint32
+	// last is the last version of each ticket recorded by an entry, and
+	// lastEntry the id of that entry.
+	last      map[string]uint32
+	lastEntry map[string]uint32
+
+	entries     int
+	unversioned int
+}
+
+func newVerifier() *verifier {
+	return &verifier{
+		last:      make(map[string]uint32),
+		lastEntry: make(map[string]uint32),
+	}
+}
+
+// check returns the problems found in e.
+func (v *verifier) check(e *entry) []*problem {
+	v.entries++
+	var problems []*problem
+	switch {
+	case e.decryptErr != nil:
+		problems = append(problems, &problem{
+			Problem: problemDecrypt,
+			EntryId: e.GetId(),
+			Message: e.decryptErr.Error(),
+		})
+	case e.decodeErr != nil && !errors.Match(errors.T(errors.KeyNotFound), e.decodeErr):
+		// Entries with items of types not in the catalog, e.g. written by a
+		// newer version, are only reported by the export command.
+		problems = append(problems, &problem{
+			Problem: problemDecode,
+			EntryId: e.GetId(),
+			Message: e.decodeErr.Error(),
+		})
+	}
+
+	name, version := e.GetTicketName(), e.GetTicketVersion()
+	if name == "" {
+		v.unversioned++
+		return problems
+	}
+	if last, ok := v.last[name]; ok {
+		switch expected := last + 1; {
+		case version > expected:
+			problems = append(problems, &problem{
+				Problem:         problemGap,
+				EntryId:         e.GetId(),
+				TicketName:      name,
+				TicketVersion:   version,
+				ExpectedVersion: expected,
+				Message:         fmt.Sprintf("entries with versions %d to %d of ticket %q are missing", expected, version-1, name),
+			})
+		case version < expected:
+			problems = append(problems, &problem{
+				Problem:         problemOutOfOrder,
+				EntryId:         e.GetId(),
+				TicketName:      name,
+				TicketVersion:   version,
+				ExpectedVersion: expected,
+				Message:         fmt.Sprintf("version %d of ticket %q was already recorded by entry %d or an earlier one", version, name, v.lastEntry[name]),
+			})
+			// Keep checking against the highest version recorded.
+			return problems
+		}
+	}
+	v.last[name] = version
+	v.lastEntry[name] = e.GetId()
+	return problems
+}
+
+// finish returns the problems found once all the entries were checked.
+func (v *verifier) finish() []*problem {
+	if v.tickets == nil {
+		return nil
+	}
+	names := make([]string, 0, len(v.last))
+	for name := range v.last {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var problems []*problem
+	for _, name := range names {
+		current, ok := v.tickets[name]
+		if !ok {
+			continue
+		}
+		// Entries recording versions up to, but not including, the current
+		// version of the ticket were written before it was read.
+		if expected := v.last[name] + 1; expected < current {
+			problems = append(problems, &problem{
+				Problem:         problemMissingTail,
+				EntryId:         v.lastEntry[name],
+				TicketName:      name,
+				TicketVersion:   v.last[name],
+				ExpectedVersion: expected,
+				Message:         fmt.Sprintf("entries with versions %d to %d of ticket %q are missing", expected, current-1, name),
+			})
+		}
+	}
+	return problems
+}
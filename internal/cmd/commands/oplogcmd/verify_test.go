@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oplogcmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifier(t *testing.T) {
+	ctx := context.Background()
+	versioned := func(id uint32, name string, version uint32) *entry {
+		return &entry{Entry: &store.Entry{Id: id, TicketName: name, TicketVersion: version}}
+	}
+
+	tests := []struct {
+		name     string
+		tickets  map[string]uint32
+		entries  []*entry
+		want     []*problem
+		wantTail []*problem
+	}{
+		{
+			name: "consecutive",
+			tickets: map[string]uint32{
+				"iam_role":  4,
+				"iam_scope": 3,
+			},
+			entries: []*entry{
+				versioned(1, "iam_role", 1),
+				versioned(2, "iam_scope", 1),
+				versioned(3, "iam_role", 2),
+				versioned(4, "iam_role", 3),
+				versioned(5, "iam_scope", 2),
+			},
+		},
+		{
+			name: "unversioned entries",
+			entries: []*entry{
+				{Entry: &store.Entry{Id: 1}},
+				versioned(2, "iam_role", 7),
+				versioned(3, "iam_role", 8),
+			},
+		},
+		{
+			name: "gap",
+			entries: []*entry{
+				versioned(1, "iam_role", 1),
+				versioned(2, "iam_role", 4),
+				versioned(3, "iam_role", 5),
+			},
+			want: []*problem{
+				{Problem: problemGap, EntryId: 2, TicketName: "iam_role", TicketVersion: 4, ExpectedVersion: 2},
+			},
+		},
+		{
+			name: "duplicate",
+			entries: []*entry{
+				versioned(1, "iam_role", 1),
+				versioned(2, "iam_role", 2),
+				versioned(3, "iam_role", 2),
+				versioned(4, "iam_role", 3),
+			},
+			want: []*problem{
+				{Problem: problemOutOfOrder, EntryId: 3, TicketName: "iam_role", TicketVersion: 2, ExpectedVersion: 3},
+			},
+		},
+		{
+			name: "out of order",
+			entries: []*entry{
+				versioned(1, "iam_role", 2),
+				versioned(2, "iam_role", 1),
+				versioned(3, "iam_role", 3),
+			},
+			want: []*problem{
+				{Problem: problemOutOfOrder, EntryId: 2, TicketName: "iam_role", TicketVersion: 1, ExpectedVersion: 3},
+			},
+		},
+		{
+			name: "missing tail",
+			tickets: map[string]uint32{
+				"iam_role":  6,
+				"iam_scope": 2,
+			},
+			entries: []*entry{
+				versioned(1, "iam_role", 1),
+				versioned(2, "iam_role", 2),
+				versioned(3, "iam_scope", 1),
+			},
+			wantTail: []*problem{
+				{Problem: problemMissingTail, EntryId: 2, TicketName: "iam_role", TicketVersion: 2, ExpectedVersion: 3},
+			},
+		},
+		{
+			name: "written while verifying",
+			tickets: map[string]uint32{
+				"iam_role": 2,
+			},
+			entries: []*entry{
+				versioned(1, "iam_role", 1),
+				versioned(2, "iam_role", 2),
+				versioned(3, "iam_role", 3),
+			},
+		},
+		{
+			name: "decrypt and decode errors",
+			entries: []*entry{
+				{Entry: &store.Entry{Id: 1}, decryptErr: errors.New(ctx, errors.Decrypt, "test", "bad data")},
+				{Entry: &store.Entry{Id: 2}, decodeErr: errors.New(ctx, errors.Decode, "test", "bad message")},
+				{Entry: &store.Entry{Id: 3}, decodeErr: errors.New(ctx, errors.KeyNotFound, "test", "type name not found")},
+			},
+			want: []*problem{
+				{Problem: problemDecrypt, EntryId: 1},
+				{Problem: problemDecode, EntryId: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			v := newVerifier()
+			v.tickets = tt.tickets
+			var got []*problem
+			for _, e := range tt.entries {
+				got = append(got, v.check(e)...)
+			}
+			assert.Equal(tt.want, withoutMessages(got))
+			assert.Equal(tt.wantTail, withoutMessages(v.finish()))
+			assert.Equal(len(tt.entries), v.entries)
+		})
+	}
+}
+
+// withoutMessages returns the problems without their messages, which are
+// only meant to be read by people.
+func withoutMessages(problems []*problem) []*problem {
+	for _, p := range problems {
+		p.Message = ""
+	}
+	return problems
+}
+
+func TestFilter_where(t *testing.T) {
+	assert := assert.New(t)
+	f := &commonFlags{
+		flagStartTime: "2023-04-01T00:00:00Z",
+		flagEndTime:   "2023-04-02T00:00:00Z",
+	}
+	got, err := f.filter()
+	assert.NoError(err)
+	got.aggregateName = "iam_role"
+	where, args := got.where(42)
+	assert.Equal("id > ? and create_time >= ? and create_time < ? and aggregate_name = ?", where)
+	assert.Equal([]any{uint32(42), got.startTime, got.endTime, "iam_role"}, args)
+
+	f.flagStartTime, f.flagEndTime = f.flagEndTime, f.flagStartTime
+	_, err = f.filter()
+	assert.Error(err)
+
+	f.flagStartTime = "yesterday"
+	_, err = f.filter()
+	assert.Error(err)
+}
+
+func TestNewTypeCatalog(t *testing.T) {
+	ctx := context.Background()
+	catalog, err := newTypeCatalog(ctx)
+	assert.NoError(t, err)
+	// Every type is registered under a single name, so the name of a decoded
+	// message is the one it was written with.
+	for name := range *catalog {
+		i, err := catalog.Get(ctx, name)
+		assert.NoError(t, err)
+		got, err := catalog.GetTypeName(ctx, i)
+		assert.NoError(t, err)
+		assert.Equal(t, name, got)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rolescmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/roles"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ExplainCommand)(nil)
+	_ cli.CommandAutocomplete = (*ExplainCommand)(nil)
+)
+
+type ExplainCommand struct {
+	*base.Command
+
+	flagPrincipalId string
+	flagResourceId  string
+	flagType        string
+	flagAction      string
+}
+
+func (c *ExplainCommand) Synopsis() string {
+	return wordwrap.WrapString("Explain whether a principal is authorized to perform an action on a resource", base.TermWidth)
+}
+
+func (c *ExplainCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary roles explain [options]",
+		"",
+		"  Explain whether a principal (user, group, or managed group) is authorized to perform an action on a resource, listing the grants that authorize or deny it. If no principal is given, the permissions of the caller are explained; explaining the permissions of other principals requires the explain action on roles in the resource's scope. Example:",
+		"",
+		`    $ boundary roles explain -principal-id u_1234567890 -resource-id ttcp_1234567890 -action authorize-session`,
+		"",
+		"  Collection actions are explained by passing the collection type and the scope or parent resource containing it:",
+		"",
+		`    $ boundary roles explain -resource-id p_1234567890 -type target -action create`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ExplainCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "principal-id",
+		Target: &c.flagPrincipalId,
+		Usage:  "The ID of the user, group, or managed group to explain the permissions of. Defaults to the caller.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "resource-id",
+		Target: &c.flagResourceId,
+		Usage:  "The ID of the resource to evaluate the action against, or of the scope or parent resource containing the collection if -type is set.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "type",
+		Target: &c.flagType,
+		Usage:  "The type of the collection to evaluate a collection action, such as create or list, against.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "action",
+		Target: &c.flagAction,
+		Usage:  "The action to evaluate.",
+	})
+
+	return set
+}
+
+func (c *ExplainCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *ExplainCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ExplainCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	switch {
+	case c.flagResourceId == "":
+		c.PrintCliError(errors.New("Resource ID must be passed in via -resource-id"))
+		return base.CommandUserError
+	case c.flagAction == "":
+		c.PrintCliError(errors.New("Action must be passed in via -action"))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	result, err := roles.NewClient(client).Explain(c.Context, c.flagPrincipalId, c.flagResourceId, c.flagType, c.flagAction)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when performing explain on role")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to explain permission: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(printExplanationTable(result.GetItem()))
+	case "json":
+		if ok := c.PrintJsonItem(result.GetResponse()); !ok {
+			return base.CommandCliError
+		}
+	}
+
+	return base.CommandSuccess
+}
+
+func printExplanationTable(item *roles.Explanation) string {
+	output := []string{
+		"",
+		"Permission explanation:",
+		fmt.Sprintf("  Principal ID:          %s", item.PrincipalId),
+		fmt.Sprintf("  Resource ID:           %s", item.ResourceId),
+		fmt.Sprintf("  Type:                  %s", item.Type),
+		fmt.Sprintf("  Scope ID:              %s", item.ScopeId),
+		fmt.Sprintf("  Action:                %s", item.Action),
+		fmt.Sprintf("  Authorized:            %t", item.Authorized),
+	}
+	if len(item.AllowedBy) > 0 {
+		output = append(output,
+			"",
+			"  Allowed By:",
+		)
+		output = append(output, explanationGrantsLines(item.AllowedBy...)...)
+	}
+	if item.DeniedBy != nil {
+		output = append(output,
+			"",
+			"  Denied By:",
+		)
+		output = append(output, explanationGrantsLines(item.DeniedBy)...)
+	}
+	if item.RequiredGrant != "" {
+		output = append(output,
+			"",
+			fmt.Sprintf("  Required Grant:        %s", item.RequiredGrant),
+		)
+	}
+	return base.WrapForHelpText(output)
+}
+
+func explanationGrantsLines(grants ...*roles.ExplanationGrant) []string {
+	var output []string
+	for i, g := range grants {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("    Role ID:             %s", g.RoleId),
+			fmt.Sprintf("    Grant Scope ID:      %s", g.GrantScopeId),
+			fmt.Sprintf("    Grant:               %s", g.Grant),
+		)
+	}
+	return output
+}
@@ -28,18 +28,24 @@ type extraCmdVars struct {
 	flagGrantScopeId string
 	flagPrincipals   []string
 	flagGrants       []string
+	flagGrantScopes  []string
+	flagNotBefore    string
+	flagNotAfter     string
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create":            {"grant-scope-id"},
-		"update":            {"grant-scope-id"},
-		"add-principals":    {"id", "principal", "version"},
-		"set-principals":    {"id", "principal", "version"},
-		"remove-principals": {"id", "principal", "version"},
-		"add-grants":        {"id", "grant", "version"},
-		"set-grants":        {"id", "grant", "version"},
-		"remove-grants":     {"id", "grant", "version"},
+		"create":              {"grant-scope-id"},
+		"update":              {"grant-scope-id"},
+		"add-principals":      {"id", "principal", "not-before", "not-after", "version"},
+		"set-principals":      {"id", "principal", "version"},
+		"remove-principals":   {"id", "principal", "version"},
+		"add-grants":          {"id", "grant", "version"},
+		"set-grants":          {"id", "grant", "version"},
+		"remove-grants":       {"id", "grant", "version"},
+		"add-grant-scopes":    {"id", "grant-scope", "version"},
+		"set-grant-scopes":    {"id", "grant-scope", "version"},
+		"remove-grant-scopes": {"id", "grant-scope", "version"},
 	}
 }
 
@@ -49,6 +55,8 @@ func extraSynopsisFuncImpl(c *Command) string {
 		return c.principalsGrantsSynopsisFunc(c.Func, true)
 	case "add-grants", "set-grants", "remove-grants":
 		return c.principalsGrantsSynopsisFunc(c.Func, false)
+	case "add-grant-scopes", "set-grant-scopes", "remove-grant-scopes":
+		return c.grantScopesSynopsisFunc(c.Func)
 	}
 
 	return ""
@@ -71,6 +79,19 @@ func (c *Command) principalsGrantsSynopsisFunc(inFunc string, principals bool) s
 	return wordwrap.WrapString(fmt.Sprintf("%s a role", in), base.TermWidth)
 }
 
+func (c *Command) grantScopesSynopsisFunc(inFunc string) string {
+	var in string
+	switch {
+	case strings.HasPrefix(inFunc, "add"):
+		in = "Add grant scopes to"
+	case strings.HasPrefix(inFunc, "set"):
+		in = "Set the full contents of the grant scopes on"
+	case strings.HasPrefix(inFunc, "remove"):
+		in = "Remove grant scopes from"
+	}
+	return wordwrap.WrapString(fmt.Sprintf("%s a role", in), base.TermWidth)
+}
+
 func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 	var helpStr string
 	switch c.Func {
@@ -82,6 +103,10 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			`    $ boundary roles add-principals -id r_1234567890 -principal u_1234567890`,
 			"",
+			`  The "not-before" and "not-after" flags limit when the added principals are granted the role. Expired assignments are removed from the role automatically. Example:`,
+			"",
+			`    $ boundary roles add-principals -id r_1234567890 -principal u_1234567890 -not-after 2030-01-02T15:04:05Z`,
+			"",
 			"",
 		})
 
@@ -140,6 +165,39 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 		})
 
+	case "add-grant-scopes":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary roles add-grant-scopes [options] [args]",
+			"",
+			`  Adds grant scopes to a role given its ID. The "grant-scope" flag can be specified multiple times and accepts a scope ID or one of the special values "this", "children", or "descendants". Example:`,
+			"",
+			`    $ boundary roles add-grant-scopes -id r_1234567890 -grant-scope this -grant-scope children`,
+			"",
+			"",
+		})
+
+	case "set-grant-scopes":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary roles set-grant-scopes [options] [args]",
+			"",
+			`  Sets the complete set of grant scopes on a role given its ID. The "grant-scope" flag can be specified multiple times and accepts a scope ID or one of the special values "this", "children", or "descendants". Example:`,
+			"",
+			`    $ boundary roles set-grant-scopes -id r_1234567890 -grant-scope this -grant-scope descendants`,
+			"",
+			"",
+		})
+
+	case "remove-grant-scopes":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary roles remove-grant-scopes [options] [args]",
+			"",
+			`  Removes grant scopes from a role given its ID. The "grant-scope" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary roles remove-grant-scopes -id r_1234567890 -grant-scope children`,
+			"",
+			"",
+		})
+
 	default:
 		helpStr = helpMap["base"]()
 	}
@@ -161,12 +219,30 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagPrincipals,
 				Usage:  "The principals (users or groups) to add, remove, or set. May be specified multiple times.",
 			})
+		case "not-before":
+			f.StringVar(&base.StringVar{
+				Name:   "not-before",
+				Target: &c.flagNotBefore,
+				Usage:  "The time, in RFC3339 format, before which the added principals are not granted the role.",
+			})
+		case "not-after":
+			f.StringVar(&base.StringVar{
+				Name:   "not-after",
+				Target: &c.flagNotAfter,
+				Usage:  "The time, in RFC3339 format, at which the added principals stop being granted the role.",
+			})
 		case "grant":
 			f.StringSliceVar(&base.StringSliceVar{
 				Name:   "grant",
 				Target: &c.flagGrants,
 				Usage:  "The grants to add, remove, or set. May be specified multiple times. Can be in compact string format or JSON (be sure to escape JSON properly).",
 			})
+		case "grant-scope":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "grant-scope",
+				Target: &c.flagGrantScopes,
+				Usage:  `The grant scopes to add, remove, or set. May be specified multiple times. Can be a scope ID or one of "this", "children", or "descendants".`,
+			})
 		}
 	}
 }
@@ -186,6 +262,22 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]roles.Opti
 			c.UI.Error("No principals supplied via -principal")
 			return false
 		}
+		if c.flagNotBefore != "" {
+			t, err := time.Parse(time.RFC3339, c.flagNotBefore)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error parsing -not-before: %w", err).Error())
+				return false
+			}
+			*opts = append(*opts, roles.WithNotBefore(t))
+		}
+		if c.flagNotAfter != "" {
+			t, err := time.Parse(time.RFC3339, c.flagNotAfter)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error parsing -not-after: %w", err).Error())
+				return false
+			}
+			*opts = append(*opts, roles.WithNotAfter(t))
+		}
 
 	case "add-grants", "remove-grants":
 		if len(c.flagGrants) == 0 {
@@ -193,6 +285,23 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]roles.Opti
 			return false
 		}
 
+	case "add-grant-scopes", "remove-grant-scopes":
+		if len(c.flagGrantScopes) == 0 {
+			c.UI.Error("No grant scopes supplied via -grant-scope")
+			return false
+		}
+
+	case "set-grant-scopes":
+		switch len(c.flagGrantScopes) {
+		case 0:
+			c.UI.Error("No grant scopes supplied via -grant-scope")
+			return false
+		case 1:
+			if c.flagGrantScopes[0] == "null" {
+				c.flagGrantScopes = nil
+			}
+		}
+
 	case "set-principals":
 		switch len(c.flagPrincipals) {
 		case 0:
@@ -267,6 +376,24 @@ func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *roles
 			return nil, nil, nil, err
 		}
 		return result.GetResponse(), result.GetItem(), nil, err
+	case "add-grant-scopes":
+		result, err := roleClient.AddGrantScopes(c.Context, c.FlagId, version, c.flagGrantScopes, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
+	case "set-grant-scopes":
+		result, err := roleClient.SetGrantScopes(c.Context, c.FlagId, version, c.flagGrantScopes, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
+	case "remove-grant-scopes":
+		result, err := roleClient.RemoveGrantScopes(c.Context, c.FlagId, version, c.flagGrantScopes, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
 	}
 	return origResp, origItem, origItems, origError
 }
@@ -385,6 +512,15 @@ func printItemTable(item *roles.Role, resp *api.Response) string {
 			fmt.Sprintf("      Type:         %s", principal.Type),
 			fmt.Sprintf("      Scope ID:     %s", principal.ScopeId),
 		)
+		if principal.Status != "" {
+			ret = append(ret, fmt.Sprintf("      Status:       %s", principal.Status))
+		}
+		if !principal.NotBefore.IsZero() {
+			ret = append(ret, fmt.Sprintf("      Not Before:   %s", principal.NotBefore.Local().Format(time.RFC1123)))
+		}
+		if !principal.NotAfter.IsZero() {
+			ret = append(ret, fmt.Sprintf("      Not After:    %s", principal.NotAfter.Local().Format(time.RFC1123)))
+		}
 	}
 	if len(item.Grants) > 0 {
 		ret = append(ret,
@@ -397,6 +533,13 @@ func printItemTable(item *roles.Role, resp *api.Response) string {
 			fmt.Sprintf("    %s", grant.Canonical),
 		)
 	}
+	if len(item.GrantScopeIds) > 0 {
+		ret = append(ret,
+			"",
+			"  Grant Scope IDs:",
+			base.WrapSlice(4, item.GrantScopeIds),
+		)
+	}
 
 	return base.WrapForHelpText(ret)
 }
@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rolescmd
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/roles"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+)
+
+// NewListHistoryCommand returns the command listing the change history of a
+// role.
+func NewListHistoryCommand(c *base.Command) *common.ListHistoryCommand {
+	return &common.ListHistoryCommand{
+		Command:      c,
+		ResourceType: "role",
+		Changes:      "a role and its grants, grant scopes, and principals",
+		ExampleId:    "r_1234567890",
+		ListFn: func(ctx context.Context, client *api.Client, id string) ([]*roles.HistoryEntry, *api.Response, error) {
+			result, err := roles.NewClient(client).ListHistory(ctx, id)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result.GetItems(), result.GetResponse(), nil
+		},
+	}
+}
@@ -171,6 +171,18 @@ func printItemTable(item *scopes.Scope, resp *api.Response) string {
 		)
 	}
 
+	if len(item.Quotas) > 0 {
+		ret = append(ret,
+			"",
+			"  Quotas:",
+		)
+		for _, q := range item.Quotas {
+			ret = append(ret,
+				fmt.Sprintf("    %-20s %d of %d", q.ResourceType+":", q.Usage, q.Limit),
+			)
+		}
+	}
+
 	if len(item.AuthorizedActions) > 0 {
 		ret = append(ret,
 			"",
@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scopescmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/scopes"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ReadKeyRotationPolicyCommand)(nil)
+	_ cli.CommandAutocomplete = (*ReadKeyRotationPolicyCommand)(nil)
+)
+
+type ReadKeyRotationPolicyCommand struct {
+	*base.Command
+}
+
+func (c *ReadKeyRotationPolicyCommand) Synopsis() string {
+	return wordwrap.WrapString("Read the key rotation policy of a scope", base.TermWidth)
+}
+
+func (c *ReadKeyRotationPolicyCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary scopes read-key-rotation-policy [args]",
+		"",
+		"  Read the key rotation policy of a scope, including the time of its next scheduled rotation and the history of the rotations of the keys within the scope. Example:",
+		"",
+		`    $ boundary scopes read-key-rotation-policy -scope-id global`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ReadKeyRotationPolicyCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "scope-id",
+		Target: &c.FlagScopeId,
+		Usage:  "The id of the scope whose key rotation policy to read",
+	})
+
+	return set
+}
+
+func (c *ReadKeyRotationPolicyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *ReadKeyRotationPolicyCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ReadKeyRotationPolicyCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	switch {
+	case c.FlagScopeId == "":
+		c.PrintCliError(errors.New("Scope ID must be provided via -scope-id"))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	result, err := scopes.NewClient(client).ReadKeyRotationPolicy(c.Context, c.FlagScopeId)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when reading key rotation policy")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to read key rotation policy: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		if ok := c.PrintJsonItem(result.GetResponse()); !ok {
+			return base.CommandCliError
+		}
+
+	default:
+		c.UI.Output(printKeyRotationPolicyTable(result.GetItem()))
+	}
+
+	return base.CommandSuccess
+}
+
+func printKeyRotationPolicyTable(item *scopes.KeyRotationPolicy) string {
+	nonAttributeMap := map[string]any{}
+	if item.RotationIntervalSeconds == 0 {
+		nonAttributeMap["Rotation Interval"] = "not set; keys are only rotated when requested"
+	} else {
+		nonAttributeMap["Rotation Interval"] = (time.Duration(item.RotationIntervalSeconds) * time.Second).String()
+		nonAttributeMap["Destroy Previous Versions"] = item.DestroyPreviousVersions
+	}
+	if !item.NextRotationTime.IsZero() {
+		nonAttributeMap["Next Rotation Time"] = item.NextRotationTime.Local().Format(time.RFC1123)
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Key rotation policy information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if item.Scope != nil {
+		ret = append(ret,
+			"",
+			"  Scope:",
+			base.ScopeInfoForOutput(item.Scope, maxLength),
+		)
+	}
+
+	if len(item.Rotations) > 0 {
+		ret = append(ret,
+			"",
+			"  Rotations:",
+		)
+		for _, r := range item.Rotations {
+			trigger := "requested"
+			if r.Scheduled {
+				trigger = "scheduled"
+			}
+			line := fmt.Sprintf("    %s  %s", r.RotatedTime.Local().Format(time.RFC1123), trigger)
+			if r.Rewrap {
+				line += ", rewrapped"
+			}
+			if r.DestroyPreviousVersions {
+				line += ", previous versions destroyed"
+			}
+			ret = append(ret, line)
+		}
+	}
+
+	return base.WrapForHelpText(ret)
+}
@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scopescmd
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/scopes"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*SetKeyRotationPolicyCommand)(nil)
+	_ cli.CommandAutocomplete = (*SetKeyRotationPolicyCommand)(nil)
+)
+
+type SetKeyRotationPolicyCommand struct {
+	*base.Command
+
+	flagRotationInterval        string
+	flagDestroyPreviousVersions bool
+}
+
+func (c *SetKeyRotationPolicyCommand) Synopsis() string {
+	return wordwrap.WrapString("Set the key rotation policy of a scope", base.TermWidth)
+}
+
+func (c *SetKeyRotationPolicyCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary scopes set-key-rotation-policy [args]",
+		"",
+		"  Set the interval at which the controller rotates the keys within a scope, rewrapping existing DEKs with the new KEK. The first scheduled rotation happens one interval after the last scheduled rotation, or after the policy was first set. Example:",
+		"",
+		`    $ boundary scopes set-key-rotation-policy -scope-id global -rotation-interval 90d`,
+		"",
+		"  To also destroy the DEK versions that were active before each scheduled rotation, once the data they encrypt has been re-encrypted:",
+		"",
+		`    $ boundary scopes set-key-rotation-policy -scope-id global -rotation-interval 90d -destroy-previous-versions`,
+		"",
+		"  To remove the policy of the scope, set an interval of 0:",
+		"",
+		`    $ boundary scopes set-key-rotation-policy -scope-id global -rotation-interval 0`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *SetKeyRotationPolicyCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "scope-id",
+		Target: &c.FlagScopeId,
+		Usage:  "The id of the scope whose key rotation policy to set",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "rotation-interval",
+		Target: &c.flagRotationInterval,
+		Usage:  `The interval between scheduled rotations, e.g. "90d" or "2160h". A number without a unit is in seconds. An interval of 0 removes the policy.`,
+	})
+	f.BoolVar(&base.BoolVar{
+		Name:   "destroy-previous-versions",
+		Target: &c.flagDestroyPreviousVersions,
+		Usage:  "Whether to destroy the DEK versions that were active before each scheduled rotation, once the data they encrypt has been re-encrypted. Oplog DEK versions are never destroyed.",
+	})
+
+	return set
+}
+
+func (c *SetKeyRotationPolicyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *SetKeyRotationPolicyCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *SetKeyRotationPolicyCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	switch {
+	case c.FlagScopeId == "":
+		c.PrintCliError(errors.New("Scope ID must be provided via -scope-id"))
+		return base.CommandUserError
+	case c.flagRotationInterval == "":
+		c.PrintCliError(errors.New("Rotation interval must be provided via -rotation-interval"))
+		return base.CommandUserError
+	}
+
+	interval, err := parseutil.ParseDurationSecond(c.flagRotationInterval)
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error parsing rotation interval: %w", err))
+		return base.CommandUserError
+	}
+	if interval < 0 || interval/time.Second > math.MaxUint32 {
+		c.PrintCliError(fmt.Errorf("Rotation interval %q is out of range", c.flagRotationInterval))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	result, err := scopes.NewClient(client).SetKeyRotationPolicy(c.Context, c.FlagScopeId, uint32(interval/time.Second), c.flagDestroyPreviousVersions)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when setting key rotation policy")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to set key rotation policy: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		if ok := c.PrintJsonItem(result.GetResponse()); !ok {
+			return base.CommandCliError
+		}
+
+	default:
+		c.UI.Output(printKeyRotationPolicyTable(result.GetItem()))
+	}
+
+	return base.CommandSuccess
+}
@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scopescmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/scopes"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*SetQuotasCommand)(nil)
+	_ cli.CommandAutocomplete = (*SetQuotasCommand)(nil)
+)
+
+type SetQuotasCommand struct {
+	*base.Command
+
+	flagQuotas []string
+}
+
+func (c *SetQuotasCommand) Synopsis() string {
+	return wordwrap.WrapString("Set the resource quotas of an org or project scope", base.TermWidth)
+}
+
+func (c *SetQuotasCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary scopes set-quotas [options] [args]",
+		"",
+		"  Set the full set of resource quotas on an org or project scope, replacing any existing quotas. A quota on an org limits the total across all of its projects. Quotas can be set on targets, host catalogs, credential stores, and sessions that have not been terminated. Example:",
+		"",
+		`    $ boundary scopes set-quotas -id p_1234567890 -quota target=100 -quota session=1000`,
+		"",
+		"  To remove all quotas from the scope, pass null:",
+		"",
+		`    $ boundary scopes set-quotas -id p_1234567890 -quota null`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *SetQuotasCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "id",
+		Target: &c.FlagId,
+		Usage:  "The id of the org or project scope on which to set quotas",
+	})
+	f.IntVar(&base.IntVar{
+		Name:   "version",
+		Target: &c.FlagVersion,
+		Usage:  "The version of the scope against which to perform the operation. If not specified, the command will perform a check-and-set automatically.",
+	})
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:   "quota",
+		Target: &c.flagQuotas,
+		Usage:  `A quota to set, in the form <resource type>=<limit>, e.g. "target=100". May be specified multiple times.`,
+	})
+
+	return set
+}
+
+func (c *SetQuotasCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *SetQuotasCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *SetQuotasCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	switch {
+	case c.FlagId == "":
+		c.PrintCliError(errors.New("ID must be provided via -id"))
+		return base.CommandUserError
+	case len(c.flagQuotas) == 0:
+		c.PrintCliError(errors.New("No quotas supplied via -quota"))
+		return base.CommandUserError
+	}
+
+	quotas := make([]*scopes.Quota, 0, len(c.flagQuotas))
+	if len(c.flagQuotas) != 1 || c.flagQuotas[0] != "null" {
+		for _, q := range c.flagQuotas {
+			resourceType, limit, ok := strings.Cut(q, "=")
+			if !ok || resourceType == "" {
+				c.PrintCliError(fmt.Errorf("Quota %q is not in the form <resource type>=<limit>", q))
+				return base.CommandUserError
+			}
+			l, err := strconv.ParseUint(limit, 10, 32)
+			if err != nil {
+				c.PrintCliError(fmt.Errorf("Error parsing limit of quota %q: %w", q, err))
+				return base.CommandUserError
+			}
+			quotas = append(quotas, &scopes.Quota{ResourceType: resourceType, Limit: uint32(l)})
+		}
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	var opts []scopes.Option
+	version := uint32(c.FlagVersion)
+	if version == 0 {
+		opts = append(opts, scopes.WithAutomaticVersioning(true))
+	}
+
+	result, err := scopes.NewClient(client).SetQuotas(c.Context, c.FlagId, version, quotas, opts...)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when performing set-quotas on scope")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to set quotas on scope: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(printItemTable(result.GetItem(), result.GetResponse()))
+	case "json":
+		if ok := c.PrintJsonItem(result.GetResponse()); !ok {
+			return base.CommandCliError
+		}
+	}
+
+	return base.CommandSuccess
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
 	"go.uber.org/atomic"
+	"golang.org/x/exp/slices"
 )
 
 var (
@@ -411,6 +412,13 @@ func (c *Command) Run(args []string) int {
 		c.DatabaseMaxOpenConnections = c.Config.Controller.Database.MaxOpenConnections
 		c.DatabaseMaxIdleConnections = c.Config.Controller.Database.MaxIdleConnections
 		c.DatabaseConnMaxIdleTimeDuration = c.Config.Controller.Database.ConnMaxIdleTimeDuration
+		c.DatabaseReplicaUrls, err = parseReplicaUrls(c.Config.Controller.Database.ReplicaUrls)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return base.CommandUserError
+		}
+		c.DatabaseMaxReplicaLag = c.Config.Controller.Database.MaxReplicaLagDuration
+		c.DatabaseSlowQueryThreshold = c.Config.Controller.Database.SlowQueryThresholdDuration
 
 		if err := c.OpenAndSetServerDatabase(c.Context, "postgres"); err != nil {
 			c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
@@ -800,6 +808,9 @@ func (c *Command) Reload(newConf *config.Config) error {
 	if err != nil {
 		reloadErrors = multierror.Append(reloadErrors, fmt.Errorf("failed to reload controller database: %w", err))
 	}
+	if err := c.reloadControllerDatabaseReplicas(newConf); err != nil {
+		reloadErrors = multierror.Append(reloadErrors, fmt.Errorf("failed to reload controller database replicas: %w", err))
+	}
 
 	if newConf != nil && c.worker != nil {
 		workerReloadErr := func() error {
@@ -912,6 +923,71 @@ func (c *Command) reloadControllerDatabase(newConfig *config.Config) error {
 	return nil
 }
 
+func (c *Command) reloadControllerDatabaseReplicas(newConfig *config.Config) error {
+	if c.Server == nil || c.Server.Database == nil {
+		return nil
+	}
+	if c.controller == nil {
+		return nil
+	}
+	if newConfig == nil || newConfig.Controller == nil || newConfig.Controller.Database == nil {
+		return nil
+	}
+
+	urls, err := parseReplicaUrls(newConfig.Controller.Database.ReplicaUrls)
+	if err != nil {
+		return err
+	}
+	maxLag := newConfig.Controller.Database.MaxReplicaLagDuration
+	if slices.Equal(urls, c.DatabaseReplicaUrls) && maxLag == c.DatabaseMaxReplicaLag {
+		return nil
+	}
+
+	// With no replica URLs, replicas are no longer used
+	var newReplicas *db.Replicas
+	if len(urls) > 0 {
+		newReplicas, err = c.Server.OpenDatabaseReplicas(c.Context, "postgres", urls, maxLag)
+		if err != nil {
+			return fmt.Errorf("failed to open connections to new database replicas: %w", err)
+		}
+	}
+
+	oldReplicasCloseFn, err := c.Database.SwapReplicas(c.Context, newReplicas)
+	if err != nil {
+		if newReplicas != nil {
+			_ = newReplicas.Close(c.Context)
+		}
+		return fmt.Errorf("failed to swap database replicas: %w", err)
+	}
+	c.Server.DatabaseReplicaUrls = urls
+	c.Server.DatabaseMaxReplicaLag = maxLag
+	c.Config.Controller.Database.ReplicaUrls = newConfig.Controller.Database.ReplicaUrls
+	c.Config.Controller.Database.MaxReplicaLagDuration = maxLag
+	oldReplicasCloseFn(c.Context)
+
+	return nil
+}
+
+// parseReplicaUrls resolves the replica URLs in the controller's database
+// configuration, which may refer to environment variables or files.
+func parseReplicaUrls(in []string) ([]string, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	urls := make([]string, 0, len(in))
+	for i, u := range in {
+		parsed, err := parseutil.ParsePath(u)
+		if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+			return nil, fmt.Errorf("Error parsing database replica url %d: %w", i, err)
+		}
+		if parsed == "" {
+			return nil, fmt.Errorf("Database replica url %d is empty", i)
+		}
+		urls = append(urls, parsed)
+	}
+	return urls, nil
+}
+
 // acquireSchemaManager returns a schema manager and generally acquires a shared lock on
 // the database. This is done as a mechanism to disallow running migration commands
 // while the database is in use.
@@ -959,9 +1035,11 @@ func verifyDatabaseState(ctx context.Context, db *db.DB, schemaManager *schema.M
 			"has access to all Boundary tables, or run 'boundary database init' if you haven't initialized " +
 			"your database for Boundary.")
 	}
-	if !s.MigrationsApplied() {
+	// A database that online migrations have moved past this binary's schema
+	// version is still compatible with it.
+	if !s.Compatible() {
 		for _, e := range s.Editions {
-			if e.DatabaseSchemaState == schema.Ahead {
+			if e.DatabaseSchemaState == schema.Ahead && !e.Compatible() {
 				return fmt.Errorf("Newer schema version (%s %d) "+
 					"than this binary expects. Please use a newer version of the boundary "+
 					"binary.", e.Name, e.DatabaseSchemaVersion)
@@ -969,8 +1047,9 @@ func verifyDatabaseState(ctx context.Context, db *db.DB, schemaManager *schema.M
 		}
 		return fmt.Errorf("Database schema must be updated to use this version. " +
 			"Run 'boundary database migrate' to update the database. " +
-			"NOTE: Boundary does not currently support live migration; " +
-			"Ensure all controllers are shut down before running the migration command.")
+			"Unless all pending migrations are online, ensure all controllers are shut down " +
+			"before running the migration command. Online migrations can be applied while " +
+			"controllers are running with 'boundary database migrate -online'.")
 	}
 
 	err = verifyKmsSetup(db)
@@ -0,0 +1,286 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceaccountscmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/serviceaccounts"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/go-wordwrap"
+)
+
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+}
+
+type extraCmdVars struct {
+	flagApiKeyScopeId    string
+	flagApiKeyId         string
+	flagApiKeyExpiration string
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"add-api-key":    {"id", "api-key-scope-id", "api-key-expiration", "version"},
+		"remove-api-key": {"id", "api-key-id", "version"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "add-api-key":
+		return wordwrap.WrapString("Add an API key to a service account", base.TermWidth)
+	case "remove-api-key":
+		return wordwrap.WrapString("Remove an API key from a service account", base.TermWidth)
+	default:
+		return ""
+	}
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "":
+		return helpMap["base"]()
+
+	case "add-api-key":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary service-accounts add-api-key [options] [args]",
+			"",
+			`  Adds an API key to a service account given its ID. The key can only be used for resources within the given org or project scope. The key's token is only shown in the output of this command. A service account can have at most two keys, so a key can be rotated by adding a new one and removing the old one once it is no longer in use. Example:`,
+			"",
+			`    $ boundary service-accounts add-api-key -id sa_1234567890 -api-key-scope-id p_1234567890 -api-key-expiration 720h`,
+			"",
+			"",
+		})
+
+	case "remove-api-key":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary service-accounts remove-api-key [options] [args]",
+			"",
+			`  Removes an API key from a service account given its ID. Example:`,
+			"",
+			`    $ boundary service-accounts remove-api-key -id sa_1234567890 -api-key-id sak_1234567890`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "api-key-scope-id":
+			f.StringVar(&base.StringVar{
+				Name:   "api-key-scope-id",
+				Target: &c.flagApiKeyScopeId,
+				Usage:  "The ID of the org or project scope the API key can be used in.",
+			})
+		case "api-key-expiration":
+			f.StringVar(&base.StringVar{
+				Name:   "api-key-expiration",
+				Target: &c.flagApiKeyExpiration,
+				Usage:  "How long the API key is valid for, as a duration (e.g. \"720h\"). If not set, the controller's default is used.",
+			})
+		case "api-key-id":
+			f.StringVar(&base.StringVar{
+				Name:   "api-key-id",
+				Target: &c.flagApiKeyId,
+				Usage:  "The ID of the API key to remove.",
+			})
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, _ *[]serviceaccounts.Option) bool {
+	switch c.Func {
+	case "add-api-key":
+		if c.flagApiKeyScopeId == "" {
+			c.UI.Error("No API key scope ID supplied via -api-key-scope-id")
+			return false
+		}
+		if c.flagApiKeyExpiration != "" {
+			if _, err := time.ParseDuration(c.flagApiKeyExpiration); err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing -api-key-expiration: %s", err))
+				return false
+			}
+		}
+
+	case "remove-api-key":
+		if c.flagApiKeyId == "" {
+			c.UI.Error("No API key ID supplied via -api-key-id")
+			return false
+		}
+	}
+
+	return true
+}
+
+func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *serviceaccounts.ServiceAccount, origItems []*serviceaccounts.ServiceAccount, origError error, saClient *serviceaccounts.Client, version uint32, opts []serviceaccounts.Option) (*api.Response, *serviceaccounts.ServiceAccount, []*serviceaccounts.ServiceAccount, error) {
+	switch c.Func {
+	case "add-api-key":
+		var expiration time.Time
+		if c.flagApiKeyExpiration != "" {
+			// Already validated in extraFlagsHandlingFuncImpl
+			d, _ := time.ParseDuration(c.flagApiKeyExpiration)
+			expiration = time.Now().Add(d)
+		}
+		result, err := saClient.AddApiKey(c.Context, c.FlagId, version, c.flagApiKeyScopeId, expiration, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
+	case "remove-api-key":
+		result, err := saClient.RemoveApiKey(c.Context, c.FlagId, version, c.flagApiKeyId, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
+	}
+	return origResp, origItem, origItems, origError
+}
+
+func (c *Command) printListTable(items []*serviceaccounts.ServiceAccount) string {
+	if len(items) == 0 {
+		return "No service accounts found"
+	}
+	var output []string
+	output = []string{
+		"",
+		"Service Account information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if item.Id != "" {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", item.Id),
+			)
+		} else {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", "(not available)"),
+			)
+		}
+		if c.FlagRecursive && item.ScopeId != "" {
+			output = append(output,
+				fmt.Sprintf("    Scope ID:            %s", item.ScopeId),
+			)
+		}
+		if item.Version > 0 {
+			output = append(output,
+				fmt.Sprintf("    Version:             %d", item.Version),
+			)
+		}
+		if item.Name != "" {
+			output = append(output,
+				fmt.Sprintf("    Name:                %s", item.Name),
+			)
+		}
+		if item.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:         %s", item.Description),
+			)
+		}
+		if len(item.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, item.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(item *serviceaccounts.ServiceAccount, resp *api.Response) string {
+	nonAttributeMap := map[string]any{}
+	if item.Id != "" {
+		nonAttributeMap["ID"] = item.Id
+	}
+	if item.Version != 0 {
+		nonAttributeMap["Version"] = item.Version
+	}
+	if !item.CreatedTime.IsZero() {
+		nonAttributeMap["Created Time"] = item.CreatedTime.Local().Format(time.RFC1123)
+	}
+	if !item.UpdatedTime.IsZero() {
+		nonAttributeMap["Updated Time"] = item.UpdatedTime.Local().Format(time.RFC1123)
+	}
+	if item.Name != "" {
+		nonAttributeMap["Name"] = item.Name
+	}
+	if item.Description != "" {
+		nonAttributeMap["Description"] = item.Description
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	var keyMaps []map[string]any
+	if len(item.ApiKeys) > 0 {
+		for _, key := range item.ApiKeys {
+			m := map[string]any{
+				"ID":              key.Id,
+				"Scope ID":        key.ScopeId,
+				"Created Time":    key.CreatedTime.Local().Format(time.RFC1123),
+				"Expiration Time": key.ExpirationTime.Local().Format(time.RFC1123),
+			}
+			if !key.LastUsedTime.IsZero() {
+				m["Last Used Time"] = key.LastUsedTime.Local().Format(time.RFC1123)
+			}
+			if key.Token != "" {
+				m["Token"] = key.Token
+			}
+			keyMaps = append(keyMaps, m)
+		}
+		if l := len("Expiration Time"); l > maxLength {
+			maxLength = l
+		}
+	}
+
+	ret := []string{
+		"",
+		"Service Account information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if item.Scope != nil {
+		ret = append(ret,
+			"",
+			"  Scope:",
+			base.ScopeInfoForOutput(item.Scope, maxLength),
+		)
+	}
+
+	if len(item.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, item.AuthorizedActions),
+		)
+	}
+
+	if len(item.ApiKeys) > 0 {
+		ret = append(ret,
+			"",
+			"  API Keys:",
+		)
+		for _, m := range keyMaps {
+			ret = append(ret,
+				base.WrapMap(4, maxLength, m),
+				"",
+			)
+		}
+	}
+
+	return base.WrapForHelpText(ret)
+}
@@ -536,6 +536,9 @@ func printItemTable(item *targets.Target, resp *api.Response) string {
 	if item.IngressWorkerFilter != "" {
 		nonAttributeMap["Ingress Worker Filter"] = item.IngressWorkerFilter
 	}
+	if item.EndpointTlsServerName != "" {
+		nonAttributeMap["Endpoint TLS Server Name"] = item.EndpointTlsServerName
+	}
 	if resp != nil && resp.Map != nil {
 		if resp.Map[globals.SessionConnectionLimitField] != nil {
 			nonAttributeMap["Session Connection Limit"] = item.SessionConnectionLimit
@@ -543,6 +546,18 @@ func printItemTable(item *targets.Target, resp *api.Response) string {
 		if resp.Map[globals.SessionMaxSecondsField] != nil {
 			nonAttributeMap["Session Max Seconds"] = item.SessionMaxSeconds
 		}
+		if resp.Map[globals.SessionMaxBytesField] != nil {
+			nonAttributeMap["Session Max Bytes"] = item.SessionMaxBytes
+		}
+		if resp.Map[globals.ConnectionMaxBytesPerSecondField] != nil {
+			nonAttributeMap["Connection Max Bytes Per Second"] = item.ConnectionMaxBytesPerSecond
+		}
+		if resp.Map[globals.ConnectionIdleTimeoutSecondsField] != nil {
+			nonAttributeMap["Connection Idle Timeout Seconds"] = item.ConnectionIdleTimeoutSeconds
+		}
+		if resp.Map[globals.EndpointTlsEnabledField] != nil {
+			nonAttributeMap["Endpoint TLS Enabled"] = item.EndpointTlsEnabled
+		}
 	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, item.Attributes, keySubstMap)
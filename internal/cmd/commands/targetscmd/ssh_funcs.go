@@ -22,19 +22,22 @@ func init() {
 
 func extraSshActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"address", "default-port", "session-max-seconds", "session-connection-limit", "egress-worker-filter", "ingress-worker-filter"},
-		"update": {"address", "default-port", "session-max-seconds", "session-connection-limit", "worker-filter", "egress-worker-filter", "ingress-worker-filter"},
+		"create": {"address", "default-port", "session-max-seconds", "session-connection-limit", "session-max-bytes", "connection-max-bytes-per-second", "connection-idle-timeout", "egress-worker-filter", "ingress-worker-filter"},
+		"update": {"address", "default-port", "session-max-seconds", "session-connection-limit", "session-max-bytes", "connection-max-bytes-per-second", "connection-idle-timeout", "worker-filter", "egress-worker-filter", "ingress-worker-filter"},
 	}
 }
 
 type extraSshCmdVars struct {
-	flagDefaultPort            string
-	flagSessionMaxSeconds      string
-	flagSessionConnectionLimit string
-	flagWorkerFilter           string
-	flagEgressWorkerFilter     string
-	flagIngressWorkerFilter    string
-	flagAddress                string
+	flagDefaultPort                 string
+	flagSessionMaxSeconds           string
+	flagSessionConnectionLimit      string
+	flagSessionMaxBytes             string
+	flagConnectionMaxBytesPerSecond string
+	flagConnectionIdleTimeout       string
+	flagWorkerFilter                string
+	flagEgressWorkerFilter          string
+	flagIngressWorkerFilter         string
+	flagAddress                     string
 }
 
 func (c *SshCommand) extraSshHelpFunc(helpMap map[string]func() string) string {
@@ -94,6 +97,24 @@ func extraSshFlagsFuncImpl(c *SshCommand, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagSessionConnectionLimit,
 				Usage:  "The maximum number of connections allowed for a session. -1 means unlimited.",
 			})
+		case "session-max-bytes":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-max-bytes",
+				Target: &c.flagSessionMaxBytes,
+				Usage:  "The maximum number of bytes that may be proxied across all connections of a session. 0 means unlimited.",
+			})
+		case "connection-max-bytes-per-second":
+			fs.StringVar(&base.StringVar{
+				Name:   "connection-max-bytes-per-second",
+				Target: &c.flagConnectionMaxBytesPerSecond,
+				Usage:  "The maximum throughput of each connection of a session, in bytes per second. 0 means unlimited.",
+			})
+		case "connection-idle-timeout":
+			fs.StringVar(&base.StringVar{
+				Name:   "connection-idle-timeout",
+				Target: &c.flagConnectionIdleTimeout,
+				Usage:  `The maximum time a connection may go without proxying data in either direction before it is closed. Can be specified as an integer number of seconds or a duration string. 0 means no timeout.`,
+			})
 		case "worker-filter":
 			fs.StringVar(&base.StringVar{
 				Name:   "worker-filter",
@@ -163,6 +184,52 @@ func extraSshFlagsHandlingFuncImpl(c *SshCommand, _ *base.FlagSets, opts *[]targ
 		*opts = append(*opts, targets.WithSessionConnectionLimit(int32(limit)))
 	}
 
+	switch c.flagSessionMaxBytes {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionMaxBytes())
+	default:
+		limit, err := strconv.ParseInt(c.flagSessionMaxBytes, 10, 64)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionMaxBytes, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithSessionMaxBytes(limit))
+	}
+
+	switch c.flagConnectionMaxBytesPerSecond {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultConnectionMaxBytesPerSecond())
+	default:
+		limit, err := strconv.ParseInt(c.flagConnectionMaxBytesPerSecond, 10, 64)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagConnectionMaxBytesPerSecond, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithConnectionMaxBytesPerSecond(limit))
+	}
+
+	switch c.flagConnectionIdleTimeout {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultConnectionIdleTimeoutSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagConnectionIdleTimeout, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagConnectionIdleTimeout)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagConnectionIdleTimeout, err))
+				return false
+			}
+			final = uint32(dur.Seconds())
+		}
+		*opts = append(*opts, targets.WithConnectionIdleTimeoutSeconds(final))
+	}
+
 	switch c.flagWorkerFilter {
 	case "":
 	case "null":
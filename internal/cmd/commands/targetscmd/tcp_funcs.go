@@ -4,6 +4,7 @@
 package targetscmd
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/hashicorp/boundary/api/targets"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
 )
 
 func init() {
@@ -21,19 +23,25 @@ func init() {
 
 func extraTcpActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"address", "default-port", "session-max-seconds", "session-connection-limit", "egress-worker-filter", "ingress-worker-filter"},
-		"update": {"address", "default-port", "session-max-seconds", "session-connection-limit", "worker-filter", "egress-worker-filter", "ingress-worker-filter"},
+		"create": {"address", "default-port", "session-max-seconds", "session-connection-limit", "session-max-bytes", "connection-max-bytes-per-second", "connection-idle-timeout", "endpoint-tls-enabled", "endpoint-tls-ca-cert", "endpoint-tls-server-name", "egress-worker-filter", "ingress-worker-filter"},
+		"update": {"address", "default-port", "session-max-seconds", "session-connection-limit", "session-max-bytes", "connection-max-bytes-per-second", "connection-idle-timeout", "endpoint-tls-enabled", "endpoint-tls-ca-cert", "endpoint-tls-server-name", "worker-filter", "egress-worker-filter", "ingress-worker-filter"},
 	}
 }
 
 type extraTcpCmdVars struct {
-	flagDefaultPort            string
-	flagSessionMaxSeconds      string
-	flagSessionConnectionLimit string
-	flagWorkerFilter           string
-	flagEgressWorkerFilter     string
-	flagIngressWorkerFilter    string
-	flagAddress                string
+	flagDefaultPort                 string
+	flagSessionMaxSeconds           string
+	flagSessionConnectionLimit      string
+	flagSessionMaxBytes             string
+	flagConnectionMaxBytesPerSecond string
+	flagConnectionIdleTimeout       string
+	flagEndpointTlsEnabled          string
+	flagEndpointTlsCaCert           string
+	flagEndpointTlsServerName       string
+	flagWorkerFilter                string
+	flagEgressWorkerFilter          string
+	flagIngressWorkerFilter         string
+	flagAddress                     string
 }
 
 func (c *TcpCommand) extraTcpHelpFunc(helpMap map[string]func() string) string {
@@ -93,6 +101,42 @@ func extraTcpFlagsFuncImpl(c *TcpCommand, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagSessionConnectionLimit,
 				Usage:  "The maximum number of connections allowed for a session. -1 means unlimited.",
 			})
+		case "session-max-bytes":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-max-bytes",
+				Target: &c.flagSessionMaxBytes,
+				Usage:  "The maximum number of bytes that may be proxied across all connections of a session. 0 means unlimited.",
+			})
+		case "connection-max-bytes-per-second":
+			fs.StringVar(&base.StringVar{
+				Name:   "connection-max-bytes-per-second",
+				Target: &c.flagConnectionMaxBytesPerSecond,
+				Usage:  "The maximum throughput of each connection of a session, in bytes per second. 0 means unlimited.",
+			})
+		case "connection-idle-timeout":
+			fs.StringVar(&base.StringVar{
+				Name:   "connection-idle-timeout",
+				Target: &c.flagConnectionIdleTimeout,
+				Usage:  `The maximum time a connection may go without proxying data in either direction before it is closed. Can be specified as an integer number of seconds or a duration string. 0 means no timeout.`,
+			})
+		case "endpoint-tls-enabled":
+			fs.StringVar(&base.StringVar{
+				Name:   "endpoint-tls-enabled",
+				Target: &c.flagEndpointTlsEnabled,
+				Usage:  "If true, the worker establishes TLS with the endpoint and presents any injected application TLS certificate credential to it, so clients connect in plaintext.",
+			})
+		case "endpoint-tls-ca-cert":
+			fs.StringVar(&base.StringVar{
+				Name:   "endpoint-tls-ca-cert",
+				Target: &c.flagEndpointTlsCaCert,
+				Usage:  "The PEM encoded CA certificate used by the worker to verify the endpoint's certificate. If not set, the worker's system roots are used. This can refer to a file on disk (file://) from which the value will be read or an env var (env://) from which the value will be read.",
+			})
+		case "endpoint-tls-server-name":
+			fs.StringVar(&base.StringVar{
+				Name:   "endpoint-tls-server-name",
+				Target: &c.flagEndpointTlsServerName,
+				Usage:  "The server name used by the worker to verify the endpoint's certificate. If not set, the endpoint's host is used.",
+			})
 		case "worker-filter":
 			fs.StringVar(&base.StringVar{
 				Name:   "worker-filter",
@@ -162,6 +206,86 @@ func extraTcpFlagsHandlingFuncImpl(c *TcpCommand, _ *base.FlagSets, opts *[]targ
 		*opts = append(*opts, targets.WithSessionConnectionLimit(int32(limit)))
 	}
 
+	switch c.flagSessionMaxBytes {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionMaxBytes())
+	default:
+		limit, err := strconv.ParseInt(c.flagSessionMaxBytes, 10, 64)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionMaxBytes, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithSessionMaxBytes(limit))
+	}
+
+	switch c.flagConnectionMaxBytesPerSecond {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultConnectionMaxBytesPerSecond())
+	default:
+		limit, err := strconv.ParseInt(c.flagConnectionMaxBytesPerSecond, 10, 64)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagConnectionMaxBytesPerSecond, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithConnectionMaxBytesPerSecond(limit))
+	}
+
+	switch c.flagConnectionIdleTimeout {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultConnectionIdleTimeoutSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagConnectionIdleTimeout, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagConnectionIdleTimeout)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagConnectionIdleTimeout, err))
+				return false
+			}
+			final = uint32(dur.Seconds())
+		}
+		*opts = append(*opts, targets.WithConnectionIdleTimeoutSeconds(final))
+	}
+
+	switch c.flagEndpointTlsEnabled {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultEndpointTlsEnabled())
+	default:
+		enabled, err := strconv.ParseBool(c.flagEndpointTlsEnabled)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagEndpointTlsEnabled, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithEndpointTlsEnabled(enabled))
+	}
+
+	switch c.flagEndpointTlsCaCert {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultEndpointTlsCaCert())
+	default:
+		caCert, err := parseutil.ParsePath(c.flagEndpointTlsCaCert)
+		if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+			c.UI.Error(fmt.Sprintf("Error parsing endpoint tls ca cert flag: %v", err))
+			return false
+		}
+		*opts = append(*opts, targets.WithEndpointTlsCaCert(caCert))
+	}
+
+	switch c.flagEndpointTlsServerName {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultEndpointTlsServerName())
+	default:
+		*opts = append(*opts, targets.WithEndpointTlsServerName(c.flagEndpointTlsServerName))
+	}
+
 	switch c.flagWorkerFilter {
 	case "":
 	case "null":
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package userscmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/users"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ListEffectiveGrantsCommand)(nil)
+	_ cli.CommandAutocomplete = (*ListEffectiveGrantsCommand)(nil)
+)
+
+type ListEffectiveGrantsCommand struct {
+	*base.Command
+
+	flagAt string
+}
+
+func (c *ListEffectiveGrantsCommand) Synopsis() string {
+	return wordwrap.WrapString("List the grants that apply to a user", base.TermWidth)
+}
+
+func (c *ListEffectiveGrantsCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary users list-effective-grants [options] [args]",
+		"",
+		"  List the grants that apply to a user through the roles assigned to it directly, to its groups, and to all users. Example:",
+		"",
+		`    $ boundary users list-effective-grants -id u_1234567890`,
+		"",
+		"  To list the grants that applied to the user at a point in the past, reconstructed from the change history, pass -at:",
+		"",
+		`    $ boundary users list-effective-grants -id u_1234567890 -at 2024-01-02T15:04:05Z`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ListEffectiveGrantsCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "id",
+		Target: &c.FlagId,
+		Usage:  "The id of the user to list the grants of",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "at",
+		Target: &c.flagAt,
+		Usage:  "An RFC 3339 timestamp at which to evaluate the user's grants. If not specified, the current grants are listed.",
+	})
+
+	return set
+}
+
+func (c *ListEffectiveGrantsCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *ListEffectiveGrantsCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ListEffectiveGrantsCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	if c.FlagId == "" {
+		c.PrintCliError(errors.New("ID must be provided via -id"))
+		return base.CommandUserError
+	}
+
+	var at time.Time
+	if c.flagAt != "" {
+		var err error
+		at, err = time.Parse(time.RFC3339, c.flagAt)
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("Error parsing -at as an RFC 3339 timestamp: %w", err))
+			return base.CommandUserError
+		}
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	result, err := users.NewClient(client).ListEffectiveGrants(c.Context, c.FlagId, at)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when performing list-effective-grants on user")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to list user effective grants: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(printEffectiveGrantsTable(result.GetItems()))
+	case "json":
+		if ok := c.PrintJsonItems(result.GetResponse()); !ok {
+			return base.CommandCliError
+		}
+	}
+
+	return base.CommandSuccess
+}
+
+func printEffectiveGrantsTable(items []*users.EffectiveGrant) string {
+	if len(items) == 0 {
+		return "No grants apply to the user."
+	}
+	output := []string{
+		"",
+		"Effective Grants:",
+	}
+	for i, g := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  Role ID:                %s", g.RoleId),
+			fmt.Sprintf("  Role Scope ID:          %s", g.RoleScopeId),
+			fmt.Sprintf("  Grant Scope ID:         %s", g.GrantScopeId),
+			fmt.Sprintf("  Grant:                  %s", g.Grant),
+		)
+	}
+	return base.WrapForHelpText(output)
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package userscmd
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/roles"
+	"github.com/hashicorp/boundary/api/users"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+)
+
+// NewListHistoryCommand returns the command listing the change history of a
+// user.
+func NewListHistoryCommand(c *base.Command) *common.ListHistoryCommand {
+	return &common.ListHistoryCommand{
+		Command:      c,
+		ResourceType: "user",
+		Changes:      "a user, its accounts, its group memberships, and its assignment to roles",
+		ExampleId:    "u_1234567890",
+		ListFn: func(ctx context.Context, client *api.Client, id string) ([]*roles.HistoryEntry, *api.Response, error) {
+			result, err := users.NewClient(client).ListHistory(ctx, id)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result.GetItems(), result.GetResponse(), nil
+		},
+	}
+}
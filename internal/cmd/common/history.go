@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/roles"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ListHistoryCommand)(nil)
+	_ cli.CommandAutocomplete = (*ListHistoryCommand)(nil)
+)
+
+// ListHistoryCommand lists the change history of a role, group or user.
+type ListHistoryCommand struct {
+	*base.Command
+
+	// ResourceType is the type of the resource, e.g. "role".
+	ResourceType string
+	// Changes describes the changes listed, e.g. "a role and its grants".
+	Changes string
+	// ExampleId is an id of the resource to use in the help text.
+	ExampleId string
+	// ListFn lists the history of the resource with the id.
+	ListFn func(ctx context.Context, client *api.Client, id string) ([]*roles.HistoryEntry, *api.Response, error)
+}
+
+func (c *ListHistoryCommand) Synopsis() string {
+	return wordwrap.WrapString(fmt.Sprintf("List the change history of a %s", c.ResourceType), base.TermWidth)
+}
+
+func (c *ListHistoryCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		fmt.Sprintf("Usage: boundary %ss list-history [options] [args]", c.ResourceType),
+		"",
+		fmt.Sprintf("  List the changes made to %s, oldest first, along with the user who made each change where known. Example:", c.Changes),
+		"",
+		fmt.Sprintf(`    $ boundary %ss list-history -id %s`, c.ResourceType, c.ExampleId),
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ListHistoryCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "id",
+		Target: &c.FlagId,
+		Usage:  fmt.Sprintf("The id of the %s to list the history of", c.ResourceType),
+	})
+
+	return set
+}
+
+func (c *ListHistoryCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *ListHistoryCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ListHistoryCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	if c.FlagId == "" {
+		c.PrintCliError(errors.New("ID must be provided via -id"))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	items, resp, err := c.ListFn(c.Context, client, c.FlagId)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, fmt.Sprintf("Error from controller when performing list-history on %s", c.ResourceType))
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to list %s history: %w", c.ResourceType, err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(base.HistoryForOutput(items))
+	case "json":
+		if ok := c.PrintJsonItems(resp); !ok {
+			return base.CommandCliError
+		}
+	}
+
+	return base.CommandSuccess
+}
@@ -282,6 +282,20 @@ type Database struct {
 	ConnMaxIdleTime         any            `hcl:"max_idle_time"`
 	ConnMaxIdleTimeDuration *time.Duration `hcl:"-"`
 
+	// ReplicaUrls are the URLs of read replicas of the database. Reads that
+	// tolerate slightly out of date data, such as listing resources, are
+	// spread across the replicas whose replication lag is within
+	// MaxReplicaLag, and go to the primary database when none are.
+	ReplicaUrls           []string      `hcl:"replica_urls"`
+	MaxReplicaLag         any           `hcl:"max_replica_lag"`
+	MaxReplicaLagDuration time.Duration `hcl:"-"`
+
+	// SlowQueryThreshold is how long a database operation can take before a
+	// slow query event is emitted for it. Slow query events are not emitted
+	// when it is zero.
+	SlowQueryThreshold         any           `hcl:"slow_query_threshold"`
+	SlowQueryThresholdDuration time.Duration `hcl:"-"`
+
 	// SkipSharedLockAcquisition allows skipping grabbing the database shared
 	// lock. This is dangerous unless you know what you're doing, and you should
 	// not set it unless you are the reason it's here in the first place, as not
@@ -302,6 +316,19 @@ type Scheduler struct {
 	//
 	MonitorInterval         any `hcl:"monitor_interval"`
 	MonitorIntervalDuration time.Duration
+
+	// JobRunHistoryLimit is the number of ended runs of each job kept by the
+	// job run cleaner.
+	JobRunHistoryLimit int `hcl:"job_run_history_limit"`
+
+	// JobFailureThreshold is the number of consecutive failed or interrupted
+	// runs of a job after which the scheduler reports the job as failing.
+	JobFailureThreshold int `hcl:"job_failure_threshold"`
+
+	// JobOverdueThreshold is the time after which the scheduler reports a job
+	// that has been due to run without completing as overdue.
+	JobOverdueThreshold         any `hcl:"job_overdue_threshold"`
+	JobOverdueThresholdDuration time.Duration
 }
 
 type Plugins struct {
@@ -546,6 +573,23 @@ func Parse(d string) (*Config, error) {
 				}
 				result.Controller.Scheduler.MonitorIntervalDuration = t
 			}
+
+			if result.Controller.Scheduler.JobOverdueThreshold != "" {
+				t, err := parseutil.ParseDurationSecond(result.Controller.Scheduler.JobOverdueThreshold)
+				if err != nil {
+					return result, err
+				}
+				result.Controller.Scheduler.JobOverdueThresholdDuration = t
+			}
+			if result.Controller.Scheduler.JobOverdueThresholdDuration < 0 {
+				return nil, errors.New("Scheduler job overdue threshold value is negative")
+			}
+			if result.Controller.Scheduler.JobRunHistoryLimit < 0 {
+				return nil, errors.New("Scheduler job run history limit value is negative")
+			}
+			if result.Controller.Scheduler.JobFailureThreshold < 0 {
+				return nil, errors.New("Scheduler job failure threshold value is negative")
+			}
 		}
 
 		workerStatusGracePeriod := result.Controller.WorkerStatusGracePeriod
@@ -633,6 +677,46 @@ func Parse(d string) (*Config, error) {
 						reflect.TypeOf(t).String())
 				}
 			}
+			if result.Controller.Database.MaxReplicaLag != nil {
+				switch t := result.Controller.Database.MaxReplicaLag.(type) {
+				case string:
+					durationString, err := parseutil.ParsePath(t)
+					if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+						return nil, fmt.Errorf("Error parsing database max replica lag: %w", err)
+					}
+					maxReplicaLag, err := parseutil.ParseDurationSecond(durationString)
+					if err != nil {
+						return nil, fmt.Errorf("Database max replica lag is not a duration: %w", err)
+					}
+					if maxReplicaLag <= 0 {
+						return nil, fmt.Errorf("Database max replica lag must be greater than zero")
+					}
+					result.Controller.Database.MaxReplicaLagDuration = maxReplicaLag
+				default:
+					return nil, fmt.Errorf("Database max replica lag: unsupported type %q",
+						reflect.TypeOf(t).String())
+				}
+			}
+			if result.Controller.Database.SlowQueryThreshold != nil {
+				switch t := result.Controller.Database.SlowQueryThreshold.(type) {
+				case string:
+					durationString, err := parseutil.ParsePath(t)
+					if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+						return nil, fmt.Errorf("Error parsing database slow query threshold: %w", err)
+					}
+					slowQueryThreshold, err := parseutil.ParseDurationSecond(durationString)
+					if err != nil {
+						return nil, fmt.Errorf("Database slow query threshold is not a duration: %w", err)
+					}
+					if slowQueryThreshold < 0 {
+						return nil, fmt.Errorf("Database slow query threshold must not be negative")
+					}
+					result.Controller.Database.SlowQueryThresholdDuration = slowQueryThreshold
+				default:
+					return nil, fmt.Errorf("Database slow query threshold: unsupported type %q",
+						reflect.TypeOf(t).String())
+				}
+			}
 
 		}
 	}
@@ -617,6 +617,9 @@ func TestParsingSchedulerIntervals(t *testing.T) {
 		wantErr             bool
 		wantMonitorInterval time.Duration
 		wantRunJobInterval  time.Duration
+		wantRunHistory      int
+		wantFailures        int
+		wantOverdue         time.Duration
 	}{
 		{
 			name: "invalid-run-interval",
@@ -683,6 +686,54 @@ controller {
 			wantMonitorInterval: 7 * 24 * time.Hour,
 			wantRunJobInterval:  20 * time.Second,
 		},
+		{
+			name: "invalid-overdue-threshold",
+			config: `
+controller {
+  scheduler {
+    job_overdue_threshold = "hello"
+  }
+}
+`,
+			wantErr: true,
+		},
+		{
+			name: "negative-run-history-limit",
+			config: `
+controller {
+  scheduler {
+    job_run_history_limit = -1
+  }
+}
+`,
+			wantErr: true,
+		},
+		{
+			name: "negative-failure-threshold",
+			config: `
+controller {
+  scheduler {
+    job_failure_threshold = -1
+  }
+}
+`,
+			wantErr: true,
+		},
+		{
+			name: "run-history-and-alerting",
+			config: `
+controller {
+  scheduler {
+    job_run_history_limit = 20
+    job_failure_threshold = 5
+    job_overdue_threshold = "2h"
+  }
+}
+`,
+			wantRunHistory: 20,
+			wantFailures:   5,
+			wantOverdue:    2 * time.Hour,
+		},
 	}
 	for _, tt := range cases {
 		tt := tt
@@ -695,6 +746,9 @@ controller {
 			require.NoError(t, err)
 			assert.Equal(t, tt.wantMonitorInterval, out.Controller.Scheduler.MonitorIntervalDuration)
 			assert.Equal(t, tt.wantRunJobInterval, out.Controller.Scheduler.JobRunIntervalDuration)
+			assert.Equal(t, tt.wantRunHistory, out.Controller.Scheduler.JobRunHistoryLimit)
+			assert.Equal(t, tt.wantFailures, out.Controller.Scheduler.JobFailureThreshold)
+			assert.Equal(t, tt.wantOverdue, out.Controller.Scheduler.JobOverdueThresholdDuration)
 		})
 	}
 }
@@ -1847,6 +1901,170 @@ func TestDatabaseConnMaxIdleTimeDuration(t *testing.T) {
 	}
 }
 
+func TestDatabaseReplicas(t *testing.T) {
+	tests := []struct {
+		name             string
+		in               string
+		envMaxReplicaLag string
+		expReplicaUrls   []string
+		expMaxReplicaLag time.Duration
+		expErr           bool
+		expErrStr        string
+	}{
+		{
+			name: "not set",
+			in: `
+			controller {
+				name = "example-controller"
+				database {
+				}
+			}`,
+		},
+		{
+			name: "replicas with max lag",
+			in: `
+			controller {
+				name = "example-controller"
+				database {
+					replica_urls    = ["postgres://replica1", "env://ENV_REPLICA_URL"]
+					max_replica_lag = "10s"
+				}
+			}`,
+			expReplicaUrls:   []string{"postgres://replica1", "env://ENV_REPLICA_URL"},
+			expMaxReplicaLag: 10 * time.Second,
+		},
+		{
+			name:             "max lag from env var",
+			envMaxReplicaLag: "2s",
+			in: `
+			controller {
+				name = "example-controller"
+				database {
+					replica_urls    = ["postgres://replica1"]
+					max_replica_lag = "env://ENV_MAX_REPLICA_LAG"
+				}
+			}`,
+			expReplicaUrls:   []string{"postgres://replica1"},
+			expMaxReplicaLag: 2 * time.Second,
+		},
+		{
+			name: "invalid max lag",
+			in: `
+			controller {
+				name = "example-controller"
+				database {
+					max_replica_lag = "soon"
+				}
+			}`,
+			expErr: true,
+			expErrStr: "Database max replica lag is not a duration: " +
+				"time: invalid duration \"soon\"",
+		},
+		{
+			name: "zero max lag",
+			in: `
+			controller {
+				name = "example-controller"
+				database {
+					max_replica_lag = "0s"
+				}
+			}`,
+			expErr:    true,
+			expErrStr: "Database max replica lag must be greater than zero",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ENV_MAX_REPLICA_LAG", tt.envMaxReplicaLag)
+			c, err := Parse(tt.in)
+			if tt.expErr {
+				require.EqualError(t, err, tt.expErrStr)
+				require.Nil(t, c)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, c)
+			require.NotNil(t, c.Controller)
+			require.NotNil(t, c.Controller.Database)
+			require.Equal(t, tt.expReplicaUrls, c.Controller.Database.ReplicaUrls)
+			require.Equal(t, tt.expMaxReplicaLag, c.Controller.Database.MaxReplicaLagDuration)
+		})
+	}
+}
+
+func TestDatabaseSlowQueryThreshold(t *testing.T) {
+	tests := []struct {
+		name                  string
+		in                    string
+		expSlowQueryThreshold time.Duration
+		expErr                bool
+		expErrStr             string
+	}{
+		{
+			name: "not set",
+			in: `
+			controller {
+				name = "example-controller"
+				database {
+				}
+			}`,
+		},
+		{
+			name: "set",
+			in: `
+			controller {
+				name = "example-controller"
+				database {
+					slow_query_threshold = "500ms"
+				}
+			}`,
+			expSlowQueryThreshold: 500 * time.Millisecond,
+		},
+		{
+			name: "invalid",
+			in: `
+			controller {
+				name = "example-controller"
+				database {
+					slow_query_threshold = "slow"
+				}
+			}`,
+			expErr: true,
+			expErrStr: "Database slow query threshold is not a duration: " +
+				"time: invalid duration \"slow\"",
+		},
+		{
+			name: "negative",
+			in: `
+			controller {
+				name = "example-controller"
+				database {
+					slow_query_threshold = "-1s"
+				}
+			}`,
+			expErr:    true,
+			expErrStr: "Database slow query threshold must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Parse(tt.in)
+			if tt.expErr {
+				require.EqualError(t, err, tt.expErrStr)
+				require.Nil(t, c)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, c)
+			require.NotNil(t, c.Controller)
+			require.NotNil(t, c.Controller.Database)
+			require.Equal(t, tt.expSlowQueryThreshold, c.Controller.Database.SlowQueryThresholdDuration)
+		})
+	}
+}
+
 func TestDatabaseSkipSharedLockAcquisition(t *testing.T) {
 	tests := []struct {
 		name                         string
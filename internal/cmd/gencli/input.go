@@ -364,6 +364,22 @@ var inputStructs = map[string][]*cmdInfo{
 			PrefixAttributeFieldErrorsWithSubactionPrefix: true,
 			HasJsonObject: true,
 		},
+		{
+			ResourceType:         resource.Credential.String(),
+			Pkg:                  "credentials",
+			StdActions:           []string{"create", "update"},
+			SubActionPrefix:      "tls_certificate",
+			HasExtraCommandVars:  true,
+			SkipNormalHelp:       true,
+			HasExtraHelpFunc:     true,
+			HasId:                true,
+			HasName:              true,
+			HasDescription:       true,
+			Container:            "CredentialStore",
+			VersionedActions:     []string{"update"},
+			NeedsSubtypeInCreate: true,
+			PrefixAttributeFieldErrorsWithSubactionPrefix: true,
+		},
 	},
 	"groups": {
 		{
@@ -488,6 +504,17 @@ var inputStructs = map[string][]*cmdInfo{
 			VersionedActions:    []string{"update"},
 		},
 	},
+	"jobs": {
+		{
+			ResourceType:     resource.Job.String(),
+			Pkg:              "jobs",
+			StdActions:       []string{"read", "list"},
+			Container:        "Scope",
+			HasExtraHelpFunc: true,
+			SkipNormalHelp:   true,
+			HasId:            true,
+		},
+	},
 	"managedgroups": {
 		{
 			ResourceType:   resource.ManagedGroup.String(),
@@ -538,7 +565,7 @@ var inputStructs = map[string][]*cmdInfo{
 			Container:           "Scope",
 			HasName:             true,
 			HasDescription:      true,
-			VersionedActions:    []string{"update", "add-grants", "remove-grants", "set-grants", "add-principals", "remove-principals", "set-principals"},
+			VersionedActions:    []string{"update", "add-grants", "remove-grants", "set-grants", "add-principals", "remove-principals", "set-principals", "add-grant-scopes", "remove-grant-scopes", "set-grant-scopes"},
 		},
 	},
 	"scopes": {
@@ -554,6 +581,20 @@ var inputStructs = map[string][]*cmdInfo{
 			VersionedActions:    []string{"update"},
 		},
 	},
+	"serviceaccounts": {
+		{
+			ResourceType:        resource.ServiceAccount.String(),
+			Pkg:                 "serviceaccounts",
+			StdActions:          []string{"create", "read", "update", "delete", "list"},
+			HasExtraCommandVars: true,
+			HasExtraHelpFunc:    true,
+			HasId:               true,
+			Container:           "Scope",
+			HasName:             true,
+			HasDescription:      true,
+			VersionedActions:    []string{"update", "add-api-key", "remove-api-key"},
+		},
+	},
 	"sessions": {
 		{
 			ResourceType:        resource.Session.String(),
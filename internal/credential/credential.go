@@ -35,6 +35,7 @@ const (
 	SshPrivateKeyType    Type = "ssh_private_key"
 	SshCertificateType   Type = "ssh_certificate"
 	JsonType             Type = "json"
+	TlsCertificateType   Type = "tls_certificate"
 )
 
 // A Library is a resource that provides credentials that are of the same
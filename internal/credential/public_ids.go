@@ -22,6 +22,9 @@ func init() {
 	if err := subtypes.Register(Domain, JsonSubtype, globals.JsonCredentialPrefix); err != nil {
 		panic(err)
 	}
+	if err := subtypes.Register(Domain, TlsCertificateSubtype, globals.TlsCertificateCredentialPrefix); err != nil {
+		panic(err)
+	}
 }
 
 const (
@@ -30,6 +33,8 @@ const (
 	SshPrivateKeySubtype = subtypes.Subtype("ssh_private_key")
 
 	JsonSubtype = subtypes.Subtype("json")
+
+	TlsCertificateSubtype = subtypes.Subtype("tls_certificate")
 )
 
 func NewUsernamePasswordCredentialId(ctx context.Context) (string, error) {
@@ -55,3 +60,11 @@ func NewJsonCredentialId(ctx context.Context) (string, error) {
 	}
 	return id, nil
 }
+
+func NewTlsCertificateCredentialId(ctx context.Context) (string, error) {
+	id, err := db.NewPublicId(globals.TlsCertificateCredentialPrefix)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "credential.NewTlsCertificateCredentialId")
+	}
+	return id, nil
+}
@@ -12,4 +12,5 @@ const (
 	privateKeyField           = "PrivateKey"
 	PrivateKeyPassphraseField = "PrivateKeyPassphrase"
 	objectField               = "Object"
+	certificateField          = "Certificate"
 )
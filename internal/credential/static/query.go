@@ -40,4 +40,16 @@ from credential_static_json_credential json
 where store.project_id = ?
   and json.key_id = ?;
 `
+
+	credStaticTlsCertificateRewrapQuery = `
+select distinct
+  tls.public_id,
+  tls.private_key_encrypted,
+  tls.key_id
+from credential_static_tls_certificate_credential tls
+  inner join credential_static_store store
+    on store.public_id = tls.store_id
+where store.project_id = ?
+  and tls.key_id = ?;
+`
 )
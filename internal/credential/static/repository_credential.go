@@ -35,6 +35,7 @@ func (r *Repository) CreateUsernamePasswordCredential(
 	_ ...Option,
 ) (*UsernamePasswordCredential, error) {
 	const op = "static.(Repository).CreateUsernamePasswordCredential"
+	ctx = db.NewOpContext(ctx, op)
 	if c == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing credential")
 	}
@@ -123,6 +124,7 @@ func (r *Repository) CreateSshPrivateKeyCredential(
 	_ ...Option,
 ) (*SshPrivateKeyCredential, error) {
 	const op = "static.(Repository).CreateSshPrivateKeyCredential"
+	ctx = db.NewOpContext(ctx, op)
 	if c == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing credential")
 	}
@@ -213,6 +215,7 @@ func (r *Repository) CreateJsonCredential(
 	_ ...Option,
 ) (*JsonCredential, error) {
 	const op = "static.(Repository).CreateJsonCredential"
+	ctx = db.NewOpContext(ctx, op)
 	if c == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing credential")
 	}
@@ -278,11 +281,99 @@ func (r *Repository) CreateJsonCredential(
 	return newCred, nil
 }
 
+// CreateTlsCertificateCredential inserts c into the repository and returns a
+// new TlsCertificateCredential containing the credential's PublicId. c is not
+// changed. c must not contain a PublicId. The PublicId is generated and
+// assigned by this method. c must contain a valid StoreId.
+//
+// The private key is encrypted and a HmacSha256 of the private key is
+// calculated. Only the PrivateKeyHmac is returned, the plain-text and encrypted
+// private key is not returned.
+//
+// Both c.Name and c.Description are optional. If c.Name is set, it must be
+// unique within c.ProjectId. Both c.CreateTime and c.UpdateTime are ignored.
+func (r *Repository) CreateTlsCertificateCredential(
+	ctx context.Context,
+	projectId string,
+	c *TlsCertificateCredential,
+	_ ...Option,
+) (*TlsCertificateCredential, error) {
+	const op = "static.(Repository).CreateTlsCertificateCredential"
+	ctx = db.NewOpContext(ctx, op)
+	if c == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing credential")
+	}
+	if c.TlsCertificateCredential == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing embedded credential")
+	}
+	if projectId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	}
+	if len(c.Certificate) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing certificate")
+	}
+	if len(c.PrivateKey) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing private key")
+	}
+	if c.StoreId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing store id")
+	}
+	if c.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+
+	c = c.clone()
+	id, err := credential.NewTlsCertificateCredentialId(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	c.PublicId = id
+	oplogWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	// encrypt
+	databaseWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := c.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	var newCred *TlsCertificateCredential
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newCred = c.clone()
+			if err := w.Create(ctx, newCred,
+				db.WithOplog(oplogWrapper, newCred.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in store: %s: name %s already exists", c.StoreId, c.Name)))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in store: %s", c.StoreId)))
+	}
+
+	// Clear private key fields, only PrivateKeyHmac should be returned
+	newCred.PrivateKeyEncrypted = nil
+	newCred.PrivateKey = nil
+
+	return newCred, nil
+}
+
 // LookupCredential returns the Credential for the publicId. Returns
 // nil, nil if no Credential is found for the publicId.
 // TODO: This should hit a view and return the interface type...
 func (r *Repository) LookupCredential(ctx context.Context, publicId string, _ ...Option) (credential.Static, error) {
 	const op = "static.(Repository).LookupCredential"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
@@ -334,6 +425,20 @@ func (r *Repository) LookupCredential(ctx context.Context, publicId string, _ ..
 		jsonCred.ObjectEncrypted = nil
 		jsonCred.Object = nil
 		cred = jsonCred
+
+	case credential.TlsCertificateSubtype:
+		tlsCred := allocTlsCertificateCredential()
+		tlsCred.PublicId = publicId
+		if err := r.reader.LookupByPublicId(ctx, tlsCred); err != nil {
+			if errors.IsNotFoundError(err) {
+				return nil, nil
+			}
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", publicId)))
+		}
+		// Clear private key fields, only PrivateKeyHmac should be returned
+		tlsCred.PrivateKeyEncrypted = nil
+		tlsCred.PrivateKey = nil
+		cred = tlsCred
 	}
 
 	return cred, nil
@@ -357,6 +462,7 @@ func (r *Repository) UpdateUsernamePasswordCredential(ctx context.Context,
 	_ ...Option,
 ) (*UsernamePasswordCredential, int, error) {
 	const op = "static.(Repository).UpdateUsernamePasswordCredential"
+	ctx = db.NewOpContext(ctx, op)
 	if c == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing credential")
 	}
@@ -472,6 +578,7 @@ func (r *Repository) UpdateSshPrivateKeyCredential(ctx context.Context,
 	_ ...Option,
 ) (*SshPrivateKeyCredential, int, error) {
 	const op = "static.(Repository).UpdateSshPrivateKeyCredential"
+	ctx = db.NewOpContext(ctx, op)
 	if c == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing credential")
 	}
@@ -605,6 +712,7 @@ func (r *Repository) UpdateJsonCredential(ctx context.Context,
 	_ ...Option,
 ) (*JsonCredential, int, error) {
 	const op = "static.(Repository).UpdateJsonCredential"
+	ctx = db.NewOpContext(ctx, op)
 	if c == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing credential")
 	}
@@ -715,11 +823,127 @@ func (r *Repository) UpdateJsonCredential(ctx context.Context,
 	return returnedCredential, rowsUpdated, nil
 }
 
-// ListCredentials returns a slice of UsernamePasswordCredentials, SshPrivateKeyCredentials, and JsonCredentials
-// for the storeId. WithLimit is the only option supported.
+// UpdateTlsCertificateCredential updates the repository entry for c.PublicId
+// with the values in c for the fields listed in fieldMaskPaths. It returns a
+// new TlsCertificateCredential containing the updated values and a count of
+// the number of records updated. c is not changed.
+//
+// c must contain a valid PublicId. Only Name, Description, Certificate and
+// PrivateKey can be changed. If c.Name is set to a non-empty string, it must
+// be unique within c.ProjectId.
+//
+// An attribute of c will be set to NULL in the database if the attribute in c
+// is the zero value and it is included in fieldMaskPaths.
+func (r *Repository) UpdateTlsCertificateCredential(ctx context.Context,
+	projectId string,
+	c *TlsCertificateCredential,
+	version uint32,
+	fieldMaskPaths []string,
+	_ ...Option,
+) (*TlsCertificateCredential, int, error) {
+	const op = "static.(Repository).UpdateTlsCertificateCredential"
+	ctx = db.NewOpContext(ctx, op)
+	if c == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing credential")
+	}
+	if c.TlsCertificateCredential == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing embedded credential")
+	}
+	if c.PublicId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+	if projectId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	}
+	if c.StoreId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing store id")
+	}
+	c = c.clone()
+
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold(nameField, f):
+		case strings.EqualFold(descriptionField, f):
+		case strings.EqualFold(certificateField, f):
+		case strings.EqualFold(privateKeyField, f):
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
+		}
+	}
+	dbMask, nullFields := dbw.BuildUpdatePaths(
+		map[string]any{
+			nameField:        c.Name,
+			descriptionField: c.Description,
+			certificateField: c.Certificate,
+			privateKeyField:  c.PrivateKey,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "missing field mask")
+	}
+
+	for _, f := range fieldMaskPaths {
+		if strings.EqualFold(privateKeyField, f) {
+			databaseWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeDatabase)
+			if err != nil {
+				return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+			}
+			if err := c.encrypt(ctx, databaseWrapper); err != nil {
+				return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+			}
+
+			// Set PrivateKeyHmac and PrivateKeyEncrypted masks for update.
+			dbMask = append(dbMask, "PrivateKeyHmac", "PrivateKeyEncrypted", "KeyId")
+		}
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected,
+			errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsUpdated int
+	var returnedCredential *TlsCertificateCredential
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedCredential = c.clone()
+			var err error
+			rowsUpdated, err = w.Update(ctx, returnedCredential,
+				dbMask, nullFields,
+				db.WithOplog(oplogWrapper, returnedCredential.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, db.NoRowsAffected, err
+	}
+
+	// Clear private key fields, only PrivateKeyHmac should be returned
+	returnedCredential.PrivateKeyEncrypted = nil
+	returnedCredential.PrivateKey = nil
+
+	return returnedCredential, rowsUpdated, nil
+}
+
+// ListCredentials returns a slice of UsernamePasswordCredentials, SshPrivateKeyCredentials, JsonCredentials
+// and TlsCertificateCredentials for the storeId. WithLimit is the only option supported.
 // TODO: This should hit a view and return the interface type...
 func (r *Repository) ListCredentials(ctx context.Context, storeId string, opt ...Option) ([]credential.Static, error) {
 	const op = "static.(Repository).ListCredentials"
+	ctx = db.NewOpContext(ctx, op)
 	if storeId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no storeId")
 	}
@@ -748,7 +972,13 @@ func (r *Repository) ListCredentials(ctx context.Context, storeId string, opt ..
 		return nil, errors.Wrap(ctx, err, op)
 	}
 
-	ret := make([]credential.Static, 0, len(upCreds)+len(spkCreds)+len(jsonCreds))
+	var tlsCreds []*TlsCertificateCredential
+	err = r.reader.SearchWhere(ctx, &tlsCreds, "store_id = ?", []any{storeId}, db.WithLimit(limit))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	ret := make([]credential.Static, 0, len(upCreds)+len(spkCreds)+len(jsonCreds)+len(tlsCreds))
 
 	for _, c := range upCreds {
 		// Clear password fields, only PasswordHmac should be returned
@@ -775,6 +1005,13 @@ func (r *Repository) ListCredentials(ctx context.Context, storeId string, opt ..
 		ret = append(ret, c)
 	}
 
+	for _, c := range tlsCreds {
+		// Clear private key fields, only PrivateKeyHmac should be returned
+		c.PrivateKeyEncrypted = nil
+		c.PrivateKey = nil
+		ret = append(ret, c)
+	}
+
 	return ret, nil
 }
 
@@ -783,6 +1020,7 @@ func (r *Repository) ListCredentials(ctx context.Context, storeId string, opt ..
 // TODO: This should hit a view...
 func (r *Repository) DeleteCredential(ctx context.Context, projectId, id string, _ ...Option) (int, error) {
 	const op = "static.(Repository).DeleteCredential"
+	ctx = db.NewOpContext(ctx, op)
 	if id == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
@@ -808,6 +1046,11 @@ func (r *Repository) DeleteCredential(ctx context.Context, projectId, id string,
 		c.PublicId = id
 		input = c
 		md = c.oplog(oplog.OpType_OP_TYPE_DELETE)
+	case credential.TlsCertificateSubtype:
+		c := allocTlsCertificateCredential()
+		c.PublicId = id
+		input = c
+		md = c.oplog(oplog.OpType_OP_TYPE_DELETE)
 	default:
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "unknown type")
 	}
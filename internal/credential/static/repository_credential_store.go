@@ -10,8 +10,10 @@ import (
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/go-dbw"
 )
 
@@ -25,6 +27,7 @@ import (
 // ignored.
 func (r *Repository) CreateCredentialStore(ctx context.Context, cs *CredentialStore, _ ...Option) (*CredentialStore, error) {
 	const op = "static.(Repository).CreateCredentialStore"
+	ctx = db.NewOpContext(ctx, op)
 	if cs == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing CredentialStore")
 	}
@@ -52,7 +55,10 @@ func (r *Repository) CreateCredentialStore(ctx context.Context, cs *CredentialSt
 
 	var newCredentialStore *CredentialStore
 	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
-		func(_ db.Reader, w db.Writer) error {
+		func(reader db.Reader, w db.Writer) error {
+			if err := iam.CheckQuota(ctx, reader, cs.ProjectId, resource.CredentialStore); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 			newCredentialStore = cs.clone()
 			if err := w.Create(ctx, newCredentialStore,
 				db.WithOplog(oplogWrapper, newCredentialStore.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
@@ -76,6 +82,7 @@ func (r *Repository) CreateCredentialStore(ctx context.Context, cs *CredentialSt
 // nil, nil if no CredentialStore is found for publicId.
 func (r *Repository) LookupCredentialStore(ctx context.Context, publicId string, _ ...Option) (*CredentialStore, error) {
 	const op = "static.(Repository).LookupCredentialStore"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
@@ -102,6 +109,7 @@ func (r *Repository) LookupCredentialStore(ctx context.Context, publicId string,
 // in cs is the zero value and it is included in fieldMaskPaths.
 func (r *Repository) UpdateCredentialStore(ctx context.Context, cs *CredentialStore, version uint32, fieldMaskPaths []string, _ ...Option) (*CredentialStore, int, error) {
 	const op = "static.(Repository).UpdateCredentialStore"
+	ctx = db.NewOpContext(ctx, op)
 	if cs == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing CredentialStore")
 	}
@@ -175,6 +183,7 @@ func (r *Repository) UpdateCredentialStore(ctx context.Context, cs *CredentialSt
 // projectIds. WithLimit is the only option supported.
 func (r *Repository) ListCredentialStores(ctx context.Context, projectIds []string, opt ...Option) ([]*CredentialStore, error) {
 	const op = "static.(Repository).ListCredentialStores"
+	ctx = db.NewOpContext(ctx, op)
 	if len(projectIds) == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no projectIds")
 	}
@@ -185,7 +194,7 @@ func (r *Repository) ListCredentialStores(ctx context.Context, projectIds []stri
 		limit = opts.withLimit
 	}
 	var credentialStores []*CredentialStore
-	err := r.reader.SearchWhere(ctx, &credentialStores, "project_id in (?)", []any{projectIds}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &credentialStores, "project_id in (?)", []any{projectIds}, db.WithLimit(limit), db.WithReplicaRead(true))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -196,6 +205,7 @@ func (r *Repository) ListCredentialStores(ctx context.Context, projectIds []stri
 // the number of records deleted. All options are ignored.
 func (r *Repository) DeleteCredentialStore(ctx context.Context, publicId string, _ ...Option) (int, error) {
 	const op = "static.(Repository).DeleteCredentialStore"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
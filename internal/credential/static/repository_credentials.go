@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 )
@@ -16,6 +17,7 @@ import (
 // ids. All the returned static credentials will have their secret fields decrypted.
 func (r *Repository) Retrieve(ctx context.Context, projectId string, ids []string) ([]credential.Static, error) {
 	const op = "static.(Repository).Retrieve"
+	ctx = db.NewOpContext(ctx, op)
 	if len(ids) == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no ids")
 	}
@@ -35,10 +37,15 @@ func (r *Repository) Retrieve(ctx context.Context, projectId string, ids []strin
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
+	var tlsCreds []*TlsCertificateCredential
+	err = r.reader.SearchWhere(ctx, &tlsCreds, "public_id in (?)", []any{ids})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
 
-	if len(upCreds)+len(spkCreds)+len(jsonCreds) != len(ids) {
+	if len(upCreds)+len(spkCreds)+len(jsonCreds)+len(tlsCreds) != len(ids) {
 		return nil, errors.New(ctx, errors.NotSpecificIntegrity, op,
-			fmt.Sprintf("mismatch between creds and number of ids requested, expected %d got %d", len(ids), len(upCreds)+len(spkCreds)+len(jsonCreds)))
+			fmt.Sprintf("mismatch between creds and number of ids requested, expected %d got %d", len(ids), len(upCreds)+len(spkCreds)+len(jsonCreds)+len(tlsCreds)))
 	}
 
 	out := make([]credential.Static, 0, len(ids))
@@ -81,5 +88,18 @@ func (r *Repository) Retrieve(ctx context.Context, projectId string, ids []strin
 		out = append(out, c)
 	}
 
+	for _, c := range tlsCreds {
+		// decrypt credential
+		databaseWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := c.decrypt(ctx, databaseWrapper); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+
+		out = append(out, c)
+	}
+
 	return out, nil
 }
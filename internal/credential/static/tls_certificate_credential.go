@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package static
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/libs/crypto"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ credential.Static = (*TlsCertificateCredential)(nil)
+
+// A TlsCertificateCredential contains the credential with a PEM encoded TLS
+// client certificate and its private key. It is owned by a credential store.
+type TlsCertificateCredential struct {
+	*store.TlsCertificateCredential
+	tableName string `gorm:"-"`
+}
+
+// NewTlsCertificateCredential creates a new in memory static Credential
+// containing a PEM encoded client certificate chain and private key that is
+// assigned to storeId. If both the certificate and the private key are set,
+// they must form a valid key pair. Name and description are the only valid
+// options. All other options are ignored.
+func NewTlsCertificateCredential(
+	ctx context.Context,
+	storeId string,
+	certificate []byte,
+	privateKey credential.PrivateKey,
+	opt ...Option,
+) (*TlsCertificateCredential, error) {
+	const op = "static.NewTlsCertificateCredential"
+
+	switch {
+	case len(certificate) != 0 && len(privateKey) != 0:
+		if _, err := tls.X509KeyPair(certificate, privateKey); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter))
+		}
+	case len(certificate) != 0:
+		if err := parseCertificateChain(ctx, certificate); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+
+	opts := getOpts(opt...)
+	c := &TlsCertificateCredential{
+		TlsCertificateCredential: &store.TlsCertificateCredential{
+			StoreId:     storeId,
+			Name:        opts.withName,
+			Description: opts.withDescription,
+			Certificate: certificate,
+			PrivateKey:  privateKey,
+		},
+	}
+	return c, nil
+}
+
+// parseCertificateChain returns an error unless every PEM block in certs is
+// a parsable x509 certificate and there is at least one of them.
+func parseCertificateChain(ctx context.Context, certs []byte) error {
+	const op = "static.parseCertificateChain"
+	var found bool
+	for {
+		var block *pem.Block
+		block, certs = pem.Decode(certs)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unexpected pem block type %q", block.Type))
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter))
+		}
+		found = true
+	}
+	if !found {
+		return errors.New(ctx, errors.InvalidParameter, op, "no pem encoded certificate found")
+	}
+	return nil
+}
+
+func allocTlsCertificateCredential() *TlsCertificateCredential {
+	return &TlsCertificateCredential{
+		TlsCertificateCredential: &store.TlsCertificateCredential{},
+	}
+}
+
+func (c *TlsCertificateCredential) clone() *TlsCertificateCredential {
+	cp := proto.Clone(c.TlsCertificateCredential)
+	return &TlsCertificateCredential{
+		TlsCertificateCredential: cp.(*store.TlsCertificateCredential),
+	}
+}
+
+// TableName returns the table name.
+func (c *TlsCertificateCredential) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return "credential_static_tls_certificate_credential"
+}
+
+// SetTableName sets the table name.
+func (c *TlsCertificateCredential) SetTableName(n string) {
+	c.tableName = n
+}
+
+func (c *TlsCertificateCredential) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{c.PublicId},
+		"resource-type":      []string{"credential-static-tls-certificate"},
+		"op-type":            []string{op.String()},
+	}
+	if c.StoreId != "" {
+		metadata["store-id"] = []string{c.StoreId}
+	}
+	return metadata
+}
+
+func (c *TlsCertificateCredential) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(TlsCertificateCredential).encrypt"
+	if len(c.PrivateKey) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "no private key defined")
+	}
+	if err := structwrapping.WrapStruct(ctx, cipher, c.TlsCertificateCredential, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	keyId, err := cipher.KeyId(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("error reading cipher key id"))
+	}
+	c.KeyId = keyId
+	if err := c.hmacPrivateKey(ctx, cipher); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+func (c *TlsCertificateCredential) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(TlsCertificateCredential).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, c.TlsCertificateCredential, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+func (c *TlsCertificateCredential) hmacPrivateKey(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "static.(TlsCertificateCredential).hmacPrivateKey"
+	if cipher == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing cipher")
+	}
+	hm, err := crypto.HmacSha256(ctx, c.PrivateKey, cipher, []byte(c.StoreId), nil)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	c.PrivateKeyHmac = []byte(hm)
+	return nil
+}
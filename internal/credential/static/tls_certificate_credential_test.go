@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package static
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/libs/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestTlsCertificateCredential_New(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kkms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	cert, key := TestTlsCertificate(t)
+	_, otherKey := TestTlsCertificate(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStore(t, conn, wrapper, prj.PublicId)
+
+	type args struct {
+		certificate []byte
+		privateKey  credential.PrivateKey
+		storeId     string
+		options     []Option
+	}
+
+	tests := []struct {
+		name           string
+		args           args
+		want           *TlsCertificateCredential
+		wantCreateErr  bool
+		wantEncryptErr bool
+		wantAllocError bool
+	}{
+		{
+			name: "missing-private-key",
+			args: args{
+				certificate: cert,
+				storeId:     cs.PublicId,
+			},
+			want:           allocTlsCertificateCredential(),
+			wantEncryptErr: true,
+		},
+		{
+			name: "missing-certificate",
+			args: args{
+				privateKey: key,
+				storeId:    cs.PublicId,
+			},
+			want:          allocTlsCertificateCredential(),
+			wantCreateErr: true,
+		},
+		{
+			name: "missing-store-id",
+			args: args{
+				certificate: cert,
+				privateKey:  key,
+			},
+			want:          allocTlsCertificateCredential(),
+			wantCreateErr: true,
+		},
+		{
+			name: "bad-certificate",
+			args: args{
+				certificate: []byte("foobar"),
+				storeId:     cs.PublicId,
+			},
+			wantAllocError: true,
+		},
+		{
+			name: "mismatched-private-key",
+			args: args{
+				certificate: cert,
+				privateKey:  otherKey,
+				storeId:     cs.PublicId,
+			},
+			wantAllocError: true,
+		},
+		{
+			name: "valid-no-options",
+			args: args{
+				certificate: cert,
+				privateKey:  key,
+				storeId:     cs.PublicId,
+			},
+			want: &TlsCertificateCredential{
+				TlsCertificateCredential: &store.TlsCertificateCredential{
+					Certificate: cert,
+					PrivateKey:  key,
+					StoreId:     cs.PublicId,
+				},
+			},
+		},
+		{
+			name: "valid-with-name-and-description",
+			args: args{
+				certificate: cert,
+				privateKey:  key,
+				storeId:     cs.PublicId,
+				options:     []Option{WithName("my-credential"), WithDescription("my-credential-description")},
+			},
+			want: &TlsCertificateCredential{
+				TlsCertificateCredential: &store.TlsCertificateCredential{
+					Certificate: cert,
+					PrivateKey:  key,
+					StoreId:     cs.PublicId,
+					Name:        "my-credential",
+					Description: "my-credential-description",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			got, err := NewTlsCertificateCredential(ctx, tt.args.storeId, tt.args.certificate, tt.args.privateKey, tt.args.options...)
+			if tt.wantAllocError {
+				assert.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Emptyf(got.PublicId, "PublicId set")
+
+			id, err := credential.NewTlsCertificateCredentialId(ctx)
+			require.NoError(err)
+
+			tt.want.PublicId = id
+			got.PublicId = id
+
+			databaseWrapper, err := kkms.GetWrapper(context.Background(), prj.PublicId, kms.KeyPurposeDatabase)
+			require.NoError(err)
+
+			err = got.encrypt(ctx, databaseWrapper)
+			if tt.wantEncryptErr {
+				require.Error(err)
+				return
+			}
+			assert.NoError(err)
+
+			err = rw.Create(context.Background(), got)
+			if tt.wantCreateErr {
+				require.Error(err)
+				return
+			}
+			assert.NoError(err)
+
+			got2 := allocTlsCertificateCredential()
+			got2.PublicId = id
+			assert.Equal(id, got2.GetPublicId())
+			require.NoError(rw.LookupById(ctx, got2))
+
+			err = got2.decrypt(ctx, databaseWrapper)
+			require.NoError(err)
+
+			// Timestamps and version are automatically set
+			tt.want.CreateTime = got2.CreateTime
+			tt.want.UpdateTime = got2.UpdateTime
+			tt.want.Version = got2.Version
+
+			// KeyId is allocated via kms no need to validate in this test
+			tt.want.KeyId = got2.KeyId
+			got2.PrivateKeyEncrypted = nil
+
+			// encrypt also calculates the hmac, validate it is correct
+			hm, err := crypto.HmacSha256(ctx, got.PrivateKey, databaseWrapper, []byte(got.StoreId), nil)
+			require.NoError(err)
+			tt.want.PrivateKeyHmac = []byte(hm)
+
+			assert.Empty(cmp.Diff(tt.want, got2.clone(), protocmp.Transform()))
+		})
+	}
+}
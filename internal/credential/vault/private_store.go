@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
@@ -16,6 +17,7 @@ import (
 
 func (r *Repository) lookupClientStore(ctx context.Context, publicId string) (*clientStore, error) {
 	const op = "vault.(Repository).lookupClientStore"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
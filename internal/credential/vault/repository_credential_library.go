@@ -29,6 +29,7 @@ import (
 // Both l.CreateTime and l.UpdateTime are ignored.
 func (r *Repository) CreateCredentialLibrary(ctx context.Context, projectId string, l *CredentialLibrary, _ ...Option) (*CredentialLibrary, error) {
 	const op = "vault.(Repository).CreateCredentialLibrary"
+	ctx = db.NewOpContext(ctx, op)
 	if l == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil CredentialLibrary")
 	}
@@ -128,6 +129,7 @@ func (r *Repository) CreateCredentialLibrary(ctx context.Context, projectId stri
 // for HttpRequestBody when l.HttpMethod is set to GET the update will fail.
 func (r *Repository) UpdateCredentialLibrary(ctx context.Context, projectId string, l *CredentialLibrary, version uint32, fieldMaskPaths []string, _ ...Option) (*CredentialLibrary, int, error) {
 	const op = "vault.(Repository).UpdateCredentialLibrary"
+	ctx = db.NewOpContext(ctx, op)
 	if l == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing CredentialLibrary")
 	}
@@ -330,6 +332,7 @@ func (r *Repository) UpdateCredentialLibrary(ctx context.Context, projectId stri
 // Returns nil, nil if no CredentialLibrary is found for publicId.
 func (r *Repository) LookupCredentialLibrary(ctx context.Context, publicId string, _ ...Option) (*CredentialLibrary, error) {
 	const op = "vault.(Repository).LookupCredentialLibrary"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
@@ -417,6 +420,7 @@ func (pl *listLookupLibrary) GetPublicId() string { return pl.PublicId }
 // the number of records deleted.
 func (r *Repository) DeleteCredentialLibrary(ctx context.Context, projectId string, publicId string, _ ...Option) (int, error) {
 	const op = "vault.(Repository).DeleteCredentialLibrary"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
@@ -456,6 +460,7 @@ func (r *Repository) DeleteCredentialLibrary(ctx context.Context, projectId stri
 // storeId. WithLimit is the only option supported.
 func (r *Repository) ListCredentialLibraries(ctx context.Context, storeId string, opt ...Option) ([]*CredentialLibrary, error) {
 	const op = "vault.(Repository).ListCredentialLibraries"
+	ctx = db.NewOpContext(ctx, op)
 	if storeId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no storeId")
 	}
@@ -11,9 +11,11 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/go-dbw"
 	"github.com/hashicorp/go-secure-stdlib/parseutil"
 	vault "github.com/hashicorp/vault/api"
@@ -44,6 +46,7 @@ import (
 // https://www.vaultproject.io/api-docs/auth/token#lookup-a-token-self.
 func (r *Repository) CreateCredentialStore(ctx context.Context, cs *CredentialStore, _ ...Option) (*CredentialStore, error) {
 	const op = "vault.(Repository).CreateCredentialStore"
+	ctx = db.NewOpContext(ctx, op)
 	if cs == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil CredentialStore")
 	}
@@ -147,7 +150,10 @@ func (r *Repository) CreateCredentialStore(ctx context.Context, cs *CredentialSt
 	var newClientCertificate *ClientCertificate
 	var newCredentialStore *CredentialStore
 	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
-		func(_ db.Reader, w db.Writer) error {
+		func(reader db.Reader, w db.Writer) error {
+			if err := iam.CheckQuota(ctx, reader, cs.ProjectId, resource.CredentialStore); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 			msgs := make([]*oplog.Message, 0, 3)
 			ticket, err := w.GetTicket(ctx, cs)
 			if err != nil {
@@ -254,6 +260,7 @@ func validateTokenLookup(op errors.Op, s *vault.Secret) error {
 // nil, nil if no CredentialStore is found for publicId.
 func (r *Repository) LookupCredentialStore(ctx context.Context, publicId string, _ ...Option) (*CredentialStore, error) {
 	const op = "vault.(Repository).LookupCredentialStore"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
@@ -345,6 +352,7 @@ func (ps *listLookupStore) GetPublicId() string { return ps.PublicId }
 // in cs is the zero value and it is included in fieldMaskPaths.
 func (r *Repository) UpdateCredentialStore(ctx context.Context, cs *CredentialStore, version uint32, fieldMaskPaths []string, _ ...Option) (*CredentialStore, int, error) {
 	const op = "vault.(Repository).UpdateCredentialStore"
+	ctx = db.NewOpContext(ctx, op)
 	if cs == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing CredentialStore")
 	}
@@ -650,6 +658,7 @@ func (r *Repository) UpdateCredentialStore(ctx context.Context, cs *CredentialSt
 // projectIds. WithLimit is the only option supported.
 func (r *Repository) ListCredentialStores(ctx context.Context, projectIds []string, opt ...Option) ([]*CredentialStore, error) {
 	const op = "vault.(Repository).ListCredentialStores"
+	ctx = db.NewOpContext(ctx, op)
 	if len(projectIds) == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no projectIds")
 	}
@@ -660,7 +669,7 @@ func (r *Repository) ListCredentialStores(ctx context.Context, projectIds []stri
 		limit = opts.withLimit
 	}
 	var credentialStores []*listLookupStore
-	err := r.reader.SearchWhere(ctx, &credentialStores, "project_id in (?)", []any{projectIds}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &credentialStores, "project_id in (?)", []any{projectIds}, db.WithLimit(limit), db.WithReplicaRead(true))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -675,6 +684,7 @@ func (r *Repository) ListCredentialStores(ctx context.Context, projectIds []stri
 // the number of records deleted. All options are ignored.
 func (r *Repository) DeleteCredentialStore(ctx context.Context, publicId string, _ ...Option) (int, error) {
 	const op = "vault.(Repository).DeleteCredentialStore"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
@@ -53,6 +53,7 @@ func updateSessionQuery(c *Credential, sessionId string, purpose credential.Purp
 // requests and assigns them to sessionId.
 func (r *Repository) Issue(ctx context.Context, sessionId string, requests []credential.Request, opt ...credential.Option) ([]credential.Dynamic, error) {
 	const op = "vault.(Repository).Issue"
+	ctx = db.NewOpContext(ctx, op)
 	if sessionId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no session id")
 	}
@@ -147,6 +148,7 @@ var _ credential.Revoker = (*Repository)(nil)
 // Revoke revokes all dynamic credentials issued from Vault for sessionId.
 func (r *Repository) Revoke(ctx context.Context, sessionId string) error {
 	const op = "vault.(Repository).Revoke"
+	ctx = db.NewOpContext(ctx, op)
 	if sessionId == "" {
 		return errors.New(ctx, errors.InvalidParameter, op, "no session id")
 	}
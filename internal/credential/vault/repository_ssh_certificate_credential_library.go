@@ -27,6 +27,7 @@ import (
 // Both l.CreateTime and l.UpdateTime are ignored.
 func (r *Repository) CreateSSHCertificateCredentialLibrary(ctx context.Context, projectId string, l *SSHCertificateCredentialLibrary, _ ...Option) (*SSHCertificateCredentialLibrary, error) {
 	const op = "vault.(Repository).CreateSSHCertificateCredentialLibrary"
+	ctx = db.NewOpContext(ctx, op)
 	if l == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil SSHCertificateCredentialLibrary")
 	}
@@ -124,6 +125,7 @@ func (r *Repository) CreateSSHCertificateCredentialLibrary(ctx context.Context,
 // in l is the zero value and it is included in fieldMaskPaths.
 func (r *Repository) UpdateSSHCertificateCredentialLibrary(ctx context.Context, projectId string, l *SSHCertificateCredentialLibrary, version uint32, fieldMaskPaths []string, _ ...Option) (*SSHCertificateCredentialLibrary, int, error) {
 	const op = "vault.(Repository).UpdateSSHCertificateCredentialLibrary"
+	ctx = db.NewOpContext(ctx, op)
 	if l == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing SSHCertificateCredentialLibrary")
 	}
@@ -291,6 +293,7 @@ func (r *Repository) UpdateSSHCertificateCredentialLibrary(ctx context.Context,
 // Returns nil, nil if no SSHCertificateCredentialLibrary is found for publicId.
 func (r *Repository) LookupSSHCertificateCredentialLibrary(ctx context.Context, publicId string, _ ...Option) (*SSHCertificateCredentialLibrary, error) {
 	const op = "vault.(Repository).LookupCredentialLibrary"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
@@ -309,6 +312,7 @@ func (r *Repository) LookupSSHCertificateCredentialLibrary(ctx context.Context,
 // storeId. WithLimit is the only option supported.
 func (r *Repository) ListSSHCertificateCredentialLibraries(ctx context.Context, storeId string, opt ...Option) ([]*SSHCertificateCredentialLibrary, error) {
 	const op = "vault.(Repository).ListSSHCertificateCredentialLibraries"
+	ctx = db.NewOpContext(ctx, op)
 	if storeId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no storeId")
 	}
@@ -330,6 +334,7 @@ func (r *Repository) ListSSHCertificateCredentialLibraries(ctx context.Context,
 // the number of records deleted.
 func (r *Repository) DeleteSSHCertificateCredentialLibrary(ctx context.Context, projectId string, publicId string, _ ...Option) (int, error) {
 	const op = "vault.(Repository).DeleteSSHCertificateCredentialLibrary"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
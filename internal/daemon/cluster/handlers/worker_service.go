@@ -457,18 +457,24 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 			Certificate: sessionInfo.Certificate,
 			PrivateKey:  sessionInfo.CertificatePrivateKey,
 		},
-		Status:          sessionInfo.States[0].Status.ProtoVal(),
-		Version:         sessionInfo.Version,
-		TofuToken:       string(sessionInfo.TofuToken),
-		Endpoint:        sessionInfo.Endpoint,
-		Expiration:      sessionInfo.ExpirationTime.Timestamp,
-		ConnectionLimit: sessionInfo.ConnectionLimit,
-		ConnectionsLeft: authzSummary.ConnectionLimit,
-		HostId:          sessionInfo.HostId,
-		HostSetId:       sessionInfo.HostSetId,
-		TargetId:        sessionInfo.TargetId,
-		UserId:          sessionInfo.UserId,
-		Credentials:     workerCreds,
+		Status:                       sessionInfo.States[0].Status.ProtoVal(),
+		Version:                      sessionInfo.Version,
+		TofuToken:                    string(sessionInfo.TofuToken),
+		Endpoint:                     sessionInfo.Endpoint,
+		Expiration:                   sessionInfo.ExpirationTime.Timestamp,
+		ConnectionLimit:              sessionInfo.ConnectionLimit,
+		ConnectionsLeft:              authzSummary.ConnectionLimit,
+		HostId:                       sessionInfo.HostId,
+		HostSetId:                    sessionInfo.HostSetId,
+		TargetId:                     sessionInfo.TargetId,
+		UserId:                       sessionInfo.UserId,
+		Credentials:                  workerCreds,
+		MaxBytes:                     sessionInfo.MaxBytes,
+		ConnectionMaxBytesPerSecond:  sessionInfo.ConnectionMaxBytesPerSecond,
+		ConnectionIdleTimeoutSeconds: sessionInfo.ConnectionIdleTimeoutSeconds,
+		EndpointTlsEnabled:           sessionInfo.EndpointTlsEnabled,
+		EndpointTlsCaCert:            sessionInfo.EndpointTlsCaCert,
+		EndpointTlsServerName:        sessionInfo.EndpointTlsServerName,
 	}
 	if resp.ConnectionsLeft != -1 {
 		resp.ConnectionsLeft -= int32(authzSummary.CurrentConnectionCount)
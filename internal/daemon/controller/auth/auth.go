@@ -112,6 +112,15 @@ type verifier struct {
 	act                action.Type
 	ctx                context.Context
 	acl                perms.ACL
+
+	// scopeParentIds maps the IDs of scopes seen during verification to their
+	// parent scope IDs, so that grants applying to the children of a scope can
+	// be evaluated for resources that don't carry a parent scope ID
+	scopeParentIds map[string]string
+
+	// apiKey is the service account API key the request was authenticated
+	// with, if any. Such requests are limited to the key's scope.
+	apiKey *iam.ServiceAccountApiKey
 }
 
 // TODO (jefferai 10/2022): NewVerifierContextWithAccounts performs the function
@@ -261,6 +270,8 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 		Id:      opts.withId,
 		Pin:     opts.withPin,
 		Type:    opts.withType,
+		Name:    opts.withName,
+		Tags:    opts.withTags,
 	}
 	// Global scope has no parent ID; account for this
 	if opts.withId == scope.Global.String() && opts.withType == resource.Scope {
@@ -316,6 +327,16 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 			ea.UserInfo = &event.UserInfo{
 				UserId: ret.UserId,
 			}
+			ea.ServiceAccountInfo = v.serviceAccountInfo()
+			if authResults.DeniedBy != nil {
+				ea.GrantsInfo = &event.GrantsInfo{
+					DeniedBy: &event.Grant{
+						Grant:   authResults.DeniedBy.CanonicalString(),
+						RoleId:  authResults.DeniedBy.RoleId(),
+						ScopeId: authResults.DeniedBy.GrantScopeId(),
+					},
+				}
+			}
 			return
 		}
 	}
@@ -325,7 +346,7 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 		grants = append(grants, event.Grant{
 			Grant:   g.Grant,
 			RoleId:  g.RoleId,
-			ScopeId: g.ScopeId,
+			ScopeId: g.GrantScopeId,
 		})
 	}
 	ea.UserInfo = &event.UserInfo{
@@ -334,6 +355,7 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 	if userData.Account.Id != nil {
 		ea.UserInfo.AuthAccountId = *userData.Account.Id
 	}
+	ea.ServiceAccountInfo = v.serviceAccountInfo()
 	ea.GrantsInfo = &event.GrantsInfo{
 		Grants: grants,
 	}
@@ -361,6 +383,10 @@ func (v *verifier) decryptToken(ctx context.Context) {
 		return
 
 	case uint32(AuthTokenTypeBearer), uint32(AuthTokenTypeSplitCookie):
+		if strings.HasPrefix(v.requestInfo.PublicId, globals.ServiceAccountApiKeyPrefix+"_") {
+			v.validateApiKey(ctx)
+			return
+		}
 		if v.kms == nil {
 			event.WriteError(ctx, op, stderrors.New("no KMS object available to authz system"))
 			v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
@@ -479,7 +505,65 @@ func (v *verifier) decryptToken(ctx context.Context) {
 	}
 }
 
-func (v verifier) performAuthCheck(ctx context.Context) (
+// validateApiKey validates a service account API key. Unlike auth tokens, API
+// keys are not encrypted: the key's secret is checked against the hash stored
+// for it.
+func (v *verifier) validateApiKey(ctx context.Context) {
+	const op = "auth.(verifier).validateApiKey"
+	iamRepo, err := v.iamRepoFn()
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("failed to get iam repo"))
+		v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
+		return
+	}
+	key, err := iamRepo.ValidateServiceAccountApiKey(v.ctx, v.requestInfo.PublicId, v.requestInfo.EncryptedToken)
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error validating api key; continuing as anonymous user"))
+		v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
+		return
+	}
+	if key == nil {
+		event.WriteError(ctx, op, stderrors.New("api key not found, invalid or expired; continuing as anonymous user"), event.WithInfo("api_key_id", v.requestInfo.PublicId))
+		v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
+		return
+	}
+	v.apiKey = key
+}
+
+// serviceAccountInfo returns the audit information about the API key used to
+// authenticate the request, or nil if none was used.
+func (v *verifier) serviceAccountInfo() *event.ServiceAccountInfo {
+	if v.apiKey == nil {
+		return nil
+	}
+	return &event.ServiceAccountInfo{
+		ServiceAccountId: v.apiKey.GetServiceAccountId(),
+		ApiKeyId:         v.apiKey.GetPublicId(),
+		ApiKeyScopeId:    v.apiKey.GetScopeId(),
+	}
+}
+
+// withinApiKeyScope reports whether the resource is within the scope of the
+// API key the request was authenticated with: the key's scope itself, a
+// resource in it, or a resource in one of its child scopes. It is always true
+// for requests that were not authenticated with an API key.
+func (v *verifier) withinApiKeyScope(res perms.Resource) bool {
+	if v.apiKey == nil {
+		return true
+	}
+	keyScopeId := v.apiKey.GetScopeId()
+	switch {
+	case res.Type == resource.Scope && res.Id == keyScopeId:
+		return true
+	case res.ScopeId == keyScopeId:
+		return true
+	case res.ScopeId != "" && res.ParentScopeId == keyScopeId:
+		return true
+	}
+	return false
+}
+
+func (v *verifier) performAuthCheck(ctx context.Context) (
 	aclResults perms.ACLResults,
 	userData template.Data,
 	scopeInfo *scopes.ScopeInfo,
@@ -509,6 +593,12 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 		userData.User.Id = util.Pointer(globals.RecoveryUserId)
 
 	case uint32(AuthTokenTypeBearer), uint32(AuthTokenTypeSplitCookie):
+		if v.apiKey != nil {
+			// The API key was validated in decryptToken; the request is
+			// made as its service account
+			userData.User.Id = util.Pointer(v.apiKey.GetServiceAccountId())
+			break
+		}
 		if v.requestInfo.Token == "" {
 			// This will end up staying as the anonymous user
 			break
@@ -542,14 +632,16 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 		return
 	}
 
-	u, _, err := iamRepo.LookupUser(ctx, *userData.User.Id)
-	if err != nil {
-		retErr = errors.Wrap(ctx, err, op, errors.WithMsg("failed to lookup user"))
-		return
+	if v.apiKey == nil {
+		u, _, err := iamRepo.LookupUser(ctx, *userData.User.Id)
+		if err != nil {
+			retErr = errors.Wrap(ctx, err, op, errors.WithMsg("failed to lookup user"))
+			return
+		}
+		userData.User.Name = util.Pointer(u.Name)
+		userData.User.Email = util.Pointer(u.Email)
+		userData.User.FullName = util.Pointer(u.FullName)
 	}
-	userData.User.Name = util.Pointer(u.Name)
-	userData.User.Email = util.Pointer(u.Email)
-	userData.User.FullName = util.Pointer(u.FullName)
 
 	if userData.Account.Id != nil && *userData.Account.Id != "" && v.passwordAuthRepoFn != nil && v.oidcAuthRepoFn != nil && v.ldapAuthRepoFn != nil {
 		const domain = "auth"
@@ -593,6 +685,16 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 		userData.Account.Email = util.Pointer(acct.GetEmail())
 		userData.Account.LoginName = util.Pointer(acct.GetLoginName())
 		userData.Account.Subject = util.Pointer(acct.GetSubject())
+		// OIDC and LDAP accounts carry the full name mapped from the
+		// account_claim_map or account_attribute_map of their auth method
+		if fn, ok := acct.(interface{ GetFullName() string }); ok {
+			userData.Account.FullName = util.Pointer(fn.GetFullName())
+		}
+		// Any claim of an OIDC account can be used in templates, not only
+		// those mapped to account fields
+		if oa, ok := acct.(*oidc.Account); ok {
+			userData.Account.Claims = oa.StringClaims()
+		}
 	}
 
 	// Look up scope details to return. We can skip a lookup when using the
@@ -626,6 +728,9 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 		}
 	}
 
+	v.res.ParentScopeId = scopeInfo.ParentScopeId
+	v.scopeParentIds = map[string]string{scopeInfo.Id: scopeInfo.ParentScopeId}
+
 	// At this point we don't need to look up grants since it's automatically allowed
 	if v.requestInfo.TokenFormat == uint32(AuthTokenTypeRecoveryKms) {
 		aclResults.AuthenticationFinished = true
@@ -637,8 +742,12 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 	var parsedGrants []perms.Grant
 
 	// Fetch and parse grants for this user ID (which may include grants for
-	// u_anon and u_auth)
-	grantTuples, err = iamRepo.GrantsForUser(v.ctx, *userData.User.Id)
+	// u_anon and u_auth), or for the service account of the API key
+	if v.apiKey != nil {
+		grantTuples, err = iamRepo.GrantsForServiceAccount(v.ctx, *userData.User.Id)
+	} else {
+		grantTuples, err = iamRepo.GrantsForUser(v.ctx, *userData.User.Id)
+	}
 	if err != nil {
 		retErr = errors.Wrap(ctx, err, op)
 		return
@@ -650,13 +759,23 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 	for _, pair := range grantTuples {
 		permsOpts := []perms.Option{
 			perms.WithUserId(*userData.User.Id),
+			perms.WithRoleId(pair.RoleId),
+			perms.WithTemplateData(userData),
 			perms.WithSkipFinalValidation(true),
 		}
 		if userData.Account.Id != nil {
 			permsOpts = append(permsOpts, perms.WithAccountId(*userData.Account.Id))
 		}
+		// Grants applying to the children or descendants of a scope are
+		// parsed against the role's scope
+		scopeId := pair.GrantScopeId
+		switch pair.GrantScopeId {
+		case globals.GrantScopeChildren, globals.GrantScopeDescendants:
+			scopeId = pair.RoleScopeId
+			permsOpts = append(permsOpts, perms.WithGrantScope(pair.GrantScopeId))
+		}
 		parsed, err := perms.Parse(
-			pair.ScopeId,
+			scopeId,
 			pair.Grant,
 			permsOpts...)
 		if err != nil {
@@ -668,6 +787,10 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 
 	retAcl = perms.NewACL(parsedGrants...)
 	aclResults = retAcl.Allowed(*v.res, v.act, *userData.User.Id)
+	if aclResults.Authorized && !v.withinApiKeyScope(*v.res) {
+		aclResults.Authorized = false
+		aclResults.OutputFields = nil
+	}
 	// We don't set authenticated above because setting this but not authorized
 	// is used for further permissions checks, such as during recursive listing.
 	// So we want to make sure any code relying on that has the full set of
@@ -721,6 +844,10 @@ func (r *VerifyResults) fetchActions(id string, typ resource.Type, availableActi
 	if typ != resource.Unknown {
 		res.Type = typ
 	}
+	r.v.resolveParentScopeId(res)
+	if !r.v.withinApiKeyScope(*res) {
+		return nil
+	}
 
 	ret := make(action.ActionSet, 0, len(availableActions))
 	for _, act := range availableActions {
@@ -748,9 +875,31 @@ func (r *VerifyResults) FetchOutputFields(res perms.Resource, act action.Type) *
 		return ret
 	}
 
+	r.v.resolveParentScopeId(&res)
+	if !r.v.withinApiKeyScope(res) {
+		return ret
+	}
 	return r.v.acl.Allowed(res, act, *r.UserData.User.Id).OutputFields
 }
 
+// listDenied returns whether listing the resource's type in its scope is
+// removed by a deny grant.
+func (r *VerifyResults) listDenied(res perms.Resource) bool {
+	if r.UserData.User.Id == nil {
+		return false
+	}
+	return r.v.acl.Allowed(res, action.List, *r.UserData.User.Id).DeniedBy != nil
+}
+
+// resolveParentScopeId fills in the parent scope ID of res from the scopes
+// seen during verification, if it isn't already set.
+func (v *verifier) resolveParentScopeId(res *perms.Resource) {
+	if res.ParentScopeId != "" || res.ScopeId == "" {
+		return
+	}
+	res.ParentScopeId = v.scopeParentIds[res.ScopeId]
+}
+
 // ACL returns the perms.ACL of the verifier.
 func (r *VerifyResults) ACL() perms.ACL {
 	if r.v == nil {
@@ -852,15 +1001,21 @@ func (r *VerifyResults) ScopesAuthorizedForList(ctx context.Context, rootScopeId
 	// For each scope, see if we have permission to list that resource in that scope
 	for _, scp := range scps {
 		scpId := scp.GetPublicId()
+		if r.v.scopeParentIds != nil {
+			r.v.scopeParentIds[scpId] = scp.GetParentId()
+		}
 		aSet := r.FetchActionSetForType(ctx,
 			resource.Unknown, // This is overridden by `WithResource` option.
 			action.ActionSet{action.List},
-			WithResource(&perms.Resource{Type: resourceType, ScopeId: scpId}),
+			WithResource(&perms.Resource{Type: resourceType, ScopeId: scpId, ParentScopeId: scp.GetParentId()}),
 		)
 
 		// We only expect the action set to be nothing, or list. In case
 		// this is not the case, we bail out.
 		switch {
+		case len(aSet) == 0 && r.listDenied(perms.Resource{Type: resourceType, ScopeId: scpId, ParentScopeId: scp.GetParentId()}):
+			// A deny grant removes list in this scope, so it must not be
+			// picked up via its parent below
 		case len(aSet) == 0:
 			// Defer until we've read all scopes. We do this because if the
 			// ordering coming back isn't in parent-first ordering our map
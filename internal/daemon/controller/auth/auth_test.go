@@ -11,6 +11,7 @@ import (
 	"os"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -18,10 +19,13 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	authpb "github.com/hashicorp/boundary/internal/gen/controller/auth"
 	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/iam/store"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/tests/api"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/eventlogger/filters/encrypt"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -177,15 +181,20 @@ func TestVerify_AuditEvent(t *testing.T) {
 	tokValue := at.GetPublicId() + "_" + encToken
 	jsCookieVal, httpCookieVal := tokValue[:len(tokValue)/2], tokValue[len(tokValue)/2:]
 
+	sa := iam.TestServiceAccount(t, conn, o.GetPublicId())
+	apiKey, err := iamRepo.AddServiceAccountApiKey(context.Background(), sa.GetPublicId(), sa.GetVersion(), o.GetPublicId(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
 	tests := []struct {
-		name              string
-		headers           map[string]string
-		cookies           []http.Cookie
-		opt               []Option
-		disableAuth       bool
-		wantResults       VerifyResults
-		wantAuthAuditData bool
-		wantUserId        string
+		name                   string
+		headers                map[string]string
+		cookies                []http.Cookie
+		opt                    []Option
+		disableAuth            bool
+		wantResults            VerifyResults
+		wantAuthAuditData      bool
+		wantUserId             string
+		wantServiceAccountInfo map[string]any
 	}{
 		{
 			name:              "bearer-token",
@@ -204,6 +213,18 @@ func TestVerify_AuditEvent(t *testing.T) {
 			wantAuthAuditData: true,
 			wantUserId:        at.IamUserId,
 		},
+		{
+			name:              "service-account-api-key",
+			headers:           map[string]string{"Authorization": fmt.Sprintf("Bearer %s", apiKey.Token())},
+			opt:               []Option{WithScopeId(o.PublicId)},
+			wantAuthAuditData: true,
+			wantUserId:        sa.GetPublicId(),
+			wantServiceAccountInfo: map[string]any{
+				"id":               sa.GetPublicId(),
+				"api_key_id":       apiKey.GetPublicId(),
+				"api_key_scope_id": o.GetPublicId(),
+			},
+		},
 		{
 			name:              "no-auth-data",
 			opt:               []Option{WithScopeId(o.PublicId)},
@@ -257,7 +278,63 @@ func TestVerify_AuditEvent(t *testing.T) {
 					require.True(ok)
 					assert.Equal(tt.wantUserId, userInfo["id"])
 				}
+				if tt.wantServiceAccountInfo != nil {
+					assert.Equal(tt.wantServiceAccountInfo, auth["service_account_info"])
+				} else {
+					assert.Nil(auth["service_account_info"])
+				}
 			}
 		})
 	}
 }
+
+func TestVerifier_withinApiKeyScope(t *testing.T) {
+	t.Parallel()
+	key := &iam.ServiceAccountApiKey{ServiceAccountApiKey: &store.ServiceAccountApiKey{ScopeId: "o_1234567890"}}
+	tests := []struct {
+		name   string
+		apiKey *iam.ServiceAccountApiKey
+		res    perms.Resource
+		want   bool
+	}{
+		{
+			name: "no-api-key",
+			res:  perms.Resource{ScopeId: "global", Type: resource.User},
+			want: true,
+		},
+		{
+			name:   "key-scope",
+			apiKey: key,
+			res:    perms.Resource{Id: "o_1234567890", ScopeId: "global", Type: resource.Scope},
+			want:   true,
+		},
+		{
+			name:   "in-key-scope",
+			apiKey: key,
+			res:    perms.Resource{ScopeId: "o_1234567890", ParentScopeId: "global", Type: resource.User},
+			want:   true,
+		},
+		{
+			name:   "in-child-scope",
+			apiKey: key,
+			res:    perms.Resource{ScopeId: "p_1234567890", ParentScopeId: "o_1234567890", Type: resource.Target},
+			want:   true,
+		},
+		{
+			name:   "other-org",
+			apiKey: key,
+			res:    perms.Resource{ScopeId: "o_0987654321", ParentScopeId: "global", Type: resource.User},
+		},
+		{
+			name:   "global",
+			apiKey: key,
+			res:    perms.Resource{ScopeId: "global", Type: resource.User},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &verifier{apiKey: tt.apiKey}
+			assert.Equal(t, tt.want, v.withinApiKeyScope(tt.res))
+		})
+	}
+}
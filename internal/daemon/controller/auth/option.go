@@ -26,6 +26,8 @@ type options struct {
 	withScopeId                 string
 	withPin                     string
 	withId                      string
+	withName                    string
+	withTags                    map[string][]string
 	withAction                  action.Type
 	withType                    resource.Type
 	withUserId                  string
@@ -57,6 +59,22 @@ func WithId(id string) Option {
 	}
 }
 
+// WithName specifies the name of the resource, used to evaluate grants that
+// match on name patterns
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
+
+// WithTags specifies the tags of the resource, used to evaluate grants that
+// match on tags
+func WithTags(tags map[string][]string) Option {
+	return func(o *options) {
+		o.withTags = tags
+	}
+}
+
 func WithAction(action action.Type) Option {
 	return func(o *options) {
 		o.withAction = action
@@ -24,6 +24,8 @@ func Test_GetOpts(t *testing.T) {
 		WithScopeId("foo"),
 		WithPin("bar"),
 		WithId("zip"),
+		WithName("name"),
+		WithTags(map[string][]string{"env": {"dev"}}),
 		WithAction(action.AddHosts),
 		WithType(resource.Group),
 		WithUserId("user"),
@@ -36,6 +38,8 @@ func Test_GetOpts(t *testing.T) {
 		withScopeId:                 "foo",
 		withPin:                     "bar",
 		withId:                      "zip",
+		withName:                    "name",
+		withTags:                    map[string][]string{"env": {"dev"}},
 		withAction:                  action.AddHosts,
 		withType:                    resource.Group,
 		withUserId:                  "user",
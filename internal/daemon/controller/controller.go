@@ -28,6 +28,7 @@ import (
 	pluginhost "github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
+	iamjob "github.com/hashicorp/boundary/internal/iam/job"
 	"github.com/hashicorp/boundary/internal/kms"
 	kmsjob "github.com/hashicorp/boundary/internal/kms/job"
 	"github.com/hashicorp/boundary/internal/observability/event"
@@ -128,6 +129,7 @@ type Controller struct {
 	HostPluginRepoFn        common.HostPluginRepoFactory
 	TargetRepoFn            target.RepositoryFactory
 	WorkerAuthRepoStorageFn common.WorkerAuthRepoStorageFactory
+	JobRepoFn               common.JobRepoFactory
 
 	scheduler *scheduler.Scheduler
 
@@ -144,6 +146,8 @@ type Controller struct {
 
 func New(ctx context.Context, conf *Config) (*Controller, error) {
 	metric.InitializeApiCollectors(conf.PrometheusRegisterer)
+	scheduler.InitializeCollectors(conf.PrometheusRegisterer)
+	db.InitializeCollectors(conf.PrometheusRegisterer, conf.Database)
 	c := &Controller{
 		conf:                    conf,
 		logger:                  conf.Logger.Named("controller"),
@@ -318,7 +322,7 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 	if err := c.conf.Eventer.RotateAuditWrapper(ctx, auditWrapper); err != nil {
 		return nil, fmt.Errorf("error rotating eventer audit wrapper: %w", err)
 	}
-	jobRepoFn := func() (*job.Repository, error) {
+	c.JobRepoFn = func() (*job.Repository, error) {
 		return job.NewRepository(dbase, dbase, c.kms)
 	}
 	// TODO: Allow setting run jobs limit from config
@@ -330,8 +334,14 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 		if sche.MonitorIntervalDuration > 0 {
 			schedulerOpts = append(schedulerOpts, scheduler.WithMonitorInterval(sche.MonitorIntervalDuration))
 		}
+		if sche.JobFailureThreshold > 0 {
+			schedulerOpts = append(schedulerOpts, scheduler.WithFailureThreshold(sche.JobFailureThreshold))
+		}
+		if sche.JobOverdueThresholdDuration > 0 {
+			schedulerOpts = append(schedulerOpts, scheduler.WithOverdueThreshold(sche.JobOverdueThresholdDuration))
+		}
 	}
-	c.scheduler, err = scheduler.New(c.conf.RawConfig.Controller.Name, jobRepoFn, schedulerOpts...)
+	c.scheduler, err = scheduler.New(c.conf.RawConfig.Controller.Name, c.JobRepoFn, schedulerOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("error creating new scheduler: %w", err)
 	}
@@ -438,7 +448,7 @@ func (c *Controller) Start() error {
 		return fmt.Errorf("error starting scheduler: %w", err)
 	}
 
-	c.tickerWg.Add(5)
+	c.tickerWg.Add(6)
 	go func() {
 		defer c.tickerWg.Done()
 		c.startStatusTicking(c.baseContext)
@@ -455,6 +465,10 @@ func (c *Controller) Start() error {
 		defer c.tickerWg.Done()
 		c.startCloseExpiredPendingTokens(c.baseContext)
 	}()
+	go func() {
+		defer c.tickerWg.Done()
+		c.startReplicaLagTicking(c.baseContext)
+	}()
 	if err := c.startWorkerConnectionMaintenanceTicking(c.baseContext, c.tickerWg, c.pkiConnManager); err != nil {
 		return errors.Wrap(c.baseContext, err, op)
 	}
@@ -524,7 +538,14 @@ func (c *Controller) registerJobs() error {
 	if err := kmsjob.RegisterJobs(c.baseContext, c.scheduler, c.kms); err != nil {
 		return err
 	}
-	if err := cleaner.RegisterJob(c.baseContext, c.scheduler, rw); err != nil {
+	if err := iamjob.RegisterJobs(c.baseContext, c.scheduler, rw, rw, c.kms); err != nil {
+		return err
+	}
+	var runHistoryLimit int
+	if sche := c.conf.RawConfig.Controller.Scheduler; sche != nil {
+		runHistoryLimit = sche.JobRunHistoryLimit
+	}
+	if err := cleaner.RegisterJob(c.baseContext, c.scheduler, rw, runHistoryLimit); err != nil {
 		return err
 	}
 
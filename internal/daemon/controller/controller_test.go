@@ -315,7 +315,9 @@ func Test_ControllerStart(t *testing.T) {
 		jobNames = append(jobNames, jobName)
 	}
 	require.NoError(t, rows.Err())
-	// Check that the monitor job and at least one of the rewrapping jobs has been registered
+	// Check that the monitor job, the key rotation job and at least one of the
+	// rewrapping jobs has been registered
 	assert.Contains(t, jobNames, "data-key-version-destruction-monitor-job")
+	assert.Contains(t, jobNames, "key-rotation-job")
 	assert.Contains(t, jobNames, "session-rewrapping-job")
 }
@@ -32,9 +32,11 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/host_catalogs"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/host_sets"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/hosts"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/jobs"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/managed_groups"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/roles"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/scopes"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/serviceaccounts"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/users"
@@ -221,6 +223,20 @@ func (c *Controller) registerGrpcServices(s *grpc.Server) error {
 		}
 		services.RegisterRoleServiceServer(s, rs)
 	}
+	if _, ok := currentServices[services.ServiceAccountService_ServiceDesc.ServiceName]; !ok {
+		sas, err := serviceaccounts.NewService(c.IamRepoFn)
+		if err != nil {
+			return fmt.Errorf("failed to create service account handler service: %w", err)
+		}
+		services.RegisterServiceAccountServiceServer(s, sas)
+	}
+	if _, ok := currentServices[services.JobService_ServiceDesc.ServiceName]; !ok {
+		js, err := jobs.NewService(c.JobRepoFn, c.scheduler)
+		if err != nil {
+			return fmt.Errorf("failed to create job handler service: %w", err)
+		}
+		services.RegisterJobServiceServer(s, js)
+	}
 	if _, ok := currentServices[services.SessionService_ServiceDesc.ServiceName]; !ok {
 		ss, err := sessions.NewService(c.SessionRepoFn, c.IamRepoFn)
 		if err != nil {
@@ -309,6 +325,12 @@ func registerGrpcGatewayEndpoints(ctx context.Context, gwMux *runtime.ServeMux,
 	if err := services.RegisterRoleServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
 		return fmt.Errorf("failed to register role service handler: %w", err)
 	}
+	if err := services.RegisterServiceAccountServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
+		return fmt.Errorf("failed to register service account service handler: %w", err)
+	}
+	if err := services.RegisterJobServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
+		return fmt.Errorf("failed to register job service handler: %w", err)
+	}
 	if err := services.RegisterSessionServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
 		return fmt.Errorf("failed to register session service handler: %w", err)
 	}
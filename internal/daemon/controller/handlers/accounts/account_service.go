@@ -159,9 +159,10 @@ func (s Service) ListAccounts(ctx context.Context, req *pbs.ListAccountsRequest)
 	finalItems := make([]*pb.Account, 0, len(ul))
 
 	res := perms.Resource{
-		ScopeId: authResults.Scope.Id,
-		Type:    resource.Account,
-		Pin:     req.GetAuthMethodId(),
+		ScopeId:       authResults.Scope.Id,
+		Type:          resource.Account,
+		Pin:           req.GetAuthMethodId(),
+		ParentScopeId: authResults.Scope.ParentScopeId,
 	}
 	for _, acct := range ul {
 		res.Id = acct.GetPublicId()
@@ -112,6 +112,7 @@ func (s Service) ListAuthTokens(ctx context.Context, req *pbs.ListAuthTokensRequ
 	for _, at := range ul {
 		res.Id = at.GetPublicId()
 		res.ScopeId = at.GetScopeId()
+		res.ParentScopeId = scopeInfoMap[at.GetScopeId()].GetParentScopeId()
 		authorizedActions := authResults.FetchActionSetForId(ctx, at.GetPublicId(), IdActions, auth.WithResource(&res))
 		if len(authorizedActions) == 0 {
 			continue
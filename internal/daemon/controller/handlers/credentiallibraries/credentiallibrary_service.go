@@ -145,9 +145,10 @@ func (s Service) ListCredentialLibraries(ctx context.Context, req *pbs.ListCrede
 	}
 	finalItems := make([]*pb.CredentialLibrary, 0, len(csl))
 	res := perms.Resource{
-		ScopeId: authResults.Scope.Id,
-		Type:    resource.CredentialLibrary,
-		Pin:     req.GetCredentialStoreId(),
+		ScopeId:       authResults.Scope.Id,
+		Type:          resource.CredentialLibrary,
+		Pin:           req.GetCredentialStoreId(),
+		ParentScopeId: authResults.Scope.ParentScopeId,
 	}
 	for _, item := range csl {
 		res.Id = item.GetPublicId()
@@ -5,6 +5,7 @@ package credentials
 
 import (
 	"context"
+	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
@@ -38,6 +39,7 @@ const (
 	privateKeyField           = "attributes.private_key"
 	privateKeyPassphraseField = "attributes.private_key_passphrase"
 	objectField               = "attributes.object"
+	certificateField          = "attributes.certificate"
 	domain                    = "credential"
 )
 
@@ -45,6 +47,7 @@ var (
 	upMaskManager   handlers.MaskManager
 	spkMaskManager  handlers.MaskManager
 	jsonMaskManager handlers.MaskManager
+	tlsMaskManager  handlers.MaskManager
 
 	// IdActions contains the set of actions that can be performed on
 	// individual resources
@@ -77,6 +80,10 @@ func init() {
 		handlers.MaskSource{&pb.Credential{}, &pb.JsonAttributes{}}); err != nil {
 		panic(err)
 	}
+	if tlsMaskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.TlsCertificateCredential{}},
+		handlers.MaskSource{&pb.Credential{}, &pb.TlsCertificateAttributes{}}); err != nil {
+		panic(err)
+	}
 }
 
 // Service handles request as described by the pbs.CredentialServiceServer interface.
@@ -125,9 +132,10 @@ func (s Service) ListCredentials(ctx context.Context, req *pbs.ListCredentialsRe
 	}
 	finalItems := make([]*pb.Credential, 0, len(creds))
 	res := perms.Resource{
-		ScopeId: authResults.Scope.Id,
-		Type:    resource.Credential,
-		Pin:     req.GetCredentialStoreId(),
+		ScopeId:       authResults.Scope.Id,
+		Type:          resource.Credential,
+		Pin:           req.GetCredentialStoreId(),
+		ParentScopeId: authResults.Scope.ParentScopeId,
 	}
 	for _, item := range creds {
 		res.Id = item.GetPublicId()
@@ -388,6 +396,23 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Cred
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create credential but no error returned from repository.")
 		}
 		return out, nil
+	case credential.TlsCertificateSubtype.String():
+		cred, err := toTlsCertificateStorageCredential(ctx, item.GetCredentialStoreId(), item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		out, err := repo.CreateTlsCertificateCredential(ctx, scopeId, cred)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create credential"))
+		}
+		if out == nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create credential but no error returned from repository.")
+		}
+		return out, nil
 	default:
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, fmt.Sprintf("Unsupported credential type %q", item.GetType()))
 	}
@@ -487,6 +512,30 @@ func (s Service) updateInRepo(
 		}
 		return out, nil
 
+	case credential.TlsCertificateSubtype:
+		dbMasks = append(dbMasks, tlsMaskManager.Translate(masks)...)
+		if len(dbMasks) == 0 {
+			return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+		}
+
+		cred, err := toTlsCertificateStorageCredential(ctx, storeId, in)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to convert to tls certificate storage credential"))
+		}
+		cred.PublicId = id
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		out, rowsUpdated, err := repo.UpdateTlsCertificateCredential(ctx, scopeId, cred, item.GetVersion(), dbMasks)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update credential"))
+		}
+		if rowsUpdated == 0 {
+			return nil, handlers.NotFoundErrorf("Credential %q doesn't exist or incorrect version provided.", id)
+		}
+		return out, nil
+
 	default:
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, fmt.Sprintf("Unsupported credential type %q", item.GetType()))
 
@@ -581,6 +630,8 @@ func toProto(in credential.Static, opt ...handlers.Option) (*pb.Credential, erro
 			out.Type = credential.SshPrivateKeySubtype.String()
 		case *static.JsonCredential:
 			out.Type = credential.JsonSubtype.String()
+		case *static.TlsCertificateCredential:
+			out.Type = credential.TlsCertificateSubtype.String()
 		}
 	}
 	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
@@ -633,6 +684,15 @@ func toProto(in credential.Static, opt ...handlers.Option) (*pb.Credential, erro
 				},
 			}
 		}
+	case *static.TlsCertificateCredential:
+		if outputFields.Has(globals.AttributesField) {
+			out.Attrs = &pb.Credential_TlsCertificateAttributes{
+				TlsCertificateAttributes: &pb.TlsCertificateAttributes{
+					Certificate:    wrapperspb.String(string(cred.GetCertificate())),
+					PrivateKeyHmac: base64.RawURLEncoding.EncodeToString(cred.GetPrivateKeyHmac()),
+				},
+			}
+		}
 	}
 	return &out, nil
 }
@@ -719,6 +779,30 @@ func toJsonStorageCredential(ctx context.Context, storeId string, in *pb.Credent
 	return cs, err
 }
 
+func toTlsCertificateStorageCredential(ctx context.Context, storeId string, in *pb.Credential) (out *static.TlsCertificateCredential, err error) {
+	const op = "credentials.toTlsCertificateStorageCredential"
+	var opts []static.Option
+	if in.GetName() != nil {
+		opts = append(opts, static.WithName(in.GetName().GetValue()))
+	}
+	if in.GetDescription() != nil {
+		opts = append(opts, static.WithDescription(in.GetDescription().GetValue()))
+	}
+
+	attrs := in.GetTlsCertificateAttributes()
+	cs, err := static.NewTlsCertificateCredential(
+		ctx,
+		storeId,
+		[]byte(attrs.GetCertificate().GetValue()),
+		credential.PrivateKey(attrs.GetPrivateKey().GetValue()),
+		opts...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to build credential"))
+	}
+
+	return cs, err
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //   - The path passed in is correctly formatted
@@ -732,6 +816,7 @@ func validateGetRequest(req *pbs.GetCredentialRequest) error {
 		globals.UsernamePasswordCredentialPreviousPrefix,
 		globals.SshPrivateKeyCredentialPrefix,
 		globals.JsonCredentialPrefix,
+		globals.TlsCertificateCredentialPrefix,
 	)
 }
 
@@ -792,6 +877,22 @@ func validateCreateRequest(req *pbs.CreateCredentialRequest) error {
 				badFields[objectField] = "Field required for creating a json credential."
 			}
 
+		case credential.TlsCertificateSubtype.String():
+			attrs := req.GetItem().GetTlsCertificateAttributes()
+			certificate := attrs.GetCertificate().GetValue()
+			privateKey := attrs.GetPrivateKey().GetValue()
+			if certificate == "" {
+				badFields[certificateField] = "Field required for creating a TLS certificate credential."
+			}
+			if privateKey == "" {
+				badFields[privateKeyField] = "Field required for creating a TLS certificate credential."
+			}
+			if certificate != "" && privateKey != "" {
+				if _, err := tls.X509KeyPair([]byte(certificate), []byte(privateKey)); err != nil {
+					badFields[certificateField] = fmt.Sprintf("Unable to parse given certificate and private key values: %v.", err)
+				}
+			}
+
 		default:
 			badFields[globals.TypeField] = fmt.Sprintf("Unsupported credential type %q", req.Item.GetType())
 		}
@@ -863,6 +964,31 @@ func validateUpdateRequest(req *pbs.UpdateCredentialRequest) error {
 				badFields[objectField] = "This is a required field and cannot be set to empty."
 			}
 
+		case credential.TlsCertificateSubtype:
+			attrs := req.GetItem().GetTlsCertificateAttributes()
+			paths := req.GetUpdateMask().GetPaths()
+			certificate := attrs.GetCertificate().GetValue()
+			privateKey := attrs.GetPrivateKey().GetValue()
+			// The certificate and private key must form a valid pair, which
+			// can only be checked when both are given.
+			if handlers.MaskContains(paths, certificateField) != handlers.MaskContains(paths, privateKeyField) {
+				badFields[certificateField] = "The certificate and private key must be updated together."
+				badFields[privateKeyField] = "The certificate and private key must be updated together."
+				break
+			}
+			if handlers.MaskContains(paths, certificateField) {
+				switch {
+				case certificate == "":
+					badFields[certificateField] = "This is a required field and cannot be set to empty."
+				case privateKey == "":
+					badFields[privateKeyField] = "This is a required field and cannot be set to empty."
+				default:
+					if _, err := tls.X509KeyPair([]byte(certificate), []byte(privateKey)); err != nil {
+						badFields[certificateField] = fmt.Sprintf("Unable to parse given certificate and private key values: %v.", err)
+					}
+				}
+			}
+
 		default:
 			badFields[globals.IdField] = "Unknown credential type."
 		}
@@ -873,6 +999,7 @@ func validateUpdateRequest(req *pbs.UpdateCredentialRequest) error {
 		globals.UsernamePasswordCredentialPreviousPrefix,
 		globals.SshPrivateKeyCredentialPrefix,
 		globals.JsonCredentialPrefix,
+		globals.TlsCertificateCredentialPrefix,
 	)
 }
 
@@ -884,6 +1011,7 @@ func validateDeleteRequest(req *pbs.DeleteCredentialRequest) error {
 		globals.UsernamePasswordCredentialPreviousPrefix,
 		globals.SshPrivateKeyCredentialPrefix,
 		globals.JsonCredentialPrefix,
+		globals.TlsCertificateCredentialPrefix,
 	)
 }
 
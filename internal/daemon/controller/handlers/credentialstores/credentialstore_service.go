@@ -54,6 +54,9 @@ var (
 		action.Read,
 		action.Update,
 		action.Delete,
+		action.AddTags,
+		action.SetTags,
+		action.RemoveTags,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -157,6 +160,19 @@ func (s Service) ListCredentialStores(ctx context.Context, req *pbs.ListCredenti
 	if err != nil {
 		return nil, err
 	}
+	ids := make([]string, 0, len(csl))
+	for _, item := range csl {
+		ids = append(ids, item.GetPublicId())
+	}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	tags, err := iamRepo.ListResourceTags(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
 	finalItems := make([]*pb.CredentialStore, 0, len(csl))
 	res := perms.Resource{
 		Type: resource.CredentialStore,
@@ -164,6 +180,9 @@ func (s Service) ListCredentialStores(ctx context.Context, req *pbs.ListCredenti
 	for _, item := range csl {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetProjectId()
+		res.ParentScopeId = scopeInfoMap[item.GetProjectId()].GetParentScopeId()
+		res.Name = item.GetName()
+		res.Tags = tags[item.GetPublicId()]
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
 			continue
@@ -178,6 +197,9 @@ func (s Service) ListCredentialStores(ctx context.Context, req *pbs.ListCredenti
 		if outputFields.Has(globals.AuthorizedActionsField) {
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
+		if outputFields.Has(globals.ResourceTagsField) {
+			outputOpts = append(outputOpts, handlers.WithTags(tags[item.GetPublicId()]))
+		}
 		if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 			collectionActions, err := calculateAuthorizedCollectionActions(ctx, authResults, item.GetPublicId())
 			if err != nil {
@@ -231,6 +253,13 @@ func (s Service) GetCredentialStore(ctx context.Context, req *pbs.GetCredentialS
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, cs.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		tags, err := s.lookupTags(ctx, cs.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
 	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 		collectionActions, err := calculateAuthorizedCollectionActions(ctx, authResults, cs.GetPublicId())
 		if err != nil {
@@ -324,6 +353,13 @@ func (s Service) UpdateCredentialStore(ctx context.Context, req *pbs.UpdateCrede
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, cs.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		tags, err := s.lookupTags(ctx, cs.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
 	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 		collectionActions, err := calculateAuthorizedCollectionActions(ctx, authResults, cs.GetPublicId())
 		if err != nil {
@@ -356,6 +392,141 @@ func (s Service) DeleteCredentialStore(ctx context.Context, req *pbs.DeleteCrede
 	return nil, nil
 }
 
+// AddCredentialStoreTags implements the interface pbs.CredentialStoreServiceServer.
+func (s Service) AddCredentialStoreTags(ctx context.Context, req *pbs.AddCredentialStoreTagsRequest) (*pbs.AddCredentialStoreTagsResponse, error) {
+	const op = "credentialstores.(Service).AddCredentialStoreTags"
+
+	if err := validateAddTagsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.AddTags)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	tags, err := s.addTagsInRepo(ctx, authResults, req.GetVersion(), handlers.TagsFromProto(req.GetTags()))
+	if err != nil {
+		return nil, err
+	}
+	cs, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 4)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, cs.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
+
+	item, err := toProto(ctx, cs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.AddCredentialStoreTagsResponse{Item: item}, nil
+}
+
+// SetCredentialStoreTags implements the interface pbs.CredentialStoreServiceServer.
+func (s Service) SetCredentialStoreTags(ctx context.Context, req *pbs.SetCredentialStoreTagsRequest) (*pbs.SetCredentialStoreTagsResponse, error) {
+	const op = "credentialstores.(Service).SetCredentialStoreTags"
+
+	if err := validateSetTagsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetTags)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	tags, err := s.setTagsInRepo(ctx, authResults, req.GetVersion(), handlers.TagsFromProto(req.GetTags()))
+	if err != nil {
+		return nil, err
+	}
+	cs, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 4)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, cs.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
+
+	item, err := toProto(ctx, cs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.SetCredentialStoreTagsResponse{Item: item}, nil
+}
+
+// RemoveCredentialStoreTags implements the interface pbs.CredentialStoreServiceServer.
+func (s Service) RemoveCredentialStoreTags(ctx context.Context, req *pbs.RemoveCredentialStoreTagsRequest) (*pbs.RemoveCredentialStoreTagsResponse, error) {
+	const op = "credentialstores.(Service).RemoveCredentialStoreTags"
+
+	if err := validateRemoveTagsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RemoveTags)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	tags, err := s.removeTagsInRepo(ctx, authResults, req.GetVersion(), handlers.TagsFromProto(req.GetTags()))
+	if err != nil {
+		return nil, err
+	}
+	cs, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 4)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, cs.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
+
+	item, err := toProto(ctx, cs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RemoveCredentialStoreTagsResponse{Item: item}, nil
+}
+
 func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]credential.Store, error) {
 	const op = "credentialstores.(Service).listFromRepo"
 
@@ -545,6 +716,80 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
+func (s Service) lookupTags(ctx context.Context, storeId string) (map[string][]string, error) {
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	tags, err := iamRepo.ListResourceTags(ctx, []string{storeId})
+	if err != nil {
+		return nil, err
+	}
+	return tags[storeId], nil
+}
+
+// checkTagsVersion ensures tags are only changed against the version of the
+// credential store that the caller provided. Changing tags does not increment
+// the credential store's version.
+func checkTagsVersion(ctx context.Context, authResults auth.VerifyResults, version uint32) (credential.Store, error) {
+	const op = "credentialstores.checkTagsVersion"
+	cs, ok := authResults.RoundTripValue.(credential.Store)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no credential store found in auth results")
+	}
+	if cs.GetVersion() != version {
+		return nil, handlers.NotFoundErrorf("Credential Store %q doesn't exist or incorrect version provided.", cs.GetPublicId())
+	}
+	return cs, nil
+}
+
+func (s Service) addTagsInRepo(ctx context.Context, authResults auth.VerifyResults, version uint32, tags map[string][]string) (map[string][]string, error) {
+	cs, err := checkTagsVersion(ctx, authResults, version)
+	if err != nil {
+		return nil, err
+	}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := iamRepo.AddResourceTags(ctx, cs.GetPublicId(), tags)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add credential store tags in repo: %v.", err)
+	}
+	return out, nil
+}
+
+func (s Service) setTagsInRepo(ctx context.Context, authResults auth.VerifyResults, version uint32, tags map[string][]string) (map[string][]string, error) {
+	cs, err := checkTagsVersion(ctx, authResults, version)
+	if err != nil {
+		return nil, err
+	}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := iamRepo.SetResourceTags(ctx, cs.GetPublicId(), tags)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set credential store tags in repo: %v.", err)
+	}
+	return out, nil
+}
+
+func (s Service) removeTagsInRepo(ctx context.Context, authResults auth.VerifyResults, version uint32, tags map[string][]string) (map[string][]string, error) {
+	cs, err := checkTagsVersion(ctx, authResults, version)
+	if err != nil {
+		return nil, err
+	}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := iamRepo.DeleteResourceTags(ctx, cs.GetPublicId(), tags); err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove credential store tags in repo: %v.", err)
+	}
+	return s.lookupTags(ctx, cs.GetPublicId())
+}
+
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
 	res := auth.VerifyResults{}
 	iamRepo, err := s.iamRepoFn()
@@ -564,6 +809,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	}
 
 	var parentId string
+	var rt credential.Store
 	opts := []auth.Option{auth.WithType(resource.CredentialStore), auth.WithAction(a)}
 	switch a {
 	case action.List, action.Create:
@@ -590,6 +836,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 				return res
 			}
 			parentId = cs.GetProjectId()
+			rt = cs
 
 		case static.Subtype:
 			var err error
@@ -603,11 +850,23 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 				return res
 			}
 			parentId = cs.GetProjectId()
+			rt = cs
+		}
+		if rt == nil {
+			res.Error = handlers.NotFoundError()
+			return res
 		}
-		opts = append(opts, auth.WithId(id))
+		tags, err := iamRepo.ListResourceTags(ctx, []string{id})
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		opts = append(opts, auth.WithId(id), auth.WithName(rt.GetName()), auth.WithTags(tags[id]))
 	}
 	opts = append(opts, auth.WithScopeId(parentId))
-	return auth.Verify(ctx, opts...)
+	ret := auth.Verify(ctx, opts...)
+	ret.RoundTripValue = rt
+	return ret
 }
 
 func toProto(ctx context.Context, in credential.Store, opt ...handlers.Option) (*pb.CredentialStore, error) {
@@ -653,6 +912,13 @@ func toProto(ctx context.Context, in credential.Store, opt ...handlers.Option) (
 	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 		out.AuthorizedCollectionActions = opts.WithAuthorizedCollectionActions
 	}
+	if outputFields.Has(globals.ResourceTagsField) && len(opts.WithTags) > 0 {
+		var err error
+		out.ResourceTags, err = handlers.TagsToProto(opts.WithTags)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to convert tags"))
+		}
+	}
 	if outputFields.Has(globals.AttributesField) {
 		switch subtypes.SubtypeFromId(domain, in.GetPublicId()) {
 		case vault.Subtype:
@@ -897,6 +1163,51 @@ func validateListRequest(req *pbs.ListCredentialStoresRequest) error {
 	return nil
 }
 
+func validateAddTagsRequest(req *pbs.AddCredentialStoreTagsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.VaultCredentialStorePrefix, globals.StaticCredentialStorePrefix, globals.StaticCredentialStorePreviousPrefix) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	handlers.ValidateTags(badFields, req.GetTags(), false)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateSetTagsRequest(req *pbs.SetCredentialStoreTagsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.VaultCredentialStorePrefix, globals.StaticCredentialStorePrefix, globals.StaticCredentialStorePreviousPrefix) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	handlers.ValidateTags(badFields, req.GetTags(), true)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateRemoveTagsRequest(req *pbs.RemoveCredentialStoreTagsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.VaultCredentialStorePrefix, globals.StaticCredentialStorePrefix, globals.StaticCredentialStorePreviousPrefix) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	handlers.ValidateTags(badFields, req.GetTags(), false)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
 func calculateAuthorizedCollectionActions(ctx context.Context, authResults auth.VerifyResults, id string) (map[string]*structpb.ListValue, error) {
 	var collectionActions map[string]*structpb.ListValue
 	var err error
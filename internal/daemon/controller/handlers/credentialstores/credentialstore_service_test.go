@@ -40,7 +40,7 @@ import (
 )
 
 var (
-	testAuthorizedActions                = []string{"no-op", "read", "update", "delete"}
+	testAuthorizedActions                = []string{"no-op", "read", "update", "delete", "add-tags", "set-tags", "remove-tags"}
 	testAuthorizedVaultCollectionActions = map[string]*structpb.ListValue{
 		"credential-libraries": {
 			Values: []*structpb.Value{
@@ -205,6 +205,14 @@ func backendErrorToApiError(inErr error) *ApiError {
 		return InvalidArgumentErrorf(genericUniquenessMsg, nil)
 	case errors.IsConflictError(inErr):
 		return ConflictErrorf(inErr.Error())
+	case errors.Match(errors.T(errors.QuotaExceeded), inErr):
+		return &ApiError{
+			Status: http.StatusTooManyRequests,
+			Inner: &pb.Error{
+				Kind:    codes.ResourceExhausted.String(),
+				Message: inErr.Error(),
+			},
+		}
 	}
 
 	var statusCode int32 = http.StatusInternalServerError
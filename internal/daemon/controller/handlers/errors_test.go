@@ -197,6 +197,17 @@ func TestApiErrorHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Domain error quota exceeded",
+			err:  errors.E(ctx, errors.WithCode(errors.QuotaExceeded), errors.WithMsg("test msg")),
+			expected: ApiError{
+				Status: http.StatusTooManyRequests,
+				Inner: &pb.Error{
+					Kind:    "ResourceExhausted",
+					Message: "test msg: state violation: error #126",
+				},
+			},
+		},
 		{
 			name: "Wrapped domain error",
 			err:  errors.E(ctx, errors.WithCode(errors.InvalidAddress), errors.WithMsg("test msg"), errors.WithWrap(errors.E(ctx, errors.WithCode(errors.NotNull), errors.WithMsg("inner msg")))),
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/roles"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
@@ -40,6 +41,7 @@ var (
 		action.AddMembers,
 		action.SetMembers,
 		action.RemoveMembers,
+		action.ListHistory,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -123,6 +125,7 @@ func (s Service) ListGroups(ctx context.Context, req *pbs.ListGroupsRequest) (*p
 	for _, item := range gl {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetScopeId()
+		res.ParentScopeId = scopeInfoMap[item.GetScopeId()].GetParentScopeId()
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
 			continue
@@ -394,6 +397,31 @@ func (s Service) RemoveGroupMembers(ctx context.Context, req *pbs.RemoveGroupMem
 	return &pbs.RemoveGroupMembersResponse{Item: item}, nil
 }
 
+// ListGroupHistory implements the interface pbs.GroupServiceServer.
+func (s Service) ListGroupHistory(ctx context.Context, req *pbs.ListGroupHistoryRequest) (*pbs.ListGroupHistoryResponse, error) {
+	const op = "groups.(Service).ListGroupHistory"
+	if err := validateListHistoryRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ListHistory)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	history, err := repo.ListHistory(ctx, req.GetId(), roles.HistoryOptions(req)...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	items, err := roles.HistoryToProto(history)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return &pbs.ListGroupHistoryResponse{Items: items}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*iam.Group, []*iam.GroupMember, error) {
 	const op = "groups.(Service).getFromRepo"
 	repo, err := s.repoFn()
@@ -657,6 +685,12 @@ func toProto(ctx context.Context, in *iam.Group, members []*iam.GroupMember, opt
 //   - The path passed in is correctly formatted
 //   - All required parameters are set
 //   - There are no conflicting parameters provided
+func validateListHistoryRequest(req *pbs.ListGroupHistoryRequest) error {
+	return handlers.ValidateGetRequest(func() map[string]string {
+		return roles.ValidateHistoryRequest(req)
+	}, req, globals.GroupPrefix)
+}
+
 func validateGetRequest(req *pbs.GetGroupRequest) error {
 	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, globals.GroupPrefix)
 }
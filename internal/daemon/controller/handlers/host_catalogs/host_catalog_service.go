@@ -48,6 +48,9 @@ var (
 		action.Read,
 		action.Update,
 		action.Delete,
+		action.AddTags,
+		action.SetTags,
+		action.RemoveTags,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -153,6 +156,19 @@ func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogs
 	if err != nil {
 		return nil, err
 	}
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.GetPublicId())
+	}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	tags, err := iamRepo.ListResourceTags(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
 	finalItems := make([]*pb.HostCatalog, 0, len(items))
 	res := perms.Resource{
 		Type: resource.HostCatalog,
@@ -160,6 +176,9 @@ func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogs
 	for _, item := range items {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetProjectId()
+		res.ParentScopeId = scopeInfoMap[item.GetProjectId()].GetParentScopeId()
+		res.Name = item.GetName()
+		res.Tags = tags[item.GetPublicId()]
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
 			continue
@@ -174,6 +193,9 @@ func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogs
 		if outputFields.Has(globals.AuthorizedActionsField) {
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
+		if outputFields.Has(globals.ResourceTagsField) {
+			outputOpts = append(outputOpts, handlers.WithTags(tags[item.GetPublicId()]))
+		}
 		if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 			var subtype subtypes.Subtype
 			switch item.(type) {
@@ -244,6 +266,13 @@ func (s Service) GetHostCatalog(ctx context.Context, req *pbs.GetHostCatalogRequ
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hc.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		tags, err := s.lookupTags(ctx, hc.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
 	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 		var subtype subtypes.Subtype
 		switch hc.(type) {
@@ -364,6 +393,13 @@ func (s Service) UpdateHostCatalog(ctx context.Context, req *pbs.UpdateHostCatal
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hc.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		tags, err := s.lookupTags(ctx, hc.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
 	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 		var subtype subtypes.Subtype
 		switch hc.(type) {
@@ -404,6 +440,147 @@ func (s Service) DeleteHostCatalog(ctx context.Context, req *pbs.DeleteHostCatal
 	return nil, nil
 }
 
+// AddHostCatalogTags implements the interface pbs.HostCatalogServiceServer.
+func (s Service) AddHostCatalogTags(ctx context.Context, req *pbs.AddHostCatalogTagsRequest) (*pbs.AddHostCatalogTagsResponse, error) {
+	const op = "host_catalogs.(Service).AddHostCatalogTags"
+
+	if err := validateAddTagsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.AddTags)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	tags, err := s.addTagsInRepo(ctx, authResults, req.GetVersion(), handlers.TagsFromProto(req.GetTags()))
+	if err != nil {
+		return nil, err
+	}
+	hc, plg, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 4)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if plg != nil {
+		outputOpts = append(outputOpts, handlers.WithPlugin(plg))
+	}
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hc.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
+	item, err := toProto(ctx, hc, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.AddHostCatalogTagsResponse{Item: item}, nil
+}
+
+// SetHostCatalogTags implements the interface pbs.HostCatalogServiceServer.
+func (s Service) SetHostCatalogTags(ctx context.Context, req *pbs.SetHostCatalogTagsRequest) (*pbs.SetHostCatalogTagsResponse, error) {
+	const op = "host_catalogs.(Service).SetHostCatalogTags"
+
+	if err := validateSetTagsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetTags)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	tags, err := s.setTagsInRepo(ctx, authResults, req.GetVersion(), handlers.TagsFromProto(req.GetTags()))
+	if err != nil {
+		return nil, err
+	}
+	hc, plg, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 4)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if plg != nil {
+		outputOpts = append(outputOpts, handlers.WithPlugin(plg))
+	}
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hc.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
+	item, err := toProto(ctx, hc, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.SetHostCatalogTagsResponse{Item: item}, nil
+}
+
+// RemoveHostCatalogTags implements the interface pbs.HostCatalogServiceServer.
+func (s Service) RemoveHostCatalogTags(ctx context.Context, req *pbs.RemoveHostCatalogTagsRequest) (*pbs.RemoveHostCatalogTagsResponse, error) {
+	const op = "host_catalogs.(Service).RemoveHostCatalogTags"
+
+	if err := validateRemoveTagsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RemoveTags)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	tags, err := s.removeTagsInRepo(ctx, authResults, req.GetVersion(), handlers.TagsFromProto(req.GetTags()))
+	if err != nil {
+		return nil, err
+	}
+	hc, plg, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 4)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if plg != nil {
+		outputOpts = append(outputOpts, handlers.WithPlugin(plg))
+	}
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hc.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
+	item, err := toProto(ctx, hc, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RemoveHostCatalogTagsResponse{Item: item}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (host.Catalog, *plugins.PluginInfo, error) {
 	var plg *plugins.PluginInfo
 	var cat host.Catalog
@@ -628,10 +805,85 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
+func (s Service) lookupTags(ctx context.Context, catalogId string) (map[string][]string, error) {
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	tags, err := iamRepo.ListResourceTags(ctx, []string{catalogId})
+	if err != nil {
+		return nil, err
+	}
+	return tags[catalogId], nil
+}
+
+// checkTagsVersion ensures tags are only changed against the version of the
+// host catalog that the caller provided. Changing tags does not increment the
+// host catalog's version.
+func checkTagsVersion(ctx context.Context, authResults auth.VerifyResults, version uint32) (host.Catalog, error) {
+	const op = "host_catalogs.checkTagsVersion"
+	hc, ok := authResults.RoundTripValue.(host.Catalog)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no host catalog found in auth results")
+	}
+	if hc.GetVersion() != version {
+		return nil, handlers.NotFoundErrorf("Host Catalog %q doesn't exist or incorrect version provided.", hc.GetPublicId())
+	}
+	return hc, nil
+}
+
+func (s Service) addTagsInRepo(ctx context.Context, authResults auth.VerifyResults, version uint32, tags map[string][]string) (map[string][]string, error) {
+	hc, err := checkTagsVersion(ctx, authResults, version)
+	if err != nil {
+		return nil, err
+	}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := iamRepo.AddResourceTags(ctx, hc.GetPublicId(), tags)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add host catalog tags in repo: %v.", err)
+	}
+	return out, nil
+}
+
+func (s Service) setTagsInRepo(ctx context.Context, authResults auth.VerifyResults, version uint32, tags map[string][]string) (map[string][]string, error) {
+	hc, err := checkTagsVersion(ctx, authResults, version)
+	if err != nil {
+		return nil, err
+	}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := iamRepo.SetResourceTags(ctx, hc.GetPublicId(), tags)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set host catalog tags in repo: %v.", err)
+	}
+	return out, nil
+}
+
+func (s Service) removeTagsInRepo(ctx context.Context, authResults auth.VerifyResults, version uint32, tags map[string][]string) (map[string][]string, error) {
+	hc, err := checkTagsVersion(ctx, authResults, version)
+	if err != nil {
+		return nil, err
+	}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := iamRepo.DeleteResourceTags(ctx, hc.GetPublicId(), tags); err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove host catalog tags in repo: %v.", err)
+	}
+	return s.lookupTags(ctx, hc.GetPublicId())
+}
+
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
 	res := auth.VerifyResults{}
 
 	var parentId string
+	var rt host.Catalog
 	opts := []auth.Option{auth.WithType(resource.HostCatalog), auth.WithAction(a)}
 	switch a {
 	case action.List, action.Create:
@@ -668,7 +920,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 				return res
 			}
 			parentId = cat.GetProjectId()
-			opts = append(opts, auth.WithId(id))
+			rt = cat
 		case plugin.Subtype:
 			repo, err := s.pluginHostRepoFn()
 			if err != nil {
@@ -685,11 +937,28 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 				return res
 			}
 			parentId = cat.GetProjectId()
-			opts = append(opts, auth.WithId(id))
+			rt = cat
+		}
+		if rt == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		iamRepo, err := s.iamRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		tags, err := iamRepo.ListResourceTags(ctx, []string{id})
+		if err != nil {
+			res.Error = err
+			return res
 		}
+		opts = append(opts, auth.WithId(id), auth.WithName(rt.GetName()), auth.WithTags(tags[id]))
 	}
 	opts = append(opts, auth.WithScopeId(parentId))
-	return auth.Verify(ctx, opts...)
+	ret := auth.Verify(ctx, opts...)
+	ret.RoundTripValue = rt
+	return ret
 }
 
 func toPluginInfo(plg *hostplugin.Plugin) *plugins.PluginInfo {
@@ -753,6 +1022,13 @@ func toProto(ctx context.Context, in host.Catalog, opt ...handlers.Option) (*pb.
 	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 		out.AuthorizedCollectionActions = opts.WithAuthorizedCollectionActions
 	}
+	if outputFields.Has(globals.ResourceTagsField) && len(opts.WithTags) > 0 {
+		var err error
+		out.ResourceTags, err = handlers.TagsToProto(opts.WithTags)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to convert tags"))
+		}
+	}
 	switch h := in.(type) {
 	case *plugin.HostCatalog:
 		if outputFields.Has(globals.PluginIdField) {
@@ -900,3 +1176,48 @@ func validateListRequest(req *pbs.ListHostCatalogsRequest) error {
 	}
 	return nil
 }
+
+func validateAddTagsRequest(req *pbs.AddHostCatalogTagsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.StaticHostCatalogPrefix, globals.PluginHostCatalogPrefix, globals.PluginHostCatalogPreviousPrefix) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	handlers.ValidateTags(badFields, req.GetTags(), false)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateSetTagsRequest(req *pbs.SetHostCatalogTagsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.StaticHostCatalogPrefix, globals.PluginHostCatalogPrefix, globals.PluginHostCatalogPreviousPrefix) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	handlers.ValidateTags(badFields, req.GetTags(), true)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateRemoveTagsRequest(req *pbs.RemoveHostCatalogTagsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.StaticHostCatalogPrefix, globals.PluginHostCatalogPrefix, globals.PluginHostCatalogPreviousPrefix) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	handlers.ValidateTags(badFields, req.GetTags(), false)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
@@ -75,7 +75,7 @@ var authorizedCollectionActions = map[subtypes.Subtype]map[string]*structpb.List
 	},
 }
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-tags", "set-tags", "remove-tags"}
 
 func TestGet_Static(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
@@ -126,9 +126,10 @@ func (s Service) ListHostSetsWithOptions(ctx context.Context, req *pbs.ListHostS
 	finalItems := make([]*pb.HostSet, 0, len(hl))
 
 	res := perms.Resource{
-		ScopeId: authResults.Scope.Id,
-		Type:    resource.HostSet,
-		Pin:     req.GetHostCatalogId(),
+		ScopeId:       authResults.Scope.Id,
+		Type:          resource.HostSet,
+		Pin:           req.GetHostCatalogId(),
+		ParentScopeId: authResults.Scope.ParentScopeId,
 	}
 	for _, item := range hl {
 		res.Id = item.GetPublicId()
@@ -111,9 +111,10 @@ func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs
 	finalItems := make([]*pb.Host, 0, len(hl))
 
 	res := perms.Resource{
-		ScopeId: authResults.Scope.Id,
-		Type:    resource.Host,
-		Pin:     req.GetHostCatalogId(),
+		ScopeId:       authResults.Scope.Id,
+		Type:          resource.Host,
+		Pin:           req.GetHostCatalogId(),
+		ParentScopeId: authResults.Scope.ParentScopeId,
 	}
 	for _, item := range hl {
 		res.Id = item.GetPublicId()
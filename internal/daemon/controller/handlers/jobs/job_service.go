@@ -0,0 +1,381 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/scheduler/job"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/jobs"
+	"google.golang.org/grpc/codes"
+)
+
+// runningStatus is the status of a job run that is in progress.
+const runningStatus = "running"
+
+var (
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Run,
+	}
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.ActionSet{
+		action.List,
+	}
+)
+
+// Service handles request as described by the pbs.JobServiceServer interface.
+type Service struct {
+	pbs.UnsafeJobServiceServer
+
+	repoFn    common.JobRepoFactory
+	scheduler *scheduler.Scheduler
+}
+
+var _ pbs.JobServiceServer = (*Service)(nil)
+
+// NewService returns a job service which handles job related requests to
+// boundary.
+func NewService(repo common.JobRepoFactory, sche *scheduler.Scheduler) (Service, error) {
+	const op = "jobs.NewService"
+	if repo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing job repository")
+	}
+	if sche == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing scheduler")
+	}
+	return Service{repoFn: repo, scheduler: sche}, nil
+}
+
+// ListJobs implements the interface pbs.JobServiceServer.
+func (s Service) ListJobs(ctx context.Context, req *pbs.ListJobsRequest) (*pbs.ListJobsResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	jl, runs, err := s.listFromRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(jl) == 0 {
+		return &pbs.ListJobsResponse{}, nil
+	}
+
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.Job, 0, len(jl))
+	res := perms.Resource{
+		Type:    resource.Job,
+		ScopeId: scope.Global.String(),
+	}
+	for _, item := range jl {
+		res.Id = item.GetName()
+		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetName(), IdActions, auth.WithResource(&res)).Strings()
+		if len(authorizedActions) == 0 {
+			continue
+		}
+
+		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+		if outputFields.Has(globals.ScopeField) {
+			outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+		}
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+		}
+
+		item, err := toProto(ctx, item, runs[item.GetName()], outputOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if filter.Match(item) {
+			finalItems = append(finalItems, item)
+		}
+	}
+	return &pbs.ListJobsResponse{Items: finalItems}, nil
+}
+
+// GetJob implements the interface pbs.JobServiceServer.
+func (s Service) GetJob(ctx context.Context, req *pbs.GetJobRequest) (*pbs.GetJobResponse, error) {
+	const op = "jobs.(Service).GetJob"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	j, runs, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, j.GetName(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, j, runs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.GetJobResponse{Item: item}, nil
+}
+
+// RunJob implements the interface pbs.JobServiceServer. The job is made due
+// to run immediately, and is run by whichever controller's scheduler picks it
+// up first.
+func (s Service) RunJob(ctx context.Context, req *pbs.RunJobRequest) (*pbs.RunJobResponse, error) {
+	const op = "jobs.(Service).RunJob"
+
+	if err := validateRunRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Run)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	j, runs, err := s.runInRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, j.GetName(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, j, runs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RunJobResponse{Item: item}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, name string) (*job.Job, []*job.Run, error) {
+	const op = "jobs.(Service).getFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	j, err := repo.LookupJob(ctx, name)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	if j == nil {
+		return nil, nil, handlers.NotFoundErrorf("Job %q doesn't exist.", name)
+	}
+	runs, err := repo.ListLatestRuns(ctx, job.WithName(name))
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	return j, runs, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context) ([]*job.Job, map[string][]*job.Run, error) {
+	const op = "jobs.(Service).listFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	jl, err := repo.ListJobs(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	rl, err := repo.ListLatestRuns(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	runs := make(map[string][]*job.Run, len(jl))
+	for _, r := range rl {
+		runs[r.GetJobName()] = append(runs[r.GetJobName()], r)
+	}
+	return jl, runs, nil
+}
+
+func (s Service) runInRepo(ctx context.Context, name string) (*job.Job, []*job.Run, error) {
+	const op = "jobs.(Service).runInRepo"
+	_, runs, err := s.getFromRepo(ctx, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, r := range runs {
+		if r.GetStatus() == runningStatus {
+			return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Job %q is already running.", name)
+		}
+	}
+	if err := s.scheduler.UpdateJobNextRunInAtLeast(ctx, name, 0, scheduler.WithRunNow(true)); err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to schedule job to run"))
+	}
+	return s.getFromRepo(ctx, name)
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+	repo, err := s.repoFn()
+	if err != nil {
+		res.Error = err
+		return res
+	}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.Job), auth.WithAction(a)}
+	switch a {
+	case action.List:
+		parentId = id
+	default:
+		j, err := repo.LookupJob(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if j == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		// Jobs are registered by the controllers in the global scope.
+		parentId = scope.Global.String()
+		opts = append(opts, auth.WithId(id))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(ctx context.Context, in *job.Job, runs []*job.Run, opt ...handlers.Option) (*pb.Job, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building job proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.Job{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetName()
+	}
+	if outputFields.Has(globals.DescriptionField) {
+		out.Description = in.GetDescription()
+	}
+	if outputFields.Has(globals.NextScheduledRunField) {
+		out.NextScheduledRun = in.GetNextScheduledRun().GetTimestamp()
+	}
+	if outputFields.Has(globals.ConsecutiveFailuresField) {
+		out.ConsecutiveFailures = in.GetConsecutiveFailures()
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	for _, r := range runs {
+		switch {
+		case r.GetStatus() == runningStatus && outputFields.Has(globals.CurrentRunField):
+			out.CurrentRun = runToProto(r)
+		case r.GetStatus() != runningStatus && outputFields.Has(globals.LastRunField):
+			out.LastRun = runToProto(r)
+		}
+	}
+	return &out, nil
+}
+
+func runToProto(in *job.Run) *pb.JobRun {
+	return &pb.JobRun{
+		Status:         in.GetStatus(),
+		ControllerId:   in.GetControllerId(),
+		StartTime:      in.GetCreateTime().GetTimestamp(),
+		UpdatedTime:    in.GetUpdateTime().GetTimestamp(),
+		EndTime:        in.GetEndTime().GetTimestamp(),
+		CompletedCount: in.GetCompletedCount(),
+		TotalCount:     in.GetTotalCount(),
+		ErrorMessage:   in.GetErrorMessage(),
+	}
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetJobRequest) error {
+	badFields := map[string]string{}
+	if req.GetId() == "" {
+		badFields["id"] = "This field is required."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateRunRequest(req *pbs.RunJobRequest) error {
+	badFields := map[string]string{}
+	if req.GetId() == "" {
+		badFields["id"] = "This field is required."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateListRequest(req *pbs.ListJobsRequest) error {
+	badFields := map[string]string{}
+	if req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "Must be 'global' when listing."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/jobs"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/scheduler/job"
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/server/store"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/jobs"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+var testAuthorizedActions = []string{"no-op", "read", "run"}
+
+func testService(t *testing.T) (jobs.Service, *job.Repository, *server.Repository, context.Context) {
+	t.Helper()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	kmsCache := kms.TestKms(t, conn, wrap)
+	jobRepo, err := job.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	jobRepoFn := func() (*job.Repository, error) {
+		return jobRepo, nil
+	}
+	serversRepo, err := server.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	s, err := jobs.NewService(jobRepoFn, scheduler.TestScheduler(t, conn, wrap))
+	require.NoError(t, err)
+	return s, jobRepo, serversRepo, auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String())
+}
+
+func TestNewService(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	sche := scheduler.TestScheduler(t, conn, wrap)
+	jobRepoFn := func() (*job.Repository, error) {
+		return nil, nil
+	}
+
+	_, err := jobs.NewService(nil, sche)
+	assert.Error(t, err)
+	_, err = jobs.NewService(jobRepoFn, nil)
+	assert.Error(t, err)
+	_, err = jobs.NewService(jobRepoFn, sche)
+	assert.NoError(t, err)
+}
+
+func TestGet(t *testing.T) {
+	s, jobRepo, _, ctx := testService(t)
+	j, err := jobRepo.UpsertJob(context.Background(), "test-job", "test description")
+	require.NoError(t, err)
+
+	want := &pb.Job{
+		Id:                "test-job",
+		Scope:             &scopes.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"},
+		Description:       "test description",
+		NextScheduledRun:  j.GetNextScheduledRun().GetTimestamp(),
+		AuthorizedActions: testAuthorizedActions,
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.GetJobRequest
+		res  *pbs.GetJobResponse
+		err  error
+	}{
+		{
+			name: "Get an existing job",
+			req:  &pbs.GetJobRequest{Id: "test-job"},
+			res:  &pbs.GetJobResponse{Item: want},
+		},
+		{
+			name: "Get a non existent job",
+			req:  &pbs.GetJobRequest{Id: "doesnt-exist"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Missing id",
+			req:  &pbs.GetJobRequest{},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.GetJob(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetJob(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()))
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	s, jobRepo, _, ctx := testService(t)
+	_, err := jobRepo.UpsertJob(context.Background(), "test-job-1", "description")
+	require.NoError(err)
+	_, err = jobRepo.UpsertJob(context.Background(), "test-job-2", "description")
+	require.NoError(err)
+
+	_, err = s.ListJobs(ctx, &pbs.ListJobsRequest{ScopeId: "o_1234567890"})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got %v", err)
+
+	got, err := s.ListJobs(ctx, &pbs.ListJobsRequest{ScopeId: scope.Global.String()})
+	require.NoError(err)
+	var ids []string
+	for _, item := range got.GetItems() {
+		ids = append(ids, item.GetId())
+		assert.Equal(testAuthorizedActions, item.GetAuthorizedActions())
+	}
+	assert.Subset(ids, []string{"test-job-1", "test-job-2"})
+
+	got, err = s.ListJobs(ctx, &pbs.ListJobsRequest{ScopeId: scope.Global.String(), Filter: `"/item/id"=="test-job-2"`})
+	require.NoError(err)
+	require.Len(got.GetItems(), 1)
+	assert.Equal("test-job-2", got.GetItems()[0].GetId())
+}
+
+func TestRun(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	s, jobRepo, serversRepo, ctx := testService(t)
+	_, err := jobRepo.UpsertJob(context.Background(), "test-job", "description")
+	require.NoError(err)
+
+	_, err = s.RunJob(ctx, &pbs.RunJobRequest{Id: "doesnt-exist"})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "got %v", err)
+
+	got, err := s.RunJob(ctx, &pbs.RunJobRequest{Id: "test-job"})
+	require.NoError(err)
+	assert.Equal("test-job", got.GetItem().GetId())
+	assert.Nil(got.GetItem().GetCurrentRun())
+
+	// Start a run of the job, which can't be run again until it ends.
+	_, err = serversRepo.UpsertController(context.Background(), &store.Controller{
+		PrivateId: "test-controller",
+		Address:   "127.0.0.1",
+	})
+	require.NoError(err)
+	runs, err := jobRepo.RunJobs(context.Background(), "test-controller")
+	require.NoError(err)
+	require.Len(runs, 1)
+
+	got2, err := s.GetJob(ctx, &pbs.GetJobRequest{Id: "test-job"})
+	require.NoError(err)
+	require.NotNil(got2.GetItem().GetCurrentRun())
+	assert.Equal("running", got2.GetItem().GetCurrentRun().GetStatus())
+	assert.Equal("test-controller", got2.GetItem().GetCurrentRun().GetControllerId())
+
+	_, err = s.RunJob(ctx, &pbs.RunJobRequest{Id: "test-job"})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.FailedPrecondition)), "got %v", err)
+
+	_, err = jobRepo.FailRun(context.Background(), runs[0].GetPrivateId(), 1, 2, job.WithErrorMessage("test error"))
+	require.NoError(err)
+	got, err = s.RunJob(ctx, &pbs.RunJobRequest{Id: "test-job"})
+	require.NoError(err)
+	require.NotNil(got.GetItem().GetLastRun())
+	assert.Equal("failed", got.GetItem().GetLastRun().GetStatus())
+	assert.Equal(uint32(1), got.GetItem().GetLastRun().GetCompletedCount())
+	assert.Equal(uint32(2), got.GetItem().GetLastRun().GetTotalCount())
+	assert.Equal("test error", got.GetItem().GetLastRun().GetErrorMessage())
+	assert.Equal(uint32(1), got.GetItem().GetConsecutiveFailures())
+}
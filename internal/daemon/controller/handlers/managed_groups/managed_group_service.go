@@ -123,9 +123,10 @@ func (s Service) ListManagedGroups(ctx context.Context, req *pbs.ListManagedGrou
 	finalItems := make([]*pb.ManagedGroup, 0, len(ul))
 
 	res := perms.Resource{
-		ScopeId: authResults.Scope.Id,
-		Type:    resource.ManagedGroup,
-		Pin:     req.GetAuthMethodId(),
+		ScopeId:       authResults.Scope.Id,
+		Type:          resource.ManagedGroup,
+		Pin:           req.GetAuthMethodId(),
+		ParentScopeId: authResults.Scope.ParentScopeId,
 	}
 	for _, mg := range ul {
 		res.Id = mg.GetPublicId()
@@ -87,4 +87,16 @@ func Test_GetOpts(t *testing.T) {
 		opts = GetOpts(WithHostSetIds(out))
 		require.Equal(out, opts.WithHostSetIds)
 	})
+	t.Run("WithTags", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		opts := GetOpts()
+		assert.Nil(opts.WithTags)
+
+		out := map[string][]string{"env": {"dev"}}
+
+		opts = GetOpts(WithTags(out))
+		require.Equal(out, opts.WithTags)
+	})
 }
@@ -36,6 +36,7 @@ type options struct {
 	WithManagedGroupIds             []string
 	WithMemberIds                   []string
 	WithHostSetIds                  []string
+	WithTags                        map[string][]string
 }
 
 func getDefaultOptions() options {
@@ -122,3 +123,11 @@ func WithHostSetIds(ids []string) Option {
 		o.WithHostSetIds = ids
 	}
 }
+
+// WithTags provides an option when creating responses to include the given
+// resource tags if allowed
+func WithTags(tags map[string][]string) Option {
+	return func(o *options) {
+		o.WithTags = tags
+	}
+}
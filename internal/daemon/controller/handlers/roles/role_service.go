@@ -6,6 +6,7 @@ package roles
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
@@ -21,9 +22,12 @@ import (
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/internal/util"
+	"github.com/hashicorp/boundary/internal/util/template"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/roles"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -43,6 +47,10 @@ var (
 		action.AddGrants,
 		action.SetGrants,
 		action.RemoveGrants,
+		action.AddGrantScopes,
+		action.SetGrantScopes,
+		action.RemoveGrantScopes,
+		action.ListHistory,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -50,6 +58,7 @@ var (
 	CollectionActions = action.ActionSet{
 		action.Create,
 		action.List,
+		action.Explain,
 	}
 )
 
@@ -126,6 +135,7 @@ func (s Service) ListRoles(ctx context.Context, req *pbs.ListRolesRequest) (*pbs
 	for _, item := range items {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetScopeId()
+		res.ParentScopeId = scopeInfoMap[item.GetScopeId()].GetParentScopeId()
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
 			continue
@@ -294,7 +304,14 @@ func (s Service) AddRolePrincipals(ctx context.Context, req *pbs.AddRolePrincipa
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.addPrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
+	var opts []iam.Option
+	if req.GetNotBefore() != nil {
+		opts = append(opts, iam.WithNotBefore(req.GetNotBefore().AsTime()))
+	}
+	if req.GetNotAfter() != nil {
+		opts = append(opts, iam.WithNotAfter(req.GetNotAfter().AsTime()))
+	}
+	r, prs, rgs, err := s.addPrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion(), opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -511,6 +528,198 @@ func (s Service) RemoveRoleGrants(ctx context.Context, req *pbs.RemoveRoleGrants
 	return &pbs.RemoveRoleGrantsResponse{Item: item}, nil
 }
 
+// AddRoleGrantScopes implements the interface pbs.RoleServiceServer.
+func (s Service) AddRoleGrantScopes(ctx context.Context, req *pbs.AddRoleGrantScopesRequest) (*pbs.AddRoleGrantScopesResponse, error) {
+	const op = "roles.(Service).AddRoleGrantScopes"
+
+	if err := validateAddRoleGrantScopesRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.AddGrantScopes)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	r, prs, rgs, err := s.addGrantScopesInRepo(ctx, req.GetId(), req.GetGrantScopeIds(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.AddRoleGrantScopesResponse{Item: item}, nil
+}
+
+// SetRoleGrantScopes implements the interface pbs.RoleServiceServer.
+func (s Service) SetRoleGrantScopes(ctx context.Context, req *pbs.SetRoleGrantScopesRequest) (*pbs.SetRoleGrantScopesResponse, error) {
+	const op = "roles.(Service).SetRoleGrantScopes"
+
+	if err := validateSetRoleGrantScopesRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetGrantScopes)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	r, prs, rgs, err := s.setGrantScopesInRepo(ctx, req.GetId(), req.GetGrantScopeIds(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.SetRoleGrantScopesResponse{Item: item}, nil
+}
+
+// RemoveRoleGrantScopes implements the interface pbs.RoleServiceServer.
+func (s Service) RemoveRoleGrantScopes(ctx context.Context, req *pbs.RemoveRoleGrantScopesRequest) (*pbs.RemoveRoleGrantScopesResponse, error) {
+	const op = "roles.(Service).RemoveRoleGrantScopes"
+
+	if err := validateRemoveRoleGrantScopesRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RemoveGrantScopes)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	r, prs, rgs, err := s.removeGrantScopesInRepo(ctx, req.GetId(), req.GetGrantScopeIds(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RemoveRoleGrantScopesResponse{Item: item}, nil
+}
+
+// ListRoleHistory implements the interface pbs.RoleServiceServer.
+func (s Service) ListRoleHistory(ctx context.Context, req *pbs.ListRoleHistoryRequest) (*pbs.ListRoleHistoryResponse, error) {
+	const op = "roles.(Service).ListRoleHistory"
+	if err := validateListHistoryRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ListHistory)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	history, err := repo.ListHistory(ctx, req.GetId(), HistoryOptions(req)...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	items, err := HistoryToProto(history)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return &pbs.ListRoleHistoryResponse{Items: items}, nil
+}
+
+// ExplainPermission implements the interface pbs.RoleServiceServer.
+func (s Service) ExplainPermission(ctx context.Context, req *pbs.ExplainPermissionRequest) (*pbs.ExplainPermissionResponse, error) {
+	if err := validateExplainPermissionRequest(req); err != nil {
+		return nil, err
+	}
+	res, found, err := s.explainResource(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// Nothing about the resource is returned before the request is
+	// authorized. A resource that doesn't exist is authorized against the
+	// global scope and then refused like one the caller may not explain, so
+	// the two can't be told apart.
+	authScopeId := res.ScopeId
+	if !found {
+		authScopeId = scope.Global.String()
+	}
+	authResults := s.authResult(ctx, authScopeId, action.Explain)
+	var self bool
+	if authResults.Error != nil {
+		// Authenticated users are always allowed to explain their own
+		// permissions on resources they can see; explaining those of anyone
+		// else requires the explain action.
+		if authResults.Error == handlers.ForbiddenError() &&
+			authResults.AuthenticationFinished &&
+			authResults.UserId != globals.AnonymousUserId &&
+			(req.GetPrincipalId() == "" || req.GetPrincipalId() == authResults.UserId) {
+			self = true
+		} else {
+			return nil, authResults.Error
+		}
+	}
+	if !found || (self && !canSee(ctx, authResults, res)) {
+		return nil, handlers.ForbiddenError()
+	}
+	principalId := req.GetPrincipalId()
+	if principalId == "" {
+		principalId = authResults.UserId
+	}
+
+	// The account used to authenticate is only known for the caller's own
+	// permissions
+	var templateData *template.Data
+	if principalId == authResults.UserId {
+		templateData = &authResults.UserData
+	}
+	item, err := s.explainInRepo(ctx, principalId, templateData, res, action.Map[req.GetAction()])
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ExplainPermissionResponse{Item: item}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -624,13 +833,13 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.Ro
 	return rl, nil
 }
 
-func (s Service) addPrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, error) {
+func (s Service) addPrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32, opt ...iam.Option) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, error) {
 	const op = "roles.(Service).addPrincpleInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	_, err = repo.AddPrincipalRoles(ctx, roleId, version, strutil.RemoveDuplicates(principalIds, false))
+	_, err = repo.AddPrincipalRoles(ctx, roleId, version, strutil.RemoveDuplicates(principalIds, false), opt...)
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
 		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add principals to role: %v.", err)
@@ -754,6 +963,285 @@ func (s Service) removeGrantsInRepo(ctx context.Context, roleId string, grants [
 	return out, pr, roleGrants, nil
 }
 
+func (s Service) addGrantScopesInRepo(ctx context.Context, roleId string, grantScopes []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, error) {
+	const op = "roles.(Service).addGrantScopesInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	_, err = repo.AddRoleGrantScopes(ctx, roleId, version, strutil.RemoveDuplicates(grantScopes, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add grant scopes to role: %v.", err)
+	}
+	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after adding grant scopes"))
+	}
+	if out == nil {
+		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after adding grant scopes to it.")
+	}
+	return out, pr, roleGrants, nil
+}
+
+func (s Service) setGrantScopesInRepo(ctx context.Context, roleId string, grantScopes []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, error) {
+	const op = "roles.(Service).setGrantScopesInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// If no grant scope was provided, we clear the grant scopes.
+	if grantScopes == nil {
+		grantScopes = []string{}
+	}
+	_, _, err = repo.SetRoleGrantScopes(ctx, roleId, version, strutil.RemoveDuplicates(grantScopes, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set grant scopes on role: %v.", err)
+	}
+	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after setting grant scopes"))
+	}
+	if out == nil {
+		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after setting grant scopes on it.")
+	}
+	return out, pr, roleGrants, nil
+}
+
+func (s Service) removeGrantScopesInRepo(ctx context.Context, roleId string, grantScopes []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, error) {
+	const op = "roles.(Service).removeGrantScopesInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	_, err = repo.DeleteRoleGrantScopes(ctx, roleId, version, strutil.RemoveDuplicates(grantScopes, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove grant scopes from role: %v", err)
+	}
+	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after removing grant scopes"))
+	}
+	if out == nil {
+		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after removing grant scopes from it.")
+	}
+	return out, pr, roleGrants, nil
+}
+
+// canSee reports whether the caller can read the resource, or list the
+// collection it describes.
+func canSee(ctx context.Context, authResults auth.VerifyResults, res perms.Resource) bool {
+	if res.Id == "" {
+		return len(authResults.FetchActionSetForType(ctx, res.Type, action.ActionSet{action.List}, auth.WithResource(&res))) > 0
+	}
+	return len(authResults.FetchActionSetForId(ctx, res.Id, action.ActionSet{action.Read}, auth.WithResource(&res))) > 0
+}
+
+// explainResource resolves the resource the request is evaluated against. If
+// the request has a type it describes the collection of that type within the
+// scope or parent resource identified by resource_id. It reports whether the
+// resource was found.
+func (s Service) explainResource(ctx context.Context, req *pbs.ExplainPermissionRequest) (perms.Resource, bool, error) {
+	const op = "roles.(Service).explainResource"
+	repo, err := s.repoFn()
+	if err != nil {
+		return perms.Resource{}, false, err
+	}
+	info, err := repo.LookupResourceInfo(ctx, req.GetResourceId())
+	if err != nil {
+		return perms.Resource{}, false, errors.Wrap(ctx, err, op)
+	}
+	if info == nil {
+		return perms.Resource{}, false, nil
+	}
+
+	if req.GetType() != "" {
+		res := perms.Resource{Type: resource.Map[req.GetType()]}
+		switch globals.ResourceTypeFromPrefix(req.GetResourceId()) {
+		case resource.Scope:
+			res.ScopeId = req.GetResourceId()
+			if info.ScopeId != req.GetResourceId() {
+				res.ParentScopeId = info.ScopeId
+			}
+		default:
+			res.ScopeId = info.ScopeId
+			res.ParentScopeId = info.ParentScopeId
+			res.Pin = req.GetResourceId()
+		}
+		return res, true, nil
+	}
+
+	res := perms.Resource{
+		Id:            req.GetResourceId(),
+		Type:          globals.ResourceTypeFromPrefix(req.GetResourceId()),
+		ScopeId:       info.ScopeId,
+		ParentScopeId: info.ParentScopeId,
+		Pin:           info.Pin,
+		Name:          info.Name,
+	}
+	if resource.Taggable(res.Type) {
+		tags, err := repo.ListResourceTags(ctx, []string{res.Id})
+		if err != nil {
+			return perms.Resource{}, false, errors.Wrap(ctx, err, op)
+		}
+		res.Tags = tags[res.Id]
+	}
+	return res, true, nil
+}
+
+// explainInRepo explains the action on the resource for the principal. Grant
+// templates are evaluated with templateData if it is set. Otherwise they are
+// evaluated with the data of the principal if it is a user, and placeholders
+// for the account used to authenticate, which is not known, do not match.
+func (s Service) explainInRepo(ctx context.Context, principalId string, templateData *template.Data, res perms.Resource, act action.Type) (*pb.Explanation, error) {
+	const op = "roles.(Service).explainInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	grantTuples, err := repo.GrantsForPrincipal(ctx, principalId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	var userId string
+	if globals.ResourceTypeFromPrefix(principalId) == resource.User {
+		userId = principalId
+	}
+	if templateData == nil {
+		templateData = &template.Data{}
+	}
+	if userId != "" && templateData.User.Id == nil {
+		u, _, err := repo.LookupUser(ctx, userId)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if u != nil {
+			templateData.User = template.User{
+				Id:       util.Pointer(u.GetPublicId()),
+				Name:     util.Pointer(u.GetName()),
+				FullName: util.Pointer(u.FullName),
+				Email:    util.Pointer(u.Email),
+			}
+		}
+	}
+	parsedGrants := make([]perms.Grant, 0, len(grantTuples))
+	for _, pair := range grantTuples {
+		permsOpts := []perms.Option{
+			perms.WithUserId(userId),
+			perms.WithRoleId(pair.RoleId),
+			perms.WithTemplateData(*templateData),
+			perms.WithSkipFinalValidation(true),
+		}
+		scopeId := pair.GrantScopeId
+		switch pair.GrantScopeId {
+		case globals.GrantScopeChildren, globals.GrantScopeDescendants:
+			scopeId = pair.RoleScopeId
+			permsOpts = append(permsOpts, perms.WithGrantScope(pair.GrantScopeId))
+		}
+		parsed, err := perms.Parse(scopeId, pair.Grant, permsOpts...)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed to parse grant %#v", pair.Grant)))
+		}
+		parsedGrants = append(parsedGrants, parsed)
+	}
+
+	exp := perms.NewACL(parsedGrants...).Explain(res, act, userId)
+	out := &pb.Explanation{
+		PrincipalId:   principalId,
+		ResourceId:    res.Id,
+		Type:          res.Type.String(),
+		ScopeId:       res.ScopeId,
+		Action:        act.String(),
+		Authorized:    exp.Authorized,
+		RequiredGrant: exp.RequiredGrant,
+	}
+	if out.ResourceId == "" {
+		out.ResourceId = res.Pin
+	}
+	if out.ResourceId == "" {
+		out.ResourceId = res.ScopeId
+	}
+	for _, g := range exp.AllowedBy {
+		out.AllowedBy = append(out.AllowedBy, toExplanationGrant(g))
+	}
+	if exp.DeniedBy != nil {
+		out.DeniedBy = toExplanationGrant(*exp.DeniedBy)
+	}
+	return out, nil
+}
+
+func toExplanationGrant(g perms.Grant) *pb.ExplanationGrant {
+	return &pb.ExplanationGrant{
+		RoleId:       g.RoleId(),
+		GrantScopeId: g.GrantScopeId(),
+		Grant:        g.CanonicalString(),
+	}
+}
+
+// HistoryToProto converts the history of a role, group or user to its API
+// representation.
+func HistoryToProto(history []*iam.HistoryEntry) ([]*pb.HistoryEntry, error) {
+	out := make([]*pb.HistoryEntry, 0, len(history))
+	for _, e := range history {
+		entry := &pb.HistoryEntry{
+			Id:          e.Id,
+			CreatedTime: timestamppb.New(e.CreateTime),
+			ActorId:     e.ActorId,
+		}
+		for _, c := range e.Changes {
+			change := &pb.HistoryChange{
+				Operation:     c.Operation,
+				Type:          c.Type,
+				UpdatedFields: c.UpdatedFields,
+			}
+			var err error
+			if change.Values, err = handlers.ProtoToStruct(c.Values); err != nil {
+				return nil, err
+			}
+			if c.PreviousValues != nil {
+				if change.PreviousValues, err = handlers.ProtoToStruct(c.PreviousValues); err != nil {
+					return nil, err
+				}
+			}
+			entry.Changes = append(entry.Changes, change)
+		}
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+// HistoryRequest is a request to list the history of a role, group or user.
+type HistoryRequest interface {
+	GetAfterId() uint32
+	GetStartTime() *timestamppb.Timestamp
+	GetEndTime() *timestamppb.Timestamp
+}
+
+// ValidateHistoryRequest returns the invalid fields of the paging and time
+// range of a request to list a history.
+func ValidateHistoryRequest(req HistoryRequest) map[string]string {
+	badFields := map[string]string{}
+	if req.GetStartTime() != nil && req.GetEndTime() != nil && !req.GetStartTime().AsTime().Before(req.GetEndTime().AsTime()) {
+		badFields["end_time"] = "Must be after start_time."
+	}
+	return badFields
+}
+
+// HistoryOptions returns the repository options for the paging and time range
+// of a request to list a history.
+func HistoryOptions(req HistoryRequest) []iam.Option {
+	opts := []iam.Option{iam.WithAfterHistoryId(req.GetAfterId())}
+	if req.GetStartTime() != nil {
+		opts = append(opts, iam.WithNotBefore(req.GetStartTime().AsTime()))
+	}
+	if req.GetEndTime() != nil {
+		opts = append(opts, iam.WithNotAfter(req.GetEndTime().AsTime()))
+	}
+	return opts
+}
+
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
 	res := auth.VerifyResults{}
 	repo, err := s.repoFn()
@@ -765,7 +1253,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	var parentId string
 	opts := []auth.Option{auth.WithType(resource.Role), auth.WithAction(a)}
 	switch a {
-	case action.List, action.Create:
+	case action.List, action.Create, action.Explain:
 		parentId = id
 		scp, err := repo.LookupScope(ctx, parentId)
 		if err != nil {
@@ -831,17 +1319,26 @@ func toProto(ctx context.Context, in *iam.Role, principals []*iam.PrincipalRole,
 	if outputFields.Has(globals.GrantScopeIdField) && in.GetGrantScopeId() != "" {
 		out.GrantScopeId = &wrapperspb.StringValue{Value: in.GetGrantScopeId()}
 	}
+	if outputFields.Has(globals.GrantScopeIdsField) {
+		for _, gs := range in.GrantScopes {
+			out.GrantScopeIds = append(out.GrantScopeIds, gs.GetScopeIdOrSpecial())
+		}
+	}
 	if outputFields.Has(globals.PrincipalIdsField) {
 		for _, p := range principals {
 			out.PrincipalIds = append(out.PrincipalIds, p.GetPrincipalId())
 		}
 	}
 	if outputFields.Has(globals.PrincipalsField) {
+		now := time.Now()
 		for _, p := range principals {
 			principal := &pb.Principal{
-				Id:      p.GetPrincipalId(),
-				Type:    p.GetType(),
-				ScopeId: p.GetPrincipalScopeId(),
+				Id:        p.GetPrincipalId(),
+				Type:      p.GetType(),
+				ScopeId:   p.GetPrincipalScopeId(),
+				NotBefore: p.GetNotBefore().GetTimestamp(),
+				NotAfter:  p.GetNotAfter().GetTimestamp(),
+				Status:    p.Status(now),
 			}
 			out.Principals = append(out.Principals, principal)
 		}
@@ -864,14 +1361,18 @@ func toProto(ctx context.Context, in *iam.Role, principals []*iam.PrincipalRole,
 				})
 			} else {
 				_, actions := parsed.Actions()
+				grantJson := &pb.GrantJson{
+					Id:      parsed.Id(),
+					Type:    parsed.Type().String(),
+					Actions: actions,
+				}
+				if parsed.Deny() {
+					grantJson.Effect = "deny"
+				}
 				out.Grants = append(out.Grants, &pb.Grant{
 					Raw:       g.GetRawGrant(),
 					Canonical: g.GetCanonicalGrant(),
-					Json: &pb.GrantJson{
-						Id:      parsed.Id(),
-						Type:    parsed.Type().String(),
-						Actions: actions,
-					},
+					Json:      grantJson,
 				})
 			}
 		}
@@ -889,6 +1390,12 @@ func validateGetRequest(req *pbs.GetRoleRequest) error {
 	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, globals.RolePrefix)
 }
 
+func validateListHistoryRequest(req *pbs.ListRoleHistoryRequest) error {
+	return handlers.ValidateGetRequest(func() map[string]string {
+		return ValidateHistoryRequest(req)
+	}, req, globals.RolePrefix)
+}
+
 func validateCreateRequest(req *pbs.CreateRoleRequest) error {
 	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
@@ -973,8 +1480,9 @@ func validateAddRolePrincipalsRequest(req *pbs.AddRolePrincipalsRequest) error {
 	for _, id := range req.GetPrincipalIds() {
 		if !handlers.ValidId(handlers.Id(id), globals.GroupPrefix) &&
 			!handlers.ValidId(handlers.Id(id), globals.UserPrefix) &&
-			!handlers.ValidId(handlers.Id(id), globals.OidcManagedGroupPrefix) {
-			badFields["principal_ids"] = "Must only have valid user, group, and/or managed group ids."
+			!handlers.ValidId(handlers.Id(id), globals.OidcManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), globals.ServiceAccountPrefix) {
+			badFields["principal_ids"] = "Must only have valid user, group, managed group, and/or service account ids."
 			break
 		}
 		if id == globals.RecoveryUserId {
@@ -982,6 +1490,14 @@ func validateAddRolePrincipalsRequest(req *pbs.AddRolePrincipalsRequest) error {
 			break
 		}
 	}
+	if req.GetNotAfter() != nil {
+		switch {
+		case req.GetNotBefore() != nil && !req.GetNotBefore().AsTime().Before(req.GetNotAfter().AsTime()):
+			badFields["not_after"] = "Must be after not_before."
+		case !req.GetNotAfter().AsTime().After(time.Now()):
+			badFields["not_after"] = "Must be in the future."
+		}
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
 	}
@@ -999,8 +1515,9 @@ func validateSetRolePrincipalsRequest(req *pbs.SetRolePrincipalsRequest) error {
 	for _, id := range req.GetPrincipalIds() {
 		if !handlers.ValidId(handlers.Id(id), globals.GroupPrefix) &&
 			!handlers.ValidId(handlers.Id(id), globals.UserPrefix) &&
-			!handlers.ValidId(handlers.Id(id), globals.OidcManagedGroupPrefix) {
-			badFields["principal_ids"] = "Must only have valid user, group, and/or managed group ids."
+			!handlers.ValidId(handlers.Id(id), globals.OidcManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), globals.ServiceAccountPrefix) {
+			badFields["principal_ids"] = "Must only have valid user, group, managed group, and/or service account ids."
 			break
 		}
 		if id == globals.RecoveryUserId {
@@ -1028,8 +1545,9 @@ func validateRemoveRolePrincipalsRequest(req *pbs.RemoveRolePrincipalsRequest) e
 	for _, id := range req.GetPrincipalIds() {
 		if !handlers.ValidId(handlers.Id(id), globals.GroupPrefix) &&
 			!handlers.ValidId(handlers.Id(id), globals.UserPrefix) &&
-			!handlers.ValidId(handlers.Id(id), globals.OidcManagedGroupPrefix) {
-			badFields["principal_ids"] = "Must only have valid user, group, and/or managed group ids."
+			!handlers.ValidId(handlers.Id(id), globals.OidcManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), globals.ServiceAccountPrefix) {
+			badFields["principal_ids"] = "Must only have valid user, group, managed group, and/or service account ids."
 			break
 		}
 	}
@@ -1131,3 +1649,108 @@ func validateRemoveRoleGrantsRequest(req *pbs.RemoveRoleGrantsRequest) error {
 	}
 	return nil
 }
+
+// validateGrantScopeIds checks that each of the given grant scopes is either a
+// special value or a well formed scope ID. Whether a grant scope is valid for
+// the role is checked when it is written.
+func validateGrantScopeIds(grantScopeIds []string, badFields map[string]string) {
+	for _, v := range grantScopeIds {
+		switch {
+		case v == globals.GrantScopeThis,
+			v == globals.GrantScopeChildren,
+			v == globals.GrantScopeDescendants,
+			v == scope.Global.String(),
+			handlers.ValidId(handlers.Id(v), scope.Org.Prefix()),
+			handlers.ValidId(handlers.Id(v), scope.Project.Prefix()):
+		default:
+			badFields["grant_scope_ids"] = fmt.Sprintf("Improperly formatted grant scope %q.", v)
+			return
+		}
+	}
+}
+
+func validateAddRoleGrantScopesRequest(req *pbs.AddRoleGrantScopesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.RolePrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(req.GetGrantScopeIds()) == 0 {
+		badFields["grant_scope_ids"] = "Must be non-empty."
+	}
+	validateGrantScopeIds(req.GetGrantScopeIds(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateSetRoleGrantScopesRequest(req *pbs.SetRoleGrantScopesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.RolePrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	validateGrantScopeIds(req.GetGrantScopeIds(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateRemoveRoleGrantScopesRequest(req *pbs.RemoveRoleGrantScopesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.RolePrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(req.GetGrantScopeIds()) == 0 {
+		badFields["grant_scope_ids"] = "Must be non-empty."
+	}
+	validateGrantScopeIds(req.GetGrantScopeIds(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateExplainPermissionRequest(req *pbs.ExplainPermissionRequest) error {
+	badFields := map[string]string{}
+	if req.GetPrincipalId() != "" &&
+		!handlers.ValidId(handlers.Id(req.GetPrincipalId()), globals.UserPrefix) &&
+		!handlers.ValidId(handlers.Id(req.GetPrincipalId()), globals.GroupPrefix) &&
+		!handlers.ValidId(handlers.Id(req.GetPrincipalId()), globals.OidcManagedGroupPrefix) &&
+		!handlers.ValidId(handlers.Id(req.GetPrincipalId()), globals.LdapManagedGroupPrefix) &&
+		!handlers.ValidId(handlers.Id(req.GetPrincipalId()), globals.ServiceAccountPrefix) &&
+		req.GetPrincipalId() != globals.AnonymousUserId &&
+		req.GetPrincipalId() != globals.AnyAuthenticatedUserId {
+		badFields["principal_id"] = "Must be the ID of a user, group, managed group or service account."
+	}
+	if req.GetResourceId() == "" {
+		badFields["resource_id"] = "Required field."
+	} else if globals.ResourceTypeFromPrefix(req.GetResourceId()) == resource.Unknown {
+		badFields["resource_id"] = "Unknown resource type for this identifier."
+	}
+	if req.GetType() != "" {
+		switch resource.Map[req.GetType()] {
+		case resource.Unknown, resource.All:
+			badFields["type"] = "Unknown resource type."
+		}
+	}
+	switch act := action.Map[req.GetAction()]; {
+	case req.GetAction() == "":
+		badFields["action"] = "Required field."
+	case act == action.Unknown, act == action.All:
+		badFields["action"] = "Unknown action."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
@@ -9,18 +9,23 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/roles"
 	"github.com/hashicorp/boundary/internal/db"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	authpb "github.com/hashicorp/boundary/internal/gen/controller/auth"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/roles"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
@@ -37,7 +42,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-principals", "set-principals", "remove-principals", "add-grants", "set-grants", "remove-grants"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-principals", "set-principals", "remove-principals", "add-grants", "set-grants", "remove-grants", "add-grant-scopes", "set-grant-scopes", "remove-grant-scopes", "list-history"}
 
 func createDefaultRolesAndRepo(t *testing.T) (*iam.Role, *iam.Role, func() (*iam.Repository, error)) {
 	t.Helper()
@@ -92,6 +97,7 @@ func TestGet(t *testing.T) {
 		Name:              &wrapperspb.StringValue{Value: or.GetName()},
 		Description:       &wrapperspb.StringValue{Value: or.GetDescription()},
 		GrantScopeId:      &wrapperspb.StringValue{Value: pr.GetGrantScopeId()},
+		GrantScopeIds:     []string{pr.GetGrantScopeId()},
 		CreatedTime:       or.CreateTime.GetTimestamp(),
 		UpdatedTime:       or.UpdateTime.GetTimestamp(),
 		Version:           or.GetVersion(),
@@ -105,6 +111,7 @@ func TestGet(t *testing.T) {
 		Name:              &wrapperspb.StringValue{Value: pr.GetName()},
 		Description:       &wrapperspb.StringValue{Value: pr.GetDescription()},
 		GrantScopeId:      &wrapperspb.StringValue{Value: pr.GetGrantScopeId()},
+		GrantScopeIds:     []string{globals.GrantScopeThis},
 		CreatedTime:       pr.CreateTime.GetTimestamp(),
 		UpdatedTime:       pr.UpdateTime.GetTimestamp(),
 		Version:           pr.GetVersion(),
@@ -447,6 +454,7 @@ func TestCreate(t *testing.T) {
 					Name:              &wrapperspb.StringValue{Value: "name"},
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					GrantScopeId:      &wrapperspb.StringValue{Value: defaultProjRole.ScopeId},
+					GrantScopeIds:     []string{defaultProjRole.ScopeId},
 					Version:           1,
 					AuthorizedActions: testAuthorizedActions,
 				},
@@ -468,6 +476,7 @@ func TestCreate(t *testing.T) {
 					Name:              &wrapperspb.StringValue{Value: "name"},
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					GrantScopeId:      &wrapperspb.StringValue{Value: defaultProjRole.ScopeId},
+					GrantScopeIds:     []string{defaultProjRole.ScopeId},
 					Version:           1,
 					AuthorizedActions: testAuthorizedActions,
 				},
@@ -490,6 +499,7 @@ func TestCreate(t *testing.T) {
 					Name:              &wrapperspb.StringValue{Value: "name"},
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					GrantScopeId:      &wrapperspb.StringValue{Value: defaultProjRole.ScopeId},
+					GrantScopeIds:     []string{globals.GrantScopeThis},
 					Version:           1,
 					AuthorizedActions: testAuthorizedActions,
 				},
@@ -605,6 +615,7 @@ func TestUpdate(t *testing.T) {
 		Id:      u.GetPublicId(),
 		Type:    iam.UserRoleType.String(),
 		ScopeId: u.GetScopeId(),
+		Status:  "active",
 	}
 
 	var orVersion uint32 = 1
@@ -663,6 +674,7 @@ func TestUpdate(t *testing.T) {
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					CreatedTime:       or.GetCreateTime().GetTimestamp(),
 					GrantScopeId:      &wrapperspb.StringValue{Value: or.GetScopeId()},
+					GrantScopeIds:     []string{globals.GrantScopeThis},
 					GrantStrings:      []string{grant.GetRaw()},
 					Grants:            []*pb.Grant{grant},
 					PrincipalIds:      []string{u.GetPublicId()},
@@ -692,6 +704,7 @@ func TestUpdate(t *testing.T) {
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					CreatedTime:       or.GetCreateTime().GetTimestamp(),
 					GrantScopeId:      &wrapperspb.StringValue{Value: or.GetScopeId()},
+					GrantScopeIds:     []string{globals.GrantScopeThis},
 					GrantStrings:      []string{grant.GetRaw()},
 					Grants:            []*pb.Grant{grant},
 					PrincipalIds:      []string{u.GetPublicId()},
@@ -722,6 +735,7 @@ func TestUpdate(t *testing.T) {
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					CreatedTime:       pr.GetCreateTime().GetTimestamp(),
 					GrantScopeId:      &wrapperspb.StringValue{Value: pr.GetScopeId()},
+					GrantScopeIds:     []string{globals.GrantScopeThis},
 					GrantStrings:      []string{grant.GetRaw()},
 					Grants:            []*pb.Grant{grant},
 					PrincipalIds:      []string{u.GetPublicId()},
@@ -752,6 +766,7 @@ func TestUpdate(t *testing.T) {
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					CreatedTime:       pr.GetCreateTime().GetTimestamp(),
 					GrantScopeId:      &wrapperspb.StringValue{Value: pr.GetScopeId()},
+					GrantScopeIds:     []string{globals.GrantScopeThis},
 					GrantStrings:      []string{grant.GetRaw()},
 					Grants:            []*pb.Grant{grant},
 					PrincipalIds:      []string{u.GetPublicId()},
@@ -813,6 +828,7 @@ func TestUpdate(t *testing.T) {
 					Description:       &wrapperspb.StringValue{Value: "default"},
 					CreatedTime:       or.GetCreateTime().GetTimestamp(),
 					GrantScopeId:      &wrapperspb.StringValue{Value: or.GetScopeId()},
+					GrantScopeIds:     []string{globals.GrantScopeThis},
 					GrantStrings:      []string{grant.GetRaw()},
 					Grants:            []*pb.Grant{grant},
 					PrincipalIds:      []string{u.GetPublicId()},
@@ -842,6 +858,7 @@ func TestUpdate(t *testing.T) {
 					Description:       &wrapperspb.StringValue{Value: "default"},
 					CreatedTime:       or.GetCreateTime().GetTimestamp(),
 					GrantScopeId:      &wrapperspb.StringValue{Value: or.GetScopeId()},
+					GrantScopeIds:     []string{globals.GrantScopeThis},
 					GrantStrings:      []string{grant.GetRaw()},
 					Grants:            []*pb.Grant{grant},
 					PrincipalIds:      []string{u.GetPublicId()},
@@ -871,6 +888,7 @@ func TestUpdate(t *testing.T) {
 					Description:       &wrapperspb.StringValue{Value: "notignored"},
 					CreatedTime:       or.GetCreateTime().GetTimestamp(),
 					GrantScopeId:      &wrapperspb.StringValue{Value: or.GetScopeId()},
+					GrantScopeIds:     []string{globals.GrantScopeThis},
 					GrantStrings:      []string{grant.GetRaw()},
 					Grants:            []*pb.Grant{grant},
 					PrincipalIds:      []string{u.GetPublicId()},
@@ -1193,6 +1211,27 @@ func TestAddPrincipal(t *testing.T) {
 			},
 			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
 		},
+		{
+			name: "Not After In The Past",
+			req: &pbs.AddRolePrincipalsRequest{
+				Id:           role.GetPublicId(),
+				Version:      role.GetVersion(),
+				PrincipalIds: []string{users[0].GetPublicId()},
+				NotAfter:     timestamppb.New(time.Now().Add(-time.Hour)),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Not After Before Not Before",
+			req: &pbs.AddRolePrincipalsRequest{
+				Id:           role.GetPublicId(),
+				Version:      role.GetVersion(),
+				PrincipalIds: []string{users[0].GetPublicId()},
+				NotBefore:    timestamppb.New(time.Now().Add(2 * time.Hour)),
+				NotAfter:     timestamppb.New(time.Now().Add(time.Hour)),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 	}
 	for _, tc := range failCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1206,6 +1245,57 @@ func TestAddPrincipal(t *testing.T) {
 	}
 }
 
+func TestAddPrincipal_timeBounds(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	o, _ := iam.TestScopes(t, iamRepo)
+	s, err := roles.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new role service.")
+
+	activeUser := iam.TestUser(t, iamRepo, o.GetPublicId())
+	pendingUser := iam.TestUser(t, iamRepo, o.GetPublicId())
+	role := iam.TestRole(t, conn, o.GetPublicId())
+
+	notAfter := timestamppb.New(time.Now().Add(time.Hour).Truncate(time.Microsecond))
+	got, err := s.AddRolePrincipals(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), &pbs.AddRolePrincipalsRequest{
+		Id:           role.GetPublicId(),
+		Version:      role.GetVersion(),
+		PrincipalIds: []string{activeUser.GetPublicId()},
+		NotAfter:     notAfter,
+	})
+	require.NoError(t, err)
+	notBefore := timestamppb.New(time.Now().Add(time.Minute).Truncate(time.Microsecond))
+	got, err = s.AddRolePrincipals(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), &pbs.AddRolePrincipalsRequest{
+		Id:           role.GetPublicId(),
+		Version:      got.GetItem().GetVersion(),
+		PrincipalIds: []string{pendingUser.GetPublicId()},
+		NotBefore:    notBefore,
+	})
+	require.NoError(t, err)
+
+	principals := make(map[string]*pb.Principal)
+	for _, p := range got.GetItem().GetPrincipals() {
+		principals[p.GetId()] = p
+	}
+	require.Len(t, principals, 2)
+
+	active := principals[activeUser.GetPublicId()]
+	require.NotNil(t, active)
+	assert.Equal(t, "active", active.GetStatus())
+	assert.Nil(t, active.GetNotBefore())
+	assert.True(t, notAfter.AsTime().Equal(active.GetNotAfter().AsTime()))
+
+	pending := principals[pendingUser.GetPublicId()]
+	require.NotNil(t, pending)
+	assert.Equal(t, "pending", pending.GetStatus())
+	assert.True(t, notBefore.AsTime().Equal(pending.GetNotBefore().AsTime()))
+	assert.Nil(t, pending.GetNotAfter())
+}
+
 func TestSetPrincipal(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrap := db.TestWrapper(t)
@@ -1687,6 +1777,12 @@ func TestAddGrants(t *testing.T) {
 			add:      []string{"id=*;type=*;actions=delete"},
 			wantErr:  true,
 		},
+		{
+			name:     "Add deny grant on role with grant",
+			existing: []string{"id=*;type=*;actions=*"},
+			add:      []string{"effect=deny;id=*;type=target;actions=delete"},
+			result:   []string{"id=*;type=*;actions=*", "effect=deny;id=*;type=target;actions=delete"},
+		},
 		{
 			name:            "Check deprecation",
 			existing:        []string{"id=u_foo;actions=read", "id=*;type=*;actions=delete"},
@@ -2056,3 +2152,212 @@ func TestRemoveGrants(t *testing.T) {
 		})
 	}
 }
+
+func TestExplainPermission(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := roles.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new role service.")
+
+	o, p := iam.TestScopes(t, iamRepo, iam.WithSkipAdminRoleCreation(true), iam.WithSkipDefaultRoleCreation(true))
+	u := iam.TestUser(t, iamRepo, o.GetPublicId())
+	r := iam.TestRole(t, conn, p.GetPublicId())
+	iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=role;actions=read")
+	iam.TestUserRole(t, conn, r.GetPublicId(), u.GetPublicId())
+
+	cases := []struct {
+		name string
+		req  *pbs.ExplainPermissionRequest
+		res  *pb.Explanation
+		err  error
+	}{
+		{
+			name: "authorized",
+			req:  &pbs.ExplainPermissionRequest{PrincipalId: u.GetPublicId(), ResourceId: r.GetPublicId(), Action: "read"},
+			res: &pb.Explanation{
+				PrincipalId: u.GetPublicId(),
+				ResourceId:  r.GetPublicId(),
+				Type:        "role",
+				ScopeId:     p.GetPublicId(),
+				Action:      "read",
+				Authorized:  true,
+				AllowedBy: []*pb.ExplanationGrant{
+					{RoleId: r.GetPublicId(), GrantScopeId: p.GetPublicId(), Grant: "id=*;type=role;actions=read"},
+				},
+			},
+		},
+		{
+			name: "not authorized",
+			req:  &pbs.ExplainPermissionRequest{PrincipalId: u.GetPublicId(), ResourceId: r.GetPublicId(), Action: "delete"},
+			res: &pb.Explanation{
+				PrincipalId:   u.GetPublicId(),
+				ResourceId:    r.GetPublicId(),
+				Type:          "role",
+				ScopeId:       p.GetPublicId(),
+				Action:        "delete",
+				RequiredGrant: fmt.Sprintf("id=%s;actions=delete", r.GetPublicId()),
+			},
+		},
+		{
+			name: "collection",
+			req:  &pbs.ExplainPermissionRequest{PrincipalId: u.GetPublicId(), ResourceId: p.GetPublicId(), Type: "role", Action: "create"},
+			res: &pb.Explanation{
+				PrincipalId:   u.GetPublicId(),
+				ResourceId:    p.GetPublicId(),
+				Type:          "role",
+				ScopeId:       p.GetPublicId(),
+				Action:        "create",
+				RequiredGrant: "type=role;actions=create",
+			},
+		},
+		{
+			name: "unknown action",
+			req:  &pbs.ExplainPermissionRequest{PrincipalId: u.GetPublicId(), ResourceId: r.GetPublicId(), Action: "fly"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "missing resource",
+			req:  &pbs.ExplainPermissionRequest{PrincipalId: u.GetPublicId(), Action: "read"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "bad principal",
+			req:  &pbs.ExplainPermissionRequest{PrincipalId: r.GetPublicId(), ResourceId: r.GetPublicId(), Action: "read"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "resource not found",
+			req:  &pbs.ExplainPermissionRequest{PrincipalId: u.GetPublicId(), ResourceId: "r_doesntexist", Action: "read"},
+			err:  handlers.ForbiddenError(),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.ExplainPermission(auth.DisabledAuthTestContext(repoFn, p.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ExplainPermission(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(tc.res, got.GetItem(), protocmp.Transform()))
+		})
+	}
+}
+
+func TestExplainPermission_Self(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrap)
+	rw := db.New(conn)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, kmsCache)
+	}
+	s, err := roles.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new role service.")
+
+	o, p := iam.TestScopes(t, iamRepo, iam.WithSkipAdminRoleCreation(true), iam.WithSkipDefaultRoleCreation(true))
+	_, hiddenProj := iam.TestScopes(t, iamRepo, iam.WithSkipAdminRoleCreation(true), iam.WithSkipDefaultRoleCreation(true))
+	at := authtoken.TestAuthToken(t, conn, kmsCache, o.GetPublicId())
+	r := iam.TestRole(t, conn, p.GetPublicId())
+	iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=role;actions=read")
+	iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	hidden := iam.TestRole(t, conn, hiddenProj.GetPublicId())
+
+	requestInfo := authpb.RequestInfo{
+		TokenFormat: uint32(auth.AuthTokenTypeBearer),
+		PublicId:    at.GetPublicId(),
+		Token:       at.GetToken(),
+	}
+	requestContext := context.WithValue(context.Background(), requests.ContextRequestInformationKey, &requests.RequestContext{})
+	ctx := auth.NewVerifierContext(requestContext, repoFn, tokenRepoFn, serversRepoFn, kmsCache, &requestInfo)
+
+	got, err := s.ExplainPermission(ctx, &pbs.ExplainPermissionRequest{ResourceId: r.GetPublicId(), Action: "read"})
+	require.NoError(t, err)
+	assert.Equal(t, at.GetIamUserId(), got.GetItem().GetPrincipalId())
+	assert.True(t, got.GetItem().GetAuthorized())
+
+	// A resource the user can't see and one that doesn't exist are refused
+	// with the same error
+	_, hiddenErr := s.ExplainPermission(ctx, &pbs.ExplainPermissionRequest{ResourceId: hidden.GetPublicId(), Action: "read"})
+	require.Error(t, hiddenErr)
+	assert.True(t, errors.Is(hiddenErr, handlers.ForbiddenError()), "got error %v", hiddenErr)
+	_, missingErr := s.ExplainPermission(ctx, &pbs.ExplainPermissionRequest{ResourceId: "r_doesntexist", Action: "read"})
+	require.Error(t, missingErr)
+	assert.Equal(t, hiddenErr.Error(), missingErr.Error())
+}
+
+func TestListRoleHistory(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := roles.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new role service.")
+
+	_, p := iam.TestScopes(t, iamRepo)
+	r, err := iam.NewRole(p.GetPublicId())
+	require.NoError(t, err)
+	r, err = iamRepo.CreateRole(ctx, r)
+	require.NoError(t, err)
+	_, err = iamRepo.AddRoleGrants(ctx, r.GetPublicId(), r.GetVersion(), []string{"ids=*;type=*;actions=read"})
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListRoleHistory(auth.DisabledAuthTestContext(repoFn, p.GetPublicId()), &pbs.ListRoleHistoryRequest{Id: r.GetPublicId()})
+		require.NoError(err)
+		require.Len(got.GetItems(), 2)
+		require.Len(got.GetItems()[0].GetChanges(), 1)
+		assert.Equal("create", got.GetItems()[0].GetChanges()[0].GetOperation())
+		assert.Equal("role", got.GetItems()[0].GetChanges()[0].GetType())
+		assert.Equal(r.GetPublicId(), got.GetItems()[0].GetChanges()[0].GetValues().AsMap()["public_id"])
+		require.Len(got.GetItems()[1].GetChanges(), 1)
+		assert.Equal("role-grant", got.GetItems()[1].GetChanges()[0].GetType())
+		assert.Equal("ids=*;type=*;actions=read", got.GetItems()[1].GetChanges()[0].GetValues().AsMap()["canonical_grant"])
+	})
+
+	t.Run("after-last", func(t *testing.T) {
+		all, err := s.ListRoleHistory(auth.DisabledAuthTestContext(repoFn, p.GetPublicId()), &pbs.ListRoleHistoryRequest{Id: r.GetPublicId()})
+		require.NoError(t, err)
+		require.NotEmpty(t, all.GetItems())
+		got, err := s.ListRoleHistory(auth.DisabledAuthTestContext(repoFn, p.GetPublicId()), &pbs.ListRoleHistoryRequest{
+			Id:      r.GetPublicId(),
+			AfterId: all.GetItems()[len(all.GetItems())-1].GetId(),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, got.GetItems())
+	})
+
+	t.Run("bad-id", func(t *testing.T) {
+		_, err := s.ListRoleHistory(auth.DisabledAuthTestContext(repoFn, p.GetPublicId()), &pbs.ListRoleHistoryRequest{Id: "g_1234567890"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+	})
+
+	t.Run("bad-time-range", func(t *testing.T) {
+		now := time.Now()
+		_, err := s.ListRoleHistory(auth.DisabledAuthTestContext(repoFn, p.GetPublicId()), &pbs.ListRoleHistoryRequest{
+			Id:        r.GetPublicId(),
+			StartTime: timestamppb.New(now),
+			EndTime:   timestamppb.New(now.Add(-time.Hour)),
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+	})
+}
@@ -6,8 +6,10 @@ package scopes
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
@@ -19,7 +21,9 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/credentialstores"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/groups"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/host_catalogs"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/jobs"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/roles"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/serviceaccounts"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/users"
@@ -52,6 +56,7 @@ var (
 		action.Read,
 		action.Update,
 		action.Delete,
+		action.SetQuotas,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -63,26 +68,31 @@ var (
 		action.RotateScopeKeys,
 		action.ListScopeKeyVersionDestructionJobs,
 		action.DestroyScopeKeyVersion,
+		action.ReadScopeKeyRotationPolicy,
+		action.SetScopeKeyRotationPolicy,
 	}
 
 	scopeCollectionTypeMapMap = map[string]map[resource.Type]action.ActionSet{
 		scope.Global.String(): {
-			resource.AuthMethod: authmethods.CollectionActions,
-			resource.AuthToken:  authtokens.CollectionActions,
-			resource.Group:      groups.CollectionActions,
-			resource.Role:       roles.CollectionActions,
-			resource.Scope:      CollectionActions,
-			resource.User:       users.CollectionActions,
-			resource.Worker:     workers.CollectionActions,
+			resource.AuthMethod:     authmethods.CollectionActions,
+			resource.AuthToken:      authtokens.CollectionActions,
+			resource.Group:          groups.CollectionActions,
+			resource.Job:            jobs.CollectionActions,
+			resource.Role:           roles.CollectionActions,
+			resource.Scope:          CollectionActions,
+			resource.ServiceAccount: serviceaccounts.CollectionActions,
+			resource.User:           users.CollectionActions,
+			resource.Worker:         workers.CollectionActions,
 		},
 
 		scope.Org.String(): {
-			resource.AuthMethod: authmethods.CollectionActions,
-			resource.AuthToken:  authtokens.CollectionActions,
-			resource.Group:      groups.CollectionActions,
-			resource.Role:       roles.CollectionActions,
-			resource.Scope:      CollectionActions,
-			resource.User:       users.CollectionActions,
+			resource.AuthMethod:     authmethods.CollectionActions,
+			resource.AuthToken:      authtokens.CollectionActions,
+			resource.Group:          groups.CollectionActions,
+			resource.Role:           roles.CollectionActions,
+			resource.Scope:          CollectionActions,
+			resource.ServiceAccount: serviceaccounts.CollectionActions,
+			resource.User:           users.CollectionActions,
 		},
 
 		scope.Project.String(): {
@@ -177,6 +187,7 @@ func (s Service) ListScopes(ctx context.Context, req *pbs.ListScopesRequest) (*p
 	for _, item := range pl {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetParentId()
+		res.ParentScopeId = scopeInfoMap[item.GetParentId()].GetParentScopeId()
 
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
@@ -259,6 +270,13 @@ func (s Service) GetScope(ctx context.Context, req *pbs.GetScopeRequest) (*pbs.G
 	if err != nil {
 		return nil, err
 	}
+	if outputFields.Has(globals.QuotasField) && p.GetType() != scope.Global.String() {
+		quotas, err := s.listQuotasFromRepo(ctx, p.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		item.Quotas = quotasToProto(quotas)
+	}
 
 	return &pbs.GetScopeResponse{Item: item}, nil
 }
@@ -369,6 +387,54 @@ func (s Service) DeleteScope(ctx context.Context, req *pbs.DeleteScopeRequest) (
 	return nil, nil
 }
 
+// SetScopeQuotas implements the interface pbs.ScopeServiceServer.
+func (s Service) SetScopeQuotas(ctx context.Context, req *pbs.SetScopeQuotasRequest) (*pbs.SetScopeQuotasResponse, error) {
+	const op = "scopes.(Service).SetScopeQuotas"
+
+	if err := validateSetQuotasRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetQuotas)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	p, quotas, err := s.setQuotasInRepo(ctx, req.GetId(), req.GetVersion(), req.GetQuotas())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, p.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
+		collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, scopeCollectionTypeMapMap[p.Type], p.GetPublicId(), "")
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
+	}
+
+	item, err := ToProto(ctx, p, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if outputFields.Has(globals.QuotasField) {
+		item.Quotas = quotasToProto(quotas)
+	}
+
+	return &pbs.SetScopeQuotasResponse{Item: item}, nil
+}
+
 // ListKeys implements the interface pbs.ScopeServiceServer.
 func (s Service) ListKeys(ctx context.Context, req *pbs.ListKeysRequest) (*pbs.ListKeysResponse, error) {
 	if req.GetId() == "" {
@@ -397,6 +463,7 @@ func (s Service) ListKeys(ctx context.Context, req *pbs.ListKeysRequest) (*pbs.L
 	for _, item := range items {
 		res.Id = item.Id
 		res.ScopeId = item.Scope
+		res.ParentScopeId = authResults.Scope.ParentScopeId
 
 		outputFields := authResults.FetchOutputFields(res, action.ListScopeKeys).SelfOrDefaults(authResults.UserId)
 		outputOpts := make([]handlers.Option, 0, 3)
@@ -458,6 +525,7 @@ func (s Service) ListKeyVersionDestructionJobs(ctx context.Context, req *pbs.Lis
 	for _, job := range jobs {
 		res.Id = job.ScopeId
 		res.ScopeId = job.ScopeId
+		res.ParentScopeId = authResults.Scope.ParentScopeId
 
 		outputFields := authResults.FetchOutputFields(res, action.ListScopeKeyVersionDestructionJobs).SelfOrDefaults(authResults.UserId)
 		outputOpts := make([]handlers.Option, 0, 3)
@@ -505,6 +573,76 @@ func (s Service) DestroyKeyVersion(ctx context.Context, req *pbs.DestroyKeyVersi
 	}, nil
 }
 
+// GetKeyRotationPolicy implements the interface pbs.ScopeServiceServer.
+func (s Service) GetKeyRotationPolicy(ctx context.Context, req *pbs.GetKeyRotationPolicyRequest) (*pbs.GetKeyRotationPolicyResponse, error) {
+	if req.GetScopeId() == "" {
+		req.ScopeId = scope.Global.String()
+	}
+	if err := validateGetKeyRotationPolicyRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.ReadScopeKeyRotationPolicy)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	policy, err := s.kmsRepo.LookupKeyRotationPolicy(ctx, req.GetScopeId())
+	if err != nil {
+		return nil, err
+	}
+	rotations, err := s.kmsRepo.ListKeyRotations(ctx, req.GetScopeId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := keyRotationPolicyToProto(ctx, policy, rotations, s.keyRotationPolicyOutputOpts(authResults, req.GetScopeId(), action.ReadScopeKeyRotationPolicy)...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.GetKeyRotationPolicyResponse{Item: item}, nil
+}
+
+// SetKeyRotationPolicy implements the interface pbs.ScopeServiceServer.
+func (s Service) SetKeyRotationPolicy(ctx context.Context, req *pbs.SetKeyRotationPolicyRequest) (*pbs.SetKeyRotationPolicyResponse, error) {
+	if req.GetScopeId() == "" {
+		req.ScopeId = scope.Global.String()
+	}
+	if err := validateSetKeyRotationPolicyRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.SetScopeKeyRotationPolicy)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	interval := time.Duration(req.GetRotationIntervalSeconds()) * time.Second
+	policy, err := s.kmsRepo.SetKeyRotationPolicy(ctx, req.GetScopeId(), interval, req.GetDestroyPreviousVersions())
+	if err != nil {
+		return nil, err
+	}
+	rotations, err := s.kmsRepo.ListKeyRotations(ctx, req.GetScopeId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := keyRotationPolicyToProto(ctx, policy, rotations, s.keyRotationPolicyOutputOpts(authResults, req.GetScopeId(), action.SetScopeKeyRotationPolicy)...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.SetKeyRotationPolicyResponse{Item: item}, nil
+}
+
+func (s Service) keyRotationPolicyOutputOpts(authResults auth.VerifyResults, scopeId string, a action.Type) []handlers.Option {
+	res := perms.Resource{
+		Id:      scopeId,
+		ScopeId: scopeId,
+		Type:    resource.Scope,
+	}
+	outputFields := authResults.FetchOutputFields(res, a).SelfOrDefaults(authResults.UserId)
+	outputOpts := make([]handlers.Option, 0, 2)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	return outputOpts
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*iam.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -520,6 +658,42 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*iam.Scope, error)
 	return out, nil
 }
 
+func (s Service) listQuotasFromRepo(ctx context.Context, scopeId string) ([]*iam.ScopeQuota, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListScopeQuotas(ctx, scopeId)
+}
+
+func (s Service) setQuotasInRepo(ctx context.Context, scopeId string, version uint32, quotas []*pb.Quota) (*iam.Scope, []*iam.ScopeQuota, error) {
+	const op = "scopes.(Service).setQuotasInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, err
+	}
+	scopeQuotas := make([]*iam.ScopeQuota, 0, len(quotas))
+	for _, q := range quotas {
+		sq, err := iam.NewScopeQuota(ctx, scopeId, resource.Map[q.GetResourceType()], int64(q.GetLimit()))
+		if err != nil {
+			return nil, nil, errors.Wrap(ctx, err, op)
+		}
+		scopeQuotas = append(scopeQuotas, sq)
+	}
+	out, err := repo.SetScopeQuotas(ctx, scopeId, version, scopeQuotas)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to set quotas on scope"))
+	}
+	p, err := repo.LookupScope(ctx, scopeId)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up scope after setting quotas"))
+	}
+	if p == nil {
+		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to look up scope after setting quotas on it.")
+	}
+	return p, out, nil
+}
+
 func (s Service) createInRepo(ctx context.Context, authResults auth.VerifyResults, req *pbs.CreateScopeRequest) (*iam.Scope, error) {
 	const op = "scopes.(Service).createInRepo"
 	item := req.GetItem()
@@ -678,7 +852,8 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	var parentId string
 	opts := []auth.Option{auth.WithType(resource.Scope), auth.WithAction(a)}
 	switch a {
-	case action.List, action.Create, action.ListScopeKeys, action.ListScopeKeyVersionDestructionJobs, action.DestroyScopeKeyVersion:
+	case action.List, action.Create, action.ListScopeKeys, action.ListScopeKeyVersionDestructionJobs, action.DestroyScopeKeyVersion,
+		action.ReadScopeKeyRotationPolicy, action.SetScopeKeyRotationPolicy:
 		parentId = id
 		s, err := repo.LookupScope(ctx, parentId)
 		if err != nil {
@@ -754,6 +929,24 @@ func ToProto(ctx context.Context, in *iam.Scope, opt ...handlers.Option) (*pb.Sc
 	return &out, nil
 }
 
+func quotasToProto(in []*iam.ScopeQuota) []*pb.Quota {
+	out := make([]*pb.Quota, 0, len(in))
+	for _, q := range in {
+		// Limits are at most iam.MaxQuotaCount; usage can exceed the limit
+		// if it was lowered, so it is capped at the largest value reported
+		usage := q.Usage
+		if usage > iam.MaxQuotaCount {
+			usage = iam.MaxQuotaCount
+		}
+		out = append(out, &pb.Quota{
+			ResourceType: q.GetResourceType(),
+			Limit:        uint32(q.GetMaxCount()),
+			Usage:        uint32(usage),
+		})
+	}
+	return out
+}
+
 func keyToProto(ctx context.Context, in wrappingKms.Key, opt ...handlers.Option) (*pb.Key, error) {
 	opts := handlers.GetOpts(opt...)
 	if opts.WithOutputFields == nil {
@@ -819,6 +1012,43 @@ func destructionJobToProto(ctx context.Context, in *kms.DataKeyVersionDestructio
 	return &out, nil
 }
 
+// keyRotationPolicyToProto returns the key rotation policy of a scope and the
+// history of its rotations. The policy is nil if the scope has none.
+func keyRotationPolicyToProto(ctx context.Context, in *kms.KeyRotationPolicy, rotations []*kms.KeyRotation, opt ...handlers.Option) (*pb.KeyRotationPolicy, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building key rotation policy proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.KeyRotationPolicy{}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if in != nil {
+		if outputFields.Has(globals.RotationIntervalSecondsField) {
+			out.RotationIntervalSeconds = uint32(in.GetRotationIntervalSeconds())
+		}
+		if outputFields.Has(globals.DestroyPreviousVersionsField) {
+			out.DestroyPreviousVersions = in.GetDestroyPreviousVersions()
+		}
+		if outputFields.Has(globals.NextRotationTimeField) {
+			out.NextRotationTime = in.GetNextRotationTime().GetTimestamp()
+		}
+	}
+	if outputFields.Has(globals.RotationsField) {
+		for _, r := range rotations {
+			out.Rotations = append(out.Rotations, &pb.KeyRotation{
+				RotatedTime:             r.GetRotateTime().GetTimestamp(),
+				Scheduled:               r.GetScheduled(),
+				Rewrap:                  r.GetRewrap(),
+				DestroyPreviousVersions: r.GetDestroyPreviousVersions(),
+			})
+		}
+	}
+	return &out, nil
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //   - The path passed in is correctly formatted
@@ -876,6 +1106,9 @@ func validateCreateRequest(req *pbs.CreateScopeRequest) error {
 	if item.GetVersion() != 0 {
 		badFields["version"] = "This cannot be specified at create time."
 	}
+	if len(item.GetQuotas()) > 0 {
+		badFields["quotas"] = "This is a read only field."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
 	}
@@ -962,6 +1195,36 @@ func validateDeleteRequest(req *pbs.DeleteScopeRequest) error {
 	return nil
 }
 
+func validateSetQuotasRequest(req *pbs.SetScopeQuotasRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), scope.Org.Prefix()) && !handlers.ValidId(handlers.Id(req.GetId()), scope.Project.Prefix()) {
+		badFields["id"] = "Quotas can only be set on a valid org or project scope id."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	seen := make(map[string]bool, len(req.GetQuotas()))
+	for _, q := range req.GetQuotas() {
+		if !slices.Contains(iam.QuotaResourceTypes, resource.Map[q.GetResourceType()]) {
+			badFields["quotas"] = fmt.Sprintf("Unsupported resource type %q.", q.GetResourceType())
+			break
+		}
+		if seen[q.GetResourceType()] {
+			badFields["quotas"] = fmt.Sprintf("Duplicate quota for resource type %q.", q.GetResourceType())
+			break
+		}
+		seen[q.GetResourceType()] = true
+		if q.GetUsage() != 0 {
+			badFields["quotas"] = "Usage is a read only field."
+			break
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
 func validateListRequest(req *pbs.ListScopesRequest) error {
 	badFields := map[string]string{}
 	if req.GetScopeId() != scope.Global.String() && !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) {
@@ -1023,3 +1286,28 @@ func validateDestroyKeyVersionRequest(req *pbs.DestroyKeyVersionRequest) error {
 	}
 	return nil
 }
+
+func validateGetKeyRotationPolicyRequest(req *pbs.GetKeyRotationPolicyRequest) error {
+	badFields := map[string]string{}
+	if req.GetScopeId() != scope.Global.String() && !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) && !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Project.Prefix()) {
+		badFields["scope_id"] = "Must be 'global', a valid org scope id or a valid project scope id when reading a key rotation policy."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateSetKeyRotationPolicyRequest(req *pbs.SetKeyRotationPolicyRequest) error {
+	badFields := map[string]string{}
+	if req.GetScopeId() != scope.Global.String() && !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) && !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Project.Prefix()) {
+		badFields["scope_id"] = "Must be 'global', a valid org scope id or a valid project scope id when setting a key rotation policy."
+	}
+	if req.GetRotationIntervalSeconds() == 0 && req.GetDestroyPreviousVersions() {
+		badFields["destroy_previous_versions"] = "Cannot be set when removing the key rotation policy."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/google/go-cmp/cmp"
@@ -40,7 +41,7 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "set-quotas"}
 
 func createDefaultScopesRepoAndKms(t *testing.T) (*iam.Scope, *iam.Scope, func() (*iam.Repository, error), *kms.Kms) {
 	t.Helper()
@@ -88,10 +89,16 @@ var globalAuthorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("list"),
 		},
 	},
+	"jobs": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("list"),
+		},
+	},
 	"roles": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
 			structpb.NewStringValue("list"),
+			structpb.NewStringValue("explain"),
 		},
 	},
 	"scopes": {
@@ -102,6 +109,14 @@ var globalAuthorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("rotate-keys"),
 			structpb.NewStringValue("list-key-version-destruction-jobs"),
 			structpb.NewStringValue("destroy-key-version"),
+			structpb.NewStringValue("read-key-rotation-policy"),
+			structpb.NewStringValue("set-key-rotation-policy"),
+		},
+	},
+	"service-accounts": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("create"),
+			structpb.NewStringValue("list"),
 		},
 	},
 	"users": {
@@ -143,6 +158,7 @@ var orgAuthorizedCollectionActions = map[string]*structpb.ListValue{
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
 			structpb.NewStringValue("list"),
+			structpb.NewStringValue("explain"),
 		},
 	},
 	"scopes": {
@@ -153,6 +169,14 @@ var orgAuthorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("rotate-keys"),
 			structpb.NewStringValue("list-key-version-destruction-jobs"),
 			structpb.NewStringValue("destroy-key-version"),
+			structpb.NewStringValue("read-key-rotation-policy"),
+			structpb.NewStringValue("set-key-rotation-policy"),
+		},
+	},
+	"service-accounts": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("create"),
+			structpb.NewStringValue("list"),
 		},
 	},
 	"users": {
@@ -186,6 +210,7 @@ var projectAuthorizedCollectionActions = map[string]*structpb.ListValue{
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
 			structpb.NewStringValue("list"),
+			structpb.NewStringValue("explain"),
 		},
 	},
 	"sessions": {
@@ -199,6 +224,8 @@ var projectAuthorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("rotate-keys"),
 			structpb.NewStringValue("list-key-version-destruction-jobs"),
 			structpb.NewStringValue("destroy-key-version"),
+			structpb.NewStringValue("read-key-rotation-policy"),
+			structpb.NewStringValue("set-key-rotation-policy"),
 		},
 	},
 	"targets": {
@@ -620,6 +647,156 @@ func TestDelete_twice(t *testing.T) {
 	assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.NotFound)), "Expected not found for the second delete.")
 }
 
+func TestSetQuotas(t *testing.T) {
+	org, proj, repoFn, kms := createDefaultScopesRepoAndKms(t)
+
+	s, err := scopes.NewService(context.Background(), repoFn, kms)
+	require.NoError(t, err, "Error when getting new scopes service.")
+
+	cases := []struct {
+		name    string
+		scopeId string
+		req     *pbs.SetScopeQuotasRequest
+		res     []*pb.Quota
+		err     error
+	}{
+		{
+			name:    "Set project quotas",
+			scopeId: org.GetPublicId(),
+			req: &pbs.SetScopeQuotasRequest{
+				Id:      proj.GetPublicId(),
+				Version: proj.GetVersion(),
+				Quotas: []*pb.Quota{
+					{ResourceType: "target", Limit: 10},
+					{ResourceType: "session", Limit: 100},
+				},
+			},
+			res: []*pb.Quota{
+				{ResourceType: "session", Limit: 100},
+				{ResourceType: "target", Limit: 10},
+			},
+		},
+		{
+			name:    "Replace project quotas",
+			scopeId: org.GetPublicId(),
+			req: &pbs.SetScopeQuotasRequest{
+				Id:      proj.GetPublicId(),
+				Version: proj.GetVersion() + 1,
+				Quotas: []*pb.Quota{
+					{ResourceType: "target", Limit: 5},
+					{ResourceType: "host-catalog", Limit: 2},
+				},
+			},
+			res: []*pb.Quota{
+				{ResourceType: "host-catalog", Limit: 2},
+				{ResourceType: "target", Limit: 5},
+			},
+		},
+		{
+			name:    "Clear project quotas",
+			scopeId: org.GetPublicId(),
+			req: &pbs.SetScopeQuotasRequest{
+				Id:      proj.GetPublicId(),
+				Version: proj.GetVersion() + 2,
+			},
+		},
+		{
+			name:    "Set org quotas",
+			scopeId: scope.Global.String(),
+			req: &pbs.SetScopeQuotasRequest{
+				Id:      org.GetPublicId(),
+				Version: org.GetVersion(),
+				Quotas: []*pb.Quota{
+					{ResourceType: "credential-store", Limit: 3},
+				},
+			},
+			res: []*pb.Quota{
+				{ResourceType: "credential-store", Limit: 3},
+			},
+		},
+		{
+			name:    "Bad version",
+			scopeId: org.GetPublicId(),
+			req: &pbs.SetScopeQuotasRequest{
+				Id:      proj.GetPublicId(),
+				Version: 100,
+				Quotas:  []*pb.Quota{{ResourceType: "target", Limit: 1}},
+			},
+			err: handlers.ApiErrorWithCode(codes.Internal),
+		},
+		{
+			name:    "Missing version",
+			scopeId: org.GetPublicId(),
+			req: &pbs.SetScopeQuotasRequest{
+				Id:     proj.GetPublicId(),
+				Quotas: []*pb.Quota{{ResourceType: "target", Limit: 1}},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "Global scope",
+			scopeId: scope.Global.String(),
+			req: &pbs.SetScopeQuotasRequest{
+				Id:      scope.Global.String(),
+				Version: 1,
+				Quotas:  []*pb.Quota{{ResourceType: "target", Limit: 1}},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "Unsupported resource type",
+			scopeId: org.GetPublicId(),
+			req: &pbs.SetScopeQuotasRequest{
+				Id:      proj.GetPublicId(),
+				Version: proj.GetVersion(),
+				Quotas:  []*pb.Quota{{ResourceType: "user", Limit: 1}},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "Duplicate resource type",
+			scopeId: org.GetPublicId(),
+			req: &pbs.SetScopeQuotasRequest{
+				Id:      proj.GetPublicId(),
+				Version: proj.GetVersion(),
+				Quotas: []*pb.Quota{
+					{ResourceType: "target", Limit: 1},
+					{ResourceType: "target", Limit: 2},
+				},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "Usage specified",
+			scopeId: org.GetPublicId(),
+			req: &pbs.SetScopeQuotasRequest{
+				Id:      proj.GetPublicId(),
+				Version: proj.GetVersion(),
+				Quotas:  []*pb.Quota{{ResourceType: "target", Limit: 1, Usage: 1}},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.SetScopeQuotas(auth.DisabledAuthTestContext(repoFn, tc.scopeId), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "SetScopeQuotas(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(tc.req.GetVersion()+1, got.GetItem().GetVersion())
+			assert.Empty(cmp.Diff(tc.res, got.GetItem().GetQuotas(), protocmp.Transform()))
+
+			read, err := s.GetScope(auth.DisabledAuthTestContext(repoFn, tc.scopeId), &pbs.GetScopeRequest{Id: tc.req.GetId()})
+			require.NoError(err)
+			assert.Empty(cmp.Diff(tc.res, read.GetItem().GetQuotas(), protocmp.Transform()))
+		})
+	}
+}
+
 func TestCreate(t *testing.T) {
 	ctx := context.Background()
 	defaultOrg, defaultProj, repoFn, kms := createDefaultScopesRepoAndKms(t)
@@ -1962,6 +2139,114 @@ func TestRotateKeys(t *testing.T) {
 	}
 }
 
+func TestKeyRotationPolicy(t *testing.T) {
+	tc := controller.NewTestController(t, nil)
+	t.Cleanup(tc.Shutdown)
+
+	aToken := tc.Token()
+	uToken := tc.UnprivilegedToken()
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return tc.IamRepo(), nil
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return tc.ServersRepo(), nil
+	}
+	authTokenRepoFn := func() (*authtoken.Repository, error) {
+		return tc.AuthTokenRepo(), nil
+	}
+
+	privCtx := auth.NewVerifierContext(
+		context.Background(),
+		iamRepoFn,
+		authTokenRepoFn,
+		serversRepoFn,
+		tc.Kms(),
+		&authpb.RequestInfo{
+			PublicId:       aToken.Id,
+			EncryptedToken: strings.Split(aToken.Token, "_")[2],
+			TokenFormat:    uint32(auth.AuthTokenTypeBearer),
+		})
+
+	unprivCtx := auth.NewVerifierContext(
+		context.Background(),
+		iamRepoFn,
+		authTokenRepoFn,
+		serversRepoFn,
+		tc.Kms(),
+		&authpb.RequestInfo{
+			PublicId:       uToken.Id,
+			EncryptedToken: strings.Split(uToken.Token, "_")[2],
+			TokenFormat:    uint32(auth.AuthTokenTypeBearer),
+		})
+
+	org, _ := iam.TestScopes(t, tc.IamRepo(), iam.WithUserId(aToken.UserId))
+
+	// Add new role for managing key rotation in org
+	policyRole := iam.TestRole(t, tc.DbConn(), org.PublicId)
+	iam.TestRoleGrant(t, tc.DbConn(), policyRole.PublicId, "id=*;type=*;actions=read-key-rotation-policy,set-key-rotation-policy,rotate-keys")
+	_ = iam.TestUserRole(t, tc.DbConn(), policyRole.PublicId, aToken.UserId)
+
+	s, err := scopes.NewService(context.Background(), iamRepoFn, tc.Kms())
+	require.NoError(t, err, "Couldn't create new project service.")
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := s.GetKeyRotationPolicy(privCtx, &pbs.GetKeyRotationPolicyRequest{ScopeId: "u_1234567890"})
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+		_, err = s.SetKeyRotationPolicy(privCtx, &pbs.SetKeyRotationPolicyRequest{ScopeId: org.GetPublicId(), DestroyPreviousVersions: true})
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		_, err := s.GetKeyRotationPolicy(unprivCtx, &pbs.GetKeyRotationPolicyRequest{ScopeId: org.GetPublicId()})
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.PermissionDenied)), "got error %v", err)
+		_, err = s.SetKeyRotationPolicy(unprivCtx, &pbs.SetKeyRotationPolicyRequest{ScopeId: org.GetPublicId(), RotationIntervalSeconds: 3600})
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.PermissionDenied)), "got error %v", err)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		_, err := s.GetKeyRotationPolicy(privCtx, &pbs.GetKeyRotationPolicyRequest{ScopeId: "o_DoesntExis"})
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "got error %v", err)
+	})
+
+	t.Run("set-read-and-remove", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		got, err := s.GetKeyRotationPolicy(privCtx, &pbs.GetKeyRotationPolicyRequest{ScopeId: org.GetPublicId()})
+		require.NoError(err)
+		assert.Zero(got.GetItem().GetRotationIntervalSeconds())
+		assert.Nil(got.GetItem().GetNextRotationTime())
+		assert.Empty(got.GetItem().GetRotations())
+
+		set, err := s.SetKeyRotationPolicy(privCtx, &pbs.SetKeyRotationPolicyRequest{
+			ScopeId:                 org.GetPublicId(),
+			RotationIntervalSeconds: 90 * 24 * 60 * 60,
+			DestroyPreviousVersions: true,
+		})
+		require.NoError(err)
+		assert.Equal(org.GetPublicId(), set.GetItem().GetScope().GetId())
+		assert.Equal(uint32(90*24*60*60), set.GetItem().GetRotationIntervalSeconds())
+		assert.True(set.GetItem().GetDestroyPreviousVersions())
+		assert.WithinDuration(time.Now().Add(90*24*time.Hour), set.GetItem().GetNextRotationTime().AsTime(), time.Minute)
+
+		_, err = s.RotateKeys(privCtx, &pbs.RotateKeysRequest{ScopeId: org.GetPublicId(), Rewrap: true})
+		require.NoError(err)
+
+		got, err = s.GetKeyRotationPolicy(privCtx, &pbs.GetKeyRotationPolicyRequest{ScopeId: org.GetPublicId()})
+		require.NoError(err)
+		assert.Equal(set.GetItem().GetNextRotationTime().AsTime(), got.GetItem().GetNextRotationTime().AsTime())
+		require.Len(got.GetItem().GetRotations(), 1)
+		assert.False(got.GetItem().GetRotations()[0].GetScheduled())
+		assert.True(got.GetItem().GetRotations()[0].GetRewrap())
+
+		set, err = s.SetKeyRotationPolicy(privCtx, &pbs.SetKeyRotationPolicyRequest{ScopeId: org.GetPublicId()})
+		require.NoError(err)
+		assert.Zero(set.GetItem().GetRotationIntervalSeconds())
+		assert.Nil(set.GetItem().GetNextRotationTime())
+		assert.Len(set.GetItem().GetRotations(), 1)
+	})
+}
+
 func TestListKeyVersionDestructionJobs(t *testing.T) {
 	tc := controller.NewTestController(t, nil)
 	t.Cleanup(tc.Shutdown)
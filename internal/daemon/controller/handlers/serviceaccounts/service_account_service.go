@@ -0,0 +1,698 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceaccounts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/serviceaccounts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// defaultApiKeyTimeToLive is how long an API key is valid for if no
+// expiration time is given when it is added.
+const defaultApiKeyTimeToLive = 90 * 24 * time.Hour
+
+var (
+	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.Delete,
+		action.AddApiKey,
+		action.RemoveApiKey,
+	}
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.ActionSet{
+		action.Create,
+		action.List,
+	}
+)
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.ServiceAccount{}}, handlers.MaskSource{&pb.ServiceAccount{}}); err != nil {
+		panic(err)
+	}
+}
+
+// Service handles request as described by the pbs.ServiceAccountServiceServer
+// interface.
+type Service struct {
+	pbs.UnsafeServiceAccountServiceServer
+
+	repoFn common.IamRepoFactory
+}
+
+var _ pbs.ServiceAccountServiceServer = (*Service)(nil)
+
+// NewService returns a service account service which handles service account
+// related requests to boundary.
+func NewService(repo common.IamRepoFactory) (Service, error) {
+	const op = "serviceaccounts.NewService"
+	if repo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
+	}
+	return Service{repoFn: repo}, nil
+}
+
+// ListServiceAccounts implements the interface pbs.ServiceAccountServiceServer.
+func (s Service) ListServiceAccounts(ctx context.Context, req *pbs.ListServiceAccountsRequest) (*pbs.ListServiceAccountsResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		// If it's forbidden, and it's a recursive request, and they're
+		// successfully authenticated but just not authorized, keep going as we
+		// may have authorization on downstream scopes. Or, if they've not
+		// authenticated, still process in case u_anon has permissions.
+		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
+			req.GetRecursive() &&
+			authResults.AuthenticationFinished {
+		} else {
+			return nil, authResults.Error
+		}
+	}
+
+	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
+		ctx, s.repoFn, authResults, req.GetScopeId(), resource.ServiceAccount, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	// If no scopes match, return an empty response
+	if len(scopeIds) == 0 {
+		return &pbs.ListServiceAccountsResponse{}, nil
+	}
+
+	sal, err := s.listFromRepo(ctx, scopeIds)
+	if err != nil {
+		return nil, err
+	}
+	if len(sal) == 0 {
+		return &pbs.ListServiceAccountsResponse{}, nil
+	}
+
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.ServiceAccount, 0, len(sal))
+	res := perms.Resource{
+		Type: resource.ServiceAccount,
+	}
+	for _, item := range sal {
+		res.Id = item.GetPublicId()
+		res.ScopeId = item.GetScopeId()
+		res.ParentScopeId = scopeInfoMap[item.GetScopeId()].GetParentScopeId()
+		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+		if len(authorizedActions) == 0 {
+			continue
+		}
+
+		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+		if outputFields.Has(globals.ScopeField) {
+			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
+		}
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+		}
+
+		item, err := toProto(ctx, item, nil, outputOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if filter.Match(item) {
+			finalItems = append(finalItems, item)
+		}
+	}
+	return &pbs.ListServiceAccountsResponse{Items: finalItems}, nil
+}
+
+// GetServiceAccount implements the interface pbs.ServiceAccountServiceServer.
+func (s Service) GetServiceAccount(ctx context.Context, req *pbs.GetServiceAccountRequest) (*pbs.GetServiceAccountResponse, error) {
+	const op = "serviceaccounts.(Service).GetServiceAccount"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	sa, keys, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, sa.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, sa, keys, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.GetServiceAccountResponse{Item: item}, nil
+}
+
+// CreateServiceAccount implements the interface pbs.ServiceAccountServiceServer.
+func (s Service) CreateServiceAccount(ctx context.Context, req *pbs.CreateServiceAccountRequest) (*pbs.CreateServiceAccountResponse, error) {
+	const op = "serviceaccounts.(Service).CreateServiceAccount"
+
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	sa, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, sa.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, sa, nil, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.CreateServiceAccountResponse{Item: item, Uri: fmt.Sprintf("service-accounts/%s", item.GetId())}, nil
+}
+
+// UpdateServiceAccount implements the interface pbs.ServiceAccountServiceServer.
+func (s Service) UpdateServiceAccount(ctx context.Context, req *pbs.UpdateServiceAccountRequest) (*pbs.UpdateServiceAccountResponse, error) {
+	const op = "serviceaccounts.(Service).UpdateServiceAccount"
+
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	sa, keys, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, sa.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, sa, keys, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.UpdateServiceAccountResponse{Item: item}, nil
+}
+
+// DeleteServiceAccount implements the interface pbs.ServiceAccountServiceServer.
+func (s Service) DeleteServiceAccount(ctx context.Context, req *pbs.DeleteServiceAccountRequest) (*pbs.DeleteServiceAccountResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// AddServiceAccountApiKey implements the interface
+// pbs.ServiceAccountServiceServer. The bearer token of the added key is set
+// in the returned item's api_keys; it can't be read afterwards.
+func (s Service) AddServiceAccountApiKey(ctx context.Context, req *pbs.AddServiceAccountApiKeyRequest) (*pbs.AddServiceAccountApiKeyResponse, error) {
+	const op = "serviceaccounts.(Service).AddServiceAccountApiKey"
+
+	if err := validateAddApiKeyRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.AddApiKey)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	expiration := time.Now().Add(defaultApiKeyTimeToLive)
+	if req.GetApiKeyExpirationTime() != nil {
+		expiration = req.GetApiKeyExpirationTime().AsTime()
+	}
+	sa, keys, newKey, err := s.addApiKeyInRepo(ctx, req.GetId(), req.GetVersion(), req.GetApiKeyScopeId(), expiration)
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, sa.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, sa, keys, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range item.GetApiKeys() {
+		if k.GetId() == newKey.GetPublicId() {
+			k.Token = newKey.Token()
+		}
+	}
+
+	return &pbs.AddServiceAccountApiKeyResponse{Item: item}, nil
+}
+
+// RemoveServiceAccountApiKey implements the interface
+// pbs.ServiceAccountServiceServer.
+func (s Service) RemoveServiceAccountApiKey(ctx context.Context, req *pbs.RemoveServiceAccountApiKeyRequest) (*pbs.RemoveServiceAccountApiKeyResponse, error) {
+	const op = "serviceaccounts.(Service).RemoveServiceAccountApiKey"
+
+	if err := validateRemoveApiKeyRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RemoveApiKey)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	sa, keys, err := s.removeApiKeyInRepo(ctx, req.GetId(), req.GetVersion(), req.GetApiKeyId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, sa.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, sa, keys, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RemoveServiceAccountApiKeyResponse{Item: item}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*iam.ServiceAccount, []*iam.ServiceAccountApiKey, error) {
+	const op = "serviceaccounts.(Service).getFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	sa, keys, err := repo.LookupServiceAccount(ctx, id)
+	if err != nil && !errors.IsNotFoundError(err) {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	if sa == nil {
+		return nil, nil, handlers.NotFoundErrorf("Service account %q doesn't exist.", id)
+	}
+	return sa, keys, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.ServiceAccount) (*iam.ServiceAccount, error) {
+	const op = "serviceaccounts.(Service).createInRepo"
+	var opts []iam.Option
+	if item.GetName() != nil {
+		opts = append(opts, iam.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, iam.WithDescription(item.GetDescription().GetValue()))
+	}
+	sa, err := iam.NewServiceAccount(ctx, scopeId, opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build service account for creation: %v.", err)
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.CreateServiceAccount(ctx, sa)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create service account but no error returned from repository.")
+	}
+	return out, nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.ServiceAccount) (*iam.ServiceAccount, []*iam.ServiceAccountApiKey, error) {
+	const op = "serviceaccounts.(Service).updateInRepo"
+	var opts []iam.Option
+	if desc := item.GetDescription(); desc != nil {
+		opts = append(opts, iam.WithDescription(desc.GetValue()))
+	}
+	if name := item.GetName(); name != nil {
+		opts = append(opts, iam.WithName(name.GetValue()))
+	}
+	version := item.GetVersion()
+	sa, err := iam.NewServiceAccount(ctx, scopeId, opts...)
+	if err != nil {
+		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build service account for update: %v.", err)
+	}
+	sa.PublicId = id
+	dbMask := maskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	out, keys, rowsUpdated, err := repo.UpdateServiceAccount(ctx, sa, version, dbMask)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	if rowsUpdated == 0 {
+		return nil, nil, handlers.NotFoundErrorf("Service account %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, keys, nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	const op = "serviceaccounts.(Service).deleteFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteServiceAccount(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete service account"))
+	}
+	return rows > 0, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.ServiceAccount, error) {
+	const op = "serviceaccounts.(Service).listFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	sal, err := repo.ListServiceAccounts(ctx, scopeIds)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return sal, nil
+}
+
+func (s Service) addApiKeyInRepo(ctx context.Context, id string, version uint32, keyScopeId string, expiration time.Time) (*iam.ServiceAccount, []*iam.ServiceAccountApiKey, *iam.ServiceAccountApiKey, error) {
+	const op = "serviceaccounts.(Service).addApiKeyInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(ctx, err, op)
+	}
+	key, err := repo.AddServiceAccountApiKey(ctx, id, version, keyScopeId, expiration)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to add api key to service account"))
+	}
+	out, keys, err := repo.LookupServiceAccount(ctx, id)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up service account after adding api key"))
+	}
+	if out == nil {
+		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup service account after adding api key to it.")
+	}
+	return out, keys, key, nil
+}
+
+func (s Service) removeApiKeyInRepo(ctx context.Context, id string, version uint32, keyId string) (*iam.ServiceAccount, []*iam.ServiceAccountApiKey, error) {
+	const op = "serviceaccounts.(Service).removeApiKeyInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	_, err = repo.DeleteServiceAccountApiKey(ctx, id, version, keyId)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to remove api key from service account"))
+	}
+	out, keys, err := repo.LookupServiceAccount(ctx, id)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up service account after removing api key"))
+	}
+	if out == nil {
+		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup service account after removing api key from it.")
+	}
+	return out, keys, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+	repo, err := s.repoFn()
+	if err != nil {
+		res.Error = err
+		return res
+	}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.ServiceAccount), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+		scp, err := repo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	default:
+		sa, _, err := repo.LookupServiceAccount(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if sa == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = sa.GetScopeId()
+		opts = append(opts, auth.WithId(id), auth.WithName(sa.GetName()))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(ctx context.Context, in *iam.ServiceAccount, keys []*iam.ServiceAccountApiKey, opt ...handlers.Option) (*pb.ServiceAccount, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building service account proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.ServiceAccount{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if outputFields.Has(globals.NameField) && in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.UpdatedTimeField) {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.VersionField) {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	if outputFields.Has(globals.ApiKeysField) {
+		for _, k := range keys {
+			out.ApiKeys = append(out.ApiKeys, &pb.ApiKey{
+				Id:             k.GetPublicId(),
+				ScopeId:        k.GetScopeId(),
+				CreatedTime:    k.GetCreateTime().GetTimestamp(),
+				ExpirationTime: k.GetExpirationTime().GetTimestamp(),
+				LastUsedTime:   k.GetLastUsedTime().GetTimestamp(),
+			})
+		}
+	}
+	return &out, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetServiceAccountRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, globals.ServiceAccountPrefix)
+}
+
+func validateCreateRequest(req *pbs.CreateServiceAccountRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if !handlers.ValidId(handlers.Id(req.GetItem().GetScopeId()), scope.Org.Prefix()) &&
+			scope.Global.String() != req.GetItem().GetScopeId() {
+			badFields["scope_id"] = "This field is missing or improperly formatted."
+		}
+		if len(req.GetItem().GetApiKeys()) > 0 {
+			badFields["api_keys"] = "This is a read only field."
+		}
+		return badFields
+	})
+}
+
+func validateUpdateRequest(req *pbs.UpdateServiceAccountRequest) error {
+	return handlers.ValidateUpdateRequest(req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if len(req.GetItem().GetApiKeys()) > 0 {
+			badFields["api_keys"] = "This is a read only field and cannot be specified in an update request."
+		}
+		return badFields
+	}, globals.ServiceAccountPrefix)
+}
+
+func validateDeleteRequest(req *pbs.DeleteServiceAccountRequest) error {
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, globals.ServiceAccountPrefix)
+}
+
+func validateListRequest(req *pbs.ListServiceAccountsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "Incorrectly formatted identifier."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+func validateAddApiKeyRequest(req *pbs.AddServiceAccountApiKeyRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.ServiceAccountPrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if !handlers.ValidId(handlers.Id(req.GetApiKeyScopeId()), scope.Org.Prefix()) &&
+		!handlers.ValidId(handlers.Id(req.GetApiKeyScopeId()), scope.Project.Prefix()) {
+		badFields["api_key_scope_id"] = "Must be an org or project scope id."
+	}
+	if exp := req.GetApiKeyExpirationTime(); exp != nil && !exp.AsTime().After(time.Now()) {
+		badFields["api_key_expiration_time"] = "Must be in the future."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateRemoveApiKeyRequest(req *pbs.RemoveServiceAccountApiKeyRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.ServiceAccountPrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if !handlers.ValidId(handlers.Id(req.GetApiKeyId()), globals.ServiceAccountApiKeyPrefix) {
+		badFields["api_key_id"] = "Incorrectly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
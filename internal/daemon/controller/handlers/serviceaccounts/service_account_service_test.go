@@ -0,0 +1,214 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceaccounts_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/serviceaccounts"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/serviceaccounts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-api-key", "remove-api-key"}
+
+func TestGet(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	o, _ := iam.TestScopes(t, iamRepo)
+	sa := iam.TestServiceAccount(t, conn, o.GetPublicId(), iam.WithName("default"), iam.WithDescription("default"))
+
+	want := &pb.ServiceAccount{
+		Id:                sa.GetPublicId(),
+		ScopeId:           sa.GetScopeId(),
+		Scope:             &scopes.ScopeInfo{Id: o.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+		Name:              wrapperspb.String("default"),
+		Description:       wrapperspb.String("default"),
+		CreatedTime:       sa.GetCreateTime().GetTimestamp(),
+		UpdatedTime:       sa.GetUpdateTime().GetTimestamp(),
+		Version:           1,
+		AuthorizedActions: testAuthorizedActions,
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.GetServiceAccountRequest
+		res  *pbs.GetServiceAccountResponse
+		err  error
+	}{
+		{
+			name: "Get an existing service account",
+			req:  &pbs.GetServiceAccountRequest{Id: sa.GetPublicId()},
+			res:  &pbs.GetServiceAccountResponse{Item: want},
+		},
+		{
+			name: "Get a non existent service account",
+			req:  &pbs.GetServiceAccountRequest{Id: globals.ServiceAccountPrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.GetServiceAccountRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := serviceaccounts.NewService(repoFn)
+			require.NoError(err)
+
+			got, gErr := s.GetServiceAccount(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetServiceAccount(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()))
+		})
+	}
+}
+
+func TestCrud(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	o, p := iam.TestScopes(t, iamRepo)
+	s, err := serviceaccounts.NewService(repoFn)
+	require.NoError(err)
+	ctx := auth.DisabledAuthTestContext(repoFn, o.GetPublicId())
+
+	_, err = s.CreateServiceAccount(auth.DisabledAuthTestContext(repoFn, p.GetPublicId()), &pbs.CreateServiceAccountRequest{Item: &pb.ServiceAccount{
+		ScopeId: p.GetPublicId(),
+	}})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got %v", err)
+
+	created, err := s.CreateServiceAccount(ctx, &pbs.CreateServiceAccountRequest{Item: &pb.ServiceAccount{
+		ScopeId: o.GetPublicId(),
+		Name:    wrapperspb.String("ci"),
+	}})
+	require.NoError(err)
+	assert.True(strings.HasPrefix(created.GetItem().GetId(), globals.ServiceAccountPrefix+"_"))
+	assert.Equal("service-accounts/"+created.GetItem().GetId(), created.GetUri())
+
+	updated, err := s.UpdateServiceAccount(ctx, &pbs.UpdateServiceAccountRequest{
+		Id:         created.GetItem().GetId(),
+		UpdateMask: &field_mask.FieldMask{Paths: []string{globals.DescriptionField}},
+		Item: &pb.ServiceAccount{
+			Version:     created.GetItem().GetVersion(),
+			Description: wrapperspb.String("deploys"),
+		},
+	})
+	require.NoError(err)
+	assert.Equal("deploys", updated.GetItem().GetDescription().GetValue())
+
+	list, err := s.ListServiceAccounts(ctx, &pbs.ListServiceAccountsRequest{ScopeId: o.GetPublicId()})
+	require.NoError(err)
+	require.Len(list.GetItems(), 1)
+	assert.Equal(created.GetItem().GetId(), list.GetItems()[0].GetId())
+
+	_, err = s.DeleteServiceAccount(ctx, &pbs.DeleteServiceAccountRequest{Id: created.GetItem().GetId()})
+	require.NoError(err)
+	_, err = s.GetServiceAccount(ctx, &pbs.GetServiceAccountRequest{Id: created.GetItem().GetId()})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "got %v", err)
+}
+
+func TestApiKeys(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	o, p := iam.TestScopes(t, iamRepo)
+	s, err := serviceaccounts.NewService(repoFn)
+	require.NoError(t, err)
+	ctx := auth.DisabledAuthTestContext(repoFn, o.GetPublicId())
+	sa := iam.TestServiceAccount(t, conn, o.GetPublicId())
+
+	failCases := []struct {
+		name string
+		req  *pbs.AddServiceAccountApiKeyRequest
+	}{
+		{
+			name: "bad id",
+			req:  &pbs.AddServiceAccountApiKeyRequest{Id: "bad id", Version: sa.GetVersion(), ApiKeyScopeId: p.GetPublicId()},
+		},
+		{
+			name: "missing version",
+			req:  &pbs.AddServiceAccountApiKeyRequest{Id: sa.GetPublicId(), ApiKeyScopeId: p.GetPublicId()},
+		},
+		{
+			name: "global key scope",
+			req:  &pbs.AddServiceAccountApiKeyRequest{Id: sa.GetPublicId(), Version: sa.GetVersion(), ApiKeyScopeId: scope.Global.String()},
+		},
+		{
+			name: "expired",
+			req: &pbs.AddServiceAccountApiKeyRequest{
+				Id:                   sa.GetPublicId(),
+				Version:              sa.GetVersion(),
+				ApiKeyScopeId:        p.GetPublicId(),
+				ApiKeyExpirationTime: timestamppb.New(time.Now().Add(-time.Hour)),
+			},
+		},
+	}
+	for _, tc := range failCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := s.AddServiceAccountApiKey(ctx, tc.req)
+			assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got %v", err)
+		})
+	}
+
+	added, err := s.AddServiceAccountApiKey(ctx, &pbs.AddServiceAccountApiKeyRequest{
+		Id:            sa.GetPublicId(),
+		Version:       sa.GetVersion(),
+		ApiKeyScopeId: p.GetPublicId(),
+	})
+	require.NoError(t, err)
+	require.Len(t, added.GetItem().GetApiKeys(), 1)
+	key := added.GetItem().GetApiKeys()[0]
+	assert.Equal(t, p.GetPublicId(), key.GetScopeId())
+	assert.True(t, strings.HasPrefix(key.GetToken(), key.GetId()+"_"))
+	assert.WithinDuration(t, time.Now().Add(90*24*time.Hour), key.GetExpirationTime().AsTime(), time.Minute)
+
+	got, err := s.GetServiceAccount(ctx, &pbs.GetServiceAccountRequest{Id: sa.GetPublicId()})
+	require.NoError(t, err)
+	require.Len(t, got.GetItem().GetApiKeys(), 1)
+	assert.Empty(t, got.GetItem().GetApiKeys()[0].GetToken())
+
+	removed, err := s.RemoveServiceAccountApiKey(ctx, &pbs.RemoveServiceAccountApiKeyRequest{
+		Id:       sa.GetPublicId(),
+		Version:  added.GetItem().GetVersion(),
+		ApiKeyId: key.GetId(),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, removed.GetItem().GetApiKeys())
+}
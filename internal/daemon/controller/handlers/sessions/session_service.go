@@ -180,6 +180,7 @@ func (s Service) ListSessions(ctx context.Context, req *pbs.ListSessionsRequest)
 	for _, item := range sesList {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetProjectId()
+		res.ParentScopeId = scopeIds[item.GetProjectId()].GetParentScopeId()
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
 			continue
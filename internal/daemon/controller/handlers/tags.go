@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"github.com/hashicorp/boundary/globals"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// maxTagLength is the maximum length of a resource tag key or value
+const maxTagLength = 512
+
+// ValidateTags checks the tags of a request that adds, sets or removes resource
+// tags and records any problem in badFields. Tags may only be empty when
+// allowEmpty is set, which is the case when setting tags.
+func ValidateTags(badFields map[string]string, tags map[string]*structpb.ListValue, allowEmpty bool) {
+	if len(tags) == 0 {
+		if !allowEmpty {
+			badFields[globals.TagsField] = "Must be non-empty."
+		}
+		return
+	}
+	for k, lv := range tags {
+		if err := validateTagString(k); err != "" {
+			badFields[globals.TagsField] = "Tag keys " + err
+			return
+		}
+		if len(lv.GetValues()) == 0 {
+			badFields[globals.TagsField] = "Tag values must be non-empty."
+			return
+		}
+		for _, v := range lv.GetValues() {
+			if _, ok := v.GetKind().(*structpb.Value_StringValue); !ok {
+				badFields[globals.TagsField] = "Tag values must be strings."
+				return
+			}
+			if err := validateTagString(v.GetStringValue()); err != "" {
+				badFields[globals.TagsField] = "Tag values " + err
+				return
+			}
+		}
+	}
+}
+
+func validateTagString(str string) string {
+	switch {
+	case len(str) == 0:
+		return "must be non-empty."
+	case len(str) > maxTagLength:
+		return "must be within 512 characters."
+	default:
+		return ""
+	}
+}
+
+// TagsFromProto converts resource tags from their API representation. It
+// expects tags that passed ValidateTags.
+func TagsFromProto(in map[string]*structpb.ListValue) map[string][]string {
+	ret := make(map[string][]string, len(in))
+	for k, lv := range in {
+		for _, v := range lv.GetValues() {
+			ret[k] = append(ret[k], v.GetStringValue())
+		}
+	}
+	return ret
+}
+
+// TagsToProto converts resource tags to their API representation
+func TagsToProto(in map[string][]string) (map[string]*structpb.ListValue, error) {
+	ret := make(map[string]*structpb.ListValue, len(in))
+	for k, values := range in {
+		lv := make([]any, 0, len(values))
+		for _, v := range values {
+			lv = append(lv, v)
+		}
+		var err error
+		ret[k], err = structpb.NewList(lv)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
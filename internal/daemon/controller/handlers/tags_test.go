@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestValidateTags(t *testing.T) {
+	t.Parallel()
+	list := func(values ...any) *structpb.ListValue {
+		lv, err := structpb.NewList(values)
+		require.NoError(t, err)
+		return lv
+	}
+	tests := []struct {
+		name       string
+		tags       map[string]*structpb.ListValue
+		allowEmpty bool
+		wantErr    string
+	}{
+		{
+			name: "valid",
+			tags: map[string]*structpb.ListValue{"env": list("dev", "test")},
+		},
+		{
+			name:    "empty",
+			wantErr: "Must be non-empty.",
+		},
+		{
+			name:       "empty-allowed",
+			allowEmpty: true,
+		},
+		{
+			name:    "empty-key",
+			tags:    map[string]*structpb.ListValue{"": list("dev")},
+			wantErr: "Tag keys must be non-empty.",
+		},
+		{
+			name:    "no-values",
+			tags:    map[string]*structpb.ListValue{"env": list()},
+			wantErr: "Tag values must be non-empty.",
+		},
+		{
+			name:    "non-string-value",
+			tags:    map[string]*structpb.ListValue{"env": list(1)},
+			wantErr: "Tag values must be strings.",
+		},
+		{
+			name:    "long-value",
+			tags:    map[string]*structpb.ListValue{"env": list(strings.Repeat("a", 513))},
+			wantErr: "Tag values must be within 512 characters.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			badFields := map[string]string{}
+			ValidateTags(badFields, tt.tags, tt.allowEmpty)
+			if tt.wantErr == "" {
+				assert.Empty(t, badFields)
+				return
+			}
+			assert.Equal(t, map[string]string{globals.TagsField: tt.wantErr}, badFields)
+		})
+	}
+}
+
+func TestTagsRoundTrip(t *testing.T) {
+	t.Parallel()
+	tags := map[string][]string{"env": {"dev", "test"}, "team": {"db"}}
+	pbTags, err := TagsToProto(tags)
+	require.NoError(t, err)
+	assert.Equal(t, tags, TagsFromProto(pbTags))
+}
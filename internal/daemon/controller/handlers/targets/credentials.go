@@ -174,6 +174,16 @@ func staticToWorkerCredential(ctx context.Context, cred credential.Static) (sess
 			},
 		}
 
+	case *credstatic.TlsCertificateCredential:
+		workerCred = &serverpb.Credential{
+			Credential: &serverpb.Credential_TlsCertificate{
+				TlsCertificate: &serverpb.TlsCertificate{
+					Certificate: string(c.GetCertificate()),
+					PrivateKey:  string(c.GetPrivateKey()),
+				},
+			},
+		}
+
 	default:
 		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported credential %T", c))
 	}
@@ -185,6 +195,9 @@ func staticToWorkerCredential(ctx context.Context, cred credential.Static) (sess
 	return data, nil
 }
 
+// staticToSessionCredential converts the credential.Static into a
+// pb.SessionCredential suitable for returning to the client. TLS certificate
+// credentials are not supported, since they can only be injected.
 func staticToSessionCredential(ctx context.Context, cred credential.Static) (*pb.SessionCredential, error) {
 	const op = "targets.staticToSessionCredential"
 
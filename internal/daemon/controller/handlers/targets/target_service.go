@@ -5,6 +5,7 @@ package targets
 
 import (
 	"context"
+	"crypto/x509"
 	stderrors "errors"
 	"fmt"
 	"math/rand"
@@ -84,6 +85,9 @@ var (
 		action.AddCredentialSources,
 		action.SetCredentialSources,
 		action.RemoveCredentialSources,
+		action.AddTags,
+		action.SetTags,
+		action.RemoveTags,
 		action.AuthorizeSession,
 	}
 
@@ -229,9 +233,29 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 		return nil, err
 	}
 
+	ids := make([]string, 0, len(tl))
+	for _, item := range tl {
+		ids = append(ids, item.GetPublicId())
+	}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	tags, err := iamRepo.ListResourceTags(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
 	finalItems := make([]*pb.Target, 0, len(tl))
 	for _, item := range tl {
-		pr := perms.Resource{Id: item.GetPublicId(), ScopeId: item.GetProjectId(), Type: resource.Target}
+		pr := perms.Resource{
+			Id:            item.GetPublicId(),
+			ScopeId:       item.GetProjectId(),
+			ParentScopeId: authzScopes[item.GetProjectId()].GetParentScopeId(),
+			Type:          resource.Target,
+			Name:          item.GetName(),
+			Tags:          tags[item.GetPublicId()],
+		}
 		outputFields := authResults.FetchOutputFields(pr, action.List).SelfOrDefaults(authResults.UserId)
 
 		outputOpts := make([]handlers.Option, 0, 3)
@@ -244,6 +268,9 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 			authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&pr)).Strings()
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
+		if outputFields.Has(globals.ResourceTagsField) {
+			outputOpts = append(outputOpts, handlers.WithTags(tags[item.GetPublicId()]))
+		}
 
 		item, err := toProto(ctx, item, outputOpts...)
 		if err != nil {
@@ -291,6 +318,13 @@ func (s Service) GetTarget(ctx context.Context, req *pbs.GetTargetRequest) (*pbs
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		tags, err := s.lookupTags(ctx, t.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
 	t.SetHostSources(ts)
 	t.SetCredentialSources(cl)
 
@@ -371,6 +405,13 @@ func (s Service) UpdateTarget(ctx context.Context, req *pbs.UpdateTargetRequest)
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		tags, err := s.lookupTags(ctx, t.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
 	t.SetHostSources(ts)
 	t.SetCredentialSources(cl)
 
@@ -429,6 +470,13 @@ func (s Service) AddTargetHostSources(ctx context.Context, req *pbs.AddTargetHos
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		tags, err := s.lookupTags(ctx, t.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
 	t.SetHostSources(ts)
 	t.SetCredentialSources(cl)
 
@@ -469,6 +517,13 @@ func (s Service) SetTargetHostSources(ctx context.Context, req *pbs.SetTargetHos
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		tags, err := s.lookupTags(ctx, t.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
 	t.SetHostSources(ts)
 	t.SetCredentialSources(cl)
 
@@ -509,6 +564,13 @@ func (s Service) RemoveTargetHostSources(ctx context.Context, req *pbs.RemoveTar
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		tags, err := s.lookupTags(ctx, t.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
 	t.SetHostSources(ts)
 	t.SetCredentialSources(cl)
 
@@ -551,6 +613,13 @@ func (s Service) AddTargetCredentialSources(ctx context.Context, req *pbs.AddTar
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		tags, err := s.lookupTags(ctx, t.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
 	t.SetHostSources(ts)
 	t.SetCredentialSources(cl)
 
@@ -593,6 +662,13 @@ func (s Service) SetTargetCredentialSources(ctx context.Context, req *pbs.SetTar
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		tags, err := s.lookupTags(ctx, t.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
 	t.SetHostSources(ts)
 	t.SetCredentialSources(cl)
 
@@ -635,6 +711,13 @@ func (s Service) RemoveTargetCredentialSources(ctx context.Context, req *pbs.Rem
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		tags, err := s.lookupTags(ctx, t.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
 	t.SetHostSources(ts)
 	t.SetCredentialSources(cl)
 
@@ -646,6 +729,147 @@ func (s Service) RemoveTargetCredentialSources(ctx context.Context, req *pbs.Rem
 	return &pbs.RemoveTargetCredentialSourcesResponse{Item: item}, nil
 }
 
+// AddTargetTags implements the interface pbs.TargetServiceServer.
+func (s Service) AddTargetTags(ctx context.Context, req *pbs.AddTargetTagsRequest) (*pbs.AddTargetTagsResponse, error) {
+	const op = "targets.(Service).AddTargetTags"
+
+	if err := validateAddTagsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.AddTags)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	tags, err := s.addTagsInRepo(ctx, authResults, req.GetVersion(), handlers.TagsFromProto(req.GetTags()))
+	if err != nil {
+		return nil, err
+	}
+	t, ts, cl, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 4)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
+	t.SetHostSources(ts)
+	t.SetCredentialSources(cl)
+
+	item, err := toProto(ctx, t, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.AddTargetTagsResponse{Item: item}, nil
+}
+
+// SetTargetTags implements the interface pbs.TargetServiceServer.
+func (s Service) SetTargetTags(ctx context.Context, req *pbs.SetTargetTagsRequest) (*pbs.SetTargetTagsResponse, error) {
+	const op = "targets.(Service).SetTargetTags"
+
+	if err := validateSetTagsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetTags)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	tags, err := s.setTagsInRepo(ctx, authResults, req.GetVersion(), handlers.TagsFromProto(req.GetTags()))
+	if err != nil {
+		return nil, err
+	}
+	t, ts, cl, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 4)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
+	t.SetHostSources(ts)
+	t.SetCredentialSources(cl)
+
+	item, err := toProto(ctx, t, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.SetTargetTagsResponse{Item: item}, nil
+}
+
+// RemoveTargetTags implements the interface pbs.TargetServiceServer.
+func (s Service) RemoveTargetTags(ctx context.Context, req *pbs.RemoveTargetTagsRequest) (*pbs.RemoveTargetTagsResponse, error) {
+	const op = "targets.(Service).RemoveTargetTags"
+
+	if err := validateRemoveTagsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RemoveTags)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	tags, err := s.removeTagsInRepo(ctx, authResults, req.GetVersion(), handlers.TagsFromProto(req.GetTags()))
+	if err != nil {
+		return nil, err
+	}
+	t, ts, cl, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 4)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.ResourceTagsField) {
+		outputOpts = append(outputOpts, handlers.WithTags(tags))
+	}
+	t.SetHostSources(ts)
+	t.SetCredentialSources(cl)
+
+	item, err := toProto(ctx, t, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RemoveTargetTagsResponse{Item: item}, nil
+}
+
 // If set, use the worker_filter or egress_worker_filter to filter the selected workers
 // and ensure we have workers available to service this request.
 func AuthorizeSessionWithWorkerFilter(_ context.Context, t target.Target, selectedWorkers wl.WorkerList, _ string, _ common.Downstreamers) (wl.WorkerList, error) {
@@ -901,20 +1125,26 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	expTime := timestamppb.Now()
 	expTime.Seconds += int64(t.GetSessionMaxSeconds())
 	sessionComposition := session.ComposedOf{
-		UserId:              authResults.UserId,
-		HostId:              hostId,
-		TargetId:            t.GetPublicId(),
-		HostSetId:           hostSetId,
-		AuthTokenId:         authResults.AuthTokenId,
-		ProjectId:           authResults.Scope.Id,
-		Endpoint:            endpointUrl.String(),
-		ExpirationTime:      &timestamp.Timestamp{Timestamp: expTime},
-		ConnectionLimit:     t.GetSessionConnectionLimit(),
-		WorkerFilter:        t.GetWorkerFilter(),
-		EgressWorkerFilter:  t.GetEgressWorkerFilter(),
-		IngressWorkerFilter: t.GetIngressWorkerFilter(),
-		DynamicCredentials:  dynCreds,
-		StaticCredentials:   staticCreds,
+		UserId:                       authResults.UserId,
+		HostId:                       hostId,
+		TargetId:                     t.GetPublicId(),
+		HostSetId:                    hostSetId,
+		AuthTokenId:                  authResults.AuthTokenId,
+		ProjectId:                    authResults.Scope.Id,
+		Endpoint:                     endpointUrl.String(),
+		ExpirationTime:               &timestamp.Timestamp{Timestamp: expTime},
+		ConnectionLimit:              t.GetSessionConnectionLimit(),
+		MaxBytes:                     t.GetSessionMaxBytes(),
+		ConnectionMaxBytesPerSecond:  t.GetConnectionMaxBytesPerSecond(),
+		ConnectionIdleTimeoutSeconds: t.GetConnectionIdleTimeoutSeconds(),
+		EndpointTlsEnabled:           t.GetEndpointTlsEnabled(),
+		EndpointTlsCaCert:            t.GetEndpointTlsCaCert(),
+		EndpointTlsServerName:        t.GetEndpointTlsServerName(),
+		WorkerFilter:                 t.GetWorkerFilter(),
+		EgressWorkerFilter:           t.GetEgressWorkerFilter(),
+		IngressWorkerFilter:          t.GetIngressWorkerFilter(),
+		DynamicCredentials:           dynCreds,
+		StaticCredentials:            staticCreds,
 	}
 	sess, err := session.New(sessionComposition)
 	if err != nil {
@@ -1088,6 +1318,24 @@ func (s Service) createInRepo(ctx context.Context, item *pb.Target) (target.Targ
 	if item.GetSessionConnectionLimit() != nil {
 		opts = append(opts, target.WithSessionConnectionLimit(item.GetSessionConnectionLimit().GetValue()))
 	}
+	if item.GetSessionMaxBytes() != nil {
+		opts = append(opts, target.WithSessionMaxBytes(item.GetSessionMaxBytes().GetValue()))
+	}
+	if item.GetConnectionMaxBytesPerSecond() != nil {
+		opts = append(opts, target.WithConnectionMaxBytesPerSecond(item.GetConnectionMaxBytesPerSecond().GetValue()))
+	}
+	if item.GetConnectionIdleTimeoutSeconds() != nil {
+		opts = append(opts, target.WithConnectionIdleTimeoutSeconds(item.GetConnectionIdleTimeoutSeconds().GetValue()))
+	}
+	if item.GetEndpointTlsEnabled() != nil {
+		opts = append(opts, target.WithEndpointTlsEnabled(item.GetEndpointTlsEnabled().GetValue()))
+	}
+	if item.GetEndpointTlsCaCert() != nil {
+		opts = append(opts, target.WithEndpointTlsCaCert(item.GetEndpointTlsCaCert().GetValue()))
+	}
+	if item.GetEndpointTlsServerName() != nil {
+		opts = append(opts, target.WithEndpointTlsServerName(item.GetEndpointTlsServerName().GetValue()))
+	}
 	if item.GetEgressWorkerFilter() != nil {
 		opts = append(opts, target.WithEgressWorkerFilter(item.GetEgressWorkerFilter().GetValue()))
 	}
@@ -1141,6 +1389,24 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if item.GetSessionConnectionLimit() != nil {
 		opts = append(opts, target.WithSessionConnectionLimit(item.GetSessionConnectionLimit().GetValue()))
 	}
+	if item.GetSessionMaxBytes() != nil {
+		opts = append(opts, target.WithSessionMaxBytes(item.GetSessionMaxBytes().GetValue()))
+	}
+	if item.GetConnectionMaxBytesPerSecond() != nil {
+		opts = append(opts, target.WithConnectionMaxBytesPerSecond(item.GetConnectionMaxBytesPerSecond().GetValue()))
+	}
+	if item.GetConnectionIdleTimeoutSeconds() != nil {
+		opts = append(opts, target.WithConnectionIdleTimeoutSeconds(item.GetConnectionIdleTimeoutSeconds().GetValue()))
+	}
+	if item.GetEndpointTlsEnabled() != nil {
+		opts = append(opts, target.WithEndpointTlsEnabled(item.GetEndpointTlsEnabled().GetValue()))
+	}
+	if item.GetEndpointTlsCaCert() != nil {
+		opts = append(opts, target.WithEndpointTlsCaCert(item.GetEndpointTlsCaCert().GetValue()))
+	}
+	if item.GetEndpointTlsServerName() != nil {
+		opts = append(opts, target.WithEndpointTlsServerName(item.GetEndpointTlsServerName().GetValue()))
+	}
 	// worker_filter is deprecated, but we allow users who have migrated with a worker_filter value to update it.
 	if workerFilter := item.GetWorkerFilter(); workerFilter != nil {
 		opts = append(opts, target.WithWorkerFilter(item.GetWorkerFilter().GetValue()))
@@ -1398,6 +1664,80 @@ func (s Service) removeCredentialSourcesInRepo(ctx context.Context, targetId str
 	return out, hs, credSources, nil
 }
 
+func (s Service) lookupTags(ctx context.Context, targetId string) (map[string][]string, error) {
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	tags, err := iamRepo.ListResourceTags(ctx, []string{targetId})
+	if err != nil {
+		return nil, err
+	}
+	return tags[targetId], nil
+}
+
+// checkTagsVersion ensures tags are only changed against the version of the
+// target that the caller provided. Changing tags does not increment the
+// target's version.
+func checkTagsVersion(ctx context.Context, authResults auth.VerifyResults, version uint32) (target.Target, error) {
+	const op = "targets.checkTagsVersion"
+	t, ok := authResults.RoundTripValue.(target.Target)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no target found in auth results")
+	}
+	if t.GetVersion() != version {
+		return nil, handlers.NotFoundErrorf("Target %q doesn't exist or incorrect version provided.", t.GetPublicId())
+	}
+	return t, nil
+}
+
+func (s Service) addTagsInRepo(ctx context.Context, authResults auth.VerifyResults, version uint32, tags map[string][]string) (map[string][]string, error) {
+	t, err := checkTagsVersion(ctx, authResults, version)
+	if err != nil {
+		return nil, err
+	}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := iamRepo.AddResourceTags(ctx, t.GetPublicId(), tags)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add target tags in repo: %v.", err)
+	}
+	return out, nil
+}
+
+func (s Service) setTagsInRepo(ctx context.Context, authResults auth.VerifyResults, version uint32, tags map[string][]string) (map[string][]string, error) {
+	t, err := checkTagsVersion(ctx, authResults, version)
+	if err != nil {
+		return nil, err
+	}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := iamRepo.SetResourceTags(ctx, t.GetPublicId(), tags)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set target tags in repo: %v.", err)
+	}
+	return out, nil
+}
+
+func (s Service) removeTagsInRepo(ctx context.Context, authResults auth.VerifyResults, version uint32, tags map[string][]string) (map[string][]string, error) {
+	t, err := checkTagsVersion(ctx, authResults, version)
+	if err != nil {
+		return nil, err
+	}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := iamRepo.DeleteResourceTags(ctx, t.GetPublicId(), tags); err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove target tags in repo: %v.", err)
+	}
+	return s.lookupTags(ctx, t.GetPublicId())
+}
+
 func (s Service) authResult(ctx context.Context, id string, a action.Type, lookupOpt ...target.Option) auth.VerifyResults {
 	res := auth.VerifyResults{}
 
@@ -1443,7 +1783,17 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type, looku
 		}
 		id = t.GetPublicId()
 		parentId = t.GetProjectId()
-		opts = append(opts, auth.WithId(id))
+		iamRepo, err := s.iamRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		tags, err := iamRepo.ListResourceTags(ctx, []string{id})
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		opts = append(opts, auth.WithId(id), auth.WithName(t.GetName()), auth.WithTags(tags[id]))
 	}
 	opts = append(opts, auth.WithScopeId(parentId))
 	ret := auth.Verify(ctx, opts...)
@@ -1492,6 +1842,24 @@ func toProto(ctx context.Context, in target.Target, opt ...handlers.Option) (*pb
 	if outputFields.Has(globals.SessionConnectionLimitField) {
 		out.SessionConnectionLimit = wrapperspb.Int32(in.GetSessionConnectionLimit())
 	}
+	if outputFields.Has(globals.SessionMaxBytesField) {
+		out.SessionMaxBytes = wrapperspb.Int64(in.GetSessionMaxBytes())
+	}
+	if outputFields.Has(globals.ConnectionMaxBytesPerSecondField) {
+		out.ConnectionMaxBytesPerSecond = wrapperspb.Int64(in.GetConnectionMaxBytesPerSecond())
+	}
+	if outputFields.Has(globals.ConnectionIdleTimeoutSecondsField) {
+		out.ConnectionIdleTimeoutSeconds = wrapperspb.UInt32(in.GetConnectionIdleTimeoutSeconds())
+	}
+	if outputFields.Has(globals.EndpointTlsEnabledField) {
+		out.EndpointTlsEnabled = wrapperspb.Bool(in.GetEndpointTlsEnabled())
+	}
+	if outputFields.Has(globals.EndpointTlsCaCertField) && in.GetEndpointTlsCaCert() != "" {
+		out.EndpointTlsCaCert = wrapperspb.String(in.GetEndpointTlsCaCert())
+	}
+	if outputFields.Has(globals.EndpointTlsServerNameField) && in.GetEndpointTlsServerName() != "" {
+		out.EndpointTlsServerName = wrapperspb.String(in.GetEndpointTlsServerName())
+	}
 	if outputFields.Has(globals.WorkerFilterField) && in.GetWorkerFilter() != "" {
 		out.WorkerFilter = wrapperspb.String(in.GetWorkerFilter())
 	}
@@ -1507,6 +1875,13 @@ func toProto(ctx context.Context, in target.Target, opt ...handlers.Option) (*pb
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
+	if outputFields.Has(globals.ResourceTagsField) && len(opts.WithTags) > 0 {
+		var err error
+		out.ResourceTags, err = handlers.TagsToProto(opts.WithTags)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to convert tags"))
+		}
+	}
 	if outputFields.Has(globals.HostSourceIdsField) {
 		for _, hs := range hostSources {
 			out.HostSourceIds = append(out.HostSourceIds, hs.Id())
@@ -1602,9 +1977,18 @@ func validateCreateRequest(req *pbs.CreateTargetRequest) error {
 				badFields[globals.SessionConnectionLimitField] = "This must be -1 (unlimited) or greater than zero."
 			}
 		}
+		if req.GetItem().GetSessionMaxBytes() != nil && req.GetItem().GetSessionMaxBytes().GetValue() < 0 {
+			badFields[globals.SessionMaxBytesField] = "This must be 0 (unlimited) or greater than zero."
+		}
+		if req.GetItem().GetConnectionMaxBytesPerSecond() != nil && req.GetItem().GetConnectionMaxBytesPerSecond().GetValue() < 0 {
+			badFields[globals.ConnectionMaxBytesPerSecondField] = "This must be 0 (unlimited) or greater than zero."
+		}
 		if req.GetItem().GetSessionMaxSeconds() != nil && req.GetItem().GetSessionMaxSeconds().GetValue() == 0 {
 			badFields[globals.SessionMaxSecondsField] = "This must be greater than zero."
 		}
+		if caCert := req.GetItem().GetEndpointTlsCaCert(); caCert != nil && !x509.NewCertPool().AppendCertsFromPEM([]byte(caCert.GetValue())) {
+			badFields[globals.EndpointTlsCaCertField] = "This must contain at least one PEM encoded certificate."
+		}
 		if req.GetItem().GetType() == "" {
 			badFields[globals.TypeField] = "This is a required field."
 		} else if target.SubtypeFromType(req.GetItem().GetType()) == "" {
@@ -1672,9 +2056,18 @@ func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
 				badFields[globals.SessionConnectionLimitField] = "This must be -1 (unlimited) or greater than zero."
 			}
 		}
+		if req.GetItem().GetSessionMaxBytes() != nil && req.GetItem().GetSessionMaxBytes().GetValue() < 0 {
+			badFields[globals.SessionMaxBytesField] = "This must be 0 (unlimited) or greater than zero."
+		}
+		if req.GetItem().GetConnectionMaxBytesPerSecond() != nil && req.GetItem().GetConnectionMaxBytesPerSecond().GetValue() < 0 {
+			badFields[globals.ConnectionMaxBytesPerSecondField] = "This must be 0 (unlimited) or greater than zero."
+		}
 		if req.GetItem().GetSessionMaxSeconds() != nil && req.GetItem().GetSessionMaxSeconds().GetValue() == 0 {
 			badFields[globals.SessionMaxSecondsField] = "This must be greater than zero."
 		}
+		if caCert := req.GetItem().GetEndpointTlsCaCert(); caCert != nil && !x509.NewCertPool().AppendCertsFromPEM([]byte(caCert.GetValue())) {
+			badFields[globals.EndpointTlsCaCertField] = "This must contain at least one PEM encoded certificate."
+		}
 		// worker_filter is mutually exclusive from ingress and egress filter
 		workerFilterFound := false
 		if workerFilter := req.GetItem().GetWorkerFilter(); workerFilter != nil {
@@ -1856,13 +2249,16 @@ func validateAddCredentialSourcesRequest(req *pbs.AddTargetCredentialSourcesRequ
 			break
 		}
 	}
+	// TLS certificate credentials can only be injected, since brokering them
+	// would send their private key to the client.
 	for _, cl := range req.GetInjectedApplicationCredentialSourceIds() {
 		if !handlers.ValidId(handlers.Id(cl),
 			globals.VaultCredentialLibraryPrefix,
 			globals.VaultSshCertificateCredentialLibraryPrefix,
 			globals.UsernamePasswordCredentialPrefix,
 			globals.UsernamePasswordCredentialPreviousPrefix,
-			globals.SshPrivateKeyCredentialPrefix) {
+			globals.SshPrivateKeyCredentialPrefix,
+			globals.TlsCertificateCredentialPrefix) {
 			badFields[globals.InjectedApplicationCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
@@ -1904,13 +2300,16 @@ func validateSetCredentialSourcesRequest(req *pbs.SetTargetCredentialSourcesRequ
 			break
 		}
 	}
+	// TLS certificate credentials can only be injected, since brokering them
+	// would send their private key to the client.
 	for _, cl := range req.GetInjectedApplicationCredentialSourceIds() {
 		if !handlers.ValidId(handlers.Id(cl),
 			globals.VaultCredentialLibraryPrefix,
 			globals.VaultSshCertificateCredentialLibraryPrefix,
 			globals.UsernamePasswordCredentialPrefix,
 			globals.UsernamePasswordCredentialPreviousPrefix,
-			globals.SshPrivateKeyCredentialPrefix) {
+			globals.SshPrivateKeyCredentialPrefix,
+			globals.TlsCertificateCredentialPrefix) {
 			badFields[globals.InjectedApplicationCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
@@ -1940,7 +2339,8 @@ func validateRemoveCredentialSourcesRequest(req *pbs.RemoveTargetCredentialSourc
 			globals.UsernamePasswordCredentialPrefix,
 			globals.UsernamePasswordCredentialPreviousPrefix,
 			globals.SshPrivateKeyCredentialPrefix,
-			globals.JsonCredentialPrefix) {
+			globals.JsonCredentialPrefix,
+			globals.TlsCertificateCredentialPrefix) {
 			badFields[globals.ApplicationCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
@@ -1951,7 +2351,8 @@ func validateRemoveCredentialSourcesRequest(req *pbs.RemoveTargetCredentialSourc
 			globals.UsernamePasswordCredentialPrefix,
 			globals.UsernamePasswordCredentialPreviousPrefix,
 			globals.SshPrivateKeyCredentialPrefix,
-			globals.JsonCredentialPrefix) {
+			globals.JsonCredentialPrefix,
+			globals.TlsCertificateCredentialPrefix) {
 			badFields[globals.BrokeredCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
@@ -1963,7 +2364,8 @@ func validateRemoveCredentialSourcesRequest(req *pbs.RemoveTargetCredentialSourc
 			globals.UsernamePasswordCredentialPrefix,
 			globals.UsernamePasswordCredentialPreviousPrefix,
 			globals.SshPrivateKeyCredentialPrefix,
-			globals.JsonCredentialPrefix) {
+			globals.JsonCredentialPrefix,
+			globals.TlsCertificateCredentialPrefix) {
 			badFields[globals.InjectedApplicationCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
@@ -1974,6 +2376,51 @@ func validateRemoveCredentialSourcesRequest(req *pbs.RemoveTargetCredentialSourc
 	return nil
 }
 
+func validateAddTagsRequest(req *pbs.AddTargetTagsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.Prefixes()...) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	handlers.ValidateTags(badFields, req.GetTags(), false)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateSetTagsRequest(req *pbs.SetTargetTagsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.Prefixes()...) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	handlers.ValidateTags(badFields, req.GetTags(), true)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateRemoveTagsRequest(req *pbs.RemoveTargetTagsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.Prefixes()...) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	handlers.ValidateTags(badFields, req.GetTags(), false)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
 func validateAuthorizeSessionRequest(req *pbs.AuthorizeSessionRequest) error {
 	badFields := map[string]string{}
 	nameEmpty := req.GetName() == ""
@@ -15,6 +15,7 @@ import (
 	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/globals"
@@ -77,6 +78,9 @@ var testAuthorizedActions = []string{
 	"add-credential-sources",
 	"set-credential-sources",
 	"remove-credential-sources",
+	"add-tags",
+	"set-tags",
+	"remove-tags",
 	"authorize-session",
 }
 
@@ -411,6 +415,175 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestList_DescendantsGrantScope(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, kms)
+	}
+
+	org1, proj1 := iam.TestScopes(t, iamRepo)
+	_, proj2 := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, org1.GetPublicId())
+
+	// The grant only applies through the role's descendants grant scope, so
+	// the parent scope of each listed target must be known to authorize it.
+	r := iam.TestRole(t, conn, scope.Global.String())
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=target;actions=list,read")
+	_, _, err := iamRepo.SetRoleGrantScopes(ctx, r.GetPublicId(), r.GetVersion(), []string{globals.GrantScopeDescendants})
+	require.NoError(t, err)
+
+	tar1 := tcp.TestTarget(ctx, t, conn, proj1.GetPublicId(), "target one")
+	tar2 := tcp.TestTarget(ctx, t, conn, proj2.GetPublicId(), "target two")
+
+	s, err := testService(t, ctx, conn, kms, wrapper)
+	require.NoError(t, err, "Couldn't create new target service.")
+
+	requestInfo := authpb.RequestInfo{
+		TokenFormat: uint32(auth.AuthTokenTypeBearer),
+		PublicId:    at.GetPublicId(),
+		Token:       at.GetToken(),
+	}
+	requestContext := context.WithValue(context.Background(), requests.ContextRequestInformationKey, &requests.RequestContext{})
+	ctx = auth.NewVerifierContext(requestContext, iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+
+	got, err := s.ListTargets(ctx, &pbs.ListTargetsRequest{ScopeId: scope.Global.String(), Recursive: true})
+	require.NoError(t, err)
+	require.Len(t, got.GetItems(), 2)
+	gotIds := make([]string, 0, len(got.GetItems()))
+	for _, item := range got.GetItems() {
+		gotIds = append(gotIds, item.GetId())
+		assert.ElementsMatch(t, []string{"read"}, item.GetAuthorizedActions(), "target %s", item.GetId())
+		assert.NotEmpty(t, item.GetName(), "target %s", item.GetId())
+	}
+	assert.ElementsMatch(t, []string{tar1.GetPublicId(), tar2.GetPublicId()}, gotIds)
+}
+
+func TestList_OrgScopedApiKey(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, kms)
+	}
+
+	org1, proj1 := iam.TestScopes(t, iamRepo)
+	_, proj2 := iam.TestScopes(t, iamRepo)
+
+	// The service account's grants cover both projects, but its API key is
+	// limited to the first org. Targets in that org's project are only within
+	// the key's scope through their parent scope.
+	sa := iam.TestServiceAccount(t, conn, scope.Global.String())
+	r := iam.TestRole(t, conn, scope.Global.String())
+	_ = iam.TestServiceAccountRole(t, conn, r.GetPublicId(), sa.GetPublicId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=target;actions=list,read")
+	_, _, err := iamRepo.SetRoleGrantScopes(ctx, r.GetPublicId(), r.GetVersion(), []string{globals.GrantScopeDescendants})
+	require.NoError(t, err)
+	sa, _, err = iamRepo.LookupServiceAccount(ctx, sa.GetPublicId())
+	require.NoError(t, err)
+	key, err := iamRepo.AddServiceAccountApiKey(ctx, sa.GetPublicId(), sa.GetVersion(), org1.GetPublicId(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	tar1 := tcp.TestTarget(ctx, t, conn, proj1.GetPublicId(), "target one")
+	_ = tcp.TestTarget(ctx, t, conn, proj2.GetPublicId(), "target two")
+
+	s, err := testService(t, ctx, conn, kms, wrapper)
+	require.NoError(t, err, "Couldn't create new target service.")
+
+	requestInfo := authpb.RequestInfo{
+		TokenFormat:    uint32(auth.AuthTokenTypeBearer),
+		PublicId:       key.GetPublicId(),
+		EncryptedToken: key.Secret,
+	}
+	requestContext := context.WithValue(context.Background(), requests.ContextRequestInformationKey, &requests.RequestContext{})
+	ctx = auth.NewVerifierContext(requestContext, iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+
+	got, err := s.ListTargets(ctx, &pbs.ListTargetsRequest{ScopeId: scope.Global.String(), Recursive: true})
+	require.NoError(t, err)
+	require.Len(t, got.GetItems(), 1)
+	assert.Equal(t, tar1.GetPublicId(), got.GetItems()[0].GetId())
+	assert.ElementsMatch(t, []string{"read"}, got.GetItems()[0].GetAuthorizedActions())
+	assert.NotEmpty(t, got.GetItems()[0].GetName())
+}
+
+func TestList_DenyByTag(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, kms)
+	}
+
+	org, proj := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	r := iam.TestRole(t, conn, proj.GetPublicId())
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=target;actions=list,read")
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "effect=deny;type=target;tags.env=prod;actions=*")
+
+	dev := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "dev")
+	_, err := iamRepo.SetResourceTags(ctx, dev.GetPublicId(), map[string][]string{"env": {"dev"}})
+	require.NoError(t, err)
+	prod := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "prod")
+	_, err = iamRepo.SetResourceTags(ctx, prod.GetPublicId(), map[string][]string{"env": {"prod"}})
+	require.NoError(t, err)
+	untagged := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "untagged")
+
+	s, err := testService(t, ctx, conn, kms, wrapper)
+	require.NoError(t, err, "Couldn't create new target service.")
+
+	requestInfo := authpb.RequestInfo{
+		TokenFormat: uint32(auth.AuthTokenTypeBearer),
+		PublicId:    at.GetPublicId(),
+		Token:       at.GetToken(),
+	}
+	requestContext := context.WithValue(context.Background(), requests.ContextRequestInformationKey, &requests.RequestContext{})
+	ctx = auth.NewVerifierContext(requestContext, iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+
+	got, err := s.ListTargets(ctx, &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId()})
+	require.NoError(t, err)
+	gotIds := make([]string, 0, len(got.GetItems()))
+	for _, item := range got.GetItems() {
+		gotIds = append(gotIds, item.GetId())
+	}
+	assert.ElementsMatch(t, []string{dev.GetPublicId(), untagged.GetPublicId()}, gotIds)
+}
+
 func TestDelete(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -1835,6 +2008,8 @@ func TestAddTargetCredentialSources(t *testing.T) {
 
 	storeStatic := credstatic.TestCredentialStore(t, conn, wrapper, proj.GetPublicId())
 	creds := credstatic.TestUsernamePasswordCredentials(t, conn, wrapper, "user", "pass", storeStatic.GetPublicId(), proj.GetPublicId(), 2)
+	certificate, privateKey := credstatic.TestTlsCertificate(t)
+	tlsCred := credstatic.TestTlsCertificateCredential(t, conn, wrapper, certificate, privateKey, storeStatic.GetPublicId(), proj.GetPublicId())
 
 	ctx := context.Background()
 	addCases := []struct {
@@ -1965,6 +2140,24 @@ func TestAddTargetCredentialSources(t *testing.T) {
 			},
 			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
 		},
+		{
+			name: "Brokered tls certificate",
+			req: &pbs.AddTargetCredentialSourcesRequest{
+				Id:                          tar.GetPublicId(),
+				Version:                     tar.GetVersion(),
+				BrokeredCredentialSourceIds: []string{tlsCred.GetPublicId()},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Application tls certificate",
+			req: &pbs.AddTargetCredentialSourcesRequest{
+				Id:                             tar.GetPublicId(),
+				Version:                        tar.GetVersion(),
+				ApplicationCredentialSourceIds: []string{tlsCred.GetPublicId()},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 	}
 	for _, tc := range failCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2017,6 +2210,8 @@ func TestSetTargetCredentialSources(t *testing.T) {
 
 	storeStatic := credstatic.TestCredentialStore(t, conn, wrapper, proj.GetPublicId())
 	creds := credstatic.TestUsernamePasswordCredentials(t, conn, wrapper, "user", "pass", storeStatic.GetPublicId(), proj.GetPublicId(), 2)
+	certificate, privateKey := credstatic.TestTlsCertificate(t)
+	tlsCred := credstatic.TestTlsCertificateCredential(t, conn, wrapper, certificate, privateKey, storeStatic.GetPublicId(), proj.GetPublicId())
 
 	ctx := context.Background()
 	setCases := []struct {
@@ -2141,6 +2336,15 @@ func TestSetTargetCredentialSources(t *testing.T) {
 			},
 			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
 		},
+		{
+			name: "Brokered tls certificate",
+			req: &pbs.SetTargetCredentialSourcesRequest{
+				Id:                          tar.GetPublicId(),
+				Version:                     tar.GetVersion(),
+				BrokeredCredentialSourceIds: []string{tlsCred.GetPublicId()},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 	}
 	for _, tc := range failCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2394,6 +2598,158 @@ func TestRemoveTargetCredentialSources(t *testing.T) {
 	}
 }
 
+func TestTargetTags(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, kms)
+	}
+
+	org, proj := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	r := iam.TestRole(t, conn, proj.GetPublicId())
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=*;actions=*")
+
+	// A second user can only read targets tagged env=dev
+	devAt := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	devRole := iam.TestRole(t, conn, proj.GetPublicId())
+	_ = iam.TestUserRole(t, conn, devRole.GetPublicId(), devAt.GetIamUserId())
+	_ = iam.TestRoleGrant(t, conn, devRole.GetPublicId(), "type=target;tags.env=dev;actions=read")
+
+	s, err := testService(t, ctx, conn, kms, wrapper)
+	require.NoError(t, err, "Error when getting new target service.")
+
+	requestCtx := func(at *authtoken.AuthToken) context.Context {
+		requestInfo := authpb.RequestInfo{
+			TokenFormat: uint32(auth.AuthTokenTypeBearer),
+			PublicId:    at.GetPublicId(),
+			Token:       at.GetToken(),
+		}
+		requestContext := context.WithValue(context.Background(), requests.ContextRequestInformationKey, &requests.RequestContext{})
+		return auth.NewVerifierContext(requestContext, iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+	}
+	pbTags := func(tags map[string][]string) map[string]*structpb.ListValue {
+		ret, err := handlers.TagsToProto(tags)
+		require.NoError(t, err)
+		return ret
+	}
+
+	tar := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "tagged")
+	untagged := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "untagged")
+
+	addResp, err := s.AddTargetTags(requestCtx(at), &pbs.AddTargetTagsRequest{
+		Id:      tar.GetPublicId(),
+		Version: tar.GetVersion(),
+		Tags:    pbTags(map[string][]string{"env": {"dev"}, "team": {"db", "web"}}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"env": {"dev"}, "team": {"db", "web"}}, handlers.TagsFromProto(addResp.GetItem().GetResourceTags()))
+	// Tags don't change the version of the target
+	assert.Equal(t, tar.GetVersion(), addResp.GetItem().GetVersion())
+
+	removeResp, err := s.RemoveTargetTags(requestCtx(at), &pbs.RemoveTargetTagsRequest{
+		Id:      tar.GetPublicId(),
+		Version: tar.GetVersion(),
+		Tags:    pbTags(map[string][]string{"team": {"web"}}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"env": {"dev"}, "team": {"db"}}, handlers.TagsFromProto(removeResp.GetItem().GetResourceTags()))
+
+	setResp, err := s.SetTargetTags(requestCtx(at), &pbs.SetTargetTagsRequest{
+		Id:      tar.GetPublicId(),
+		Version: tar.GetVersion(),
+		Tags:    pbTags(map[string][]string{"env": {"dev"}}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"env": {"dev"}}, handlers.TagsFromProto(setResp.GetItem().GetResourceTags()))
+
+	t.Run("tag grants", func(t *testing.T) {
+		got, err := s.GetTarget(requestCtx(devAt), &pbs.GetTargetRequest{Id: tar.GetPublicId()})
+		require.NoError(t, err)
+		assert.Equal(t, tar.GetPublicId(), got.GetItem().GetId())
+
+		_, err = s.GetTarget(requestCtx(devAt), &pbs.GetTargetRequest{Id: untagged.GetPublicId()})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ForbiddenError()))
+
+		_, err = s.AddTargetTags(requestCtx(devAt), &pbs.AddTargetTagsRequest{
+			Id:      untagged.GetPublicId(),
+			Version: untagged.GetVersion(),
+			Tags:    pbTags(map[string][]string{"env": {"dev"}}),
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ForbiddenError()))
+
+		list, err := s.ListTargets(requestCtx(devAt), &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId()})
+		require.NoError(t, err)
+		require.Len(t, list.GetItems(), 1)
+		assert.Equal(t, tar.GetPublicId(), list.GetItems()[0].GetId())
+	})
+
+	failCases := []struct {
+		name string
+		req  *pbs.AddTargetTagsRequest
+		err  error
+	}{
+		{
+			name: "Bad Id",
+			req: &pbs.AddTargetTagsRequest{
+				Id:      "bad id",
+				Version: tar.GetVersion(),
+				Tags:    pbTags(map[string][]string{"env": {"dev"}}),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad version",
+			req: &pbs.AddTargetTagsRequest{
+				Id:      tar.GetPublicId(),
+				Version: tar.GetVersion() + 2,
+				Tags:    pbTags(map[string][]string{"env": {"dev"}}),
+			},
+			err: handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "No tags",
+			req: &pbs.AddTargetTagsRequest{
+				Id:      tar.GetPublicId(),
+				Version: tar.GetVersion(),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Empty tag value",
+			req: &pbs.AddTargetTagsRequest{
+				Id:      tar.GetPublicId(),
+				Version: tar.GetVersion(),
+				Tags:    pbTags(map[string][]string{"env": {""}}),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range failCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			_, gErr := s.AddTargetTags(requestCtx(at), tc.req)
+			require.Error(gErr)
+			assert.True(errors.Is(gErr, tc.err), "AddTargetTags(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+		})
+	}
+}
+
 func TestAuthorizeSession(t *testing.T) {
 	ctx := context.Background()
 	targets.SetupSuiteTargetFilters(t)
@@ -6,12 +6,14 @@ package users
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/roles"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
@@ -40,6 +42,8 @@ var (
 		action.AddAccounts,
 		action.SetAccounts,
 		action.RemoveAccounts,
+		action.ListHistory,
+		action.ListEffectiveGrants,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -123,6 +127,7 @@ func (s Service) ListUsers(ctx context.Context, req *pbs.ListUsersRequest) (*pbs
 	for _, item := range ul {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetScopeId()
+		res.ParentScopeId = scopeInfoMap[item.GetScopeId()].GetParentScopeId()
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
 			continue
@@ -393,6 +398,66 @@ func (s Service) RemoveUserAccounts(ctx context.Context, req *pbs.RemoveUserAcco
 	return &pbs.RemoveUserAccountsResponse{Item: item}, nil
 }
 
+// ListUserHistory implements the interface pbs.UserServiceServer.
+func (s Service) ListUserHistory(ctx context.Context, req *pbs.ListUserHistoryRequest) (*pbs.ListUserHistoryResponse, error) {
+	const op = "users.(Service).ListUserHistory"
+	if err := validateListHistoryRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ListHistory)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	history, err := repo.ListHistory(ctx, req.GetId(), roles.HistoryOptions(req)...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	items, err := roles.HistoryToProto(history)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return &pbs.ListUserHistoryResponse{Items: items}, nil
+}
+
+// ListUserEffectiveGrants implements the interface pbs.UserServiceServer.
+func (s Service) ListUserEffectiveGrants(ctx context.Context, req *pbs.ListUserEffectiveGrantsRequest) (*pbs.ListUserEffectiveGrantsResponse, error) {
+	const op = "users.(Service).ListUserEffectiveGrants"
+	if err := validateListEffectiveGrantsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ListEffectiveGrants)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	var grants []perms.GrantTuple
+	if req.GetAt() == nil {
+		grants, err = repo.GrantsForUser(ctx, req.GetId())
+	} else {
+		grants, err = repo.GrantsForUserAt(ctx, req.GetId(), req.GetAt().AsTime())
+	}
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	items := make([]*pb.EffectiveGrant, 0, len(grants))
+	for _, g := range grants {
+		items = append(items, &pb.EffectiveGrant{
+			RoleId:       g.RoleId,
+			RoleScopeId:  g.RoleScopeId,
+			GrantScopeId: g.GrantScopeId,
+			Grant:        g.Grant,
+		})
+	}
+	return &pbs.ListUserEffectiveGrantsResponse{Items: items}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*iam.User, []string, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -669,6 +734,22 @@ func validateGetRequest(req *pbs.GetUserRequest) error {
 	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, globals.UserPrefix)
 }
 
+func validateListHistoryRequest(req *pbs.ListUserHistoryRequest) error {
+	return handlers.ValidateGetRequest(func() map[string]string {
+		return roles.ValidateHistoryRequest(req)
+	}, req, globals.UserPrefix)
+}
+
+func validateListEffectiveGrantsRequest(req *pbs.ListUserEffectiveGrantsRequest) error {
+	return handlers.ValidateGetRequest(func() map[string]string {
+		badFields := map[string]string{}
+		if req.GetAt() != nil && req.GetAt().AsTime().After(time.Now()) {
+			badFields["at"] = "Must not be in the future."
+		}
+		return badFields
+	}, req, globals.UserPrefix)
+}
+
 func validateCreateRequest(req *pbs.CreateUserRequest) error {
 	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/globals"
@@ -36,7 +37,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "list-history", "list-effective-grants"}
 
 func createDefaultUserAndRepo(t *testing.T, withAccts bool) (*iam.User, []string, func() (*iam.Repository, error)) {
 	t.Helper()
@@ -1220,3 +1221,64 @@ func TestRemoveAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestListUserEffectiveGrants(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	repo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return repo, nil
+	}
+	s, err := users.NewService(repoFn)
+	require.NoError(t, err)
+
+	o, _ := iam.TestScopes(t, repo, iam.WithSkipAdminRoleCreation(true), iam.WithSkipDefaultRoleCreation(true))
+	u := iam.TestUser(t, repo, o.GetPublicId())
+	r, err := iam.NewRole(o.GetPublicId())
+	require.NoError(t, err)
+	r, err = repo.CreateRole(ctx, r)
+	require.NoError(t, err)
+	_, err = repo.AddRoleGrants(ctx, r.GetPublicId(), r.GetVersion(), []string{"ids=*;type=*;actions=read"})
+	require.NoError(t, err)
+	_, err = repo.AddPrincipalRoles(ctx, r.GetPublicId(), r.GetVersion()+1, []string{u.GetPublicId()})
+	require.NoError(t, err)
+
+	want := &pb.EffectiveGrant{
+		RoleId:       r.GetPublicId(),
+		RoleScopeId:  o.GetPublicId(),
+		GrantScopeId: o.GetPublicId(),
+		Grant:        "ids=*;type=*;actions=read",
+	}
+	hasWant := func(t *testing.T, items []*pb.EffectiveGrant) {
+		t.Helper()
+		var found bool
+		for _, item := range items {
+			if proto.Equal(want, item) {
+				found = true
+			}
+		}
+		assert.True(t, found, "grant %v not found in %v", want, items)
+	}
+
+	t.Run("current", func(t *testing.T) {
+		got, err := s.ListUserEffectiveGrants(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), &pbs.ListUserEffectiveGrantsRequest{Id: u.GetPublicId()})
+		require.NoError(t, err)
+		hasWant(t, got.GetItems())
+	})
+
+	t.Run("at", func(t *testing.T) {
+		got, err := s.ListUserEffectiveGrants(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), &pbs.ListUserEffectiveGrantsRequest{Id: u.GetPublicId(), At: timestamppb.Now()})
+		require.NoError(t, err)
+		hasWant(t, got.GetItems())
+	})
+
+	t.Run("future", func(t *testing.T) {
+		_, err := s.ListUserEffectiveGrants(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), &pbs.ListUserEffectiveGrantsRequest{
+			Id: u.GetPublicId(),
+			At: timestamppb.New(time.Now().Add(time.Hour)),
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api"
 	authpb "github.com/hashicorp/boundary/internal/gen/controller/auth"
@@ -80,7 +81,7 @@ func requestCtxInterceptor(
 	// Authorization unary interceptor function to handle authorize per RPC call
 	return func(interceptorCtx context.Context,
 		req any,
-		_ *grpc.UnaryServerInfo,
+		serverInfo *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (any, error) {
 		md, ok := metadata.FromIncomingContext(interceptorCtx)
@@ -143,6 +144,10 @@ func requestCtxInterceptor(
 			return nil, errors.Wrap(interceptorCtx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to create context with eventer"))
 		}
 
+		// Attribute the database operations of the request that are not
+		// performed by a repository method to the request's method
+		interceptorCtx = db.NewOpContext(interceptorCtx, serverInfo.FullMethod)
+
 		// Calls the handler
 		h, err := handler(interceptorCtx, req)
 
@@ -23,6 +23,7 @@ const (
 	workerConnectionMaintenanceInterval = 3 * time.Second
 	statusInterval                      = 10 * time.Second
 	terminationInterval                 = 1 * time.Minute
+	replicaLagInterval                  = 5 * time.Second
 )
 
 // This is exported so it can be tweaked in tests
@@ -46,6 +47,28 @@ func (c *Controller) startStatusTicking(cancelCtx context.Context) {
 	}
 }
 
+// startReplicaLagTicking periodically checks the replication lag of the
+// database's read replicas, if there are any, so that lagging replicas stop
+// serving reads until they catch up. The replicas are looked up on every tick
+// as they can change when the configuration is reloaded.
+func (c *Controller) startReplicaLagTicking(cancelCtx context.Context) {
+	const op = "controller.(Controller).startReplicaLagTicking"
+	timer := time.NewTimer(replicaLagInterval)
+	for {
+		select {
+		case <-cancelCtx.Done():
+			event.WriteSysEvent(cancelCtx, op, "replica lag ticking shutting down")
+			return
+
+		case <-timer.C:
+			if replicas := c.conf.Database.Replicas(); replicas != nil {
+				replicas.CheckLag(cancelCtx)
+			}
+			timer.Reset(replicaLagInterval)
+		}
+	}
+}
+
 func (c *Controller) upsertController(ctx context.Context) error {
 	const op = "controller.(Controller).upsertController"
 	controller := &store.Controller{
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package worker
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+)
+
+// endpointTlsConfig returns the tls.Config used by the worker to establish
+// TLS with a session's endpoint. If caCert is empty the endpoint's certificate
+// is verified against the system's roots. If serverName is empty host is used
+// to verify the endpoint's certificate. The first TLS certificate credential
+// in creds, if any, is presented to the endpoint as the client certificate.
+func endpointTlsConfig(ctx context.Context, host, caCert, serverName string, creds []*pbs.Credential) (*tls.Config, error) {
+	const op = "worker.endpointTlsConfig"
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+	}
+	if cfg.ServerName == "" {
+		cfg.ServerName = host
+	}
+	if caCert != "" {
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "no valid certificates found in endpoint tls ca cert")
+		}
+	}
+	for _, c := range creds {
+		tc := c.GetTlsCertificate()
+		if tc == nil {
+			continue
+		}
+		cert, err := tls.X509KeyPair([]byte(tc.GetCertificate()), []byte(tc.GetPrivateKey()))
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to parse tls certificate credential"))
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+		break
+	}
+	return cfg, nil
+}
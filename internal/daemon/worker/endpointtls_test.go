@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package worker
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPemCertificate(t *testing.T) (certificate, privateKey string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}))
+}
+
+func TestEndpointTlsConfig(t *testing.T) {
+	ctx := context.Background()
+	cert, key := testPemCertificate(t)
+	tlsCred := &pbs.Credential{
+		Credential: &pbs.Credential_TlsCertificate{
+			TlsCertificate: &pbs.TlsCertificate{Certificate: cert, PrivateKey: key},
+		},
+	}
+	upCred := &pbs.Credential{
+		Credential: &pbs.Credential_UsernamePassword{
+			UsernamePassword: &pbs.UsernamePassword{Username: "user", Password: "pass"},
+		},
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		cfg, err := endpointTlsConfig(ctx, "db.example.com", "", "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "db.example.com", cfg.ServerName)
+		assert.Nil(t, cfg.RootCAs)
+		assert.Empty(t, cfg.Certificates)
+	})
+	t.Run("server-name-and-ca", func(t *testing.T) {
+		cfg, err := endpointTlsConfig(ctx, "10.0.0.1", cert, "db.example.com", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "db.example.com", cfg.ServerName)
+		assert.NotNil(t, cfg.RootCAs)
+	})
+	t.Run("bad-ca", func(t *testing.T) {
+		_, err := endpointTlsConfig(ctx, "db.example.com", "not a cert", "", nil)
+		assert.Error(t, err)
+	})
+	t.Run("client-certificate", func(t *testing.T) {
+		cfg, err := endpointTlsConfig(ctx, "db.example.com", "", "", []*pbs.Credential{upCred, tlsCred})
+		require.NoError(t, err)
+		assert.Len(t, cfg.Certificates, 1)
+	})
+	t.Run("bad-client-certificate", func(t *testing.T) {
+		_, otherKey := testPemCertificate(t)
+		badCred := &pbs.Credential{
+			Credential: &pbs.Credential_TlsCertificate{
+				TlsCertificate: &pbs.TlsCertificate{Certificate: cert, PrivateKey: otherKey},
+			},
+		}
+		_, err := endpointTlsConfig(ctx, "db.example.com", "", "", []*pbs.Credential{badCred})
+		assert.Error(t, err)
+	})
+}
@@ -23,6 +23,7 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/proxy"
+	sessionrepo "github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/util"
 	"github.com/hashicorp/go-secure-stdlib/listenerutil"
 	"github.com/hashicorp/nodeenrollment"
@@ -206,6 +207,14 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 		}
 		workerId := w.LastStatusSuccess().WorkerId
 
+		if maxBytes := sess.GetMaxBytes(); maxBytes > 0 && sess.AddBytesProxied(0) >= maxBytes {
+			event.WriteError(ctx, op, errByteQuotaExceeded, event.WithInfo("session_id", sessionId))
+			if err = conn.Close(websocket.StatusPolicyViolation, "session byte quota exceeded"); err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+			}
+			return
+		}
+
 		var acResp *pbs.AuthorizeConnectionResponse
 		var connsLeft int32
 		acResp, connsLeft, err = sess.RequestAuthorizeConnection(ctx, workerId, connCancel)
@@ -231,6 +240,13 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 			return
 		}
 
+		// Track activity so the connection can be closed once it has been
+		// idle for longer than the target allows.
+		ic := newIdleConn(cc)
+		// Limit the throughput of the connection and the bytes proxied for
+		// the session, if the target defines either.
+		sc := newShapingConn(connCtx, ic, sess.GetConnectionMaxBytesPerSecond(), sess.GetMaxBytes(), sess.AddBytesProxied)
+
 		defer func() {
 			ccd := map[string]*session.ConnectionCloseData{
 				acResp.GetConnectionId(): {
@@ -239,6 +255,14 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 					BytesDown: cc.BytesWritten(),
 				},
 			}
+			switch {
+			case sc.QuotaExceeded():
+				ccd[acResp.GetConnectionId()].Reason = sessionrepo.ConnectionByteQuota
+				auditByteQuotaExceeded(ctx, sess, acResp.GetConnectionId(), ccd[acResp.GetConnectionId()])
+			case ic.TimedOut():
+				ccd[acResp.GetConnectionId()].Reason = sessionrepo.ConnectionIdleTimeout
+				event.WriteSysEvent(ctx, op, "connection idle timeout", "session_id", sessionId, "connection_id", acResp.GetConnectionId())
+			}
 			if sessionManager.RequestCloseConnections(ctx, ccd) {
 				event.WriteSysEvent(ctx, op, "connection closed", "session_id", sessionId, "connection_id", acResp.GetConnectionId())
 			}
@@ -248,6 +272,8 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 			Expiration:      timestamppb.New(sess.GetExpiration()),
 			ConnectionLimit: sess.GetConnectionLimit(),
 			ConnectionsLeft: connsLeft,
+
+			ConnectionIdleTimeoutSeconds: uint32(sess.GetConnectionIdleTimeout().Seconds()),
 		}
 		if err := wspb.Write(connCtx, conn, handshakeResult); err != nil {
 			event.WriteError(ctx, op, err, event.WithInfoMsg("error sending handshake result to client"))
@@ -281,6 +307,21 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 			event.WriteError(ctx, op, err)
 			return
 		}
+		if sess.GetEndpointTlsEnabled() {
+			// The worker establishes TLS with the endpoint so the client can
+			// connect to it in plaintext.
+			tlsCfg, err := endpointTlsConfig(ctx, endpointUrl.Hostname(), sess.GetEndpointTlsCaCert(), sess.GetEndpointTlsServerName(), sess.GetCredentials())
+			if err != nil {
+				conn.Close(proxyHandlers.WebsocketStatusProtocolSetupError, "unable to build endpoint tls configuration")
+				event.WriteError(ctx, op, err)
+				return
+			}
+			if pDialer, err = proxyHandlers.NewTlsDialer(ctx, pDialer, tlsCfg); err != nil {
+				conn.Close(proxyHandlers.WebsocketStatusProtocolSetupError, "unable to get endpoint tls dialer")
+				event.WriteError(ctx, op, err)
+				return
+			}
+		}
 
 		// Verify the protocol has a supported proxy before calling RequestAuthorizeConnection
 		handleProxyFn, err := proxyHandlers.GetHandler(workerId, acResp.GetProtocolContext())
@@ -294,7 +335,7 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 			conn.Close(proxyHandlers.WebsocketStatusProtocolSetupError, "error getting decryption function")
 			event.WriteError(ctx, op, err)
 		}
-		runProxy, err := handleProxyFn(ctx, decryptFn, cc, pDialer, acResp.GetConnectionId(), protocolCtx)
+		runProxy, err := handleProxyFn(ctx, decryptFn, sc, pDialer, acResp.GetConnectionId(), protocolCtx)
 		if err != nil {
 			conn.Close(proxyHandlers.WebsocketStatusProtocolSetupError, "unable to setup proxying")
 			event.WriteError(ctx, op, err)
@@ -321,10 +362,36 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 			return
 		}
 
+		go ic.watch(connCtx, sess.GetConnectionIdleTimeout(), func() {
+			if err := conn.Close(websocket.StatusPolicyViolation, "connection idle timeout"); err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+			}
+			connCancel()
+		})
+
 		runProxy(ctx)
 	}, nil
 }
 
+// auditByteQuotaExceeded writes an audit event recording that a connection
+// was closed because its session exhausted its byte quota.
+func auditByteQuotaExceeded(ctx context.Context, sess session.Session, connectionId string, data *session.ConnectionCloseData) {
+	const op = "worker.auditByteQuotaExceeded"
+	event.WriteSysEvent(ctx, op, "session byte quota exceeded", "session_id", sess.GetId(), "connection_id", connectionId, "max_bytes", sess.GetMaxBytes())
+	err := event.WriteAudit(ctx, op, event.WithRequest(&event.Request{
+		Operation: "session byte quota exceeded",
+		Details: &pbs.CloseConnectionRequestData{
+			ConnectionId: connectionId,
+			BytesUp:      data.BytesUp,
+			BytesDown:    data.BytesDown,
+			Reason:       data.Reason.String(),
+		},
+	}))
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to write audit event", "session_id", sess.GetId()))
+	}
+}
+
 // credDecryptFn returns a DecryptFn if the worker is a pki worker with
 // WorkerAuthStorage defined. An error is returned if there is an error
 // loading the node credentials.
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package worker
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+// idleConn is a `net.Conn` implementation that records the last time data
+// went across Read() or Write() so that a connection which has been idle in
+// both directions for too long can be closed. Websocket control frames, such
+// as the keepalive pings sent by clients, are not data and do not count as
+// activity. All other `net.Conn` function calls are a pass-through to the
+// underlying `net.Conn`.
+type idleConn struct {
+	net.Conn
+
+	// now and after are time.Now and a stoppable time.After, replaced in
+	// tests.
+	now   func() time.Time
+	after func(time.Duration) (<-chan time.Time, func() bool)
+
+	// lastActivity is the time of the last read or write in unix nanoseconds.
+	lastActivity atomic.Int64
+	timedOut     atomic.Bool
+}
+
+func newIdleConn(conn net.Conn) *idleConn {
+	c := &idleConn{
+		Conn: conn,
+		now:  time.Now,
+		after: func(d time.Duration) (<-chan time.Time, func() bool) {
+			timer := time.NewTimer(d)
+			return timer.C, timer.Stop
+		},
+	}
+	c.lastActivity.Store(c.now().UnixNano())
+	return c
+}
+
+// Read wraps the embedded conn's Read() and records the activity.
+func (c *idleConn) Read(in []byte) (int, error) {
+	n, err := c.Conn.Read(in)
+	if n > 0 {
+		c.lastActivity.Store(c.now().UnixNano())
+	}
+	return n, err
+}
+
+// Write wraps the embedded conn's Write() and records the activity.
+func (c *idleConn) Write(in []byte) (int, error) {
+	n, err := c.Conn.Write(in)
+	if n > 0 {
+		c.lastActivity.Store(c.now().UnixNano())
+	}
+	return n, err
+}
+
+// IdleFor reports how long it has been since data last went across the
+// connection.
+func (c *idleConn) IdleFor() time.Duration {
+	return c.now().Sub(time.Unix(0, c.lastActivity.Load()))
+}
+
+// TimedOut reports whether the connection was closed for being idle.
+func (c *idleConn) TimedOut() bool {
+	return c.timedOut.Load()
+}
+
+// watch blocks until ctx is done or the connection has been idle for at least
+// timeout, in which case onTimeout is called. The idle time is measured from
+// the later of the last activity and the call to watch. A timeout of zero
+// disables the check and watch returns immediately.
+func (c *idleConn) watch(ctx context.Context, timeout time.Duration, onTimeout func()) {
+	if timeout <= 0 {
+		return
+	}
+	c.lastActivity.Store(c.now().UnixNano())
+	wait := timeout
+	for {
+		ch, stop := c.after(wait)
+		select {
+		case <-ctx.Done():
+			stop()
+			return
+		case <-ch:
+		}
+		idle := c.IdleFor()
+		if idle >= timeout {
+			c.timedOut.Store(true)
+			onTimeout()
+			return
+		}
+		wait = timeout - idle
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package worker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClock is a clock that only moves when advanced. Each timer started on
+// it is sent on timers, and fires when the test sends on its channel.
+type testClock struct {
+	mu     sync.Mutex
+	t      time.Time
+	timers chan testTimer
+}
+
+type testTimer struct {
+	d time.Duration
+	c chan time.Time
+}
+
+func newTestClock() *testClock {
+	return &testClock{
+		t:      time.Unix(1_700_000_000, 0),
+		timers: make(chan testTimer),
+	}
+}
+
+func (c *testClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *testClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func (c *testClock) after(d time.Duration) (<-chan time.Time, func() bool) {
+	ch := make(chan time.Time, 1)
+	c.timers <- testTimer{d: d, c: ch}
+	return ch, func() bool { return true }
+}
+
+// nextTimer waits for the next timer to be started and returns it.
+func (c *testClock) nextTimer(t *testing.T) testTimer {
+	t.Helper()
+	select {
+	case tm := <-c.timers:
+		return tm
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no timer started")
+		return testTimer{}
+	}
+}
+
+func newTestIdleConn(conn *testNetConn, clk *testClock) *idleConn {
+	c := newIdleConn(conn)
+	c.now = clk.now
+	c.after = clk.after
+	c.lastActivity.Store(clk.now().UnixNano())
+	return c
+}
+
+func TestIdleConn_Watch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("times-out", func(t *testing.T) {
+		clk := newTestClock()
+		conn := newTestIdleConn(&testNetConn{bytesToRead: 10}, clk)
+		timedOut := make(chan struct{})
+		go conn.watch(context.Background(), 50*time.Millisecond, func() { close(timedOut) })
+
+		tm := clk.nextTimer(t)
+		assert.Equal(t, 50*time.Millisecond, tm.d)
+		clk.advance(50 * time.Millisecond)
+		tm.c <- clk.now()
+
+		select {
+		case <-timedOut:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "connection did not time out")
+		}
+		assert.True(t, conn.TimedOut())
+		assert.Equal(t, 50*time.Millisecond, conn.IdleFor())
+	})
+
+	t.Run("activity-resets", func(t *testing.T) {
+		clk := newTestClock()
+		conn := newTestIdleConn(&testNetConn{bytesToRead: 10}, clk)
+		timedOut := make(chan struct{})
+		go conn.watch(context.Background(), 200*time.Millisecond, func() { close(timedOut) })
+
+		tm := clk.nextTimer(t)
+		assert.Equal(t, 200*time.Millisecond, tm.d)
+
+		// Activity in either direction resets the idle time.
+		clk.advance(100 * time.Millisecond)
+		_, err := conn.Read(make([]byte, 10))
+		require.NoError(t, err)
+		clk.advance(50 * time.Millisecond)
+		_, err = conn.Write([]byte("hello"))
+		require.NoError(t, err)
+
+		// When the timer fires the connection has only been idle since the
+		// write, so the watch waits for the rest of the timeout.
+		clk.advance(50 * time.Millisecond)
+		tm.c <- clk.now()
+		tm = clk.nextTimer(t)
+		assert.Equal(t, 150*time.Millisecond, tm.d)
+		assert.False(t, conn.TimedOut())
+
+		clk.advance(150 * time.Millisecond)
+		tm.c <- clk.now()
+		select {
+		case <-timedOut:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "connection did not time out")
+		}
+		assert.True(t, conn.TimedOut())
+		assert.Equal(t, 200*time.Millisecond, conn.IdleFor())
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		clk := newTestClock()
+		conn := newTestIdleConn(&testNetConn{}, clk)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			conn.watch(ctx, time.Hour, func() { t.Error("unexpected timeout") })
+			close(done)
+		}()
+		clk.nextTimer(t)
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "watch did not return")
+		}
+		assert.False(t, conn.TimedOut())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		conn := newIdleConn(&testNetConn{})
+		conn.watch(context.Background(), 0, func() { t.Error("unexpected timeout") })
+		assert.False(t, conn.TimedOut())
+	})
+}
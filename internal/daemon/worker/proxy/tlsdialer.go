@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// tlsConn is a TLS client connection which reports the ip address and port of
+// the underlying connection so it can be returned by a ProxyDialer's dial
+// function.
+type tlsConn struct {
+	*tls.Conn
+	addr *proxyAddr
+}
+
+// GetIp returns the ip address of the endpoint the connection was made to.
+func (c *tlsConn) GetIp() string {
+	return c.addr.Ip()
+}
+
+// GetPort returns the tcp port of the endpoint the connection was made to.
+func (c *tlsConn) GetPort() uint32 {
+	return c.addr.Port()
+}
+
+// NewTlsDialer returns a ProxyDialer which dials using d and then completes a
+// TLS handshake with the endpoint using cfg before returning the connection.
+// Data written to and read from the returned connection is encrypted between
+// the worker and the endpoint.
+func NewTlsDialer(ctx context.Context, d *ProxyDialer, cfg *tls.Config) (*ProxyDialer, error) {
+	const op = "proxy.NewTlsDialer"
+	switch {
+	case d == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "proxy dialer is nil")
+	case cfg == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "tls config is nil")
+	}
+	return NewProxyDialer(ctx, func(opt ...Option) (net.Conn, error) {
+		c, err := d.Dial(ctx, opt...)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		tc := tls.Client(c, cfg)
+		if err := tc.HandshakeContext(ctx); err != nil {
+			c.Close()
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("tls handshake with endpoint failed"))
+		}
+		return &tlsConn{Conn: tc, addr: d.LastConnectionAddr()}, nil
+	})
+}
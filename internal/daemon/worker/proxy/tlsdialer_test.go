@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package proxy
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTlsCertificate(t *testing.T, name string, usage x509.ExtKeyUsage) (tls.Certificate, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{name},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{usage},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, cert
+}
+
+func TestNewTlsDialer(t *testing.T) {
+	ctx := context.Background()
+	d, err := NewProxyDialer(ctx, func(...Option) (net.Conn, error) {
+		c, _ := net.Pipe()
+		return c, nil
+	})
+	require.NoError(t, err)
+
+	td, err := NewTlsDialer(ctx, nil, &tls.Config{})
+	assert.Error(t, err)
+	assert.Nil(t, td)
+
+	td, err = NewTlsDialer(ctx, d, nil)
+	assert.Error(t, err)
+	assert.Nil(t, td)
+
+	td, err = NewTlsDialer(ctx, d, &tls.Config{})
+	assert.NoError(t, err)
+	assert.NotNil(t, td)
+}
+
+func TestTlsDialer(t *testing.T) {
+	ctx := context.Background()
+	serverCert, serverX509 := testTlsCertificate(t, "endpoint.example.com", x509.ExtKeyUsageServerAuth)
+	clientCert, clientX509 := testTlsCertificate(t, "client", x509.ExtKeyUsageClientAuth)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientX509)
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	})
+	require.NoError(t, err)
+	defer l.Close()
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				io.Copy(c, c)
+			}()
+		}
+	}()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(serverX509)
+	tcpAddr := l.Addr().(*net.TCPAddr)
+
+	newDialer := func(t *testing.T, cfg *tls.Config) *ProxyDialer {
+		t.Helper()
+		d, err := NewProxyDialer(ctx, func(...Option) (net.Conn, error) {
+			return net.Dial("tcp", l.Addr().String())
+		})
+		require.NoError(t, err)
+		td, err := NewTlsDialer(ctx, d, cfg)
+		require.NoError(t, err)
+		return td
+	}
+
+	t.Run("Successful Dial", func(t *testing.T) {
+		d := newDialer(t, &tls.Config{
+			RootCAs:      rootCAs,
+			ServerName:   "endpoint.example.com",
+			Certificates: []tls.Certificate{clientCert},
+		})
+		c, err := d.Dial(ctx)
+		require.NoError(t, err)
+		defer c.Close()
+
+		assert.Equal(t, tcpAddr.IP.String(), d.LastConnectionAddr().Ip())
+		assert.EqualValues(t, tcpAddr.Port, d.LastConnectionAddr().Port())
+
+		_, err = c.Write([]byte("hello"))
+		require.NoError(t, err)
+		buf := make([]byte, 5)
+		_, err = io.ReadFull(c, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(buf))
+	})
+
+	t.Run("Untrusted endpoint", func(t *testing.T) {
+		d := newDialer(t, &tls.Config{
+			ServerName:   "endpoint.example.com",
+			Certificates: []tls.Certificate{clientCert},
+		})
+		c, err := d.Dial(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, c)
+	})
+
+	t.Run("Wrong server name", func(t *testing.T) {
+		d := newDialer(t, &tls.Config{
+			RootCAs:      rootCAs,
+			ServerName:   "other.example.com",
+			Certificates: []tls.Certificate{clientCert},
+		})
+		c, err := d.Dial(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, c)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
@@ -48,6 +49,9 @@ type ConnectionCloseData struct {
 	SessionId string
 	BytesUp   int64
 	BytesDown int64
+	// Reason the connection was closed. If empty, the reason is reported as
+	// unknown.
+	Reason session.ClosedReason
 }
 
 // Session is the local representation of a session.  After initial loading
@@ -70,6 +74,12 @@ type Session interface {
 
 	GetTofuToken() string
 	GetConnectionLimit() int32
+	GetMaxBytes() int64
+	GetConnectionMaxBytesPerSecond() int64
+	GetConnectionIdleTimeout() time.Duration
+	GetEndpointTlsEnabled() bool
+	GetEndpointTlsCaCert() string
+	GetEndpointTlsServerName() string
 	GetEndpoint() string
 	GetHostKeys() ([]crypto.Signer, error)
 	GetCredentials() []*pbs.Credential
@@ -107,6 +117,13 @@ type Session interface {
 	// is returned.
 	ApplyConnectionCounterCallbacks(connId string, bytesUp func() int64, bytesDown func() int64) error
 
+	// AddBytesProxied records that n bytes were proxied for one of this
+	// session's connections and returns the total number of bytes proxied
+	// by this worker across all of the session's connections. The
+	// controller enforces the session's byte quota across all workers. It is
+	// safe for concurrent use.
+	AddBytesProxied(n int64) int64
+
 	// RequestAuthorizeConnection sends an AuthorizeConnection request to
 	// the controller.
 	// It is called by the worker handler after a connection has been received by
@@ -132,6 +149,8 @@ type sess struct {
 	cert        *x509.Certificate
 	sessionId   string
 	tofuToken   string
+
+	bytesProxied atomic.Int64
 }
 
 func newSess(client pbs.SessionServiceClient, resp *pbs.LookupSessionResponse) (*sess, error) {
@@ -233,6 +252,42 @@ func (s *sess) GetConnectionLimit() int32 {
 	return s.resp.GetConnectionLimit()
 }
 
+func (s *sess) GetMaxBytes() int64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.resp.GetMaxBytes()
+}
+
+func (s *sess) GetConnectionMaxBytesPerSecond() int64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.resp.GetConnectionMaxBytesPerSecond()
+}
+
+func (s *sess) GetConnectionIdleTimeout() time.Duration {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return time.Duration(s.resp.GetConnectionIdleTimeoutSeconds()) * time.Second
+}
+
+func (s *sess) GetEndpointTlsEnabled() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.resp.GetEndpointTlsEnabled()
+}
+
+func (s *sess) GetEndpointTlsCaCert() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.resp.GetEndpointTlsCaCert()
+}
+
+func (s *sess) GetEndpointTlsServerName() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.resp.GetEndpointTlsServerName()
+}
+
 func (s *sess) GetEndpoint() string {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -421,6 +476,11 @@ func (s *sess) ApplyConnectionCounterCallbacks(connId string, bytesUp func() int
 	return nil
 }
 
+// AddBytesProxied satisfies the Session interface
+func (s *sess) AddBytesProxied(n int64) int64 {
+	return s.bytesProxied.Add(n)
+}
+
 // activate is a helper worker function that sends session activation request to the
 // controller.
 func activate(ctx context.Context, sessClient pbs.SessionServiceClient, sessionId, tofuToken string, version uint32) (pbs.SESSIONSTATUS, error) {
@@ -548,9 +608,13 @@ func closeConnections(ctx context.Context, sessClient pbs.SessionServiceClient,
 func makeCloseConnectionRequest(closeInfo map[string]*ConnectionCloseData) *pbs.CloseConnectionRequest {
 	closeData := make([]*pbs.CloseConnectionRequestData, 0, len(closeInfo))
 	for connId, data := range closeInfo {
+		reason := session.UnknownReason
+		if data.Reason != "" {
+			reason = data.Reason
+		}
 		closeData = append(closeData, &pbs.CloseConnectionRequestData{
 			ConnectionId: connId,
-			Reason:       session.UnknownReason.String(),
+			Reason:       reason.String(),
 			BytesUp:      data.BytesUp,
 			BytesDown:    data.BytesDown,
 		})
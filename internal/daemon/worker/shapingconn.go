@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package worker
+
+import (
+	"context"
+	stderrors "errors"
+	"net"
+	"sync/atomic"
+
+	"golang.org/x/time/rate"
+)
+
+// errByteQuotaExceeded is returned by a shapingConn once the session it
+// belongs to has proxied its maximum number of bytes.
+var errByteQuotaExceeded = stderrors.New("session byte quota exceeded")
+
+// shapingConn is a `net.Conn` implementation that limits the throughput of
+// Read() and Write() using a token bucket and stops proxying once the session
+// it belongs to has exhausted its byte quota. Both directions share the same
+// bucket and quota. All other `net.Conn` function calls are a pass-through to
+// the underlying `net.Conn`.
+//
+// The quota checked here only counts the bytes proxied by this worker, so it
+// stops a connection as soon as this worker alone exhausts the quota. The
+// controller enforces the quota across all of the session's workers from the
+// bytes they report in their status, canceling the session once it is
+// exhausted.
+type shapingConn struct {
+	net.Conn
+
+	ctx context.Context
+	// limiter is nil when the connection's throughput is unlimited.
+	limiter *rate.Limiter
+	// maxBytes is the session's byte quota; zero means there is no quota.
+	maxBytes int64
+	// addBytes records bytes proxied for the session and returns the
+	// session's running total.
+	addBytes func(int64) int64
+
+	quotaExceeded atomic.Bool
+}
+
+// newShapingConn wraps conn so that it is limited to bytesPerSecond and stops
+// proxying once addBytes reports more than maxBytes. A zero value for either
+// limit disables it. The limiter waits are cancelled with ctx.
+func newShapingConn(ctx context.Context, conn net.Conn, bytesPerSecond, maxBytes int64, addBytes func(int64) int64) *shapingConn {
+	c := &shapingConn{
+		Conn:     conn,
+		ctx:      ctx,
+		maxBytes: maxBytes,
+		addBytes: addBytes,
+	}
+	if bytesPerSecond > 0 {
+		// Allow bursts of up to one second's worth of data.
+		c.limiter = rate.NewLimiter(rate.Limit(bytesPerSecond), int(bytesPerSecond))
+	}
+	return c
+}
+
+// QuotaExceeded reports whether this connection was stopped because the
+// session's byte quota was exhausted.
+func (c *shapingConn) QuotaExceeded() bool {
+	return c.quotaExceeded.Load()
+}
+
+// allowance returns the number of bytes that may be read or written in a
+// single call, given a buffer of length n.
+func (c *shapingConn) allowance(n int) (int, error) {
+	if c.maxBytes > 0 {
+		remaining := c.maxBytes - c.addBytes(0)
+		if remaining <= 0 {
+			c.quotaExceeded.Store(true)
+			return 0, errByteQuotaExceeded
+		}
+		if int64(n) > remaining {
+			n = int(remaining)
+		}
+	}
+	if c.limiter != nil && n > c.limiter.Burst() {
+		n = c.limiter.Burst()
+	}
+	return n, nil
+}
+
+// record accounts for n proxied bytes against the session quota and waits
+// for the token bucket to allow them.
+func (c *shapingConn) record(n int) error {
+	if n <= 0 {
+		return nil
+	}
+	if c.addBytes != nil {
+		c.addBytes(int64(n))
+	}
+	if c.limiter != nil {
+		return c.limiter.WaitN(c.ctx, n)
+	}
+	return nil
+}
+
+// Read wraps the embedded conn's Read(), reading no more than the connection
+// is allowed to proxy.
+func (c *shapingConn) Read(in []byte) (int, error) {
+	max, err := c.allowance(len(in))
+	if err != nil {
+		return 0, err
+	}
+	n, err := c.Conn.Read(in[:max])
+	if rerr := c.record(n); rerr != nil && err == nil {
+		err = rerr
+	}
+	return n, err
+}
+
+// Write wraps the embedded conn's Write(), splitting the data so no single
+// underlying write exceeds what the connection is allowed to proxy.
+func (c *shapingConn) Write(in []byte) (int, error) {
+	var written int
+	for written < len(in) {
+		max, err := c.allowance(len(in) - written)
+		if err != nil {
+			return written, err
+		}
+		n, err := c.Conn.Write(in[written : written+max])
+		written += n
+		if rerr := c.record(n); rerr != nil && err == nil {
+			err = rerr
+		}
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
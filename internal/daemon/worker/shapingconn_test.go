@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShapingConn_Unlimited(t *testing.T) {
+	t.Parallel()
+	var total atomic.Int64
+	underlying := &testNetConn{bytesToRead: 100}
+	conn := newShapingConn(context.Background(), underlying, 0, 0, total.Add)
+
+	read, err := conn.Read(make([]byte, 100))
+	require.NoError(t, err)
+	assert.Equal(t, 100, read)
+
+	written, err := conn.Write([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, 5, written)
+
+	assert.EqualValues(t, 105, total.Load())
+	assert.False(t, conn.QuotaExceeded())
+}
+
+func TestShapingConn_ByteQuota(t *testing.T) {
+	t.Parallel()
+	var total atomic.Int64
+	underlying := &testNetConn{bytesToRead: 6}
+	conn := newShapingConn(context.Background(), underlying, 0, 10, total.Add)
+
+	read, err := conn.Read(make([]byte, 6))
+	require.NoError(t, err)
+	assert.Equal(t, 6, read)
+
+	// Only the remaining 4 bytes of the quota are written.
+	written, err := conn.Write([]byte("hello world"))
+	require.ErrorIs(t, err, errByteQuotaExceeded)
+	assert.Equal(t, 4, written)
+	assert.True(t, conn.QuotaExceeded())
+	assert.EqualValues(t, 10, total.Load())
+
+	// Once the quota is exhausted nothing else is proxied.
+	read, err = conn.Read(make([]byte, 6))
+	require.ErrorIs(t, err, errByteQuotaExceeded)
+	assert.Equal(t, 0, read)
+}
+
+func TestShapingConn_SharedQuota(t *testing.T) {
+	t.Parallel()
+	var total atomic.Int64
+	first := newShapingConn(context.Background(), &testNetConn{}, 0, 10, total.Add)
+	second := newShapingConn(context.Background(), &testNetConn{}, 0, 10, total.Add)
+
+	written, err := first.Write([]byte("0123456789"))
+	require.NoError(t, err)
+	assert.Equal(t, 10, written)
+
+	_, err = second.Write([]byte("a"))
+	require.ErrorIs(t, err, errByteQuotaExceeded)
+	assert.True(t, second.QuotaExceeded())
+}
+
+func TestShapingConn_RateLimit(t *testing.T) {
+	t.Parallel()
+	var total atomic.Int64
+	conn := newShapingConn(context.Background(), &testNetConn{}, 1000, 0, total.Add)
+
+	// The first second's worth of data is allowed as a burst, the next 500
+	// bytes have to wait for the bucket to refill.
+	start := time.Now()
+	written, err := conn.Write(make([]byte, 1500))
+	require.NoError(t, err)
+	assert.Equal(t, 1500, written)
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	canceled := newShapingConn(ctx, &testNetConn{}, 1000, 0, total.Add)
+	_, err = canceled.Write(make([]byte, 1000))
+	require.Error(t, err)
+}
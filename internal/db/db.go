@@ -48,6 +48,14 @@ func StringToDbType(dialect string) (DbType, error) {
 // DB is a wrapper around the ORM
 type DB struct {
 	wrapped *atomic.Pointer[dbw.DB]
+
+	// replicas are the read replicas of the database, if any. They are only
+	// set on databases returned by Open, not those wrapping a transaction.
+	replicas *atomic.Pointer[Replicas]
+
+	// slowQueryThreshold is the duration above which an operation emits a
+	// slow query event. Zero disables the events.
+	slowQueryThreshold time.Duration
 }
 
 type closeDbFn func(context.Context)
@@ -70,40 +78,73 @@ func (db *DB) Swap(ctx context.Context, newDB *DB) (closeDbFn, error) {
 	// Grab the old db to allow for cleanup after swap.
 	oldDbw := db.wrapped.Swap(newDB.wrapped.Load())
 	closeOldDbFn := func(ctx context.Context) {
-		go func() {
-			maxTime := time.Now().Add(CloseSwappedDbDuration)
-			t := time.NewTicker(time.Second)
-			var done bool
-			for {
-				select {
-				case <-t.C:
-					if time.Now().After(maxTime) || done {
-						t.Stop()
-						if err := oldDbw.Close(ctx); err != nil {
-							event.WriteError(ctx, op, errors.Wrap(ctx, err, op))
-						}
-						return
-					}
-					sqlDb, err := oldDbw.SqlDB(ctx)
-					if err != nil {
-						event.WriteError(ctx, op, fmt.Errorf("unable to load old sqldb to check stats"))
-						continue
-					}
-					stats := sqlDb.Stats()
-					if stats.InUse == 0 {
-						done = true
-					}
+		closeWhenIdle(ctx, op, oldDbw)
+	}
 
-				case <-ctx.Done():
+	return closeOldDbFn, nil
+}
+
+// closeWhenIdle closes a database that is no longer used for new queries once
+// its in-flight queries have finished, or after CloseSwappedDbDuration.
+func closeWhenIdle(ctx context.Context, op string, oldDbw *dbw.DB) {
+	go func() {
+		maxTime := time.Now().Add(CloseSwappedDbDuration)
+		t := time.NewTicker(time.Second)
+		var done bool
+		for {
+			select {
+			case <-t.C:
+				if time.Now().After(maxTime) || done {
 					t.Stop()
-					event.WriteError(ctx, op, fmt.Errorf("context canceled before old database connection was closed, aborting"))
+					if err := oldDbw.Close(ctx); err != nil {
+						event.WriteError(ctx, event.Op(op), errors.Wrap(ctx, err, errors.Op(op)))
+					}
 					return
 				}
+				sqlDb, err := oldDbw.SqlDB(ctx)
+				if err != nil {
+					event.WriteError(ctx, event.Op(op), fmt.Errorf("unable to load old sqldb to check stats"))
+					continue
+				}
+				stats := sqlDb.Stats()
+				if stats.InUse == 0 {
+					done = true
+				}
+
+			case <-ctx.Done():
+				t.Stop()
+				event.WriteError(ctx, event.Op(op), fmt.Errorf("context canceled before old database connection was closed, aborting"))
+				return
 			}
-		}()
+		}
+	}()
+}
+
+// SwapReplicas replaces the read replicas of the database with newReplicas,
+// which may be nil to stop reading from replicas. It returns a function that
+// closes the outgoing replicas once they are no longer in use.
+func (db *DB) SwapReplicas(ctx context.Context, newReplicas *Replicas) (closeDbFn, error) {
+	const op = "db.(DB).SwapReplicas"
+	if db == nil || db.replicas == nil {
+		return nil, fmt.Errorf("no current db is present to swap replicas on, aborting")
 	}
+	old := db.replicas.Swap(newReplicas)
+	return func(ctx context.Context) {
+		if old == nil {
+			return
+		}
+		for _, r := range old.replicas {
+			closeWhenIdle(ctx, op, r.db.wrapped.Load())
+		}
+	}, nil
+}
 
-	return closeOldDbFn, nil
+// Replicas returns the read replicas of the database, or nil if it has none.
+func (db *DB) Replicas() *Replicas {
+	if db == nil || db.replicas == nil {
+		return nil
+	}
+	return db.replicas.Load()
 }
 
 // Debug will enable/disable debug info for the connection
@@ -134,7 +175,8 @@ func (d *DB) Close(ctx context.Context) error {
 }
 
 // Open a database connection which is long-lived. The options of
-// WithGormFormatter and WithMaxOpenConnections are supported.
+// WithGormFormatter, WithMaxOpenConnections, WithMaxIdleConnections,
+// WithConnMaxIdleTimeDuration and WithSlowQueryThreshold are supported.
 //
 // Note: Consider if you need to call Close() on the returned DB.  Typically the
 // answer is no, but there are occasions when it's necessary.  See the sql.DB
@@ -181,7 +223,15 @@ func Open(ctx context.Context, dbType DbType, connectionUrl string, opt ...Optio
 		sdb.SetConnMaxIdleTime(*opts.withConnMaxIdleTimeDuration)
 	}
 
-	ret := &DB{wrapped: new(atomic.Pointer[dbw.DB])}
+	if opts.withSlowQueryThreshold < 0 {
+		return nil, fmt.Errorf("slow query threshold cannot be negative")
+	}
+
+	ret := &DB{
+		wrapped:            new(atomic.Pointer[dbw.DB]),
+		replicas:           new(atomic.Pointer[Replicas]),
+		slowQueryThreshold: opts.withSlowQueryThreshold,
+	}
 	ret.wrapped.Store(wrapped)
 	return ret, nil
 }
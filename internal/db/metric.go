@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	databaseSubsystem = "controller_database"
+
+	labelOp          = "op"
+	labelDbOperation = "db_operation"
+
+	// unknownOp is the op of operations performed without an op.
+	unknownOp = "unknown"
+)
+
+// The database operations performed by the methods of Db.
+const (
+	createOperation      = "create"
+	createItemsOperation = "create_items"
+	updateOperation      = "update"
+	deleteOperation      = "delete"
+	deleteItemsOperation = "delete_items"
+	lookupOperation      = "lookup"
+	searchOperation      = "search"
+	execOperation        = "exec"
+	queryOperation       = "query"
+	transactionOperation = "transaction"
+)
+
+// operationDuration collects measurements of how long database operations
+// took, by the op of the function that performed them and the kind of
+// operation.
+var operationDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: globals.MetricNamespace,
+		Subsystem: databaseSubsystem,
+		Name:      "operation_duration_seconds",
+		Help:      "Histogram of the durations of database operations.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	},
+	[]string{labelOp, labelDbOperation},
+)
+
+// poolCollector collects the statistics of the connection pool of a
+// database when metrics are gathered, so the pool of the database it was
+// swapped with is reported after a reload.
+type poolCollector struct {
+	db *DB
+
+	openConnections    *prometheus.Desc
+	idleConnections    *prometheus.Desc
+	inUseConnections   *prometheus.Desc
+	maxOpenConnections *prometheus.Desc
+	waitCount          *prometheus.Desc
+	waitDuration       *prometheus.Desc
+}
+
+func newPoolCollector(db *DB) *poolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(globals.MetricNamespace, databaseSubsystem, name), help, nil, nil)
+	}
+	return &poolCollector{
+		db:                 db,
+		openConnections:    desc("open_connections", "Number of established connections to the database, both in use and idle."),
+		idleConnections:    desc("idle_connections", "Number of idle connections to the database."),
+		inUseConnections:   desc("in_use_connections", "Number of connections to the database currently in use."),
+		maxOpenConnections: desc("max_open_connections", "Maximum number of open connections to the database, 0 if unlimited."),
+		waitCount:          desc("wait_count_total", "Count of the times a connection to the database was waited for."),
+		waitDuration:       desc("wait_duration_seconds_total", "Total time spent waiting for a connection to the database."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.openConnections
+	ch <- c.idleConnections
+	ch <- c.inUseConnections
+	ch <- c.maxOpenConnections
+	ch <- c.waitCount
+	ch <- c.waitDuration
+}
+
+// Collect implements prometheus.Collector.
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	const op = "db.(poolCollector).Collect"
+	ctx := context.Background()
+	sqlDb, err := c.db.SqlDB(ctx)
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to get database to collect connection pool statistics"))
+		return
+	}
+	stats := sqlDb.Stats()
+	ch <- prometheus.MustNewConstMetric(c.openConnections, prometheus.GaugeValue, float64(stats.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.idleConnections, prometheus.GaugeValue, float64(stats.Idle))
+	ch <- prometheus.MustNewConstMetric(c.inUseConnections, prometheus.GaugeValue, float64(stats.InUse))
+	ch <- prometheus.MustNewConstMetric(c.maxOpenConnections, prometheus.GaugeValue, float64(stats.MaxOpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.waitCount, prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stats.WaitDuration.Seconds())
+}
+
+// InitializeCollectors registers the database collectors with r. The
+// statistics of the connection pool of db are only collected if it is not
+// nil.
+func InitializeCollectors(r prometheus.Registerer, db *DB) {
+	if r == nil {
+		return
+	}
+	r.MustRegister(operationDuration)
+	if db != nil {
+		r.MustRegister(newPoolCollector(db))
+	}
+}
+
+// opContextKey is the context key of the op database operations are
+// attributed to.
+type opContextKey struct{}
+
+// NewOpContext returns a context whose database operations are attributed to
+// op in their duration metric and slow query events, unless they are
+// performed with WithOp. Repository methods set their op constant on their
+// context so their operations are attributed to them.
+func NewOpContext(ctx context.Context, op string) context.Context {
+	return context.WithValue(ctx, opContextKey{}, op)
+}
+
+// operationOp returns the op a database operation is attributed to: the op of
+// WithOp, or else that of the context, or else unknownOp.
+func operationOp(ctx context.Context, opt ...Option) string {
+	if op := GetOpts(opt...).withOp; op != "" {
+		return op
+	}
+	if op, ok := ctx.Value(opContextKey{}).(string); ok && op != "" {
+		return op
+	}
+	return unknownOp
+}
+
+// observe records the duration of a database operation that started at
+// start, and emits a slow query event if it took longer than the slow query
+// threshold of the database. It is deferred by the Db method that performs
+// the operation, with the options the method was called with.
+func (rw *Db) observe(ctx context.Context, dbOperation string, start time.Time, opt ...Option) {
+	const op = "db.(Db).observe"
+	d := time.Since(start)
+	caller := operationOp(ctx, opt...)
+	operationDuration.WithLabelValues(caller, dbOperation).Observe(d.Seconds())
+	if threshold := rw.underlying.slowQueryThreshold; threshold > 0 && d > threshold {
+		event.WriteSysEvent(ctx, op, "slow database operation",
+			"op", caller,
+			"db_operation", dbOperation,
+			"duration", d.String(),
+			"threshold", threshold.String())
+	}
+}
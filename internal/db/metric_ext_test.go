@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package db_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitializeCollectors(t *testing.T) {
+	ctx := context.Background()
+	require, assert := require.New(t), assert.New(t)
+	conn, mock := db.TestSetupWithMock(t)
+	reg := prometheus.NewRegistry()
+	db.InitializeCollectors(reg, conn)
+
+	mock.ExpectExec("update test_table").WillReturnResult(sqlmock.NewResult(0, 1))
+	rw := db.New(conn)
+	rowsAffected, err := rw.Exec(ctx, "update test_table set name = 'alice'", nil, db.WithOp("db_test.TestInitializeCollectors"))
+	require.NoError(err)
+	assert.Equal(1, rowsAffected)
+	require.NoError(mock.ExpectationsWereMet())
+
+	families, err := reg.Gather()
+	require.NoError(err)
+	var observed bool
+	var sampleCount uint64
+	for _, f := range families {
+		if f.GetName() != "boundary_controller_database_operation_duration_seconds" {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			labels := make(map[string]string)
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["op"] == "db_test.TestInitializeCollectors" && labels["db_operation"] == "exec" {
+				observed = true
+				sampleCount = m.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	require.True(observed, "the exec operation of the test was not observed")
+	assert.Equal(uint64(1), sampleCount)
+
+	// No connection is in use once the operation is done.
+	expected := `
+# HELP boundary_controller_database_in_use_connections Number of connections to the database currently in use.
+# TYPE boundary_controller_database_in_use_connections gauge
+boundary_controller_database_in_use_connections 0
+`
+	assert.NoError(testutil.GatherAndCompare(reg, strings.NewReader(expected), "boundary_controller_database_in_use_connections"))
+	count, err := testutil.GatherAndCount(reg,
+		"boundary_controller_database_open_connections",
+		"boundary_controller_database_idle_connections",
+		"boundary_controller_database_max_open_connections",
+		"boundary_controller_database_wait_count_total",
+		"boundary_controller_database_wait_duration_seconds_total",
+	)
+	require.NoError(err)
+	assert.Equal(5, count)
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationOp(t *testing.T) {
+	ctx := context.Background()
+	opCtx := NewOpContext(ctx, "iam.(Repository).ListRoles")
+	tests := []struct {
+		name string
+		ctx  context.Context
+		opt  []Option
+		want string
+	}{
+		{name: "none", ctx: ctx, want: unknownOp},
+		{name: "context", ctx: opCtx, want: "iam.(Repository).ListRoles"},
+		{name: "option", ctx: ctx, opt: []Option{WithOp("iam.(Repository).LookupRole")}, want: "iam.(Repository).LookupRole"},
+		{name: "option-over-context", ctx: opCtx, opt: []Option{WithOp("iam.(Repository).LookupRole")}, want: "iam.(Repository).LookupRole"},
+		{name: "empty-context", ctx: NewOpContext(ctx, ""), want: unknownOp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, operationOp(tt.ctx, tt.opt...))
+		})
+	}
+}
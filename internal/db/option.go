@@ -174,6 +174,8 @@ type Options struct {
 	withMaxOpenConnections      int
 	withMaxIdleConnections      *int
 	withConnMaxIdleTimeDuration *time.Duration
+	withSlowQueryThreshold      time.Duration
+	withOp                      string
 
 	// withDebug indicates that the given operation should invoke Gorm's debug
 	// mode
@@ -181,6 +183,9 @@ type Options struct {
 
 	withOnConflict   *OnConflict
 	withRowsAffected *int64
+
+	withReplicaRead bool
+	withMaxLag      time.Duration
 }
 
 type oplogOpts struct {
@@ -340,6 +345,23 @@ func WithConnMaxIdleTimeDuration(max *time.Duration) Option {
 	}
 }
 
+// WithSlowQueryThreshold specifies the duration above which a database
+// operation emits a slow query event. Zero disables the events.
+func WithSlowQueryThreshold(threshold time.Duration) Option {
+	return func(o *Options) {
+		o.withSlowQueryThreshold = threshold
+	}
+}
+
+// WithOp specifies the op of the function performing a database operation,
+// e.g. "iam.(Repository).LookupRole", to label its duration metric and slow
+// query event with. It takes precedence over the op of the context.
+func WithOp(op string) Option {
+	return func(o *Options) {
+		o.withOp = op
+	}
+}
+
 // WithDebug specifies the given operation should invoke debug mode in Gorm
 func WithDebug(with bool) Option {
 	return func(o *Options) {
@@ -362,3 +384,22 @@ func WithReturnRowsAffected(rowsAffected *int64) Option {
 		o.withRowsAffected = rowsAffected
 	}
 }
+
+// WithReplicaRead specifies that a read can be served by a read replica of the
+// database, if any are configured and one is within its maximum replication
+// lag, and by the primary otherwise. Only use it for reads that tolerate data
+// that is slightly out of date, such as listing resources; reads within a
+// transaction always use the transaction.
+func WithReplicaRead(enable bool) Option {
+	return func(o *Options) {
+		o.withReplicaRead = enable
+	}
+}
+
+// WithMaxLag specifies the maximum replication lag of a read replica for it
+// to serve reads.
+func WithMaxLag(max time.Duration) Option {
+	return func(o *Options) {
+		o.withMaxLag = max
+	}
+}
@@ -216,6 +216,24 @@ func Test_getOpts(t *testing.T) {
 		testOpts.withConnMaxIdleTimeDuration = &d
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithSlowQueryThreshold", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts()
+		testOpts := getDefaultOptions()
+		assert.Equal(opts, testOpts)
+		opts = GetOpts(WithSlowQueryThreshold(time.Second))
+		testOpts.withSlowQueryThreshold = time.Second
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithOp", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts()
+		testOpts := getDefaultOptions()
+		assert.Equal(opts, testOpts)
+		opts = GetOpts(WithOp("iam.(Repository).LookupRole"))
+		testOpts.withOp = "iam.(Repository).LookupRole"
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithDebug", func(t *testing.T) {
 		assert := assert.New(t)
 		// test default of false
@@ -255,4 +273,25 @@ func Test_getOpts(t *testing.T) {
 		testOpts.withRowsAffected = &rowsAffected
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithReplicaRead", func(t *testing.T) {
+		assert := assert.New(t)
+		// test default of false
+		opts := GetOpts()
+		testOpts := getDefaultOptions()
+		assert.Equal(opts, testOpts)
+
+		opts = GetOpts(WithReplicaRead(true))
+		testOpts.withReplicaRead = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithMaxLag", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts()
+		testOpts := getDefaultOptions()
+		assert.Equal(opts, testOpts)
+
+		opts = GetOpts(WithMaxLag(time.Minute))
+		testOpts.withMaxLag = time.Minute
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -229,6 +229,7 @@ func (rw *Db) Exec(ctx context.Context, sql string, values []any, opt ...Option)
 	if sql == "" {
 		return NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing sql")
 	}
+	defer rw.observe(ctx, execOperation, time.Now(), opt...)
 	opts := GetOpts(opt...)
 	rowsAffected, err := dbw.New(rw.underlying.wrapped.Load()).Exec(ctx, sql, values, dbw.WithDebug(opts.withDebug))
 	if err != nil {
@@ -241,13 +242,19 @@ func (rw *Db) Exec(ctx context.Context, sql string, values []any, opt ...Option)
 // operate within the context of any ongoing transaction for the db.Reader.  The
 // caller must close the returned *sql.Rows. Query can/should be used in
 // combination with ScanRows.
-func (rw *Db) Query(ctx context.Context, sql string, values []any, opt ...Option) (*sql.Rows, error) {
+func (rw *Db) Query(ctx context.Context, query string, values []any, opt ...Option) (*sql.Rows, error) {
 	const op = "db.Query"
-	if sql == "" {
+	if query == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing sql")
 	}
+	defer rw.observe(ctx, queryOperation, time.Now(), opt...)
 	opts := GetOpts(opt...)
-	rows, err := dbw.New(rw.underlying.wrapped.Load()).Query(ctx, sql, values, dbw.WithDebug(opts.withDebug))
+	var rows *sql.Rows
+	err := rw.read(ctx, opts, func(d *dbw.RW) error {
+		var err error
+		rows, err = d.Query(ctx, query, values, dbw.WithDebug(opts.withDebug))
+		return err
+	})
 	if err != nil {
 		return nil, wrapError(ctx, err, op)
 	}
@@ -289,6 +296,7 @@ func (rw *Db) Create(ctx context.Context, i any, opt ...Option) error {
 	if rw.underlying == nil {
 		return errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
+	defer rw.observe(ctx, createOperation, time.Now(), opt...)
 	dbwOpts, err := getDbwOptions(ctx, rw, i, CreateOp, opt...)
 	if err != nil {
 		return errors.Wrap(ctx, err, op)
@@ -308,6 +316,7 @@ func (rw *Db) CreateItems(ctx context.Context, createItems []any, opt ...Option)
 	if rw.underlying == nil {
 		return errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
+	defer rw.observe(ctx, createItemsOperation, time.Now(), opt...)
 	dbwOpts, err := getDbwOptions(ctx, rw, createItems, CreateItemsOp, opt...)
 	if err != nil {
 		return errors.Wrap(ctx, err, op)
@@ -343,6 +352,7 @@ func (rw *Db) Update(ctx context.Context, i any, fieldMaskPaths []string, setToN
 	if rw.underlying == nil {
 		return NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
+	defer rw.observe(ctx, updateOperation, time.Now(), opt...)
 	optCp := make([]Option, 0, len(opt)+2)
 	optCp = append(optCp, opt...)
 	optCp = append(optCp, WithFieldMaskPaths(fieldMaskPaths), WithNullPaths(setToNullPaths))
@@ -367,6 +377,7 @@ func (rw *Db) Delete(ctx context.Context, i any, opt ...Option) (int, error) {
 	if rw.underlying == nil {
 		return NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
+	defer rw.observe(ctx, deleteOperation, time.Now(), opt...)
 	dbwOpts, err := getDbwOptions(ctx, rw, i, DeleteOp, opt...)
 	if err != nil {
 		return NoRowsAffected, wrapError(ctx, err, op)
@@ -386,6 +397,7 @@ func (rw *Db) DeleteItems(ctx context.Context, deleteItems []any, opt ...Option)
 	if rw.underlying == nil {
 		return NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
+	defer rw.observe(ctx, deleteItemsOperation, time.Now(), opt...)
 	dbwOpts, err := getDbwOptions(ctx, rw, deleteItems, DeleteItemsOp, opt...)
 	if err != nil {
 		return NoRowsAffected, errors.Wrap(ctx, err, op)
@@ -412,6 +424,7 @@ func (rw *Db) DoTx(ctx context.Context, retries uint, backOff Backoff, handler T
 	if handler == nil {
 		return RetryInfo{}, errors.New(ctx, errors.InvalidParameter, op, "missing handler")
 	}
+	defer rw.observe(ctx, transactionOperation, time.Now())
 	info := RetryInfo{}
 	for attempts := uint(1); ; attempts++ {
 		if attempts > retries+1 {
@@ -424,7 +437,7 @@ func (rw *Db) DoTx(ctx context.Context, retries uint, backOff Backoff, handler T
 			return info, wrapError(ctx, err, op)
 		}
 
-		newTxDb := &DB{wrapped: new(atomic.Pointer[dbw.DB])}
+		newTxDb := &DB{wrapped: new(atomic.Pointer[dbw.DB]), slowQueryThreshold: rw.underlying.slowQueryThreshold}
 		newTxDb.wrapped.Store(beginTx.DB())
 		newRW := New(newTxDb)
 
@@ -458,14 +471,18 @@ func (rw *Db) DoTx(ctx context.Context, retries uint, backOff Backoff, handler T
 }
 
 // LookupByPublicId will lookup resource by its public_id or private_id, which
-// must be unique. WithDebug is the only valid option, all other options are ignored.
+// must be unique. WithDebug and WithReplicaRead are the only valid options, all
+// other options are ignored.
 func (rw *Db) LookupById(ctx context.Context, resourceWithIder any, opt ...Option) error {
 	const op = "db.LookupById"
 	if rw.underlying == nil {
 		return errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
+	defer rw.observe(ctx, lookupOperation, time.Now(), opt...)
 	opts := GetOpts(opt...)
-	if err := dbw.New(rw.underlying.wrapped.Load()).LookupBy(ctx, resourceWithIder, dbw.WithDebug(opts.withDebug)); err != nil {
+	if err := rw.read(ctx, opts, func(d *dbw.RW) error {
+		return d.LookupBy(ctx, resourceWithIder, dbw.WithDebug(opts.withDebug))
+	}); err != nil {
 		var errOpts []errors.Option
 		if errors.Is(err, dbw.ErrRecordNotFound) {
 			// Not found is a common workflow in the application layer during lookup, suppress
@@ -478,20 +495,24 @@ func (rw *Db) LookupById(ctx context.Context, resourceWithIder any, opt ...Optio
 }
 
 // LookupByPublicId will lookup resource by its public_id, which must be unique.
-// WithDebug is supported.
+// WithDebug and WithReplicaRead are supported.
 func (rw *Db) LookupByPublicId(ctx context.Context, resource ResourcePublicIder, opt ...Option) error {
 	return rw.LookupById(ctx, resource, opt...)
 }
 
 // LookupWhere will lookup the first resource using a where clause with
-// parameters (it only returns the first one). WithDebug is supported.
+// parameters (it only returns the first one). WithDebug and WithReplicaRead
+// are supported.
 func (rw *Db) LookupWhere(ctx context.Context, resource any, where string, args []any, opt ...Option) error {
 	const op = "db.LookupWhere"
 	if rw.underlying == nil {
 		return errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
+	defer rw.observe(ctx, lookupOperation, time.Now(), opt...)
 	opts := GetOpts(opt...)
-	if err := dbw.New(rw.underlying.wrapped.Load()).LookupWhere(ctx, resource, where, args, dbw.WithDebug(opts.withDebug)); err != nil {
+	if err := rw.read(ctx, opts, func(d *dbw.RW) error {
+		return d.LookupWhere(ctx, resource, where, args, dbw.WithDebug(opts.withDebug))
+	}); err != nil {
 		var errOpts []errors.Option
 		if errors.Is(err, dbw.ErrRecordNotFound) {
 			// Not found is a common workflow in the application layer during lookup, suppress
@@ -509,22 +530,42 @@ func (rw *Db) LookupWhere(ctx context.Context, resource any, where string, args
 //
 // Supports the WithLimit option.  If WithLimit < 0, then unlimited results are returned.
 // If WithLimit == 0, then default limits are used for results.
-// Supports the WithOrder and WithDebug options.
+// Supports the WithOrder, WithDebug and WithReplicaRead options.
 func (rw *Db) SearchWhere(ctx context.Context, resources any, where string, args []any, opt ...Option) error {
 	const op = "db.SearchWhere"
 	if rw.underlying == nil {
 		return errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
+	defer rw.observe(ctx, searchOperation, time.Now(), opt...)
 	dbwOpts, err := getDbwOptions(ctx, rw, resources, SearchOp, opt...)
 	if err != nil {
 		return errors.Wrap(ctx, err, op)
 	}
-	if err := dbw.New(rw.underlying.wrapped.Load()).SearchWhere(ctx, resources, where, args, dbwOpts...); err != nil {
+	if err := rw.read(ctx, GetOpts(opt...), func(d *dbw.RW) error {
+		return d.SearchWhere(ctx, resources, where, args, dbwOpts...)
+	}); err != nil {
 		return wrapError(ctx, err, op)
 	}
 	return nil
 }
 
+// read runs fn against a read replica if the options allow it and one is
+// usable, and against the database otherwise. A read that fails on a replica
+// is retried against the database, unless the record was not found.
+func (rw *Db) read(ctx context.Context, opts Options, fn func(*dbw.RW) error) error {
+	if opts.withReplicaRead {
+		if replicas := rw.underlying.Replicas(); replicas != nil {
+			if r := replicas.reader(); r != nil {
+				err := fn(dbw.New(r))
+				if err == nil || errors.Is(err, dbw.ErrRecordNotFound) || ctx.Err() != nil {
+					return err
+				}
+			}
+		}
+	}
+	return fn(dbw.New(rw.underlying.wrapped.Load()))
+}
+
 func isNil(i any) bool {
 	if i == nil {
 		return true
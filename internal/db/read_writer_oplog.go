@@ -11,11 +11,16 @@ import (
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/hashicorp/boundary/internal/oplog/store"
+	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/go-dbw"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 	"google.golang.org/protobuf/proto"
 )
 
+// OplogActorIdKey is the oplog metadata key recording the ID of the user whose
+// request caused the entry to be written.
+const OplogActorIdKey = "actor-id"
+
 type (
 	beforeWriteFn func(any) error
 	afterWriteFn  func(any, int) error
@@ -256,7 +261,7 @@ func (rw *Db) addOplogForItems(ctx context.Context, opType OpType, opts Options,
 	entry, err := oplog.NewEntry(
 		ctx,
 		replayable.TableName(),
-		oplogArgs.metadata,
+		withActor(ctx, oplogArgs.metadata),
 		oplogArgs.wrapper,
 		ticketer,
 	)
@@ -291,7 +296,7 @@ func (rw *Db) addOplog(ctx context.Context, opType OpType, opts Options, ticket
 	entry, err := oplog.NewEntry(
 		ctx,
 		replayable.TableName(),
-		oplogArgs.metadata,
+		withActor(ctx, oplogArgs.metadata),
 		oplogArgs.wrapper,
 		ticketer,
 	)
@@ -341,7 +346,7 @@ func (rw *Db) WriteOplogEntryWith(ctx context.Context, wrapper wrapping.Wrapper,
 	entry, err := oplog.NewEntry(
 		ctx,
 		ticket.Name,
-		metadata,
+		withActor(ctx, metadata),
 		wrapper,
 		ticketer,
 	)
@@ -389,3 +394,22 @@ func (rw *Db) newOplogMessage(ctx context.Context, opType OpType, i any, opt ...
 	}
 	return &msg, nil
 }
+
+// withActor returns a copy of the metadata with the ID of the user making the
+// request added, if the request has one. The metadata passed in is not
+// modified.
+func withActor(ctx context.Context, metadata oplog.Metadata) oplog.Metadata {
+	reqInfo, ok := requests.RequestContextFromCtx(ctx)
+	if !ok || reqInfo.UserId == "" {
+		return metadata
+	}
+	if _, ok := metadata[OplogActorIdKey]; ok {
+		return metadata
+	}
+	md := make(oplog.Metadata, len(metadata)+1)
+	for k, v := range metadata {
+		md[k] = v
+	}
+	md[OplogActorIdKey] = []string{reqInfo.UserId}
+	return md
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/hashicorp/boundary/internal/oplog/store"
+	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/go-hclog"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 	"github.com/hashicorp/go-secure-stdlib/base62"
@@ -2516,6 +2517,22 @@ func TestDb_WriteOplogEntryWith(t *testing.T) {
 			require.NoError(err)
 		})
 	}
+	t.Run("records-actor", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		rw := New(conn)
+		md := oplog.Metadata{
+			"resource-public-id": []string{"actor-test-" + id},
+		}
+		ctx := requests.NewRequestContext(testCtx, requests.WithUserId("u_1234567890"))
+		require.NoError(rw.WriteOplogEntryWith(ctx, wrapper, ticket, md, []*oplog.Message{&createMsg}))
+		assert.NotContains(md, OplogActorIdKey)
+
+		var found store.Metadata
+		require.NoError(rw.LookupWhere(testCtx, &found, "key = ? and value = ?", []any{"resource-public-id", "actor-test-" + id}))
+		var actor store.Metadata
+		require.NoError(rw.LookupWhere(testCtx, &actor, "entry_id = ? and key = ?", []any{found.EntryId, OplogActorIdKey}))
+		assert.Equal("u_1234567890", actor.Value)
+	})
 }
 
 // func TestClear_InputTypes(t *testing.T) {
@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/go-dbw"
+)
+
+// DefaultMaxReplicaLag is the maximum replication lag of a read replica for
+// it to serve reads when no maximum is configured.
+const DefaultMaxReplicaLag = 5 * time.Second
+
+// replicaCheckTimeout bounds how long measuring the lag of a replica can take.
+const replicaCheckTimeout = 5 * time.Second
+
+// replicaLagQuery returns whether the server is a replica, whether it is
+// receiving WAL from the primary and its replication lag in seconds. A replica
+// that has replayed all of the WAL it received is not lagging, even if its last
+// replayed transaction is old because the primary has been idle, as long as it
+// is still receiving WAL. The status of the WAL receiver is only visible to
+// roles with the privileges of pg_read_all_stats; for others a running
+// receiver is taken to be streaming.
+const replicaLagQuery = `
+select pg_is_in_recovery() as in_recovery,
+       exists (
+         select
+           from pg_stat_wal_receiver
+          where status = 'streaming'
+             or status is null
+       ) as streaming,
+       coalesce(
+         case
+           when pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() then 0
+           else extract(epoch from now() - pg_last_xact_replay_timestamp())
+         end,
+         0
+       )::float8 as lag_seconds;
+`
+
+// Replicas are read replicas of a database. Reads made with WithReplicaRead
+// are spread across the replicas whose replication lag was within the maximum
+// at the last CheckLag, and go to the primary when there are none.
+type Replicas struct {
+	replicas []*replica
+	maxLag   time.Duration
+	next     atomic.Uint64
+}
+
+type replica struct {
+	db *DB
+	// usable is set if the replica responded and was within the maximum lag
+	// at the last check
+	usable atomic.Bool
+}
+
+// NewReplicas returns read replicas using the given databases and checks
+// their lag. WithMaxLag is supported; if it is not set DefaultMaxReplicaLag is
+// used.
+func NewReplicas(ctx context.Context, dbs []*DB, opt ...Option) (*Replicas, error) {
+	const op = "db.NewReplicas"
+	if len(dbs) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing replica databases")
+	}
+	opts := GetOpts(opt...)
+	r := &Replicas{
+		replicas: make([]*replica, 0, len(dbs)),
+		maxLag:   opts.withMaxLag,
+	}
+	if r.maxLag <= 0 {
+		r.maxLag = DefaultMaxReplicaLag
+	}
+	for _, d := range dbs {
+		if d == nil || d.wrapped == nil || d.wrapped.Load() == nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "missing replica database")
+		}
+		rep := &replica{db: d}
+		// Start usable so that the first check reports replicas that are not
+		// usable.
+		rep.usable.Store(true)
+		r.replicas = append(r.replicas, rep)
+	}
+	r.CheckLag(ctx)
+	return r, nil
+}
+
+// CheckLag measures the replication lag of each replica. Until the next check,
+// only the replicas that responded and were within the maximum lag serve
+// reads. Replicas starting or stopping serving reads are reported as events.
+func (r *Replicas) CheckLag(ctx context.Context) {
+	const op = "db.(Replicas).CheckLag"
+	for i, rep := range r.replicas {
+		lag, err := rep.lag(ctx)
+		usable := err == nil && lag <= r.maxLag
+		if rep.usable.Swap(usable) == usable {
+			continue
+		}
+		switch {
+		case err != nil:
+			event.WriteError(ctx, op, err, event.WithInfoMsg("read replica unavailable, reading from the primary database instead", "replica", i))
+		case !usable:
+			event.WriteSysEvent(ctx, op, "read replica is lagging, reading from the primary database instead", "replica", i, "lag", lag.String(), "max_lag", r.maxLag.String())
+		default:
+			event.WriteSysEvent(ctx, op, "read replica is serving reads", "replica", i, "lag", lag.String())
+		}
+	}
+}
+
+func (r *replica) lag(ctx context.Context) (time.Duration, error) {
+	const op = "db.(replica).lag"
+	ctx, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+	defer cancel()
+	rows, err := dbw.New(r.db.wrapped.Load()).Query(ctx, replicaLagQuery, nil)
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var inRecovery, streaming bool
+	var seconds float64
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, errors.Wrap(ctx, err, op)
+		}
+		return 0, errors.New(ctx, errors.Internal, op, "no replication lag returned")
+	}
+	if err := rows.Scan(&inRecovery, &streaming, &seconds); err != nil {
+		return 0, errors.Wrap(ctx, err, op)
+	}
+	if inRecovery && !streaming {
+		// A replica that is not receiving WAL has replayed everything it
+		// received, so its lag can't be measured; it may be arbitrarily far
+		// behind the primary.
+		return 0, errors.New(ctx, errors.Internal, op, "replica is not receiving WAL from the primary")
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// reader returns the next usable replica, or nil if there are none.
+func (r *Replicas) reader() *dbw.DB {
+	n := uint64(len(r.replicas))
+	start := r.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if rep := r.replicas[(start+i)%n]; rep.usable.Load() {
+			return rep.db.wrapped.Load()
+		}
+	}
+	return nil
+}
+
+// NewReplicaReader returns a reader whose reads all go to the same database: a
+// usable read replica of the reader's database if there is one, and the
+// reader itself otherwise. Use it for several reads that tolerate slightly out
+// of date data but must see the same state of the database, such as items and
+// the rows related to them; separate reads made with WithReplicaRead may each
+// be served by a different replica. Reads from the replica are not retried
+// against the primary.
+func NewReplicaReader(r Reader) Reader {
+	rw, ok := r.(*Db)
+	if !ok {
+		return r
+	}
+	replicas := rw.underlying.Replicas()
+	if replicas == nil {
+		return r
+	}
+	d := replicas.reader()
+	if d == nil {
+		return r
+	}
+	wrapped := new(atomic.Pointer[dbw.DB])
+	wrapped.Store(d)
+	return New(&DB{wrapped: wrapped, slowQueryThreshold: rw.underlying.slowQueryThreshold})
+}
+
+// Close closes the replica databases.
+func (r *Replicas) Close(ctx context.Context) error {
+	const op = "db.(Replicas).Close"
+	var closeErr error
+	for i, rep := range r.replicas {
+		if err := rep.db.Close(ctx); err != nil && closeErr == nil {
+			closeErr = errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to close replica %d", i)))
+		}
+	}
+	return closeErr
+}
@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lagRows returns the result of replicaLagQuery for a replica.
+func lagRows(streaming bool, seconds float64) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"in_recovery", "streaming", "lag_seconds"}).AddRow(true, streaming, seconds)
+}
+
+func TestNewReplicas(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("missing-dbs", func(t *testing.T) {
+		_, err := NewReplicas(ctx, nil)
+		require.Error(t, err)
+	})
+	t.Run("nil-db", func(t *testing.T) {
+		_, err := NewReplicas(ctx, []*DB{nil})
+		require.Error(t, err)
+	})
+	t.Run("default-max-lag", func(t *testing.T) {
+		conn, mock := TestSetupWithMock(t)
+		mock.ExpectQuery(`pg_last_wal_replay_lsn`).WillReturnRows(lagRows(true, 1.0))
+		r, err := NewReplicas(ctx, []*DB{conn})
+		require.NoError(t, err)
+		assert.Equal(t, DefaultMaxReplicaLag, r.maxLag)
+		assert.True(t, r.replicas[0].usable.Load())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReplicas_CheckLag(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	conn, mock := TestSetupWithMock(t)
+	mock.ExpectQuery(`pg_last_wal_replay_lsn`).WillReturnRows(lagRows(true, 0.5))
+	r, err := NewReplicas(ctx, []*DB{conn}, WithMaxLag(time.Second))
+	require.NoError(t, err)
+	assert.NotNil(t, r.reader())
+
+	// A replica lagging more than the maximum stops serving reads
+	mock.ExpectQuery(`pg_last_wal_replay_lsn`).WillReturnRows(lagRows(true, 2.0))
+	r.CheckLag(ctx)
+	assert.Nil(t, r.reader())
+
+	// and serves them again once it catches up
+	mock.ExpectQuery(`pg_last_wal_replay_lsn`).WillReturnRows(lagRows(true, 0.0))
+	r.CheckLag(ctx)
+	assert.NotNil(t, r.reader())
+
+	// A replica that is not receiving WAL does not serve reads, even though it
+	// has replayed everything it received
+	mock.ExpectQuery(`pg_last_wal_replay_lsn`).WillReturnRows(lagRows(false, 0.0))
+	r.CheckLag(ctx)
+	assert.Nil(t, r.reader())
+
+	mock.ExpectQuery(`pg_last_wal_replay_lsn`).WillReturnRows(lagRows(true, 0.0))
+	r.CheckLag(ctx)
+	assert.NotNil(t, r.reader())
+
+	// A replica that cannot be reached does not serve reads
+	mock.ExpectQuery(`pg_last_wal_replay_lsn`).WillReturnError(fmt.Errorf("connection refused"))
+	r.CheckLag(ctx)
+	assert.Nil(t, r.reader())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDb_ReplicaRead(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*Db, sqlmock.Sqlmock, sqlmock.Sqlmock) {
+		primary, primaryMock := TestSetupWithMock(t)
+		replica, replicaMock := TestSetupWithMock(t)
+		replicaMock.ExpectQuery(`pg_last_wal_replay_lsn`).WillReturnRows(lagRows(true, 0.0))
+		replicas, err := NewReplicas(ctx, []*DB{replica})
+		require.NoError(t, err)
+		_, err = primary.SwapReplicas(ctx, replicas)
+		require.NoError(t, err)
+		return New(primary), primaryMock, replicaMock
+	}
+
+	t.Run("reads-from-replica", func(t *testing.T) {
+		rw, primaryMock, replicaMock := setup(t)
+		replicaMock.ExpectQuery(`select 1`).WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+		rows, err := rw.Query(ctx, "select 1", nil, WithReplicaRead(true))
+		require.NoError(t, err)
+		require.NoError(t, rows.Close())
+		assert.NoError(t, replicaMock.ExpectationsWereMet())
+		assert.NoError(t, primaryMock.ExpectationsWereMet())
+	})
+	t.Run("reads-from-primary-without-option", func(t *testing.T) {
+		rw, primaryMock, replicaMock := setup(t)
+		primaryMock.ExpectQuery(`select 1`).WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+		rows, err := rw.Query(ctx, "select 1", nil)
+		require.NoError(t, err)
+		require.NoError(t, rows.Close())
+		assert.NoError(t, replicaMock.ExpectationsWereMet())
+		assert.NoError(t, primaryMock.ExpectationsWereMet())
+	})
+	t.Run("falls-back-to-primary", func(t *testing.T) {
+		rw, primaryMock, replicaMock := setup(t)
+		replicaMock.ExpectQuery(`select 1`).WillReturnError(fmt.Errorf("connection reset"))
+		primaryMock.ExpectQuery(`select 1`).WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+		rows, err := rw.Query(ctx, "select 1", nil, WithReplicaRead(true))
+		require.NoError(t, err)
+		require.NoError(t, rows.Close())
+		assert.NoError(t, replicaMock.ExpectationsWereMet())
+		assert.NoError(t, primaryMock.ExpectationsWereMet())
+	})
+	t.Run("replica-reader", func(t *testing.T) {
+		rw, primaryMock, replicaMock := setup(t)
+		replicaMock.ExpectQuery(`select 1`).WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+		replicaMock.ExpectQuery(`select 2`).WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(2))
+		r := NewReplicaReader(rw)
+		for _, q := range []string{"select 1", "select 2"} {
+			rows, err := r.Query(ctx, q, nil, WithReplicaRead(true))
+			require.NoError(t, err)
+			require.NoError(t, rows.Close())
+		}
+		assert.NoError(t, replicaMock.ExpectationsWereMet())
+		assert.NoError(t, primaryMock.ExpectationsWereMet())
+	})
+	t.Run("replica-reader-without-replicas", func(t *testing.T) {
+		primary, primaryMock := TestSetupWithMock(t)
+		rw := New(primary)
+		assert.Same(t, rw, NewReplicaReader(rw))
+		assert.NoError(t, primaryMock.ExpectationsWereMet())
+	})
+}
@@ -21,4 +21,12 @@
 //
 //	m := schema.NewManager(ctx, schema.Postgres, db)
 //	m.ApplyMigrations()
+//
+// A migration file that includes the line "-- migration:online" is an
+// online migration: it only makes changes that controllers of the previous
+// version can keep using the database through, which is checked when the
+// edition is registered. When all pending migrations are online, they can be
+// applied while controllers are running:
+//
+//	m.ApplyOnlineMigrations()
 package schema
@@ -21,3 +21,14 @@ type MigrationCheckError struct {
 func (e MigrationCheckError) Error() string {
 	return fmt.Sprintf("check failed for %s:%d", e.Edition, e.Version)
 }
+
+// OfflineMigrationError is an error returned when online migrations are
+// applied and one of the migrations to apply is not online.
+type OfflineMigrationError struct {
+	Version int
+	Edition string
+}
+
+func (e OfflineMigrationError) Error() string {
+	return fmt.Sprintf("migration %s:%d is not online", e.Edition, e.Version)
+}
@@ -77,6 +77,11 @@ func (e Editions) Sort() {
 //	 2/
 //	   01_add_new_table.up.sql
 //	   02_refactor_views.up.sql
+//
+// A migration file that includes a line with the OnlineDirective is an online
+// migration, and New returns an error if any of its statements is not an
+// online change. A migration file that builds indexes concurrently can't do
+// anything else, since its statements are run outside of a transaction.
 func New(name string, dialect Dialect, m embed.FS, priority int, opt ...Option) (Edition, error) {
 	var largestSchemaVersion int
 	migrations := make(migration.Migrations)
@@ -132,11 +137,29 @@ func New(name string, dialect Dialect, m embed.FS, priority int, opt ...Option)
 		if _, exists := migrations[fullV]; exists {
 			return fmt.Errorf("migration file for version %d already exists", fullV)
 		}
+		concurrent, err := concurrentStatements(contents)
+		if err != nil {
+			return fmt.Errorf("migration file %s: %w", path, err)
+		}
+		if len(concurrent) > 0 {
+			if prehook[fullV] != nil {
+				return fmt.Errorf("migration file %s: concurrent index builds can't have a prehook", path)
+			}
+			contents = strings.Join(concurrent, ";\n") + ";"
+		}
+		online := isOnline(string(cbts))
+		if online {
+			if err := checkOnline(contents); err != nil {
+				return fmt.Errorf("online migration file %s: %w", path, err)
+			}
+		}
 		migrations[fullV] = migration.Migration{
 			Edition:    name,
 			Statements: []byte(contents),
 			Version:    fullV,
 			PreHook:    prehook[fullV],
+			Online:     online,
+			Concurrent: len(concurrent) > 0,
 		}
 
 		return nil
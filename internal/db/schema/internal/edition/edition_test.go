@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/db/schema/internal/edition"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // valid embed.FS
@@ -66,6 +67,30 @@ func TestNew(t *testing.T) {
 	}
 }
 
+//go:embed testdata/online
+var online embed.FS
+
+func TestNew_Online(t *testing.T) {
+	t.Parallel()
+
+	e, err := edition.New("online", edition.Dialect("postgres"), online, 0)
+	require.NoError(t, err)
+	require.Len(t, e.Migrations, 3)
+
+	assert.False(t, e.Migrations[1].Online)
+	assert.False(t, e.Migrations[1].Concurrent)
+
+	assert.True(t, e.Migrations[2].Online)
+	assert.False(t, e.Migrations[2].Concurrent)
+
+	assert.True(t, e.Migrations[3].Online)
+	assert.True(t, e.Migrations[3].Concurrent)
+	assert.Equal(t,
+		"create index concurrently foo_name_ix on foo (name);\n"+
+			"create unique index concurrently bar_foo_id_uq on bar (foo_id);",
+		string(e.Migrations[3].Statements))
+}
+
 // invalid embed.FS
 var (
 	//go:embed testdata/invalid/major-version-not-int
@@ -80,6 +105,10 @@ var (
 	noMajorVersion embed.FS
 	//go:embed testdata/invalid/duplicate-versions
 	duplicateVersions embed.FS
+	//go:embed testdata/invalid/online-not-online
+	onlineNotOnline embed.FS
+	//go:embed testdata/invalid/concurrent-mixed
+	concurrentMixed embed.FS
 )
 
 func TestNewErrors(t *testing.T) {
@@ -113,6 +142,14 @@ func TestNewErrors(t *testing.T) {
 			"duplicateVersions",
 			duplicateVersions,
 		},
+		{
+			"onlineNotOnline",
+			onlineNotOnline,
+		},
+		{
+			"concurrentMixed",
+			concurrentMixed,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
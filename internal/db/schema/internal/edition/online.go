@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package edition
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db/schema/internal/statement"
+)
+
+// OnlineDirective is the line that marks a migration file as online. Online
+// migrations may only make changes that the previous version of Boundary can
+// keep using the database through: creating tables and the objects that
+// belong to them, adding columns, and building indexes concurrently.
+const OnlineDirective = "-- migration:online"
+
+const sqlIdent = `(?:"[^"]+"|[a-z_][a-z0-9_$]*)(?:\.(?:"[^"]+"|[a-z_][a-z0-9_$]*))?`
+
+var (
+	createTableRe      = regexp.MustCompile(`^create\s+(?:unlogged\s+)?table\s+(?:if\s+not\s+exists\s+)?(` + sqlIdent + `)`)
+	concurrentIndexRe  = regexp.MustCompile(`^create\s+(?:unique\s+)?index\s+concurrently\s`)
+	indexRe            = regexp.MustCompile(`^create\s+(?:unique\s+)?index\s+(?:if\s+not\s+exists\s+)?(?:` + sqlIdent + `\s+)?on\s+(?:only\s+)?(` + sqlIdent + `)`)
+	triggerRe          = regexp.MustCompile(`(?s)^create\s+(?:constraint\s+)?trigger\s+` + sqlIdent + `\s+.*?\s+on\s+(` + sqlIdent + `)`)
+	insertRe           = regexp.MustCompile(`^insert\s+into\s+(` + sqlIdent + `)`)
+	alterTableRe       = regexp.MustCompile(`(?s)^alter\s+table\s+(?:if\s+exists\s+)?(?:only\s+)?(` + sqlIdent + `)\s+(.*)$`)
+	addColumnRe        = regexp.MustCompile(`^add\s+`)
+	addConstraintRe    = regexp.MustCompile(`^add\s+(?:constraint|primary|unique|check|foreign|exclude)\b`)
+	rewritingColumnRe  = regexp.MustCompile(`\b(?:generated|serial|smallserial|bigserial|serial4|serial8|serial2|primary\s+key|unique)\b`)
+	notNullRe          = regexp.MustCompile(`\bnot\s+null\b`)
+	defaultRe          = regexp.MustCompile(`\bdefault\b`)
+	additiveStatements = []*regexp.Regexp{
+		regexp.MustCompile(`^create\s+function\s`),
+		regexp.MustCompile(`^create\s+view\s`),
+		regexp.MustCompile(`^create\s+(?:sequence|type|domain)\s`),
+		regexp.MustCompile(`^comment\s+on\s`),
+	}
+)
+
+// isOnline reports whether the contents of a migration file include the
+// OnlineDirective.
+func isOnline(contents string) bool {
+	for _, line := range strings.Split(contents, "\n") {
+		if strings.TrimSpace(line) == OnlineDirective {
+			return true
+		}
+	}
+	return false
+}
+
+// isTransactionControl reports whether stmt begins or ends a transaction.
+// Migrations are run in a transaction, so these are ignored.
+func isTransactionControl(stmt string) bool {
+	switch strings.ToLower(strings.Join(strings.Fields(stmt), " ")) {
+	case "begin", "commit", "start transaction", "end":
+		return true
+	}
+	return false
+}
+
+// concurrentStatements returns the statements if they build indexes
+// concurrently, without any statements that begin or end a transaction, or
+// nil if they don't. An error is returned if they also do anything else,
+// since concurrent index builds can't be run in a transaction.
+func concurrentStatements(statements string) ([]string, error) {
+	var concurrent []string
+	var other bool
+	for _, stmt := range statement.Split(statements) {
+		if isTransactionControl(stmt) {
+			continue
+		}
+		if concurrentIndexRe.MatchString(strings.ToLower(stmt)) {
+			concurrent = append(concurrent, stmt)
+		} else {
+			other = true
+		}
+	}
+	if len(concurrent) > 0 && other {
+		return nil, fmt.Errorf("concurrent index builds must be in a migration of their own")
+	}
+	return concurrent, nil
+}
+
+// checkOnline returns an error if any of the statements of an online migration
+// makes a change that the previous version of Boundary can't keep using the
+// database through, or that blocks its use of the database for longer than it
+// takes to change the catalog. Tables created by the statements can be changed
+// freely, since the previous version does not use them.
+func checkOnline(statements string) error {
+	created := make(map[string]bool)
+	for _, stmt := range statement.Skeletons(statements) {
+		if isTransactionControl(stmt) {
+			continue
+		}
+		stmt = strings.ToLower(stmt)
+		if err := checkOnlineStatement(stmt, created); err != nil {
+			return fmt.Errorf("%w: %s", err, summarize(stmt))
+		}
+	}
+	return nil
+}
+
+func checkOnlineStatement(stmt string, created map[string]bool) error {
+	if m := createTableRe.FindStringSubmatch(stmt); m != nil {
+		created[unqualify(m[1])] = true
+		return nil
+	}
+	if concurrentIndexRe.MatchString(stmt) {
+		return nil
+	}
+	for _, re := range additiveStatements {
+		if re.MatchString(stmt) {
+			return nil
+		}
+	}
+	for _, re := range []*regexp.Regexp{indexRe, triggerRe, insertRe} {
+		if m := re.FindStringSubmatch(stmt); m != nil {
+			if created[unqualify(m[1])] {
+				return nil
+			}
+			return fmt.Errorf("statement changes a table that is not created by the migration")
+		}
+	}
+	if m := alterTableRe.FindStringSubmatch(stmt); m != nil {
+		if created[unqualify(m[1])] {
+			return nil
+		}
+		for _, action := range splitActions(m[2]) {
+			switch {
+			case !addColumnRe.MatchString(action), addConstraintRe.MatchString(action):
+				return fmt.Errorf("statement alters a table other than by adding columns")
+			case rewritingColumnRe.MatchString(action):
+				return fmt.Errorf("statement adds a column that rewrites or indexes the table")
+			case notNullRe.MatchString(action) && !defaultRe.MatchString(action):
+				return fmt.Errorf("statement adds a not null column without a default")
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("statement is not an online change")
+}
+
+// splitActions splits the actions of an alter table statement on the commas
+// that are not within parentheses.
+func splitActions(actions string) []string {
+	var ret []string
+	var depth, start int
+	for i, c := range actions {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				ret = append(ret, strings.TrimSpace(actions[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(ret, strings.TrimSpace(actions[start:]))
+}
+
+// unqualify returns the unquoted name of a table without its schema.
+func unqualify(name string) string {
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.Trim(name, `"`)
+}
+
+// summarize returns the start of a statement, on a single line, for use in
+// error messages.
+func summarize(stmt string) string {
+	const maxLen = 80
+	stmt = strings.Join(strings.Fields(stmt), " ")
+	if len(stmt) > maxLen {
+		stmt = stmt[:maxLen] + "..."
+	}
+	return stmt
+}
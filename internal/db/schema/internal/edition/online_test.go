@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package edition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckOnline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		statements string
+		wantErr    string
+	}{
+		{
+			name: "new-table",
+			statements: `
+begin;
+create table foo (
+  public_id wt_public_id primary key,
+  name text,
+  create_time wt_timestamp
+);
+create trigger default_create_time_column before insert on foo
+  for each row execute procedure default_create_time();
+create index foo_name_ix on foo (name);
+alter table foo add constraint foo_name_uq unique (name);
+insert into foo (public_id, name) values ('f_1234567890', 'one; two');
+comment on table foo is 'foo is a table; not a view';
+commit;
+`,
+		},
+		{
+			name: "functions-and-views",
+			statements: `
+create function foo_name(id text) returns text
+as $$
+  delete from foo where public_id = id;
+  select name from foo where public_id = id;
+$$ language sql;
+create view foo_view as select * from foo;
+create type foo_status as enum ('active', 'inactive');
+create domain foo_id as text;
+`,
+		},
+		{
+			name: "add-columns",
+			statements: `
+alter table only public.session
+  add column bytes_up bigint,
+  add column if not exists reason text not null default 'unknown',
+  add column foo_id text references foo (public_id) on delete set null,
+  add column limits numeric(10, 2) check (limits > 0);
+`,
+		},
+		{
+			name:       "concurrent-index",
+			statements: `create unique index concurrently session_name_uq on session (name);`,
+		},
+		{
+			name:       "drop-column",
+			statements: `alter table session drop column bytes_up;`,
+			wantErr:    "statement alters a table other than by adding columns",
+		},
+		{
+			name:       "add-and-rename-column",
+			statements: `alter table session add column bytes_up bigint, rename column name to title;`,
+			wantErr:    "statement alters a table other than by adding columns",
+		},
+		{
+			name:       "add-constraint",
+			statements: `alter table session add constraint session_name_uq unique (name);`,
+			wantErr:    "statement alters a table other than by adding columns",
+		},
+		{
+			name:       "add-not-null-column",
+			statements: `alter table session add column bytes_up bigint not null;`,
+			wantErr:    "statement adds a not null column without a default",
+		},
+		{
+			name:       "add-serial-column",
+			statements: `alter table session add column seq bigserial;`,
+			wantErr:    "statement adds a column that rewrites or indexes the table",
+		},
+		{
+			name:       "index-on-existing-table",
+			statements: `create index session_name_ix on session (name);`,
+			wantErr:    "statement changes a table that is not created by the migration",
+		},
+		{
+			name:       "trigger-on-existing-table",
+			statements: `create trigger immutable_columns before update on session for each row execute procedure immutable_columns('name');`,
+			wantErr:    "statement changes a table that is not created by the migration",
+		},
+		{
+			name:       "update",
+			statements: `update session set name = 'online';`,
+			wantErr:    "statement is not an online change",
+		},
+		{
+			name:       "replace-function",
+			statements: `create or replace function foo() returns int as $$ select 1 $$ language sql;`,
+			wantErr:    "statement is not an online change",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkOnline(tt.statements)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "update session set name = ''", summarize("update session\n  set name = ''"))
+	assert.Equal(t,
+		"create trigger immutable_columns before update on session for each row execute p...",
+		summarize("create trigger immutable_columns before update on session for each row execute procedure immutable_columns('name')"))
+}
+
+func TestConcurrentStatements(t *testing.T) {
+	t.Parallel()
+
+	got, err := concurrentStatements(`create table foo (id int); create index foo_ix on foo (id);`)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = concurrentStatements(`
+begin;
+create index concurrently foo_ix on foo (id);
+-- a comment
+create unique index concurrently bar_uq on bar (id) where name <> ';';
+commit;
+`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"create index concurrently foo_ix on foo (id)",
+		"create unique index concurrently bar_uq on bar (id) where name <> ';'",
+	}, got)
+
+	_, err = concurrentStatements(`alter table foo add column id int; create index concurrently foo_ix on foo (id);`)
+	assert.EqualError(t, err, "concurrent index builds must be in a migration of their own")
+}
@@ -0,0 +1,8 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+alter table foo
+  add column name text;
+create index concurrently foo_name_ix on foo (name);
+commit;
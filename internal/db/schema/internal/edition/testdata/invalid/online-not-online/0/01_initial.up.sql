@@ -0,0 +1,8 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+-- migration:online
+begin;
+alter table foo
+  drop column name;
+commit;
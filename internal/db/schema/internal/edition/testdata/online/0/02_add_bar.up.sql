@@ -0,0 +1,15 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+-- migration:online
+begin;
+alter table foo
+  add column name text,
+  add column enabled boolean not null default true;
+
+create table bar (
+  id bigint primary key,
+  foo_id bigint references foo (id)
+);
+create index bar_foo_id_ix on bar (foo_id);
+commit;
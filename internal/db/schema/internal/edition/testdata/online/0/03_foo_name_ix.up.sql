@@ -0,0 +1,8 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+-- migration:online
+begin;
+create index concurrently foo_name_ix on foo (name);
+create unique index concurrently bar_foo_id_uq on bar (foo_id);
+commit;
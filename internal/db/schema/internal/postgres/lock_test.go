@@ -168,3 +168,37 @@ func TestUnlockShared(t *testing.T) {
 	err = p2.TryLock(ctx)
 	require.NoError(t, err)
 }
+
+// Tests that TryMigrationLock:
+// - can get the lock while shared locks on the schema are held
+// - a second connection cannot get the migration lock
+// - the lock can be captured again once released
+func TestTryMigrationLock(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	p, _, u := setup(ctx, t)
+
+	err := p.TrySharedLock(ctx)
+	require.NoError(t, err)
+
+	d2, err := common.SqlOpen(dbtest.Postgres, u)
+	require.NoError(t, err)
+
+	p2, err := postgres.New(ctx, d2)
+	require.NoError(t, err)
+
+	err = p2.TrySharedLock(ctx)
+	require.NoError(t, err)
+
+	err = p2.TryMigrationLock(ctx)
+	require.NoError(t, err)
+
+	err = p.TryMigrationLock(ctx)
+	require.Error(t, err)
+
+	err = p2.UnlockMigration(ctx)
+	require.NoError(t, err)
+
+	err = p.TryMigrationLock(ctx)
+	require.NoError(t, err)
+}
@@ -48,6 +48,12 @@ import (
 // on a postgres server at a time.  The value has no meaning and was picked randomly.
 const schemaAccessLockId int64 = 3865661975
 
+// migrationLockId is a Lock key used to ensure a single boundary binary is
+// applying online migrations at a time, since they are applied while
+// controllers hold shared locks on schemaAccessLockId. The value has no
+// meaning and was picked randomly.
+const migrationLockId int64 = 2493871157
+
 // nilVersion is used to identify when a migration version has not be set.
 const nilVersion = -1
 
@@ -115,6 +121,37 @@ func (p *Postgres) TryLock(ctx context.Context) error {
 	return nil
 }
 
+// TryMigrationLock attempts to capture the exclusive lock used to serialize
+// the application of online migrations. If it is not successful it returns an
+// error.
+func (p *Postgres) TryMigrationLock(ctx context.Context) error {
+	const op = "postgres.(Postgres).TryMigrationLock"
+
+	r := p.conn.QueryRowContext(ctx, tryLock, migrationLockId)
+	if r.Err() != nil {
+		return errors.Wrap(ctx, r.Err(), op)
+	}
+	var gotLock bool
+	if err := r.Scan(&gotLock); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if !gotLock {
+		return errors.New(ctx, errors.MigrationLock, op, "Lock failed")
+	}
+	return nil
+}
+
+// UnlockMigration releases the lock captured by TryMigrationLock and returns
+// an error if we were unable to release the lock before the context cancels.
+func (p *Postgres) UnlockMigration(ctx context.Context) error {
+	const op = "postgres.(Postgres).UnlockMigration"
+
+	if _, err := p.conn.ExecContext(ctx, unlock, migrationLockId); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
 // Lock calls pg_advisory_lock with the provided context and returns an error
 // if we were unable to get the lock before the context cancels.
 func (p *Postgres) Lock(ctx context.Context) error {
@@ -186,6 +223,48 @@ func (p *Postgres) RepairHook(ctx context.Context, f migration.RepairFunc) (migr
 	return f(ctx, p.tx)
 }
 
+// DryRunCheckHook runs a check hook in a read only transaction which is rolled
+// back, so a check can be run without running its migration.
+func (p *Postgres) DryRunCheckHook(ctx context.Context, f migration.CheckFunc) (migration.Problems, error) {
+	const op = "postgres.(Postgres).DryRunCheckHook"
+	if f == nil {
+		return nil, errors.New(ctx, errors.MigrationIntegrity, op, "no check function")
+	}
+	tx, err := p.conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	return f(ctx, tx)
+}
+
+// EstimateRows returns the planner's estimate of the number of rows of each
+// table in the current schema. Tables that have never been analyzed are
+// estimated to have no rows.
+func (p *Postgres) EstimateRows(ctx context.Context) (map[string]int64, error) {
+	const op = "postgres.(Postgres).EstimateRows"
+	rows, err := p.conn.QueryContext(ctx, estimateRows)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	estimates := make(map[string]int64)
+	for rows.Next() {
+		var table string
+		var n int64
+		if err := rows.Scan(&table, &n); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		estimates[table] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return estimates, nil
+}
+
 // CommitRun commits a transaction, if there is an error it should rollback the transaction.
 func (p *Postgres) CommitRun(ctx context.Context) error {
 	const op = "postgres.(Postgres).CommitRun"
@@ -206,8 +285,9 @@ func (p *Postgres) CommitRun(ctx context.Context) error {
 
 // Run will apply a migration. The io.Reader should provide the SQL
 // statements to execute, and the int is the version for that set of
-// statements. This should always be wrapped by StartRun and CommitRun.
-func (p *Postgres) Run(ctx context.Context, migration io.Reader, version int, edition string) error {
+// statements. Online migrations keep the compatible version of the edition.
+// This should always be wrapped by StartRun and CommitRun.
+func (p *Postgres) Run(ctx context.Context, migration io.Reader, version int, edition string, online bool) error {
 	const op = "postgres.(Postgres).Run"
 
 	if p.tx == nil {
@@ -223,34 +303,72 @@ func (p *Postgres) Run(ctx context.Context, migration io.Reader, version int, ed
 
 	// set the version first, so logs will be associated with this new version.
 	// if there's an error, it will get rollback
-	if err := p.setVersion(ctx, version, edition); err != nil {
+	if err := p.setVersion(ctx, version, edition, online); err != nil {
 		return errors.Wrap(ctx, err, op)
 	}
 
 	if _, err := p.tx.ExecContext(ctx, query); err != nil {
-		if pgErr, ok := err.(*pgconn.PgError); ok {
-			var line uint
-			var col uint
-			var lineColOK bool
-			if pgErr.Position != 0 {
-				line, col, lineColOK = computeLineFromPos(query, int(pgErr.Position))
-			}
-			message := "migration failed"
-			if lineColOK {
-				message = fmt.Sprintf("%s (column %d)", message, col)
-			}
-			if pgErr.Detail != "" {
-				message = fmt.Sprintf("%s, %s", message, pgErr.Detail)
-			}
-			message = fmt.Sprintf("%s, on line %v: %s", message, line, migr)
-			return errors.Wrap(ctx, err, op, errors.WithMsg(message))
+		return migrationError(ctx, op, err, query)
+	}
+
+	return nil
+}
+
+// RunConcurrently will apply a migration whose statements can't be run in a
+// transaction, such as concurrent index builds. Each statement is run on its
+// own, and the version is set once they all succeeded. It must not be wrapped
+// by StartRun and CommitRun. If a statement fails, the statements before it
+// are not rolled back.
+func (p *Postgres) RunConcurrently(ctx context.Context, statements []string, version int, edition string, online bool) error {
+	const op = "postgres.(Postgres).RunConcurrently"
+
+	if p.tx != nil {
+		return errors.New(ctx, errors.MigrationIntegrity, op, "pending transaction")
+	}
+
+	for _, stmt := range statements {
+		if _, err := p.conn.ExecContext(ctx, stmt); err != nil {
+			return migrationError(ctx, op, err, stmt)
 		}
-		return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("migration failed: %s", migr)))
 	}
 
+	if err := p.StartRun(ctx); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := p.setVersion(ctx, version, edition, online); err != nil {
+		_ = p.tx.Rollback()
+		p.tx = nil
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := p.CommitRun(ctx); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
 	return nil
 }
 
+// migrationError wraps the error returned by running the statements of a
+// migration with the position in the statements that it happened at, if known.
+func migrationError(ctx context.Context, op errors.Op, err error, query string) error {
+	if pgErr, ok := err.(*pgconn.PgError); ok {
+		var line uint
+		var col uint
+		var lineColOK bool
+		if pgErr.Position != 0 {
+			line, col, lineColOK = computeLineFromPos(query, int(pgErr.Position))
+		}
+		message := "migration failed"
+		if lineColOK {
+			message = fmt.Sprintf("%s (column %d)", message, col)
+		}
+		if pgErr.Detail != "" {
+			message = fmt.Sprintf("%s, %s", message, pgErr.Detail)
+		}
+		message = fmt.Sprintf("%s, on line %v: %s", message, line, query)
+		return errors.Wrap(ctx, err, op, errors.WithMsg(message))
+	}
+	return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("migration failed: %s", query)))
+}
+
 // Close closes the underlying Postgres database connection.
 func (p *Postgres) Close() error {
 	return p.conn.Close()
@@ -392,6 +510,7 @@ func (p *Postgres) EnsureVersionTable(ctx context.Context) error {
 		dropDirtyColumn,
 		addEditionColumn,
 		setVersionNotNull,
+		addCompatibleVersionColumn,
 	}
 
 	for _, a := range alterations {
@@ -402,22 +521,56 @@ func (p *Postgres) EnsureVersionTable(ctx context.Context) error {
 	return nil
 }
 
-// setVersion sets the version number, and whether the database is in a dirty state.
+// setVersion sets the version number of the edition. Unless the migration of
+// the version is online, it also becomes the compatible version.
 // A version value of -1 indicates no version is set.
-func (p *Postgres) setVersion(ctx context.Context, version int, edition string) error {
+func (p *Postgres) setVersion(ctx context.Context, version int, edition string, online bool) error {
 	const op = "postgres.(Postgres).setVersion"
 
 	if p.tx == nil {
 		return errors.New(ctx, errors.MigrationIntegrity, op, "no pending transaction")
 	}
 
-	if _, err := p.tx.ExecContext(ctx, upsertVersion, edition, version); err != nil {
+	query := upsertVersion
+	if online {
+		query = upsertOnlineVersion
+	}
+	if _, err := p.tx.ExecContext(ctx, query, edition, version); err != nil {
 		return errors.Wrap(ctx, err, op)
 	}
 
 	return nil
 }
 
+// CompatibleVersion returns the oldest schema version of the given edition
+// that a binary can use the database with. A version of -1 indicates none is
+// recorded, in which case only a binary with the current version of the
+// edition can use it.
+func (p *Postgres) CompatibleVersion(ctx context.Context, edition string) (int, error) {
+	const op = "postgres.(Postgres).CompatibleVersion"
+
+	var exists bool
+	if err := p.conn.QueryRowContext(ctx, compatibleVersionColumnExists).Scan(&exists); err != nil {
+		return nilVersion, errors.Wrap(ctx, err, op)
+	}
+	if !exists {
+		return nilVersion, nil
+	}
+
+	var version sql.NullInt64
+	err := p.conn.QueryRowContext(ctx, selectCompatibleVersion, edition).Scan(&version)
+	switch {
+	case err == sql.ErrNoRows:
+		return nilVersion, nil
+	case err != nil:
+		return nilVersion, errors.Wrap(ctx, err, op)
+	case !version.Valid:
+		return nilVersion, nil
+	default:
+		return int(version.Int64), nil
+	}
+}
+
 // EnsureMigrationLogTable ensures that the table used to record migration lgos
 // exists and is in the correct state.
 func (p *Postgres) EnsureMigrationLogTable(ctx context.Context) error {
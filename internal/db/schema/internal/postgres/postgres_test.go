@@ -34,7 +34,7 @@ create table foo (
 	err = p.EnsureMigrationLogTable(ctx)
 	require.NoError(t, err)
 
-	err = p.Run(ctx, statements, 1001, "oss")
+	err = p.Run(ctx, statements, 1001, "oss", false)
 	require.NoError(t, err)
 
 	err = p.CommitRun(ctx)
@@ -57,10 +57,80 @@ create table foo (
 );
 `))
 
-	err := p.Run(ctx, statements, 1001, "oss")
+	err := p.Run(ctx, statements, 1001, "oss", false)
 	require.EqualError(
 		t,
 		err,
 		fmt.Sprintf("postgres.(Postgres).Run: no pending transaction: integrity violation: error #%d", errors.MigrationIntegrity),
 	)
 }
+
+func TestRun_CompatibleVersion(t *testing.T) {
+	ctx := context.Background()
+	p, _, _ := setup(ctx, t)
+
+	run := func(version int, online bool) {
+		t.Helper()
+		require.NoError(t, p.StartRun(ctx))
+		require.NoError(t, p.EnsureVersionTable(ctx))
+		require.NoError(t, p.EnsureMigrationLogTable(ctx))
+		require.NoError(t, p.Run(ctx, bytes.NewReader([]byte(`select 1`)), version, "oss", online))
+		require.NoError(t, p.CommitRun(ctx))
+	}
+
+	v, err := p.CompatibleVersion(ctx, "oss")
+	require.NoError(t, err)
+	assert.Equal(t, -1, v)
+
+	run(1001, false)
+	v, err = p.CompatibleVersion(ctx, "oss")
+	require.NoError(t, err)
+	assert.Equal(t, 1001, v)
+
+	// Online migrations keep the compatible version.
+	run(1002, true)
+	run(1003, true)
+	v, err = p.CompatibleVersion(ctx, "oss")
+	require.NoError(t, err)
+	assert.Equal(t, 1001, v)
+
+	run(2001, false)
+	v, err = p.CompatibleVersion(ctx, "oss")
+	require.NoError(t, err)
+	assert.Equal(t, 2001, v)
+}
+
+func TestRunConcurrently(t *testing.T) {
+	ctx := context.Background()
+	p, db, _ := setup(ctx, t)
+
+	require.NoError(t, p.StartRun(ctx))
+	require.NoError(t, p.EnsureVersionTable(ctx))
+	require.NoError(t, p.EnsureMigrationLogTable(ctx))
+	require.NoError(t, p.Run(ctx, bytes.NewReader([]byte(`create table foo (id bigint primary key, bar text)`)), 1001, "oss", false))
+
+	// Concurrent index builds can't be run in a transaction.
+	err := p.RunConcurrently(ctx, []string{`create index concurrently foo_bar_ix on foo (bar)`}, 1002, "oss", true)
+	require.EqualError(
+		t,
+		err,
+		fmt.Sprintf("postgres.(Postgres).RunConcurrently: pending transaction: integrity violation: error #%d", errors.MigrationIntegrity),
+	)
+	require.NoError(t, p.CommitRun(ctx))
+
+	err = p.RunConcurrently(ctx, []string{
+		`create index concurrently foo_bar_ix on foo (bar)`,
+		`create unique index concurrently foo_id_bar_uq on foo (id, bar)`,
+	}, 1002, "oss", true)
+	require.NoError(t, err)
+
+	var indexes int
+	require.NoError(t, db.QueryRowContext(ctx, `select count(*) from pg_indexes where tablename = 'foo'`).Scan(&indexes))
+	assert.Equal(t, 3, indexes)
+	v, _, err := p.CurrentState(ctx, "oss")
+	require.NoError(t, err)
+	assert.Equal(t, 1002, v)
+	v, err = p.CompatibleVersion(ctx, "oss")
+	require.NoError(t, err)
+	assert.Equal(t, 1001, v)
+}
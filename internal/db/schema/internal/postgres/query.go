@@ -17,14 +17,42 @@ const (
 	schemaVersionTable = `boundary_schema_version`
 	upsertVersion      = `
 insert into boundary_schema_version
-	(edition, version)
+	(edition, version, compatible_version)
 values
-	($1, $2)
+	($1, $2, $2)
 on conflict (edition)
 do update set
-	version = $2
+	version            = $2,
+	compatible_version = $2
 ;`
 
+	// upsertOnlineVersion keeps the compatible version of an edition, or sets
+	// it to the version before the online migration if none was recorded.
+	upsertOnlineVersion = `
+insert into boundary_schema_version
+	(edition, version, compatible_version)
+values
+	($1, $2, $2)
+on conflict (edition)
+do update set
+	version            = $2,
+	compatible_version = coalesce(boundary_schema_version.compatible_version, boundary_schema_version.version)
+;`
+
+	selectCompatibleVersion = `
+select compatible_version
+  from boundary_schema_version
+ where edition = $1
+;`
+
+	compatibleVersionColumnExists = `
+select exists (
+	select 1 from information_schema.columns
+	 where table_schema = (select current_schema())
+	   and table_name   = 'boundary_schema_version'
+	   and column_name  = 'compatible_version'
+);`
+
 	selectVersion = `
 select version
   from boundary_schema_version
@@ -82,6 +110,11 @@ alter table boundary_schema_version add primary key (edition);
 	setVersionNotNull = `
 alter table boundary_schema_version
 	alter column version set not null;
+`
+	addCompatibleVersionColumn = `
+alter table boundary_schema_version
+	add column if not exists
+	compatible_version bigint;
 `
 )
 
@@ -138,3 +171,14 @@ comment on function log_migration_version() is
   'log_migration_version will set the log_migration entries to the current migration version';
 `
 )
+
+// Queries for planning migrations
+const (
+	estimateRows = `
+select c.relname, greatest(c.reltuples, 0)::bigint
+  from pg_class c
+  join pg_namespace n on n.oid = c.relnamespace
+ where n.nspname = current_schema()
+   and c.relkind in ('r', 'p')
+;`
+)
@@ -100,3 +100,20 @@ func (p *Provider) PreHook() *migration.Hook {
 	}
 	return p.migrations[p.pos].PreHook
 }
+
+// Online returns whether the current migration is online.
+func (p *Provider) Online() bool {
+	if p.pos < 0 || p.pos >= len(p.migrations) {
+		return false
+	}
+	return p.migrations[p.pos].Online
+}
+
+// Concurrent returns whether the statements of the current migration must be
+// run outside of a transaction.
+func (p *Provider) Concurrent() bool {
+	if p.pos < 0 || p.pos >= len(p.migrations) {
+		return false
+	}
+	return p.migrations[p.pos].Concurrent
+}
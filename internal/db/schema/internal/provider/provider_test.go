@@ -247,3 +247,31 @@ func TestProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestProvider_Online(t *testing.T) {
+	p := provider.New(provider.DatabaseState{"one": 1}, edition.Editions{
+		edition.Edition{
+			Name:          "one",
+			LatestVersion: 3,
+			Migrations: migration.Migrations{
+				1: migration.Migration{Statements: []byte(`migration one`), Edition: "one", Version: 1},
+				2: migration.Migration{Statements: []byte(`migration two`), Edition: "one", Version: 2, Online: true},
+				3: migration.Migration{Statements: []byte(`migration three`), Edition: "one", Version: 3, Online: true, Concurrent: true},
+			},
+		},
+	})
+
+	require.True(t, p.Next())
+	assert.Equal(t, 2, p.Version())
+	assert.True(t, p.Online())
+	assert.False(t, p.Concurrent())
+
+	require.True(t, p.Next())
+	assert.Equal(t, 3, p.Version())
+	assert.True(t, p.Online())
+	assert.True(t, p.Concurrent())
+
+	assert.False(t, p.Next())
+	assert.False(t, p.Online())
+	assert.False(t, p.Concurrent())
+}
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package statement provides internal functions for the schema package for
+// splitting the sql of a migration into its top level statements.
+package statement
+
+import (
+	"regexp"
+	"strings"
+)
+
+var dollarQuote = regexp.MustCompile(`^\$[A-Za-z_]*\$`)
+
+// Split returns the top level statements of s, with comments removed and
+// without their terminating semicolons. Semicolons within string literals and
+// dollar quoted strings, such as function bodies, do not end a statement.
+func Split(s string) []string {
+	return split(s, false)
+}
+
+// Skeletons returns the top level statements of s like Split, but with string
+// literals and dollar quoted strings emptied, so that the structure of each
+// statement can be matched without matching the contents of its strings.
+func Skeletons(s string) []string {
+	return split(s, true)
+}
+
+func split(s string, empty bool) []string {
+	var stmts []string
+	var b strings.Builder
+	flush := func() {
+		if stmt := strings.TrimSpace(b.String()); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+		b.Reset()
+	}
+	for i := 0; i < len(s); {
+		switch {
+		case strings.HasPrefix(s[i:], "--"):
+			j := strings.IndexByte(s[i:], '\n')
+			if j < 0 {
+				i = len(s)
+				continue
+			}
+			i += j
+		case strings.HasPrefix(s[i:], "/*"):
+			j := strings.Index(s[i+2:], "*/")
+			if j < 0 {
+				i = len(s)
+				continue
+			}
+			i += j + 4
+			b.WriteByte(' ')
+		case s[i] == '\'':
+			// An escaped quote within a literal is read as two adjacent
+			// literals.
+			j := strings.IndexByte(s[i+1:], '\'')
+			if j < 0 {
+				i = len(s)
+				continue
+			}
+			if empty {
+				b.WriteString("''")
+			} else {
+				b.WriteString(s[i : i+j+2])
+			}
+			i += j + 2
+		case s[i] == '"':
+			j := strings.IndexByte(s[i+1:], '"')
+			if j < 0 {
+				i = len(s)
+				continue
+			}
+			b.WriteString(s[i : i+j+2])
+			i += j + 2
+		case s[i] == '$' && dollarQuote.MatchString(s[i:]):
+			tag := dollarQuote.FindString(s[i:])
+			j := strings.Index(s[i+len(tag):], tag)
+			if j < 0 {
+				i = len(s)
+				continue
+			}
+			if empty {
+				b.WriteString("$$")
+			} else {
+				b.WriteString(s[i : i+len(tag)+j+len(tag)])
+			}
+			i += len(tag) + j + len(tag)
+		case s[i] == ';':
+			flush()
+			i++
+		default:
+			b.WriteByte(s[i])
+			i++
+		}
+	}
+	flush()
+	return stmts
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statement_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db/schema/internal/statement"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		sql           string
+		wantSplit     []string
+		wantSkeletons []string
+	}{
+		{
+			name: "empty",
+			sql:  "  \n-- nothing to see here\n",
+		},
+		{
+			name:          "no-terminating-semicolon",
+			sql:           `create table foo (id int primary key)`,
+			wantSplit:     []string{`create table foo (id int primary key)`},
+			wantSkeletons: []string{`create table foo (id int primary key)`},
+		},
+		{
+			name: "comments",
+			sql: `
+-- drop table foo;
+create table foo (id int); /* drop table bar; */
+create table bar (id int);
+`,
+			wantSplit:     []string{`create table foo (id int)`, `create table bar (id int)`},
+			wantSkeletons: []string{`create table foo (id int)`, `create table bar (id int)`},
+		},
+		{
+			name: "literals",
+			sql: `
+insert into foo (name) values ('it''s; here');
+comment on table "foo;bar" is 'a; b';
+`,
+			wantSplit: []string{
+				`insert into foo (name) values ('it''s; here')`,
+				`comment on table "foo;bar" is 'a; b'`,
+			},
+			wantSkeletons: []string{
+				`insert into foo (name) values ('''')`,
+				`comment on table "foo;bar" is ''`,
+			},
+		},
+		{
+			name: "function-bodies",
+			sql: `
+create function foo() returns trigger
+as $body$
+begin
+  delete from bar;
+  return null;
+end;
+$body$ language plpgsql;
+do $$ begin update bar set name = null; end $$;
+`,
+			wantSplit: []string{
+				"create function foo() returns trigger\nas $body$\nbegin\n  delete from bar;\n  return null;\nend;\n$body$ language plpgsql",
+				`do $$ begin update bar set name = null; end $$`,
+			},
+			wantSkeletons: []string{
+				"create function foo() returns trigger\nas $$ language plpgsql",
+				`do $$`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantSplit, statement.Split(tt.sql))
+			assert.Equal(t, tt.wantSkeletons, statement.Skeletons(tt.sql))
+		})
+	}
+}
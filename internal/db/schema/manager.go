@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/db/schema/internal/log"
 	"github.com/hashicorp/boundary/internal/db/schema/internal/postgres"
 	"github.com/hashicorp/boundary/internal/db/schema/internal/provider"
+	"github.com/hashicorp/boundary/internal/db/schema/internal/statement"
 	"github.com/hashicorp/boundary/internal/db/schema/migration"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/observability/event"
@@ -27,6 +28,10 @@ type driver interface {
 	Lock(context.Context) error
 	Unlock(context.Context) error
 	UnlockShared(context.Context) error
+	// TryMigrationLock attempts to capture the lock that serializes the
+	// application of online migrations, which don't take an exclusive lock.
+	TryMigrationLock(context.Context) error
+	UnlockMigration(context.Context) error
 	Close() error
 	// StartRun begins a transaction internal to the driver.
 	StartRun(context.Context) error
@@ -38,15 +43,29 @@ type driver interface {
 	// RepairHook is a hook that runs prior to a migration's statements.
 	// It should run in the same transaction a corresponding Run call.
 	RepairHook(context.Context, migration.RepairFunc) (migration.Repairs, error)
+	// DryRunCheckHook runs a check hook in a transaction of its own that does
+	// not write anything, so a check can be run without running its migration.
+	DryRunCheckHook(context.Context, migration.CheckFunc) (migration.Problems, error)
+	// EstimateRows returns an estimate of the number of rows of each table.
+	EstimateRows(context.Context) (map[string]int64, error)
 	// Run will apply a migrations statements. The io.Reader should provide the SQL
 	// statements to execute, and the int is the version for that set of
-	// statements. This should always be wrapped by StartRun and CommitRun.
-	Run(ctx context.Context, statements io.Reader, version int, edition string) error
+	// statements. Online migrations keep the compatible version of the
+	// edition. This should always be wrapped by StartRun and CommitRun.
+	Run(ctx context.Context, statements io.Reader, version int, edition string, online bool) error
+	// RunConcurrently will apply a migration whose statements can't be run in
+	// a transaction, running them one at a time. It must not be wrapped by
+	// StartRun and CommitRun.
+	RunConcurrently(ctx context.Context, statements []string, version int, edition string, online bool) error
 	// CurrentState returns the state of the given edition.
 	// ver is the current migration version number as recorded in the database.
 	// A version of -1 indicates no version is set.
 	// initialized will be true if the schema was previously initialized.
 	CurrentState(ctx context.Context, edition string) (version int, initialized bool, err error)
+	// CompatibleVersion returns the oldest version of the given edition that
+	// a binary can use the database with. A version of -1 indicates none is
+	// recorded.
+	CompatibleVersion(ctx context.Context, edition string) (int, error)
 	// EnsureVersionTable ensures that the table used to record the schema versions for each edition
 	// exists and is in the correct state.
 	EnsureVersionTable(ctx context.Context) error
@@ -120,13 +139,23 @@ func (b *Manager) CurrentState(ctx context.Context) (*State, error) {
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op)
 		}
-		dbS.Initialized = initialized || dbS.Initialized
-		dbS.Editions = append(dbS.Editions, EditionState{
+		es := EditionState{
 			Name:                  e.Name,
 			DatabaseSchemaVersion: v,
 			BinarySchemaVersion:   e.LatestVersion,
 			DatabaseSchemaState:   compareVersions(v, e.LatestVersion),
-		})
+		}
+		if initialized && v != nilVersion {
+			cv, err := b.driver.CompatibleVersion(ctx, e.Name)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+			if cv != nilVersion && cv < v {
+				es.MinBinarySchemaVersion = cv
+			}
+		}
+		dbS.Initialized = initialized || dbS.Initialized
+		dbS.Editions = append(dbS.Editions, es)
 	}
 
 	return &dbS, nil
@@ -230,7 +259,64 @@ func (b *Manager) ApplyMigrations(ctx context.Context) ([]RepairLog, error) {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 
-	logs, err := b.runMigrations(ctx, provider.New(state.databaseState(), b.editions))
+	logs, err := b.runMigrations(ctx, provider.New(state.databaseState(), b.editions), false)
+	if err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// ApplyOnlineMigrations updates the database schema to match the latest
+// version known by the boundary binary while controllers of the previous
+// version keep using the database. It does not take the exclusive lock, which
+// can't be captured while controllers hold shared locks, but a shared lock and
+// a lock that only one binary applying migrations can hold. Each migration is
+// committed as soon as it has run, so the locks it takes are held briefly.
+//
+// An OfflineMigrationError is returned, and no migrations are applied, if any
+// of the migrations to apply is not online. An error is not returned if the
+// database is already at the most recent version.
+func (b *Manager) ApplyOnlineMigrations(ctx context.Context) ([]RepairLog, error) {
+	const op = "schema.(Manager).ApplyOnlineMigrations"
+
+	b.sharedLockMutex.Lock()
+	heldSharedLock := b.sharedLockAcquired
+	b.sharedLockMutex.Unlock()
+	if err := b.SharedLock(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if !heldSharedLock {
+		defer func() {
+			if err := b.SharedUnlock(ctx); err != nil {
+				event.WriteError(ctx, op, fmt.Errorf("error unlocking shared database lock: %w", err))
+			}
+		}()
+	}
+	if err := b.driver.TryMigrationLock(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer func() {
+		if err := b.driver.UnlockMigration(ctx); err != nil {
+			event.WriteError(ctx, op, fmt.Errorf("error unlocking migration lock: %w", err))
+		}
+	}()
+
+	state, err := b.CurrentState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	p := provider.New(state.databaseState(), b.editions)
+	for p.Next() {
+		if !p.Online() {
+			return nil, OfflineMigrationError{
+				Version: p.Version(),
+				Edition: p.Edition(),
+			}
+		}
+	}
+
+	logs, err := b.runMigrations(ctx, provider.New(state.databaseState(), b.editions), true)
 	if err != nil {
 		return nil, err
 	}
@@ -239,19 +325,42 @@ func (b *Manager) ApplyMigrations(ctx context.Context) ([]RepairLog, error) {
 
 // runMigrations passes migration queries to a database driver and manages
 // the version and dirty bit. Cancellation or deadline/timeout is managed
-// through the passed in context.
-func (b *Manager) runMigrations(ctx context.Context, p *provider.Provider) ([]RepairLog, error) {
+// through the passed in context. The migrations are run in a single
+// transaction, except for concurrent migrations, which are run outside of a
+// transaction once the migrations before them are committed. If commitEach
+// is set, each migration is committed as soon as it has run.
+func (b *Manager) runMigrations(ctx context.Context, p *provider.Provider, commitEach bool) ([]RepairLog, error) {
 	const op = "schema.(Manager).runMigrations"
 
 	var logEntries []RepairLog
 	var err error
 
-	if startErr := b.driver.StartRun(ctx); startErr != nil {
-		err = errors.Wrap(ctx, startErr, op)
+	// running is set while a transaction started by start is pending.
+	var running bool
+	start := func() error {
+		if err := b.driver.StartRun(ctx); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		running = true
+		return nil
+	}
+	commit := func() error {
+		running = false
+		if err := b.driver.CommitRun(ctx); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		return nil
+	}
+
+	if startErr := start(); startErr != nil {
+		err = startErr
 		return nil, err
 	}
 
 	defer func() {
+		if !running {
+			return
+		}
 		if commitErr := b.driver.CommitRun(ctx); commitErr != nil {
 			err = errors.Wrap(ctx, commitErr, op)
 		}
@@ -276,6 +385,21 @@ func (b *Manager) runMigrations(ctx context.Context, p *provider.Provider) ([]Re
 			// context is not done yet. Continue on to the next query to execute.
 		}
 
+		if p.Concurrent() {
+			// Concurrent index builds can't be run in a transaction, so the
+			// migrations before them are committed first.
+			if err := commit(); err != nil {
+				return nil, err
+			}
+			if runErr := b.driver.RunConcurrently(ctx, statement.Split(string(p.Statements())), p.Version(), p.Edition(), p.Online()); runErr != nil {
+				return nil, errors.Wrap(ctx, runErr, op)
+			}
+			if err := start(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
 		if h := p.PreHook(); h != nil {
 			problems, err := b.driver.CheckHook(ctx, h.CheckFunc)
 			if err != nil {
@@ -303,10 +427,18 @@ func (b *Manager) runMigrations(ctx context.Context, p *provider.Provider) ([]Re
 				})
 			}
 		}
-		if runErr := b.driver.Run(ctx, bytes.NewReader(p.Statements()), p.Version(), p.Edition()); runErr != nil {
+		if runErr := b.driver.Run(ctx, bytes.NewReader(p.Statements()), p.Version(), p.Edition(), p.Online()); runErr != nil {
 			err = errors.Wrap(ctx, runErr, op)
 			return nil, err
 		}
+		if commitEach {
+			if err := commit(); err != nil {
+				return nil, err
+			}
+			if err := start(); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return logEntries, nil
@@ -784,3 +784,117 @@ func Test_GetMigrationLog(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyOnlineMigrations(t *testing.T) {
+	ctx := context.Background()
+	dialect := dbtest.Postgres
+
+	c, u, _, err := dbtest.StartUsingTemplate(dialect, dbtest.WithTemplate(dbtest.Template1))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c())
+	})
+
+	migrations := migration.Migrations{
+		1: migration.Migration{
+			Statements: []byte(`create table foo (id bigint primary key, name text);`),
+			Edition:    "oss",
+			Version:    1,
+		},
+		2: migration.Migration{
+			Statements: []byte(`alter table foo add column bar text;`),
+			Edition:    "oss",
+			Version:    2,
+			Online:     true,
+		},
+		3: migration.Migration{
+			Statements: []byte(`create index concurrently foo_name_ix on foo (name);`),
+			Edition:    "oss",
+			Version:    3,
+			Online:     true,
+			Concurrent: true,
+		},
+		4: migration.Migration{
+			Statements: []byte(`alter table foo drop column bar;`),
+			Edition:    "oss",
+			Version:    4,
+		},
+	}
+	editionAt := func(version int) schema.Option {
+		ms := make(migration.Migrations)
+		for v, m := range migrations {
+			if v <= version {
+				ms[v] = m
+			}
+		}
+		return schema.WithEditions(edition.Editions{
+			{
+				Name:          "oss",
+				Dialect:       schema.Postgres,
+				LatestVersion: version,
+				Migrations:    ms,
+				Priority:      0,
+			},
+		})
+	}
+
+	d, err := common.SqlOpen(dialect, u)
+	require.NoError(t, err)
+	m, err := schema.NewManager(ctx, schema.Dialect(dialect), d, editionAt(1))
+	require.NoError(t, err)
+	require.NoError(t, m.ExclusiveLock(ctx))
+	_, err = m.ApplyMigrations(ctx)
+	require.NoError(t, err)
+	require.NoError(t, m.ExclusiveUnlock(ctx))
+
+	// A controller of the previous version keeps its shared lock while the
+	// online migrations are applied.
+	require.NoError(t, m.SharedLock(ctx))
+
+	onlineD, err := common.SqlOpen(dialect, u)
+	require.NoError(t, err)
+	onlineM, err := schema.NewManager(ctx, schema.Dialect(dialect), onlineD, editionAt(3))
+	require.NoError(t, err)
+	_, err = onlineM.ApplyOnlineMigrations(ctx)
+	require.NoError(t, err)
+
+	s, err := onlineM.CurrentState(ctx)
+	require.NoError(t, err)
+	assert.True(t, s.MigrationsApplied())
+
+	s, err = m.CurrentState(ctx)
+	require.NoError(t, err)
+	want := &schema.State{
+		Initialized: true,
+		Editions: []schema.EditionState{
+			{
+				Name:                   "oss",
+				BinarySchemaVersion:    1,
+				DatabaseSchemaVersion:  3,
+				MinBinarySchemaVersion: 1,
+				DatabaseSchemaState:    schema.Ahead,
+			},
+		},
+	}
+	assert.Equal(t, want, s)
+	assert.False(t, s.MigrationsApplied())
+	assert.True(t, s.Compatible())
+
+	var exists bool
+	require.NoError(t, d.QueryRowContext(ctx,
+		`select exists (select 1 from pg_indexes where indexname = 'foo_name_ix')`).Scan(&exists))
+	assert.True(t, exists)
+
+	offlineD, err := common.SqlOpen(dialect, u)
+	require.NoError(t, err)
+	offlineM, err := schema.NewManager(ctx, schema.Dialect(dialect), offlineD, editionAt(4))
+	require.NoError(t, err)
+	_, err = offlineM.ApplyOnlineMigrations(ctx)
+	var offlineErr schema.OfflineMigrationError
+	require.ErrorAs(t, err, &offlineErr)
+	assert.Equal(t, schema.OfflineMigrationError{Version: 4, Edition: "oss"}, offlineErr)
+
+	s, err = offlineM.CurrentState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, s.Editions[0].DatabaseSchemaVersion)
+}
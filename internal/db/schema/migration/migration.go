@@ -41,6 +41,15 @@ type Migration struct {
 	Edition    string
 	Version    int
 	PreHook    *Hook
+
+	// Online is set if the migration only makes changes that the previous
+	// version of Boundary can keep using the database through, so that it can
+	// be applied while controllers of that version are running.
+	Online bool
+	// Concurrent is set if the migration only builds indexes concurrently,
+	// which can't be done in a transaction, so its statements are run one at a
+	// time outside of the transaction of the other migrations.
+	Concurrent bool
 }
 
 // Migrations are a set of migrations by version.
@@ -3,7 +3,11 @@
 
 begin;
 create table boundary_schema_version (
-	edition text   primary key,
-	version bigint not null
+	edition            text   primary key,
+	version            bigint not null,
+	-- compatible_version is the oldest schema version that a binary can use
+	-- the database with. It is the version of the last migration applied that
+	-- was not online.
+	compatible_version bigint
 );
 commit;
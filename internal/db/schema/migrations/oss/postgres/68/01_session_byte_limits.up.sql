@@ -0,0 +1,126 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- session_max_bytes is the total number of bytes that may be proxied across
+  -- all connections of a session. connection_max_bytes_per_second is the
+  -- maximum throughput of each connection of a session. Zero means unlimited.
+  alter table target_tcp
+    add column session_max_bytes bigint not null default 0
+      constraint session_max_bytes_must_not_be_negative
+        check(session_max_bytes >= 0),
+    add column connection_max_bytes_per_second bigint not null default 0
+      constraint connection_max_bytes_per_second_must_not_be_negative
+        check(connection_max_bytes_per_second >= 0);
+
+  alter table target_ssh
+    add column session_max_bytes bigint not null default 0
+      constraint session_max_bytes_must_not_be_negative
+        check(session_max_bytes >= 0),
+    add column connection_max_bytes_per_second bigint not null default 0
+      constraint connection_max_bytes_per_second_must_not_be_negative
+        check(connection_max_bytes_per_second >= 0);
+
+  -- The limits are copied to the session when it is authorized so a change to
+  -- the target does not affect existing sessions.
+  alter table session
+    add column max_bytes bigint not null default 0
+      constraint max_bytes_must_not_be_negative
+        check(max_bytes >= 0),
+    add column connection_max_bytes_per_second bigint not null default 0
+      constraint connection_max_bytes_per_second_must_not_be_negative
+        check(connection_max_bytes_per_second >= 0);
+
+  -- Replaces trigger from 59/01_target_ingress_egress_worker_filters.up.sql
+  drop trigger immutable_columns on session;
+  create trigger immutable_columns before update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit',
+      'create_time', 'endpoint', 'worker_filter', 'egress_worker_filter', 'ingress_worker_filter',
+      'max_bytes', 'connection_max_bytes_per_second');
+
+  -- Replaces view from 64/01_ssh_targets.up.sql
+  -- New columns are appended so the dependent whx_* views can be kept.
+  create or replace view target_all_subtypes as
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    'tcp' as type,
+    session_max_bytes,
+    connection_max_bytes_per_second
+  from target_tcp
+  union
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    'ssh' as type,
+    session_max_bytes,
+    connection_max_bytes_per_second
+  from
+    target_ssh;
+
+  -- Add a termination and a connection closed reason for sessions that have
+  -- exhausted their byte quota.
+  alter table session_termination_reason_enm
+    drop constraint only_predefined_session_termination_reasons_allowed;
+  alter table session_termination_reason_enm
+    add constraint only_predefined_session_termination_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'terminated',
+          'network error',
+          'system error',
+          'connection limit',
+          'canceled',
+          'byte quota exceeded'
+        )
+      );
+  insert into session_termination_reason_enm (name)
+  values
+    ('byte quota exceeded');
+
+  alter table session_connection_closed_reason_enm
+    drop constraint only_predefined_session_connection_closed_reasons_allowed;
+  alter table session_connection_closed_reason_enm
+    add constraint only_predefined_session_connection_closed_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'canceled',
+          'network error',
+          'system error',
+          'byte quota exceeded'
+        )
+      );
+  insert into session_connection_closed_reason_enm (name)
+  values
+    ('byte quota exceeded');
+
+commit;
@@ -0,0 +1,95 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- connection_idle_timeout_seconds is the number of seconds a session
+  -- connection may go without proxying data in either direction before the
+  -- worker closes it. Zero means there is no idle timeout.
+  alter table target_tcp
+    add column connection_idle_timeout_seconds int not null default 0
+      constraint connection_idle_timeout_seconds_must_not_be_negative
+        check(connection_idle_timeout_seconds >= 0);
+
+  alter table target_ssh
+    add column connection_idle_timeout_seconds int not null default 0
+      constraint connection_idle_timeout_seconds_must_not_be_negative
+        check(connection_idle_timeout_seconds >= 0);
+
+  alter table session
+    add column connection_idle_timeout_seconds int not null default 0
+      constraint connection_idle_timeout_seconds_must_not_be_negative
+        check(connection_idle_timeout_seconds >= 0);
+
+  -- Replaces trigger from 68/01_session_byte_limits.up.sql
+  drop trigger immutable_columns on session;
+  create trigger immutable_columns before update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit',
+      'create_time', 'endpoint', 'worker_filter', 'egress_worker_filter', 'ingress_worker_filter',
+      'max_bytes', 'connection_max_bytes_per_second', 'connection_idle_timeout_seconds');
+
+  -- Replaces view from 68/01_session_byte_limits.up.sql
+  create or replace view target_all_subtypes as
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    'tcp' as type,
+    session_max_bytes,
+    connection_max_bytes_per_second,
+    connection_idle_timeout_seconds
+  from target_tcp
+  union
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    'ssh' as type,
+    session_max_bytes,
+    connection_max_bytes_per_second,
+    connection_idle_timeout_seconds
+  from
+    target_ssh;
+
+  -- Connections closed by the worker because they were idle for too long.
+  alter table session_connection_closed_reason_enm
+    drop constraint only_predefined_session_connection_closed_reasons_allowed;
+  alter table session_connection_closed_reason_enm
+    add constraint only_predefined_session_connection_closed_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'canceled',
+          'network error',
+          'system error',
+          'byte quota exceeded',
+          'idle timeout'
+        )
+      );
+  insert into session_connection_closed_reason_enm (name)
+  values
+    ('idle timeout');
+
+commit;
@@ -0,0 +1,86 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- When endpoint_tls_enabled is true the worker establishes TLS with the
+  -- endpoint on behalf of the client, optionally verifying the endpoint's
+  -- certificate against endpoint_tls_ca_cert and endpoint_tls_server_name.
+  alter table target_tcp
+    add column endpoint_tls_enabled boolean not null default false,
+    add column endpoint_tls_ca_cert text
+      constraint endpoint_tls_ca_cert_must_not_be_empty
+        check(length(trim(endpoint_tls_ca_cert)) > 0),
+    add column endpoint_tls_server_name text
+      constraint endpoint_tls_server_name_must_not_be_empty
+        check(length(trim(endpoint_tls_server_name)) > 0);
+
+  alter table session
+    add column endpoint_tls_enabled boolean not null default false,
+    add column endpoint_tls_ca_cert text
+      constraint endpoint_tls_ca_cert_must_not_be_empty
+        check(length(trim(endpoint_tls_ca_cert)) > 0),
+    add column endpoint_tls_server_name text
+      constraint endpoint_tls_server_name_must_not_be_empty
+        check(length(trim(endpoint_tls_server_name)) > 0);
+
+  -- Replaces trigger from 68/02_connection_idle_timeout.up.sql
+  drop trigger immutable_columns on session;
+  create trigger immutable_columns before update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit',
+      'create_time', 'endpoint', 'worker_filter', 'egress_worker_filter', 'ingress_worker_filter',
+      'max_bytes', 'connection_max_bytes_per_second', 'connection_idle_timeout_seconds',
+      'endpoint_tls_enabled', 'endpoint_tls_ca_cert', 'endpoint_tls_server_name');
+
+  -- Replaces view from 68/02_connection_idle_timeout.up.sql
+  -- ssh targets establish their own protocol with the endpoint, so endpoint
+  -- TLS does not apply to them.
+  create or replace view target_all_subtypes as
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    'tcp' as type,
+    session_max_bytes,
+    connection_max_bytes_per_second,
+    connection_idle_timeout_seconds,
+    endpoint_tls_enabled,
+    endpoint_tls_ca_cert,
+    endpoint_tls_server_name
+  from target_tcp
+  union
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    'ssh' as type,
+    session_max_bytes,
+    connection_max_bytes_per_second,
+    connection_idle_timeout_seconds,
+    false as endpoint_tls_enabled,
+    null as endpoint_tls_ca_cert,
+    null as endpoint_tls_server_name
+  from
+    target_ssh;
+
+commit;
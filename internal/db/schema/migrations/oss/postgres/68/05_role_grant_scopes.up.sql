@@ -0,0 +1,147 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- iam_role_grant_scope holds the set of scopes that the grants of a role
+  -- apply to. scope_id_or_special is either a scope id or one of the special
+  -- values 'this', 'children', or 'descendants'. A scope id that matches the
+  -- role's own scope is normalized to 'this'.
+  create table iam_role_grant_scope (
+    create_time wt_timestamp,
+    role_id wt_role_id -- pk
+      references iam_role(public_id)
+      on delete cascade
+      on update cascade,
+    scope_id_or_special text -- pk
+      constraint scope_id_or_special_is_valid
+      check(
+        length(trim(scope_id_or_special)) > 10
+        or scope_id_or_special in ('global', 'this', 'children', 'descendants')
+      ),
+    primary key(role_id, scope_id_or_special)
+  );
+  comment on table iam_role_grant_scope is
+    'iam_role_grant_scope is a table where each row represents a scope that the grants of a role apply to';
+
+  create function iam_role_grant_scope_valid() returns trigger
+  as $$
+  declare
+    role_scope_id text;
+    role_scope_type text;
+    grant_scope_parent_id text;
+  begin
+    select r.scope_id, s.type
+      from iam_role r
+      join iam_scope s
+        on s.public_id = r.scope_id
+     where r.public_id = new.role_id
+      into role_scope_id, role_scope_type;
+
+    if new.scope_id_or_special = role_scope_id then
+      new.scope_id_or_special = 'this';
+    end if;
+
+    case new.scope_id_or_special
+      when 'this' then
+        return new;
+      when 'children' then
+        if role_scope_type = 'project' then
+          raise exception 'invalid to set grant scope to children when role scope type is project';
+        end if;
+        perform from iam_role_grant_scope
+          where role_id = new.role_id
+            and scope_id_or_special = 'descendants';
+        if found then
+          raise exception 'invalid to set grant scope to children when descendants is already set';
+        end if;
+        return new;
+      when 'descendants' then
+        if role_scope_type != 'global' then
+          raise exception 'invalid to set grant scope to descendants when role scope type is not global';
+        end if;
+        perform from iam_role_grant_scope
+          where role_id = new.role_id
+            and scope_id_or_special = 'children';
+        if found then
+          raise exception 'invalid to set grant scope to descendants when children is already set';
+        end if;
+        return new;
+      else
+        -- An explicit scope id; the rules match grant_scope_id_valid()
+        if role_scope_type = 'project' then
+          raise exception 'invalid to set grant scope to non-same scope_id when role scope type is project';
+        end if;
+        select isc.parent_id
+          from iam_scope isc
+         where isc.public_id = new.scope_id_or_special
+          into grant_scope_parent_id;
+        if not found then
+          raise exception 'grant scope % does not exist', new.scope_id_or_special;
+        end if;
+        if role_scope_type = 'org' and grant_scope_parent_id is distinct from role_scope_id then
+          raise exception 'grant scope % is not a child project of the role scope', new.scope_id_or_special;
+        end if;
+        return new;
+    end case;
+  end;
+  $$ language plpgsql;
+  comment on function iam_role_grant_scope_valid is
+    'iam_role_grant_scope_valid validates a grant scope against the scope of its role';
+
+  create trigger ensure_grant_scope_valid before insert on iam_role_grant_scope
+    for each row execute procedure iam_role_grant_scope_valid();
+
+  create trigger immutable_columns before update on iam_role_grant_scope
+    for each row execute procedure immutable_columns('create_time', 'role_id', 'scope_id_or_special');
+
+  create trigger default_create_time_column before insert on iam_role_grant_scope
+    for each row execute procedure default_create_time();
+
+  -- Existing roles keep the scope their grants apply to today
+  insert into iam_role_grant_scope (role_id, scope_id_or_special)
+    select public_id, grant_scope_id
+      from iam_role;
+
+  -- iam_role_sync_grant_scope keeps the legacy grant_scope_id column of a role
+  -- and its grant scopes in step: a new role starts with its grant_scope_id as
+  -- its only grant scope, and setting grant_scope_id replaces the grant scopes
+  -- of the role.
+  create function iam_role_sync_grant_scope() returns trigger
+  as $$
+  begin
+    if tg_op = 'UPDATE' then
+      if new.grant_scope_id = old.grant_scope_id then
+        return new;
+      end if;
+      delete from iam_role_grant_scope
+       where role_id = new.public_id;
+    end if;
+    insert into iam_role_grant_scope (role_id, scope_id_or_special)
+    values (new.public_id, new.grant_scope_id);
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function iam_role_sync_grant_scope is
+    'iam_role_sync_grant_scope replaces the grant scopes of a role when its grant_scope_id is set';
+
+  create trigger sync_grant_scope after insert or update of grant_scope_id on iam_role
+    for each row execute procedure iam_role_sync_grant_scope();
+
+  -- iam_scope_delete_grant_scope removes grant scopes that reference a scope
+  -- when the scope is deleted.
+  create function iam_scope_delete_grant_scope() returns trigger
+  as $$
+  begin
+    delete from iam_role_grant_scope
+     where scope_id_or_special = old.public_id;
+    return old;
+  end;
+  $$ language plpgsql;
+  comment on function iam_scope_delete_grant_scope is
+    'iam_scope_delete_grant_scope removes grant scopes that reference a deleted scope';
+
+  create trigger delete_grant_scope after delete on iam_scope
+    for each row execute procedure iam_scope_delete_grant_scope();
+
+commit;
@@ -0,0 +1,72 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- resource_tag holds key/value tags attached to targets, host catalogs and
+  -- credential stores. Multiple tags may share a key with different values.
+  create table resource_tag (
+    resource_id wt_public_id not null,
+    key wt_tagpair,
+    value wt_tagpair,
+    create_time wt_timestamp,
+    primary key(resource_id, key, value)
+  );
+  comment on table resource_tag is
+    'resource_tag is a table where each row represents a tag attached to a target, host catalog or credential store';
+
+  create function resource_tag_resource_exists() returns trigger
+  as $$
+  begin
+    perform from target where public_id = new.resource_id;
+    if found then
+      return new;
+    end if;
+    perform from host_catalog where public_id = new.resource_id;
+    if found then
+      return new;
+    end if;
+    perform from credential_store where public_id = new.resource_id;
+    if found then
+      return new;
+    end if;
+    raise exception 'resource % does not exist or does not support tags', new.resource_id;
+  end;
+  $$ language plpgsql;
+  comment on function resource_tag_resource_exists is
+    'resource_tag_resource_exists ensures that a tag is attached to an existing resource that supports tags';
+
+  create trigger ensure_resource_exists before insert on resource_tag
+    for each row execute procedure resource_tag_resource_exists();
+
+  create trigger immutable_columns before update on resource_tag
+    for each row execute procedure immutable_columns('resource_id', 'key', 'value', 'create_time');
+
+  create trigger default_create_time_column before insert on resource_tag
+    for each row execute procedure default_create_time();
+
+  -- delete_resource_tags removes the tags of a resource when it is deleted
+  create function delete_resource_tags() returns trigger
+  as $$
+  begin
+    delete from resource_tag
+     where resource_id = old.public_id;
+    return old;
+  end;
+  $$ language plpgsql;
+  comment on function delete_resource_tags is
+    'delete_resource_tags removes the tags of a deleted resource';
+
+  create trigger delete_resource_tags after delete on target
+    for each row execute procedure delete_resource_tags();
+
+  create trigger delete_resource_tags after delete on host_catalog
+    for each row execute procedure delete_resource_tags();
+
+  create trigger delete_resource_tags after delete on credential_store
+    for each row execute procedure delete_resource_tags();
+
+  create index resource_tag_key_value_ix
+    on resource_tag (key, value);
+
+commit;
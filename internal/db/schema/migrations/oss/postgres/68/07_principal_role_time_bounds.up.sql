@@ -0,0 +1,89 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- Principal role assignments can be bounded in time. An assignment only
+  -- grants the role's permissions at or after not_before and before not_after;
+  -- either bound may be null. Expired assignments are pruned by a scheduled
+  -- job.
+  alter table iam_user_role
+    add column not_before timestamp with time zone null,
+    add column not_after timestamp with time zone null,
+    add constraint not_before_before_not_after
+      check(not_before is null or not_after is null or not_before < not_after);
+
+  alter table iam_group_role
+    add column not_before timestamp with time zone null,
+    add column not_after timestamp with time zone null,
+    add constraint not_before_before_not_after
+      check(not_before is null or not_after is null or not_before < not_after);
+
+  alter table iam_managed_group_role
+    add column not_before timestamp with time zone null,
+    add column not_after timestamp with time zone null,
+    add constraint not_before_before_not_after
+      check(not_before is null or not_after is null or not_before < not_after);
+
+  create index iam_user_role_not_after_ix on iam_user_role (not_after) where not_after is not null;
+  create index iam_group_role_not_after_ix on iam_group_role (not_after) where not_after is not null;
+  create index iam_managed_group_role_not_after_ix on iam_managed_group_role (not_after) where not_after is not null;
+
+  -- Replaces the view created in 9/04_oidc_managed_group_principal_role to
+  -- add the not_before and not_after columns.
+  create or replace view iam_principal_role as
+  select
+    ur.create_time,
+    ur.principal_id,
+    ur.role_id,
+    u.scope_id as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, u.scope_id, ur.principal_id) as scoped_principal_id,
+    'user' as type,
+    ur.not_before,
+    ur.not_after
+  from
+    iam_user_role ur,
+    iam_role r,
+    iam_user u
+  where
+    ur.role_id = r.public_id and
+    u.public_id = ur.principal_id
+  union
+  select
+    gr.create_time,
+    gr.principal_id,
+    gr.role_id,
+    g.scope_id as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, g.scope_id, gr.principal_id) as scoped_principal_id,
+    'group' as type,
+    gr.not_before,
+    gr.not_after
+  from
+    iam_group_role gr,
+    iam_role r,
+    iam_group g
+  where
+    gr.role_id = r.public_id and
+    g.public_id = gr.principal_id
+  union
+  select
+    mgr.create_time,
+    mgr.principal_id,
+    mgr.role_id,
+    (select scope_id from auth_method am where am.public_id = amg.auth_method_id) as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, (select scope_id from auth_method am where am.public_id = amg.auth_method_id), mgr.principal_id) as scoped_principal_id,
+    'managed group' as type,
+    mgr.not_before,
+    mgr.not_after
+  from
+    iam_managed_group_role mgr,
+    iam_role r,
+    auth_managed_group amg
+  where
+    mgr.role_id = r.public_id and
+    amg.public_id = mgr.principal_id;
+
+commit;
@@ -0,0 +1,76 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  create table iam_scope_quota_resource_type_enm (
+    name text primary key
+      constraint only_predefined_quota_resource_types_allowed
+      check (
+        name in ('target', 'host-catalog', 'credential-store', 'session')
+      )
+  );
+  comment on table iam_scope_quota_resource_type_enm is
+    'iam_scope_quota_resource_type_enm is an enumeration table for the resource types that can be limited by a scope quota';
+
+  insert into iam_scope_quota_resource_type_enm (name)
+  values
+    ('target'),
+    ('host-catalog'),
+    ('credential-store'),
+    ('session');
+
+  -- iam_scope_quota limits the number of resources of a type that can exist
+  -- in an org or project scope. A quota on an org applies to the sum over all
+  -- of its projects. For sessions the limit applies to sessions that have not
+  -- been terminated.
+  create table iam_scope_quota (
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    scope_id wt_scope_id not null -- pk
+      references iam_scope(public_id)
+      on delete cascade
+      on update cascade,
+    resource_type text not null -- pk
+      references iam_scope_quota_resource_type_enm(name)
+      on delete restrict
+      on update cascade,
+    max_count bigint not null
+      constraint max_count_must_not_be_negative
+      check(max_count >= 0)
+      -- limits are reported as unsigned 32 bit integers in the api
+      constraint max_count_must_fit_in_uint32
+      check(max_count <= 4294967295),
+    primary key(scope_id, resource_type)
+  );
+  comment on table iam_scope_quota is
+    'iam_scope_quota is a table where each row limits the number of resources of a type in an org or project scope';
+
+  create function iam_scope_quota_scope_valid() returns trigger
+  as $$
+  begin
+    perform from iam_scope
+      where public_id = new.scope_id
+        and type in ('org', 'project');
+    if not found then
+      raise exception 'quotas can only be set on org and project scopes';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function iam_scope_quota_scope_valid is
+    'iam_scope_quota_scope_valid ensures quotas are only set on org and project scopes';
+
+  create trigger ensure_scope_valid before insert on iam_scope_quota
+    for each row execute procedure iam_scope_quota_scope_valid();
+
+  create trigger immutable_columns before update on iam_scope_quota
+    for each row execute procedure immutable_columns('create_time', 'scope_id', 'resource_type');
+
+  create trigger default_create_time_column before insert on iam_scope_quota
+    for each row execute procedure default_create_time();
+
+  create trigger update_time_column before update on iam_scope_quota
+    for each row execute procedure update_time_column();
+
+commit;
@@ -0,0 +1,224 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- iam_service_account is a principal used by automation. It has no accounts
+  -- and authenticates with the API keys in iam_service_account_api_key.
+  create table iam_service_account (
+    public_id wt_public_id primary key,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    name text,
+    description text,
+    scope_id wt_scope_id not null
+      constraint iam_scope_fkey
+        references iam_scope(public_id)
+        on delete cascade
+        on update cascade,
+    version wt_version,
+    constraint iam_service_account_name_scope_id_uq
+      unique(name, scope_id),
+    constraint iam_service_account_scope_id_public_id_uq
+      unique(scope_id, public_id)
+  );
+  comment on table iam_service_account is
+    'iam_service_account is a table where each row is a service account principal. '
+    'Service accounts exist in the global scope or an org scope.';
+
+  create trigger update_version_column after update on iam_service_account
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on iam_service_account
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on iam_service_account
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on iam_service_account
+    for each row execute procedure immutable_columns('public_id', 'create_time', 'scope_id');
+
+  -- user_scope_id_valid is defined in 0/06_iam and limits the scope to global
+  -- or an org.
+  create trigger service_account_scope_id_valid before insert or update on iam_service_account
+    for each row execute procedure user_scope_id_valid();
+
+  -- iam_service_account_api_key contains the API keys of service accounts. The
+  -- key secrets are random and only their SHA-256 hashes are stored, so they
+  -- do not need to be encrypted. Each key is limited to an org or project
+  -- scope that must be the scope of its service account or within it.
+  create table iam_service_account_api_key (
+    public_id wt_public_id primary key,
+    service_account_id wt_public_id not null
+      constraint iam_service_account_fkey
+        references iam_service_account(public_id)
+        on delete cascade
+        on update cascade,
+    scope_id wt_scope_id not null
+      constraint iam_scope_fkey
+        references iam_scope(public_id)
+        on delete cascade
+        on update cascade,
+    secret_hash bytea not null
+      constraint secret_hash_must_not_be_empty
+        check(length(secret_hash) > 0)
+      constraint iam_service_account_api_key_secret_hash_uq
+        unique,
+    create_time wt_timestamp,
+    expiration_time timestamp with time zone not null,
+    last_used_time timestamp with time zone null,
+    constraint expiration_time_after_create_time
+      check(expiration_time > create_time)
+  );
+  comment on table iam_service_account_api_key is
+    'iam_service_account_api_key is a table where each row is an API key of a service account. '
+    'API keys are not replicated, so they do not have oplog entries.';
+
+  create index iam_service_account_api_key_service_account_id_ix
+    on iam_service_account_api_key (service_account_id);
+
+  create trigger default_create_time_column before insert on iam_service_account_api_key
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on iam_service_account_api_key
+    for each row execute procedure immutable_columns('public_id', 'service_account_id', 'scope_id', 'secret_hash', 'create_time', 'expiration_time');
+
+  create function service_account_api_key_scope_valid() returns trigger
+  as $$
+  declare
+    account_scope_id text;
+  begin
+    select scope_id into account_scope_id
+      from iam_service_account
+     where public_id = new.service_account_id;
+    perform
+       from iam_scope
+      where public_id = new.scope_id
+        and type in ('org', 'project')
+        and (account_scope_id = 'global'
+             or public_id = account_scope_id
+             or parent_id = account_scope_id);
+    if not found then
+      raise exception 'api key scope % is not an org or project within the scope of service account %', new.scope_id, new.service_account_id;
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function service_account_api_key_scope_valid is
+    'service_account_api_key_scope_valid is a before insert trigger function that ensures an API key is limited '
+    'to an org or project within the scope of its service account.';
+
+  create trigger service_account_api_key_scope_valid before insert on iam_service_account_api_key
+    for each row execute procedure service_account_api_key_scope_valid();
+
+  -- iam_service_account_role contains roles that have been assigned to service
+  -- accounts. Like iam_user_role, the rows are immutable after insert.
+  create table iam_service_account_role (
+    create_time wt_timestamp,
+    role_id wt_role_id
+      constraint iam_role_fkey
+        references iam_role(public_id)
+        on delete cascade
+        on update cascade,
+    principal_id wt_public_id
+      constraint iam_service_account_fkey
+        references iam_service_account(public_id)
+        on delete cascade
+        on update cascade,
+    not_before timestamp with time zone null,
+    not_after timestamp with time zone null,
+    constraint not_before_before_not_after
+      check(not_before is null or not_after is null or not_before < not_after),
+    primary key (role_id, principal_id)
+  );
+
+  create index iam_service_account_role_not_after_ix
+    on iam_service_account_role (not_after) where not_after is not null;
+
+  create trigger immutable_role_principal before update on iam_service_account_role
+    for each row execute procedure iam_immutable_role_principal();
+
+  create trigger default_create_time_column before insert on iam_service_account_role
+    for each row execute procedure default_create_time();
+
+  insert into oplog_ticket (name, version)
+    values
+      ('iam_service_account', 1),
+      ('iam_service_account_role', 1);
+
+  -- Replaces the view created in 68/07_principal_role_time_bounds to add
+  -- service accounts.
+  create or replace view iam_principal_role as
+  select
+    ur.create_time,
+    ur.principal_id,
+    ur.role_id,
+    u.scope_id as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, u.scope_id, ur.principal_id) as scoped_principal_id,
+    'user' as type,
+    ur.not_before,
+    ur.not_after
+  from
+    iam_user_role ur,
+    iam_role r,
+    iam_user u
+  where
+    ur.role_id = r.public_id and
+    u.public_id = ur.principal_id
+  union
+  select
+    gr.create_time,
+    gr.principal_id,
+    gr.role_id,
+    g.scope_id as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, g.scope_id, gr.principal_id) as scoped_principal_id,
+    'group' as type,
+    gr.not_before,
+    gr.not_after
+  from
+    iam_group_role gr,
+    iam_role r,
+    iam_group g
+  where
+    gr.role_id = r.public_id and
+    g.public_id = gr.principal_id
+  union
+  select
+    mgr.create_time,
+    mgr.principal_id,
+    mgr.role_id,
+    (select scope_id from auth_method am where am.public_id = amg.auth_method_id) as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, (select scope_id from auth_method am where am.public_id = amg.auth_method_id), mgr.principal_id) as scoped_principal_id,
+    'managed group' as type,
+    mgr.not_before,
+    mgr.not_after
+  from
+    iam_managed_group_role mgr,
+    iam_role r,
+    auth_managed_group amg
+  where
+    mgr.role_id = r.public_id and
+    amg.public_id = mgr.principal_id
+  union
+  select
+    sar.create_time,
+    sar.principal_id,
+    sar.role_id,
+    sa.scope_id as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, sa.scope_id, sar.principal_id) as scoped_principal_id,
+    'service account' as type,
+    sar.not_before,
+    sar.not_after
+  from
+    iam_service_account_role sar,
+    iam_role r,
+    iam_service_account sa
+  where
+    sar.role_id = r.public_id and
+    sa.public_id = sar.principal_id;
+
+commit;
@@ -0,0 +1,57 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- kms_key_rotation_policy schedules the rotation of the keys of a scope.
+  -- The key rotation job rotates the keys of each scope whose
+  -- next_rotation_time has passed.
+  create table kms_key_rotation_policy (
+    scope_id wt_scope_id primary key
+      references iam_scope(public_id)
+        on delete cascade
+        on update cascade,
+    rotation_interval_seconds bigint not null
+      constraint rotation_interval_seconds_must_be_positive
+        check (rotation_interval_seconds > 0),
+    destroy_previous_versions boolean not null default false,
+    next_rotation_time wt_timestamp,
+    last_rotation_time timestamp with time zone,
+    create_time wt_timestamp,
+    update_time wt_timestamp
+  );
+  comment on table kms_key_rotation_policy is
+    'kms_key_rotation_policy is a table where each row schedules the rotation of the keys of a scope';
+
+  create index kms_key_rotation_policy_next_rotation_time_ix
+    on kms_key_rotation_policy (next_rotation_time);
+
+  create trigger immutable_columns before update on kms_key_rotation_policy
+    for each row execute procedure immutable_columns('scope_id', 'create_time');
+
+  create trigger default_create_time_column before insert on kms_key_rotation_policy
+    for each row execute procedure default_create_time();
+
+  create trigger update_time_column before update on kms_key_rotation_policy
+    for each row execute procedure update_time_column();
+
+  -- kms_key_rotation records each rotation of the keys of a scope, whether it
+  -- was requested through the api or run by the key rotation job.
+  create table kms_key_rotation (
+    scope_id wt_scope_id not null
+      references iam_scope(public_id)
+        on delete cascade
+        on update cascade,
+    rotate_time wt_timestamp,
+    scheduled boolean not null,
+    rewrap boolean not null,
+    destroy_previous_versions boolean not null,
+    primary key (scope_id, rotate_time)
+  );
+  comment on table kms_key_rotation is
+    'kms_key_rotation is a table where each row is a rotation of the keys of a scope';
+
+  create trigger immutable_columns before update on kms_key_rotation
+    for each row execute procedure immutable_columns('scope_id', 'rotate_time', 'scheduled', 'rewrap', 'destroy_previous_versions');
+
+commit;
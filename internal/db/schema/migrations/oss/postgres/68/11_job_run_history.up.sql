@@ -0,0 +1,48 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- Adds the error of failed job runs, so the history of runs kept by the
+  -- job run cleaner records why a run failed.
+  -- Alters table job_run defined in 7/03_job.up.sql
+  alter table job_run
+    add column error_message text;
+
+  -- Adds the count of runs of a job that have failed or been interrupted
+  -- since its last completed run.
+  -- Alters table job defined in 7/03_job.up.sql
+  alter table job
+    add column consecutive_failures int not null default 0
+      constraint consecutive_failures_can_not_be_negative
+        check(consecutive_failures >= 0);
+
+  create function update_job_consecutive_failures() returns trigger
+  as $$
+  begin
+    update job
+       set consecutive_failures = case new.status
+                                    when 'completed' then 0
+                                    else consecutive_failures + 1
+                                  end
+     where plugin_id = new.job_plugin_id
+       and name      = new.job_name;
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function update_job_consecutive_failures is
+    'update_job_consecutive_failures is a trigger function that resets the consecutive failures of a job when one of its runs completes, and increments them when one of its runs fails or is interrupted.';
+
+  create trigger update_job_consecutive_failures after update of status on job_run
+    for each row
+    when (old.status = 'running' and new.status <> 'running')
+    execute procedure update_job_consecutive_failures();
+
+  -- The job run cleaner keeps the most recent ended runs of each job.
+  create index job_run_job_end_time_ix
+    on job_run (job_plugin_id, job_name, end_time desc)
+    where status <> 'running';
+  comment on index job_run_job_end_time_ix is
+    'the job_run_job_end_time_ix is used by the job run cleaner job';
+
+commit;
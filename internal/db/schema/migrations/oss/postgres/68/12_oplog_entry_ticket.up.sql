@@ -0,0 +1,30 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- Adds the name and version of the ticket redeemed by each oplog entry, so
+  -- the entries written for a ticket can be checked for gaps. Entries written
+  -- before this migration have neither.
+  -- Alters table oplog_entry defined in 0/02_oplog.up.sql
+  alter table oplog_entry
+    add column ticket_name text,
+    add column ticket_version bigint,
+    add constraint ticket_name_and_version_set_together
+      check(
+        (ticket_name is null and ticket_version is null)
+        or
+        (ticket_name is not null and ticket_version is not null)
+      );
+
+  -- Replaces trigger from 0/02_oplog.up.sql
+  drop trigger immutable_columns on oplog_entry;
+  create trigger immutable_columns before update on oplog_entry
+    for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name', 'data', 'ticket_name', 'ticket_version');
+
+  create index oplog_entry_ticket_ix
+    on oplog_entry (ticket_name, ticket_version);
+  comment on index oplog_entry_ticket_ix is
+    'the oplog_entry_ticket_ix is used by the boundary oplog verify command';
+
+commit;
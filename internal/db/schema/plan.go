@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db/schema/internal/provider"
+	"github.com/hashicorp/boundary/internal/db/schema/internal/statement"
+	"github.com/hashicorp/boundary/internal/db/schema/migration"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// LockMode is a table lock taken by a migration.
+type LockMode int
+
+// Lock modes, from weakest to strongest.
+const (
+	RowExclusive         LockMode = iota + 1 // Taken by inserts, updates and deletes; does not block reads.
+	ShareUpdateExclusive                     // Taken by creating an index concurrently; does not block reads or writes.
+	Share                                    // Taken by creating an index; blocks writes.
+	ShareRowExclusive                        // Taken by creating a trigger; blocks writes.
+	AccessExclusive                          // Taken by altering, dropping or truncating a table; blocks reads and writes.
+)
+
+func (l LockMode) String() string {
+	switch l {
+	case RowExclusive:
+		return "row exclusive"
+	case ShareUpdateExclusive:
+		return "share update exclusive"
+	case Share:
+		return "share"
+	case ShareRowExclusive:
+		return "share row exclusive"
+	case AccessExclusive:
+		return "access exclusive"
+	default:
+		return "unknown"
+	}
+}
+
+// TableImpact is a table changed by a migration.
+type TableImpact struct {
+	Name string
+	// Lock is the strongest lock the migration takes on the table.
+	Lock LockMode
+	// EstimatedRows is an estimate of the number of rows in the table, or -1
+	// if the table does not exist yet.
+	EstimatedRows int64
+}
+
+// PlannedCheck is the result of running the check hook of a migration.
+type PlannedCheck struct {
+	Problems migration.Problems
+	// Err is set if the check could not be run.
+	Err error
+
+	RepairDescription string
+	// RepairSelected is set if the repair of the migration was selected with
+	// WithRepairMigrations, so that ApplyMigrations repairs any problems rather
+	// than failing.
+	RepairSelected bool
+}
+
+// Fails reports whether ApplyMigrations would fail because of the problems
+// found by the check.
+func (c *PlannedCheck) Fails() bool {
+	return len(c.Problems) > 0 && !c.RepairSelected
+}
+
+// PlannedMigration is a migration that ApplyMigrations would run.
+type PlannedMigration struct {
+	Edition    string
+	Version    int
+	Statements string
+	// Online is set if the migration can be applied while controllers of the
+	// previous version are running.
+	Online bool
+	// Tables are the existing or new tables the statements change, in the
+	// order they are first changed. Statements in function bodies and
+	// anonymous code blocks are not included.
+	Tables []TableImpact
+	// Check is the result of the check hook of the migration, if it has one.
+	Check *PlannedCheck
+}
+
+// PlanMigrations returns the migrations ApplyMigrations would run, in the
+// order it would run them, without taking the exclusive lock or writing
+// anything. The check hook of each migration is run in a read only
+// transaction against the current schema, so the check of a migration that
+// depends on an earlier pending migration may fail to run.
+func (b *Manager) PlanMigrations(ctx context.Context) ([]PlannedMigration, error) {
+	const op = "schema.(Manager).PlanMigrations"
+
+	state, err := b.CurrentState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	estimates, err := b.driver.EstimateRows(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	var plan []PlannedMigration
+	p := provider.New(state.databaseState(), b.editions)
+	for p.Next() {
+		pm := PlannedMigration{
+			Edition:    p.Edition(),
+			Version:    p.Version(),
+			Statements: string(p.Statements()),
+			Online:     p.Online(),
+		}
+		for _, t := range tablesChanged(pm.Statements) {
+			t.EstimatedRows = -1
+			if n, ok := estimates[t.Name]; ok {
+				t.EstimatedRows = n
+			}
+			pm.Tables = append(pm.Tables, t)
+		}
+		if h := p.PreHook(); h != nil {
+			c := &PlannedCheck{
+				RepairDescription: h.RepairDescription,
+				RepairSelected:    b.selectedRepairs.IsSet(p.Edition(), p.Version()),
+			}
+			c.Problems, c.Err = b.driver.DryRunCheckHook(ctx, h.CheckFunc)
+			pm.Check = c
+		}
+		plan = append(plan, pm)
+	}
+	return plan, nil
+}
+
+const (
+	sqlIdent     = `(?:"[^"]+"|[a-z_][a-z0-9_$]*)(?:\.(?:"[^"]+"|[a-z_][a-z0-9_$]*))?`
+	sqlIdentList = sqlIdent + `(?:\s*,\s*` + sqlIdent + `)*`
+)
+
+// tableStatements match the statements that change tables. The first group of
+// each is the table, or tables, changed. Only the first one matching a
+// statement is used, so indexes created concurrently are matched first.
+var tableStatements = []struct {
+	re   *regexp.Regexp
+	lock LockMode
+}{
+	{regexp.MustCompile(`^alter\s+table\s+(?:if\s+exists\s+)?(?:only\s+)?(` + sqlIdent + `)`), AccessExclusive},
+	{regexp.MustCompile(`^drop\s+table\s+(?:if\s+exists\s+)?(` + sqlIdentList + `)`), AccessExclusive},
+	{regexp.MustCompile(`^truncate\s+(?:table\s+)?(?:only\s+)?(` + sqlIdentList + `)`), AccessExclusive},
+	{regexp.MustCompile(`^drop\s+trigger\s+(?:if\s+exists\s+)?` + sqlIdent + `\s+on\s+(` + sqlIdent + `)`), AccessExclusive},
+	{regexp.MustCompile(`(?s)^create\s+(?:or\s+replace\s+)?(?:constraint\s+)?trigger\s+` + sqlIdent + `\s+.*?\s+on\s+(` + sqlIdent + `)`), ShareRowExclusive},
+	{regexp.MustCompile(`^create\s+(?:unique\s+)?index\s+concurrently\s+(?:if\s+not\s+exists\s+)?(?:` + sqlIdent + `\s+)?on\s+(?:only\s+)?(` + sqlIdent + `)`), ShareUpdateExclusive},
+	{regexp.MustCompile(`^create\s+(?:unique\s+)?index\s+(?:if\s+not\s+exists\s+)?(?:` + sqlIdent + `\s+)?on\s+(?:only\s+)?(` + sqlIdent + `)`), Share},
+	{regexp.MustCompile(`^update\s+(?:only\s+)?(` + sqlIdent + `)`), RowExclusive},
+	{regexp.MustCompile(`^delete\s+from\s+(?:only\s+)?(` + sqlIdent + `)`), RowExclusive},
+	{regexp.MustCompile(`^insert\s+into\s+(` + sqlIdent + `)`), RowExclusive},
+}
+
+// tablesChanged returns the tables changed by the statements and the
+// strongest lock taken on each. EstimatedRows is not set.
+func tablesChanged(statements string) []TableImpact {
+	var tables []TableImpact
+	index := make(map[string]int)
+	for _, stmt := range statement.Skeletons(statements) {
+		stmt = strings.ToLower(stmt)
+		for _, ts := range tableStatements {
+			m := ts.re.FindStringSubmatch(stmt)
+			if m == nil {
+				continue
+			}
+			for _, name := range strings.Split(m[1], ",") {
+				name = unqualify(name)
+				if i, ok := index[name]; ok {
+					if ts.lock > tables[i].Lock {
+						tables[i].Lock = ts.lock
+					}
+					continue
+				}
+				index[name] = len(tables)
+				tables = append(tables, TableImpact{Name: name, Lock: ts.lock})
+			}
+			break
+		}
+	}
+	return tables
+}
+
+// unqualify returns the unquoted name of a table without its schema.
+func unqualify(name string) string {
+	name = strings.TrimSpace(name)
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.Trim(name, `"`)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTablesChanged(t *testing.T) {
+	tests := []struct {
+		name       string
+		statements string
+		want       []TableImpact
+	}{
+		{
+			name:       "none",
+			statements: `create table foo (id int primary key);`,
+		},
+		{
+			name: "strongest-lock",
+			statements: `
+insert into session (public_id) values ('s_1234567890');
+update session set termination_reason = 'closed by end-user';
+create index session_create_time_ix on session (create_time);
+alter table only public.session
+  add column bar text;
+`,
+			want: []TableImpact{{Name: "session", Lock: AccessExclusive}},
+		},
+		{
+			name: "order-of-first-change",
+			statements: `
+create unique index if not exists target_name_uq on target (name);
+delete from only "target_host_set";
+drop table if exists host_set_old, host_old;
+truncate table session_state;
+`,
+			want: []TableImpact{
+				{Name: "target", Lock: Share},
+				{Name: "target_host_set", Lock: RowExclusive},
+				{Name: "host_set_old", Lock: AccessExclusive},
+				{Name: "host_old", Lock: AccessExclusive},
+				{Name: "session_state", Lock: AccessExclusive},
+			},
+		},
+		{
+			name: "index-concurrently",
+			statements: `
+create index concurrently on session (create_time);
+create unique index concurrently if not exists target_name_uq on target (name);
+create index target_project_ix on target (project_id);
+`,
+			want: []TableImpact{
+				{Name: "session", Lock: ShareUpdateExclusive},
+				{Name: "target", Lock: Share},
+			},
+		},
+		{
+			name: "triggers",
+			statements: `
+drop trigger if exists immutable_columns on iam_role;
+create trigger update_version_column
+  after update of version, name on iam_group
+  for each row execute procedure update_version_column();
+`,
+			want: []TableImpact{
+				{Name: "iam_role", Lock: AccessExclusive},
+				{Name: "iam_group", Lock: ShareRowExclusive},
+			},
+		},
+		{
+			name: "function-bodies-and-comments-ignored",
+			statements: `
+-- update iam_user set name = 'x';
+/* delete from iam_user; */
+create or replace function delete_all() returns trigger
+as $$
+begin
+  delete from iam_scope;
+  return null;
+end;
+$$ language plpgsql;
+comment on function delete_all() is
+  'delete_all; update iam_group';
+do $body$ begin update iam_role set name = null; end $body$;
+update auth_token set status = 'expired';
+`,
+			want: []TableImpact{{Name: "auth_token", Lock: RowExclusive}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tablesChanged(tt.statements))
+		})
+	}
+}
+
+func TestLockMode_String(t *testing.T) {
+	assert.Equal(t, "access exclusive", AccessExclusive.String())
+	assert.Equal(t, "row exclusive", RowExclusive.String())
+	assert.Equal(t, "share update exclusive", ShareUpdateExclusive.String())
+	assert.Equal(t, "unknown", LockMode(0).String())
+}
@@ -24,6 +24,17 @@ func (s State) MigrationsApplied() bool {
 	return true
 }
 
+// Compatible checks to see that a binary with the schema versions of the
+// Editions can use the database, that is, that all Editions are Compatible.
+func (s State) Compatible() bool {
+	for _, e := range s.Editions {
+		if !e.Compatible() {
+			return false
+		}
+	}
+	return true
+}
+
 func (s State) databaseState() provider.DatabaseState {
 	dbState := make(provider.DatabaseState)
 	for _, e := range s.Editions {
@@ -52,10 +63,30 @@ type EditionState struct {
 	DatabaseSchemaVersion int
 	// BinarySchemaVersion is the schema version which this boundary binary supports.
 	BinarySchemaVersion int
+	// MinBinarySchemaVersion is the oldest BinarySchemaVersion that can use
+	// the database, if online migrations were applied since the last
+	// migration that was not online. It is zero otherwise, when only a binary
+	// with the DatabaseSchemaVersion can use the database.
+	MinBinarySchemaVersion int
 
 	DatabaseSchemaState DatabaseState
 }
 
+// Compatible checks to see that a binary with the BinarySchemaVersion can use
+// the database. That is the case if the DatabaseSchemaState is Equal, or if it
+// is Ahead and the migrations applied since the BinarySchemaVersion are all
+// online.
+func (e EditionState) Compatible() bool {
+	switch e.DatabaseSchemaState {
+	case Equal:
+		return true
+	case Ahead:
+		return e.MinBinarySchemaVersion > 0 && e.MinBinarySchemaVersion <= e.BinarySchemaVersion
+	default:
+		return false
+	}
+}
+
 func compareVersions(d int, b int) DatabaseState {
 	if d == b {
 		return Equal
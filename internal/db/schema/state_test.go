@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_Compatible(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		editions   []schema.EditionState
+		applied    bool
+		compatible bool
+	}{
+		{
+			name: "equal",
+			editions: []schema.EditionState{
+				{Name: "oss", DatabaseSchemaVersion: 2, BinarySchemaVersion: 2, DatabaseSchemaState: schema.Equal},
+			},
+			applied:    true,
+			compatible: true,
+		},
+		{
+			name: "behind",
+			editions: []schema.EditionState{
+				{Name: "oss", DatabaseSchemaVersion: 1, BinarySchemaVersion: 2, DatabaseSchemaState: schema.Behind},
+			},
+		},
+		{
+			name: "ahead",
+			editions: []schema.EditionState{
+				{Name: "oss", DatabaseSchemaVersion: 3, BinarySchemaVersion: 2, DatabaseSchemaState: schema.Ahead},
+			},
+		},
+		{
+			name: "ahead-online",
+			editions: []schema.EditionState{
+				{Name: "oss", DatabaseSchemaVersion: 3, BinarySchemaVersion: 2, MinBinarySchemaVersion: 2, DatabaseSchemaState: schema.Ahead},
+			},
+			compatible: true,
+		},
+		{
+			name: "ahead-online-since-newer-version",
+			editions: []schema.EditionState{
+				{Name: "oss", DatabaseSchemaVersion: 4, BinarySchemaVersion: 2, MinBinarySchemaVersion: 3, DatabaseSchemaState: schema.Ahead},
+			},
+		},
+		{
+			name: "one-edition-not-compatible",
+			editions: []schema.EditionState{
+				{Name: "oss", DatabaseSchemaVersion: 3, BinarySchemaVersion: 2, MinBinarySchemaVersion: 1, DatabaseSchemaState: schema.Ahead},
+				{Name: "ent", DatabaseSchemaVersion: 1, BinarySchemaVersion: 2, DatabaseSchemaState: schema.Behind},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := schema.State{Initialized: true, Editions: tt.editions}
+			assert.Equal(t, tt.applied, s.MigrationsApplied())
+			assert.Equal(t, tt.compatible, s.Compatible())
+		})
+	}
+}
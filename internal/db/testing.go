@@ -107,7 +107,8 @@ func TestSetupWithMock(t *testing.T) (*DB, sqlmock.Sqlmock) {
 	}))
 	require.NoError(err)
 	ret := &DB{
-		wrapped: new(atomic.Pointer[dbw.DB]),
+		wrapped:  new(atomic.Pointer[dbw.DB]),
+		replicas: new(atomic.Pointer[Replicas]),
 	}
 	ret.wrapped.Store(dbWith)
 	return ret, mock
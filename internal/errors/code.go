@@ -52,6 +52,9 @@ const (
 	RetryLimitExceeded = 124 // RetryLimitExceeded represents an error when a retry limit is exceeded
 	// Note: Currently unused in OSS
 	QueueIsFull = 125 // QueueIsFull results in attempting to add an item to a queue which is full
+	// QuotaExceeded represents an attempt to create a resource in a scope
+	// whose quota for that resource type has been reached
+	QuotaExceeded Code = 126
 
 	AuthAttemptExpired Code = 198 // AuthAttemptExpired represents an expired authentication attempt
 	AuthMethodInactive Code = 199 // AuthMethodInactive represents an error that means the auth method is not active.
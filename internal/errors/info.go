@@ -287,4 +287,8 @@ var errorCodeInfo = map[Code]Info{
 		Message: "retry limit exceeded",
 		Kind:    State,
 	},
+	QuotaExceeded: {
+		Message: "quota exceeded",
+		Kind:    State,
+	},
 }
@@ -44,6 +44,19 @@ type CredentialStoreServiceClient interface {
 	// DeleteCredentialStore removes a Credential Store from Boundary. If the Credential Store id
 	// is malformed or not provided an error is returned.
 	DeleteCredentialStore(ctx context.Context, in *DeleteCredentialStoreRequest, opts ...grpc.CallOption) (*DeleteCredentialStoreResponse, error)
+	// AddCredentialStoreTags adds tags to an existing Credential Store. The provided request must
+	// include the Credential Store ID and the version, which must match the current
+	// version of the Credential Store. If missing, malformed, or referencing a
+	// non-existing resource, an error is returned.
+	AddCredentialStoreTags(ctx context.Context, in *AddCredentialStoreTagsRequest, opts ...grpc.CallOption) (*AddCredentialStoreTagsResponse, error)
+	// SetCredentialStoreTags sets tags for an existing Credential Store. Any existing tags are
+	// removed if they are not included in this request. If missing, malformed,
+	// or referencing a non-existing resource, an error is returned.
+	SetCredentialStoreTags(ctx context.Context, in *SetCredentialStoreTagsRequest, opts ...grpc.CallOption) (*SetCredentialStoreTagsResponse, error)
+	// RemoveCredentialStoreTags removes tags from an existing Credential Store. An error is
+	// returned if a tag is attempted to be removed from the Credential Store when the
+	// Credential Store does not have the tag.
+	RemoveCredentialStoreTags(ctx context.Context, in *RemoveCredentialStoreTagsRequest, opts ...grpc.CallOption) (*RemoveCredentialStoreTagsResponse, error)
 }
 
 type credentialStoreServiceClient struct {
@@ -99,6 +112,33 @@ func (c *credentialStoreServiceClient) DeleteCredentialStore(ctx context.Context
 	return out, nil
 }
 
+func (c *credentialStoreServiceClient) AddCredentialStoreTags(ctx context.Context, in *AddCredentialStoreTagsRequest, opts ...grpc.CallOption) (*AddCredentialStoreTagsResponse, error) {
+	out := new(AddCredentialStoreTagsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.CredentialStoreService/AddCredentialStoreTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *credentialStoreServiceClient) SetCredentialStoreTags(ctx context.Context, in *SetCredentialStoreTagsRequest, opts ...grpc.CallOption) (*SetCredentialStoreTagsResponse, error) {
+	out := new(SetCredentialStoreTagsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.CredentialStoreService/SetCredentialStoreTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *credentialStoreServiceClient) RemoveCredentialStoreTags(ctx context.Context, in *RemoveCredentialStoreTagsRequest, opts ...grpc.CallOption) (*RemoveCredentialStoreTagsResponse, error) {
+	out := new(RemoveCredentialStoreTagsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.CredentialStoreService/RemoveCredentialStoreTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CredentialStoreServiceServer is the server API for CredentialStoreService service.
 // All implementations must embed UnimplementedCredentialStoreServiceServer
 // for forward compatibility
@@ -129,6 +169,19 @@ type CredentialStoreServiceServer interface {
 	// DeleteCredentialStore removes a Credential Store from Boundary. If the Credential Store id
 	// is malformed or not provided an error is returned.
 	DeleteCredentialStore(context.Context, *DeleteCredentialStoreRequest) (*DeleteCredentialStoreResponse, error)
+	// AddCredentialStoreTags adds tags to an existing Credential Store. The provided request must
+	// include the Credential Store ID and the version, which must match the current
+	// version of the Credential Store. If missing, malformed, or referencing a
+	// non-existing resource, an error is returned.
+	AddCredentialStoreTags(context.Context, *AddCredentialStoreTagsRequest) (*AddCredentialStoreTagsResponse, error)
+	// SetCredentialStoreTags sets tags for an existing Credential Store. Any existing tags are
+	// removed if they are not included in this request. If missing, malformed,
+	// or referencing a non-existing resource, an error is returned.
+	SetCredentialStoreTags(context.Context, *SetCredentialStoreTagsRequest) (*SetCredentialStoreTagsResponse, error)
+	// RemoveCredentialStoreTags removes tags from an existing Credential Store. An error is
+	// returned if a tag is attempted to be removed from the Credential Store when the
+	// Credential Store does not have the tag.
+	RemoveCredentialStoreTags(context.Context, *RemoveCredentialStoreTagsRequest) (*RemoveCredentialStoreTagsResponse, error)
 	mustEmbedUnimplementedCredentialStoreServiceServer()
 }
 
@@ -151,6 +204,15 @@ func (UnimplementedCredentialStoreServiceServer) UpdateCredentialStore(context.C
 func (UnimplementedCredentialStoreServiceServer) DeleteCredentialStore(context.Context, *DeleteCredentialStoreRequest) (*DeleteCredentialStoreResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteCredentialStore not implemented")
 }
+func (UnimplementedCredentialStoreServiceServer) AddCredentialStoreTags(context.Context, *AddCredentialStoreTagsRequest) (*AddCredentialStoreTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCredentialStoreTags not implemented")
+}
+func (UnimplementedCredentialStoreServiceServer) SetCredentialStoreTags(context.Context, *SetCredentialStoreTagsRequest) (*SetCredentialStoreTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCredentialStoreTags not implemented")
+}
+func (UnimplementedCredentialStoreServiceServer) RemoveCredentialStoreTags(context.Context, *RemoveCredentialStoreTagsRequest) (*RemoveCredentialStoreTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCredentialStoreTags not implemented")
+}
 func (UnimplementedCredentialStoreServiceServer) mustEmbedUnimplementedCredentialStoreServiceServer() {
 }
 
@@ -255,6 +317,60 @@ func _CredentialStoreService_DeleteCredentialStore_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CredentialStoreService_AddCredentialStoreTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCredentialStoreTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialStoreServiceServer).AddCredentialStoreTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.CredentialStoreService/AddCredentialStoreTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialStoreServiceServer).AddCredentialStoreTags(ctx, req.(*AddCredentialStoreTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CredentialStoreService_SetCredentialStoreTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCredentialStoreTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialStoreServiceServer).SetCredentialStoreTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.CredentialStoreService/SetCredentialStoreTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialStoreServiceServer).SetCredentialStoreTags(ctx, req.(*SetCredentialStoreTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CredentialStoreService_RemoveCredentialStoreTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCredentialStoreTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialStoreServiceServer).RemoveCredentialStoreTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.CredentialStoreService/RemoveCredentialStoreTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialStoreServiceServer).RemoveCredentialStoreTags(ctx, req.(*RemoveCredentialStoreTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CredentialStoreService_ServiceDesc is the grpc.ServiceDesc for CredentialStoreService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -282,6 +398,18 @@ var CredentialStoreService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteCredentialStore",
 			Handler:    _CredentialStoreService_DeleteCredentialStore_Handler,
 		},
+		{
+			MethodName: "AddCredentialStoreTags",
+			Handler:    _CredentialStoreService_AddCredentialStoreTags_Handler,
+		},
+		{
+			MethodName: "SetCredentialStoreTags",
+			Handler:    _CredentialStoreService_SetCredentialStoreTags_Handler,
+		},
+		{
+			MethodName: "RemoveCredentialStoreTags",
+			Handler:    _CredentialStoreService_RemoveCredentialStoreTags_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/credential_store_service.proto",
@@ -61,6 +61,9 @@ type GroupServiceClient interface {
 	// An error is returned if any provided id is missing, malformed or
 	// references a non-existing resource.
 	RemoveGroupMembers(ctx context.Context, in *RemoveGroupMembersRequest, opts ...grpc.CallOption) (*RemoveGroupMembersResponse, error)
+	// ListGroupHistory returns the changes made to a Group, its members and
+	// its assignment to Roles, oldest first, as recorded in the oplog.
+	ListGroupHistory(ctx context.Context, in *ListGroupHistoryRequest, opts ...grpc.CallOption) (*ListGroupHistoryResponse, error)
 }
 
 type groupServiceClient struct {
@@ -143,6 +146,15 @@ func (c *groupServiceClient) RemoveGroupMembers(ctx context.Context, in *RemoveG
 	return out, nil
 }
 
+func (c *groupServiceClient) ListGroupHistory(ctx context.Context, in *ListGroupHistoryRequest, opts ...grpc.CallOption) (*ListGroupHistoryResponse, error) {
+	out := new(ListGroupHistoryResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.GroupService/ListGroupHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GroupServiceServer is the server API for GroupService service.
 // All implementations must embed UnimplementedGroupServiceServer
 // for forward compatibility
@@ -190,6 +202,9 @@ type GroupServiceServer interface {
 	// An error is returned if any provided id is missing, malformed or
 	// references a non-existing resource.
 	RemoveGroupMembers(context.Context, *RemoveGroupMembersRequest) (*RemoveGroupMembersResponse, error)
+	// ListGroupHistory returns the changes made to a Group, its members and
+	// its assignment to Roles, oldest first, as recorded in the oplog.
+	ListGroupHistory(context.Context, *ListGroupHistoryRequest) (*ListGroupHistoryResponse, error)
 	mustEmbedUnimplementedGroupServiceServer()
 }
 
@@ -221,6 +236,9 @@ func (UnimplementedGroupServiceServer) SetGroupMembers(context.Context, *SetGrou
 func (UnimplementedGroupServiceServer) RemoveGroupMembers(context.Context, *RemoveGroupMembersRequest) (*RemoveGroupMembersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveGroupMembers not implemented")
 }
+func (UnimplementedGroupServiceServer) ListGroupHistory(context.Context, *ListGroupHistoryRequest) (*ListGroupHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroupHistory not implemented")
+}
 func (UnimplementedGroupServiceServer) mustEmbedUnimplementedGroupServiceServer() {}
 
 // UnsafeGroupServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -378,6 +396,24 @@ func _GroupService_RemoveGroupMembers_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GroupService_ListGroupHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServiceServer).ListGroupHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.GroupService/ListGroupHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServiceServer).ListGroupHistory(ctx, req.(*ListGroupHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GroupService_ServiceDesc is the grpc.ServiceDesc for GroupService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -417,6 +453,10 @@ var GroupService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveGroupMembers",
 			Handler:    _GroupService_RemoveGroupMembers_Handler,
 		},
+		{
+			MethodName: "ListGroupHistory",
+			Handler:    _GroupService_ListGroupHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/group_service.proto",
@@ -44,6 +44,19 @@ type HostCatalogServiceClient interface {
 	// sets from Boundary. If the provided Host Catalog IDs is malformed or not
 	// provided DeleteHostCatalog returns an error.
 	DeleteHostCatalog(ctx context.Context, in *DeleteHostCatalogRequest, opts ...grpc.CallOption) (*DeleteHostCatalogResponse, error)
+	// AddHostCatalogTags adds tags to an existing Host Catalog. The provided request must
+	// include the Host Catalog ID and the version, which must match the current
+	// version of the Host Catalog. If missing, malformed, or referencing a
+	// non-existing resource, an error is returned.
+	AddHostCatalogTags(ctx context.Context, in *AddHostCatalogTagsRequest, opts ...grpc.CallOption) (*AddHostCatalogTagsResponse, error)
+	// SetHostCatalogTags sets tags for an existing Host Catalog. Any existing tags are
+	// removed if they are not included in this request. If missing, malformed,
+	// or referencing a non-existing resource, an error is returned.
+	SetHostCatalogTags(ctx context.Context, in *SetHostCatalogTagsRequest, opts ...grpc.CallOption) (*SetHostCatalogTagsResponse, error)
+	// RemoveHostCatalogTags removes tags from an existing Host Catalog. An error is
+	// returned if a tag is attempted to be removed from the Host Catalog when the
+	// Host Catalog does not have the tag.
+	RemoveHostCatalogTags(ctx context.Context, in *RemoveHostCatalogTagsRequest, opts ...grpc.CallOption) (*RemoveHostCatalogTagsResponse, error)
 }
 
 type hostCatalogServiceClient struct {
@@ -99,6 +112,33 @@ func (c *hostCatalogServiceClient) DeleteHostCatalog(ctx context.Context, in *De
 	return out, nil
 }
 
+func (c *hostCatalogServiceClient) AddHostCatalogTags(ctx context.Context, in *AddHostCatalogTagsRequest, opts ...grpc.CallOption) (*AddHostCatalogTagsResponse, error) {
+	out := new(AddHostCatalogTagsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.HostCatalogService/AddHostCatalogTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostCatalogServiceClient) SetHostCatalogTags(ctx context.Context, in *SetHostCatalogTagsRequest, opts ...grpc.CallOption) (*SetHostCatalogTagsResponse, error) {
+	out := new(SetHostCatalogTagsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.HostCatalogService/SetHostCatalogTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostCatalogServiceClient) RemoveHostCatalogTags(ctx context.Context, in *RemoveHostCatalogTagsRequest, opts ...grpc.CallOption) (*RemoveHostCatalogTagsResponse, error) {
+	out := new(RemoveHostCatalogTagsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.HostCatalogService/RemoveHostCatalogTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HostCatalogServiceServer is the server API for HostCatalogService service.
 // All implementations must embed UnimplementedHostCatalogServiceServer
 // for forward compatibility
@@ -129,6 +169,19 @@ type HostCatalogServiceServer interface {
 	// sets from Boundary. If the provided Host Catalog IDs is malformed or not
 	// provided DeleteHostCatalog returns an error.
 	DeleteHostCatalog(context.Context, *DeleteHostCatalogRequest) (*DeleteHostCatalogResponse, error)
+	// AddHostCatalogTags adds tags to an existing Host Catalog. The provided request must
+	// include the Host Catalog ID and the version, which must match the current
+	// version of the Host Catalog. If missing, malformed, or referencing a
+	// non-existing resource, an error is returned.
+	AddHostCatalogTags(context.Context, *AddHostCatalogTagsRequest) (*AddHostCatalogTagsResponse, error)
+	// SetHostCatalogTags sets tags for an existing Host Catalog. Any existing tags are
+	// removed if they are not included in this request. If missing, malformed,
+	// or referencing a non-existing resource, an error is returned.
+	SetHostCatalogTags(context.Context, *SetHostCatalogTagsRequest) (*SetHostCatalogTagsResponse, error)
+	// RemoveHostCatalogTags removes tags from an existing Host Catalog. An error is
+	// returned if a tag is attempted to be removed from the Host Catalog when the
+	// Host Catalog does not have the tag.
+	RemoveHostCatalogTags(context.Context, *RemoveHostCatalogTagsRequest) (*RemoveHostCatalogTagsResponse, error)
 	mustEmbedUnimplementedHostCatalogServiceServer()
 }
 
@@ -151,6 +204,15 @@ func (UnimplementedHostCatalogServiceServer) UpdateHostCatalog(context.Context,
 func (UnimplementedHostCatalogServiceServer) DeleteHostCatalog(context.Context, *DeleteHostCatalogRequest) (*DeleteHostCatalogResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteHostCatalog not implemented")
 }
+func (UnimplementedHostCatalogServiceServer) AddHostCatalogTags(context.Context, *AddHostCatalogTagsRequest) (*AddHostCatalogTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddHostCatalogTags not implemented")
+}
+func (UnimplementedHostCatalogServiceServer) SetHostCatalogTags(context.Context, *SetHostCatalogTagsRequest) (*SetHostCatalogTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetHostCatalogTags not implemented")
+}
+func (UnimplementedHostCatalogServiceServer) RemoveHostCatalogTags(context.Context, *RemoveHostCatalogTagsRequest) (*RemoveHostCatalogTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveHostCatalogTags not implemented")
+}
 func (UnimplementedHostCatalogServiceServer) mustEmbedUnimplementedHostCatalogServiceServer() {}
 
 // UnsafeHostCatalogServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -254,6 +316,60 @@ func _HostCatalogService_DeleteHostCatalog_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HostCatalogService_AddHostCatalogTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddHostCatalogTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostCatalogServiceServer).AddHostCatalogTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.HostCatalogService/AddHostCatalogTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostCatalogServiceServer).AddHostCatalogTags(ctx, req.(*AddHostCatalogTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostCatalogService_SetHostCatalogTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetHostCatalogTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostCatalogServiceServer).SetHostCatalogTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.HostCatalogService/SetHostCatalogTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostCatalogServiceServer).SetHostCatalogTags(ctx, req.(*SetHostCatalogTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostCatalogService_RemoveHostCatalogTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveHostCatalogTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostCatalogServiceServer).RemoveHostCatalogTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.HostCatalogService/RemoveHostCatalogTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostCatalogServiceServer).RemoveHostCatalogTags(ctx, req.(*RemoveHostCatalogTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HostCatalogService_ServiceDesc is the grpc.ServiceDesc for HostCatalogService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -281,6 +397,18 @@ var HostCatalogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteHostCatalog",
 			Handler:    _HostCatalogService_DeleteHostCatalog_Handler,
 		},
+		{
+			MethodName: "AddHostCatalogTags",
+			Handler:    _HostCatalogService_AddHostCatalogTags_Handler,
+		},
+		{
+			MethodName: "SetHostCatalogTags",
+			Handler:    _HostCatalogService_SetHostCatalogTags_Handler,
+		},
+		{
+			MethodName: "RemoveHostCatalogTags",
+			Handler:    _HostCatalogService_RemoveHostCatalogTags_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/host_catalog_service.proto",
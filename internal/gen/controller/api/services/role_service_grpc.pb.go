@@ -79,6 +79,30 @@ type RoleServiceClient interface {
 	// grants will be removed. If missing, malformed, or references a non-existing
 	// resource, an error is returned.
 	RemoveRoleGrants(ctx context.Context, in *RemoveRoleGrantsRequest, opts ...grpc.CallOption) (*RemoveRoleGrantsResponse, error)
+	// AddRoleGrantScopes adds grant scopes to a Role. The provided request must
+	// include the Role id which the grant scopes will be added to. An error is
+	// returned if the provided id is malformed or references a non-existing
+	// resource.
+	AddRoleGrantScopes(ctx context.Context, in *AddRoleGrantScopesRequest, opts ...grpc.CallOption) (*AddRoleGrantScopesResponse, error)
+	// SetRoleGrantScopes sets the Role's grant scopes. Any existing grant scopes
+	// on the Role are deleted if they are not included in this request. The
+	// provided request must include the Role ID on which the grant scopes will be
+	// set. If missing, malformed, or referencing a non-existing resource, an
+	// error is returned.
+	SetRoleGrantScopes(ctx context.Context, in *SetRoleGrantScopesRequest, opts ...grpc.CallOption) (*SetRoleGrantScopesResponse, error)
+	// RemoveRoleGrantScopes removes the grant scopes from the specified Role.
+	// The provided request must include the Role IDs from which the grant scopes
+	// will be removed. If missing, malformed, or references a non-existing
+	// resource, an error is returned.
+	RemoveRoleGrantScopes(ctx context.Context, in *RemoveRoleGrantScopesRequest, opts ...grpc.CallOption) (*RemoveRoleGrantScopesResponse, error)
+	// ExplainPermission evaluates whether a principal is authorized to perform
+	// an action on a resource and reports the grants that decide it. Evaluating
+	// the permissions of a principal other than the requester requires the
+	// explain action on roles in the scope of the resource.
+	ExplainPermission(ctx context.Context, in *ExplainPermissionRequest, opts ...grpc.CallOption) (*ExplainPermissionResponse, error)
+	// ListRoleHistory returns the changes made to a Role, its grants, grant
+	// scopes and principals, oldest first, as recorded in the oplog.
+	ListRoleHistory(ctx context.Context, in *ListRoleHistoryRequest, opts ...grpc.CallOption) (*ListRoleHistoryResponse, error)
 }
 
 type roleServiceClient struct {
@@ -188,6 +212,51 @@ func (c *roleServiceClient) RemoveRoleGrants(ctx context.Context, in *RemoveRole
 	return out, nil
 }
 
+func (c *roleServiceClient) AddRoleGrantScopes(ctx context.Context, in *AddRoleGrantScopesRequest, opts ...grpc.CallOption) (*AddRoleGrantScopesResponse, error) {
+	out := new(AddRoleGrantScopesResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleService/AddRoleGrantScopes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleServiceClient) SetRoleGrantScopes(ctx context.Context, in *SetRoleGrantScopesRequest, opts ...grpc.CallOption) (*SetRoleGrantScopesResponse, error) {
+	out := new(SetRoleGrantScopesResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleService/SetRoleGrantScopes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleServiceClient) RemoveRoleGrantScopes(ctx context.Context, in *RemoveRoleGrantScopesRequest, opts ...grpc.CallOption) (*RemoveRoleGrantScopesResponse, error) {
+	out := new(RemoveRoleGrantScopesResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleService/RemoveRoleGrantScopes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleServiceClient) ExplainPermission(ctx context.Context, in *ExplainPermissionRequest, opts ...grpc.CallOption) (*ExplainPermissionResponse, error) {
+	out := new(ExplainPermissionResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleService/ExplainPermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleServiceClient) ListRoleHistory(ctx context.Context, in *ListRoleHistoryRequest, opts ...grpc.CallOption) (*ListRoleHistoryResponse, error) {
+	out := new(ListRoleHistoryResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleService/ListRoleHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RoleServiceServer is the server API for RoleService service.
 // All implementations must embed UnimplementedRoleServiceServer
 // for forward compatibility
@@ -253,6 +322,30 @@ type RoleServiceServer interface {
 	// grants will be removed. If missing, malformed, or references a non-existing
 	// resource, an error is returned.
 	RemoveRoleGrants(context.Context, *RemoveRoleGrantsRequest) (*RemoveRoleGrantsResponse, error)
+	// AddRoleGrantScopes adds grant scopes to a Role. The provided request must
+	// include the Role id which the grant scopes will be added to. An error is
+	// returned if the provided id is malformed or references a non-existing
+	// resource.
+	AddRoleGrantScopes(context.Context, *AddRoleGrantScopesRequest) (*AddRoleGrantScopesResponse, error)
+	// SetRoleGrantScopes sets the Role's grant scopes. Any existing grant scopes
+	// on the Role are deleted if they are not included in this request. The
+	// provided request must include the Role ID on which the grant scopes will be
+	// set. If missing, malformed, or referencing a non-existing resource, an
+	// error is returned.
+	SetRoleGrantScopes(context.Context, *SetRoleGrantScopesRequest) (*SetRoleGrantScopesResponse, error)
+	// RemoveRoleGrantScopes removes the grant scopes from the specified Role.
+	// The provided request must include the Role IDs from which the grant scopes
+	// will be removed. If missing, malformed, or references a non-existing
+	// resource, an error is returned.
+	RemoveRoleGrantScopes(context.Context, *RemoveRoleGrantScopesRequest) (*RemoveRoleGrantScopesResponse, error)
+	// ExplainPermission evaluates whether a principal is authorized to perform
+	// an action on a resource and reports the grants that decide it. Evaluating
+	// the permissions of a principal other than the requester requires the
+	// explain action on roles in the scope of the resource.
+	ExplainPermission(context.Context, *ExplainPermissionRequest) (*ExplainPermissionResponse, error)
+	// ListRoleHistory returns the changes made to a Role, its grants, grant
+	// scopes and principals, oldest first, as recorded in the oplog.
+	ListRoleHistory(context.Context, *ListRoleHistoryRequest) (*ListRoleHistoryResponse, error)
 	mustEmbedUnimplementedRoleServiceServer()
 }
 
@@ -293,6 +386,21 @@ func (UnimplementedRoleServiceServer) SetRoleGrants(context.Context, *SetRoleGra
 func (UnimplementedRoleServiceServer) RemoveRoleGrants(context.Context, *RemoveRoleGrantsRequest) (*RemoveRoleGrantsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveRoleGrants not implemented")
 }
+func (UnimplementedRoleServiceServer) AddRoleGrantScopes(context.Context, *AddRoleGrantScopesRequest) (*AddRoleGrantScopesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRoleGrantScopes not implemented")
+}
+func (UnimplementedRoleServiceServer) SetRoleGrantScopes(context.Context, *SetRoleGrantScopesRequest) (*SetRoleGrantScopesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRoleGrantScopes not implemented")
+}
+func (UnimplementedRoleServiceServer) RemoveRoleGrantScopes(context.Context, *RemoveRoleGrantScopesRequest) (*RemoveRoleGrantScopesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRoleGrantScopes not implemented")
+}
+func (UnimplementedRoleServiceServer) ExplainPermission(context.Context, *ExplainPermissionRequest) (*ExplainPermissionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainPermission not implemented")
+}
+func (UnimplementedRoleServiceServer) ListRoleHistory(context.Context, *ListRoleHistoryRequest) (*ListRoleHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoleHistory not implemented")
+}
 func (UnimplementedRoleServiceServer) mustEmbedUnimplementedRoleServiceServer() {}
 
 // UnsafeRoleServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -504,6 +612,96 @@ func _RoleService_RemoveRoleGrants_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RoleService_AddRoleGrantScopes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRoleGrantScopesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).AddRoleGrantScopes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleService/AddRoleGrantScopes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).AddRoleGrantScopes(ctx, req.(*AddRoleGrantScopesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleService_SetRoleGrantScopes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRoleGrantScopesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).SetRoleGrantScopes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleService/SetRoleGrantScopes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).SetRoleGrantScopes(ctx, req.(*SetRoleGrantScopesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleService_RemoveRoleGrantScopes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRoleGrantScopesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).RemoveRoleGrantScopes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleService/RemoveRoleGrantScopes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).RemoveRoleGrantScopes(ctx, req.(*RemoveRoleGrantScopesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleService_ExplainPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).ExplainPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleService/ExplainPermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).ExplainPermission(ctx, req.(*ExplainPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleService_ListRoleHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoleHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).ListRoleHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleService/ListRoleHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).ListRoleHistory(ctx, req.(*ListRoleHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RoleService_ServiceDesc is the grpc.ServiceDesc for RoleService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -555,6 +753,26 @@ var RoleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveRoleGrants",
 			Handler:    _RoleService_RemoveRoleGrants_Handler,
 		},
+		{
+			MethodName: "AddRoleGrantScopes",
+			Handler:    _RoleService_AddRoleGrantScopes_Handler,
+		},
+		{
+			MethodName: "SetRoleGrantScopes",
+			Handler:    _RoleService_SetRoleGrantScopes_Handler,
+		},
+		{
+			MethodName: "RemoveRoleGrantScopes",
+			Handler:    _RoleService_RemoveRoleGrantScopes_Handler,
+		},
+		{
+			MethodName: "ExplainPermission",
+			Handler:    _RoleService_ExplainPermission_Handler,
+		},
+		{
+			MethodName: "ListRoleHistory",
+			Handler:    _RoleService_ListRoleHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/role_service.proto",
@@ -44,6 +44,11 @@ type ScopeServiceClient interface {
 	// DeleteScope remotes a Scope and all child resources from Boundary. If the
 	// provided Scope IDs are malformed or not provided an error is returned.
 	DeleteScope(ctx context.Context, in *DeleteScopeRequest, opts ...grpc.CallOption) (*DeleteScopeResponse, error)
+	// SetScopeQuotas sets the resource quotas of an org or project Scope. Any
+	// existing quotas on the Scope are removed if they are not included in this
+	// request. Quotas are enforced when resources are created and do not affect
+	// resources that already exist.
+	SetScopeQuotas(ctx context.Context, in *SetScopeQuotasRequest, opts ...grpc.CallOption) (*SetScopeQuotasResponse, error)
 	// ListKeys lists all the keys found in the scope specified. If the scope
 	// is not found an error is returned.
 	ListKeys(ctx context.Context, in *ListKeysRequest, opts ...grpc.CallOption) (*ListKeysResponse, error)
@@ -57,6 +62,17 @@ type ScopeServiceClient interface {
 	// existing data, it will start an asynchronous process to complete this operation
 	// before destroying the key. Use ListKeyVersionDestructionJobs to monitor pending destruction jobs.
 	DestroyKeyVersion(ctx context.Context, in *DestroyKeyVersionRequest, opts ...grpc.CallOption) (*DestroyKeyVersionResponse, error)
+	// GetKeyRotationPolicy returns the key rotation policy of the scope,
+	// including the time of its next scheduled rotation and the history of the
+	// rotations of the keys in the scope. If the scope is empty, the global
+	// scope is used.
+	GetKeyRotationPolicy(ctx context.Context, in *GetKeyRotationPolicyRequest, opts ...grpc.CallOption) (*GetKeyRotationPolicyResponse, error)
+	// SetKeyRotationPolicy sets the interval at which the keys in the scope are
+	// rotated by the controller, and whether the previously active key
+	// versions are destroyed after each scheduled rotation. The first scheduled
+	// rotation happens one interval after the policy is set. An interval of
+	// zero removes the policy. If the scope is empty, the global scope is used.
+	SetKeyRotationPolicy(ctx context.Context, in *SetKeyRotationPolicyRequest, opts ...grpc.CallOption) (*SetKeyRotationPolicyResponse, error)
 }
 
 type scopeServiceClient struct {
@@ -112,6 +128,15 @@ func (c *scopeServiceClient) DeleteScope(ctx context.Context, in *DeleteScopeReq
 	return out, nil
 }
 
+func (c *scopeServiceClient) SetScopeQuotas(ctx context.Context, in *SetScopeQuotasRequest, opts ...grpc.CallOption) (*SetScopeQuotasResponse, error) {
+	out := new(SetScopeQuotasResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/SetScopeQuotas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *scopeServiceClient) ListKeys(ctx context.Context, in *ListKeysRequest, opts ...grpc.CallOption) (*ListKeysResponse, error) {
 	out := new(ListKeysResponse)
 	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/ListKeys", in, out, opts...)
@@ -148,6 +173,24 @@ func (c *scopeServiceClient) DestroyKeyVersion(ctx context.Context, in *DestroyK
 	return out, nil
 }
 
+func (c *scopeServiceClient) GetKeyRotationPolicy(ctx context.Context, in *GetKeyRotationPolicyRequest, opts ...grpc.CallOption) (*GetKeyRotationPolicyResponse, error) {
+	out := new(GetKeyRotationPolicyResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/GetKeyRotationPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) SetKeyRotationPolicy(ctx context.Context, in *SetKeyRotationPolicyRequest, opts ...grpc.CallOption) (*SetKeyRotationPolicyResponse, error) {
+	out := new(SetKeyRotationPolicyResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/SetKeyRotationPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ScopeServiceServer is the server API for ScopeService service.
 // All implementations must embed UnimplementedScopeServiceServer
 // for forward compatibility
@@ -178,6 +221,11 @@ type ScopeServiceServer interface {
 	// DeleteScope remotes a Scope and all child resources from Boundary. If the
 	// provided Scope IDs are malformed or not provided an error is returned.
 	DeleteScope(context.Context, *DeleteScopeRequest) (*DeleteScopeResponse, error)
+	// SetScopeQuotas sets the resource quotas of an org or project Scope. Any
+	// existing quotas on the Scope are removed if they are not included in this
+	// request. Quotas are enforced when resources are created and do not affect
+	// resources that already exist.
+	SetScopeQuotas(context.Context, *SetScopeQuotasRequest) (*SetScopeQuotasResponse, error)
 	// ListKeys lists all the keys found in the scope specified. If the scope
 	// is not found an error is returned.
 	ListKeys(context.Context, *ListKeysRequest) (*ListKeysResponse, error)
@@ -191,6 +239,17 @@ type ScopeServiceServer interface {
 	// existing data, it will start an asynchronous process to complete this operation
 	// before destroying the key. Use ListKeyVersionDestructionJobs to monitor pending destruction jobs.
 	DestroyKeyVersion(context.Context, *DestroyKeyVersionRequest) (*DestroyKeyVersionResponse, error)
+	// GetKeyRotationPolicy returns the key rotation policy of the scope,
+	// including the time of its next scheduled rotation and the history of the
+	// rotations of the keys in the scope. If the scope is empty, the global
+	// scope is used.
+	GetKeyRotationPolicy(context.Context, *GetKeyRotationPolicyRequest) (*GetKeyRotationPolicyResponse, error)
+	// SetKeyRotationPolicy sets the interval at which the keys in the scope are
+	// rotated by the controller, and whether the previously active key
+	// versions are destroyed after each scheduled rotation. The first scheduled
+	// rotation happens one interval after the policy is set. An interval of
+	// zero removes the policy. If the scope is empty, the global scope is used.
+	SetKeyRotationPolicy(context.Context, *SetKeyRotationPolicyRequest) (*SetKeyRotationPolicyResponse, error)
 	mustEmbedUnimplementedScopeServiceServer()
 }
 
@@ -213,6 +272,9 @@ func (UnimplementedScopeServiceServer) UpdateScope(context.Context, *UpdateScope
 func (UnimplementedScopeServiceServer) DeleteScope(context.Context, *DeleteScopeRequest) (*DeleteScopeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteScope not implemented")
 }
+func (UnimplementedScopeServiceServer) SetScopeQuotas(context.Context, *SetScopeQuotasRequest) (*SetScopeQuotasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetScopeQuotas not implemented")
+}
 func (UnimplementedScopeServiceServer) ListKeys(context.Context, *ListKeysRequest) (*ListKeysResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListKeys not implemented")
 }
@@ -225,6 +287,12 @@ func (UnimplementedScopeServiceServer) ListKeyVersionDestructionJobs(context.Con
 func (UnimplementedScopeServiceServer) DestroyKeyVersion(context.Context, *DestroyKeyVersionRequest) (*DestroyKeyVersionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyKeyVersion not implemented")
 }
+func (UnimplementedScopeServiceServer) GetKeyRotationPolicy(context.Context, *GetKeyRotationPolicyRequest) (*GetKeyRotationPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeyRotationPolicy not implemented")
+}
+func (UnimplementedScopeServiceServer) SetKeyRotationPolicy(context.Context, *SetKeyRotationPolicyRequest) (*SetKeyRotationPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetKeyRotationPolicy not implemented")
+}
 func (UnimplementedScopeServiceServer) mustEmbedUnimplementedScopeServiceServer() {}
 
 // UnsafeScopeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -328,6 +396,24 @@ func _ScopeService_DeleteScope_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ScopeService_SetScopeQuotas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetScopeQuotasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).SetScopeQuotas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/SetScopeQuotas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).SetScopeQuotas(ctx, req.(*SetScopeQuotasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ScopeService_ListKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListKeysRequest)
 	if err := dec(in); err != nil {
@@ -400,6 +486,42 @@ func _ScopeService_DestroyKeyVersion_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ScopeService_GetKeyRotationPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyRotationPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).GetKeyRotationPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/GetKeyRotationPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).GetKeyRotationPolicy(ctx, req.(*GetKeyRotationPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_SetKeyRotationPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetKeyRotationPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).SetKeyRotationPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/SetKeyRotationPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).SetKeyRotationPolicy(ctx, req.(*SetKeyRotationPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ScopeService_ServiceDesc is the grpc.ServiceDesc for ScopeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -427,6 +549,10 @@ var ScopeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteScope",
 			Handler:    _ScopeService_DeleteScope_Handler,
 		},
+		{
+			MethodName: "SetScopeQuotas",
+			Handler:    _ScopeService_SetScopeQuotas_Handler,
+		},
 		{
 			MethodName: "ListKeys",
 			Handler:    _ScopeService_ListKeys_Handler,
@@ -443,6 +569,14 @@ var ScopeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DestroyKeyVersion",
 			Handler:    _ScopeService_DestroyKeyVersion_Handler,
 		},
+		{
+			MethodName: "GetKeyRotationPolicy",
+			Handler:    _ScopeService_GetKeyRotationPolicy_Handler,
+		},
+		{
+			MethodName: "SetKeyRotationPolicy",
+			Handler:    _ScopeService_SetKeyRotationPolicy_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/scope_service.proto",
@@ -97,6 +97,19 @@ type TargetServiceClient interface {
 	// Credential Source is attempted to be removed from the Target when the
 	// Target does not have the Credential Source.
 	RemoveTargetCredentialSources(ctx context.Context, in *RemoveTargetCredentialSourcesRequest, opts ...grpc.CallOption) (*RemoveTargetCredentialSourcesResponse, error)
+	// AddTargetTags adds tags to an existing Target. The provided request must
+	// include the Target ID and the version, which must match the current
+	// version of the Target. If missing, malformed, or referencing a
+	// non-existing resource, an error is returned.
+	AddTargetTags(ctx context.Context, in *AddTargetTagsRequest, opts ...grpc.CallOption) (*AddTargetTagsResponse, error)
+	// SetTargetTags sets tags for an existing Target. Any existing tags are
+	// removed if they are not included in this request. If missing, malformed,
+	// or referencing a non-existing resource, an error is returned.
+	SetTargetTags(ctx context.Context, in *SetTargetTagsRequest, opts ...grpc.CallOption) (*SetTargetTagsResponse, error)
+	// RemoveTargetTags removes tags from an existing Target. An error is
+	// returned if a tag is attempted to be removed from the Target when the
+	// Target does not have the tag.
+	RemoveTargetTags(ctx context.Context, in *RemoveTargetTagsRequest, opts ...grpc.CallOption) (*RemoveTargetTagsResponse, error)
 }
 
 type targetServiceClient struct {
@@ -215,6 +228,33 @@ func (c *targetServiceClient) RemoveTargetCredentialSources(ctx context.Context,
 	return out, nil
 }
 
+func (c *targetServiceClient) AddTargetTags(ctx context.Context, in *AddTargetTagsRequest, opts ...grpc.CallOption) (*AddTargetTagsResponse, error) {
+	out := new(AddTargetTagsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.TargetService/AddTargetTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *targetServiceClient) SetTargetTags(ctx context.Context, in *SetTargetTagsRequest, opts ...grpc.CallOption) (*SetTargetTagsResponse, error) {
+	out := new(SetTargetTagsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.TargetService/SetTargetTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *targetServiceClient) RemoveTargetTags(ctx context.Context, in *RemoveTargetTagsRequest, opts ...grpc.CallOption) (*RemoveTargetTagsResponse, error) {
+	out := new(RemoveTargetTagsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.TargetService/RemoveTargetTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TargetServiceServer is the server API for TargetService service.
 // All implementations must embed UnimplementedTargetServiceServer
 // for forward compatibility
@@ -298,6 +338,19 @@ type TargetServiceServer interface {
 	// Credential Source is attempted to be removed from the Target when the
 	// Target does not have the Credential Source.
 	RemoveTargetCredentialSources(context.Context, *RemoveTargetCredentialSourcesRequest) (*RemoveTargetCredentialSourcesResponse, error)
+	// AddTargetTags adds tags to an existing Target. The provided request must
+	// include the Target ID and the version, which must match the current
+	// version of the Target. If missing, malformed, or referencing a
+	// non-existing resource, an error is returned.
+	AddTargetTags(context.Context, *AddTargetTagsRequest) (*AddTargetTagsResponse, error)
+	// SetTargetTags sets tags for an existing Target. Any existing tags are
+	// removed if they are not included in this request. If missing, malformed,
+	// or referencing a non-existing resource, an error is returned.
+	SetTargetTags(context.Context, *SetTargetTagsRequest) (*SetTargetTagsResponse, error)
+	// RemoveTargetTags removes tags from an existing Target. An error is
+	// returned if a tag is attempted to be removed from the Target when the
+	// Target does not have the tag.
+	RemoveTargetTags(context.Context, *RemoveTargetTagsRequest) (*RemoveTargetTagsResponse, error)
 	mustEmbedUnimplementedTargetServiceServer()
 }
 
@@ -341,6 +394,15 @@ func (UnimplementedTargetServiceServer) SetTargetCredentialSources(context.Conte
 func (UnimplementedTargetServiceServer) RemoveTargetCredentialSources(context.Context, *RemoveTargetCredentialSourcesRequest) (*RemoveTargetCredentialSourcesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveTargetCredentialSources not implemented")
 }
+func (UnimplementedTargetServiceServer) AddTargetTags(context.Context, *AddTargetTagsRequest) (*AddTargetTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTargetTags not implemented")
+}
+func (UnimplementedTargetServiceServer) SetTargetTags(context.Context, *SetTargetTagsRequest) (*SetTargetTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTargetTags not implemented")
+}
+func (UnimplementedTargetServiceServer) RemoveTargetTags(context.Context, *RemoveTargetTagsRequest) (*RemoveTargetTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTargetTags not implemented")
+}
 func (UnimplementedTargetServiceServer) mustEmbedUnimplementedTargetServiceServer() {}
 
 // UnsafeTargetServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -570,6 +632,60 @@ func _TargetService_RemoveTargetCredentialSources_Handler(srv interface{}, ctx c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TargetService_AddTargetTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTargetTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TargetServiceServer).AddTargetTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.TargetService/AddTargetTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TargetServiceServer).AddTargetTags(ctx, req.(*AddTargetTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TargetService_SetTargetTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTargetTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TargetServiceServer).SetTargetTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.TargetService/SetTargetTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TargetServiceServer).SetTargetTags(ctx, req.(*SetTargetTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TargetService_RemoveTargetTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTargetTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TargetServiceServer).RemoveTargetTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.TargetService/RemoveTargetTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TargetServiceServer).RemoveTargetTags(ctx, req.(*RemoveTargetTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TargetService_ServiceDesc is the grpc.ServiceDesc for TargetService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -625,6 +741,18 @@ var TargetService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveTargetCredentialSources",
 			Handler:    _TargetService_RemoveTargetCredentialSources_Handler,
 		},
+		{
+			MethodName: "AddTargetTags",
+			Handler:    _TargetService_AddTargetTags_Handler,
+		},
+		{
+			MethodName: "SetTargetTags",
+			Handler:    _TargetService_SetTargetTags_Handler,
+		},
+		{
+			MethodName: "RemoveTargetTags",
+			Handler:    _TargetService_RemoveTargetTags_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/target_service.proto",
@@ -66,6 +66,15 @@ type UserServiceClient interface {
 	// will be removed from. If the provided Account ids is not associated with the
 	// provided User, an error is returned.
 	RemoveUserAccounts(ctx context.Context, in *RemoveUserAccountsRequest, opts ...grpc.CallOption) (*RemoveUserAccountsResponse, error)
+	// ListUserHistory returns the changes made to a User, its Accounts, its
+	// Group memberships and its assignment to Roles, oldest first, as recorded
+	// in the oplog.
+	ListUserHistory(ctx context.Context, in *ListUserHistoryRequest, opts ...grpc.CallOption) (*ListUserHistoryResponse, error)
+	// ListUserEffectiveGrants returns the grants that apply to a User. If a
+	// time is provided, the grants that applied at that time are reconstructed
+	// from the oplog; grants received through managed groups are only included
+	// for the current time.
+	ListUserEffectiveGrants(ctx context.Context, in *ListUserEffectiveGrantsRequest, opts ...grpc.CallOption) (*ListUserEffectiveGrantsResponse, error)
 }
 
 type userServiceClient struct {
@@ -148,6 +157,24 @@ func (c *userServiceClient) RemoveUserAccounts(ctx context.Context, in *RemoveUs
 	return out, nil
 }
 
+func (c *userServiceClient) ListUserHistory(ctx context.Context, in *ListUserHistoryRequest, opts ...grpc.CallOption) (*ListUserHistoryResponse, error) {
+	out := new(ListUserHistoryResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.UserService/ListUserHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListUserEffectiveGrants(ctx context.Context, in *ListUserEffectiveGrantsRequest, opts ...grpc.CallOption) (*ListUserEffectiveGrantsResponse, error) {
+	out := new(ListUserEffectiveGrantsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.UserService/ListUserEffectiveGrants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility
@@ -200,6 +227,15 @@ type UserServiceServer interface {
 	// will be removed from. If the provided Account ids is not associated with the
 	// provided User, an error is returned.
 	RemoveUserAccounts(context.Context, *RemoveUserAccountsRequest) (*RemoveUserAccountsResponse, error)
+	// ListUserHistory returns the changes made to a User, its Accounts, its
+	// Group memberships and its assignment to Roles, oldest first, as recorded
+	// in the oplog.
+	ListUserHistory(context.Context, *ListUserHistoryRequest) (*ListUserHistoryResponse, error)
+	// ListUserEffectiveGrants returns the grants that apply to a User. If a
+	// time is provided, the grants that applied at that time are reconstructed
+	// from the oplog; grants received through managed groups are only included
+	// for the current time.
+	ListUserEffectiveGrants(context.Context, *ListUserEffectiveGrantsRequest) (*ListUserEffectiveGrantsResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -231,6 +267,12 @@ func (UnimplementedUserServiceServer) SetUserAccounts(context.Context, *SetUserA
 func (UnimplementedUserServiceServer) RemoveUserAccounts(context.Context, *RemoveUserAccountsRequest) (*RemoveUserAccountsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveUserAccounts not implemented")
 }
+func (UnimplementedUserServiceServer) ListUserHistory(context.Context, *ListUserHistoryRequest) (*ListUserHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserHistory not implemented")
+}
+func (UnimplementedUserServiceServer) ListUserEffectiveGrants(context.Context, *ListUserEffectiveGrantsRequest) (*ListUserEffectiveGrantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserEffectiveGrants not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
 // UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -388,6 +430,42 @@ func _UserService_RemoveUserAccounts_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ListUserHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListUserHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.UserService/ListUserHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListUserHistory(ctx, req.(*ListUserHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListUserEffectiveGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserEffectiveGrantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListUserEffectiveGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.UserService/ListUserEffectiveGrants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListUserEffectiveGrants(ctx, req.(*ListUserEffectiveGrantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -427,6 +505,14 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveUserAccounts",
 			Handler:    _UserService_RemoveUserAccounts_Handler,
 		},
+		{
+			MethodName: "ListUserHistory",
+			Handler:    _UserService_ListUserHistory_Handler,
+		},
+		{
+			MethodName: "ListUserEffectiveGrants",
+			Handler:    _UserService_ListUserEffectiveGrants_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/user_service.proto",
@@ -16,6 +16,7 @@ import (
 // found, it will return nil, nil. All options are ignored.
 func (r *Repository) LookupHost(ctx context.Context, publicId string, opt ...Option) (*Host, *hostplugin.Plugin, error) {
 	const op = "plugin.(Repository).LookupHost"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
@@ -39,6 +40,7 @@ func (r *Repository) LookupHost(ctx context.Context, publicId string, opt ...Opt
 // WithLimit is the only option supported.
 func (r *Repository) ListHostsByCatalogId(ctx context.Context, catalogId string, opt ...Option) ([]*Host, *hostplugin.Plugin, error) {
 	const op = "plugin.(Repository).ListHostsByCatalogId"
+	ctx = db.NewOpContext(ctx, op)
 	if catalogId == "" {
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "no catalog id")
 	}
@@ -76,6 +78,7 @@ func (r *Repository) ListHostsByCatalogId(ctx context.Context, catalogId string,
 // WithLimit is the only option supported.
 func (r *Repository) ListHostsBySetIds(ctx context.Context, setIds []string, opt ...Option) ([]*Host, error) {
 	const op = "plugin.(Repository).ListHostsBySetIds"
+	ctx = db.NewOpContext(ctx, op)
 	if len(setIds) == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no set ids")
 	}
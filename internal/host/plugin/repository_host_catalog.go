@@ -11,12 +11,14 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/libs/patchstruct"
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/oplog"
 	hostplugin "github.com/hashicorp/boundary/internal/plugin/host"
 	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/util"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hostcatalogs"
 	pbset "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hostsets"
@@ -73,6 +75,7 @@ func normalizeCatalogAttributes(ctx context.Context, plgClient plgpb.HostPluginS
 // Both c.CreateTime and c.UpdateTime are ignored.
 func (r *Repository) CreateCatalog(ctx context.Context, c *HostCatalog, _ ...Option) (*HostCatalog, *hostplugin.Plugin, error) {
 	const op = "plugin.(Repository).CreateCatalog"
+	ctx = db.NewOpContext(ctx, op)
 	if c == nil {
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "nil HostCatalog")
 	}
@@ -147,7 +150,10 @@ func (r *Repository) CreateCatalog(ctx context.Context, c *HostCatalog, _ ...Opt
 		ctx,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
-		func(_ db.Reader, w db.Writer) error {
+		func(reader db.Reader, w db.Writer) error {
+			if err := iam.CheckQuota(ctx, reader, c.ProjectId, resource.HostCatalog); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 			msgs := make([]*oplog.Message, 0, 3)
 			ticket, err := w.GetTicket(ctx, c)
 			if err != nil {
@@ -238,6 +244,7 @@ func (r *Repository) CreateCatalog(ctx context.Context, c *HostCatalog, _ ...Opt
 // record in the database is aborted if this call fails.
 func (r *Repository) UpdateCatalog(ctx context.Context, c *HostCatalog, version uint32, fieldMask []string, _ ...Option) (*HostCatalog, *hostplugin.Plugin, int, error) {
 	const op = "plugin.(Repository).UpdateCatalog"
+	ctx = db.NewOpContext(ctx, op)
 	if c == nil {
 		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil HostCatalog")
 	}
@@ -592,6 +599,7 @@ func (r *Repository) LookupCatalog(ctx context.Context, id string, _ ...Option)
 // ListCatalogs returns a slice of HostCatalogs for the project IDs. WithLimit is the only option supported.
 func (r *Repository) ListCatalogs(ctx context.Context, projectIds []string, opt ...host.Option) ([]*HostCatalog, []*hostplugin.Plugin, error) {
 	const op = "plugin.(Repository).ListCatalogs"
+	ctx = db.NewOpContext(ctx, op)
 	if len(projectIds) == 0 {
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "no project id")
 	}
@@ -604,8 +612,11 @@ func (r *Repository) ListCatalogs(ctx context.Context, projectIds []string, opt
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.WithLimit
 	}
+	// The catalogs and their plugins are read from the same database, so a
+	// plugin isn't missed by reading it from a replica further behind.
+	reader := db.NewReplicaReader(r.reader)
 	var hostCatalogs []*HostCatalog
-	if err := r.reader.SearchWhere(ctx, &hostCatalogs, "project_id in (?)", []any{projectIds}, db.WithLimit(limit)); err != nil {
+	if err := reader.SearchWhere(ctx, &hostCatalogs, "project_id in (?)", []any{projectIds}, db.WithLimit(limit), db.WithReplicaRead(true)); err != nil {
 		return nil, nil, errors.Wrap(ctx, err, op)
 	}
 	plgIds := make([]string, 0, len(hostCatalogs))
@@ -613,7 +624,7 @@ func (r *Repository) ListCatalogs(ctx context.Context, projectIds []string, opt
 		plgIds = append(plgIds, c.PluginId)
 	}
 	var plgs []*hostplugin.Plugin
-	if err := r.reader.SearchWhere(ctx, &plgs, "public_id in (?)", []any{plgIds}); err != nil {
+	if err := reader.SearchWhere(ctx, &plgs, "public_id in (?)", []any{plgIds}, db.WithReplicaRead(true)); err != nil {
 		return nil, nil, errors.Wrap(ctx, err, op)
 	}
 	return hostCatalogs, plgs, nil
@@ -623,6 +634,7 @@ func (r *Repository) ListCatalogs(ctx context.Context, projectIds []string, opt
 // returning a count of the number of records deleted. All options are ignored.
 func (r *Repository) DeleteCatalog(ctx context.Context, id string, _ ...Option) (int, error) {
 	const op = "plugin.(Repository).DeleteCatalog"
+	ctx = db.NewOpContext(ctx, op)
 	if id == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
@@ -708,6 +720,7 @@ func (r *Repository) DeleteCatalog(ctx context.Context, id string, _ ...Option)
 // is an problem getting it from the database an error is returned instead.
 func (r *Repository) getCatalog(ctx context.Context, id string) (*HostCatalog, *plgpb.HostCatalogPersisted, error) {
 	const op = "plugin.(Repository).getCatalog"
+	ctx = db.NewOpContext(ctx, op)
 	ca := &catalogAgg{}
 	ca.PublicId = id
 	if err := r.reader.LookupByPublicId(ctx, ca); err != nil {
@@ -727,6 +740,7 @@ func (r *Repository) getCatalog(ctx context.Context, id string) (*HostCatalog, *
 
 func (r *Repository) getPlugin(ctx context.Context, plgId string) (*hostplugin.Plugin, error) {
 	const op = "plugin.(Repository).getPlugin"
+	ctx = db.NewOpContext(ctx, op)
 	if plgId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no plugin id")
 	}
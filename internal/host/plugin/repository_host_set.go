@@ -70,6 +70,7 @@ func normalizeSetAttributes(ctx context.Context, plgClient plgpb.HostPluginServi
 // unique within s.CatalogId.
 func (r *Repository) CreateSet(ctx context.Context, projectId string, s *HostSet, _ ...Option) (*HostSet, *hostplugin.Plugin, error) {
 	const op = "plugin.(Repository).CreateSet"
+	ctx = db.NewOpContext(ctx, op)
 	if s == nil {
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "nil HostSet")
 	}
@@ -241,6 +242,7 @@ func (r *Repository) CreateSet(ctx context.Context, projectId string, s *HostSet
 // fails.
 func (r *Repository) UpdateSet(ctx context.Context, projectId string, s *HostSet, version uint32, fieldMask []string, opt ...Option) (*HostSet, []*Host, *hostplugin.Plugin, int, error) {
 	const op = "plugin.(Repository).UpdateSet"
+	ctx = db.NewOpContext(ctx, op)
 	if s == nil {
 		return nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil HostSet")
 	}
@@ -613,6 +615,7 @@ func (r *Repository) ListSets(ctx context.Context, catalogId string, opt ...host
 // ignored.
 func (r *Repository) DeleteSet(ctx context.Context, projectId string, publicId string, _ ...Option) (int, error) {
 	const op = "plugin.(Repository).DeleteSet"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
@@ -685,6 +688,7 @@ func (r *Repository) DeleteSet(ctx context.Context, projectId string, publicId s
 
 func (r *Repository) getSets(ctx context.Context, publicId string, catalogId string, opt ...host.Option) ([]*HostSet, *hostplugin.Plugin, error) {
 	const op = "plugin.(Repository).getSets"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" && catalogId == "" {
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing search criteria: both host set id and catalog id are empty")
 	}
@@ -791,6 +795,7 @@ func toPluginSet(ctx context.Context, in *HostSet) (*pb.HostSet, error) {
 // it is not included in the resulting slice of endpoints.
 func (r *Repository) Endpoints(ctx context.Context, setIds []string) ([]*host.Endpoint, error) {
 	const op = "plugin.(Repository).Endpoints"
+	ctx = db.NewOpContext(ctx, op)
 	if len(setIds) == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no set ids")
 	}
@@ -27,6 +27,7 @@ import (
 // unique within h.CatalogId.
 func (r *Repository) CreateHost(ctx context.Context, projectId string, h *Host, opt ...Option) (*Host, error) {
 	const op = "static.(Repository).CreateHost"
+	ctx = db.NewOpContext(ctx, op)
 	if h == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil Host")
 	}
@@ -115,6 +116,7 @@ func (r *Repository) CreateHost(ctx context.Context, projectId string, h *Host,
 // in h is the zero value and it is included in fieldMaskPaths.
 func (r *Repository) UpdateHost(ctx context.Context, projectId string, h *Host, version uint32, fieldMaskPaths []string, opt ...Option) (*Host, int, error) {
 	const op = "static.(Repository).UpdateHost"
+	ctx = db.NewOpContext(ctx, op)
 	if h == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil Host")
 	}
@@ -211,6 +213,7 @@ func (r *Repository) UpdateHost(ctx context.Context, projectId string, h *Host,
 // found, it will return nil, nil. All options are ignored.
 func (r *Repository) LookupHost(ctx context.Context, publicId string, opt ...Option) (*Host, error) {
 	const op = "static.(Repository).LookupHost"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
@@ -230,6 +233,7 @@ func (r *Repository) LookupHost(ctx context.Context, publicId string, opt ...Opt
 // WithLimit is the only option supported.
 func (r *Repository) ListHosts(ctx context.Context, catalogId string, opt ...Option) ([]*Host, error) {
 	const op = "static.(Repository).ListHosts"
+	ctx = db.NewOpContext(ctx, op)
 	if catalogId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no catalog id")
 	}
@@ -257,6 +261,7 @@ func (r *Repository) ListHosts(ctx context.Context, catalogId string, opt ...Opt
 // ignored.
 func (r *Repository) DeleteHost(ctx context.Context, projectId string, publicId string, opt ...Option) (int, error) {
 	const op = "static.(Repository).DeleteHost"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
@@ -11,8 +11,10 @@ import (
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/resource"
 )
 
 // CreateCatalog inserts c into the repository and returns a new
@@ -26,6 +28,7 @@ import (
 // Both c.CreateTime and c.UpdateTime are ignored.
 func (r *Repository) CreateCatalog(ctx context.Context, c *HostCatalog, opt ...Option) (*HostCatalog, error) {
 	const op = "static.(Repository).CreateCatalog"
+	ctx = db.NewOpContext(ctx, op)
 	if c == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil HostCatalog")
 	}
@@ -71,7 +74,10 @@ func (r *Repository) CreateCatalog(ctx context.Context, c *HostCatalog, opt ...O
 		ctx,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
-		func(_ db.Reader, w db.Writer) error {
+		func(reader db.Reader, w db.Writer) error {
+			if err := iam.CheckQuota(ctx, reader, c.ProjectId, resource.HostCatalog); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 			newHostCatalog = c.clone()
 			err := w.Create(
 				ctx,
@@ -107,6 +113,7 @@ func (r *Repository) CreateCatalog(ctx context.Context, c *HostCatalog, opt ...O
 // in c is the zero value and it is included in fieldMask.
 func (r *Repository) UpdateCatalog(ctx context.Context, c *HostCatalog, version uint32, fieldMask []string, opt ...Option) (*HostCatalog, int, error) {
 	const op = "static.(Repository).UpdateCatalog"
+	ctx = db.NewOpContext(ctx, op)
 	if c == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil HostCatalog")
 	}
@@ -190,6 +197,7 @@ func (r *Repository) UpdateCatalog(ctx context.Context, c *HostCatalog, version
 // HostCatalog is found for id.
 func (r *Repository) LookupCatalog(ctx context.Context, id string, opt ...Option) (*HostCatalog, error) {
 	const op = "static.(Repository).LookupCatalog"
+	ctx = db.NewOpContext(ctx, op)
 	if id == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
@@ -207,6 +215,7 @@ func (r *Repository) LookupCatalog(ctx context.Context, id string, opt ...Option
 // ListCatalogs returns a slice of HostCatalogs for the project IDs. WithLimit is the only option supported.
 func (r *Repository) ListCatalogs(ctx context.Context, projectIds []string, opt ...Option) ([]*HostCatalog, error) {
 	const op = "static.(Repository).ListCatalogs"
+	ctx = db.NewOpContext(ctx, op)
 	if len(projectIds) == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no project id")
 	}
@@ -217,7 +226,7 @@ func (r *Repository) ListCatalogs(ctx context.Context, projectIds []string, opt
 		limit = opts.withLimit
 	}
 	var hostCatalogs []*HostCatalog
-	err := r.reader.SearchWhere(ctx, &hostCatalogs, "project_id in (?)", []any{projectIds}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &hostCatalogs, "project_id in (?)", []any{projectIds}, db.WithLimit(limit), db.WithReplicaRead(true))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -228,6 +237,7 @@ func (r *Repository) ListCatalogs(ctx context.Context, projectIds []string, opt
 // number of records deleted.
 func (r *Repository) DeleteCatalog(ctx context.Context, id string, opt ...Option) (int, error) {
 	const op = "static.(Repository).DeleteCatalog"
+	ctx = db.NewOpContext(ctx, op)
 	if id == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
@@ -26,6 +26,7 @@ import (
 // unique within s.CatalogId.
 func (r *Repository) CreateSet(ctx context.Context, projectId string, s *HostSet, opt ...Option) (*HostSet, error) {
 	const op = "static.(Repository).CreateSet"
+	ctx = db.NewOpContext(ctx, op)
 	if s == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil HostSet")
 	}
@@ -104,6 +105,7 @@ func (r *Repository) CreateSet(ctx context.Context, projectId string, s *HostSet
 // All other options are ignored.
 func (r *Repository) UpdateSet(ctx context.Context, projectId string, s *HostSet, version uint32, fieldMaskPaths []string, opt ...Option) (*HostSet, []*Host, int, error) {
 	const op = "static.(Repository).UpdateSet"
+	ctx = db.NewOpContext(ctx, op)
 	if s == nil {
 		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil HostSet")
 	}
@@ -221,6 +223,7 @@ func (r *Repository) LookupSet(ctx context.Context, publicId string, opt ...Opti
 
 func (r *Repository) lookupSet(ctx context.Context, publicId string, opt ...Option) (*HostSet, []*Host, error) {
 	const op = "static.(Repository).lookupSet"
+	ctx = db.NewOpContext(ctx, op)
 	opts := getOpts(opt...)
 	limit := r.defaultLimit
 	if opts.withLimit != 0 {
@@ -252,6 +255,7 @@ func (r *Repository) lookupSet(ctx context.Context, publicId string, opt ...Opti
 // only option supported.
 func (r *Repository) ListSets(ctx context.Context, catalogId string, opt ...Option) ([]*HostSet, error) {
 	const op = "static.(Repository).ListSets"
+	ctx = db.NewOpContext(ctx, op)
 	if catalogId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no catalog id")
 	}
@@ -274,6 +278,7 @@ func (r *Repository) ListSets(ctx context.Context, catalogId string, opt ...Opti
 // ignored.
 func (r *Repository) DeleteSet(ctx context.Context, projectId string, publicId string, opt ...Option) (int, error) {
 	const op = "static.(Repository).DeleteSet"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
@@ -22,6 +22,7 @@ import (
 // setId in the repository.
 func (r *Repository) AddSetMembers(ctx context.Context, projectId string, setId string, version uint32, hostIds []string, opt ...Option) ([]*Host, error) {
 	const op = "static.(Repository).AddSetMembers"
+	ctx = db.NewOpContext(ctx, op)
 	if projectId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no project id")
 	}
@@ -166,6 +167,7 @@ func getHosts(ctx context.Context, reader db.Reader, setId string, limit int) ([
 // the current version of the setId in the repository.
 func (r *Repository) DeleteSetMembers(ctx context.Context, projectId string, setId string, version uint32, hostIds []string, opt ...Option) (int, error) {
 	const op = "static.(Repository).DeleteSetMembers"
+	ctx = db.NewOpContext(ctx, op)
 	if projectId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no project id")
 	}
@@ -234,6 +236,7 @@ func deleteMembers(ctx context.Context, w db.Writer, members []any) ([]*oplog.Me
 // in the repository. If hostIds is empty, all hosts will be removed setId.
 func (r *Repository) SetSetMembers(ctx context.Context, projectId string, setId string, version uint32, hostIds []string, opt ...Option) ([]*Host, int, error) {
 	const op = "static.(Repository).SetSetMembers"
+	ctx = db.NewOpContext(ctx, op)
 	if projectId == "" {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no project id")
 	}
@@ -333,6 +336,7 @@ type change struct {
 
 func (r *Repository) changes(ctx context.Context, setId string, hostIds []string) ([]*change, error) {
 	const op = "static.(Repository).changes"
+	ctx = db.NewOpContext(ctx, op)
 	var inClauseSpots []string
 	// starts at 2 because there is already a @1 in the query
 	for i := 2; i < len(hostIds)+2; i++ {
@@ -41,6 +41,22 @@ func newGroupId() (string, error) {
 	return id, nil
 }
 
+func newServiceAccountId() (string, error) {
+	id, err := db.NewPublicId(globals.ServiceAccountPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, "iam.newServiceAccountId")
+	}
+	return id, nil
+}
+
+func newServiceAccountApiKeyId() (string, error) {
+	id, err := db.NewPublicId(globals.ServiceAccountApiKeyPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, "iam.newServiceAccountApiKeyId")
+	}
+	return id, nil
+}
+
 func newScopeId(scopeType scope.Type) (string, error) {
 	const op = "iam.newScopeId"
 	if scopeType == scope.Unknown {
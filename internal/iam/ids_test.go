@@ -30,6 +30,16 @@ func Test_PublicIds(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, strings.HasPrefix(id, globals.GroupPrefix+"_"))
 	})
+	t.Run("service account", func(t *testing.T) {
+		id, err := newServiceAccountId()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, globals.ServiceAccountPrefix+"_"))
+	})
+	t.Run("service account api key", func(t *testing.T) {
+		id, err := newServiceAccountApiKeyId()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, globals.ServiceAccountApiKeyPrefix+"_"))
+	})
 	t.Run("oidc managed group", func(t *testing.T) {
 		assert.True(t, strings.HasPrefix("mgoidc_1234567890", globals.OidcManagedGroupPrefix+"_"))
 	})
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package job
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+const pruneExpiredPrincipalRolesFrequency = time.Minute * 5
+
+// pruneExpiredPrincipalRolesJob defines a periodic job that removes principal
+// role assignments whose not after time has passed. Expired assignments no
+// longer grant anything, so this only keeps roles tidy; each removal is
+// recorded in an audit event.
+type pruneExpiredPrincipalRolesJob struct {
+	r   db.Reader
+	w   db.Writer
+	kms *kms.Kms
+
+	// the number of assignments removed in the most recent run
+	prunedInRun int
+	// the number of expired assignments found in the most recent run
+	totalInRun int
+}
+
+// newPruneExpiredPrincipalRolesJob instantiates the prune expired principal
+// roles job.
+func newPruneExpiredPrincipalRolesJob(ctx context.Context, r db.Reader, w db.Writer, kms *kms.Kms) (*pruneExpiredPrincipalRolesJob, error) {
+	const op = "job.newPruneExpiredPrincipalRolesJob"
+	switch {
+	case isNil(r):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	case isNil(w):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing writer")
+	case kms == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing kms")
+	}
+	return &pruneExpiredPrincipalRolesJob{
+		r:   r,
+		w:   w,
+		kms: kms,
+	}, nil
+}
+
+// Name returns a short, unique name for the job.
+func (j *pruneExpiredPrincipalRolesJob) Name() string { return "prune_expired_principal_roles" }
+
+// Description returns the description for the job.
+func (j *pruneExpiredPrincipalRolesJob) Description() string {
+	return "Remove principal role assignments that have expired"
+}
+
+// NextRunIn returns the next run time after a job is completed.
+func (j *pruneExpiredPrincipalRolesJob) NextRunIn(_ context.Context) (time.Duration, error) {
+	return pruneExpiredPrincipalRolesFrequency, nil
+}
+
+// Status returns the status of the running job.
+func (j *pruneExpiredPrincipalRolesJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: j.prunedInRun,
+		Total:     j.totalInRun,
+	}
+}
+
+// Run removes the expired principal role assignments, one role at a time. A
+// role that fails to be updated, e.g. because it was modified concurrently, is
+// skipped and retried on the next run.
+func (j *pruneExpiredPrincipalRolesJob) Run(ctx context.Context) error {
+	const op = "job.(pruneExpiredPrincipalRolesJob).Run"
+	j.prunedInRun, j.totalInRun = 0, 0
+
+	repo, err := iam.NewRepository(j.r, j.w, j.kms)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	expired, err := repo.ListExpiredPrincipalRoles(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	j.totalInRun = len(expired)
+
+	byRole := make(map[string][]string)
+	for _, pr := range expired {
+		byRole[pr.GetRoleId()] = append(byRole[pr.GetRoleId()], pr.GetPrincipalId())
+	}
+	roleIds := make([]string, 0, len(byRole))
+	for roleId := range byRole {
+		roleIds = append(roleIds, roleId)
+	}
+	sort.Strings(roleIds)
+
+	for _, roleId := range roleIds {
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		principalIds := byRole[roleId]
+		role, _, _, err := repo.LookupRole(ctx, roleId)
+		if err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("unable to look up role", "role_id", roleId))
+			continue
+		}
+		if role == nil {
+			continue
+		}
+		deleted, err := repo.DeletePrincipalRoles(ctx, roleId, role.GetVersion(), principalIds)
+		if err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("unable to remove expired principals from role", "role_id", roleId))
+			continue
+		}
+		j.prunedInRun += deleted
+		auditPrunedPrincipalRoles(ctx, roleId, role.GetVersion(), principalIds)
+	}
+	return nil
+}
+
+// auditPrunedPrincipalRoles writes an audit event recording that expired
+// principals were removed from a role.
+func auditPrunedPrincipalRoles(ctx context.Context, roleId string, version uint32, principalIds []string) {
+	const op = "job.auditPrunedPrincipalRoles"
+	err := event.WriteAudit(ctx, op, event.WithRequest(&event.Request{
+		Operation: "expired principal roles removed",
+		Details: &pbs.RemoveRolePrincipalsRequest{
+			Id:           roleId,
+			Version:      version,
+			PrincipalIds: principalIds,
+		},
+	}))
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to write audit event", "role_id", roleId))
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package job
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPruneExpiredPrincipalRolesJob(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, db.TestWrapper(t))
+
+	tests := []struct {
+		name    string
+		r       db.Reader
+		w       db.Writer
+		kms     *kms.Kms
+		wantErr bool
+	}{
+		{name: "nil reader", w: rw, kms: kmsCache, wantErr: true},
+		{name: "nil writer", r: rw, kms: kmsCache, wantErr: true},
+		{name: "nil kms", r: rw, w: rw, wantErr: true},
+		{name: "valid", r: rw, w: rw, kms: kmsCache},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newPruneExpiredPrincipalRolesJob(ctx, tt.r, tt.w, tt.kms)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "prune_expired_principal_roles", got.Name())
+		})
+	}
+}
+
+func TestPruneExpiredPrincipalRolesJob_Run(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo := iam.TestRepo(t, conn, wrapper)
+
+	org, _ := iam.TestScopes(t, repo)
+	role := iam.TestRole(t, conn, org.GetPublicId())
+	expiredUser := iam.TestUser(t, repo, org.GetPublicId())
+	activeUser := iam.TestUser(t, repo, org.GetPublicId())
+	group := iam.TestGroup(t, conn, org.GetPublicId())
+
+	_, err := repo.AddPrincipalRoles(ctx, role.GetPublicId(), role.GetVersion(), []string{expiredUser.GetPublicId(), group.GetPublicId()},
+		iam.WithNotBefore(time.Now().Add(-time.Hour)), iam.WithNotAfter(time.Now().Add(-time.Minute)))
+	require.NoError(t, err)
+	_, err = repo.AddPrincipalRoles(ctx, role.GetPublicId(), role.GetVersion()+1, []string{activeUser.GetPublicId()},
+		iam.WithNotAfter(time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+
+	job, err := newPruneExpiredPrincipalRolesJob(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 2, job.Status().Completed)
+	assert.Equal(t, 2, job.Status().Total)
+
+	principals, err := repo.ListPrincipalRoles(ctx, role.GetPublicId())
+	require.NoError(t, err)
+	require.Len(t, principals, 1)
+	assert.Equal(t, activeUser.GetPublicId(), principals[0].GetPrincipalId())
+
+	// Nothing left to prune
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 0, job.Status().Total)
+}
@@ -3,7 +3,10 @@
 
 package iam
 
-import "io"
+import (
+	"io"
+	"time"
+)
 
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
@@ -32,6 +35,9 @@ type options struct {
 	withRandomReader            io.Reader
 	withAccountIds              []string
 	withPrimaryAuthMethodId     string
+	withNotBefore               time.Time
+	withNotAfter                time.Time
+	withAfterHistoryId          uint32
 }
 
 func getDefaultOptions() options {
@@ -144,3 +150,27 @@ func WithPrimaryAuthMethodId(id string) Option {
 		o.withPrimaryAuthMethodId = id
 	}
 }
+
+// WithNotBefore provides an option to specify the time from which a principal
+// role assignment is in effect.
+func WithNotBefore(t time.Time) Option {
+	return func(o *options) {
+		o.withNotBefore = t
+	}
+}
+
+// WithNotAfter provides an option to specify the time at which a principal
+// role assignment stops being in effect.
+func WithNotAfter(t time.Time) Option {
+	return func(o *options) {
+		o.withNotAfter = t
+	}
+}
+
+// WithAfterHistoryId provides an option to only list history entries with a
+// greater id, to page through the history.
+func WithAfterHistoryId(id uint32) Option {
+	return func(o *options) {
+		o.withAfterHistoryId = id
+	}
+}
@@ -5,6 +5,7 @@ package iam
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -82,4 +83,27 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withPrimaryAuthMethodId = "test"
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithNotBefore", func(t *testing.T) {
+		assert := assert.New(t)
+		now := time.Now()
+		opts := getOpts(WithNotBefore(now))
+		testOpts := getDefaultOptions()
+		testOpts.withNotBefore = now
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithNotAfter", func(t *testing.T) {
+		assert := assert.New(t)
+		now := time.Now()
+		opts := getOpts(WithNotAfter(now))
+		testOpts := getDefaultOptions()
+		testOpts.withNotAfter = now
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithAfterHistoryId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithAfterHistoryId(42))
+		testOpts := getDefaultOptions()
+		testOpts.withAfterHistoryId = 42
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -5,8 +5,10 @@ package iam
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/iam/store"
 	"google.golang.org/protobuf/proto"
@@ -20,6 +22,9 @@ const (
 	UserRoleType         RoleType = 1
 	GroupRoleType        RoleType = 2
 	ManagedGroupRoleType RoleType = 3
+	// ServiceAccountRoleType is the type of roles assigned to service
+	// accounts
+	ServiceAccountRoleType RoleType = 4
 )
 
 // String returns a string representation of the role type.
@@ -29,14 +34,16 @@ func (r RoleType) String() string {
 		"user",
 		"group",
 		"managed group",
+		"service account",
 	}[r]
 }
 
 const (
-	principalRoleViewDefaultTable = "iam_principal_role"
-	userRoleDefaultTable          = "iam_user_role"
-	groupRoleDefaultTable         = "iam_group_role"
-	managedGroupRoleDefaultTable  = "iam_managed_group_role"
+	principalRoleViewDefaultTable  = "iam_principal_role"
+	userRoleDefaultTable           = "iam_user_role"
+	groupRoleDefaultTable          = "iam_group_role"
+	managedGroupRoleDefaultTable   = "iam_managed_group_role"
+	serviceAccountRoleDefaultTable = "iam_service_account_role"
 )
 
 // PrincipalRole provides a common way to return roles regardless of their
@@ -78,9 +85,9 @@ var (
 	_ db.VetForWriter = (*UserRole)(nil)
 )
 
-// NewUserRole creates a new user role in memory. No options are supported
-// currently.
-func NewUserRole(roleId, userId string, _ ...Option) (*UserRole, error) {
+// NewUserRole creates a new user role in memory. Supported options are
+// WithNotBefore and WithNotAfter, which bound the assignment in time.
+func NewUserRole(roleId, userId string, opt ...Option) (*UserRole, error) {
 	const op = "iam.NewUserRole"
 	if roleId == "" {
 		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing role id")
@@ -88,10 +95,16 @@ func NewUserRole(roleId, userId string, _ ...Option) (*UserRole, error) {
 	if userId == "" {
 		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing user id")
 	}
+	notBefore, notAfter, err := timeBounds(op, opt...)
+	if err != nil {
+		return nil, err
+	}
 	return &UserRole{
 		UserRole: &store.UserRole{
 			PrincipalId: userId,
 			RoleId:      roleId,
+			NotBefore:   notBefore,
+			NotAfter:    notAfter,
 		},
 	}, nil
 }
@@ -155,8 +168,8 @@ var (
 	_ db.VetForWriter = (*GroupRole)(nil)
 )
 
-// NewGroupRole creates a new group role in memory. No options are supported
-// currently.
+// NewGroupRole creates a new group role in memory. Supported options are
+// WithNotBefore and WithNotAfter, which bound the assignment in time.
 func NewGroupRole(roleId, groupId string, opt ...Option) (*GroupRole, error) {
 	const op = "iam.NewGroupRole"
 	if roleId == "" {
@@ -165,10 +178,16 @@ func NewGroupRole(roleId, groupId string, opt ...Option) (*GroupRole, error) {
 	if groupId == "" {
 		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing group id")
 	}
+	notBefore, notAfter, err := timeBounds(op, opt...)
+	if err != nil {
+		return nil, err
+	}
 	return &GroupRole{
 		GroupRole: &store.GroupRole{
 			PrincipalId: groupId,
 			RoleId:      roleId,
+			NotBefore:   notBefore,
+			NotAfter:    notAfter,
 		},
 	}, nil
 }
@@ -232,8 +251,9 @@ var (
 	_ db.VetForWriter = (*ManagedGroupRole)(nil)
 )
 
-// NewGroupRole creates a new group role in memory. No options are supported
-// currently.
+// NewManagedGroupRole creates a new managed group role in memory. Supported
+// options are WithNotBefore and WithNotAfter, which bound the assignment in
+// time.
 func NewManagedGroupRole(roleId, managedGroupId string, opt ...Option) (*ManagedGroupRole, error) {
 	const op = "iam.NewManagedGroupRole"
 	if roleId == "" {
@@ -242,10 +262,16 @@ func NewManagedGroupRole(roleId, managedGroupId string, opt ...Option) (*Managed
 	if managedGroupId == "" {
 		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing managed group id")
 	}
+	notBefore, notAfter, err := timeBounds(op, opt...)
+	if err != nil {
+		return nil, err
+	}
 	return &ManagedGroupRole{
 		ManagedGroupRole: &store.ManagedGroupRole{
 			PrincipalId: managedGroupId,
 			RoleId:      roleId,
+			NotBefore:   notBefore,
+			NotAfter:    notAfter,
 		},
 	}, nil
 }
@@ -297,3 +323,118 @@ func (r *ManagedGroupRole) SetTableName(n string) {
 		r.tableName = n
 	}
 }
+
+// ServiceAccountRole is a service account assigned to a role
+type ServiceAccountRole struct {
+	*store.ServiceAccountRole
+	tableName string `gorm:"-"`
+}
+
+// ensure that ServiceAccountRole implements the interfaces of: Cloneable and
+// db.VetForWriter
+var (
+	_ Cloneable       = (*ServiceAccountRole)(nil)
+	_ db.VetForWriter = (*ServiceAccountRole)(nil)
+)
+
+// NewServiceAccountRole creates a new service account role in memory.
+// Supported options are WithNotBefore and WithNotAfter, which bound the
+// assignment in time.
+func NewServiceAccountRole(roleId, serviceAccountId string, opt ...Option) (*ServiceAccountRole, error) {
+	const op = "iam.NewServiceAccountRole"
+	if roleId == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing role id")
+	}
+	if serviceAccountId == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing service account id")
+	}
+	notBefore, notAfter, err := timeBounds(op, opt...)
+	if err != nil {
+		return nil, err
+	}
+	return &ServiceAccountRole{
+		ServiceAccountRole: &store.ServiceAccountRole{
+			PrincipalId: serviceAccountId,
+			RoleId:      roleId,
+			NotBefore:   notBefore,
+			NotAfter:    notAfter,
+		},
+	}, nil
+}
+
+func allocServiceAccountRole() ServiceAccountRole {
+	return ServiceAccountRole{
+		ServiceAccountRole: &store.ServiceAccountRole{},
+	}
+}
+
+// Clone creates a clone of the ServiceAccountRole.
+func (r *ServiceAccountRole) Clone() any {
+	cp := proto.Clone(r.ServiceAccountRole)
+	return &ServiceAccountRole{
+		ServiceAccountRole: cp.(*store.ServiceAccountRole),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface for service account
+// roles.
+func (r *ServiceAccountRole) VetForWrite(ctx context.Context, _ db.Reader, _ db.OpType, _ ...db.Option) error {
+	const op = "iam.(ServiceAccountRole).VetForWrite"
+	if r.RoleId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing role id")
+	}
+	if r.PrincipalId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing service account id")
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name for
+// service account roles.
+func (r *ServiceAccountRole) TableName() string {
+	if r.tableName != "" {
+		return r.tableName
+	}
+	return serviceAccountRoleDefaultTable
+}
+
+// SetTableName sets the table name for the resource. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (r *ServiceAccountRole) SetTableName(n string) {
+	switch n {
+	case "":
+		r.tableName = serviceAccountRoleDefaultTable
+	default:
+		r.tableName = n
+	}
+}
+
+// timeBounds returns the bounds of a principal role assignment set with the
+// WithNotBefore and WithNotAfter options, nil for any bound that isn't set.
+func timeBounds(op errors.Op, opt ...Option) (notBefore, notAfter *timestamp.Timestamp, err error) {
+	opts := getOpts(opt...)
+	if !opts.withNotBefore.IsZero() {
+		notBefore = timestamp.New(opts.withNotBefore)
+	}
+	if !opts.withNotAfter.IsZero() {
+		notAfter = timestamp.New(opts.withNotAfter)
+	}
+	if notBefore != nil && notAfter != nil && !opts.withNotBefore.Before(opts.withNotAfter) {
+		return nil, nil, errors.NewDeprecated(errors.InvalidParameter, op, "not before must be before not after")
+	}
+	return notBefore, notAfter, nil
+}
+
+// Status returns the status of the principal role assignment at the given
+// time: "pending" before its not before time, "expired" from its not after
+// time and "active" otherwise.
+func (v *PrincipalRole) Status(t time.Time) string {
+	switch {
+	case v.GetNotBefore() != nil && t.Before(v.GetNotBefore().AsTime()):
+		return "pending"
+	case v.GetNotAfter() != nil && !t.Before(v.GetNotAfter().AsTime()):
+		return "expired"
+	default:
+		return "active"
+	}
+}
@@ -6,9 +6,11 @@ package iam
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/iam/store"
 	"github.com/stretchr/testify/assert"
@@ -906,3 +908,59 @@ func TestManagedGroupRole_SetTableName(t *testing.T) {
 		})
 	}
 }
+
+func TestNewPrincipalRoles_timeBounds(t *testing.T) {
+	t.Parallel()
+	now := time.Now().Truncate(time.Second)
+	later := now.Add(time.Hour)
+
+	ur, err := NewUserRole("r_1234567890", "u_1234567890", WithNotBefore(now), WithNotAfter(later))
+	require.NoError(t, err)
+	assert.True(t, now.Equal(ur.GetNotBefore().AsTime()))
+	assert.True(t, later.Equal(ur.GetNotAfter().AsTime()))
+
+	gr, err := NewGroupRole("r_1234567890", "g_1234567890", WithNotAfter(later))
+	require.NoError(t, err)
+	assert.Nil(t, gr.GetNotBefore())
+	assert.True(t, later.Equal(gr.GetNotAfter().AsTime()))
+
+	mgr, err := NewManagedGroupRole("r_1234567890", "mgoidc_1234567890")
+	require.NoError(t, err)
+	assert.Nil(t, mgr.GetNotBefore())
+	assert.Nil(t, mgr.GetNotAfter())
+
+	_, err = NewUserRole("r_1234567890", "u_1234567890", WithNotBefore(later), WithNotAfter(now))
+	require.Error(t, err)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+}
+
+func TestPrincipalRole_Status(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	tests := []struct {
+		name      string
+		notBefore time.Time
+		notAfter  time.Time
+		want      string
+	}{
+		{name: "unbounded", want: "active"},
+		{name: "started", notBefore: now.Add(-time.Hour), want: "active"},
+		{name: "pending", notBefore: now.Add(time.Hour), want: "pending"},
+		{name: "not expired", notAfter: now.Add(time.Hour), want: "active"},
+		{name: "expired", notAfter: now.Add(-time.Hour), want: "expired"},
+		{name: "expires now", notAfter: now, want: "expired"},
+		{name: "within window", notBefore: now.Add(-time.Hour), notAfter: now.Add(time.Hour), want: "active"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &PrincipalRole{PrincipalRoleView: &store.PrincipalRoleView{}}
+			if !tt.notBefore.IsZero() {
+				pr.NotBefore = timestamp.New(tt.notBefore)
+			}
+			if !tt.notAfter.IsZero() {
+				pr.NotAfter = timestamp.New(tt.notAfter)
+			}
+			assert.Equal(t, tt.want, pr.Status(now))
+		})
+	}
+}
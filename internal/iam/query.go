@@ -111,4 +111,154 @@ const (
 	select * from final
 	order by action, member_id;
 	`
+
+	// deleteResourceTagsQuery - remove every tag of a resource.
+	deleteResourceTagsQuery = `delete from resource_tag where resource_id = ?`
+
+	// lookupResourceInfoQuery - given a resource id, return the scope and
+	// parent scope containing it, its pin and, for resources that can be
+	// matched by name in grants, its name.
+	lookupResourceInfoQuery = `
+with
+resource (scope_id, pin, name) as (
+  select coalesce(parent_id, public_id), null, null
+    from iam_scope
+   where public_id = @resource_id
+   union all
+  select scope_id, null, null
+    from iam_user
+   where public_id = @resource_id
+   union all
+  select scope_id, null, null
+    from iam_group
+   where public_id = @resource_id
+   union all
+  select scope_id, null, null
+    from iam_role
+   where public_id = @resource_id
+   union all
+  select scope_id, null, null
+    from auth_method
+   where public_id = @resource_id
+   union all
+  select scope_id, auth_method_id, null
+    from auth_account
+   where public_id = @resource_id
+   union all
+  select auth_method.scope_id, auth_managed_group.auth_method_id, null
+    from auth_managed_group
+    join auth_method
+      on auth_method.public_id = auth_managed_group.auth_method_id
+   where auth_managed_group.public_id = @resource_id
+   union all
+  select scope_id, null, null
+    from server_worker
+   where public_id = @resource_id
+   union all
+  select project_id, null, name
+    from host_catalog
+   where public_id = @resource_id
+   union all
+  select host_catalog.project_id, host_set.catalog_id, null
+    from host_set
+    join host_catalog
+      on host_catalog.public_id = host_set.catalog_id
+   where host_set.public_id = @resource_id
+   union all
+  select host_catalog.project_id, host.catalog_id, null
+    from host
+    join host_catalog
+      on host_catalog.public_id = host.catalog_id
+   where host.public_id = @resource_id
+   union all
+  select credential_store.project_id, null, coalesce(credential_vault_store.name, credential_static_store.name)
+    from credential_store
+    left join credential_vault_store
+      on credential_vault_store.public_id = credential_store.public_id
+    left join credential_static_store
+      on credential_static_store.public_id = credential_store.public_id
+   where credential_store.public_id = @resource_id
+   union all
+  select credential_store.project_id, credential_library.store_id, null
+    from credential_library
+    join credential_store
+      on credential_store.public_id = credential_library.store_id
+   where credential_library.public_id = @resource_id
+   union all
+  select credential_store.project_id, credential_static.store_id, null
+    from credential_static
+    join credential_store
+      on credential_store.public_id = credential_static.store_id
+   where credential_static.public_id = @resource_id
+   union all
+  select project_id, null, name
+    from target_all_subtypes
+   where public_id = @resource_id
+   union all
+  select project_id, null, null
+    from session
+   where public_id = @resource_id
+)
+select resource.scope_id                    as scope_id,
+       coalesce(iam_scope.parent_id, '')    as parent_scope_id,
+       coalesce(resource.pin, '')           as pin,
+       coalesce(resource.name, '')          as name
+  from resource
+  left join iam_scope
+    on iam_scope.public_id = resource.scope_id
+ limit 1;
+`
+)
+
+const (
+	// scopeQuotaUsageQuery counts the resources of each quota resource type in
+	// the scope identified by @scope_id: the scope itself if it is a project,
+	// or all of its projects if it is an org.
+	scopeQuotaUsageQuery = `
+with
+projects (public_id) as (
+  select public_id
+    from iam_scope
+   where type = 'project'
+     and (public_id = @scope_id or parent_id = @scope_id)
+)
+select 'target'           as resource_type,
+       count(*)           as usage
+  from target
+ where project_id in (select public_id from projects)
+ union all
+select 'host-catalog', count(*)
+  from host_catalog
+ where project_id in (select public_id from projects)
+ union all
+select 'credential-store', count(*)
+  from credential_store
+  left join credential_vault_store
+    on credential_vault_store.public_id = credential_store.public_id
+ where credential_store.project_id in (select public_id from projects)
+   and credential_vault_store.delete_time is null
+ union all
+select 'session', count(*)
+  from session
+  join session_state
+    on session_state.session_id = session.public_id
+   and session_state.end_time is null
+ where session.project_id in (select public_id from projects)
+   and session_state.state != 'terminated';
+`
+
+	// lockProjectQuotasQuery returns, and locks for the rest of the
+	// transaction, the quotas on @resource_type that apply to the project
+	// @project_id: those of the project and of its org.
+	lockProjectQuotasQuery = `
+select iam_scope_quota.scope_id,
+       iam_scope_quota.max_count
+  from iam_scope_quota
+  join iam_scope
+    on iam_scope.public_id = @project_id
+ where iam_scope_quota.resource_type = @resource_type
+   and iam_scope_quota.scope_id in (iam_scope.public_id, iam_scope.parent_id)
+ order by iam_scope_quota.scope_id
+   for update of iam_scope_quota;
+`
 )
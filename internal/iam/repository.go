@@ -65,9 +65,23 @@ func (r *Repository) list(ctx context.Context, resources any, where string, args
 	return r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit))
 }
 
+// listFromReplica is list for the listings returned by list endpoints, which
+// tolerate slightly out of date results and so can be read from a read
+// replica of the database.
+func (r *Repository) listFromReplica(ctx context.Context, resources any, where string, args []any, opt ...Option) error {
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	return r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit), db.WithReplicaRead(true))
+}
+
 // create will create a new iam resource in the db repository with an oplog entry
 func (r *Repository) create(ctx context.Context, resource Resource, _ ...Option) (Resource, error) {
 	const op = "iam.(Repository).create"
+	ctx = db.NewOpContext(ctx, op)
 	if resource == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing resource")
 	}
@@ -117,6 +131,7 @@ func (r *Repository) create(ctx context.Context, resource Resource, _ ...Option)
 // update will update an iam resource in the db repository with an oplog entry
 func (r *Repository) update(ctx context.Context, resource Resource, version uint32, fieldMaskPaths []string, setToNullPaths []string, opt ...Option) (Resource, int, error) {
 	const op = "iam.(Repository).update"
+	ctx = db.NewOpContext(ctx, op)
 	if version == 0 {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
 	}
@@ -191,6 +206,7 @@ func (r *Repository) update(ctx context.Context, resource Resource, version uint
 // delete will delete an iam resource in the db repository with an oplog entry
 func (r *Repository) delete(ctx context.Context, resource Resource, _ ...Option) (int, error) {
 	const op = "iam.(Repository).delete"
+	ctx = db.NewOpContext(ctx, op)
 	if resource == nil {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing resource")
 	}
@@ -244,6 +260,7 @@ func (r *Repository) delete(ctx context.Context, resource Resource, _ ...Option)
 
 func (r *Repository) stdMetadata(ctx context.Context, resource Resource) (oplog.Metadata, error) {
 	const op = "iam.(Repository).stdMetadata"
+	ctx = db.NewOpContext(ctx, op)
 	if s, ok := resource.(*Scope); ok {
 		newScope := AllocScope()
 		newScope.PublicId = s.PublicId
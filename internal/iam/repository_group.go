@@ -54,6 +54,7 @@ func (r *Repository) CreateGroup(ctx context.Context, group *Group, _ ...Option)
 // If no updatable fields are included in the fieldMaskPaths, then an error is returned.
 func (r *Repository) UpdateGroup(ctx context.Context, group *Group, version uint32, fieldMaskPaths []string, _ ...Option) (*Group, []*GroupMember, int, error) {
 	const op = "iam.(Repository).UpdateGroup"
+	ctx = db.NewOpContext(ctx, op)
 	if group == nil {
 		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing group")
 	}
@@ -121,6 +122,7 @@ func (r *Repository) UpdateGroup(ctx context.Context, group *Group, version uint
 // found, it will return nil, nil.
 func (r *Repository) LookupGroup(ctx context.Context, withPublicId string, _ ...Option) (*Group, []*GroupMember, error) {
 	const op = "iam.(Repository).LookupGroup"
+	ctx = db.NewOpContext(ctx, op)
 	if withPublicId == "" {
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
 	}
@@ -158,6 +160,7 @@ func (r *Repository) LookupGroup(ctx context.Context, withPublicId string, _ ...
 // DeleteGroup will delete a group from the repository.
 func (r *Repository) DeleteGroup(ctx context.Context, withPublicId string, _ ...Option) (int, error) {
 	const op = "iam.(Repository).DeleteGroup"
+	ctx = db.NewOpContext(ctx, op)
 	if withPublicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
 	}
@@ -180,7 +183,7 @@ func (r *Repository) ListGroups(ctx context.Context, withScopeIds []string, opt
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
 	}
 	var grps []*Group
-	err := r.list(ctx, &grps, "scope_id in (?)", []any{withScopeIds}, opt...)
+	err := r.listFromReplica(ctx, &grps, "scope_id in (?)", []any{withScopeIds}, opt...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -206,6 +209,7 @@ func (r *Repository) ListGroupMembers(ctx context.Context, withGroupId string, o
 // and will return an error.
 func (r *Repository) AddGroupMembers(ctx context.Context, groupId string, groupVersion uint32, userIds []string, _ ...Option) ([]*GroupMember, error) {
 	const op = "iam.(Repository).AddGroupMembers"
+	ctx = db.NewOpContext(ctx, op)
 	if groupId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing group id")
 	}
@@ -270,6 +274,7 @@ func (r *Repository) AddGroupMembers(ctx context.Context, groupId string, groupV
 				"scope-type":         []string{scope.Type},
 				"resource-public-id": []string{groupId},
 			}
+			addHistoryMetadata(metadata, msgs)
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, groupTicket, metadata, msgs); err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
 			}
@@ -299,6 +304,7 @@ func (r *Repository) AddGroupMembers(ctx context.Context, groupId string, groupV
 // value for the WithVersion option and will return an error.
 func (r *Repository) DeleteGroupMembers(ctx context.Context, groupId string, groupVersion uint32, userIds []string, _ ...Option) (int, error) {
 	const op = "iam.(Repository).DeleteGroupMembers"
+	ctx = db.NewOpContext(ctx, op)
 	if groupId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing group id")
 	}
@@ -368,6 +374,7 @@ func (r *Repository) DeleteGroupMembers(ctx context.Context, groupId string, gro
 				"scope-type":         []string{scope.Type},
 				"resource-public-id": []string{groupId},
 			}
+			addHistoryMetadata(metadata, msgs)
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, groupTicket, metadata, msgs); err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
 			}
@@ -385,6 +392,7 @@ func (r *Repository) DeleteGroupMembers(ctx context.Context, groupId string, gro
 // and will return an error.
 func (r *Repository) SetGroupMembers(ctx context.Context, groupId string, groupVersion uint32, userIds []string, _ ...Option) ([]*GroupMember, int, error) {
 	const op = "iam.(Repository).SetGroupMembers"
+	ctx = db.NewOpContext(ctx, op)
 	if groupId == "" {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing group id")
 	}
@@ -481,6 +489,7 @@ func (r *Repository) SetGroupMembers(ctx context.Context, groupId string, groupV
 			}
 			// we're done with all the membership writes, so let's write the
 			// group's update oplog message
+			addHistoryMetadata(metadata, msgs)
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, groupTicket, metadata, msgs); err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
 			}
@@ -0,0 +1,811 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	authStore "github.com/hashicorp/boundary/internal/auth/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/oplog"
+	oplogStore "github.com/hashicorp/boundary/internal/oplog/store"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// HistoryEntry is an oplog entry that changed a role, group or user.
+type HistoryEntry struct {
+	// Id is the id of the oplog entry.
+	Id uint32
+	// CreateTime is when the change was made.
+	CreateTime time.Time
+	// ActorId is the id of the user whose request made the change. It is
+	// empty for changes made outside of a request, e.g. by a job, or recorded
+	// before actors were.
+	ActorId string
+	// Changes are the items that were written.
+	Changes []*HistoryChange
+}
+
+// HistoryChange is an item written by an oplog entry.
+type HistoryChange struct {
+	// Operation is one of create, update or delete.
+	Operation string
+	// Type is the type of the item, e.g. role or role-grant.
+	Type string
+	// Values are the values of the item. For updates they are limited to the
+	// fields that were updated.
+	Values proto.Message
+	// UpdatedFields are the names of the fields changed by an update.
+	UpdatedFields []string
+	// PreviousValues are the values of the updated fields before an update,
+	// if the item's creation is still in the oplog.
+	PreviousValues proto.Message
+}
+
+// historyType is a type of item written in the oplog entries of roles, groups,
+// users and scopes.
+type historyType struct {
+	name  string
+	alloc func() proto.Message
+}
+
+// historyTypes are the item types that can be read from the history, keyed by
+// their table name.
+var historyTypes = map[string]historyType{
+	defaultScopeTableName:          {name: "scope", alloc: func() proto.Message { return &store.Scope{} }},
+	defaultScopeQuotaTable:         {name: "scope-quota", alloc: func() proto.Message { return &store.ScopeQuota{} }},
+	defaultRoleTableName:           {name: "role", alloc: func() proto.Message { return &store.Role{} }},
+	defaultRoleGrantTable:          {name: "role-grant", alloc: func() proto.Message { return &store.RoleGrant{} }},
+	defaultRoleGrantScopeTable:     {name: "role-grant-scope", alloc: func() proto.Message { return &store.RoleGrantScope{} }},
+	userRoleDefaultTable:           {name: "user-role", alloc: func() proto.Message { return &store.UserRole{} }},
+	groupRoleDefaultTable:          {name: "group-role", alloc: func() proto.Message { return &store.GroupRole{} }},
+	managedGroupRoleDefaultTable:   {name: "managed-group-role", alloc: func() proto.Message { return &store.ManagedGroupRole{} }},
+	serviceAccountRoleDefaultTable: {name: "service-account-role", alloc: func() proto.Message { return &store.ServiceAccountRole{} }},
+	defaultGroupTableName:          {name: "group", alloc: func() proto.Message { return &store.Group{} }},
+	groupMemberUserDefaultTable:    {name: "group-member", alloc: func() proto.Message { return &store.GroupMemberUser{} }},
+	defaultUserTableName:           {name: "user", alloc: func() proto.Message { return &store.User{} }},
+	defaultAccountTableName:        {name: "account", alloc: func() proto.Message { return &authStore.Account{} }},
+}
+
+// Oplog metadata keys recording the principals a role was assigned to or
+// unassigned from, the users added to or removed from a group and the user an
+// account was associated with or dissociated from, so the history of a group
+// or user can be found without decrypting the entries of every role, group
+// and account.
+const (
+	principalIdMetadataKey   = "principal-public-id"
+	memberIdMetadataKey      = "member-public-id"
+	accountUserIdMetadataKey = "account-user-id"
+)
+
+// addHistoryMetadata adds the ids of the principals and members written by
+// the messages to the oplog metadata.
+func addHistoryMetadata(metadata oplog.Metadata, msgs []*oplog.Message) {
+	seen := make(map[string]bool)
+	for _, m := range msgs {
+		var key, id string
+		switch v := m.Message.(type) {
+		case interface{ GetMemberId() string }:
+			key, id = memberIdMetadataKey, v.GetMemberId()
+		case interface{ GetPrincipalId() string }:
+			key, id = principalIdMetadataKey, v.GetPrincipalId()
+		default:
+			continue
+		}
+		if id == "" || seen[key+"/"+id] {
+			continue
+		}
+		seen[key+"/"+id] = true
+		metadata[key] = append(metadata[key], id)
+	}
+}
+
+// historyEntry is a decrypted and decoded oplog entry.
+type historyEntry struct {
+	id         uint32
+	createTime time.Time
+	resourceId string
+	actorId    string
+	msgs       []oplog.Message
+}
+
+// ListHistory returns the changes recorded in the oplog for the role, group or
+// user, oldest first. The history of a role includes changes to its grants,
+// grant scopes and principals. The history of a group also includes its
+// membership and its assignment to roles, and that of a user its accounts,
+// group memberships and assignment to roles. Oplog entries are removed with
+// the scope whose key encrypted them, so changes made within a deleted scope
+// are not included, and assignments, memberships and account associations
+// written before their principals, members and users were recorded in the
+// oplog metadata are only included in the history of the role, group or
+// account.
+//
+// At most WithLimit entries are returned, defaulting to the repository's
+// limit. Pass the id of the last entry returned to WithAfterHistoryId to read
+// the next page. WithNotBefore and WithNotAfter restrict the entries to those
+// created in the time range. The previous values of updates are those left by
+// all of the earlier entries of the history, including the ones that are not
+// returned.
+func (r *Repository) ListHistory(ctx context.Context, resourceId string, opt ...Option) ([]*HistoryEntry, error) {
+	const op = "iam.(Repository).ListHistory"
+	if resourceId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing resource id")
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+
+	// keys are the metadata keys whose value is the resource id in the
+	// entries of its history, and related reports whether a change made in
+	// the entry of another resource affects the requested one.
+	keys := []string{"resource-public-id"}
+	related := func(oplog.Message) bool { return false }
+	switch globals.ResourceTypeFromPrefix(resourceId) {
+	case resource.Role:
+	case resource.Group:
+		keys = append(keys, principalIdMetadataKey)
+		related = func(m oplog.Message) bool {
+			gr, ok := m.Message.(*store.GroupRole)
+			return ok && gr.GetPrincipalId() == resourceId
+		}
+	case resource.User:
+		keys = append(keys, principalIdMetadataKey, memberIdMetadataKey, accountUserIdMetadataKey)
+		related = func(m oplog.Message) bool {
+			switch v := m.Message.(type) {
+			case *store.UserRole:
+				return v.GetPrincipalId() == resourceId
+			case *store.GroupMemberUser:
+				return v.GetMemberId() == resourceId
+			case *authStore.Account:
+				return v.GetIamUserId() == resourceId
+			case *store.User:
+				// created with an account the user logged in with
+				return v.GetPublicId() == resourceId
+			}
+			return false
+		}
+	default:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is not a role, group or user id", resourceId))
+	}
+	const historyWhere = "id in (select entry_id from oplog_metadata where key in (?) and value = ?)"
+	historyArgs := []any{keys, resourceId}
+
+	// items holds the current values of the items in the history so the
+	// previous values of updates can be reported.
+	items := make(map[string]proto.Message)
+	changes := func(e *historyEntry) []*HistoryChange {
+		own := e.resourceId == resourceId
+		var out []*HistoryChange
+		for _, m := range e.msgs {
+			if !own && !related(m) {
+				continue
+			}
+			if c := trackChange(items, m); c != nil {
+				out = append(out, c)
+			}
+		}
+		return out
+	}
+
+	// Replay the entries before the requested ones, so the values of the
+	// items they updated are known.
+	var before []string
+	var beforeArgs []any
+	if opts.withAfterHistoryId > 0 {
+		before = append(before, "id <= ?")
+		beforeArgs = append(beforeArgs, opts.withAfterHistoryId)
+	}
+	if !opts.withNotBefore.IsZero() {
+		before = append(before, "create_time < ?")
+		beforeArgs = append(beforeArgs, opts.withNotBefore)
+	}
+	if len(before) > 0 {
+		where := fmt.Sprintf("%s and (%s)", historyWhere, strings.Join(before, " or "))
+		args := append(append([]any{}, historyArgs...), beforeArgs...)
+		if err := r.eachHistoryEntry(ctx, where, args, 0, limit, func(e *historyEntry) bool {
+			changes(e)
+			return true
+		}); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+
+	where := []string{historyWhere}
+	args := historyArgs
+	if !opts.withNotBefore.IsZero() {
+		where = append(where, "create_time >= ?")
+		args = append(args, opts.withNotBefore)
+	}
+	if !opts.withNotAfter.IsZero() {
+		where = append(where, "create_time < ?")
+		args = append(args, opts.withNotAfter)
+	}
+	var history []*HistoryEntry
+	// Entries without changes to the resource are not returned, so entries
+	// are read until the page is full or the history is exhausted.
+	if err := r.eachHistoryEntry(ctx, strings.Join(where, " and "), args, opts.withAfterHistoryId, limit, func(e *historyEntry) bool {
+		if c := changes(e); len(c) > 0 {
+			history = append(history, &HistoryEntry{
+				Id:         e.id,
+				CreateTime: e.createTime,
+				ActorId:    e.actorId,
+				Changes:    c,
+			})
+		}
+		return len(history) != limit
+	}); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return history, nil
+}
+
+// userGrantsHistoryIds selects the ids of the user, the groups it has been a
+// member of and the roles the user, its groups or the anonymous and
+// authenticated users have been assigned to, using the oplog metadata. Its
+// arguments are the user id twice, then the user id, u_anon and u_auth.
+const userGrantsHistoryIds = `
+with
+groups (id) as (
+  select g.value
+    from oplog_metadata g
+   where g.key = 'resource-public-id'
+     and g.entry_id in (select entry_id from oplog_metadata where key = '` + memberIdMetadataKey + `' and value = ?)
+),
+roles (id) as (
+  select r.value
+    from oplog_metadata r
+   where r.key = 'resource-public-id'
+     and r.entry_id in (
+       select entry_id
+         from oplog_metadata
+        where key = '` + principalIdMetadataKey + `'
+          and (value in (?, ?, ?) or value in (select id from groups))
+     )
+)
+select ?
+ union
+select id from groups
+ union
+select id from roles
+`
+
+// GrantsForUserAt returns the grants that applied to the user at the given
+// time, in the same form as GrantsForUser. They are reconstructed by replaying
+// the oplog entries of scopes and of the user and the groups and roles it was
+// related to, written up to that time, reading them in pages of at most
+// WithLimit entries. Grants a user received through managed groups are not
+// reconstructed, and neither are changes that are no longer in the oplog
+// because the scope whose key encrypted them was deleted, nor assignments and
+// memberships written before their principals and members were recorded in
+// the oplog metadata.
+func (r *Repository) GrantsForUserAt(ctx context.Context, userId string, at time.Time, opt ...Option) ([]perms.GrantTuple, error) {
+	const op = "iam.(Repository).GrantsForUserAt"
+	switch {
+	case userId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	case at.IsZero():
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing time")
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+
+	where := fmt.Sprintf("create_time <= ? and (aggregate_name = ? or id in (select entry_id from oplog_metadata where key = 'resource-public-id' and value in (%s)))", userGrantsHistoryIds)
+	args := []any{
+		at,
+		defaultScopeTableName,
+		userId,
+		userId, globals.AnonymousUserId, globals.AnyAuthenticatedUserId,
+		userId,
+	}
+	s := newAclState()
+	if err := r.eachHistoryEntry(ctx, where, args, 0, limit, func(e *historyEntry) bool {
+		for _, m := range e.msgs {
+			s.apply(m)
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return s.grantsForUser(userId, at), nil
+}
+
+// eachHistoryEntry calls fn with each oplog entry matching the where clause
+// with an id greater than afterId, oldest first, until fn returns false. The
+// entries are read in pages of up to limit entries, or all at once if limit
+// is negative.
+func (r *Repository) eachHistoryEntry(ctx context.Context, where string, args []any, afterId uint32, limit int, fn func(*historyEntry) bool) error {
+	const op = "iam.(Repository).eachHistoryEntry"
+	for {
+		entries, lastId, err := r.readHistory(ctx, where, args, afterId, limit)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		for _, e := range entries {
+			if !fn(e) {
+				return nil
+			}
+		}
+		if lastId == 0 || limit < 0 {
+			return nil
+		}
+		afterId = lastId
+	}
+}
+
+// readHistory reads, decrypts and decodes up to limit oplog entries matching
+// the where clause with an id greater than afterId, oldest first. A negative
+// limit reads all of them. Entries containing items of types that are not in
+// historyTypes are skipped. The id of the last entry read, including skipped
+// ones, is returned to read the next page, or 0 if there were none.
+func (r *Repository) readHistory(ctx context.Context, where string, args []any, afterId uint32, limit int) ([]*historyEntry, uint32, error) {
+	const op = "iam.(Repository).readHistory"
+	ctx = db.NewOpContext(ctx, op)
+	where = fmt.Sprintf("id > ? and (%s)", where)
+	args = append([]any{afterId}, args...)
+	var entries []*oplogStore.Entry
+	if err := r.reader.SearchWhere(ctx, &entries, where, args, db.WithOrder("id asc"), db.WithLimit(limit)); err != nil {
+		return nil, 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to search oplog entries"))
+	}
+	if len(entries) == 0 {
+		return nil, 0, nil
+	}
+	lastId := entries[len(entries)-1].GetId()
+
+	entryIds := make([]uint32, 0, len(entries))
+	for _, se := range entries {
+		entryIds = append(entryIds, se.GetId())
+	}
+	var metadata []*oplogStore.Metadata
+	if err := r.reader.SearchWhere(ctx, &metadata, "key in (?, ?) and entry_id in (?)", []any{"resource-public-id", db.OplogActorIdKey, entryIds}, db.WithLimit(-1)); err != nil {
+		return nil, 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to search oplog metadata"))
+	}
+	resourceIds := make(map[uint32]string, len(entries))
+	actorIds := make(map[uint32]string)
+	for _, md := range metadata {
+		switch md.GetKey() {
+		case "resource-public-id":
+			resourceIds[md.GetEntryId()] = md.GetValue()
+		default:
+			actorIds[md.GetEntryId()] = md.GetValue()
+		}
+	}
+
+	types := make([]oplog.Type, 0, len(historyTypes))
+	for name, t := range historyTypes {
+		types = append(types, oplog.Type{Interface: t.alloc(), Name: name})
+	}
+	catalog, err := oplog.NewTypeCatalog(ctx, types...)
+	if err != nil {
+		return nil, 0, errors.Wrap(ctx, err, op)
+	}
+
+	wrappers := make(map[string]wrapping.Wrapper)
+	out := make([]*historyEntry, 0, len(entries))
+	for _, se := range entries {
+		w, ok := wrappers[se.GetKeyId()]
+		if !ok {
+			w, err = r.kms.GetWrapper(ctx, se.GetScopeId(), kms.KeyPurposeOplog, kms.WithKeyId(se.GetKeyId()))
+			if err != nil {
+				return nil, 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+			}
+			wrappers[se.GetKeyId()] = w
+		}
+		e := &oplog.Entry{Entry: se, Wrapper: w}
+		if err := e.DecryptData(ctx); err != nil {
+			return nil, 0, errors.Wrap(ctx, err, op)
+		}
+		msgs, err := e.UnmarshalData(ctx, catalog)
+		if err != nil {
+			if errors.Match(errors.T(errors.KeyNotFound), err) {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("skipping oplog entry with unknown item type", "entry_id", se.GetId()))
+				continue
+			}
+			return nil, 0, errors.Wrap(ctx, err, op)
+		}
+		out = append(out, &historyEntry{
+			id:         se.GetId(),
+			createTime: se.GetCreateTime().AsTime(),
+			resourceId: resourceIds[se.GetId()],
+			actorId:    actorIds[se.GetId()],
+			msgs:       msgs,
+		})
+	}
+	return out, lastId, nil
+}
+
+// trackChange updates the current values of the items with the message and
+// returns it as a change. Updates that only bump the version of an item,
+// which accompany every change to a role's grants or principals and a group's
+// members, are not returned.
+func trackChange(items map[string]proto.Message, m oplog.Message) *HistoryChange {
+	c := &HistoryChange{
+		Type:   historyTypes[m.TypeName].name,
+		Values: m.Message,
+	}
+	var key string
+	if v, ok := m.Message.(interface{ GetPublicId() string }); ok {
+		key = m.TypeName + "/" + v.GetPublicId()
+	}
+
+	switch m.OpType {
+	case oplog.OpType_OP_TYPE_CREATE:
+		c.Operation = "create"
+		if key != "" {
+			items[key] = proto.Clone(m.Message)
+		}
+	case oplog.OpType_OP_TYPE_DELETE:
+		c.Operation = "delete"
+		if key != "" {
+			delete(items, key)
+		}
+	case oplog.OpType_OP_TYPE_UPDATE:
+		c.Operation = "update"
+		fields := fieldsByPath(m.Message, append(m.FieldMaskPaths, m.SetToNullPaths...))
+		for _, fd := range fields {
+			if fd.Name() != "version" {
+				c.UpdatedFields = append(c.UpdatedFields, string(fd.Name()))
+			}
+		}
+		if len(c.UpdatedFields) == 0 {
+			if prev, ok := items[key]; ok {
+				applyUpdate(prev, m)
+			}
+			return nil
+		}
+		c.Values = pickFields(m.Message, fields)
+		if prev, ok := items[key]; ok {
+			c.PreviousValues = pickFields(prev, fields)
+			applyUpdate(prev, m)
+		}
+	default:
+		return nil
+	}
+	return c
+}
+
+// fieldsByPath returns the fields of the message named by the field mask
+// paths, which use the Go names of the fields, e.g. GrantScopeId.
+func fieldsByPath(msg proto.Message, paths []string) []protoreflect.FieldDescriptor {
+	fds := msg.ProtoReflect().Descriptor().Fields()
+	var out []protoreflect.FieldDescriptor
+	for _, p := range paths {
+		for i := 0; i < fds.Len(); i++ {
+			fd := fds.Get(i)
+			if strings.EqualFold(strings.ReplaceAll(string(fd.Name()), "_", ""), p) {
+				out = append(out, fd)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// pickFields returns a copy of the message with only its public id and the
+// given fields set.
+func pickFields(msg proto.Message, fields []protoreflect.FieldDescriptor) proto.Message {
+	src := msg.ProtoReflect()
+	dst := src.New()
+	if fd := src.Descriptor().Fields().ByName("public_id"); fd != nil {
+		dst.Set(fd, src.Get(fd))
+	}
+	for _, fd := range fields {
+		if src.Has(fd) {
+			dst.Set(fd, src.Get(fd))
+		}
+	}
+	return dst.Interface()
+}
+
+// applyUpdate sets the fields updated by the message on the current values of
+// the item.
+func applyUpdate(item proto.Message, m oplog.Message) {
+	dst, src := item.ProtoReflect(), m.Message.ProtoReflect()
+	for _, fd := range fieldsByPath(m.Message, m.FieldMaskPaths) {
+		if src.Has(fd) {
+			dst.Set(fd, src.Get(fd))
+		} else {
+			dst.Clear(fd)
+		}
+	}
+	for _, fd := range fieldsByPath(m.Message, m.SetToNullPaths) {
+		dst.Clear(fd)
+	}
+}
+
+// principalRoleState is a principal's assignment to a role.
+type principalRoleState struct {
+	notBefore *timestamp.Timestamp
+	notAfter  *timestamp.Timestamp
+}
+
+// inEffect reports whether the assignment was in effect at the given time.
+func (p principalRoleState) inEffect(t time.Time) bool {
+	switch {
+	case p.notBefore != nil && t.Before(p.notBefore.AsTime()):
+		return false
+	case p.notAfter != nil && !t.Before(p.notAfter.AsTime()):
+		return false
+	}
+	return true
+}
+
+// aclState is the state of the scopes, roles, groups and users that determine
+// the grants of a user, built by replaying oplog entries. It follows the
+// cascades and triggers the database applies to the same writes.
+type aclState struct {
+	// scope id to parent scope id
+	scopes map[string]string
+	// role id to role
+	roles map[string]*store.Role
+	// role id to canonical grants
+	grants map[string]map[string]bool
+	// role id to grant scopes
+	grantScopes map[string]map[string]bool
+	// role id to principal id to assignment
+	principals map[string]map[string]principalRoleState
+	// group id to scope id
+	groups map[string]string
+	// group id to member user ids
+	members map[string]map[string]bool
+	// user id to scope id
+	users map[string]string
+}
+
+func newAclState() *aclState {
+	return &aclState{
+		scopes:      make(map[string]string),
+		roles:       make(map[string]*store.Role),
+		grants:      make(map[string]map[string]bool),
+		grantScopes: make(map[string]map[string]bool),
+		principals:  make(map[string]map[string]principalRoleState),
+		groups:      make(map[string]string),
+		members:     make(map[string]map[string]bool),
+		users:       make(map[string]string),
+	}
+}
+
+// apply applies a single oplog message to the state.
+func (s *aclState) apply(m oplog.Message) {
+	create := m.OpType == oplog.OpType_OP_TYPE_CREATE
+	del := m.OpType == oplog.OpType_OP_TYPE_DELETE
+	switch v := m.Message.(type) {
+	case *store.Scope:
+		switch {
+		case create:
+			s.scopes[v.GetPublicId()] = v.GetParentId()
+		case del:
+			s.deleteScope(v.GetPublicId())
+		}
+	case *store.Role:
+		switch {
+		case create:
+			s.roles[v.GetPublicId()] = proto.Clone(v).(*store.Role)
+			s.setGrantScope(v.GetPublicId(), v.GetGrantScopeId())
+		case del:
+			s.deleteRole(v.GetPublicId())
+		default:
+			role, ok := s.roles[v.GetPublicId()]
+			if !ok {
+				return
+			}
+			applyUpdate(role, m)
+			for _, fd := range fieldsByPath(v, append(m.FieldMaskPaths, m.SetToNullPaths...)) {
+				if fd.Name() == "grant_scope_id" {
+					s.setGrantScope(role.GetPublicId(), role.GetGrantScopeId())
+				}
+			}
+		}
+	case *store.RoleGrant:
+		switch {
+		case create:
+			if s.grants[v.GetRoleId()] == nil {
+				s.grants[v.GetRoleId()] = make(map[string]bool)
+			}
+			s.grants[v.GetRoleId()][v.GetCanonicalGrant()] = true
+		case del:
+			delete(s.grants[v.GetRoleId()], v.GetCanonicalGrant())
+		}
+	case *store.RoleGrantScope:
+		switch {
+		case create:
+			s.addGrantScope(v.GetRoleId(), v.GetScopeIdOrSpecial())
+		case del:
+			delete(s.grantScopes[v.GetRoleId()], s.normalizeGrantScope(v.GetRoleId(), v.GetScopeIdOrSpecial()))
+		}
+	case *store.UserRole:
+		s.applyPrincipalRole(m.OpType, v.GetRoleId(), v.GetPrincipalId(), v.GetNotBefore(), v.GetNotAfter())
+	case *store.GroupRole:
+		s.applyPrincipalRole(m.OpType, v.GetRoleId(), v.GetPrincipalId(), v.GetNotBefore(), v.GetNotAfter())
+	case *store.Group:
+		switch {
+		case create:
+			s.groups[v.GetPublicId()] = v.GetScopeId()
+		case del:
+			s.deleteGroup(v.GetPublicId())
+		}
+	case *store.GroupMemberUser:
+		switch {
+		case create:
+			if s.members[v.GetGroupId()] == nil {
+				s.members[v.GetGroupId()] = make(map[string]bool)
+			}
+			s.members[v.GetGroupId()][v.GetMemberId()] = true
+		case del:
+			delete(s.members[v.GetGroupId()], v.GetMemberId())
+		}
+	case *store.User:
+		switch {
+		case create:
+			s.users[v.GetPublicId()] = v.GetScopeId()
+		case del:
+			s.deleteUser(v.GetPublicId())
+		}
+	}
+}
+
+func (s *aclState) applyPrincipalRole(opType oplog.OpType, roleId, principalId string, notBefore, notAfter *timestamp.Timestamp) {
+	switch opType {
+	case oplog.OpType_OP_TYPE_CREATE:
+		if s.principals[roleId] == nil {
+			s.principals[roleId] = make(map[string]principalRoleState)
+		}
+		s.principals[roleId][principalId] = principalRoleState{notBefore: notBefore, notAfter: notAfter}
+	case oplog.OpType_OP_TYPE_DELETE:
+		delete(s.principals[roleId], principalId)
+	}
+}
+
+// normalizeGrantScope returns the grant scope as stored for the role: a grant
+// scope of the role's own scope is stored as this.
+func (s *aclState) normalizeGrantScope(roleId, grantScope string) string {
+	if role, ok := s.roles[roleId]; ok && (grantScope == "" || grantScope == role.GetScopeId()) {
+		return globals.GrantScopeThis
+	}
+	return grantScope
+}
+
+func (s *aclState) addGrantScope(roleId, grantScope string) {
+	if s.grantScopes[roleId] == nil {
+		s.grantScopes[roleId] = make(map[string]bool)
+	}
+	s.grantScopes[roleId][s.normalizeGrantScope(roleId, grantScope)] = true
+}
+
+// setGrantScope replaces the grant scopes of the role, as setting the
+// grant_scope_id of a role does.
+func (s *aclState) setGrantScope(roleId, grantScopeId string) {
+	delete(s.grantScopes, roleId)
+	s.addGrantScope(roleId, grantScopeId)
+}
+
+func (s *aclState) deleteRole(roleId string) {
+	delete(s.roles, roleId)
+	delete(s.grants, roleId)
+	delete(s.grantScopes, roleId)
+	delete(s.principals, roleId)
+}
+
+func (s *aclState) deleteGroup(groupId string) {
+	delete(s.groups, groupId)
+	delete(s.members, groupId)
+	for _, principals := range s.principals {
+		delete(principals, groupId)
+	}
+}
+
+func (s *aclState) deleteUser(userId string) {
+	delete(s.users, userId)
+	for _, members := range s.members {
+		delete(members, userId)
+	}
+	for _, principals := range s.principals {
+		delete(principals, userId)
+	}
+}
+
+// deleteScope removes the scope, its child scopes and everything within them,
+// as well as the grant scopes referencing them.
+func (s *aclState) deleteScope(scopeId string) {
+	for id, parentId := range s.scopes {
+		if parentId == scopeId {
+			s.deleteScope(id)
+		}
+	}
+	delete(s.scopes, scopeId)
+	for id, role := range s.roles {
+		if role.GetScopeId() == scopeId {
+			s.deleteRole(id)
+		}
+	}
+	for id, groupScopeId := range s.groups {
+		if groupScopeId == scopeId {
+			s.deleteGroup(id)
+		}
+	}
+	for id, userScopeId := range s.users {
+		if userScopeId == scopeId {
+			s.deleteUser(id)
+		}
+	}
+	for _, grantScopes := range s.grantScopes {
+		delete(grantScopes, scopeId)
+	}
+}
+
+// grantsForUser returns the grants of the user at the given time, sorted by
+// role, grant scope and grant.
+func (s *aclState) grantsForUser(userId string, at time.Time) []perms.GrantTuple {
+	principalIds := map[string]bool{globals.AnonymousUserId: true}
+	if userId != globals.AnonymousUserId {
+		principalIds[globals.AnyAuthenticatedUserId] = true
+		principalIds[userId] = true
+		for groupId, members := range s.members {
+			if members[userId] {
+				principalIds[groupId] = true
+			}
+		}
+	}
+
+	var grants []perms.GrantTuple
+	for roleId, principals := range s.principals {
+		role, ok := s.roles[roleId]
+		if !ok {
+			continue
+		}
+		var assigned bool
+		for principalId, pr := range principals {
+			if principalIds[principalId] && pr.inEffect(at) {
+				assigned = true
+				break
+			}
+		}
+		if !assigned {
+			continue
+		}
+		for grantScope := range s.grantScopes[roleId] {
+			if grantScope == globals.GrantScopeThis {
+				grantScope = role.GetScopeId()
+			}
+			for grant := range s.grants[roleId] {
+				grants = append(grants, perms.GrantTuple{
+					RoleId:       roleId,
+					RoleScopeId:  role.GetScopeId(),
+					GrantScopeId: grantScope,
+					Grant:        grant,
+				})
+			}
+		}
+	}
+	sort.Slice(grants, func(i, j int) bool {
+		switch {
+		case grants[i].RoleId != grants[j].RoleId:
+			return grants[i].RoleId < grants[j].RoleId
+		case grants[i].GrantScopeId != grants[j].GrantScopeId:
+			return grants[i].GrantScopeId < grants[j].GrantScopeId
+		}
+		return grants[i].Grant < grants[j].Grant
+	})
+	return grants
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/password"
+	authStore "github.com/hashicorp/boundary/internal/auth/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ListHistory_Accounts(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, repo)
+	user := iam.TestUser(t, repo, org.GetPublicId())
+	authMethod := password.TestAuthMethods(t, conn, org.GetPublicId(), 1)[0]
+	acct := password.TestAccount(t, conn, authMethod.GetPublicId(), "name1")
+
+	_, err := repo.AddUserAccounts(ctx, user.GetPublicId(), user.GetVersion(), []string{acct.GetPublicId()})
+	require.NoError(err)
+	_, err = repo.DeleteUserAccounts(ctx, user.GetPublicId(), user.GetVersion()+1, []string{acct.GetPublicId()})
+	require.NoError(err)
+
+	got, err := repo.ListHistory(ctx, user.GetPublicId())
+	require.NoError(err)
+	var accountChanges []*iam.HistoryChange
+	for _, e := range got {
+		for _, c := range e.Changes {
+			if c.Type == "account" {
+				accountChanges = append(accountChanges, c)
+			}
+		}
+	}
+	require.Len(accountChanges, 2)
+	assert.Equal([]string{"iam_user_id"}, accountChanges[0].UpdatedFields)
+	assert.Equal(acct.GetPublicId(), accountChanges[0].Values.(*authStore.Account).GetPublicId())
+	assert.Equal(user.GetPublicId(), accountChanges[0].Values.(*authStore.Account).GetIamUserId())
+	assert.Equal([]string{"iam_user_id"}, accountChanges[1].UpdatedFields)
+}
@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ListHistory(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	admin := TestUser(t, repo, org.GetPublicId())
+	ctx := requests.NewRequestContext(context.Background(), requests.WithUserId(admin.GetPublicId()))
+
+	user := TestUser(t, repo, org.GetPublicId())
+	r, err := NewRole(org.GetPublicId(), WithName("before"))
+	require.NoError(t, err)
+	role, err := repo.CreateRole(ctx, r)
+	require.NoError(t, err)
+	_, err = repo.AddRoleGrants(ctx, role.GetPublicId(), role.GetVersion(), []string{"ids=*;type=*;actions=read"})
+	require.NoError(t, err)
+	_, err = repo.AddPrincipalRoles(ctx, role.GetPublicId(), role.GetVersion()+1, []string{user.GetPublicId()})
+	require.NoError(t, err)
+	role.Name = "after"
+	_, _, _, _, err = repo.UpdateRole(ctx, role, role.GetVersion()+2, []string{"Name"})
+	require.NoError(t, err)
+
+	t.Run("role", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListHistory(ctx, role.GetPublicId())
+		require.NoError(err)
+		require.Len(got, 4)
+
+		var types []string
+		for _, e := range got {
+			assert.Equal(admin.GetPublicId(), e.ActorId)
+			for _, c := range e.Changes {
+				types = append(types, c.Operation+" "+c.Type)
+			}
+		}
+		assert.Equal([]string{"create role", "create role-grant", "create user-role", "update role"}, types)
+
+		update := got[3].Changes[0]
+		assert.Equal([]string{"name"}, update.UpdatedFields)
+		assert.Equal("after", update.Values.(*store.Role).GetName())
+		require.NotNil(update.PreviousValues)
+		assert.Equal("before", update.PreviousValues.(*store.Role).GetName())
+	})
+
+	t.Run("user", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListHistory(ctx, user.GetPublicId())
+		require.NoError(err)
+		require.Len(got, 2)
+		assert.Equal("user", got[0].Changes[0].Type)
+		require.Len(got[1].Changes, 1)
+		assert.Equal("user-role", got[1].Changes[0].Type)
+		assert.Equal(role.GetPublicId(), got[1].Changes[0].Values.(*store.UserRole).GetRoleId())
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		first, err := repo.ListHistory(ctx, role.GetPublicId(), WithLimit(3))
+		require.NoError(err)
+		require.Len(first, 3)
+		next, err := repo.ListHistory(ctx, role.GetPublicId(), WithLimit(3), WithAfterHistoryId(first[2].Id))
+		require.NoError(err)
+		require.Len(next, 1)
+		assert.Equal("update role", next[0].Changes[0].Operation+" "+next[0].Changes[0].Type)
+		last, err := repo.ListHistory(ctx, role.GetPublicId(), WithAfterHistoryId(next[0].Id))
+		require.NoError(err)
+		assert.Empty(last)
+	})
+
+	t.Run("previous-values-of-later-page", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		all, err := repo.ListHistory(ctx, role.GetPublicId())
+		require.NoError(err)
+		require.Len(all, 4)
+		for _, opt := range []Option{WithAfterHistoryId(all[2].Id), WithNotBefore(all[3].CreateTime)} {
+			got, err := repo.ListHistory(ctx, role.GetPublicId(), opt)
+			require.NoError(err)
+			require.Len(got, 1)
+			require.NotNil(got[0].Changes[0].PreviousValues)
+			assert.Equal("before", got[0].Changes[0].PreviousValues.(*store.Role).GetName())
+		}
+	})
+
+	t.Run("time-range", func(t *testing.T) {
+		all, err := repo.ListHistory(ctx, role.GetPublicId())
+		require.NoError(t, err)
+		got, err := repo.ListHistory(ctx, role.GetPublicId(), WithNotBefore(all[3].CreateTime))
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, all[3].Id, got[0].Id)
+		got, err = repo.ListHistory(ctx, role.GetPublicId(), WithNotAfter(all[0].CreateTime))
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := repo.ListHistory(ctx, "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = repo.ListHistory(ctx, org.GetPublicId())
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
+func TestRepository_GrantsForUserAt(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	user := TestUser(t, repo, org.GetPublicId())
+
+	r, err := NewRole(org.GetPublicId())
+	require.NoError(t, err)
+	role, err := repo.CreateRole(ctx, r)
+	require.NoError(t, err)
+	_, err = repo.AddRoleGrants(ctx, role.GetPublicId(), role.GetVersion(), []string{"ids=*;type=*;actions=read"})
+	require.NoError(t, err)
+	beforeAssignment := time.Now()
+
+	g, err := NewGroup(org.GetPublicId())
+	require.NoError(t, err)
+	group, err := repo.CreateGroup(ctx, g)
+	require.NoError(t, err)
+	_, err = repo.AddGroupMembers(ctx, group.GetPublicId(), group.GetVersion(), []string{user.GetPublicId()})
+	require.NoError(t, err)
+	_, err = repo.AddPrincipalRoles(ctx, role.GetPublicId(), role.GetVersion()+1, []string{group.GetPublicId()})
+	require.NoError(t, err)
+	_, _, err = repo.SetRoleGrantScopes(ctx, role.GetPublicId(), role.GetVersion()+2, []string{proj.GetPublicId()})
+	require.NoError(t, err)
+	assigned := time.Now()
+
+	_, err = repo.DeleteGroup(ctx, group.GetPublicId())
+	require.NoError(t, err)
+
+	t.Run("before-assignment", func(t *testing.T) {
+		got, err := repo.GrantsForUserAt(ctx, user.GetPublicId(), beforeAssignment)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("assigned", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.GrantsForUserAt(ctx, user.GetPublicId(), assigned)
+		require.NoError(err)
+		require.Len(got, 1)
+		assert.Equal(role.GetPublicId(), got[0].RoleId)
+		assert.Equal(org.GetPublicId(), got[0].RoleScopeId)
+		assert.Equal(proj.GetPublicId(), got[0].GrantScopeId)
+		assert.Equal("ids=*;type=*;actions=read", got[0].Grant)
+	})
+
+	t.Run("after-group-deleted", func(t *testing.T) {
+		got, err := repo.GrantsForUserAt(ctx, user.GetPublicId(), time.Now())
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := repo.GrantsForUserAt(ctx, "", time.Now())
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = repo.GrantsForUserAt(ctx, user.GetPublicId(), time.Time{})
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
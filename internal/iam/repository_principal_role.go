@@ -19,26 +19,28 @@ import (
 // groupIds) to a role (roleId).  The role's current db version must match the
 // roleVersion or an error will be returned.  The list of current PrincipalRoles
 // after the adds will be returned on success. Zero is not a valid value for
-// the WithVersion option and will return an error.
-func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleVersion uint32, principalIds []string, _ ...Option) ([]*PrincipalRole, error) {
+// the WithVersion option and will return an error. The WithNotBefore and
+// WithNotAfter options bound the new assignments in time.
+func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleVersion uint32, principalIds []string, opt ...Option) ([]*PrincipalRole, error) {
 	const op = "iam.(Repository).AddPrincipalRoles"
+	ctx = db.NewOpContext(ctx, op)
 	if roleId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing role id")
 	}
 	if roleVersion == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing version")
 	}
-	userIds, groupIds, managedGroupIds, err := splitPrincipals(ctx, principalIds)
+	userIds, groupIds, managedGroupIds, serviceAccountIds, err := splitPrincipals(ctx, principalIds)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	if len(userIds) == 0 && len(groupIds) == 0 && len(managedGroupIds) == 0 {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing any of users, groups, or managed groups to add")
+	if len(userIds) == 0 && len(groupIds) == 0 && len(managedGroupIds) == 0 && len(serviceAccountIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing any of users, groups, managed groups, or service accounts to add")
 	}
 
 	newUserRoles := make([]any, 0, len(userIds))
 	for _, id := range userIds {
-		usrRole, err := NewUserRole(roleId, id)
+		usrRole, err := NewUserRole(roleId, id, opt...)
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory user role"))
 		}
@@ -46,7 +48,7 @@ func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleV
 	}
 	newGrpRoles := make([]any, 0, len(groupIds))
 	for _, id := range groupIds {
-		grpRole, err := NewGroupRole(roleId, id)
+		grpRole, err := NewGroupRole(roleId, id, opt...)
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory group role"))
 		}
@@ -54,12 +56,20 @@ func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleV
 	}
 	newManagedGrpRoles := make([]any, 0, len(managedGroupIds))
 	for _, id := range managedGroupIds {
-		managedGrpRole, err := NewManagedGroupRole(roleId, id)
+		managedGrpRole, err := NewManagedGroupRole(roleId, id, opt...)
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory managed group role"))
 		}
 		newManagedGrpRoles = append(newManagedGrpRoles, managedGrpRole)
 	}
+	newSvcAcctRoles := make([]any, 0, len(serviceAccountIds))
+	for _, id := range serviceAccountIds {
+		svcAcctRole, err := NewServiceAccountRole(roleId, id, opt...)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory service account role"))
+		}
+		newSvcAcctRoles = append(newSvcAcctRoles, svcAcctRole)
+	}
 
 	role := allocRole()
 	role.PublicId = roleId
@@ -117,12 +127,20 @@ func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleV
 				}
 				msgs = append(msgs, managedGrpOplogMsgs...)
 			}
+			if len(newSvcAcctRoles) > 0 {
+				svcAcctOplogMsgs := make([]*oplog.Message, 0, len(newSvcAcctRoles))
+				if err := w.CreateItems(ctx, newSvcAcctRoles, db.NewOplogMsgs(&svcAcctOplogMsgs)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add service accounts"))
+				}
+				msgs = append(msgs, svcAcctOplogMsgs...)
+			}
 			metadata := oplog.Metadata{
 				"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
 				"scope-id":           []string{scope.PublicId},
 				"scope-type":         []string{scope.Type},
 				"resource-public-id": []string{roleId},
 			}
+			addHistoryMetadata(metadata, msgs)
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, roleTicket, metadata, msgs); err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
 			}
@@ -154,6 +172,7 @@ func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleV
 // return an error.
 func (r *Repository) SetPrincipalRoles(ctx context.Context, roleId string, roleVersion uint32, principalIds []string, _ ...Option) ([]*PrincipalRole, int, error) {
 	const op = "iam.(Repository).SetPrincipalRoles"
+	ctx = db.NewOpContext(ctx, op)
 	if roleId == "" {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing role id")
 	}
@@ -166,11 +185,11 @@ func (r *Repository) SetPrincipalRoles(ctx context.Context, roleId string, roleV
 	// it's "safe" to do this lookup outside the DoTx transaction because we
 	// have a roleVersion so the principals can’t change without the version
 	// changing.
-	userIds, groupIds, managedGroupIds, err := splitPrincipals(ctx, principalIds)
+	userIds, groupIds, managedGroupIds, serviceAccountIds, err := splitPrincipals(ctx, principalIds)
 	if err != nil {
 		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
 	}
-	toSet, err := r.PrincipalsToSet(ctx, &role, userIds, groupIds, managedGroupIds)
+	toSet, err := r.PrincipalsToSet(ctx, &role, userIds, groupIds, managedGroupIds, serviceAccountIds)
 	if err != nil {
 		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
 	}
@@ -225,7 +244,8 @@ func (r *Repository) SetPrincipalRoles(ctx context.Context, roleId string, roleV
 
 			if len(toSet.DeleteUserRoles) > 0 ||
 				len(toSet.DeleteGroupRoles) > 0 ||
-				len(toSet.DeleteManagedGroupRoles) > 0 {
+				len(toSet.DeleteManagedGroupRoles) > 0 ||
+				len(toSet.DeleteServiceAccountRoles) > 0 {
 				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
 				if len(toSet.DeleteUserRoles) > 0 {
 					userOplogMsgs := make([]*oplog.Message, 0, len(toSet.DeleteUserRoles))
@@ -263,10 +283,23 @@ func (r *Repository) SetPrincipalRoles(ctx context.Context, roleId string, roleV
 					totalRowsAffected += rowsDeleted
 					msgs = append(msgs, managedGrpOplogMsgs...)
 				}
+				if len(toSet.DeleteServiceAccountRoles) > 0 {
+					svcAcctOplogMsgs := make([]*oplog.Message, 0, len(toSet.DeleteServiceAccountRoles))
+					rowsDeleted, err := w.DeleteItems(ctx, toSet.DeleteServiceAccountRoles, db.NewOplogMsgs(&svcAcctOplogMsgs))
+					if err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete service accounts"))
+					}
+					if rowsDeleted != len(toSet.DeleteServiceAccountRoles) {
+						return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("service account roles deleted %d did not match request for %d", rowsDeleted, len(toSet.DeleteServiceAccountRoles)))
+					}
+					totalRowsAffected += rowsDeleted
+					msgs = append(msgs, svcAcctOplogMsgs...)
+				}
 			}
 			if len(toSet.AddUserRoles) > 0 ||
 				len(toSet.AddGroupRoles) > 0 ||
-				len(toSet.AddManagedGroupRoles) > 0 {
+				len(toSet.AddManagedGroupRoles) > 0 ||
+				len(toSet.AddServiceAccountRoles) > 0 {
 				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
 				if len(toSet.AddUserRoles) > 0 {
 					userOplogMsgs := make([]*oplog.Message, 0, len(toSet.AddUserRoles))
@@ -292,7 +325,16 @@ func (r *Repository) SetPrincipalRoles(ctx context.Context, roleId string, roleV
 					totalRowsAffected += len(toSet.AddManagedGroupRoles)
 					msgs = append(msgs, managedGrpOplogMsgs...)
 				}
+				if len(toSet.AddServiceAccountRoles) > 0 {
+					svcAcctOplogMsgs := make([]*oplog.Message, 0, len(toSet.AddServiceAccountRoles))
+					if err := w.CreateItems(ctx, toSet.AddServiceAccountRoles, db.NewOplogMsgs(&svcAcctOplogMsgs)); err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add service accounts"))
+					}
+					totalRowsAffected += len(toSet.AddServiceAccountRoles)
+					msgs = append(msgs, svcAcctOplogMsgs...)
+				}
 			}
+			addHistoryMetadata(metadata, msgs)
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, roleTicket, metadata, msgs); err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
 			}
@@ -322,15 +364,16 @@ func (r *Repository) SetPrincipalRoles(ctx context.Context, roleId string, roleV
 // and will return an error.
 func (r *Repository) DeletePrincipalRoles(ctx context.Context, roleId string, roleVersion uint32, principalIds []string, _ ...Option) (int, error) {
 	const op = "iam.(Repository).DeletePrincipalRoles"
+	ctx = db.NewOpContext(ctx, op)
 	if roleId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing role id")
 	}
-	userIds, groupIds, managedGroupIds, err := splitPrincipals(ctx, principalIds)
+	userIds, groupIds, managedGroupIds, serviceAccountIds, err := splitPrincipals(ctx, principalIds)
 	if err != nil {
 		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
 	}
-	if len(userIds) == 0 && len(groupIds) == 0 && len(managedGroupIds) == 0 {
-		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing any of users, groups, or managed groups to delete")
+	if len(userIds) == 0 && len(groupIds) == 0 && len(managedGroupIds) == 0 && len(serviceAccountIds) == 0 {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing any of users, groups, managed groups, or service accounts to delete")
 	}
 	if roleVersion == 0 {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
@@ -362,6 +405,14 @@ func (r *Repository) DeletePrincipalRoles(ctx context.Context, roleId string, ro
 		}
 		deleteManagedGrpRoles = append(deleteManagedGrpRoles, managedGrpRole)
 	}
+	deleteSvcAcctRoles := make([]any, 0, len(serviceAccountIds))
+	for _, id := range serviceAccountIds {
+		svcAcctRole, err := NewServiceAccountRole(roleId, id)
+		if err != nil {
+			return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory service account role"))
+		}
+		deleteSvcAcctRoles = append(deleteSvcAcctRoles, svcAcctRole)
+	}
 
 	scope, err := role.GetScope(ctx, r.reader)
 	if err != nil {
@@ -431,12 +482,25 @@ func (r *Repository) DeletePrincipalRoles(ctx context.Context, roleId string, ro
 				totalRowsDeleted += rowsDeleted
 				msgs = append(msgs, managedGrpOplogMsgs...)
 			}
+			if len(deleteSvcAcctRoles) > 0 {
+				svcAcctOplogMsgs := make([]*oplog.Message, 0, len(deleteSvcAcctRoles))
+				rowsDeleted, err := w.DeleteItems(ctx, deleteSvcAcctRoles, db.NewOplogMsgs(&svcAcctOplogMsgs))
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete service accounts"))
+				}
+				if rowsDeleted != len(deleteSvcAcctRoles) {
+					return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("service account roles deleted %d did not match request for %d", rowsDeleted, len(deleteSvcAcctRoles)))
+				}
+				totalRowsDeleted += rowsDeleted
+				msgs = append(msgs, svcAcctOplogMsgs...)
+			}
 			metadata := oplog.Metadata{
 				"op-type":            []string{oplog.OpType_OP_TYPE_DELETE.String()},
 				"scope-id":           []string{scope.PublicId},
 				"scope-type":         []string{scope.Type},
 				"resource-public-id": []string{roleId},
 			}
+			addHistoryMetadata(metadata, msgs)
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, roleTicket, metadata, msgs); err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
 			}
@@ -464,13 +528,27 @@ func (r *Repository) ListPrincipalRoles(ctx context.Context, roleId string, opt
 	return principals, nil
 }
 
+// ListExpiredPrincipalRoles returns the principal roles, across all roles,
+// whose not after time has passed. No options are currently supported.
+func (r *Repository) ListExpiredPrincipalRoles(ctx context.Context, _ ...Option) ([]*PrincipalRole, error) {
+	const op = "iam.(Repository).ListExpiredPrincipalRoles"
+	ctx = db.NewOpContext(ctx, op)
+	var roles []*PrincipalRole
+	if err := r.reader.SearchWhere(ctx, &roles, "not_after <= current_timestamp", nil, db.WithLimit(-1)); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to lookup expired principal roles"))
+	}
+	return roles, nil
+}
+
 type PrincipalSet struct {
-	AddUserRoles            []any
-	AddGroupRoles           []any
-	AddManagedGroupRoles    []any
-	DeleteUserRoles         []any
-	DeleteGroupRoles        []any
-	DeleteManagedGroupRoles []any
+	AddUserRoles              []any
+	AddGroupRoles             []any
+	AddManagedGroupRoles      []any
+	AddServiceAccountRoles    []any
+	DeleteUserRoles           []any
+	DeleteGroupRoles          []any
+	DeleteManagedGroupRoles   []any
+	DeleteServiceAccountRoles []any
 	// unchangedPrincipalRoles is set iff there are no changes, that is, the
 	// length of all other members is zero
 	UnchangedPrincipalRoles []*PrincipalRole
@@ -478,7 +556,7 @@ type PrincipalSet struct {
 
 // TODO: Should this be moved inside the transaction, at this point?
 // PrincipalsToSet sets principals on a role from the given lists.
-func (r *Repository) PrincipalsToSet(ctx context.Context, role *Role, userIds, groupIds, managedGroupIds []string) (*PrincipalSet, error) {
+func (r *Repository) PrincipalsToSet(ctx context.Context, role *Role, userIds, groupIds, managedGroupIds, serviceAccountIds []string) (*PrincipalSet, error) {
 	const op = "iam.(Repository).PrincipalsToSet"
 	// TODO(mgaffney) 08/2020: Use SQL to calculate changes.
 	if role == nil {
@@ -491,6 +569,7 @@ func (r *Repository) PrincipalsToSet(ctx context.Context, role *Role, userIds, g
 	existingUsers := map[string]*PrincipalRole{}
 	existingGroups := map[string]*PrincipalRole{}
 	existingManagedGroups := map[string]*PrincipalRole{}
+	existingServiceAccounts := map[string]*PrincipalRole{}
 	for _, p := range existing {
 		switch p.GetType() {
 		case UserRoleType.String():
@@ -499,6 +578,8 @@ func (r *Repository) PrincipalsToSet(ctx context.Context, role *Role, userIds, g
 			existingGroups[p.PrincipalId] = p
 		case ManagedGroupRoleType.String():
 			existingManagedGroups[p.PrincipalId] = p
+		case ServiceAccountRoleType.String():
+			existingServiceAccounts[p.PrincipalId] = p
 		default:
 			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%s is unknown principal type %s", p.PrincipalId, p.GetType()))
 		}
@@ -539,6 +620,18 @@ func (r *Repository) PrincipalsToSet(ctx context.Context, role *Role, userIds, g
 			newManagedGrpRoles = append(newManagedGrpRoles, managedGrpRole)
 		}
 	}
+	var newSvcAcctRoles []any
+	serviceAccountIdsMap := map[string]struct{}{}
+	for _, id := range serviceAccountIds {
+		serviceAccountIdsMap[id] = struct{}{}
+		if _, ok := existingServiceAccounts[id]; !ok {
+			svcAcctRole, err := NewServiceAccountRole(role.PublicId, id)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory service account role for add"))
+			}
+			newSvcAcctRoles = append(newSvcAcctRoles, svcAcctRole)
+		}
+	}
 	var deleteUserRoles []any
 	for _, p := range existingUsers {
 		if _, ok := userIdsMap[p.PrincipalId]; !ok {
@@ -569,32 +662,51 @@ func (r *Repository) PrincipalsToSet(ctx context.Context, role *Role, userIds, g
 			deleteManagedGrpRoles = append(deleteManagedGrpRoles, managedGrpRole)
 		}
 	}
+	var deleteSvcAcctRoles []any
+	for _, p := range existingServiceAccounts {
+		if _, ok := serviceAccountIdsMap[p.PrincipalId]; !ok {
+			svcAcctRole, err := NewServiceAccountRole(p.GetRoleId(), p.GetPrincipalId())
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory service account role for delete"))
+			}
+			deleteSvcAcctRoles = append(deleteSvcAcctRoles, svcAcctRole)
+		}
+	}
 
 	toSet := &PrincipalSet{
-		AddUserRoles:            newUserRoles,
-		AddGroupRoles:           newGrpRoles,
-		AddManagedGroupRoles:    newManagedGrpRoles,
-		DeleteUserRoles:         deleteUserRoles,
-		DeleteGroupRoles:        deleteGrpRoles,
-		DeleteManagedGroupRoles: deleteManagedGrpRoles,
+		AddUserRoles:              newUserRoles,
+		AddGroupRoles:             newGrpRoles,
+		AddManagedGroupRoles:      newManagedGrpRoles,
+		AddServiceAccountRoles:    newSvcAcctRoles,
+		DeleteUserRoles:           deleteUserRoles,
+		DeleteGroupRoles:          deleteGrpRoles,
+		DeleteManagedGroupRoles:   deleteManagedGrpRoles,
+		DeleteServiceAccountRoles: deleteSvcAcctRoles,
 	}
 
 	if len(toSet.AddUserRoles) == 0 &&
 		len(toSet.AddGroupRoles) == 0 &&
 		len(toSet.AddManagedGroupRoles) == 0 &&
+		len(toSet.AddServiceAccountRoles) == 0 &&
 		len(toSet.DeleteUserRoles) == 0 &&
 		len(toSet.DeleteGroupRoles) == 0 &&
-		len(toSet.DeleteManagedGroupRoles) == 0 {
+		len(toSet.DeleteManagedGroupRoles) == 0 &&
+		len(toSet.DeleteServiceAccountRoles) == 0 {
 		toSet.UnchangedPrincipalRoles = existing
 	}
 
 	return toSet, nil
 }
 
-func splitPrincipals(ctx context.Context, principals []string) (users, groups, managedGroups []string, retErr error) {
+func splitPrincipals(ctx context.Context, principals []string) (users, groups, managedGroups, serviceAccounts []string, retErr error) {
 	const op = "iam.splitPrincipals"
 	for _, principal := range principals {
 		switch {
+		case strings.HasPrefix(principal, globals.ServiceAccountPrefix+"_"):
+			if serviceAccounts == nil {
+				serviceAccounts = make([]string, 0, len(principals))
+			}
+			serviceAccounts = append(serviceAccounts, principal)
 		case strings.HasPrefix(principal, globals.UserPrefix):
 			if users == nil {
 				users = make([]string, 0, len(principals))
@@ -611,7 +723,7 @@ func splitPrincipals(ctx context.Context, principals []string) (users, groups, m
 			}
 			managedGroups = append(managedGroups, principal)
 		default:
-			return nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid principal ID %q", principal))
+			return nil, nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid principal ID %q", principal))
 		}
 	}
 
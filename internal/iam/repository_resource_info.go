@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// ResourceInfo describes where a resource lives, which is what is needed to
+// evaluate grants against it without loading the resource itself.
+type ResourceInfo struct {
+	// ScopeId is the scope that contains the resource. For a scope this is its
+	// parent scope.
+	ScopeId string
+	// ParentScopeId is the parent of ScopeId, empty for the global scope.
+	ParentScopeId string
+	// Pin is the id of the parent resource for resources that live in a
+	// collection of another resource, e.g. the host catalog of a host set.
+	Pin string
+	// Name is the name of the resource. It is only populated for resources
+	// that can be matched by name in grants.
+	Name string
+}
+
+// LookupResourceInfo returns the ResourceInfo of the resource with the given
+// public id. It returns nil if no such resource exists. No options are
+// currently supported.
+func (r *Repository) LookupResourceInfo(ctx context.Context, resourceId string, _ ...Option) (*ResourceInfo, error) {
+	const op = "iam.(Repository).LookupResourceInfo"
+	ctx = db.NewOpContext(ctx, op)
+	if resourceId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing resource id")
+	}
+	rows, err := r.reader.Query(ctx, lookupResourceInfoQuery, []any{sql.Named("resource_id", resourceId)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var info *ResourceInfo
+	for rows.Next() {
+		info = &ResourceInfo{}
+		if err := r.reader.ScanRows(ctx, rows, info); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return info, nil
+}
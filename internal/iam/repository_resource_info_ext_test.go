@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/db"
+	hoststatic "github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_LookupResourceInfo(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := iam.TestRepo(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, repo)
+
+	user := iam.TestUser(t, repo, org.GetPublicId())
+	tar := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "test-target")
+	hc := hoststatic.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	hs := hoststatic.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+
+	tests := []struct {
+		name       string
+		resourceId string
+		want       *iam.ResourceInfo
+	}{
+		{
+			name:       "global",
+			resourceId: globals.GlobalPrefix,
+			want:       &iam.ResourceInfo{ScopeId: globals.GlobalPrefix},
+		},
+		{
+			name:       "project",
+			resourceId: proj.GetPublicId(),
+			want:       &iam.ResourceInfo{ScopeId: org.GetPublicId(), ParentScopeId: globals.GlobalPrefix},
+		},
+		{
+			name:       "user",
+			resourceId: user.GetPublicId(),
+			want:       &iam.ResourceInfo{ScopeId: org.GetPublicId(), ParentScopeId: globals.GlobalPrefix},
+		},
+		{
+			name:       "target",
+			resourceId: tar.GetPublicId(),
+			want:       &iam.ResourceInfo{ScopeId: proj.GetPublicId(), ParentScopeId: org.GetPublicId(), Name: "test-target"},
+		},
+		{
+			name:       "host set",
+			resourceId: hs.GetPublicId(),
+			want:       &iam.ResourceInfo{ScopeId: proj.GetPublicId(), ParentScopeId: org.GetPublicId(), Pin: hc.GetPublicId()},
+		},
+		{
+			name:       "not found",
+			resourceId: "ttcp_doesntexist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.LookupResourceInfo(ctx, tt.resourceId)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("missing id", func(t *testing.T) {
+		_, err := repo.LookupResourceInfo(ctx, "")
+		require.Error(t, err)
+	})
+}
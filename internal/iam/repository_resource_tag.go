@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// AddResourceTags adds tags to the resource (resourceId), which must be a
+// target, host catalog or credential store. Tags the resource already carries
+// are ignored. It returns the resulting tags of the resource. No options are
+// currently supported.
+func (r *Repository) AddResourceTags(ctx context.Context, resourceId string, tags map[string][]string, _ ...Option) (map[string][]string, error) {
+	const op = "iam.(Repository).AddResourceTags"
+	ctx = db.NewOpContext(ctx, op)
+	switch {
+	case resourceId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing resource id")
+	case len(tags) == 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no tags provided")
+	}
+
+	var current map[string][]string
+	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, func(reader db.Reader, w db.Writer) error {
+		existing, err := listResourceTags(ctx, reader, resourceId)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		newTags, err := newResourceTags(ctx, resourceId, tags, func(k, v string) bool { return !hasTag(existing, k, v) })
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if len(newTags) > 0 {
+			if err := w.CreateItems(ctx, newTags); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add resource tags"))
+			}
+		}
+		current, err = listResourceTags(ctx, reader, resourceId)
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to retrieve current resource tags after add"))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return current, nil
+}
+
+// SetResourceTags clears the tags of the resource (resourceId) and sets them
+// from the input parameters. It returns the resulting tags of the resource. No
+// options are currently supported.
+func (r *Repository) SetResourceTags(ctx context.Context, resourceId string, tags map[string][]string, _ ...Option) (map[string][]string, error) {
+	const op = "iam.(Repository).SetResourceTags"
+	ctx = db.NewOpContext(ctx, op)
+	if resourceId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing resource id")
+	}
+	newTags, err := newResourceTags(ctx, resourceId, tags, nil)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	var current map[string][]string
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, func(reader db.Reader, w db.Writer) error {
+		if _, err := w.Exec(ctx, deleteResourceTagsQuery, []any{resourceId}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete resource tags"))
+		}
+		if len(newTags) > 0 {
+			if err := w.CreateItems(ctx, newTags); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add resource tags during set"))
+			}
+		}
+		current, err = listResourceTags(ctx, reader, resourceId)
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to retrieve current resource tags after set"))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return current, nil
+}
+
+// DeleteResourceTags deletes tags from the resource (resourceId). Every tag
+// must be present on the resource or an error will be returned. It returns the
+// number of tags deleted. No options are currently supported.
+func (r *Repository) DeleteResourceTags(ctx context.Context, resourceId string, tags map[string][]string, _ ...Option) (int, error) {
+	const op = "iam.(Repository).DeleteResourceTags"
+	ctx = db.NewOpContext(ctx, op)
+	switch {
+	case resourceId == "":
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing resource id")
+	case len(tags) == 0:
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no tags provided")
+	}
+	deleteTags, err := newResourceTags(ctx, resourceId, tags, nil)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+
+	rowsDeleted := 0
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, func(reader db.Reader, w db.Writer) error {
+		rowsDeleted, err = w.DeleteItems(ctx, deleteTags)
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete resource tags"))
+		}
+		if rowsDeleted != len(deleteTags) {
+			return errors.New(ctx, errors.MultipleRecords, op,
+				fmt.Sprintf("unable to delete specified tag: tags deleted %d did not match request for %d", rowsDeleted, len(deleteTags)))
+		}
+		return nil
+	})
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	return rowsDeleted, nil
+}
+
+// ListResourceTags returns the tags of the given resources, keyed by resource
+// ID and then by tag key. Resources without tags are not included. No options
+// are currently supported.
+func (r *Repository) ListResourceTags(ctx context.Context, resourceIds []string, _ ...Option) (map[string]map[string][]string, error) {
+	const op = "iam.(Repository).ListResourceTags"
+	ctx = db.NewOpContext(ctx, op)
+	ret := make(map[string]map[string][]string)
+	if len(resourceIds) == 0 {
+		return ret, nil
+	}
+	var tags []*ResourceTag
+	if err := r.reader.SearchWhere(ctx, &tags, "resource_id in (?)", []any{resourceIds}); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to lookup resource tags"))
+	}
+	for _, t := range tags {
+		if ret[t.ResourceId] == nil {
+			ret[t.ResourceId] = make(map[string][]string)
+		}
+		ret[t.ResourceId][t.Key] = append(ret[t.ResourceId][t.Key], t.Value)
+	}
+	for _, m := range ret {
+		for _, values := range m {
+			sort.Strings(values)
+		}
+	}
+	return ret, nil
+}
+
+// listResourceTags returns the tags of a single resource using the given
+// reader, so that it can be used within transactions
+func listResourceTags(ctx context.Context, reader db.Reader, resourceId string) (map[string][]string, error) {
+	const op = "iam.listResourceTags"
+	var tags []*ResourceTag
+	if err := reader.SearchWhere(ctx, &tags, "resource_id = ?", []any{resourceId}); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to lookup resource tags"))
+	}
+	ret := make(map[string][]string, len(tags))
+	for _, t := range tags {
+		ret[t.Key] = append(ret[t.Key], t.Value)
+	}
+	for _, values := range ret {
+		sort.Strings(values)
+	}
+	return ret, nil
+}
+
+// newResourceTags creates in memory resource tags for the tags that pass
+// include, or for all tags if include is nil. Duplicate key/value pairs are
+// only returned once.
+func newResourceTags(ctx context.Context, resourceId string, tags map[string][]string, include func(k, v string) bool) ([]any, error) {
+	const op = "iam.newResourceTags"
+	seen := make(map[[2]string]bool)
+	ret := make([]any, 0, len(tags))
+	for k, values := range tags {
+		for _, v := range values {
+			if include != nil && !include(k, v) {
+				continue
+			}
+			if seen[[2]string{k, v}] {
+				continue
+			}
+			seen[[2]string{k, v}] = true
+			tag, err := NewResourceTag(ctx, resourceId, k, v)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory resource tag"))
+			}
+			ret = append(ret, tag)
+		}
+	}
+	return ret, nil
+}
+
+func hasTag(tags map[string][]string, key, value string) bool {
+	for _, v := range tags[key] {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/db"
+	hoststatic "github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ResourceTags(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := iam.TestRepo(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, repo)
+
+	tar := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "test-target")
+	hc := hoststatic.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	cs := static.TestCredentialStore(t, conn, wrapper, proj.GetPublicId())
+
+	for _, resourceId := range []string{tar.GetPublicId(), hc.GetPublicId(), cs.GetPublicId()} {
+		t.Run(resourceId, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			got, err := repo.AddResourceTags(ctx, resourceId, map[string][]string{"env": {"dev", "test"}})
+			require.NoError(err)
+			assert.Equal(map[string][]string{"env": {"dev", "test"}}, got)
+
+			// Adding a tag the resource already carries is not an error
+			got, err = repo.AddResourceTags(ctx, resourceId, map[string][]string{"env": {"dev"}, "team": {"db"}})
+			require.NoError(err)
+			assert.Equal(map[string][]string{"env": {"dev", "test"}, "team": {"db"}}, got)
+
+			deleted, err := repo.DeleteResourceTags(ctx, resourceId, map[string][]string{"env": {"test"}})
+			require.NoError(err)
+			assert.Equal(1, deleted)
+
+			// Deleting a tag the resource doesn't carry is an error
+			_, err = repo.DeleteResourceTags(ctx, resourceId, map[string][]string{"env": {"test"}})
+			require.Error(err)
+
+			got, err = repo.SetResourceTags(ctx, resourceId, map[string][]string{"region": {"eu"}})
+			require.NoError(err)
+			assert.Equal(map[string][]string{"region": {"eu"}}, got)
+
+			listed, err := repo.ListResourceTags(ctx, []string{resourceId})
+			require.NoError(err)
+			assert.Equal(map[string]map[string][]string{resourceId: {"region": {"eu"}}}, listed)
+
+			got, err = repo.SetResourceTags(ctx, resourceId, nil)
+			require.NoError(err)
+			assert.Empty(got)
+		})
+	}
+
+	t.Run("deleted-with-resource", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tar := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "deleted-target")
+		_, err := repo.AddResourceTags(ctx, tar.GetPublicId(), map[string][]string{"env": {"dev"}})
+		require.NoError(err)
+
+		targetRepo, err := target.NewRepository(ctx, db.New(conn), db.New(conn), kms.TestKms(t, conn, wrapper))
+		require.NoError(err)
+		_, err = targetRepo.DeleteTarget(ctx, tar.GetPublicId())
+		require.NoError(err)
+
+		listed, err := repo.ListResourceTags(ctx, []string{tar.GetPublicId()})
+		require.NoError(err)
+		assert.Empty(listed)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := repo.AddResourceTags(ctx, "", map[string][]string{"env": {"dev"}})
+		assert.Error(t, err)
+		_, err = repo.AddResourceTags(ctx, tar.GetPublicId(), nil)
+		assert.Error(t, err)
+		_, err = repo.AddResourceTags(ctx, tar.GetPublicId(), map[string][]string{"env": {""}})
+		assert.Error(t, err)
+		// Scopes don't support tags
+		_, err = repo.AddResourceTags(ctx, proj.GetPublicId(), map[string][]string{"env": {"dev"}})
+		assert.Error(t, err)
+		_, err = repo.DeleteResourceTags(ctx, tar.GetPublicId(), nil)
+		assert.Error(t, err)
+	})
+}
@@ -42,7 +42,14 @@ func (r *Repository) CreateRole(ctx context.Context, role *Role, _ ...Option) (*
 		}
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", c.PublicId)))
 	}
-	return resource.(*Role), nil
+	created := resource.(*Role)
+	// The initial grant scope is derived from the grant scope ID by the
+	// database
+	created.GrantScopes, err = r.ListRoleGrantScopes(ctx, created.PublicId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", c.PublicId)))
+	}
+	return created, nil
 }
 
 // UpdateRole will update a role in the repository and return the written role.
@@ -53,6 +60,7 @@ func (r *Repository) CreateRole(ctx context.Context, role *Role, _ ...Option) (*
 // then an error is returned.
 func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32, fieldMaskPaths []string, _ ...Option) (*Role, []*PrincipalRole, []*RoleGrant, int, error) {
 	const op = "iam.(Repository).UpdateRole"
+	ctx = db.NewOpContext(ctx, op)
 	if role == nil {
 		return nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing role")
 	}
@@ -111,6 +119,10 @@ func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32,
 			if err != nil {
 				return errors.Wrap(ctx, err, op)
 			}
+			resource.(*Role).GrantScopes, err = repo.ListRoleGrantScopes(ctx, role.PublicId)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 			return nil
 		},
 	)
@@ -127,6 +139,7 @@ func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32,
 // found, it will return nil, nil.
 func (r *Repository) LookupRole(ctx context.Context, withPublicId string, _ ...Option) (*Role, []*PrincipalRole, []*RoleGrant, error) {
 	const op = "iam.(Repository).LookupRole"
+	ctx = db.NewOpContext(ctx, op)
 	if withPublicId == "" {
 		return nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
 	}
@@ -154,6 +167,10 @@ func (r *Repository) LookupRole(ctx context.Context, withPublicId string, _ ...O
 			if err != nil {
 				return errors.Wrap(ctx, err, op)
 			}
+			role.GrantScopes, err = repo.ListRoleGrantScopes(ctx, withPublicId)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 			return nil
 		},
 	)
@@ -169,6 +186,7 @@ func (r *Repository) LookupRole(ctx context.Context, withPublicId string, _ ...O
 // DeleteRole will delete a role from the repository.
 func (r *Repository) DeleteRole(ctx context.Context, withPublicId string, _ ...Option) (int, error) {
 	const op = "iam.(Repository).DeleteRole"
+	ctx = db.NewOpContext(ctx, op)
 	if withPublicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
 	}
@@ -191,7 +209,7 @@ func (r *Repository) ListRoles(ctx context.Context, withScopeIds []string, opt .
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope ids")
 	}
 	var roles []*Role
-	err := r.list(ctx, &roles, "scope_id in (?)", []any{withScopeIds}, opt...)
+	err := r.listFromReplica(ctx, &roles, "scope_id in (?)", []any{withScopeIds}, opt...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/resource"
 )
 
 // AddRoleGrant will add role grants associated with the role ID in the
@@ -20,6 +21,7 @@ import (
 // the WithVersion option and will return an error.
 func (r *Repository) AddRoleGrants(ctx context.Context, roleId string, roleVersion uint32, grants []string, _ ...Option) ([]*RoleGrant, error) {
 	const op = "iam.(Repository).AddRoleGrants"
+	ctx = db.NewOpContext(ctx, op)
 	if roleId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing role id")
 	}
@@ -109,6 +111,7 @@ func (r *Repository) AddRoleGrants(ctx context.Context, roleId string, roleVersi
 // error.
 func (r *Repository) DeleteRoleGrants(ctx context.Context, roleId string, roleVersion uint32, grants []string, _ ...Option) (int, error) {
 	const op = "iam.(Repository).DeleteRoleGrants"
+	ctx = db.NewOpContext(ctx, op)
 	if roleId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing role id")
 	}
@@ -224,6 +227,7 @@ func (r *Repository) DeleteRoleGrants(ctx context.Context, roleId string, roleVe
 // value for the WithVersion option and will return an error.
 func (r *Repository) SetRoleGrants(ctx context.Context, roleId string, roleVersion uint32, grants []string, _ ...Option) ([]*RoleGrant, int, error) {
 	const op = "iam.(Repository).SetRoleGrants"
+	ctx = db.NewOpContext(ctx, op)
 	if roleId == "" {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing role id")
 	}
@@ -389,6 +393,9 @@ func (r *Repository) ListRoleGrants(ctx context.Context, roleId string, opt ...O
 	return roleGrants, nil
 }
 
+// GrantsForUser returns the grants that apply to the given user, once for
+// each grant scope of the role that provides them. Principal role assignments
+// that are not in effect at the current time are ignored.
 func (r *Repository) GrantsForUser(ctx context.Context, userId string, _ ...Option) ([]perms.GrantTuple, error) {
 	const op = "iam.(Repository).GrantsForUser"
 	if userId == "" {
@@ -396,10 +403,9 @@ func (r *Repository) GrantsForUser(ctx context.Context, userId string, _ ...Opti
 	}
 
 	const (
-		anonUser    = `where public_id in (?)`
-		authUser    = `where public_id in ('u_anon', 'u_auth', ?)`
-		grantsQuery = `
-with
+		anonUser       = `where public_id in (?)`
+		authUser       = `where public_id in ('u_anon', 'u_auth', ?)`
+		userRolesQuery = `
 users (id) as (
   select public_id
     from iam_user
@@ -428,20 +434,26 @@ managed_group_roles (role_id) as (
     from iam_managed_group_role,
          user_managed_groups
    where principal_id in (user_managed_groups.id)
+     and (not_before is null or not_before <= now())
+     and (not_after is null or not_after > now())
 ),
 group_roles (role_id) as (
   select role_id
     from iam_group_role,
          user_groups
    where principal_id in (user_groups.id)
+     and (not_before is null or not_before <= now())
+     and (not_after is null or not_after > now())
 ),
 user_roles (role_id) as (
   select role_id
     from iam_user_role,
          users
    where principal_id in (users.id)
+     and (not_before is null or not_before <= now())
+     and (not_after is null or not_after > now())
 ),
-user_group_roles (role_id) as (
+principal_roles (role_id) as (
   select role_id
     from group_roles
    union
@@ -450,37 +462,143 @@ user_group_roles (role_id) as (
    union
   select role_id
     from managed_group_roles
-),
-roles (role_id, grant_scope_id) as (
-  select iam_role.public_id,
-         iam_role.grant_scope_id
-    from iam_role,
-         user_group_roles
-   where public_id in (user_group_roles.role_id)
-),
-final (role_id, role_scope, role_grant) as (
-  select roles.role_id,
-         roles.grant_scope_id,
-         iam_role_grant.canonical_grant
-    from roles
-   inner
-    join iam_role_grant
-      on roles.role_id = iam_role_grant.role_id
-)
-select role_id as role_id, role_scope as scope_id, role_grant as grant from final;
-	`
+),`
 	)
 
 	var query string
 	switch userId {
 	case globals.AnonymousUserId:
-		query = fmt.Sprintf(grantsQuery, anonUser)
+		query = fmt.Sprintf(userRolesQuery, anonUser)
 	default:
-		query = fmt.Sprintf(grantsQuery, authUser)
+		query = fmt.Sprintf(userRolesQuery, authUser)
+	}
+	grants, err := r.grantsForRoles(ctx, query, []any{userId})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return grants, nil
+}
+
+// GrantsForPrincipal returns the grants that apply to the given principal,
+// once for each grant scope of the role that provides them. For a user these
+// are the same grants returned by GrantsForUser, and for a service account those
+// returned by GrantsForServiceAccount; for a group or managed group these are
+// the grants of the roles the group is directly assigned to.
+func (r *Repository) GrantsForPrincipal(ctx context.Context, principalId string, opt ...Option) ([]perms.GrantTuple, error) {
+	const op = "iam.(Repository).GrantsForPrincipal"
+	if principalId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing principal id")
+	}
+	switch globals.ResourceTypeFromPrefix(principalId) {
+	case resource.User:
+		return r.GrantsForUser(ctx, principalId, opt...)
+	case resource.ServiceAccount:
+		return r.GrantsForServiceAccount(ctx, principalId, opt...)
+	case resource.Group, resource.ManagedGroup:
+	default:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is not a user, group, managed group or service account id", principalId))
+	}
+
+	const groupRolesQuery = `
+principal_roles (role_id) as (
+  select role_id
+    from iam_group_role
+   where principal_id = ?
+     and (not_before is null or not_before <= now())
+     and (not_after is null or not_after > now())
+   union
+  select role_id
+    from iam_managed_group_role
+   where principal_id = ?
+     and (not_before is null or not_before <= now())
+     and (not_after is null or not_after > now())
+),`
+
+	grants, err := r.grantsForRoles(ctx, groupRolesQuery, []any{principalId, principalId})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
 	}
+	return grants, nil
+}
+
+// GrantsForServiceAccount returns the grants that apply to the service
+// account, once for each grant scope of the role that provides them. These are
+// the grants of the roles assigned to the service account and those of the
+// roles assigned directly to the anonymous and authenticated users.
+func (r *Repository) GrantsForServiceAccount(ctx context.Context, serviceAccountId string, _ ...Option) ([]perms.GrantTuple, error) {
+	const op = "iam.(Repository).GrantsForServiceAccount"
+	if serviceAccountId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account id")
+	}
+
+	const serviceAccountRolesQuery = `
+principal_roles (role_id) as (
+  select role_id
+    from iam_service_account_role
+   where principal_id = ?
+     and (not_before is null or not_before <= now())
+     and (not_after is null or not_after > now())
+   union
+  select role_id
+    from iam_user_role
+   where principal_id in ('u_anon', 'u_auth')
+     and (not_before is null or not_before <= now())
+     and (not_after is null or not_after > now())
+),`
 
+	grants, err := r.grantsForRoles(ctx, serviceAccountRolesQuery, []any{serviceAccountId})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return grants, nil
+}
+
+// grantsForRolesQuery selects the grants of the roles in principal_roles, once
+// for each grant scope of the role. The common table expressions defining
+// principal_roles replace the %s.
+const grantsForRolesQuery = `
+with
+%s
+roles (role_id, role_scope_id) as (
+  select iam_role.public_id,
+         iam_role.scope_id
+    from iam_role,
+         principal_roles
+   where public_id in (principal_roles.role_id)
+),
+role_grant_scopes (role_id, role_scope_id, grant_scope_id) as (
+  select roles.role_id,
+         roles.role_scope_id,
+         case iam_role_grant_scope.scope_id_or_special
+           when 'this' then roles.role_scope_id
+           else iam_role_grant_scope.scope_id_or_special
+         end
+    from roles
+   inner
+    join iam_role_grant_scope
+      on roles.role_id = iam_role_grant_scope.role_id
+),
+final (role_id, role_scope_id, grant_scope_id, role_grant) as (
+  select role_grant_scopes.role_id,
+         role_grant_scopes.role_scope_id,
+         role_grant_scopes.grant_scope_id,
+         iam_role_grant.canonical_grant
+    from role_grant_scopes
+   inner
+    join iam_role_grant
+      on role_grant_scopes.role_id = iam_role_grant.role_id
+)
+select role_id as role_id, role_scope_id as role_scope_id, grant_scope_id as grant_scope_id, role_grant as grant from final;
+`
+
+// grantsForRoles returns the grants of the roles selected by the
+// principalRoles common table expressions, which must define principal_roles
+// (role_id) and end with a comma. The args are those of principalRoles.
+func (r *Repository) grantsForRoles(ctx context.Context, principalRoles string, args []any) ([]perms.GrantTuple, error) {
+	const op = "iam.(Repository).grantsForRoles"
+	ctx = db.NewOpContext(ctx, op)
 	var grants []perms.GrantTuple
-	rows, err := r.reader.Query(ctx, query, []any{userId})
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(grantsForRolesQuery, principalRoles), args)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
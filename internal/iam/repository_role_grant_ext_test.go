@@ -8,6 +8,7 @@ import (
 	"fmt"
 	mathrand "math/rand"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/db"
@@ -253,3 +254,120 @@ func TestGrantsForUser(t *testing.T) {
 		t.Log("finished user", user.PublicId, "total roles", len(expectedRoleIds), "roles from users", rolesFromUsers, "roles from groups", rolesFromGroups, "roles from managed groups", rolesFromManagedGroups)
 	}
 }
+
+func TestGrantsForPrincipal(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	o, p := iam.TestScopes(
+		t,
+		iamRepo,
+		iam.WithSkipAdminRoleCreation(true),
+		iam.WithSkipDefaultRoleCreation(true),
+	)
+	user := iam.TestUser(t, iamRepo, o.GetPublicId())
+	group := iam.TestGroup(t, conn, o.GetPublicId())
+	iam.TestGroupMember(t, conn, group.GetPublicId(), user.GetPublicId())
+
+	groupRole := iam.TestRole(t, conn, p.GetPublicId())
+	iam.TestRoleGrant(t, conn, groupRole.GetPublicId(), "id=*;type=target;actions=read")
+	iam.TestGroupRole(t, conn, groupRole.GetPublicId(), group.GetPublicId())
+
+	userRole := iam.TestRole(t, conn, p.GetPublicId())
+	iam.TestRoleGrant(t, conn, userRole.GetPublicId(), "id=*;type=host-catalog;actions=read")
+	iam.TestUserRole(t, conn, userRole.GetPublicId(), user.GetPublicId())
+
+	t.Run("group", func(t *testing.T) {
+		got, err := iamRepo.GrantsForPrincipal(ctx, group.GetPublicId())
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, groupRole.GetPublicId(), got[0].RoleId)
+		assert.Equal(t, p.GetPublicId(), got[0].GrantScopeId)
+		assert.Equal(t, "id=*;type=target;actions=read", got[0].Grant)
+	})
+
+	t.Run("user", func(t *testing.T) {
+		got, err := iamRepo.GrantsForPrincipal(ctx, user.GetPublicId())
+		require.NoError(t, err)
+		want, err := iamRepo.GrantsForUser(ctx, user.GetPublicId())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, want, got)
+		var roleIds []string
+		for _, g := range got {
+			roleIds = append(roleIds, g.RoleId)
+		}
+		assert.ElementsMatch(t, []string{groupRole.GetPublicId(), userRole.GetPublicId()}, roleIds)
+	})
+
+	t.Run("service account", func(t *testing.T) {
+		sa := iam.TestServiceAccount(t, conn, o.GetPublicId())
+		saRole := iam.TestRole(t, conn, p.GetPublicId())
+		iam.TestRoleGrant(t, conn, saRole.GetPublicId(), "id=*;type=session;actions=list")
+		iam.TestServiceAccountRole(t, conn, saRole.GetPublicId(), sa.GetPublicId())
+
+		got, err := iamRepo.GrantsForPrincipal(ctx, sa.GetPublicId())
+		require.NoError(t, err)
+		want, err := iamRepo.GrantsForServiceAccount(ctx, sa.GetPublicId())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, want, got)
+		require.Len(t, got, 1)
+		assert.Equal(t, saRole.GetPublicId(), got[0].RoleId)
+		assert.Equal(t, "id=*;type=session;actions=list", got[0].Grant)
+	})
+
+	t.Run("not a principal", func(t *testing.T) {
+		_, err := iamRepo.GrantsForPrincipal(ctx, p.GetPublicId())
+		require.Error(t, err)
+	})
+
+	t.Run("missing id", func(t *testing.T) {
+		_, err := iamRepo.GrantsForPrincipal(ctx, "")
+		require.Error(t, err)
+	})
+}
+
+func TestGrantsForUser_timeBounds(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	o, p := iam.TestScopes(
+		t,
+		iamRepo,
+		iam.WithSkipAdminRoleCreation(true),
+		iam.WithSkipDefaultRoleCreation(true),
+	)
+	now := time.Now()
+	tests := []struct {
+		name       string
+		opts       []iam.Option
+		wantGrants bool
+	}{
+		{name: "unbounded", wantGrants: true},
+		{name: "active", opts: []iam.Option{iam.WithNotBefore(now.Add(-time.Hour)), iam.WithNotAfter(now.Add(time.Hour))}, wantGrants: true},
+		{name: "pending", opts: []iam.Option{iam.WithNotBefore(now.Add(time.Hour))}},
+		{name: "expired", opts: []iam.Option{iam.WithNotAfter(now.Add(-time.Hour))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := iam.TestUser(t, iamRepo, o.GetPublicId())
+			role := iam.TestRole(t, conn, p.GetPublicId())
+			iam.TestRoleGrant(t, conn, role.GetPublicId(), "id=*;type=*;actions=read")
+			_, err := iamRepo.AddPrincipalRoles(ctx, role.GetPublicId(), role.GetVersion(), []string{user.GetPublicId()}, tt.opts...)
+			require.NoError(t, err)
+
+			got, err := iamRepo.GrantsForUser(ctx, user.GetPublicId())
+			require.NoError(t, err)
+			var found bool
+			for _, g := range got {
+				if g.RoleId == role.GetPublicId() {
+					found = true
+				}
+			}
+			assert.Equal(t, tt.wantGrants, found)
+		})
+	}
+}
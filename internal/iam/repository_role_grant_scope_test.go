@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_AddRoleGrantScopes(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+
+	tests := []struct {
+		name        string
+		scopeId     string
+		grantScopes []string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name:        "org-children",
+			scopeId:     org.PublicId,
+			grantScopes: []string{globals.GrantScopeChildren},
+			want:        []string{globals.GrantScopeThis, globals.GrantScopeChildren},
+		},
+		{
+			name:        "org-child-project",
+			scopeId:     org.PublicId,
+			grantScopes: []string{proj.PublicId},
+			want:        []string{globals.GrantScopeThis, proj.PublicId},
+		},
+		{
+			name:        "global-descendants",
+			scopeId:     scope.Global.String(),
+			grantScopes: []string{globals.GrantScopeDescendants},
+			want:        []string{globals.GrantScopeThis, globals.GrantScopeDescendants},
+		},
+		{
+			name:        "org-descendants",
+			scopeId:     org.PublicId,
+			grantScopes: []string{globals.GrantScopeDescendants},
+			wantErr:     true,
+		},
+		{
+			name:        "project-children",
+			scopeId:     proj.PublicId,
+			grantScopes: []string{globals.GrantScopeChildren},
+			wantErr:     true,
+		},
+		{
+			name:        "project-other-scope",
+			scopeId:     proj.PublicId,
+			grantScopes: []string{org.PublicId},
+			wantErr:     true,
+		},
+		{
+			name:        "bad-grant-scope",
+			scopeId:     org.PublicId,
+			grantScopes: []string{"ancestors"},
+			wantErr:     true,
+		},
+		{
+			name:    "no-grant-scopes",
+			scopeId: org.PublicId,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			role := TestRole(t, conn, tt.scopeId)
+			_, err := repo.AddRoleGrantScopes(context.Background(), role.PublicId, role.Version, tt.grantScopes)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+
+			got, err := repo.ListRoleGrantScopes(context.Background(), role.PublicId)
+			require.NoError(err)
+			var gotScopes []string
+			for _, gs := range got {
+				gotScopes = append(gotScopes, gs.ScopeIdOrSpecial)
+			}
+			assert.ElementsMatch(tt.want, gotScopes)
+		})
+	}
+}
+
+func TestRepository_SetRoleGrantScopes(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	ctx := context.Background()
+
+	assert, require := assert.New(t), require.New(t)
+	role := TestRole(t, conn, org.PublicId)
+
+	// Setting the role's own scope ID normalizes to "this"
+	got, affected, err := repo.SetRoleGrantScopes(ctx, role.PublicId, role.Version, []string{org.PublicId, globals.GrantScopeChildren})
+	require.NoError(err)
+	assert.Equal(0, affected)
+	require.Len(got, 2)
+
+	list, err := repo.ListRoleGrantScopes(ctx, role.PublicId)
+	require.NoError(err)
+	var gotScopes []string
+	for _, gs := range list {
+		gotScopes = append(gotScopes, gs.ScopeIdOrSpecial)
+	}
+	assert.ElementsMatch([]string{globals.GrantScopeThis, globals.GrantScopeChildren}, gotScopes)
+
+	// Removing "this" leaves the grants applying to child scopes only
+	deleted, err := repo.DeleteRoleGrantScopes(ctx, role.PublicId, role.Version+1, []string{globals.GrantScopeThis})
+	require.NoError(err)
+	assert.Equal(1, deleted)
+
+	list, err = repo.ListRoleGrantScopes(ctx, role.PublicId)
+	require.NoError(err)
+	require.Len(list, 1)
+	assert.Equal(globals.GrantScopeChildren, list[0].ScopeIdOrSpecial)
+
+	// An empty set clears all grant scopes
+	got, affected, err = repo.SetRoleGrantScopes(ctx, role.PublicId, role.Version+2, []string{})
+	require.NoError(err)
+	assert.Equal(1, affected)
+	assert.Empty(got)
+
+	_, _, err = repo.SetRoleGrantScopes(ctx, role.PublicId, role.Version+3, nil)
+	require.Error(err)
+}
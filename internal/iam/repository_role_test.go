@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/iam/store"
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/hashicorp/go-uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
@@ -669,3 +670,49 @@ func TestRepository_ListRoles_Multiple_Scopes(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, total, len(got))
 }
+
+func TestRepository_LookupRole_OperationMetrics(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	role := TestRole(t, conn, org.PublicId)
+
+	reg := prometheus.NewRegistry()
+	db.InitializeCollectors(reg, nil)
+	// sampleCount returns how many database operations of the kind
+	// dbOperation were attributed to LookupRole. Other tests can perform
+	// them too, so only the increase is checked.
+	sampleCount := func(dbOperation string) uint64 {
+		t.Helper()
+		families, err := reg.Gather()
+		require.NoError(t, err)
+		for _, f := range families {
+			if f.GetName() != "boundary_controller_database_operation_duration_seconds" {
+				continue
+			}
+			for _, m := range f.GetMetric() {
+				labels := make(map[string]string)
+				for _, l := range m.GetLabel() {
+					labels[l.GetName()] = l.GetValue()
+				}
+				if labels["op"] == "iam.(Repository).LookupRole" && labels["db_operation"] == dbOperation {
+					return m.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+		return 0
+	}
+	lookupsBefore, txsBefore := sampleCount("lookup"), sampleCount("transaction")
+
+	found, _, _, err := repo.LookupRole(ctx, role.PublicId)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+
+	// The role is read in a transaction, and both are recorded under the op
+	// of LookupRole rather than that of the caller.
+	assert.Greater(t, sampleCount("lookup"), lookupsBefore)
+	assert.Greater(t, sampleCount("transaction"), txsBefore)
+}
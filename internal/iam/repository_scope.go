@@ -24,6 +24,7 @@ import (
 // scope. Supported options include: WithPublicId and WithRandomReader.
 func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, opt ...Option) (*Scope, error) {
 	const op = "iam.(Repository).CreateScope"
+	ctx = db.NewOpContext(ctx, op)
 	if s == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope")
 	}
@@ -425,6 +426,7 @@ func (r *Repository) UpdateScope(ctx context.Context, scope *Scope, version uint
 // found, it will return nil, nil.
 func (r *Repository) LookupScope(ctx context.Context, withPublicId string, _ ...Option) (*Scope, error) {
 	const op = "iam.(Repository).LookupScope"
+	ctx = db.NewOpContext(ctx, op)
 	if withPublicId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
 	}
@@ -467,7 +469,7 @@ func (r *Repository) ListScopes(ctx context.Context, withParentIds []string, opt
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing parent id")
 	}
 	var items []*Scope
-	err := r.list(ctx, &items, "parent_id in (?)", []any{withParentIds}, opt...)
+	err := r.listFromReplica(ctx, &items, "parent_id in (?)", []any{withParentIds}, opt...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -0,0 +1,273 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+type quotaUsage struct {
+	ResourceType string
+	Usage        int64
+}
+
+// SetScopeQuotas replaces the quotas of the org or project scope (scopeId)
+// with the given quotas. The scope's current db version must match the
+// scopeVersion or an error will be returned. An empty quotas removes all
+// quotas from the scope. The current quotas, with their usage, are returned.
+func (r *Repository) SetScopeQuotas(ctx context.Context, scopeId string, scopeVersion uint32, quotas []*ScopeQuota, _ ...Option) ([]*ScopeQuota, error) {
+	const op = "iam.(Repository).SetScopeQuotas"
+	ctx = db.NewOpContext(ctx, op)
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	if scopeVersion == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+	// Explicitly set to zero clears, but treat nil as a mistake
+	if quotas == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing quotas")
+	}
+
+	scope := AllocScope()
+	scope.PublicId = scopeId
+	if err := r.reader.LookupByPublicId(ctx, &scope); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to look up scope %s", scopeId)))
+	}
+
+	// NOTE: Set calculation can safely take place out of the transaction since
+	// we are using scopeVersion to ensure that we end up operating on the same
+	// set of data from this query to the final set in the transaction function
+
+	var existing []*ScopeQuota
+	if err := r.reader.SearchWhere(ctx, &existing, "scope_id = ?", []any{scopeId}, db.WithLimit(-1)); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to search for quotas"))
+	}
+	found := make(map[string]*ScopeQuota, len(existing))
+	for _, q := range existing {
+		found[q.GetResourceType()] = q
+	}
+
+	addQuotas := make([]any, 0, len(quotas))
+	updateQuotas := make([]*ScopeQuota, 0, len(quotas))
+	seen := make(map[string]bool, len(quotas))
+	for _, q := range quotas {
+		if q.GetScopeId() != scopeId {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("quota for scope %s does not match scope %s", q.GetScopeId(), scopeId))
+		}
+		if seen[q.GetResourceType()] {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("duplicate quota for resource type %s", q.GetResourceType()))
+		}
+		seen[q.GetResourceType()] = true
+		cur, ok := found[q.GetResourceType()]
+		if !ok {
+			addQuotas = append(addQuotas, q.Clone())
+			continue
+		}
+		delete(found, q.GetResourceType())
+		if cur.GetMaxCount() != q.GetMaxCount() {
+			updateQuotas = append(updateQuotas, q.Clone().(*ScopeQuota))
+		}
+	}
+	deleteQuotas := make([]any, 0, len(found))
+	for _, q := range found {
+		deleteQuotas = append(deleteQuotas, q)
+	}
+
+	if len(addQuotas) == 0 && len(updateQuotas) == 0 && len(deleteQuotas) == 0 {
+		return r.ListScopeQuotas(ctx, scopeId)
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var current []*ScopeQuota
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			msgs := make([]*oplog.Message, 0, 1+len(addQuotas)+len(updateQuotas)+len(deleteQuotas))
+			scopeTicket, err := w.GetTicket(ctx, &scope)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+			}
+			updatedScope := AllocScope()
+			updatedScope.PublicId = scopeId
+			updatedScope.Version = scopeVersion + 1
+			var scopeOplogMsg oplog.Message
+			rowsUpdated, err := w.Update(ctx, &updatedScope, []string{"Version"}, nil, db.NewOplogMsg(&scopeOplogMsg), db.WithVersion(&scopeVersion))
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update scope version"))
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated scope and %d rows updated", rowsUpdated))
+			}
+			msgs = append(msgs, &scopeOplogMsg)
+
+			if len(deleteQuotas) > 0 {
+				quotaOplogMsgs := make([]*oplog.Message, 0, len(deleteQuotas))
+				rowsDeleted, err := w.DeleteItems(ctx, deleteQuotas, db.NewOplogMsgs(&quotaOplogMsgs))
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete quotas"))
+				}
+				if rowsDeleted != len(deleteQuotas) {
+					return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("quotas deleted %d did not match request for %d", rowsDeleted, len(deleteQuotas)))
+				}
+				msgs = append(msgs, quotaOplogMsgs...)
+			}
+
+			for _, q := range updateQuotas {
+				var quotaOplogMsg oplog.Message
+				rowsUpdated, err := w.Update(ctx, q, []string{"MaxCount"}, nil, db.NewOplogMsg(&quotaOplogMsg))
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update quota"))
+				}
+				if rowsUpdated != 1 {
+					return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated quota and %d rows updated", rowsUpdated))
+				}
+				msgs = append(msgs, &quotaOplogMsg)
+			}
+
+			if len(addQuotas) > 0 {
+				quotaOplogMsgs := make([]*oplog.Message, 0, len(addQuotas))
+				if err := w.CreateItems(ctx, addQuotas, db.NewOplogMsgs(&quotaOplogMsgs)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add quotas"))
+				}
+				msgs = append(msgs, quotaOplogMsgs...)
+			}
+
+			metadata := oplog.Metadata{
+				"op-type":            []string{oplog.OpType_OP_TYPE_DELETE.String(), oplog.OpType_OP_TYPE_UPDATE.String(), oplog.OpType_OP_TYPE_CREATE.String()},
+				"scope-id":           []string{scope.PublicId},
+				"scope-type":         []string{scope.Type},
+				"resource-public-id": []string{scopeId},
+			}
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, scopeTicket, metadata, msgs); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+			}
+
+			repo, err := NewRepository(reader, w, r.kms)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			current, err = repo.ListScopeQuotas(ctx, scopeId)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to retrieve current quotas after set"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return current, nil
+}
+
+// ListScopeQuotas returns the quotas of the scope (scopeId) ordered by
+// resource type, with Usage set to the current number of resources of each
+// type in the scope.
+func (r *Repository) ListScopeQuotas(ctx context.Context, scopeId string, _ ...Option) ([]*ScopeQuota, error) {
+	const op = "iam.(Repository).ListScopeQuotas"
+	ctx = db.NewOpContext(ctx, op)
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	var quotas []*ScopeQuota
+	if err := r.reader.SearchWhere(ctx, &quotas, "scope_id = ?", []any{scopeId}, db.WithLimit(-1), db.WithOrder("resource_type")); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to lookup quotas"))
+	}
+	if len(quotas) == 0 {
+		return quotas, nil
+	}
+	usage, err := scopeQuotaUsage(ctx, r.reader, scopeId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	for _, q := range quotas {
+		q.Usage = usage[q.GetResourceType()]
+	}
+	return quotas, nil
+}
+
+// CheckQuota returns an error with the errors.QuotaExceeded code if creating
+// one more resource of resourceType in the project (projectId) would exceed a
+// quota set on the project or on its org. It must be called with the reader
+// of the transaction that creates the resource: the applicable quotas are
+// locked until the transaction ends so that concurrent creations cannot
+// together exceed a quota.
+func CheckQuota(ctx context.Context, reader db.Reader, projectId string, resourceType resource.Type) error {
+	const op = "iam.CheckQuota"
+	if reader == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "nil reader")
+	}
+	if projectId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	}
+	rows, err := reader.Query(ctx, lockProjectQuotasQuery, []any{
+		sql.Named("project_id", projectId),
+		sql.Named("resource_type", resourceType.String()),
+	})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	var quotas []*ScopeQuota
+	for rows.Next() {
+		q := allocScopeQuota()
+		if err := reader.ScanRows(ctx, rows, &q); err != nil {
+			rows.Close()
+			return errors.Wrap(ctx, err, op)
+		}
+		quotas = append(quotas, &q)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return errors.Wrap(ctx, err, op)
+	}
+	rows.Close()
+
+	for _, q := range quotas {
+		usage, err := scopeQuotaUsage(ctx, reader, q.GetScopeId())
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if usage[resourceType.String()] >= q.GetMaxCount() {
+			return errors.New(ctx, errors.QuotaExceeded, op, fmt.Sprintf("scope %s has reached its quota of %d for %s resources", q.GetScopeId(), q.GetMaxCount(), resourceType))
+		}
+	}
+	return nil
+}
+
+// scopeQuotaUsage returns the number of resources of each quota resource
+// type in the scope, keyed by resource type.
+func scopeQuotaUsage(ctx context.Context, reader db.Reader, scopeId string) (map[string]int64, error) {
+	const op = "iam.scopeQuotaUsage"
+	rows, err := reader.Query(ctx, scopeQuotaUsageQuery, []any{sql.Named("scope_id", scopeId)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	usage := make(map[string]int64, len(QuotaResourceTypes))
+	for rows.Next() {
+		var u quotaUsage
+		if err := reader.ScanRows(ctx, rows, &u); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		usage[u.ResourceType] = u.Usage
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return usage, nil
+}
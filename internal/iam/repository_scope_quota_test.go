@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewScopeQuota(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name         string
+		scopeId      string
+		resourceType resource.Type
+		maxCount     int64
+		wantErr      bool
+	}{
+		{name: "valid", scopeId: "p_1234567890", resourceType: resource.Target, maxCount: 10},
+		{name: "zero", scopeId: "p_1234567890", resourceType: resource.Session},
+		{name: "missing-scope-id", resourceType: resource.Target, maxCount: 10, wantErr: true},
+		{name: "negative", scopeId: "p_1234567890", resourceType: resource.Target, maxCount: -1, wantErr: true},
+		{name: "max", scopeId: "p_1234567890", resourceType: resource.Target, maxCount: MaxQuotaCount},
+		{name: "too-large", scopeId: "p_1234567890", resourceType: resource.Target, maxCount: MaxQuotaCount + 1, wantErr: true},
+		{name: "unsupported-type", scopeId: "p_1234567890", resourceType: resource.User, maxCount: 10, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewScopeQuota(ctx, tt.scopeId, tt.resourceType, tt.maxCount)
+			if tt.wantErr {
+				require.Error(err)
+				assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.scopeId, got.GetScopeId())
+			assert.Equal(tt.resourceType.String(), got.GetResourceType())
+			assert.Equal(tt.maxCount, got.GetMaxCount())
+		})
+	}
+}
+
+func TestRepository_SetScopeQuotas(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+
+	newQuota := func(scopeId string, rt resource.Type, maxCount int64) *ScopeQuota {
+		q, err := NewScopeQuota(ctx, scopeId, rt, maxCount)
+		require.NoError(t, err)
+		return q
+	}
+
+	t.Run("set-replace-clear", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		version := proj.GetVersion()
+
+		got, err := repo.SetScopeQuotas(ctx, proj.GetPublicId(), version, []*ScopeQuota{
+			newQuota(proj.GetPublicId(), resource.Target, 10),
+			newQuota(proj.GetPublicId(), resource.Session, 100),
+		})
+		require.NoError(err)
+		require.Len(got, 2)
+		assert.Equal(resource.Session.String(), got[0].GetResourceType())
+		assert.Equal(int64(100), got[0].GetMaxCount())
+		assert.Equal(resource.Target.String(), got[1].GetResourceType())
+		assert.Equal(int64(10), got[1].GetMaxCount())
+		assert.Equal(int64(0), got[1].Usage)
+		version++
+
+		got, err = repo.SetScopeQuotas(ctx, proj.GetPublicId(), version, []*ScopeQuota{
+			newQuota(proj.GetPublicId(), resource.Target, 5),
+			newQuota(proj.GetPublicId(), resource.HostCatalog, 2),
+		})
+		require.NoError(err)
+		require.Len(got, 2)
+		assert.Equal(resource.HostCatalog.String(), got[0].GetResourceType())
+		assert.Equal(resource.Target.String(), got[1].GetResourceType())
+		assert.Equal(int64(5), got[1].GetMaxCount())
+		version++
+
+		s, err := repo.LookupScope(ctx, proj.GetPublicId())
+		require.NoError(err)
+		assert.Equal(version, s.GetVersion())
+
+		got, err = repo.SetScopeQuotas(ctx, proj.GetPublicId(), version, []*ScopeQuota{})
+		require.NoError(err)
+		assert.Empty(got)
+
+		listed, err := repo.ListScopeQuotas(ctx, proj.GetPublicId())
+		require.NoError(err)
+		assert.Empty(listed)
+	})
+
+	t.Run("bad-version", func(t *testing.T) {
+		require := require.New(t)
+		_, err := repo.SetScopeQuotas(ctx, org.GetPublicId(), org.GetVersion()+10, []*ScopeQuota{
+			newQuota(org.GetPublicId(), resource.Target, 10),
+		})
+		require.Error(err)
+	})
+
+	t.Run("mismatched-scope", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := repo.SetScopeQuotas(ctx, org.GetPublicId(), org.GetVersion(), []*ScopeQuota{
+			newQuota(proj.GetPublicId(), resource.Target, 10),
+		})
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("global-scope", func(t *testing.T) {
+		require := require.New(t)
+		global, err := repo.LookupScope(ctx, "global")
+		require.NoError(err)
+		_, err = repo.SetScopeQuotas(ctx, "global", global.GetVersion(), []*ScopeQuota{
+			newQuota("global", resource.Target, 10),
+		})
+		require.Error(err)
+	})
+
+	t.Run("missing-quotas", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := repo.SetScopeQuotas(ctx, org.GetPublicId(), org.GetVersion(), nil)
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
+func TestCheckQuota(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	rw := db.New(conn)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+
+	require.NoError(t, CheckQuota(ctx, rw, proj.GetPublicId(), resource.Target))
+
+	q, err := NewScopeQuota(ctx, proj.GetPublicId(), resource.Target, 1)
+	require.NoError(t, err)
+	_, err = repo.SetScopeQuotas(ctx, proj.GetPublicId(), proj.GetVersion(), []*ScopeQuota{q})
+	require.NoError(t, err)
+	assert.NoError(t, CheckQuota(ctx, rw, proj.GetPublicId(), resource.Target))
+	assert.NoError(t, CheckQuota(ctx, rw, proj.GetPublicId(), resource.Session))
+
+	// A quota on the org applies to all of its projects
+	q, err = NewScopeQuota(ctx, org.GetPublicId(), resource.Target, 0)
+	require.NoError(t, err)
+	_, err = repo.SetScopeQuotas(ctx, org.GetPublicId(), org.GetVersion(), []*ScopeQuota{q})
+	require.NoError(t, err)
+	err = CheckQuota(ctx, rw, proj.GetPublicId(), resource.Target)
+	require.Error(t, err)
+	assert.True(t, errors.Match(errors.T(errors.QuotaExceeded), err))
+
+	err = CheckQuota(ctx, nil, proj.GetPublicId(), resource.Target)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	err = CheckQuota(ctx, rw, "", resource.Target)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+}
@@ -0,0 +1,440 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/go-dbw"
+	"github.com/hashicorp/go-secure-stdlib/base62"
+)
+
+const (
+	// maxActiveApiKeys is the number of unexpired API keys a service account
+	// can have. Two allow a key to be rotated by adding its replacement before
+	// removing it.
+	maxActiveApiKeys = 2
+
+	// apiKeyLastUsedUpdateDuration is how often the last used time of an API
+	// key is updated, to save the db from being updated on every request.
+	apiKeyLastUsedUpdateDuration = 10 * time.Minute
+)
+
+// CreateServiceAccount will create a service account in the repository and
+// return the written service account. No options are currently supported.
+func (r *Repository) CreateServiceAccount(ctx context.Context, serviceAccount *ServiceAccount, _ ...Option) (*ServiceAccount, error) {
+	const op = "iam.(Repository).CreateServiceAccount"
+	if serviceAccount == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account")
+	}
+	if serviceAccount.ServiceAccount == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account store")
+	}
+	if serviceAccount.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+	if serviceAccount.ScopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	id, err := newServiceAccountId()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	sa := serviceAccount.Clone().(*ServiceAccount)
+	sa.PublicId = id
+	resource, err := r.create(ctx, sa)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("service account %s already exists in scope %s", serviceAccount.Name, serviceAccount.ScopeId))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for service account %s", sa.PublicId)))
+	}
+	return resource.(*ServiceAccount), nil
+}
+
+// UpdateServiceAccount will update a service account in the repository and
+// return the written service account and its API keys. fieldMaskPaths
+// provides field_mask.proto paths for fields that should be updated. Fields
+// will be set to NULL if the field is a zero value and included in fieldMask.
+// Name and Description are the only updatable fields. If no updatable fields
+// are included in the fieldMaskPaths, then an error is returned.
+func (r *Repository) UpdateServiceAccount(ctx context.Context, serviceAccount *ServiceAccount, version uint32, fieldMaskPaths []string, _ ...Option) (*ServiceAccount, []*ServiceAccountApiKey, int, error) {
+	const op = "iam.(Repository).UpdateServiceAccount"
+	ctx = db.NewOpContext(ctx, op)
+	if serviceAccount == nil {
+		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing service account")
+	}
+	if serviceAccount.ServiceAccount == nil {
+		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing service account store")
+	}
+	if serviceAccount.PublicId == "" {
+		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("name", f):
+		case strings.EqualFold("description", f):
+		default:
+			return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
+		}
+	}
+	var dbMask, nullFields []string
+	dbMask, nullFields = dbw.BuildUpdatePaths(
+		map[string]any{
+			"name":        serviceAccount.Name,
+			"description": serviceAccount.Description,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
+	}
+	var resource Resource
+	var rowsUpdated int
+	var keys []*ServiceAccountApiKey
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(read db.Reader, w db.Writer) error {
+			var err error
+			sa := serviceAccount.Clone().(*ServiceAccount)
+			resource, rowsUpdated, err = r.update(ctx, sa, version, dbMask, nullFields)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			repo, err := NewRepository(read, w, r.kms)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			keys, err = repo.ListServiceAccountApiKeys(ctx, serviceAccount.PublicId)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("service account %s already exists in scope %s", serviceAccount.Name, serviceAccount.ScopeId))
+		}
+		return nil, nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for service account %s", serviceAccount.PublicId)))
+	}
+	return resource.(*ServiceAccount), keys, rowsUpdated, nil
+}
+
+// LookupServiceAccount will look up a service account and its API keys in the
+// repository. If the service account is not found, it will return nil, nil,
+// nil.
+func (r *Repository) LookupServiceAccount(ctx context.Context, withPublicId string, _ ...Option) (*ServiceAccount, []*ServiceAccountApiKey, error) {
+	const op = "iam.(Repository).LookupServiceAccount"
+	ctx = db.NewOpContext(ctx, op)
+	if withPublicId == "" {
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	sa := allocServiceAccount()
+	sa.PublicId = withPublicId
+	var keys []*ServiceAccountApiKey
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(read db.Reader, w db.Writer) error {
+			if err := read.LookupByPublicId(ctx, &sa); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			repo, err := NewRepository(read, w, r.kms)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			keys, err = repo.ListServiceAccountApiKeys(ctx, withPublicId)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for service account %s", withPublicId)))
+	}
+	return &sa, keys, nil
+}
+
+// DeleteServiceAccount will delete a service account, along with its API keys
+// and role assignments, from the repository.
+func (r *Repository) DeleteServiceAccount(ctx context.Context, withPublicId string, _ ...Option) (int, error) {
+	const op = "iam.(Repository).DeleteServiceAccount"
+	ctx = db.NewOpContext(ctx, op)
+	if withPublicId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	sa := allocServiceAccount()
+	sa.PublicId = withPublicId
+	if err := r.reader.LookupByPublicId(ctx, &sa); err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for service account %s", withPublicId)))
+	}
+	rowsDeleted, err := r.delete(ctx, &sa)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for service account %s", withPublicId)))
+	}
+	return rowsDeleted, nil
+}
+
+// ListServiceAccounts lists service accounts in the given scopes and supports
+// the WithLimit option.
+func (r *Repository) ListServiceAccounts(ctx context.Context, withScopeIds []string, opt ...Option) ([]*ServiceAccount, error) {
+	const op = "iam.(Repository).ListServiceAccounts"
+	if len(withScopeIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	var accounts []*ServiceAccount
+	if err := r.listFromReplica(ctx, &accounts, "scope_id in (?)", []any{withScopeIds}, opt...); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return accounts, nil
+}
+
+// ListServiceAccountApiKeys lists the API keys of a service account, including
+// expired ones, and supports the WithLimit option. The secrets of the keys are
+// not returned.
+func (r *Repository) ListServiceAccountApiKeys(ctx context.Context, withServiceAccountId string, opt ...Option) ([]*ServiceAccountApiKey, error) {
+	const op = "iam.(Repository).ListServiceAccountApiKeys"
+	if withServiceAccountId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account id")
+	}
+	keys := []*ServiceAccountApiKey{}
+	if err := r.list(ctx, &keys, "service_account_id = ?", []any{withServiceAccountId}, opt...); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return keys, nil
+}
+
+// AddServiceAccountApiKey adds an API key, limited to the org or project
+// scope (scopeId) and expiring at expirationTime, to a service account
+// (serviceAccountId). The service account's current db version must match the
+// serviceAccountVersion or an error will be returned. A service account can
+// have at most two unexpired keys, so that a key can be rotated by adding its
+// replacement before removing it. The returned key is the only one whose
+// Secret is set.
+func (r *Repository) AddServiceAccountApiKey(ctx context.Context, serviceAccountId string, serviceAccountVersion uint32, scopeId string, expirationTime time.Time, _ ...Option) (*ServiceAccountApiKey, error) {
+	const op = "iam.(Repository).AddServiceAccountApiKey"
+	ctx = db.NewOpContext(ctx, op)
+	if serviceAccountId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account id")
+	}
+	if serviceAccountVersion == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	if expirationTime.IsZero() {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing expiration time")
+	}
+	if !expirationTime.After(time.Now()) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "expiration time is not in the future")
+	}
+
+	id, err := newServiceAccountApiKeyId()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	secret, err := base62.Random(apiKeySecretLength)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Io))
+	}
+	key := allocServiceAccountApiKey()
+	key.PublicId = id
+	key.ServiceAccountId = serviceAccountId
+	key.ScopeId = scopeId
+	key.SecretHash = hashApiKeySecret(secret)
+	key.ExpirationTime = timestamp.New(expirationTime)
+
+	var newKey *ServiceAccountApiKey
+	err = r.updateServiceAccountKeys(ctx, op, serviceAccountId, serviceAccountVersion, func(read db.Reader, w db.Writer) error {
+		var active []*ServiceAccountApiKey
+		if err := read.SearchWhere(ctx, &active, "service_account_id = ? and expiration_time > current_timestamp", []any{serviceAccountId}, db.WithLimit(-1)); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to list active api keys"))
+		}
+		if len(active) >= maxActiveApiKeys {
+			return errors.New(ctx, errors.QuotaExceeded, op, fmt.Sprintf("service account %s already has %d active api keys", serviceAccountId, len(active)))
+		}
+		newKey = key.clone()
+		// API keys are not replicated, so they don't need oplog entries.
+		if err := w.Create(ctx, newKey); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add api key"))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	newKey.Secret = secret
+	return newKey, nil
+}
+
+// DeleteServiceAccountApiKey removes an API key (keyId) from a service account
+// (serviceAccountId). The service account's current db version must match the
+// serviceAccountVersion or an error will be returned.
+func (r *Repository) DeleteServiceAccountApiKey(ctx context.Context, serviceAccountId string, serviceAccountVersion uint32, keyId string, _ ...Option) (int, error) {
+	const op = "iam.(Repository).DeleteServiceAccountApiKey"
+	ctx = db.NewOpContext(ctx, op)
+	if serviceAccountId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing service account id")
+	}
+	if serviceAccountVersion == 0 {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+	if keyId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing api key id")
+	}
+
+	var rowsDeleted int
+	err := r.updateServiceAccountKeys(ctx, op, serviceAccountId, serviceAccountVersion, func(read db.Reader, w db.Writer) error {
+		key := allocServiceAccountApiKey()
+		key.PublicId = keyId
+		if err := read.LookupByPublicId(ctx, key); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for api key %s", keyId)))
+		}
+		if key.GetServiceAccountId() != serviceAccountId {
+			return errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("api key %s not found for service account %s", keyId, serviceAccountId))
+		}
+		var err error
+		// API keys are not replicated, so they don't need oplog entries.
+		rowsDeleted, err = w.Delete(ctx, key)
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete api key"))
+		}
+		if rowsDeleted != 1 {
+			return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("api key deleted and %d rows deleted", rowsDeleted))
+		}
+		return nil
+	})
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	return rowsDeleted, nil
+}
+
+// updateServiceAccountKeys runs fn in a transaction that also increments the
+// version of the service account, so that concurrent changes to its API keys
+// are detected.
+func (r *Repository) updateServiceAccountKeys(ctx context.Context, op errors.Op, serviceAccountId string, serviceAccountVersion uint32, fn db.TxHandler) error {
+	sa := allocServiceAccount()
+	sa.PublicId = serviceAccountId
+	scope, err := sa.GetScope(ctx, r.reader)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to get service account %s scope", serviceAccountId)))
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scope.GetPublicId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(read db.Reader, w db.Writer) error {
+			ticket, err := w.GetTicket(ctx, &sa)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+			}
+			updated := allocServiceAccount()
+			updated.PublicId = serviceAccountId
+			updated.Version = serviceAccountVersion + 1
+			var saOplogMsg oplog.Message
+			rowsUpdated, err := w.Update(ctx, &updated, []string{"Version"}, nil, db.NewOplogMsg(&saOplogMsg), db.WithVersion(&serviceAccountVersion))
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update service account version"))
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated service account and %d rows updated", rowsUpdated))
+			}
+			if err := fn(read, w); err != nil {
+				return err
+			}
+			metadata := oplog.Metadata{
+				"op-type":            []string{oplog.OpType_OP_TYPE_UPDATE.String()},
+				"scope-id":           []string{scope.PublicId},
+				"scope-type":         []string{scope.Type},
+				"resource-public-id": []string{serviceAccountId},
+			}
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, []*oplog.Message{&saOplogMsg}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+			}
+			return nil
+		},
+	)
+	return err
+}
+
+// ValidateServiceAccountApiKey returns the API key with the id if the secret
+// matches it and it has not expired. If the key is not found, does not match
+// or has expired, it returns nil, nil. The key's last used time is updated,
+// at most every apiKeyLastUsedUpdateDuration.
+func (r *Repository) ValidateServiceAccountApiKey(ctx context.Context, keyId, secret string, _ ...Option) (*ServiceAccountApiKey, error) {
+	const op = "iam.(Repository).ValidateServiceAccountApiKey"
+	ctx = db.NewOpContext(ctx, op)
+	if keyId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	if secret == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing secret")
+	}
+	key := allocServiceAccountApiKey()
+	key.PublicId = keyId
+	if err := r.reader.LookupByPublicId(ctx, key); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for api key %s", keyId)))
+	}
+	if subtle.ConstantTimeCompare(hashApiKeySecret(secret), key.GetSecretHash()) != 1 {
+		return nil, nil
+	}
+	now := time.Now()
+	if !now.Before(key.GetExpirationTime().AsTime()) {
+		return nil, nil
+	}
+
+	if key.GetLastUsedTime() == nil || now.Sub(key.GetLastUsedTime().AsTime()) >= apiKeyLastUsedUpdateDuration {
+		_, err := r.writer.DoTx(
+			ctx,
+			db.StdRetryCnt,
+			db.ExpBackoff{},
+			func(_ db.Reader, w db.Writer) error {
+				k := key.clone()
+				k.LastUsedTime = timestamp.New(now)
+				// API keys are not replicated, so they don't need oplog entries.
+				rowsUpdated, err := w.Update(ctx, k, []string{"LastUsedTime"}, nil)
+				if err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				if rowsUpdated > 1 {
+					return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+				}
+				key = k
+				return nil
+			},
+		)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(keyId))
+		}
+	}
+	return key, nil
+}
@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ServiceAccount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+
+	t.Run("project-scope", func(t *testing.T) {
+		sa, err := NewServiceAccount(ctx, proj.PublicId)
+		require.NoError(t, err)
+		_, err = repo.CreateServiceAccount(ctx, sa)
+		require.Error(t, err)
+	})
+
+	sa, err := NewServiceAccount(ctx, org.PublicId, WithName("ci"), WithDescription("deploys"))
+	require.NoError(t, err)
+	sa, err = repo.CreateServiceAccount(ctx, sa)
+	require.NoError(t, err)
+	assert.NotEmpty(t, sa.PublicId)
+	assert.Equal(t, uint32(1), sa.Version)
+
+	t.Run("duplicate-name", func(t *testing.T) {
+		dup, err := NewServiceAccount(ctx, org.PublicId, WithName("ci"))
+		require.NoError(t, err)
+		_, err = repo.CreateServiceAccount(ctx, dup)
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.NotUnique), err))
+	})
+
+	got, keys, err := repo.LookupServiceAccount(ctx, sa.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, sa.Name, got.Name)
+	assert.Empty(t, keys)
+
+	sa.Name = "ci-renamed"
+	updated, _, rowsUpdated, err := repo.UpdateServiceAccount(ctx, sa, sa.Version, []string{"Name"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, rowsUpdated)
+	assert.Equal(t, "ci-renamed", updated.Name)
+
+	listed, err := repo.ListServiceAccounts(ctx, []string{org.PublicId})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, sa.PublicId, listed[0].PublicId)
+
+	rowsDeleted, err := repo.DeleteServiceAccount(ctx, sa.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rowsDeleted)
+	got, _, err = repo.LookupServiceAccount(ctx, sa.PublicId)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestRepository_ServiceAccountApiKeys(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	otherOrg, _ := TestScopes(t, repo)
+	sa := TestServiceAccount(t, conn, org.PublicId)
+	expiration := time.Now().Add(time.Hour)
+
+	version := sa.Version
+	t.Run("invalid-scope", func(t *testing.T) {
+		_, err := repo.AddServiceAccountApiKey(ctx, sa.PublicId, version, otherOrg.PublicId, expiration)
+		require.Error(t, err)
+	})
+	t.Run("expired", func(t *testing.T) {
+		_, err := repo.AddServiceAccountApiKey(ctx, sa.PublicId, version, proj.PublicId, time.Now().Add(-time.Hour))
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("bad-version", func(t *testing.T) {
+		_, err := repo.AddServiceAccountApiKey(ctx, sa.PublicId, version+1, proj.PublicId, expiration)
+		require.Error(t, err)
+	})
+
+	first, err := repo.AddServiceAccountApiKey(ctx, sa.PublicId, version, proj.PublicId, expiration)
+	require.NoError(t, err)
+	version++
+	require.NotEmpty(t, first.Secret)
+	assert.Equal(t, proj.PublicId, first.ScopeId)
+	assert.Equal(t, hashApiKeySecret(first.Secret), first.SecretHash)
+
+	second, err := repo.AddServiceAccountApiKey(ctx, sa.PublicId, version, org.PublicId, expiration)
+	require.NoError(t, err)
+	version++
+
+	t.Run("too-many-keys", func(t *testing.T) {
+		_, err := repo.AddServiceAccountApiKey(ctx, sa.PublicId, version, org.PublicId, expiration)
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.QuotaExceeded), err))
+	})
+
+	_, keys, err := repo.LookupServiceAccount(ctx, sa.PublicId)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	for _, k := range keys {
+		assert.Empty(t, k.Secret)
+	}
+
+	t.Run("validate", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		k, err := repo.ValidateServiceAccountApiKey(ctx, first.PublicId, first.Secret)
+		require.NoError(err)
+		require.NotNil(k)
+		assert.Equal(sa.PublicId, k.ServiceAccountId)
+		assert.NotNil(k.LastUsedTime)
+
+		k, err = repo.ValidateServiceAccountApiKey(ctx, first.PublicId, second.Secret)
+		require.NoError(err)
+		assert.Nil(k)
+
+		k, err = repo.ValidateServiceAccountApiKey(ctx, "sak_doesnotexist", first.Secret)
+		require.NoError(err)
+		assert.Nil(k)
+	})
+
+	rowsDeleted, err := repo.DeleteServiceAccountApiKey(ctx, sa.PublicId, version, first.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rowsDeleted)
+	version++
+
+	k, err := repo.ValidateServiceAccountApiKey(ctx, first.PublicId, first.Secret)
+	require.NoError(t, err)
+	assert.Nil(t, k)
+
+	_, err = repo.AddServiceAccountApiKey(ctx, sa.PublicId, version, proj.PublicId, expiration)
+	require.NoError(t, err)
+}
+
+func TestRepository_ServiceAccountPrincipalRoles(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	sa := TestServiceAccount(t, conn, org.PublicId)
+	role := TestRole(t, conn, proj.PublicId)
+
+	principals, err := repo.AddPrincipalRoles(ctx, role.PublicId, role.Version, []string{sa.PublicId})
+	require.NoError(t, err)
+	require.Len(t, principals, 1)
+	assert.Equal(t, ServiceAccountRoleType.String(), principals[0].GetType())
+	assert.Equal(t, sa.PublicId, principals[0].GetPrincipalId())
+
+	principals, _, err = repo.SetPrincipalRoles(ctx, role.PublicId, role.Version+1, []string{sa.PublicId})
+	require.NoError(t, err)
+	require.Len(t, principals, 1)
+
+	rowsDeleted, err := repo.DeletePrincipalRoles(ctx, role.PublicId, role.Version+1, []string{sa.PublicId})
+	require.NoError(t, err)
+	assert.Equal(t, 1, rowsDeleted)
+}
@@ -71,6 +71,7 @@ func (r *Repository) CreateUser(ctx context.Context, user *User, opt ...Option)
 // fieldMaskPaths, then an error is returned.
 func (r *Repository) UpdateUser(ctx context.Context, user *User, version uint32, fieldMaskPaths []string, opt ...Option) (*User, []string, int, error) {
 	const op = "iam.(Repository).UpdateUser"
+	ctx = db.NewOpContext(ctx, op)
 	if user == nil {
 		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing user")
 	}
@@ -194,6 +195,7 @@ func (r *Repository) LookupUser(ctx context.Context, userId string, _ ...Option)
 // DeleteUser will delete a user from the repository
 func (r *Repository) DeleteUser(ctx context.Context, withPublicId string, _ ...Option) (int, error) {
 	const op = "iam.(Repository).DeleteUser"
+	ctx = db.NewOpContext(ctx, op)
 	if withPublicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
 	}
@@ -231,6 +233,7 @@ func (r *Repository) ListUsers(ctx context.Context, withScopeIds []string, opt .
 // WithDescription options are supported as well.
 func (r *Repository) LookupUserWithLogin(ctx context.Context, accountId string, opt ...Option) (*User, error) {
 	const op = "iam.(Repository).LookupUserWithLogin"
+	ctx = db.NewOpContext(ctx, op)
 	if accountId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing account id")
 	}
@@ -309,6 +312,7 @@ func (r *Repository) LookupUserWithLogin(ctx context.Context, accountId string,
 				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("account update affected %d rows", updatedRows))
 			}
 			msgs = append(msgs, &updateMsg)
+			metadata[accountUserIdMetadataKey] = []string{id}
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
 				return errors.Wrap(ctx, err, op)
 			}
@@ -334,6 +338,7 @@ func (r *Repository) LookupUserWithLogin(ctx context.Context, accountId string,
 // allowUserAutoVivify determines if a user can be autovivified based on the account's scope
 func (r *Repository) allowUserAutoVivify(ctx context.Context, acct *authAccount) (bool, error) {
 	const op = "iam.(Repository).allowUserAutoVivify"
+	ctx = db.NewOpContext(ctx, op)
 	if acct == nil {
 		return false, errors.New(ctx, errors.InvalidParameter, op, "missing account")
 	}
@@ -348,6 +353,7 @@ func (r *Repository) allowUserAutoVivify(ctx context.Context, acct *authAccount)
 
 func (r *Repository) getUserWithAccount(ctx context.Context, withAccountId string, _ ...Option) (*User, error) {
 	const op = "iam.(Repository).getUserWithAccount"
+	ctx = db.NewOpContext(ctx, op)
 	if withAccountId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing account id")
 	}
@@ -398,6 +404,7 @@ func (r *Repository) ListUserAccounts(ctx context.Context, userId string, opt ..
 // supported.
 func (r *Repository) AddUserAccounts(ctx context.Context, userId string, userVersion uint32, accountIds []string, _ ...Option) ([]string, error) {
 	const op = "iam.(Repository).AddUserAccounts"
+	ctx = db.NewOpContext(ctx, op)
 	if userId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
 	}
@@ -477,6 +484,7 @@ func (r *Repository) AddUserAccounts(ctx context.Context, userId string, userVer
 // supported.
 func (r *Repository) DeleteUserAccounts(ctx context.Context, userId string, userVersion uint32, accountIds []string, _ ...Option) ([]string, error) {
 	const op = "iam.(Repository).DeleteUserAccounts"
+	ctx = db.NewOpContext(ctx, op)
 	if userId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
 	}
@@ -556,6 +564,7 @@ func (r *Repository) DeleteUserAccounts(ctx context.Context, userId string, user
 // supported.
 func (r *Repository) SetUserAccounts(ctx context.Context, userId string, userVersion uint32, accountIds []string, _ ...Option) ([]string, error) {
 	const op = "iam.(Repository).SetUserAccounts"
+	ctx = db.NewOpContext(ctx, op)
 	if userId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
 	}
@@ -697,10 +706,11 @@ func associateUserWithAccounts(ctx context.Context, repoKms *kms.Kms, reader db.
 		}
 
 		metadata := oplog.Metadata{
-			"resource-public-id": []string{aa.PublicId},
-			"scope-id":           []string{aa.ScopeId},
-			"resource-type":      []string{"auth-account"},
-			"op-type":            []string{oplog.OpType_OP_TYPE_UPDATE.String()},
+			"resource-public-id":     []string{aa.PublicId},
+			"scope-id":               []string{aa.ScopeId},
+			"resource-type":          []string{"auth-account"},
+			"op-type":                []string{oplog.OpType_OP_TYPE_UPDATE.String()},
+			accountUserIdMetadataKey: []string{userId},
 		}
 		var updatedRows int
 		updatedAcct := aa.Clone().(*authAccount)
@@ -760,10 +770,11 @@ func dissociateUserFromAccounts(ctx context.Context, repoKms *kms.Kms, reader db
 			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
 		}
 		metadata := oplog.Metadata{
-			"resource-public-id": []string{aa.PublicId},
-			"scope-id":           []string{aa.ScopeId},
-			"resource-type":      []string{"auth-account"},
-			"op-type":            []string{oplog.OpType_OP_TYPE_UPDATE.String()},
+			"resource-public-id":     []string{aa.PublicId},
+			"scope-id":               []string{aa.ScopeId},
+			"resource-type":          []string{"auth-account"},
+			"op-type":                []string{oplog.OpType_OP_TYPE_UPDATE.String()},
+			accountUserIdMetadataKey: []string{userId},
 		}
 		var updatedRows int
 		updatedAcct := aa.Clone().(*authAccount)
@@ -865,6 +876,7 @@ func (r *Repository) lookupUser(ctx context.Context, userId string, opt ...Optio
 // When no record is found then it returns nil, nil
 func (r *Repository) getUsers(ctx context.Context, userId string, scopeIds []string, opt ...Option) ([]*User, error) {
 	const op = "iam.(Repository).getUsers"
+	ctx = db.NewOpContext(ctx, op)
 	if userId == "" && len(scopeIds) == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing search criteria: both user id and scope ids are empty")
 	}
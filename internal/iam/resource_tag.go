@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultResourceTagTable = "resource_tag"
+
+// ResourceTag is a custom key/value pair attached to a target, host catalog or
+// credential store. Multiple tags may contain the same key and different
+// values in which case both key/value pairs are valid. Grants can match
+// resources by their tags.
+type ResourceTag struct {
+	*store.ResourceTag
+	tableName string `gorm:"-"`
+}
+
+// ensure that ResourceTag implements the interfaces of: Cloneable and db.VetForWriter
+var (
+	_ Cloneable       = (*ResourceTag)(nil)
+	_ db.VetForWriter = (*ResourceTag)(nil)
+)
+
+// NewResourceTag creates a new in memory resource tag
+func NewResourceTag(ctx context.Context, resourceId, key, value string, _ ...Option) (*ResourceTag, error) {
+	const op = "iam.NewResourceTag"
+	switch {
+	case resourceId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing resource id")
+	case key == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing key")
+	case value == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing value")
+	}
+	return &ResourceTag{
+		ResourceTag: &store.ResourceTag{
+			ResourceId: resourceId,
+			Key:        key,
+			Value:      value,
+		},
+	}, nil
+}
+
+// Clone creates a clone of the ResourceTag
+func (t *ResourceTag) Clone() any {
+	cp := proto.Clone(t.ResourceTag)
+	return &ResourceTag{
+		ResourceTag: cp.(*store.ResourceTag),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface
+func (t *ResourceTag) VetForWrite(ctx context.Context, _ db.Reader, _ db.OpType, _ ...db.Option) error {
+	const op = "iam.(ResourceTag).VetForWrite"
+	switch {
+	case t.ResourceId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing resource id")
+	case t.Key == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing key")
+	case t.Value == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing value")
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (t *ResourceTag) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return defaultResourceTagTable
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (t *ResourceTag) SetTableName(n string) {
+	t.tableName = n
+}
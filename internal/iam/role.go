@@ -22,7 +22,10 @@ const (
 // Roles are granted permissions and assignable to Users and Groups.
 type Role struct {
 	*store.Role
-	tableName string `gorm:"-"`
+	// GrantScopes are the scopes the role's grants apply to. They are
+	// populated when the role is looked up or updated.
+	GrantScopes []*RoleGrantScope `gorm:"-"`
+	tableName   string            `gorm:"-"`
 }
 
 // ensure that Role implements the interfaces of: Resource, Cloneable, and db.VetForWriter.
@@ -60,9 +63,13 @@ func allocRole() Role {
 // Clone creates a clone of the Role.
 func (r *Role) Clone() any {
 	cp := proto.Clone(r.Role)
-	return &Role{
+	ret := &Role{
 		Role: cp.(*store.Role),
 	}
+	for _, gs := range r.GrantScopes {
+		ret.GrantScopes = append(ret.GrantScopes, gs.Clone().(*RoleGrantScope))
+	}
+	return ret
 }
 
 // VetForWrite implements db.VetForWrite() interface.
@@ -95,6 +102,9 @@ func (*Role) Actions() map[string]action.Type {
 	ret[action.AddGrants.String()] = action.AddGrants
 	ret[action.RemoveGrants.String()] = action.RemoveGrants
 	ret[action.SetGrants.String()] = action.SetGrants
+	ret[action.AddGrantScopes.String()] = action.AddGrantScopes
+	ret[action.RemoveGrantScopes.String()] = action.RemoveGrantScopes
+	ret[action.SetGrantScopes.String()] = action.SetGrantScopes
 	ret[action.AddPrincipals.String()] = action.AddPrincipals
 	ret[action.RemovePrincipals.String()] = action.RemovePrincipals
 	ret[action.SetPrincipals.String()] = action.SetPrincipals
@@ -551,6 +551,9 @@ func TestRole_Actions(t *testing.T) {
 	assert.Equal(a[action.AddPrincipals.String()], action.AddPrincipals)
 	assert.Equal(a[action.RemovePrincipals.String()], action.RemovePrincipals)
 	assert.Equal(a[action.SetPrincipals.String()], action.SetPrincipals)
+	assert.Equal(a[action.AddGrantScopes.String()], action.AddGrantScopes)
+	assert.Equal(a[action.RemoveGrantScopes.String()], action.RemoveGrantScopes)
+	assert.Equal(a[action.SetGrantScopes.String()], action.SetGrantScopes)
 }
 
 func TestRole_ResourceType(t *testing.T) {
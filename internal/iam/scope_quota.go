@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultScopeQuotaTable = "iam_scope_quota"
+
+// QuotaResourceTypes are the resource types whose count can be limited by a
+// scope quota. A quota on sessions limits the sessions that have not yet been
+// terminated.
+var QuotaResourceTypes = []resource.Type{
+	resource.Target,
+	resource.HostCatalog,
+	resource.CredentialStore,
+	resource.Session,
+}
+
+// MaxQuotaCount is the largest number of resources a scope quota can allow,
+// as limits are reported as unsigned 32 bit integers.
+const MaxQuotaCount = math.MaxUint32
+
+// ScopeQuota limits the number of resources of a type in an org or project
+// scope. A quota on an org limits the total across all of its projects.
+type ScopeQuota struct {
+	*store.ScopeQuota
+
+	// Usage is the number of resources of the type currently in the scope.
+	// It is only populated when quotas are read from the repository.
+	Usage int64 `gorm:"-"`
+
+	tableName string `gorm:"-"`
+}
+
+// ensure that ScopeQuota implements the interfaces of: Cloneable and db.VetForWriter
+var (
+	_ Cloneable       = (*ScopeQuota)(nil)
+	_ db.VetForWriter = (*ScopeQuota)(nil)
+)
+
+// NewScopeQuota creates a new in memory scope quota limiting resourceType in
+// scopeId to maxCount resources.
+func NewScopeQuota(ctx context.Context, scopeId string, resourceType resource.Type, maxCount int64, _ ...Option) (*ScopeQuota, error) {
+	const op = "iam.NewScopeQuota"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	if err := validateQuota(ctx, resourceType.String(), maxCount); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return &ScopeQuota{
+		ScopeQuota: &store.ScopeQuota{
+			ScopeId:      scopeId,
+			ResourceType: resourceType.String(),
+			MaxCount:     maxCount,
+		},
+	}, nil
+}
+
+func validateQuota(ctx context.Context, resourceType string, maxCount int64) error {
+	const op = "iam.validateQuota"
+	switch {
+	case maxCount < 0:
+		return errors.New(ctx, errors.InvalidParameter, op, "max count must not be negative")
+	case maxCount > MaxQuotaCount:
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("max count must not be greater than %d", MaxQuotaCount))
+	}
+	for _, t := range QuotaResourceTypes {
+		if t.String() == resourceType {
+			return nil
+		}
+	}
+	return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("quotas are not supported for resource type %q", resourceType))
+}
+
+func allocScopeQuota() ScopeQuota {
+	return ScopeQuota{
+		ScopeQuota: &store.ScopeQuota{},
+	}
+}
+
+// Clone creates a clone of the ScopeQuota
+func (q *ScopeQuota) Clone() any {
+	cp := proto.Clone(q.ScopeQuota)
+	return &ScopeQuota{
+		ScopeQuota: cp.(*store.ScopeQuota),
+		Usage:      q.Usage,
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface
+func (q *ScopeQuota) VetForWrite(ctx context.Context, _ db.Reader, _ db.OpType, _ ...db.Option) error {
+	const op = "iam.(ScopeQuota).VetForWrite"
+	if q.ScopeId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	if err := validateQuota(ctx, q.ResourceType, q.MaxCount); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (q *ScopeQuota) TableName() string {
+	if q.tableName != "" {
+		return q.tableName
+	}
+	return defaultScopeQuotaTable
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (q *ScopeQuota) SetTableName(n string) {
+	q.tableName = n
+}
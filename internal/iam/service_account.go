@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"crypto/sha256"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultServiceAccountTableName       = "iam_service_account"
+	defaultServiceAccountApiKeyTableName = "iam_service_account_api_key"
+
+	// apiKeySecretLength is the length of the random part of an API key
+	apiKeySecretLength = 32
+)
+
+// ServiceAccount is a principal used by automation. It has no accounts and
+// authenticates with API keys. Service accounts are scoped to the global scope
+// or an org.
+type ServiceAccount struct {
+	*store.ServiceAccount
+	tableName string `gorm:"-"`
+}
+
+// ensure that ServiceAccount implements the interfaces of: Resource,
+// Cloneable, and db.VetForWriter.
+var (
+	_ Resource        = (*ServiceAccount)(nil)
+	_ Cloneable       = (*ServiceAccount)(nil)
+	_ db.VetForWriter = (*ServiceAccount)(nil)
+)
+
+// NewServiceAccount creates a new in memory service account in the given
+// scope (global/org). Allowed options include: WithDescription, WithName.
+func NewServiceAccount(ctx context.Context, scopeId string, opt ...Option) (*ServiceAccount, error) {
+	const op = "iam.NewServiceAccount"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	opts := getOpts(opt...)
+	return &ServiceAccount{
+		ServiceAccount: &store.ServiceAccount{
+			Name:        opts.withName,
+			Description: opts.withDescription,
+			ScopeId:     scopeId,
+		},
+	}, nil
+}
+
+func allocServiceAccount() ServiceAccount {
+	return ServiceAccount{
+		ServiceAccount: &store.ServiceAccount{},
+	}
+}
+
+// Clone creates a clone of the ServiceAccount.
+func (s *ServiceAccount) Clone() any {
+	cp := proto.Clone(s.ServiceAccount)
+	return &ServiceAccount{
+		ServiceAccount: cp.(*store.ServiceAccount),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the service
+// account before it's written.
+func (s *ServiceAccount) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	const op = "iam.(ServiceAccount).VetForWrite"
+	if s.PublicId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	if err := validateScopeForWrite(ctx, r, s, opType, opt...); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+func (*ServiceAccount) validScopeTypes() []scope.Type {
+	return []scope.Type{scope.Global, scope.Org}
+}
+
+// GetScope returns the scope for the ServiceAccount.
+func (s *ServiceAccount) GetScope(ctx context.Context, r db.Reader) (*Scope, error) {
+	return LookupScope(ctx, r, s)
+}
+
+// ResourceType returns the type of the ServiceAccount.
+func (*ServiceAccount) ResourceType() resource.Type { return resource.ServiceAccount }
+
+// Actions returns the available actions for ServiceAccount
+func (*ServiceAccount) Actions() map[string]action.Type {
+	return CrudActions()
+}
+
+// TableName returns the tablename to override the default gorm table name.
+func (s *ServiceAccount) TableName() string {
+	if s.tableName != "" {
+		return s.tableName
+	}
+	return defaultServiceAccountTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (s *ServiceAccount) SetTableName(n string) {
+	s.tableName = n
+}
+
+// ServiceAccountApiKey is an API key of a service account. Only the hash of
+// the key's secret is stored; the secret itself is only available when the key
+// is created.
+type ServiceAccountApiKey struct {
+	*store.ServiceAccountApiKey
+
+	// Secret is the random part of the key. It is only set on keys returned
+	// from AddServiceAccountApiKey.
+	Secret    string `gorm:"-"`
+	tableName string `gorm:"-"`
+}
+
+func allocServiceAccountApiKey() *ServiceAccountApiKey {
+	return &ServiceAccountApiKey{
+		ServiceAccountApiKey: &store.ServiceAccountApiKey{},
+	}
+}
+
+func (k *ServiceAccountApiKey) clone() *ServiceAccountApiKey {
+	cp := proto.Clone(k.ServiceAccountApiKey)
+	return &ServiceAccountApiKey{
+		ServiceAccountApiKey: cp.(*store.ServiceAccountApiKey),
+		Secret:               k.Secret,
+	}
+}
+
+// Token returns the bearer token of the key, which is its public id followed
+// by its secret. It is empty if the secret is not known.
+func (k *ServiceAccountApiKey) Token() string {
+	if k.Secret == "" {
+		return ""
+	}
+	return k.GetPublicId() + "_" + k.Secret
+}
+
+// TableName returns the tablename to override the default gorm table name.
+func (k *ServiceAccountApiKey) TableName() string {
+	if k.tableName != "" {
+		return k.tableName
+	}
+	return defaultServiceAccountApiKeyTableName
+}
+
+// SetTableName sets the table name. If the caller attempts to set the name to
+// "" the name will be reset to the default name.
+func (k *ServiceAccountApiKey) SetTableName(n string) {
+	k.tableName = n
+}
+
+func hashApiKeySecret(secret string) []byte {
+	h := sha256.Sum256([]byte(secret))
+	return h[:]
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServiceAccount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		scopeId string
+		opt     []Option
+		want    *ServiceAccount
+		wantErr errors.Code
+	}{
+		{
+			name:    "missing-scope",
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "valid",
+			scopeId: "o_1234567890",
+			opt:     []Option{WithName("name"), WithDescription("description")},
+			want: &ServiceAccount{
+				ServiceAccount: &store.ServiceAccount{
+					ScopeId:     "o_1234567890",
+					Name:        "name",
+					Description: "description",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewServiceAccount(ctx, tt.scopeId, tt.opt...)
+			if tt.wantErr != 0 {
+				require.Error(err)
+				assert.True(errors.Match(errors.T(tt.wantErr), err))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestServiceAccountApiKey_Token(t *testing.T) {
+	t.Parallel()
+	k := allocServiceAccountApiKey()
+	k.PublicId = "sak_1234567890"
+	assert.Empty(t, k.Token())
+
+	k.Secret = "secret"
+	assert.Equal(t, "sak_1234567890_secret", k.Token())
+
+	// the secret is never written, only its hash
+	cp := k.clone()
+	assert.Equal(t, k.Token(), cp.Token())
+	assert.NotEqual(t, hashApiKeySecret("secret"), hashApiKeySecret("other"))
+}
+
+func TestServiceAccountRole_Clone(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	r, err := NewServiceAccountRole("r_1234567890", "sa_1234567890")
+	require.NoError(t, err)
+	cp := r.Clone().(*ServiceAccountRole)
+	assert.Equal(r.ServiceAccountRole, cp.ServiceAccountRole)
+
+	other := allocServiceAccountRole()
+	assert.Equal(serviceAccountRoleDefaultTable, other.TableName())
+	other.SetTableName("other")
+	assert.Equal("other", other.TableName())
+}
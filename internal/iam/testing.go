@@ -273,6 +273,35 @@ func TestManagedGroupRole(t testing.TB, conn *db.DB, roleId, managedGrpId string
 	return r
 }
 
+func TestServiceAccount(t testing.TB, conn *db.DB, scopeId string, opt ...Option) *ServiceAccount {
+	t.Helper()
+	ctx := context.Background()
+	require := require.New(t)
+	rw := db.New(conn)
+
+	sa, err := NewServiceAccount(ctx, scopeId, opt...)
+	require.NoError(err)
+	id, err := newServiceAccountId()
+	require.NoError(err)
+	sa.PublicId = id
+	err = rw.Create(ctx, sa)
+	require.NoError(err)
+	require.NotEmpty(sa.PublicId)
+	return sa
+}
+
+func TestServiceAccountRole(t testing.TB, conn *db.DB, roleId, serviceAccountId string, opt ...Option) *ServiceAccountRole {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	r, err := NewServiceAccountRole(roleId, serviceAccountId, opt...)
+	require.NoError(err)
+
+	err = rw.Create(context.Background(), r)
+	require.NoError(err)
+	return r
+}
+
 // testAccount is a temporary test function.  TODO - replace with an auth
 // subsystem testAccount function.  If userId is zero value, then an auth
 // account will be created with a null IamUserId
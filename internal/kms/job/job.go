@@ -28,6 +28,13 @@ func RegisterJobs(ctx context.Context, s *scheduler.Scheduler, kmsRepo *kms.Kms)
 	if err := s.RegisterJob(ctx, dataKeyVersionDestructionMonitorJob); err != nil {
 		return errors.Wrap(ctx, err, op)
 	}
+	keyRotationJob, err := newKeyRotationJob(ctx, kmsRepo)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := s.RegisterJob(ctx, keyRotationJob); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
 	for _, tableName := range kms.ListTablesSupportingRewrap() {
 		tableRewrappingJob, err := newTableRewrappingJob(ctx, kmsRepo, tableName)
 		if err != nil {
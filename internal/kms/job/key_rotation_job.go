@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+type keyRotationJob struct {
+	kmsRepo *kms.Kms
+
+	// rotated is the number of scopes whose keys were rotated by the last run
+	rotated int
+}
+
+func newKeyRotationJob(ctx context.Context, kmsRepo *kms.Kms) (*keyRotationJob, error) {
+	const op = "kms.newKeyRotationJob"
+	if kmsRepo == nil {
+		return nil, errors.New(ctx, errors.Internal, "nil kms repo", op, errors.WithoutEvent())
+	}
+
+	return &keyRotationJob{
+		kmsRepo: kmsRepo,
+	}, nil
+}
+
+// Status reports the job’s current status. The number of scopes rotated is
+// only known once a run has completed.
+func (r *keyRotationJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: r.rotated,
+		Total:     r.rotated,
+	}
+}
+
+// Run performs the required work depending on the implementation.
+// The context is used to notify the job that it should exit early.
+func (r *keyRotationJob) Run(ctx context.Context) error {
+	const op = "kmsjob.(keyRotationJob).Run"
+
+	rotated, err := r.kmsRepo.RotateScheduledKeys(ctx)
+	r.rotated = rotated
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	return nil
+}
+
+// NextRunIn returns the duration until the next job run should be scheduled.
+// Key rotation policies are checked every minute, so a rotation runs within
+// about a minute of being due.
+func (r *keyRotationJob) NextRunIn(_ context.Context) (time.Duration, error) {
+	return time.Minute, nil
+}
+
+// Name is the unique name of the job.
+func (r *keyRotationJob) Name() string {
+	return "key-rotation-job"
+}
+
+// Description is the human readable description of the job.
+func (r *keyRotationJob) Description() string {
+	return "Rotate the keys of scopes with a key rotation policy when they are due"
+}
@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kms
+
+import (
+	"github.com/hashicorp/boundary/internal/kms/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// KeyRotation is used to read and write
+// the history of key rotations in the DB.
+type KeyRotation struct {
+	*store.KeyRotation
+}
+
+func (k *KeyRotation) TableName() string {
+	return "kms_key_rotation"
+}
+
+// allocKeyRotation makes an empty one in memory.
+func allocKeyRotation() KeyRotation {
+	return KeyRotation{
+		KeyRotation: &store.KeyRotation{},
+	}
+}
+
+// Clone a KeyRotation
+func (c *KeyRotation) Clone() *KeyRotation {
+	cp := proto.Clone(c.KeyRotation)
+	return &KeyRotation{
+		KeyRotation: cp.(*store.KeyRotation),
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kms
+
+import (
+	"github.com/hashicorp/boundary/internal/kms/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// KeyRotationPolicy is used to read and write
+// key rotation policies in the DB.
+type KeyRotationPolicy struct {
+	*store.KeyRotationPolicy
+}
+
+func (k *KeyRotationPolicy) TableName() string {
+	return "kms_key_rotation_policy"
+}
+
+// allocKeyRotationPolicy makes an empty one in memory.
+func allocKeyRotationPolicy() KeyRotationPolicy {
+	return KeyRotationPolicy{
+		KeyRotationPolicy: &store.KeyRotationPolicy{},
+	}
+}
+
+// Clone a KeyRotationPolicy
+func (c *KeyRotationPolicy) Clone() *KeyRotationPolicy {
+	cp := proto.Clone(c.KeyRotationPolicy)
+	return &KeyRotationPolicy{
+		KeyRotationPolicy: cp.(*store.KeyRotationPolicy),
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/go-dbw"
 	wrappingKms "github.com/hashicorp/go-kms-wrapping/extras/kms/v2"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-multierror"
 	"golang.org/x/exp/slices"
 )
 
@@ -238,7 +239,8 @@ func (k *Kms) ListKeys(ctx context.Context, scopeId string, _ ...Option) ([]wrap
 	return keys, nil
 }
 
-// RotateKeys rotates all keys in a given scope.
+// RotateKeys rotates all keys in a given scope and records the rotation in
+// the key rotation history of the scope.
 // Options supported: withRandomReader, withRewrap, withReader, withWriter
 // When withReader or withWriter is used, both must be passed and the caller will
 // be responsible for managing the underlying db transactions.
@@ -250,6 +252,7 @@ func (k *Kms) RotateKeys(ctx context.Context, scopeId string, opt ...Option) err
 		wrappingKms.WithRandomReader(opts.withRandomReader),
 		wrappingKms.WithRewrap(opts.withRewrap),
 	}
+	writer := k.writer
 
 	switch {
 	case !isNil(opts.withReader) && isNil(opts.withWriter):
@@ -266,12 +269,22 @@ func (k *Kms) RotateKeys(ctx context.Context, scopeId string, opt ...Option) err
 			return errors.New(ctx, errors.InvalidParameter, op, "unable to convert writer to db.Db")
 		}
 		kmsOpts = append(kmsOpts, wrappingKms.WithReaderWriter(db.NewChangeSafeDbwReader(r), db.NewChangeSafeDbwWriter(w)))
+		writer = w
 	}
 
 	err := k.underlying.RotateKeys(ctx, scopeId, kmsOpts...)
 	if err != nil {
 		return errors.Wrap(ctx, err, op)
 	}
+
+	rotation := allocKeyRotation()
+	rotation.ScopeId = scopeId
+	rotation.Scheduled = opts.withScheduledRotation
+	rotation.Rewrap = opts.withRewrap
+	rotation.DestroyPreviousVersions = opts.withDestroyPreviousVersions
+	if err := writer.Create(ctx, &rotation); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("failed to record key rotation"))
+	}
 	return nil
 }
 
@@ -574,6 +587,182 @@ keyLoop:
 	return false, err
 }
 
+// SetKeyRotationPolicy sets the interval at which the keys in the scope are
+// rotated by the key rotation job, and whether the data key versions that
+// were active before each scheduled rotation are destroyed. The next rotation
+// is scheduled one interval after the last scheduled rotation, or from now if
+// there has not been one. An interval of zero removes the policy of the
+// scope, in which case no policy is returned.
+// Options are ignored.
+func (k *Kms) SetKeyRotationPolicy(ctx context.Context, scopeId string, interval time.Duration, destroyPreviousVersions bool, _ ...Option) (*KeyRotationPolicy, error) {
+	const op = "kms.(Kms).SetKeyRotationPolicy"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	if interval < 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "rotation interval must not be negative")
+	}
+	if interval > 0 && interval < time.Second {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "rotation interval must be at least one second")
+	}
+
+	policy := allocKeyRotationPolicy()
+	policy.ScopeId = scopeId
+	if _, err := k.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, func(r db.Reader, w db.Writer) error {
+		if interval == 0 {
+			if _, err := w.Delete(ctx, &policy); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("failed to delete key rotation policy"))
+			}
+			return nil
+		}
+		args := []any{
+			sql.Named("scope_id", scopeId),
+			sql.Named("rotation_interval_seconds", int64(interval/time.Second)),
+			sql.Named("destroy_previous_versions", destroyPreviousVersions),
+		}
+		if _, err := w.Exec(ctx, upsertKeyRotationPolicyQuery, args); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("failed to set key rotation policy"))
+		}
+		if err := r.LookupById(ctx, &policy); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("failed to look up key rotation policy"))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if interval == 0 {
+		return nil, nil
+	}
+	return &policy, nil
+}
+
+// LookupKeyRotationPolicy returns the key rotation policy of the scope, or
+// nil if the scope has none.
+// Options are ignored.
+func (k *Kms) LookupKeyRotationPolicy(ctx context.Context, scopeId string, _ ...Option) (*KeyRotationPolicy, error) {
+	const op = "kms.(Kms).LookupKeyRotationPolicy"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	policy := allocKeyRotationPolicy()
+	policy.ScopeId = scopeId
+	if err := k.reader.LookupById(ctx, &policy); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return &policy, nil
+}
+
+// ListKeyRotations lists the rotations of the keys in the scope, most recent
+// first.
+// Options supported: WithLimit
+func (k *Kms) ListKeyRotations(ctx context.Context, scopeId string, opt ...Option) ([]*KeyRotation, error) {
+	const op = "kms.(Kms).ListKeyRotations"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	opts := getOpts(opt...)
+	var rotations []*KeyRotation
+	if err := k.reader.SearchWhere(ctx, &rotations, "scope_id=?", []any{scopeId}, db.WithOrder("rotate_time desc"), db.WithLimit(opts.withLimit)); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return rotations, nil
+}
+
+// RotateScheduledKeys rotates the keys of each scope whose key rotation
+// policy is due, rewrapping existing keys with the new root key version. If
+// the policy destroys previous versions, the destruction of each data key
+// version that was active before the rotation is then started, other than
+// those of oplog keys. As with DestroyKeyVersion, data encrypted by those
+// versions is rewrapped by the table rewrapping jobs and the versions are
+// destroyed once it has been. A failure to rotate the keys of one scope does
+// not prevent the others from being rotated, and the errors of every scope
+// that failed are returned together. The number of scopes whose keys were
+// rotated is returned.
+// Options are ignored.
+func (k *Kms) RotateScheduledKeys(ctx context.Context, _ ...Option) (int, error) {
+	const op = "kms.(Kms).RotateScheduledKeys"
+
+	var policies []*KeyRotationPolicy
+	if err := k.reader.SearchWhere(ctx, &policies, "next_rotation_time<=current_timestamp", nil, db.WithOrder("next_rotation_time asc"), db.WithLimit(-1)); err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("failed to find due key rotation policies"))
+	}
+	var rotated int
+	var retErr *multierror.Error
+	for _, policy := range policies {
+		ok, err := k.rotateScheduledKeys(ctx, policy)
+		if ok {
+			rotated++
+		}
+		if err != nil {
+			retErr = multierror.Append(retErr, errors.Wrap(ctx, err, op, errors.WithMsg("failed to rotate keys of scope %q", policy.GetScopeId())))
+		}
+	}
+	return rotated, retErr.ErrorOrNil()
+}
+
+// rotateScheduledKeys rotates the keys of the scope of the policy if it is
+// still due, and reports whether it did. The destruction of previous
+// versions is started once the rotation has been committed.
+func (k *Kms) rotateScheduledKeys(ctx context.Context, policy *KeyRotationPolicy) (bool, error) {
+	const op = "kms.(Kms).rotateScheduledKeys"
+	scopeId := policy.GetScopeId()
+
+	var previousVersionIds []string
+	if policy.GetDestroyPreviousVersions() {
+		keys, err := k.underlying.ListKeys(ctx, scopeId)
+		if err != nil {
+			return false, errors.Wrap(ctx, err, op)
+		}
+		for _, key := range keys {
+			switch {
+			case key.Purpose == wrappingKms.KeyPurposeRootKey,
+				key.Purpose == wrappingKms.KeyPurpose(KeyPurposeOplog.String()),
+				len(key.Versions) == 0:
+				continue
+			}
+			active := key.Versions[0]
+			for _, v := range key.Versions[1:] {
+				if v.Version > active.Version {
+					active = v
+				}
+			}
+			previousVersionIds = append(previousVersionIds, active.Id)
+		}
+	}
+
+	var rotated bool
+	if _, err := k.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, func(r db.Reader, w db.Writer) error {
+		rowsUpdated, err := w.Exec(ctx, scheduleNextKeyRotationQuery, []any{scopeId})
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("failed to schedule next key rotation"))
+		}
+		if rowsUpdated == 0 {
+			// The policy was changed or removed since it was read
+			return nil
+		}
+		if err := k.RotateKeys(ctx, scopeId, WithRewrap(true), WithReaderWriter(r, w), withScheduledRotation(policy.GetDestroyPreviousVersions())); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		rotated = true
+		return nil
+	}); err != nil {
+		return false, err
+	}
+	if !rotated {
+		return false, nil
+	}
+
+	for _, id := range previousVersionIds {
+		if _, err := k.DestroyKeyVersion(ctx, scopeId, id); err != nil {
+			return true, errors.Wrap(ctx, err, op, errors.WithMsg("failed to destroy key version %q", id))
+		}
+	}
+	return true, nil
+}
+
 // VerifyGlobalRoot will verify that the global root wrapper is reasonable.
 func (k *Kms) VerifyGlobalRoot(ctx context.Context) error {
 	const op = "kms.(Kms).VerifyGlobalRoot"
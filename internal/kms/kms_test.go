@@ -8,11 +8,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	wrappingKms "github.com/hashicorp/go-kms-wrapping/extras/kms/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 func Test_New(t *testing.T) {
@@ -905,6 +907,172 @@ func TestDestroyKeyVersion(t *testing.T) {
 	})
 }
 
+func TestKeyRotationPolicy(t *testing.T) {
+	t.Parallel()
+	testCtx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	extWrapper := db.TestWrapper(t)
+	kmsCache := TestKms(t, conn, extWrapper)
+	require.NoError(t, kmsCache.CreateKeys(testCtx, "global"))
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := kmsCache.SetKeyRotationPolicy(testCtx, "", time.Hour, false)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = kmsCache.SetKeyRotationPolicy(testCtx, "global", -time.Hour, false)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = kmsCache.SetKeyRotationPolicy(testCtx, "global", time.Millisecond, false)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = kmsCache.LookupKeyRotationPolicy(testCtx, "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = kmsCache.ListKeyRotations(testCtx, "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("set-update-and-remove", func(t *testing.T) {
+		got, err := kmsCache.LookupKeyRotationPolicy(testCtx, "global")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+
+		p, err := kmsCache.SetKeyRotationPolicy(testCtx, "global", 90*24*time.Hour, false)
+		require.NoError(t, err)
+		assert.Equal(t, int64(90*24*60*60), p.GetRotationIntervalSeconds())
+		assert.False(t, p.GetDestroyPreviousVersions())
+		assert.Nil(t, p.GetLastRotationTime())
+		assert.WithinDuration(t, p.GetCreateTime().AsTime().Add(90*24*time.Hour), p.GetNextRotationTime().AsTime(), time.Second)
+
+		// Changing the interval reschedules from when the policy was set
+		p, err = kmsCache.SetKeyRotationPolicy(testCtx, "global", 30*24*time.Hour, true)
+		require.NoError(t, err)
+		assert.True(t, p.GetDestroyPreviousVersions())
+		assert.WithinDuration(t, p.GetCreateTime().AsTime().Add(30*24*time.Hour), p.GetNextRotationTime().AsTime(), time.Second)
+
+		got, err = kmsCache.LookupKeyRotationPolicy(testCtx, "global")
+		require.NoError(t, err)
+		assert.Empty(t, cmp.Diff(p.KeyRotationPolicy, got.KeyRotationPolicy, protocmp.Transform()))
+
+		p, err = kmsCache.SetKeyRotationPolicy(testCtx, "global", 0, false)
+		require.NoError(t, err)
+		assert.Nil(t, p)
+		got, err = kmsCache.LookupKeyRotationPolicy(testCtx, "global")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("manual-rotations-are-recorded", func(t *testing.T) {
+		before, err := kmsCache.ListKeyRotations(testCtx, "global")
+		require.NoError(t, err)
+		require.NoError(t, kmsCache.RotateKeys(testCtx, "global", WithRewrap(true)))
+		rotations, err := kmsCache.ListKeyRotations(testCtx, "global")
+		require.NoError(t, err)
+		require.Len(t, rotations, len(before)+1)
+		assert.False(t, rotations[0].GetScheduled())
+		assert.True(t, rotations[0].GetRewrap())
+		assert.False(t, rotations[0].GetDestroyPreviousVersions())
+	})
+}
+
+func TestRotateScheduledKeys(t *testing.T) {
+	t.Parallel()
+	testCtx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	extWrapper := db.TestWrapper(t)
+	kmsCache := TestKms(t, conn, extWrapper)
+	require.NoError(t, kmsCache.CreateKeys(testCtx, "global"))
+	rw := db.New(conn)
+
+	activeVersions := func() map[wrappingKms.KeyPurpose]wrappingKms.KeyVersion {
+		keys, err := kmsCache.ListKeys(testCtx, "global")
+		require.NoError(t, err)
+		active := make(map[wrappingKms.KeyPurpose]wrappingKms.KeyVersion, len(keys))
+		for _, key := range keys {
+			for _, v := range key.Versions {
+				if v.Version > active[key.Purpose].Version {
+					active[key.Purpose] = v
+				}
+			}
+		}
+		return active
+	}
+	makeDue := func() {
+		_, err := rw.Exec(testCtx, "update kms_key_rotation_policy set next_rotation_time = now() - interval '1 minute' where scope_id = 'global'", nil)
+		require.NoError(t, err)
+	}
+
+	_, err := kmsCache.SetKeyRotationPolicy(testCtx, "global", time.Hour, false)
+	require.NoError(t, err)
+
+	t.Run("not-due", func(t *testing.T) {
+		before := activeVersions()
+		rotated, err := kmsCache.RotateScheduledKeys(testCtx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, rotated)
+		assert.Equal(t, before, activeVersions())
+	})
+
+	t.Run("due", func(t *testing.T) {
+		before := activeVersions()
+		makeDue()
+		rotated, err := kmsCache.RotateScheduledKeys(testCtx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, rotated)
+		after := activeVersions()
+		for purpose, v := range before {
+			assert.Equal(t, v.Version+1, after[purpose].Version, purpose)
+		}
+
+		p, err := kmsCache.LookupKeyRotationPolicy(testCtx, "global")
+		require.NoError(t, err)
+		require.NotNil(t, p.GetLastRotationTime())
+		assert.WithinDuration(t, p.GetLastRotationTime().AsTime().Add(time.Hour), p.GetNextRotationTime().AsTime(), time.Second)
+
+		rotations, err := kmsCache.ListKeyRotations(testCtx, "global", WithLimit(1))
+		require.NoError(t, err)
+		require.Len(t, rotations, 1)
+		assert.True(t, rotations[0].GetScheduled())
+		assert.True(t, rotations[0].GetRewrap())
+		assert.False(t, rotations[0].GetDestroyPreviousVersions())
+
+		// The policy is no longer due
+		rotated, err = kmsCache.RotateScheduledKeys(testCtx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, rotated)
+	})
+
+	t.Run("destroy-previous-versions", func(t *testing.T) {
+		_, err := kmsCache.SetKeyRotationPolicy(testCtx, "global", time.Hour, true)
+		require.NoError(t, err)
+		before := activeVersions()
+		makeDue()
+		rotated, err := kmsCache.RotateScheduledKeys(testCtx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, rotated)
+
+		keys, err := kmsCache.ListKeys(testCtx, "global")
+		require.NoError(t, err)
+		versionIds := make(map[string]bool)
+		for _, key := range keys {
+			for _, v := range key.Versions {
+				versionIds[v.Id] = true
+			}
+		}
+		for purpose, v := range before {
+			switch purpose {
+			case wrappingKms.KeyPurposeRootKey, wrappingKms.KeyPurpose(KeyPurposeOplog.String()):
+				assert.True(t, versionIds[v.Id], "%s version should not be destroyed", purpose)
+			default:
+				// None of the data key versions encrypt any data, so they are
+				// destroyed immediately
+				assert.False(t, versionIds[v.Id], "%s version should be destroyed", purpose)
+			}
+		}
+
+		rotations, err := kmsCache.ListKeyRotations(testCtx, "global", WithLimit(1))
+		require.NoError(t, err)
+		require.Len(t, rotations, 1)
+		assert.True(t, rotations[0].GetDestroyPreviousVersions())
+	})
+}
+
 func Test_RegisterTableRewrapFn(t *testing.T) {
 	rewrapFn := func(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kms GetWrapperer) error {
 		return nil
@@ -36,6 +36,8 @@ type options struct {
 	withReader                   db.Reader
 	withWriter                   db.Writer
 	withRewrap                   bool
+	withScheduledRotation        bool
+	withDestroyPreviousVersions  bool
 }
 
 func getDefaultOptions() options {
@@ -132,3 +134,12 @@ func WithRewrap(enableRewrap bool) Option {
 		o.withRewrap = enableRewrap
 	}
 }
+
+// withScheduledRotation records a key rotation as run by a key rotation
+// policy, and whether it destroys the previously active key versions.
+func withScheduledRotation(destroyPreviousVersions bool) Option {
+	return func(o *options) {
+		o.withScheduledRotation = true
+		o.withDestroyPreviousVersions = destroyPreviousVersions
+	}
+}
@@ -70,4 +70,29 @@ having
 	// with a specific data key version ID in a table. The interpolated
 	// variable is the table name.
 	findAffectedRowsForKeyQueryTemplate = `select count(*) from %q where key_id=?`
+	// upsertKeyRotationPolicyQuery sets the key rotation policy of a scope.
+	// The next rotation is scheduled one interval after the last scheduled
+	// rotation, or after the policy was first set if there has not been one.
+	upsertKeyRotationPolicyQuery = `
+insert into kms_key_rotation_policy
+	(scope_id, rotation_interval_seconds, destroy_previous_versions, next_rotation_time)
+values
+	(@scope_id, @rotation_interval_seconds, @destroy_previous_versions, current_timestamp + make_interval(secs => @rotation_interval_seconds))
+on conflict (scope_id) do update
+set
+	rotation_interval_seconds=excluded.rotation_interval_seconds,
+	destroy_previous_versions=excluded.destroy_previous_versions,
+	next_rotation_time=coalesce(kms_key_rotation_policy.last_rotation_time, kms_key_rotation_policy.create_time) + make_interval(secs => excluded.rotation_interval_seconds)
+`
+	// scheduleNextKeyRotationQuery records a scheduled rotation of the keys
+	// of a scope and schedules the next one. It only updates the policy if the
+	// rotation is still due, so that a rotation is not run twice.
+	scheduleNextKeyRotationQuery = `
+update kms_key_rotation_policy
+set
+	last_rotation_time=current_timestamp,
+	next_rotation_time=current_timestamp + make_interval(secs => rotation_interval_seconds)
+where
+	scope_id=? and next_rotation_time<=current_timestamp
+`
 )
@@ -0,0 +1,216 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        (unknown)
+// source: controller/storage/kms/store/v1/key_rotation.proto
+
+package store
+
+import (
+	timestamp "github.com/hashicorp/boundary/internal/db/timestamp"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// KeyRotation is used to read and write
+// data from the kms_key_rotation table.
+type KeyRotation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// scope_id is the id of the scope whose keys were rotated.
+	// @inject_tag: `gorm:"primary_key"`
+	ScopeId string `protobuf:"bytes,10,opt,name=scope_id,json=scopeId,proto3" json:"scope_id,omitempty" gorm:"primary_key"`
+	// rotate_time is when the keys were rotated.
+	// @inject_tag: `gorm:"primary_key;default:current_timestamp"`
+	RotateTime *timestamp.Timestamp `protobuf:"bytes,20,opt,name=rotate_time,json=rotateTime,proto3" json:"rotate_time,omitempty" gorm:"primary_key;default:current_timestamp"`
+	// scheduled is set if the rotation was run by a key rotation policy.
+	// @inject_tag: `gorm:"not_null"`
+	Scheduled bool `protobuf:"varint,30,opt,name=scheduled,proto3" json:"scheduled,omitempty" gorm:"not_null"`
+	// rewrap is set if existing keys were rewrapped with the new root key
+	// version.
+	// @inject_tag: `gorm:"not_null"`
+	Rewrap bool `protobuf:"varint,40,opt,name=rewrap,proto3" json:"rewrap,omitempty" gorm:"not_null"`
+	// destroy_previous_versions is set if the destruction of the key versions
+	// that were active before the rotation was started.
+	// @inject_tag: `gorm:"not_null"`
+	DestroyPreviousVersions bool `protobuf:"varint,50,opt,name=destroy_previous_versions,json=destroyPreviousVersions,proto3" json:"destroy_previous_versions,omitempty" gorm:"not_null"`
+}
+
+func (x *KeyRotation) Reset() {
+	*x = KeyRotation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_storage_kms_store_v1_key_rotation_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyRotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRotation) ProtoMessage() {}
+
+func (x *KeyRotation) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_storage_kms_store_v1_key_rotation_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRotation.ProtoReflect.Descriptor instead.
+func (*KeyRotation) Descriptor() ([]byte, []int) {
+	return file_controller_storage_kms_store_v1_key_rotation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *KeyRotation) GetScopeId() string {
+	if x != nil {
+		return x.ScopeId
+	}
+	return ""
+}
+
+func (x *KeyRotation) GetRotateTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.RotateTime
+	}
+	return nil
+}
+
+func (x *KeyRotation) GetScheduled() bool {
+	if x != nil {
+		return x.Scheduled
+	}
+	return false
+}
+
+func (x *KeyRotation) GetRewrap() bool {
+	if x != nil {
+		return x.Rewrap
+	}
+	return false
+}
+
+func (x *KeyRotation) GetDestroyPreviousVersions() bool {
+	if x != nil {
+		return x.DestroyPreviousVersions
+	}
+	return false
+}
+
+var File_controller_storage_kms_store_v1_key_rotation_proto protoreflect.FileDescriptor
+
+var file_controller_storage_kms_store_v1_key_rotation_proto_rawDesc = []byte{
+	0x0a, 0x32, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2f, 0x6b, 0x6d, 0x73, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x76,
+	0x31, 0x2f, 0x6b, 0x65, 0x79, 0x5f, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x6b, 0x6d, 0x73, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe7, 0x01, 0x0a, 0x0b, 0x4b, 0x65, 0x79, 0x52, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x49,
+	0x64, 0x12, 0x4b, 0x0a, 0x0b, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0a, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x18, 0x1e, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x77, 0x72, 0x61, 0x70, 0x18, 0x28, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65,
+	0x77, 0x72, 0x61, 0x70, 0x12, 0x3a, 0x0a, 0x19, 0x64, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x5f,
+	0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x32, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x64, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79,
+	0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68,
+	0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70, 0x2f, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72,
+	0x79, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6b, 0x6d, 0x73, 0x2f, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x3b, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_controller_storage_kms_store_v1_key_rotation_proto_rawDescOnce sync.Once
+	file_controller_storage_kms_store_v1_key_rotation_proto_rawDescData = file_controller_storage_kms_store_v1_key_rotation_proto_rawDesc
+)
+
+func file_controller_storage_kms_store_v1_key_rotation_proto_rawDescGZIP() []byte {
+	file_controller_storage_kms_store_v1_key_rotation_proto_rawDescOnce.Do(func() {
+		file_controller_storage_kms_store_v1_key_rotation_proto_rawDescData = protoimpl.X.CompressGZIP(file_controller_storage_kms_store_v1_key_rotation_proto_rawDescData)
+	})
+	return file_controller_storage_kms_store_v1_key_rotation_proto_rawDescData
+}
+
+var file_controller_storage_kms_store_v1_key_rotation_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_controller_storage_kms_store_v1_key_rotation_proto_goTypes = []interface{}{
+	(*KeyRotation)(nil),         // 0: controller.storage.kms.store.v1.KeyRotation
+	(*timestamp.Timestamp)(nil), // 1: controller.storage.timestamp.v1.Timestamp
+}
+var file_controller_storage_kms_store_v1_key_rotation_proto_depIdxs = []int32{
+	1, // 0: controller.storage.kms.store.v1.KeyRotation.rotate_time:type_name -> controller.storage.timestamp.v1.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_controller_storage_kms_store_v1_key_rotation_proto_init() }
+func file_controller_storage_kms_store_v1_key_rotation_proto_init() {
+	if File_controller_storage_kms_store_v1_key_rotation_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_controller_storage_kms_store_v1_key_rotation_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyRotation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_controller_storage_kms_store_v1_key_rotation_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_controller_storage_kms_store_v1_key_rotation_proto_goTypes,
+		DependencyIndexes: file_controller_storage_kms_store_v1_key_rotation_proto_depIdxs,
+		MessageInfos:      file_controller_storage_kms_store_v1_key_rotation_proto_msgTypes,
+	}.Build()
+	File_controller_storage_kms_store_v1_key_rotation_proto = out.File
+	file_controller_storage_kms_store_v1_key_rotation_proto_rawDesc = nil
+	file_controller_storage_kms_store_v1_key_rotation_proto_goTypes = nil
+	file_controller_storage_kms_store_v1_key_rotation_proto_depIdxs = nil
+}
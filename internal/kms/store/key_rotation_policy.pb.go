@@ -0,0 +1,256 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        (unknown)
+// source: controller/storage/kms/store/v1/key_rotation_policy.proto
+
+package store
+
+import (
+	timestamp "github.com/hashicorp/boundary/internal/db/timestamp"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// KeyRotationPolicy is used to read and write
+// data from the kms_key_rotation_policy table.
+type KeyRotationPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// scope_id is the id of the scope whose keys are rotated.
+	// @inject_tag: `gorm:"primary_key"`
+	ScopeId string `protobuf:"bytes,10,opt,name=scope_id,json=scopeId,proto3" json:"scope_id,omitempty" gorm:"primary_key"`
+	// rotation_interval_seconds is the number of seconds between rotations.
+	// @inject_tag: `gorm:"not_null"`
+	RotationIntervalSeconds int64 `protobuf:"varint,20,opt,name=rotation_interval_seconds,json=rotationIntervalSeconds,proto3" json:"rotation_interval_seconds,omitempty" gorm:"not_null"`
+	// destroy_previous_versions is set if the key versions that were active
+	// before a scheduled rotation are destroyed once the data they encrypt
+	// has been rewrapped.
+	// @inject_tag: `gorm:"not_null"`
+	DestroyPreviousVersions bool `protobuf:"varint,30,opt,name=destroy_previous_versions,json=destroyPreviousVersions,proto3" json:"destroy_previous_versions,omitempty" gorm:"not_null"`
+	// next_rotation_time is when the keys are next rotated.
+	// @inject_tag: `gorm:"default:current_timestamp"`
+	NextRotationTime *timestamp.Timestamp `protobuf:"bytes,40,opt,name=next_rotation_time,json=nextRotationTime,proto3" json:"next_rotation_time,omitempty" gorm:"default:current_timestamp"`
+	// last_rotation_time is when the keys were last rotated by the policy.
+	// @inject_tag: `gorm:"default:null"`
+	LastRotationTime *timestamp.Timestamp `protobuf:"bytes,50,opt,name=last_rotation_time,json=lastRotationTime,proto3" json:"last_rotation_time,omitempty" gorm:"default:null"`
+	// create_time from the RDBMS
+	// @inject_tag: `gorm:"default:current_timestamp"`
+	CreateTime *timestamp.Timestamp `protobuf:"bytes,60,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty" gorm:"default:current_timestamp"`
+	// update_time from the RDBMS
+	// @inject_tag: `gorm:"default:current_timestamp"`
+	UpdateTime *timestamp.Timestamp `protobuf:"bytes,70,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty" gorm:"default:current_timestamp"`
+}
+
+func (x *KeyRotationPolicy) Reset() {
+	*x = KeyRotationPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_storage_kms_store_v1_key_rotation_policy_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyRotationPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRotationPolicy) ProtoMessage() {}
+
+func (x *KeyRotationPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_storage_kms_store_v1_key_rotation_policy_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRotationPolicy.ProtoReflect.Descriptor instead.
+func (*KeyRotationPolicy) Descriptor() ([]byte, []int) {
+	return file_controller_storage_kms_store_v1_key_rotation_policy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *KeyRotationPolicy) GetScopeId() string {
+	if x != nil {
+		return x.ScopeId
+	}
+	return ""
+}
+
+func (x *KeyRotationPolicy) GetRotationIntervalSeconds() int64 {
+	if x != nil {
+		return x.RotationIntervalSeconds
+	}
+	return 0
+}
+
+func (x *KeyRotationPolicy) GetDestroyPreviousVersions() bool {
+	if x != nil {
+		return x.DestroyPreviousVersions
+	}
+	return false
+}
+
+func (x *KeyRotationPolicy) GetNextRotationTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.NextRotationTime
+	}
+	return nil
+}
+
+func (x *KeyRotationPolicy) GetLastRotationTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastRotationTime
+	}
+	return nil
+}
+
+func (x *KeyRotationPolicy) GetCreateTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *KeyRotationPolicy) GetUpdateTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+var File_controller_storage_kms_store_v1_key_rotation_policy_proto protoreflect.FileDescriptor
+
+var file_controller_storage_kms_store_v1_key_rotation_policy_proto_rawDesc = []byte{
+	0x0a, 0x39, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2f, 0x6b, 0x6d, 0x73, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x76,
+	0x31, 0x2f, 0x6b, 0x65, 0x79, 0x5f, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1f, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e,
+	0x6b, 0x6d, 0x73, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x2f, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf4, 0x03,
+	0x0a, 0x11, 0x4b, 0x65, 0x79, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x49, 0x64, 0x12, 0x3a,
+	0x0a, 0x19, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x17, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x3a, 0x0a, 0x19, 0x64, 0x65,
+	0x73, 0x74, 0x72, 0x6f, 0x79, 0x5f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x64,
+	0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x58, 0x0a, 0x12, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x72,
+	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x28, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x10,
+	0x6e, 0x65, 0x78, 0x74, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x58, 0x0a, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x32, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x4b, 0x0a, 0x0b, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x3c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x4b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x46, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70, 0x2f, 0x62, 0x6f, 0x75,
+	0x6e, 0x64, 0x61, 0x72, 0x79, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6b,
+	0x6d, 0x73, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x3b, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_controller_storage_kms_store_v1_key_rotation_policy_proto_rawDescOnce sync.Once
+	file_controller_storage_kms_store_v1_key_rotation_policy_proto_rawDescData = file_controller_storage_kms_store_v1_key_rotation_policy_proto_rawDesc
+)
+
+func file_controller_storage_kms_store_v1_key_rotation_policy_proto_rawDescGZIP() []byte {
+	file_controller_storage_kms_store_v1_key_rotation_policy_proto_rawDescOnce.Do(func() {
+		file_controller_storage_kms_store_v1_key_rotation_policy_proto_rawDescData = protoimpl.X.CompressGZIP(file_controller_storage_kms_store_v1_key_rotation_policy_proto_rawDescData)
+	})
+	return file_controller_storage_kms_store_v1_key_rotation_policy_proto_rawDescData
+}
+
+var file_controller_storage_kms_store_v1_key_rotation_policy_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_controller_storage_kms_store_v1_key_rotation_policy_proto_goTypes = []interface{}{
+	(*KeyRotationPolicy)(nil),   // 0: controller.storage.kms.store.v1.KeyRotationPolicy
+	(*timestamp.Timestamp)(nil), // 1: controller.storage.timestamp.v1.Timestamp
+}
+var file_controller_storage_kms_store_v1_key_rotation_policy_proto_depIdxs = []int32{
+	1, // 0: controller.storage.kms.store.v1.KeyRotationPolicy.next_rotation_time:type_name -> controller.storage.timestamp.v1.Timestamp
+	1, // 1: controller.storage.kms.store.v1.KeyRotationPolicy.last_rotation_time:type_name -> controller.storage.timestamp.v1.Timestamp
+	1, // 2: controller.storage.kms.store.v1.KeyRotationPolicy.create_time:type_name -> controller.storage.timestamp.v1.Timestamp
+	1, // 3: controller.storage.kms.store.v1.KeyRotationPolicy.update_time:type_name -> controller.storage.timestamp.v1.Timestamp
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_controller_storage_kms_store_v1_key_rotation_policy_proto_init() }
+func file_controller_storage_kms_store_v1_key_rotation_policy_proto_init() {
+	if File_controller_storage_kms_store_v1_key_rotation_policy_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_controller_storage_kms_store_v1_key_rotation_policy_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyRotationPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_controller_storage_kms_store_v1_key_rotation_policy_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_controller_storage_kms_store_v1_key_rotation_policy_proto_goTypes,
+		DependencyIndexes: file_controller_storage_kms_store_v1_key_rotation_policy_proto_depIdxs,
+		MessageInfos:      file_controller_storage_kms_store_v1_key_rotation_policy_proto_msgTypes,
+	}.Build()
+	File_controller_storage_kms_store_v1_key_rotation_policy_proto = out.File
+	file_controller_storage_kms_store_v1_key_rotation_policy_proto_rawDesc = nil
+	file_controller_storage_kms_store_v1_key_rotation_policy_proto_goTypes = nil
+	file_controller_storage_kms_store_v1_key_rotation_policy_proto_depIdxs = nil
+}
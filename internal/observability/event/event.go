@@ -28,8 +28,18 @@ type UserInfo struct {
 	AuthAccountId string `json:"auth_account_id,omitempty" class:"public"`
 }
 
+// ServiceAccountInfo defines the fields captured about the service account API
+// key used to authenticate a Boundary request.
+type ServiceAccountInfo struct {
+	ServiceAccountId string `json:"id,omitempty" class:"public"`
+	ApiKeyId         string `json:"api_key_id,omitempty" class:"public"`
+	ApiKeyScopeId    string `json:"api_key_scope_id,omitempty" class:"public"`
+}
+
 type GrantsInfo struct {
 	Grants []Grant `json:"grants,omitempty"`
+	// DeniedBy is the deny grant that caused authorization to fail, if any
+	DeniedBy *Grant `json:"denied_by,omitempty"`
 }
 
 type Grant struct {
@@ -45,6 +55,9 @@ type Auth struct {
 	GrantsInfo           *GrantsInfo `json:"grants_info,omitempty"`
 	UserEmail            string      `json:"email,omitempty" class:"sensitive"`
 	UserName             string      `json:"name,omitempty" class:"sensitive"`
+	// ServiceAccountInfo is set when the request was authenticated with a
+	// service account API key
+	ServiceAccountInfo *ServiceAccountInfo `json:"service_account_info,omitempty"`
 }
 
 type Request struct {
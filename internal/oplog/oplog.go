@@ -344,6 +344,8 @@ func (e *Entry) WriteEntryWith(ctx context.Context, tx *Writer, ticket *store.Ti
 			return errors.Wrap(ctx, err, op)
 		}
 	}
+	e.TicketName = ticket.Name
+	e.TicketVersion = ticket.Version
 	rw := dbw.New(tx.DB)
 	if err := rw.Create(ctx, e); err != nil {
 		return errors.Wrap(ctx, err, op, errors.WithMsg("error writing data to storage"))
@@ -372,6 +374,8 @@ func (e *Entry) Write(ctx context.Context, tx *Writer, ticket *store.Ticket) err
 			return errors.Wrap(ctx, err, op)
 		}
 	}
+	e.TicketName = ticket.Name
+	e.TicketVersion = ticket.Version
 	rw := dbw.New(tx.DB)
 	if err := rw.Create(ctx, e); err != nil {
 		return errors.Wrap(ctx, err, op, errors.WithMsg("error writing data to storage"))
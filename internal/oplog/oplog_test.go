@@ -617,6 +617,7 @@ func Test_WriteEntryWith(t *testing.T) {
 			ticketer,
 		)
 		require.NoError(err)
+		ticketVersion := ticket.Version
 		err = newLogEntry.WriteEntryWith(context.Background(), &Writer{db}, ticket,
 			&Message{Message: &u, TypeName: "user", OpType: OpType_OP_TYPE_CREATE},
 			&Message{Message: &u2, TypeName: "user", OpType: OpType_OP_TYPE_CREATE})
@@ -625,6 +626,8 @@ func Test_WriteEntryWith(t *testing.T) {
 		var foundEntry Entry
 		require.NoError(dbw.New(db).LookupWhere(testCtx, &foundEntry, "id = ?", []any{newLogEntry.Id}))
 		require.NoError(err)
+		assert.Equal("default", foundEntry.TicketName)
+		assert.Equal(ticketVersion, foundEntry.TicketVersion)
 		foundEntry.Wrapper = wrapper
 		types, err := NewTypeCatalog(testCtx, Type{new(oplog_test.TestUser), "user"})
 		require.NoError(err)
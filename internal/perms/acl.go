@@ -4,18 +4,27 @@
 package perms
 
 import (
-	"strings"
+	"fmt"
+	"sort"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 )
 
 // ACL provides an entry point into the permissions engine for determining if an
 // action is allowed on a resource based on a principal's (user or group) grants.
 type ACL struct {
+	// scopeMap holds grants that apply directly to the keyed scope
 	scopeMap map[string][]Grant
+	// childrenScopeMap holds grants that apply to the direct children of the
+	// keyed scope
+	childrenScopeMap map[string][]Grant
+	// descendantsGrants holds grants that apply to every scope other than
+	// global
+	descendantsGrants []Grant
 }
 
 // ACLResults provides a type for the permission's engine results so that we can
@@ -26,6 +35,10 @@ type ACLResults struct {
 	Authorized             bool
 	OutputFields           *OutputFields
 
+	// DeniedBy is the deny grant that matched, if any; when set, Authorized
+	// is false regardless of any allow grants
+	DeniedBy *Grant
+
 	// This is included but unexported for testing/debugging
 	scopeMap map[string][]Grant
 }
@@ -40,6 +53,19 @@ type Permission struct {
 	ResourceIds []string // Any specific resource ids that have been referred in the grant's `id` field, if applicable.
 	OnlySelf    bool     // The grant only allows actions against the user's own resources.
 	All         bool     // We got a wildcard in the grant string's `id` field.
+
+	ExcludedResourceIds []string             // Resource ids for which every id action has been denied by a deny grant; only set when All is true.
+	Attributes          []ResourceAttributes // Resources matched by name pattern and tags rather than ID.
+	ExcludedAttributes  []ResourceAttributes // Resources for which every id action has been denied by a deny grant matching on name pattern and tags.
+}
+
+// ResourceAttributes describes a set of resources by name pattern and tags, as
+// granted by grants that match on them. A resource is in the set if its name
+// matches NamePattern (if set), in which "*" matches any run of characters, and
+// it carries every tag in Tags.
+type ResourceAttributes struct {
+	NamePattern string
+	Tags        map[string][]string
 }
 
 // UserPermissions is a set of Permissions for a User.
@@ -54,6 +80,10 @@ type Resource struct {
 	// ScopeId is the scope that contains the Resource.
 	ScopeId string `json:"scope_id,omitempty"`
 
+	// ParentScopeId is the parent of the scope that contains the Resource. It
+	// is used to evaluate grants that apply to the children of a scope.
+	ParentScopeId string `json:"parent_scope_id,omitempty"`
+
 	// Id is the public id of the resource.
 	Id string `json:"id,omitempty"`
 
@@ -63,36 +93,91 @@ type Resource struct {
 	// Pin if defined would constrain the resource within the collection of the
 	// pin id.
 	Pin string `json:"pin,omitempty"`
+
+	// Name of the resource, used to evaluate grants matching on name patterns.
+	Name string `json:"name,omitempty"`
+
+	// Tags of the resource, used to evaluate grants matching on tags.
+	Tags map[string][]string `json:"tags,omitempty"`
 }
 
 // NewACL creates an ACL from the grants provided.
 func NewACL(grants ...Grant) ACL {
 	ret := ACL{
-		scopeMap: make(map[string][]Grant, len(grants)),
+		scopeMap:         make(map[string][]Grant, len(grants)),
+		childrenScopeMap: make(map[string][]Grant),
 	}
 
 	for _, grant := range grants {
-		ret.scopeMap[grant.scope.Id] = append(ret.scopeMap[grant.scope.Id], grant)
+		if grant.unresolved && !grant.deny {
+			// A templated allow grant that could not be expanded for the
+			// user matches nothing. A deny grant is kept and matches every
+			// resource of its type instead, see matchesResource.
+			continue
+		}
+		switch grant.grantScope {
+		case globals.GrantScopeChildren:
+			ret.childrenScopeMap[grant.scope.Id] = append(ret.childrenScopeMap[grant.scope.Id], grant)
+		case globals.GrantScopeDescendants:
+			ret.descendantsGrants = append(ret.descendantsGrants, grant)
+		default:
+			ret.scopeMap[grant.scope.Id] = append(ret.scopeMap[grant.scope.Id], grant)
+		}
 	}
 
 	return ret
 }
 
+// grantsForScope returns the grants that apply to the given scope, whether
+// directly, via the children of its parent, or as a descendant of global.
+func (a ACL) grantsForScope(scopeId, parentScopeId string) []Grant {
+	direct := a.scopeMap[scopeId]
+	var children []Grant
+	if parentScopeId != "" {
+		children = a.childrenScopeMap[parentScopeId]
+	}
+	var descendants []Grant
+	if scopeId != "" && scopeId != scope.Global.String() {
+		descendants = a.descendantsGrants
+	}
+	if len(children) == 0 && len(descendants) == 0 {
+		return direct
+	}
+	ret := make([]Grant, 0, len(direct)+len(children)+len(descendants))
+	ret = append(ret, direct...)
+	ret = append(ret, children...)
+	ret = append(ret, descendants...)
+	return ret
+}
+
 // Allowed determines if the grants for an ACL allow an action for a resource.
 func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option) (results ACLResults) {
 	opts := getOpts(opt...)
 
-	// First, get the grants within the specified scope
-	grants := a.scopeMap[r.ScopeId]
+	// First, get the grants that apply to the specified scope
+	grants := a.grantsForScope(r.ScopeId, r.ParentScopeId)
 	results.scopeMap = a.scopeMap
 
-	var parentAction action.Type
-	split := strings.Split(aType.String(), ":")
-	if len(split) == 2 {
-		parentAction = action.Map[split[0]]
+	anonRestricted := !opts.withSkipAnonymousUserRestrictions &&
+		(userId == globals.AnonymousUserId || userId == "")
+
+	// Deny grants take precedence over allow grants, so check them first
+	for _, grant := range grants {
+		if !grant.deny || !grant.coversAction(aType) {
+			continue
+		}
+		if grant.matchesResource(r, aType) {
+			denied := grant
+			results.DeniedBy = &denied
+			return
+		}
 	}
-	// Now, go through and check the cases indicated above
+
+	// Now, go through the allow grants
 	for _, grant := range grants {
+		if grant.deny {
+			continue
+		}
 		var outputFieldsOnly bool
 		switch {
 		case len(grant.actions) == 0:
@@ -104,14 +189,10 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 			} else {
 				continue
 			}
-		case grant.actions[aType]:
-			// We have this action
-		case grant.actions[parentAction]:
-			// We don't have this action, but it's a subaction and we have the
-			// parent action. As an example, if we are looking for "read:self"
-			// and have "read", this is sufficient.
-		case grant.actions[action.All]:
-			// All actions are allowed
+		case grant.coversAction(aType):
+			// We have this action, or it's a subaction and we have the parent
+			// action (as an example, if we are looking for "read:self" and
+			// have "read", this is sufficient), or all actions are allowed
 		default:
 			// No actions in the grant match what we're looking for, so continue
 			// with the next grant
@@ -124,23 +205,15 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 		// If the action was not found above but we did find output fields in
 		// patterns that match, we do not authorize the request, but we do build
 		// up the output fields patterns.
-		//
-		// Note that when using IsActionOrParent it is merely to test whether it
-		// is an allowed format since some formats operate ony on collections
-		// (or don't operate at all on collections) and we want to ensure that
-		// it is/isn't a create or list command or subcommand to know whether
-		// that form is valid. The actual checking of whether the given action
-		// is granted to the user already happened above.
 		var found bool
 		switch {
-		// Case 1: We only allow specific actions on specific types for the
-		// anonymous user. ID being supplied or not doesn't matter in this case,
-		// it must be an explicit type and action(s); adding this as an explicit
-		// case here prevents duplicating logic in two of the other more
-		// general-purpose cases below (3 and 4). See notes there about ID being
-		// present or not.
-		case !opts.withSkipAnonymousUserRestrictions &&
-			(userId == globals.AnonymousUserId || userId == ""):
+		// We only allow specific actions on specific types for the anonymous
+		// user. ID being supplied or not doesn't matter in this case, it must
+		// be an explicit type and action(s); adding this as an explicit case
+		// here prevents duplicating logic in two of the more general-purpose
+		// cases in matchesResource. See notes there about ID being present or
+		// not.
+		case anonRestricted:
 			switch {
 			// Allow discovery of scopes, so that auth methods within can be
 			// discovered
@@ -156,66 +229,8 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 				found = true
 			}
 
-		// Case 2:
-		// id=<resource.id>;actions=<action> where ID cannot be a wildcard; or
-		// id=<resource.id>;output_fields=<fields> where fields cannot be a
-		// wildcard.
-		case grant.id == r.Id &&
-			grant.id != "" &&
-			grant.id != "*" &&
-			grant.typ == resource.Unknown &&
-			!action.List.IsActionOrParent(aType) &&
-			!action.Create.IsActionOrParent(aType):
-
-			found = true
-
-		// Case 3: type=<resource.type>;actions=<action> when action is list or
-		// create (cannot be a wildcard). Must be a top level collection,
-		// otherwise must be one of the two formats specified in cases 4 or 5.
-		// Or, type=resource.type;output_fields=<fields> and no action. This is
-		// more of a semantic difference compared to 4 more than a security
-		// difference; this type is for clarity as it ties more closely to the
-		// concept of create and list as actions on a collection, operating on a
-		// collection directly. The format in case 4 will still work for
-		// create/list on collections but that's more of a shortcut to allow
-		// things like id=*;type=*;actions=* for admin flows so that you don't
-		// need to separate out explicit collection actions into separate typed
-		// grants for each collection within a role. This does mean there are
-		// "two ways of doing things" but it's a reasonable UX tradeoff given
-		// that "all IDs" can reasonably be construed to include "and the one
-		// I'm making" and "all of them for listing".
-		case grant.id == "" &&
-			r.Id == "" &&
-			grant.typ == r.Type &&
-			grant.typ != resource.Unknown &&
-			resource.TopLevelType(r.Type) &&
-			(action.List.IsActionOrParent(aType) ||
-				action.Create.IsActionOrParent(aType)):
-
-			found = true
-
-		// Case 4:
-		// id=*;type=<resource.type>;actions=<action> where type cannot be
-		// unknown but can be a wildcard to allow any resource at all; or
-		// id=*;type=<resource.type>;output_fields=<fields> with no action.
-		case grant.id == "*" &&
-			grant.typ != resource.Unknown &&
-			(grant.typ == r.Type ||
-				grant.typ == resource.All):
-
-			found = true
-
-		// Case 5:
-		// id=<pin>;type=<resource.type>;actions=<action> where type can be a
-		// wildcard and this this is operating on a non-top-level type. Same for
-		// output fields only.
-		case grant.id != "" &&
-			grant.id == r.Pin &&
-			grant.typ != resource.Unknown &&
-			(grant.typ == r.Type || grant.typ == resource.All) &&
-			!resource.TopLevelType(r.Type):
-
-			found = true
+		default:
+			found = grant.matchesResource(r, aType)
 		}
 
 		if found {
@@ -232,6 +247,262 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 	return
 }
 
+// Explanation details how the grants of an ACL apply to an action on a
+// resource.
+type Explanation struct {
+	// Authorized is true if the action is allowed
+	Authorized bool
+
+	// DeniedBy is the deny grant that matched, if any
+	DeniedBy *Grant
+
+	// AllowedBy contains every allow grant that matched the resource and
+	// action; it is empty if the action is not authorized
+	AllowedBy []Grant
+
+	// RequiredGrant is a grant that would allow the action in the resource's
+	// scope; it is only set if the action is not authorized
+	RequiredGrant string
+}
+
+// Explain determines if the grants for an ACL allow an action for a resource
+// in the same way as Allowed, and reports the grants that led to the decision.
+func (a ACL) Explain(r Resource, aType action.Type, userId string, opt ...Option) Explanation {
+	results := a.Allowed(r, aType, userId, opt...)
+	ret := Explanation{
+		Authorized: results.Authorized,
+		DeniedBy:   results.DeniedBy,
+	}
+	if !results.Authorized {
+		ret.RequiredGrant = RequiredGrant(r, aType)
+		return ret
+	}
+	for _, grant := range a.grantsForScope(r.ScopeId, r.ParentScopeId) {
+		if grant.deny || !grant.coversAction(aType) {
+			continue
+		}
+		if grant.matchesResource(r, aType) {
+			ret.AllowedBy = append(ret.AllowedBy, grant)
+		}
+	}
+	return ret
+}
+
+// RequiredGrant returns the narrowest grant string that allows the action on
+// the resource, when added to a role with the resource's scope as grant scope.
+func RequiredGrant(r Resource, aType action.Type) string {
+	switch {
+	case r.Id != "":
+		return fmt.Sprintf("id=%s;actions=%s", r.Id, aType.String())
+	case r.Pin != "":
+		return fmt.Sprintf("id=%s;type=%s;actions=%s", r.Pin, r.Type.String(), aType.String())
+	default:
+		return fmt.Sprintf("type=%s;actions=%s", r.Type.String(), aType.String())
+	}
+}
+
+// matchesResource checks whether the ID and type of the grant match the
+// resource for the given action, independent of the actions in the grant.
+//
+// Note that when using IsActionOrParent it is merely to test whether it is an
+// allowed format since some formats operate ony on collections (or don't
+// operate at all on collections) and we want to ensure that it is/isn't a
+// create or list command or subcommand to know whether that form is valid. The
+// actual checking of whether the given action is granted is left to the
+// caller.
+func (g Grant) matchesResource(r Resource, aType action.Type) bool {
+	switch {
+	// Case 1: type=<resource.type>;name=<pattern>;tags.<key>=<value>;actions=<action>
+	// where type must support tags. On the collection only list can match,
+	// since the resources returned are narrowed by name and tags later; a
+	// deny grant of this form only denies listing the collection as a whole
+	// if its templates could not be expanded.
+	case g.hasAttributes():
+		switch {
+		case g.id != "",
+			g.typ != r.Type,
+			!resource.Taggable(r.Type),
+			action.Create.IsActionOrParent(aType):
+			return false
+		case r.Id == "":
+			return (!g.deny || g.unresolved) && action.List.IsActionOrParent(aType)
+		}
+		// A deny grant whose templates could not be expanded for the user
+		// can't tell which resources it was meant for, so it denies all
+		return g.unresolved || g.matchesAttributes(r)
+
+	// Case 2:
+	// id=<resource.id>;actions=<action> where ID cannot be a wildcard; or
+	// id=<resource.id>;output_fields=<fields> where fields cannot be a
+	// wildcard.
+	case g.id == r.Id &&
+		g.id != "" &&
+		g.id != "*" &&
+		g.typ == resource.Unknown &&
+		!action.List.IsActionOrParent(aType) &&
+		!action.Create.IsActionOrParent(aType):
+
+		return true
+
+	// Case 3: type=<resource.type>;actions=<action> when action is list or
+	// create (cannot be a wildcard). Must be a top level collection, otherwise
+	// must be one of the two formats specified in cases 4 or 5. Or,
+	// type=resource.type;output_fields=<fields> and no action. This is more of
+	// a semantic difference compared to 4 more than a security difference;
+	// this type is for clarity as it ties more closely to the concept of
+	// create and list as actions on a collection, operating on a collection
+	// directly. The format in case 4 will still work for create/list on
+	// collections but that's more of a shortcut to allow things like
+	// id=*;type=*;actions=* for admin flows so that you don't need to separate
+	// out explicit collection actions into separate typed grants for each
+	// collection within a role. This does mean there are "two ways of doing
+	// things" but it's a reasonable UX tradeoff given that "all IDs" can
+	// reasonably be construed to include "and the one I'm making" and "all of
+	// them for listing".
+	case g.id == "" &&
+		r.Id == "" &&
+		g.typ == r.Type &&
+		g.typ != resource.Unknown &&
+		resource.TopLevelType(r.Type) &&
+		(action.List.IsActionOrParent(aType) ||
+			action.Create.IsActionOrParent(aType)):
+
+		return true
+
+	// Case 4:
+	// id=*;type=<resource.type>;actions=<action> where type cannot be
+	// unknown but can be a wildcard to allow any resource at all; or
+	// id=*;type=<resource.type>;output_fields=<fields> with no action.
+	case g.id == "*" &&
+		g.typ != resource.Unknown &&
+		(g.typ == r.Type ||
+			g.typ == resource.All):
+
+		return true
+
+	// Case 5:
+	// id=<pin>;type=<resource.type>;actions=<action> where type can be a
+	// wildcard and this this is operating on a non-top-level type. Same for
+	// output fields only.
+	case g.id != "" &&
+		g.id == r.Pin &&
+		g.typ != resource.Unknown &&
+		(g.typ == r.Type || g.typ == resource.All) &&
+		!resource.TopLevelType(r.Type):
+
+		return true
+	}
+	return false
+}
+
+// deniedActions holds the actions removed by deny grants within a scope, both
+// for every resource of a type and for specific resource IDs.
+type deniedActions struct {
+	all map[action.Type]bool
+	ids map[string]map[action.Type]bool
+	// attributes are the deny grants matching resources on name and tags
+	attributes []deniedAttributes
+	// list is true if listing the type in the scope is denied outright
+	list bool
+}
+
+// deniedAttributes holds the actions removed by a deny grant for the resources
+// matching its name pattern and tags.
+type deniedAttributes struct {
+	attrs   ResourceAttributes
+	actions map[action.Type]bool
+}
+
+// newDeniedActions collects the deny grants in grants that apply to
+// resources of the requested type.
+func newDeniedActions(grants []Grant, requestedType resource.Type) deniedActions {
+	d := deniedActions{
+		all: make(map[action.Type]bool),
+		ids: make(map[string]map[action.Type]bool),
+	}
+	for _, grant := range grants {
+		if !grant.deny {
+			continue
+		}
+		switch grant.id {
+		case "*":
+			if grant.typ != requestedType && grant.typ != resource.All {
+				continue
+			}
+			for act := range grant.actions {
+				d.all[act] = true
+			}
+			if grant.coversAction(action.List) {
+				d.list = true
+			}
+		case "":
+			if grant.typ != requestedType {
+				continue
+			}
+			// Deny grants matching on name or tags narrow the actions on
+			// individual resources but don't remove listing the type as a
+			// whole
+			if grant.hasAttributes() && !grant.unresolved {
+				d.attributes = append(d.attributes, deniedAttributes{
+					attrs:   ResourceAttributes{NamePattern: grant.name, Tags: grant.tags},
+					actions: grant.actions,
+				})
+				continue
+			}
+			// One whose templates could not be expanded denies its actions
+			// on every resource of the type
+			if grant.unresolved {
+				for act := range grant.actions {
+					d.all[act] = true
+				}
+			}
+			if grant.coversAction(action.List) {
+				d.list = true
+			}
+		default:
+			// Pinned grants operate on non-top-level types, which are not
+			// listed via permissions
+			if grant.typ != resource.Unknown {
+				continue
+			}
+			if globals.ResourceTypeFromPrefix(grant.id) != requestedType {
+				continue
+			}
+			if d.ids[grant.id] == nil {
+				d.ids[grant.id] = make(map[action.Type]bool, len(grant.actions))
+			}
+			for act := range grant.actions {
+				d.ids[grant.id][act] = true
+			}
+		}
+	}
+	return d
+}
+
+// denied checks whether the action is denied for the given resource ID, which
+// may be the wildcard ID.
+func (d deniedActions) denied(id string, act action.Type) bool {
+	if d.all[act] || d.all[action.All] {
+		return true
+	}
+	switch id {
+	case "", "*":
+		return false
+	}
+	return d.ids[id][act] || d.ids[id][action.All]
+}
+
+// deniesAll checks whether every one of the actions is denied for the
+// resources matching the attributes.
+func (d deniedActions) deniesAll(da deniedAttributes, acts action.ActionSet) bool {
+	for _, a := range acts {
+		if !d.denied("*", a) && !da.actions[a] && !da.actions[action.All] {
+			return false
+		}
+	}
+	return true
+}
+
 // ListPermissions builds a set of Permissions based on the grants in the ACL.
 // Permissions are determined for the given resource for each of the provided scopes.
 // There must be a grant for a given resource for one of the provided "id actions"
@@ -244,7 +515,7 @@ func (a ACL) ListPermissions(requestedScopes map[string]*scopes.ScopeInfo,
 	userId string,
 ) []Permission {
 	perms := make([]Permission, 0, len(requestedScopes))
-	for scopeId := range requestedScopes {
+	for scopeId, scopeInfo := range requestedScopes {
 		p := Permission{
 			ScopeId:  scopeId,
 			Resource: requestedType,
@@ -259,21 +530,33 @@ func (a ACL) ListPermissions(requestedScopes map[string]*scopes.ScopeInfo,
 		}
 
 		// Get grants for a specific scope id from the source of truth.
-		grants := a.scopeMap[scopeId]
+		var parentScopeId string
+		if scopeInfo != nil {
+			parentScopeId = scopeInfo.ParentScopeId
+		}
+		grants := a.grantsForScope(scopeId, parentScopeId)
+
+		// Deny grants take precedence over allow grants
+		denied := newDeniedActions(grants, requestedType)
+		if denied.list {
+			continue
+		}
+
 		for _, grant := range grants {
 			// This grant doesn't match what we're looking for, ignore.
-			if grant.typ != requestedType && grant.typ != resource.All {
+			if grant.deny || (grant.typ != requestedType && grant.typ != resource.All) {
 				continue
 			}
 
 			// We found a grant that matches the requested resource type:
-			// Search to see if one or all actions in the action set have been granted.
+			// Search to see if one or all actions in the action set have been
+			// granted and not denied.
 			found := false
-			if ok := grant.actions[action.All]; ok {
+			if grant.actions[action.All] && len(denied.all) == 0 {
 				found = true
 			} else {
 				for _, a := range idActions {
-					if ok := grant.actions[a]; ok {
+					if (grant.actions[a] || grant.actions[action.All]) && !denied.denied(grant.id, a) {
 						found = true
 						break
 					}
@@ -296,13 +579,44 @@ func (a ACL) ListPermissions(requestedScopes map[string]*scopes.ScopeInfo,
 			case "*":
 				p.All = true
 			case "":
+				if grant.hasAttributes() {
+					p.Attributes = append(p.Attributes, ResourceAttributes{
+						NamePattern: grant.name,
+						Tags:        grant.tags,
+					})
+				}
 				continue
 			default:
 				p.ResourceIds = append(p.ResourceIds, grant.id)
 			}
 		}
 
-		if p.All || len(p.ResourceIds) > 0 {
+		if p.All {
+			// Exclude resources for which every id action has been denied
+			for id := range denied.ids {
+				excluded := true
+				for _, a := range idActions {
+					if !denied.denied(id, a) {
+						excluded = false
+						break
+					}
+				}
+				if excluded {
+					p.ExcludedResourceIds = append(p.ExcludedResourceIds, id)
+				}
+			}
+			sort.Strings(p.ExcludedResourceIds)
+		}
+
+		// Exclude resources matching deny grants on name and tags that deny
+		// every id action, however they were granted
+		for _, da := range denied.attributes {
+			if denied.deniesAll(da, idActions) {
+				p.ExcludedAttributes = append(p.ExcludedAttributes, da.attrs)
+			}
+		}
+
+		if p.All || len(p.ResourceIds) > 0 || len(p.Attributes) > 0 {
 			perms = append(perms, p)
 		}
 	}
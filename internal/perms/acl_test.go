@@ -801,6 +801,456 @@ func TestACL_ListPermissions(t *testing.T) {
 	}
 }
 
+func Test_ACLAllowedGrantScopes(t *testing.T) {
+	t.Parallel()
+
+	type grantScopeGrant struct {
+		scope      string
+		grantScope string
+		grant      string
+	}
+	grants := []grantScopeGrant{
+		{scope: "global", grantScope: globals.GrantScopeThis, grant: "id=*;type=scope;actions=read"},
+		{scope: "global", grantScope: globals.GrantScopeChildren, grant: "id=*;type=user;actions=read"},
+		{scope: "global", grantScope: globals.GrantScopeDescendants, grant: "id=*;type=target;actions=read"},
+		{scope: "o_a", grantScope: globals.GrantScopeChildren, grant: "id=*;type=host-catalog;actions=read"},
+	}
+
+	tests := []struct {
+		name       string
+		resource   Resource
+		action     action.Type
+		authorized bool
+	}{
+		{
+			name:       "this applies to own scope",
+			resource:   Resource{ScopeId: "global", Id: "o_a", Type: resource.Scope},
+			action:     action.Read,
+			authorized: true,
+		},
+		{
+			name:     "this does not apply to child",
+			resource: Resource{ScopeId: "o_a", ParentScopeId: "global", Id: "p_a", Type: resource.Scope},
+			action:   action.Read,
+		},
+		{
+			name:       "children applies to child",
+			resource:   Resource{ScopeId: "o_a", ParentScopeId: "global", Id: "u_a", Type: resource.User},
+			action:     action.Read,
+			authorized: true,
+		},
+		{
+			name:     "children does not apply to own scope",
+			resource: Resource{ScopeId: "global", Id: "u_a", Type: resource.User},
+			action:   action.Read,
+		},
+		{
+			name:     "children does not apply to grandchild",
+			resource: Resource{ScopeId: "p_a", ParentScopeId: "o_a", Id: "u_a", Type: resource.User},
+			action:   action.Read,
+		},
+		{
+			name:     "children does not apply without parent",
+			resource: Resource{ScopeId: "o_a", Id: "u_a", Type: resource.User},
+			action:   action.Read,
+		},
+		{
+			name:       "org children applies to project",
+			resource:   Resource{ScopeId: "p_a", ParentScopeId: "o_a", Id: "hcst_a", Type: resource.HostCatalog},
+			action:     action.Read,
+			authorized: true,
+		},
+		{
+			name:     "org children does not apply to other project",
+			resource: Resource{ScopeId: "p_b", ParentScopeId: "o_b", Id: "hcst_a", Type: resource.HostCatalog},
+			action:   action.Read,
+		},
+		{
+			name:       "descendants applies to org",
+			resource:   Resource{ScopeId: "o_b", ParentScopeId: "global", Id: "ttcp_a", Type: resource.Target},
+			action:     action.Read,
+			authorized: true,
+		},
+		{
+			name:       "descendants applies to project",
+			resource:   Resource{ScopeId: "p_b", ParentScopeId: "o_b", Id: "ttcp_a", Type: resource.Target},
+			action:     action.Read,
+			authorized: true,
+		},
+		{
+			name:     "descendants does not apply to global",
+			resource: Resource{ScopeId: "global", Id: "ttcp_a", Type: resource.Target},
+			action:   action.Read,
+		},
+		{
+			name:     "descendants does not grant other actions",
+			resource: Resource{ScopeId: "p_b", ParentScopeId: "o_b", Id: "ttcp_a", Type: resource.Target},
+			action:   action.Delete,
+		},
+	}
+
+	var parsed []Grant
+	for _, g := range grants {
+		grant, err := Parse(g.scope, g.grant, WithGrantScope(g.grantScope))
+		require.NoError(t, err)
+		parsed = append(parsed, grant)
+	}
+	acl := NewACL(parsed...)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			result := acl.Allowed(tt.resource, tt.action, "u_abcd1234")
+			assert.Equal(t, tt.authorized, result.Authorized)
+		})
+	}
+
+	t.Run("list permissions", func(t *testing.T) {
+		perms := acl.ListPermissions(map[string]*scopes.ScopeInfo{
+			"global": {Id: "global"},
+			"o_a":    {Id: "o_a", ParentScopeId: "global"},
+			"p_a":    {Id: "p_a", ParentScopeId: "o_a"},
+		}, resource.Target, action.ActionSet{action.Read}, "u_abcd1234")
+		require.ElementsMatch(t, []Permission{
+			{ScopeId: "o_a", Resource: resource.Target, Action: action.List, All: true},
+			{ScopeId: "p_a", Resource: resource.Target, Action: action.List, All: true},
+		}, perms)
+	})
+}
+
+func Test_ACLAllowedDeny(t *testing.T) {
+	t.Parallel()
+
+	type scopeGrant struct {
+		scope      string
+		grantScope string
+		grant      string
+	}
+	grants := []scopeGrant{
+		{scope: "p_a", grant: "id=*;type=*;actions=*"},
+		{scope: "p_a", grant: "effect=deny;id=*;type=target;actions=delete"},
+		{scope: "p_a", grant: "effect=deny;id=hcst_denied;actions=read"},
+		{scope: "p_a", grant: "effect=deny;id=hcst_a;type=host-set;actions=update"},
+		{scope: "o_a", grantScope: globals.GrantScopeChildren, grant: "effect=deny;id=*;type=session;actions=cancel"},
+		{scope: "p_b", grant: "id=*;type=*;actions=*"},
+		{scope: "p_b", grant: "effect=deny;id=*;type=target;actions=read"},
+	}
+
+	tests := []struct {
+		name       string
+		resource   Resource
+		action     action.Type
+		authorized bool
+		denied     string
+	}{
+		{
+			name:       "not denied",
+			resource:   Resource{ScopeId: "p_a", ParentScopeId: "o_a", Id: "ttcp_a", Type: resource.Target},
+			action:     action.Read,
+			authorized: true,
+		},
+		{
+			name:     "wildcard deny",
+			resource: Resource{ScopeId: "p_a", ParentScopeId: "o_a", Id: "ttcp_a", Type: resource.Target},
+			action:   action.Delete,
+			denied:   "effect=deny;id=*;type=target;actions=delete",
+		},
+		{
+			name:     "id deny",
+			resource: Resource{ScopeId: "p_a", ParentScopeId: "o_a", Id: "hcst_denied", Type: resource.HostCatalog},
+			action:   action.Read,
+			denied:   "effect=deny;id=hcst_denied;actions=read",
+		},
+		{
+			name:       "id deny other id",
+			resource:   Resource{ScopeId: "p_a", ParentScopeId: "o_a", Id: "hcst_other", Type: resource.HostCatalog},
+			action:     action.Read,
+			authorized: true,
+		},
+		{
+			name:     "pinned deny",
+			resource: Resource{ScopeId: "p_a", ParentScopeId: "o_a", Id: "hsst_a", Pin: "hcst_a", Type: resource.HostSet},
+			action:   action.Update,
+			denied:   "effect=deny;id=hcst_a;type=host-set;actions=update",
+		},
+		{
+			name:     "broader scope deny",
+			resource: Resource{ScopeId: "p_a", ParentScopeId: "o_a", Id: "s_a", Type: resource.Session},
+			action:   action.Cancel,
+			denied:   "effect=deny;id=*;type=session;actions=cancel",
+		},
+		{
+			name:     "deny covers subaction",
+			resource: Resource{ScopeId: "p_b", ParentScopeId: "o_b", Id: "ttcp_a", Type: resource.Target},
+			action:   action.ReadSelf,
+			denied:   "effect=deny;id=*;type=target;actions=read",
+		},
+		{
+			name:       "deny does not apply to other scope",
+			resource:   Resource{ScopeId: "p_b", ParentScopeId: "o_b", Id: "ttcp_a", Type: resource.Target},
+			action:     action.Delete,
+			authorized: true,
+		},
+	}
+
+	var parsed []Grant
+	for _, g := range grants {
+		grant, err := Parse(g.scope, g.grant, WithGrantScope(g.grantScope), WithRoleId("r_"+g.scope))
+		require.NoError(t, err)
+		parsed = append(parsed, grant)
+	}
+	acl := NewACL(parsed...)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			result := acl.Allowed(tt.resource, tt.action, "u_abcd1234")
+			assert.Equal(t, tt.authorized, result.Authorized)
+			if tt.denied == "" {
+				assert.Nil(t, result.DeniedBy)
+				return
+			}
+			require.NotNil(t, result.DeniedBy)
+			assert.Equal(t, tt.denied, result.DeniedBy.CanonicalString())
+			assert.NotEmpty(t, result.DeniedBy.RoleId())
+			assert.Nil(t, result.OutputFields)
+		})
+	}
+
+	t.Run("list permissions", func(t *testing.T) {
+		acl := NewACL(append(parsed, func() []Grant {
+			var ret []Grant
+			for _, g := range []struct{ scope, grant string }{
+				{scope: "p_a", grant: "effect=deny;id=ttcp_hidden;actions=*"},
+				{scope: "p_a", grant: "effect=deny;id=ttcp_partial;actions=read"},
+				{scope: "p_c", grant: "id=*;type=target;actions=read"},
+				{scope: "p_c", grant: "effect=deny;type=target;actions=list"},
+			} {
+				grant, err := Parse(g.scope, g.grant)
+				require.NoError(t, err)
+				ret = append(ret, grant)
+			}
+			return ret
+		}()...)...)
+		perms := acl.ListPermissions(map[string]*scopes.ScopeInfo{
+			"p_a": {Id: "p_a", ParentScopeId: "o_a"},
+			"p_b": {Id: "p_b", ParentScopeId: "o_b"},
+			"p_c": {Id: "p_c", ParentScopeId: "o_c"},
+		}, resource.Target, action.ActionSet{action.Read, action.Update, action.Delete}, "u_abcd1234")
+		require.ElementsMatch(t, []Permission{
+			{ScopeId: "p_a", Resource: resource.Target, Action: action.List, All: true, ExcludedResourceIds: []string{"ttcp_hidden"}},
+			{ScopeId: "p_b", Resource: resource.Target, Action: action.List, All: true},
+		}, perms)
+	})
+}
+
+func Test_ACLExplain(t *testing.T) {
+	t.Parallel()
+
+	var parsed []Grant
+	for _, g := range []struct{ role, scope, grant string }{
+		{role: "r_read", scope: "p_a", grant: "id=*;type=target;actions=read"},
+		{role: "r_all", scope: "p_a", grant: "id=*;type=*;actions=*"},
+		{role: "r_deny", scope: "p_a", grant: "effect=deny;id=ttcp_denied;actions=delete"},
+		{role: "r_other", scope: "p_b", grant: "id=*;type=target;actions=update"},
+	} {
+		grant, err := Parse(g.scope, g.grant, WithRoleId(g.role))
+		require.NoError(t, err)
+		parsed = append(parsed, grant)
+	}
+	acl := NewACL(parsed...)
+
+	tests := []struct {
+		name          string
+		resource      Resource
+		action        action.Type
+		authorized    bool
+		allowedBy     []string
+		deniedBy      string
+		requiredGrant string
+	}{
+		{
+			name:       "allowed by multiple grants",
+			resource:   Resource{ScopeId: "p_a", Id: "ttcp_a", Type: resource.Target},
+			action:     action.Read,
+			authorized: true,
+			allowedBy:  []string{"r_read", "r_all"},
+		},
+		{
+			name:       "allowed by one grant",
+			resource:   Resource{ScopeId: "p_a", Id: "ttcp_a", Type: resource.Target},
+			action:     action.Update,
+			authorized: true,
+			allowedBy:  []string{"r_all"},
+		},
+		{
+			name:          "denied",
+			resource:      Resource{ScopeId: "p_a", Id: "ttcp_denied", Type: resource.Target},
+			action:        action.Delete,
+			deniedBy:      "r_deny",
+			requiredGrant: "id=ttcp_denied;actions=delete",
+		},
+		{
+			name:          "no matching grant",
+			resource:      Resource{ScopeId: "p_b", Id: "ttcp_b", Type: resource.Target},
+			action:        action.Read,
+			requiredGrant: "id=ttcp_b;actions=read",
+		},
+		{
+			name:          "collection",
+			resource:      Resource{ScopeId: "p_b", Type: resource.Target},
+			action:        action.Create,
+			requiredGrant: "type=target;actions=create",
+		},
+		{
+			name:          "pinned collection",
+			resource:      Resource{ScopeId: "p_b", Pin: "hcst_a", Type: resource.HostSet},
+			action:        action.Create,
+			requiredGrant: "id=hcst_a;type=host-set;actions=create",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := acl.Explain(tt.resource, tt.action, "u_abcd1234")
+			assert.Equal(t, tt.authorized, got.Authorized)
+			var allowedBy []string
+			for _, g := range got.AllowedBy {
+				allowedBy = append(allowedBy, g.RoleId())
+			}
+			assert.ElementsMatch(t, tt.allowedBy, allowedBy)
+			if tt.deniedBy == "" {
+				assert.Nil(t, got.DeniedBy)
+			} else {
+				require.NotNil(t, got.DeniedBy)
+				assert.Equal(t, tt.deniedBy, got.DeniedBy.RoleId())
+			}
+			assert.Equal(t, tt.requiredGrant, got.RequiredGrant)
+		})
+	}
+}
+
+func Test_ACLAllowedAttributes(t *testing.T) {
+	t.Parallel()
+
+	grants := []string{
+		"type=target;tags.env=dev;actions=list,authorize-session",
+		"type=target;name=db-*;tags.team=db;actions=read",
+		"effect=deny;type=target;tags.locked=true;actions=authorize-session",
+	}
+
+	tests := []struct {
+		name       string
+		resource   Resource
+		action     action.Type
+		authorized bool
+	}{
+		{
+			name:       "list collection",
+			resource:   Resource{ScopeId: "p_a", Type: resource.Target},
+			action:     action.List,
+			authorized: true,
+		},
+		{
+			name:     "create collection",
+			resource: Resource{ScopeId: "p_a", Type: resource.Target},
+			action:   action.Create,
+		},
+		{
+			name:       "matching tag",
+			resource:   Resource{ScopeId: "p_a", Id: "ttcp_a", Type: resource.Target, Tags: map[string][]string{"env": {"prod", "dev"}}},
+			action:     action.AuthorizeSession,
+			authorized: true,
+		},
+		{
+			name:     "non-matching tag",
+			resource: Resource{ScopeId: "p_a", Id: "ttcp_a", Type: resource.Target, Tags: map[string][]string{"env": {"prod"}}},
+			action:   action.AuthorizeSession,
+		},
+		{
+			name:     "no tags",
+			resource: Resource{ScopeId: "p_a", Id: "ttcp_a", Type: resource.Target},
+			action:   action.AuthorizeSession,
+		},
+		{
+			name:     "matching tag other action",
+			resource: Resource{ScopeId: "p_a", Id: "ttcp_a", Type: resource.Target, Tags: map[string][]string{"env": {"dev"}}},
+			action:   action.Delete,
+		},
+		{
+			name:       "matching name and tag",
+			resource:   Resource{ScopeId: "p_a", Id: "ttcp_a", Type: resource.Target, Name: "db-east", Tags: map[string][]string{"team": {"db"}}},
+			action:     action.Read,
+			authorized: true,
+		},
+		{
+			name:     "matching tag non-matching name",
+			resource: Resource{ScopeId: "p_a", Id: "ttcp_a", Type: resource.Target, Name: "web-east", Tags: map[string][]string{"team": {"db"}}},
+			action:   action.Read,
+		},
+		{
+			name:     "other type",
+			resource: Resource{ScopeId: "p_a", Id: "hcst_a", Type: resource.HostCatalog, Tags: map[string][]string{"env": {"dev"}}},
+			action:   action.AuthorizeSession,
+		},
+		{
+			name:     "denied by tag",
+			resource: Resource{ScopeId: "p_a", Id: "ttcp_a", Type: resource.Target, Tags: map[string][]string{"env": {"dev"}, "locked": {"true"}}},
+			action:   action.AuthorizeSession,
+		},
+	}
+
+	var parsed []Grant
+	for _, g := range grants {
+		grant, err := Parse("p_a", g)
+		require.NoError(t, err)
+		parsed = append(parsed, grant)
+	}
+	acl := NewACL(parsed...)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			result := acl.Allowed(tt.resource, tt.action, "u_abcd1234")
+			assert.Equal(t, tt.authorized, result.Authorized)
+		})
+	}
+
+	t.Run("list permissions", func(t *testing.T) {
+		perms := acl.ListPermissions(map[string]*scopes.ScopeInfo{
+			"p_a": {Id: "p_a", ParentScopeId: "o_a"},
+		}, resource.Target, action.ActionSet{action.Read, action.AuthorizeSession}, "u_abcd1234")
+		require.Len(t, perms, 1)
+		assert.False(t, perms[0].All)
+		assert.Empty(t, perms[0].ResourceIds)
+		assert.ElementsMatch(t, []ResourceAttributes{
+			{Tags: map[string][]string{"env": {"dev"}}},
+			{NamePattern: "db-*", Tags: map[string][]string{"team": {"db"}}},
+		}, perms[0].Attributes)
+	})
+
+	t.Run("list permissions with deny by tag", func(t *testing.T) {
+		var parsed []Grant
+		for _, g := range []string{
+			"id=*;type=target;actions=list,read",
+			"effect=deny;type=target;tags.env=prod;actions=*",
+			"effect=deny;type=target;tags.locked=true;actions=authorize-session",
+		} {
+			grant, err := Parse("p_a", g)
+			require.NoError(t, err)
+			parsed = append(parsed, grant)
+		}
+		perms := NewACL(parsed...).ListPermissions(map[string]*scopes.ScopeInfo{
+			"p_a": {Id: "p_a", ParentScopeId: "o_a"},
+		}, resource.Target, action.ActionSet{action.Read, action.AuthorizeSession}, "u_abcd1234")
+		require.Len(t, perms, 1)
+		assert.True(t, perms[0].All)
+		assert.Equal(t, []ResourceAttributes{
+			{Tags: map[string][]string{"env": {"prod"}}},
+		}, perms[0].ExcludedAttributes)
+	})
+}
+
 func TestJsonMarshal(t *testing.T) {
 	res := &Resource{
 		ScopeId: "scope",
@@ -852,11 +1302,11 @@ func Test_AnonRestrictions(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			require, assert := require.New(t), assert.New(t)
-			for i := resource.Type(1); i <= resource.Credential; i++ {
+			for i := resource.Type(1); i <= resource.Job; i++ {
 				if i == resource.Controller || i == resource.Worker {
 					continue
 				}
-				for j := action.Type(1); j <= action.ReadCertificateAuthority; j++ {
+				for j := action.Type(1); j <= action.Run; j++ {
 					res := Resource{
 						ScopeId: scope.Global.String(),
 						Id:      "foobar",
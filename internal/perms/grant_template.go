@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package perms
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// templatePlaceholderRe matches a placeholder such as {{account.email}} or
+// {{ .Account.Email }} within a grant value
+var templatePlaceholderRe = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+// templateValue returns the value of the named placeholder, or nil if it is
+// not known for the user the grant is being evaluated for. known is false if
+// the placeholder is not supported. Any claim of an OIDC account can be used
+// as account.claims.<name>.
+func templateValue(name string, opts options) (value *string, known bool) {
+	d := opts.withTemplateData
+	switch name {
+	case "user.id", ".User.Id":
+		if opts.withUserId != "" {
+			return &opts.withUserId, true
+		}
+		if d == nil {
+			return nil, true
+		}
+		return d.User.Id, true
+	case "account.id", ".Account.Id":
+		if opts.withAccountId != "" {
+			return &opts.withAccountId, true
+		}
+		if d == nil {
+			return nil, true
+		}
+		return d.Account.Id, true
+	}
+
+	for _, prefix := range []string{"account.claims.", ".Account.Claims."} {
+		claim, ok := strings.CutPrefix(name, prefix)
+		if !ok || claim == "" {
+			continue
+		}
+		if d == nil {
+			return nil, true
+		}
+		if v, ok := d.Account.Claims[claim]; ok {
+			return &v, true
+		}
+		return nil, true
+	}
+
+	var get func() *string
+	switch name {
+	case "user.name", ".User.Name":
+		get = func() *string { return d.User.Name }
+	case "user.full_name", ".User.FullName":
+		get = func() *string { return d.User.FullName }
+	case "user.email", ".User.Email":
+		get = func() *string { return d.User.Email }
+	case "account.name", ".Account.Name":
+		get = func() *string { return d.Account.Name }
+	case "account.login_name", ".Account.LoginName":
+		get = func() *string { return d.Account.LoginName }
+	case "account.subject", ".Account.Subject":
+		get = func() *string { return d.Account.Subject }
+	case "account.email", ".Account.Email":
+		get = func() *string { return d.Account.Email }
+	case "account.full_name", ".Account.FullName":
+		get = func() *string { return d.Account.FullName }
+	default:
+		return nil, false
+	}
+	if d == nil {
+		return nil, true
+	}
+	return get(), true
+}
+
+// expandTemplates substitutes the placeholders in the name pattern and tag
+// values of the grant with the values of the user and account it is being
+// evaluated for. If no template data was provided, e.g. when a grant is
+// validated before being written, the placeholders are only checked and left
+// as-is. A placeholder whose value is unknown for the user, or a value that
+// would widen a name pattern, leaves the grant unresolved so that it matches
+// nothing.
+func (g *Grant) expandTemplates(opts options) error {
+	const op = "perms.(Grant).expandTemplates"
+	expand := func(field, in string, pattern bool) (string, error) {
+		if !strings.Contains(in, "{{") {
+			return in, nil
+		}
+		var retErr error
+		out := templatePlaceholderRe.ReplaceAllStringFunc(in, func(m string) string {
+			name := templatePlaceholderRe.FindStringSubmatch(m)[1]
+			value, known := templateValue(name, opts)
+			switch {
+			case !known:
+				if retErr == nil {
+					retErr = errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unknown template %q in grant %q value", m, field))
+				}
+				return m
+			case opts.withTemplateData == nil:
+				return m
+			case value == nil || *value == "":
+				g.unresolved = true
+				return m
+			case pattern && strings.Contains(*value, "*"):
+				g.unresolved = true
+				return m
+			}
+			return strings.ToValidUTF8(*value, string(unicode.ReplacementChar))
+		})
+		return out, retErr
+	}
+
+	if g.name != "" {
+		name, err := expand("name", g.name, true)
+		if err != nil {
+			return err
+		}
+		g.name = name
+	}
+
+	for k, values := range g.tags {
+		expanded := make([]string, 0, len(values))
+		for _, v := range values {
+			value, err := expand("tags."+k, v, false)
+			if err != nil {
+				return err
+			}
+			expanded = append(expanded, value)
+		}
+		g.tags[k] = expanded
+	}
+	return nil
+}
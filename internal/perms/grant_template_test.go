@@ -0,0 +1,207 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package perms
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/util"
+	"github.com/hashicorp/boundary/internal/util/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseTemplates(t *testing.T) {
+	t.Parallel()
+
+	data := template.Data{
+		User: template.User{
+			Id:    util.Pointer("u_abcd1234"),
+			Name:  util.Pointer("alice"),
+			Email: util.Pointer("alice@example.com"),
+		},
+		Account: template.Account{
+			Id:       util.Pointer("acctoidc_abcd1234"),
+			Email:    util.Pointer("alice@corp.example.com"),
+			FullName: util.Pointer("Alice Doe"),
+			Subject:  util.Pointer("*"),
+			Claims:   map[string]string{"team": "db"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		grant          string
+		noData         bool
+		wantErr        string
+		wantName       string
+		wantTags       map[string][]string
+		wantUnresolved bool
+	}{
+		{
+			name:     "user email tag",
+			grant:    "type=target;tags.owner={{user.email}};actions=authorize-session",
+			wantTags: map[string][]string{"owner": {"alice@example.com"}},
+		},
+		{
+			name:     "go style placeholder",
+			grant:    "type=target;tags.owner={{ .Account.Email }};actions=authorize-session",
+			wantTags: map[string][]string{"owner": {"alice@corp.example.com"}},
+		},
+		{
+			name:     "json format",
+			grant:    `{"type":"target","tags":{"owner":["{{account.full_name}}"]},"actions":["read"]}`,
+			wantTags: map[string][]string{"owner": {"Alice Doe"}},
+		},
+		{
+			name:     "name pattern",
+			grant:    "type=target;name={{user.name}}-*;actions=read",
+			wantName: "alice-*",
+		},
+		{
+			name:     "multiple placeholders",
+			grant:    "type=target;name={{user.name}}-{{account.id}};actions=read",
+			wantName: "alice-acctoidc_abcd1234",
+		},
+		{
+			name:     "no data",
+			grant:    "type=target;tags.owner={{user.email}};actions=read",
+			noData:   true,
+			wantTags: map[string][]string{"owner": {"{{user.email}}"}},
+		},
+		{
+			name:           "missing value",
+			grant:          "type=target;tags.owner={{account.login_name}};actions=read",
+			wantTags:       map[string][]string{"owner": {"{{account.login_name}}"}},
+			wantUnresolved: true,
+		},
+		{
+			name:           "wildcard in name value",
+			grant:          "type=target;name=dev-{{account.subject}};actions=read",
+			wantName:       "dev-{{account.subject}}",
+			wantUnresolved: true,
+		},
+		{
+			name:     "account claim",
+			grant:    "type=target;tags.team={{account.claims.team}};actions=read",
+			wantTags: map[string][]string{"team": {"db"}},
+		},
+		{
+			name:     "go style account claim",
+			grant:    "type=target;name={{ .Account.Claims.team }}-*;actions=read",
+			wantName: "db-*",
+		},
+		{
+			name:           "missing claim",
+			grant:          "type=target;tags.dept={{account.claims.department}};actions=read",
+			wantTags:       map[string][]string{"dept": {"{{account.claims.department}}"}},
+			wantUnresolved: true,
+		},
+		{
+			name:     "wildcard in tag value",
+			grant:    "type=target;tags.sub={{account.subject}};actions=read",
+			wantTags: map[string][]string{"sub": {"*"}},
+		},
+		{
+			name:    "unknown placeholder",
+			grant:   "type=target;tags.owner={{user.phone}};actions=read",
+			wantErr: `perms.Parse: perms.(Grant).expandTemplates: unknown template "{{user.phone}}" in grant "tags.owner" value: parameter violation: error #100`,
+		},
+		{
+			name:    "unknown placeholder without data",
+			grant:   "type=target;name={{account.claims.}};actions=read",
+			noData:  true,
+			wantErr: `perms.Parse: perms.(Grant).expandTemplates: unknown template "{{account.claims.}}" in grant "name" value: parameter violation: error #100`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			var opts []Option
+			if !tt.noData {
+				opts = append(opts, WithTemplateData(data))
+			}
+			got, err := Parse("p_a", tt.grant, opts...)
+			if tt.wantErr != "" {
+				require.Error(err)
+				assert.Equal(tt.wantErr, err.Error())
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantName, got.NamePattern())
+			assert.Equal(tt.wantTags, got.Tags())
+			assert.Equal(tt.wantUnresolved, got.unresolved)
+		})
+	}
+}
+
+func Test_ACLAllowedTemplates(t *testing.T) {
+	t.Parallel()
+
+	grants := []string{
+		"type=target;tags.owner={{account.email}};actions=authorize-session",
+		"type=target;name={{user.name}}-*;actions=read",
+	}
+	aliceData := template.Data{
+		User:    template.User{Id: util.Pointer("u_alice"), Name: util.Pointer("alice")},
+		Account: template.Account{Id: util.Pointer("acctoidc_alice"), Email: util.Pointer("alice@example.com")},
+	}
+	// Bob authenticated with an account that has no email
+	bobData := template.Data{
+		User:    template.User{Id: util.Pointer("u_bob"), Name: util.Pointer("bob")},
+		Account: template.Account{Id: util.Pointer("acctpw_bob")},
+	}
+	aliceTarget := Resource{ScopeId: "p_a", Id: "ttcp_a", Type: resource.Target, Name: "alice-db", Tags: map[string][]string{"owner": {"alice@example.com"}}}
+	bobTarget := Resource{ScopeId: "p_a", Id: "ttcp_b", Type: resource.Target, Name: "bob-db", Tags: map[string][]string{"owner": {"bob@example.com"}}}
+
+	aclFor := func(data template.Data) ACL {
+		var parsed []Grant
+		for _, g := range grants {
+			grant, err := Parse("p_a", g, WithTemplateData(data), WithSkipFinalValidation(true))
+			require.NoError(t, err)
+			parsed = append(parsed, grant)
+		}
+		return NewACL(parsed...)
+	}
+	alice, bob := aclFor(aliceData), aclFor(bobData)
+
+	assert.True(t, alice.Allowed(aliceTarget, action.AuthorizeSession, "u_alice").Authorized)
+	assert.True(t, alice.Allowed(aliceTarget, action.Read, "u_alice").Authorized)
+	assert.False(t, alice.Allowed(bobTarget, action.AuthorizeSession, "u_alice").Authorized)
+	assert.False(t, alice.Allowed(bobTarget, action.Read, "u_alice").Authorized)
+
+	assert.False(t, bob.Allowed(bobTarget, action.AuthorizeSession, "u_bob").Authorized)
+	assert.True(t, bob.Allowed(bobTarget, action.Read, "u_bob").Authorized)
+	assert.False(t, bob.Allowed(aliceTarget, action.Read, "u_bob").Authorized)
+}
+
+func Test_ACLDeniedUnresolvedTemplates(t *testing.T) {
+	t.Parallel()
+
+	var parsed []Grant
+	for _, g := range []string{
+		"id=*;type=target;actions=list,read,authorize-session",
+		"effect=deny;type=target;tags.owner={{account.email}};actions=authorize-session",
+	} {
+		// The account has no email, so the deny grant can't be expanded
+		grant, err := Parse("p_a", g, WithTemplateData(template.Data{
+			User:    template.User{Id: util.Pointer("u_bob")},
+			Account: template.Account{Id: util.Pointer("acctpw_bob")},
+		}))
+		require.NoError(t, err)
+		parsed = append(parsed, grant)
+	}
+	acl := NewACL(parsed...)
+
+	target := Resource{ScopeId: "p_a", Id: "ttcp_a", Type: resource.Target, Tags: map[string][]string{"owner": {"alice@example.com"}}}
+	assert.True(t, acl.Allowed(target, action.Read, "u_bob").Authorized)
+	result := acl.Allowed(target, action.AuthorizeSession, "u_bob")
+	assert.False(t, result.Authorized)
+	require.NotNil(t, result.DeniedBy)
+	assert.Equal(t, "effect=deny;type=target;tags.owner={{account.email}};actions=authorize-session", result.DeniedBy.CanonicalString())
+	assert.False(t, acl.Allowed(Resource{ScopeId: "p_a", Id: "ttcp_b", Type: resource.Target}, action.AuthorizeSession, "u_bob").Authorized)
+}
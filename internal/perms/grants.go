@@ -18,11 +18,15 @@ import (
 )
 
 // GrantTuple is simply a struct that can be reference from other code to return
-// a set of scopes and grants to parse
+// a set of scopes and grants to parse. GrantScopeId is either a scope ID or one
+// of the special values globals.GrantScopeChildren or
+// globals.GrantScopeDescendants; the value globals.GrantScopeThis is normalized
+// to RoleScopeId before being returned.
 type GrantTuple struct {
-	RoleId  string
-	ScopeId string
-	Grant   string
+	RoleId       string
+	RoleScopeId  string
+	GrantScopeId string
+	Grant        string
 }
 
 // Scope provides an in-memory representation of iam.Scope without the
@@ -35,17 +39,46 @@ type Scope struct {
 	Type scope.Type
 }
 
+// Grant effects. A grant without an explicit effect allows; a deny grant
+// takes precedence over any allow grant that applies to the same scope.
+const (
+	effectAllow = "allow"
+	effectDeny  = "deny"
+)
+
 // Grant is a Go representation of a parsed grant
 type Grant struct {
 	// The scope ID, which will be a project ID or an org ID
 	scope Scope
 
+	// The ID of the role the grant came from, if known
+	roleId string
+
+	// If set, the grant applies not to scope but to its direct children
+	// (globals.GrantScopeChildren) or to all of its descendants
+	// (globals.GrantScopeDescendants)
+	grantScope string
+
+	// Whether this is a deny grant
+	deny bool
+
 	// The ID in the grant, if provided.
 	id string
 
 	// The type, if provided
 	typ resource.Type
 
+	// A name pattern, if provided; "*" matches any run of characters
+	name string
+
+	// Tags that a resource must carry, if provided, keyed by tag key
+	tags map[string][]string
+
+	// Set if a template placeholder in the name or tags could not be
+	// expanded for the user, in which case an allow grant matches nothing and
+	// a deny grant matches every resource of its type
+	unresolved bool
+
 	// The set of actions being granted
 	actions map[action.Type]bool
 
@@ -65,6 +98,100 @@ func (g Grant) Type() resource.Type {
 	return g.typ
 }
 
+// NamePattern returns the name pattern of the grant, if any
+func (g Grant) NamePattern() string {
+	return g.name
+}
+
+// Tags returns the tags a resource must carry to match the grant, if any
+func (g Grant) Tags() map[string][]string {
+	return g.tags
+}
+
+// hasAttributes returns whether the grant matches resources by name pattern
+// or tags rather than by ID
+func (g Grant) hasAttributes() bool {
+	return g.name != "" || len(g.tags) > 0
+}
+
+// matchesAttributes checks the name pattern and tags of the grant against the
+// resource
+func (g Grant) matchesAttributes(r Resource) bool {
+	if g.name != "" && !matchNamePattern(g.name, r.Name) {
+		return false
+	}
+	for k, values := range g.tags {
+		for _, v := range values {
+			var found bool
+			for _, rv := range r.Tags[k] {
+				if rv == v {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchNamePattern reports whether name matches pattern, where "*" in the
+// pattern matches any run of characters, including an empty one
+func matchNamePattern(pattern, name string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(name, part)
+		if idx < 0 {
+			return false
+		}
+		name = name[idx+len(part):]
+	}
+	return len(name) >= len(last) && strings.HasSuffix(name, last)
+}
+
+// canonicalTags returns the tags of the grant as sorted "key=value" pairs
+func (g Grant) canonicalTags() []string {
+	ret := make([]string, 0, len(g.tags))
+	for k, values := range g.tags {
+		for _, v := range values {
+			ret = append(ret, fmt.Sprintf("%s=%s", k, v))
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Deny returns whether the grant denies rather than allows its actions
+func (g Grant) Deny() bool {
+	return g.deny
+}
+
+// RoleId returns the ID of the role the grant came from, if it was provided
+// when parsing
+func (g Grant) RoleId() string {
+	return g.roleId
+}
+
+// GrantScopeId returns the scope the grant applies to: either the ID of the
+// scope it was parsed in, or globals.GrantScopeChildren or
+// globals.GrantScopeDescendants.
+func (g Grant) GrantScopeId() string {
+	if g.grantScope != "" {
+		return g.grantScope
+	}
+	return g.scope.Id
+}
+
 func (g Grant) Actions() (typs []action.Type, strs []string) {
 	typs = make([]action.Type, 0, len(g.actions))
 	strs = make([]string, 0, len(g.actions))
@@ -93,11 +220,34 @@ func (g Grant) hasActionOrSubaction(act action.Type) bool {
 	return false
 }
 
+// coversAction checks whether the grant's action set includes the given
+// action, either directly, via its parent action (e.g. "read" covers
+// "read:self"), or via the wildcard action.
+func (g Grant) coversAction(act action.Type) bool {
+	if g.actions[act] || g.actions[action.All] {
+		return true
+	}
+	if split := strings.Split(act.String(), ":"); len(split) == 2 {
+		return g.actions[action.Map[split[0]]]
+	}
+	return false
+}
+
 func (g Grant) clone() *Grant {
 	ret := &Grant{
-		scope: g.scope,
-		id:    g.id,
-		typ:   g.typ,
+		scope:      g.scope,
+		roleId:     g.roleId,
+		grantScope: g.grantScope,
+		deny:       g.deny,
+		id:         g.id,
+		typ:        g.typ,
+		name:       g.name,
+	}
+	if g.tags != nil {
+		ret.tags = make(map[string][]string, len(g.tags))
+		for k, v := range g.tags {
+			ret.tags[k] = append([]string(nil), v...)
+		}
 	}
 	if g.actionsBeingParsed != nil {
 		ret.actionsBeingParsed = append(ret.actionsBeingParsed, g.actionsBeingParsed...)
@@ -122,6 +272,10 @@ func (g Grant) clone() *Grant {
 func (g Grant) CanonicalString() string {
 	var builder []string
 
+	if g.deny {
+		builder = append(builder, fmt.Sprintf("effect=%s", effectDeny))
+	}
+
 	if g.id != "" {
 		builder = append(builder, fmt.Sprintf("id=%s", g.id))
 	}
@@ -130,6 +284,14 @@ func (g Grant) CanonicalString() string {
 		builder = append(builder, fmt.Sprintf("type=%s", g.typ.String()))
 	}
 
+	if g.name != "" {
+		builder = append(builder, fmt.Sprintf("name=%s", g.name))
+	}
+
+	for _, tag := range g.canonicalTags() {
+		builder = append(builder, fmt.Sprintf("tags.%s", tag))
+	}
+
 	if len(g.actions) > 0 {
 		actions := make([]string, 0, len(g.actions))
 		for action := range g.actions {
@@ -149,13 +311,28 @@ func (g Grant) CanonicalString() string {
 // MarshalJSON provides a custom marshaller for grants
 func (g Grant) MarshalJSON() ([]byte, error) {
 	const op = "perms.(Grant).MarshalJSON"
-	res := make(map[string]any, 4)
+	res := make(map[string]any, 7)
+	if g.deny {
+		res["effect"] = effectDeny
+	}
 	if g.id != "" {
 		res["id"] = g.id
 	}
 	if g.typ != resource.Unknown {
 		res["type"] = g.typ.String()
 	}
+	if g.name != "" {
+		res["name"] = g.name
+	}
+	if len(g.tags) > 0 {
+		tags := make(map[string][]string, len(g.tags))
+		for k, v := range g.tags {
+			values := append([]string(nil), v...)
+			sort.Strings(values)
+			tags[k] = values
+		}
+		res["tags"] = tags
+	}
 	if len(g.actions) > 0 {
 		actions := make([]string, 0, len(g.actions))
 		for action := range g.actions {
@@ -179,10 +356,19 @@ func (g Grant) MarshalJSON() ([]byte, error) {
 // when JSON is detected.
 func (g *Grant) unmarshalJSON(data []byte) error {
 	const op = "perms.(Grant).unmarshalJSON"
-	raw := make(map[string]any, 4)
+	raw := make(map[string]any, 7)
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return errors.WrapDeprecated(err, op, errors.WithCode(errors.Decode))
 	}
+	if rawEffect, ok := raw["effect"]; ok {
+		effect, ok := rawEffect.(string)
+		if !ok {
+			return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %q as string", "effect"))
+		}
+		if err := g.setEffect(effect); err != nil {
+			return errors.WrapDeprecated(err, op)
+		}
+	}
 	if rawId, ok := raw["id"]; ok {
 		id, ok := rawId.(string)
 		if !ok {
@@ -200,6 +386,34 @@ func (g *Grant) unmarshalJSON(data []byte) error {
 			return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unknown type specifier %q", typ))
 		}
 	}
+	if rawName, ok := raw["name"]; ok {
+		name, ok := rawName.(string)
+		if !ok {
+			return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %q as string", "name"))
+		}
+		g.name = name
+	}
+	if rawTags, ok := raw["tags"]; ok {
+		interfaceTags, ok := rawTags.(map[string]any)
+		if !ok {
+			return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %q as object", "tags"))
+		}
+		for k, rawValues := range interfaceTags {
+			interfaceValues, ok := rawValues.([]any)
+			if !ok {
+				return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unable to interpret values of tag %q as array", k))
+			}
+			for _, v := range interfaceValues {
+				value, ok := v.(string)
+				if !ok {
+					return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %v in values of tag %q as string", v, k))
+				}
+				if err := g.addTag(k, value); err != nil {
+					return errors.WrapDeprecated(err, op)
+				}
+			}
+		}
+	}
 	if rawActions, ok := raw["actions"]; ok {
 		interfaceActions, ok := rawActions.([]any)
 		if !ok {
@@ -265,9 +479,17 @@ func (g *Grant) unmarshalText(grantString string) error {
 		}
 
 		switch kv[0] {
+		case "effect":
+			if err := g.setEffect(kv[1]); err != nil {
+				return errors.WrapDeprecated(err, op)
+			}
+
 		case "id":
 			g.id = kv[1]
 
+		case "name":
+			g.name = kv[1]
+
 		case "type":
 			typeString := strings.ToLower(kv[1])
 			g.typ = resource.Map[typeString]
@@ -294,12 +516,52 @@ func (g *Grant) unmarshalText(grantString string) error {
 			default:
 				g.OutputFields = g.OutputFields.AddFields(strings.Split(kv[1], ","))
 			}
+
+		default:
+			if key, ok := strings.CutPrefix(kv[0], "tags."); ok {
+				if err := g.addTag(key, kv[1]); err != nil {
+					return errors.WrapDeprecated(err, op)
+				}
+			}
 		}
 	}
 
 	return nil
 }
 
+func (g *Grant) addTag(key, value string) error {
+	const op = "perms.(Grant).addTag"
+	switch {
+	case key == "":
+		return errors.NewDeprecated(errors.InvalidParameter, op, "missing tag key")
+	case value == "":
+		return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("missing value for tag %q", key))
+	}
+	if g.tags == nil {
+		g.tags = make(map[string][]string)
+	}
+	for _, v := range g.tags[key] {
+		if v == value {
+			return nil
+		}
+	}
+	g.tags[key] = append(g.tags[key], value)
+	return nil
+}
+
+func (g *Grant) setEffect(effect string) error {
+	const op = "perms.(Grant).setEffect"
+	switch strings.ToLower(effect) {
+	case effectAllow:
+		g.deny = false
+	case effectDeny:
+		g.deny = true
+	default:
+		return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unknown effect %q", effect))
+	}
+	return nil
+}
+
 // Parse parses a grant string. Note that this does not do checking
 // of the validity of IDs and such; that's left for other parts of the system.
 // We may not check at all (e.g. let it be an authz-time failure) or could check
@@ -317,8 +579,11 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 	}
 	grantString = strings.ToValidUTF8(grantString, string(unicode.ReplacementChar))
 
+	opts := getOpts(opt...)
+
 	grant := Grant{
-		scope: Scope{Id: strings.ToValidUTF8(scopeId, string(unicode.ReplacementChar))},
+		scope:  Scope{Id: strings.ToValidUTF8(scopeId, string(unicode.ReplacementChar))},
+		roleId: opts.withRoleId,
 	}
 	switch {
 	case scopeId == scope.Global.String():
@@ -331,6 +596,22 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 		return Grant{}, errors.NewDeprecated(errors.InvalidParameter, op, "invalid scope type")
 	}
 
+	switch opts.withGrantScope {
+	case "", globals.GrantScopeThis:
+	case globals.GrantScopeChildren:
+		if grant.scope.Type == scope.Project {
+			return Grant{}, errors.NewDeprecated(errors.InvalidParameter, op, "project scopes cannot have children")
+		}
+		grant.grantScope = opts.withGrantScope
+	case globals.GrantScopeDescendants:
+		if grant.scope.Type != scope.Global {
+			return Grant{}, errors.NewDeprecated(errors.InvalidParameter, op, "descendants is only valid for the global scope")
+		}
+		grant.grantScope = opts.withGrantScope
+	default:
+		return Grant{}, errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unknown grant scope %q", opts.withGrantScope))
+	}
+
 	switch {
 	case grantString[0] == '{':
 		if err := grant.unmarshalJSON([]byte(grantString)); err != nil {
@@ -343,8 +624,6 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 		}
 	}
 
-	opts := getOpts(opt...)
-
 	// Check for templated values ID, and substitute in with the authenticated
 	// values if so. If we are using a dummy user or account ID, store the
 	// original ID and return it at the end; this is usually the case when
@@ -372,6 +651,10 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 		}
 	}
 
+	if err := grant.expandTemplates(opts); err != nil {
+		return Grant{}, errors.WrapDeprecated(err, op)
+	}
+
 	if err := grant.validateType(); err != nil {
 		return Grant{}, errors.WrapDeprecated(err, op)
 	}
@@ -380,8 +663,30 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 		return Grant{}, errors.WrapDeprecated(err, op)
 	}
 
+	if grant.deny {
+		// A deny grant removes actions; restricting output fields is done by
+		// allow grants
+		if _, hasSetFields := grant.OutputFields.Fields(); hasSetFields {
+			return Grant{}, errors.NewDeprecated(errors.InvalidParameter, op, "deny grants cannot specify output fields")
+		}
+	}
+
 	if !opts.withSkipFinalValidation {
 		switch {
+		case grant.hasAttributes():
+			// Matches
+			//   type=sometype;name=pattern;tags.key=value;actions=foo,bar
+			// where name and tags may each be omitted but not both. The
+			// type must support tags, and the actions are checked against
+			// individual resources, so create cannot be granted this way.
+			switch {
+			case grant.id != "":
+				return Grant{}, errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("parsed grant string %q contains an id along with name or tag matching", grant.CanonicalString()))
+			case !resource.Taggable(grant.typ):
+				return Grant{}, errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("parsed grant string %q contains name or tag matching for a type that does not support it", grant.CanonicalString()))
+			case grant.hasActionOrSubaction(action.Create):
+				return Grant{}, errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("parsed grant string %q contains create action in a format that does not allow it", grant.CanonicalString()))
+			}
 		case grant.id == "*":
 			// Matches
 			//   id=*;type=sometype;actions=foo,bar
@@ -461,11 +766,17 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 				}
 			}
 		}
-		// This might be zero if output fields is populated
-		if len(grant.actions) > 0 {
+		// This might be zero if output fields is populated. Grants matching
+		// on name or tags were fully checked above.
+		if len(grant.actions) > 0 && !grant.hasAttributes() {
 			// Create a dummy resource and pass it through Allowed and ensure that
-			// we get allowed.
-			acl := NewACL(grant)
+			// we get allowed. The dummy resource lives in the given scope, so
+			// evaluate the grant as if it applied to that scope directly; a
+			// deny grant is checked as the allow grant it carves out of.
+			direct := grant
+			direct.grantScope = ""
+			direct.deny = false
+			acl := NewACL(direct)
 			r := Resource{
 				ScopeId: scopeId,
 				Id:      grant.id,
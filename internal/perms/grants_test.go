@@ -115,7 +115,7 @@ func Test_ActionParsingValidation(t *testing.T) {
 func Test_ValidateType(t *testing.T) {
 	t.Parallel()
 	var g Grant
-	for i := resource.Unknown; i <= resource.Credential; i++ {
+	for i := resource.Unknown; i <= resource.Job; i++ {
 		g.typ = i
 		if i == resource.Controller {
 			assert.Error(t, g.validateType())
@@ -201,6 +201,42 @@ func Test_MarshalingAndCloning(t *testing.T) {
 			jsonOutput:      `{"actions":["create","read"],"id":"baz","output_fields":["id","name","version"],"type":"group"}`,
 			canonicalString: `id=baz;type=group;actions=create,read;output_fields=id,name,version`,
 		},
+		{
+			name: "name and tags",
+			input: Grant{
+				scope: Scope{
+					Type: scope.Project,
+				},
+				typ:  resource.Target,
+				name: "dev-*",
+				tags: map[string][]string{
+					"team": {"db"},
+					"env":  {"qa", "dev"},
+				},
+				actions: map[action.Type]bool{
+					action.Read: true,
+				},
+			},
+			jsonOutput:      `{"actions":["read"],"name":"dev-*","tags":{"env":["dev","qa"],"team":["db"]},"type":"target"}`,
+			canonicalString: `type=target;name=dev-*;tags.env=dev;tags.env=qa;tags.team=db;actions=read`,
+		},
+		{
+			name: "deny",
+			input: Grant{
+				id: "*",
+				scope: Scope{
+					Type: scope.Project,
+				},
+				roleId: "r_1234567890",
+				deny:   true,
+				typ:    resource.Target,
+				actions: map[action.Type]bool{
+					action.Delete: true,
+				},
+			},
+			jsonOutput:      `{"actions":["delete"],"effect":"deny","id":"*","type":"target"}`,
+			canonicalString: `effect=deny;id=*;type=target;actions=delete`,
+		},
 	}
 
 	for _, test := range tests {
@@ -366,6 +402,7 @@ func Test_Parse(t *testing.T) {
 		accountId     string
 		err           string
 		scopeOverride string
+		grantScope    string
 		expected      Grant
 	}
 
@@ -753,6 +790,176 @@ func Test_Parse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:       "bad grant scope",
+			input:      "id=*;type=*;actions=read",
+			grantScope: "everything",
+			err:        `perms.Parse: unknown grant scope "everything": parameter violation: error #100`,
+		},
+		{
+			name:          "bad children grant scope for project",
+			input:         "id=*;type=*;actions=read",
+			scopeOverride: "p_scope",
+			grantScope:    globals.GrantScopeChildren,
+			err:           `perms.Parse: project scopes cannot have children: parameter violation: error #100`,
+		},
+		{
+			name:       "bad descendants grant scope for org",
+			input:      "id=*;type=*;actions=read",
+			grantScope: globals.GrantScopeDescendants,
+			err:        `perms.Parse: descendants is only valid for the global scope: parameter violation: error #100`,
+		},
+		{
+			name:       "good children grant scope",
+			input:      "id=*;type=*;actions=read",
+			grantScope: globals.GrantScopeChildren,
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				grantScope: globals.GrantScopeChildren,
+				id:         "*",
+				typ:        resource.All,
+				actions: map[action.Type]bool{
+					action.Read: true,
+				},
+			},
+		},
+		{
+			name:  "bad effect",
+			input: "effect=maybe;id=*;type=*;actions=read",
+			err:   `perms.Parse: unable to parse grant string: perms.(Grant).unmarshalText: perms.(Grant).setEffect: unknown effect "maybe": parameter violation: error #100`,
+		},
+		{
+			name:  "bad json effect",
+			input: `{"effect":true,"id":"*","type":"*","actions":["read"]}`,
+			err:   `perms.Parse: unable to parse JSON grant string: perms.(Grant).unmarshalJSON: unable to interpret "effect" as string: parameter violation: error #100`,
+		},
+		{
+			name:  "bad deny with output fields",
+			input: "effect=deny;id=*;type=target;actions=delete;output_fields=id",
+			err:   `perms.Parse: deny grants cannot specify output fields: parameter violation: error #100`,
+		},
+		{
+			name:  "bad deny without actions",
+			input: "effect=deny;id=*;type=target",
+			err:   `perms.Parse: perms.(Grant).parseAndValidateActions: missing actions: parameter violation: error #100`,
+		},
+		{
+			name:  "good explicit allow",
+			input: "effect=allow;id=*;type=target;actions=delete",
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				id:  "*",
+				typ: resource.Target,
+				actions: map[action.Type]bool{
+					action.Delete: true,
+				},
+			},
+		},
+		{
+			name:  "good deny",
+			input: "effect=deny;id=*;type=target;actions=delete",
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				deny: true,
+				id:   "*",
+				typ:  resource.Target,
+				actions: map[action.Type]bool{
+					action.Delete: true,
+				},
+			},
+		},
+		{
+			name:  "bad tag key",
+			input: "type=target;tags.=dev;actions=read",
+			err:   `perms.Parse: unable to parse grant string: perms.(Grant).unmarshalText: perms.(Grant).addTag: missing tag key: parameter violation: error #100`,
+		},
+		{
+			name:  "bad json tags",
+			input: `{"type":"target","tags":{"env":"dev"},"actions":["read"]}`,
+			err:   `perms.Parse: unable to parse JSON grant string: perms.(Grant).unmarshalJSON: unable to interpret values of tag "env" as array: parameter violation: error #100`,
+		},
+		{
+			name:  "bad tags with id",
+			input: "id=*;type=target;tags.env=dev;actions=read",
+			err:   `perms.Parse: parsed grant string "id=*;type=target;tags.env=dev;actions=read" contains an id along with name or tag matching: parameter violation: error #100`,
+		},
+		{
+			name:  "bad tags with untaggable type",
+			input: "type=user;tags.env=dev;actions=read",
+			err:   `perms.Parse: parsed grant string "type=user;tags.env=dev;actions=read" contains name or tag matching for a type that does not support it: parameter violation: error #100`,
+		},
+		{
+			name:  "bad name with wildcard type",
+			input: "type=*;name=dev-*;actions=read",
+			err:   `perms.Parse: parsed grant string "type=*;name=dev-*;actions=read" contains name or tag matching for a type that does not support it: parameter violation: error #100`,
+		},
+		{
+			name:  "bad tags with create",
+			input: "type=target;tags.env=dev;actions=create",
+			err:   `perms.Parse: parsed grant string "type=target;tags.env=dev;actions=create" contains create action in a format that does not allow it: parameter violation: error #100`,
+		},
+		{
+			name:  "good tags",
+			input: "type=target;tags.env=dev;tags.env=qa;tags.team=db;actions=list,authorize-session",
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				typ: resource.Target,
+				tags: map[string][]string{
+					"env":  {"dev", "qa"},
+					"team": {"db"},
+				},
+				actions: map[action.Type]bool{
+					action.List:             true,
+					action.AuthorizeSession: true,
+				},
+			},
+		},
+		{
+			name:  "good name and json tags",
+			input: `{"type":"host-catalog","name":"dev-*","tags":{"env":["dev"]},"actions":["read"]}`,
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				typ:  resource.HostCatalog,
+				name: "dev-*",
+				tags: map[string][]string{
+					"env": {"dev"},
+				},
+				actions: map[action.Type]bool{
+					action.Read: true,
+				},
+			},
+		},
+		{
+			name:  "good json deny",
+			input: `{"effect":"deny","id":"*","type":"target","actions":["delete"]}`,
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				deny: true,
+				id:   "*",
+				typ:  resource.Target,
+				actions: map[action.Type]bool{
+					action.Delete: true,
+				},
+			},
+		},
 	}
 
 	_, err := Parse("", "")
@@ -771,7 +978,7 @@ func Test_Parse(t *testing.T) {
 			if test.scopeOverride != "" {
 				scope = test.scopeOverride
 			}
-			grant, err := Parse(scope, test.input, WithUserId(test.userId), WithAccountId(test.accountId))
+			grant, err := Parse(scope, test.input, WithUserId(test.userId), WithAccountId(test.accountId), WithGrantScope(test.grantScope))
 			if test.err != "" {
 				require.Error(err)
 				assert.Equal(test.err, err.Error())
@@ -783,6 +990,37 @@ func Test_Parse(t *testing.T) {
 	}
 }
 
+func Test_MatchNamePattern(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "dev", name: "dev", want: true},
+		{pattern: "dev", name: "dev-1"},
+		{pattern: "*", name: "", want: true},
+		{pattern: "*", name: "anything", want: true},
+		{pattern: "dev-*", name: "dev-1", want: true},
+		{pattern: "dev-*", name: "dev-", want: true},
+		{pattern: "dev-*", name: "prod-1"},
+		{pattern: "*-db", name: "dev-db", want: true},
+		{pattern: "*-db", name: "dev-db-1"},
+		{pattern: "dev-*-db", name: "dev-east-db", want: true},
+		{pattern: "dev-*-db", name: "dev-db"},
+		{pattern: "a*b*c", name: "abc", want: true},
+		{pattern: "a*b*c", name: "axxbyyc", want: true},
+		{pattern: "a*b*c", name: "acb"},
+		{pattern: "ab*ba", name: "aba"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.pattern+"_"+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchNamePattern(tt.pattern, tt.name))
+		})
+	}
+}
+
 func TestHasActionOrSubaction(t *testing.T) {
 	tests := []struct {
 		name string
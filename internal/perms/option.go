@@ -3,6 +3,8 @@
 
 package perms
 
+import "github.com/hashicorp/boundary/internal/util/template"
+
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
 	opts := getDefaultOptions()
@@ -23,6 +25,9 @@ type options struct {
 	withAccountId                     string
 	withSkipFinalValidation           bool
 	withSkipAnonymousUserRestrictions bool
+	withGrantScope                    string
+	withRoleId                        string
+	withTemplateData                  *template.Data
 }
 
 func getDefaultOptions() options {
@@ -60,3 +65,29 @@ func WithSkipAnonymousUserRestrictions(with bool) Option {
 		o.withSkipAnonymousUserRestrictions = with
 	}
 }
+
+// WithGrantScope indicates that the grant being parsed applies to the children
+// (globals.GrantScopeChildren) or descendants (globals.GrantScopeDescendants)
+// of the scope passed to Parse rather than to the scope itself.
+func WithGrantScope(grantScope string) Option {
+	return func(o *options) {
+		o.withGrantScope = grantScope
+	}
+}
+
+// WithRoleId provides the ID of the role a grant came from, so that it can be
+// reported, e.g. when a deny grant matches
+func WithRoleId(roleId string) Option {
+	return func(o *options) {
+		o.withRoleId = roleId
+	}
+}
+
+// WithTemplateData provides the user and account information used to expand
+// placeholders such as {{account.email}} in the name and tag values of grant
+// strings. Without it, placeholders are validated but left unexpanded.
+func WithTemplateData(data template.Data) Option {
+	return func(o *options) {
+		o.withTemplateData = &data
+	}
+}
@@ -6,6 +6,8 @@ package perms
 import (
 	"testing"
 
+	"github.com/hashicorp/boundary/internal/util"
+	"github.com/hashicorp/boundary/internal/util/template"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,4 +45,26 @@ func Test_GetOpts(t *testing.T) {
 		opts = getOpts(WithSkipAnonymousUserRestrictions(true))
 		assert.True(opts.withSkipAnonymousUserRestrictions)
 	})
+	t.Run("with-grant-scope", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts()
+		assert.Empty(opts.withGrantScope)
+		opts = getOpts(WithGrantScope("children"))
+		assert.Equal("children", opts.withGrantScope)
+	})
+	t.Run("with-role-id", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts()
+		assert.Empty(opts.withRoleId)
+		opts = getOpts(WithRoleId("r_1234567890"))
+		assert.Equal("r_1234567890", opts.withRoleId)
+	})
+	t.Run("with-template-data", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts()
+		assert.Nil(opts.withTemplateData)
+		data := template.Data{User: template.User{Email: util.Pointer("alice@example.com")}}
+		opts = getOpts(WithTemplateData(data))
+		assert.Equal(&data, opts.withTemplateData)
+	})
 }
@@ -25,6 +25,7 @@ import (
 // Both p.CreateTime and c.UpdateTime are ignored.
 func (r *Repository) CreatePlugin(ctx context.Context, p *Plugin, opt ...Option) (*Plugin, error) {
 	const op = "host.(Repository).CreatePlugin"
+	ctx = db.NewOpContext(ctx, op)
 	if p == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil Plugin")
 	}
@@ -92,6 +93,7 @@ func (r *Repository) CreatePlugin(ctx context.Context, p *Plugin, opt ...Option)
 // Plugin is found for id.
 func (r *Repository) LookupPlugin(ctx context.Context, id string, _ ...Option) (*Plugin, error) {
 	const op = "host.(Repository).LookupPlugin"
+	ctx = db.NewOpContext(ctx, op)
 	if id == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
@@ -110,6 +112,7 @@ func (r *Repository) LookupPlugin(ctx context.Context, id string, _ ...Option) (
 // Plugin is found with that plugin name.
 func (r *Repository) LookupPluginByName(ctx context.Context, name string, _ ...Option) (*Plugin, error) {
 	const op = "host.(Repository).LookupPluginByName"
+	ctx = db.NewOpContext(ctx, op)
 	if name == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no plugin name")
 	}
@@ -127,6 +130,7 @@ func (r *Repository) LookupPluginByName(ctx context.Context, name string, _ ...O
 // ListPlugins returns a slice of Plugins for the scope IDs. WithLimit is the only option supported.
 func (r *Repository) ListPlugins(ctx context.Context, scopeIds []string, opt ...Option) ([]*Plugin, error) {
 	const op = "host.(Repository).ListPlugins"
+	ctx = db.NewOpContext(ctx, op)
 	if len(scopeIds) == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
 	}
@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/scheduler/job"
 	"github.com/hashicorp/go-hclog"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -614,6 +615,126 @@ func TestSchedulerRunNow(t *testing.T) {
 	close(jobCh)
 }
 
+func TestSchedulerFailureReporting(t *testing.T) {
+	// do not use t.Parallel() since it relies on the sys eventer
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iam.TestRepo(t, conn, wrapper)
+	testConfig := event.DefaultEventerConfig()
+	testLock := &sync.Mutex{}
+	testLogger := hclog.New(&hclog.LoggerOptions{
+		Mutex: testLock,
+	})
+	err := event.InitSysEventer(testLogger, testLock, "TestSchedulerFailureReporting", event.WithEventerConfig(testConfig))
+	require.NoError(err)
+
+	sched := TestScheduler(t, conn, wrapper, WithRunJobsLimit(10), WithRunJobsInterval(time.Second), WithFailureThreshold(2))
+
+	jobCh := make(chan error)
+	jobReady := make(chan struct{})
+	testDone := make(chan struct{})
+	fn := func(_ context.Context) error {
+		select {
+		case <-testDone:
+			return nil
+		case jobReady <- struct{}{}:
+		}
+		return <-jobCh
+	}
+	tj := testJob{name: "failing-job", description: "desc", fn: fn, nextRunIn: time.Hour}
+	err = sched.RegisterJob(context.Background(), tj)
+	require.NoError(err)
+
+	baseCtx, baseCnl := context.WithCancel(context.Background())
+	defer baseCnl()
+	var wg sync.WaitGroup
+	err = sched.Start(baseCtx, &wg)
+	require.NoError(err)
+
+	repo, err := job.NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+
+	// Fail the job twice, a failed job is run again on the next scheduling loop
+	<-jobReady
+	runJob, ok := sched.runningJobs.Load(tj.name)
+	require.True(ok)
+	runId := runJob.(*runningJob).runId
+	jobCh <- fmt.Errorf("first failure")
+	run := waitForRunStatus(t, repo, runId, string(job.Failed))
+	assert.Equal("first failure", run.ErrorMessage)
+
+	<-jobReady
+	jobCh <- fmt.Errorf("second failure")
+
+	// The failure metrics are updated before the job is run again
+	<-jobReady
+	j, err := repo.LookupJob(context.Background(), tj.name)
+	require.NoError(err)
+	assert.Equal(uint32(2), j.ConsecutiveFailures)
+	assert.Equal(float64(2), testutil.ToFloat64(jobConsecutiveFailures.WithLabelValues(tj.name)))
+	assert.Equal(float64(1), testutil.ToFloat64(jobFailing.WithLabelValues(tj.name)))
+
+	// Completing the job resets them
+	runJob, ok = sched.runningJobs.Load(tj.name)
+	require.True(ok)
+	runId = runJob.(*runningJob).runId
+	jobCh <- nil
+	waitForRunStatus(t, repo, runId, string(job.Completed))
+	assert.Eventually(func() bool {
+		return testutil.ToFloat64(jobFailing.WithLabelValues(tj.name)) == 0
+	}, 5*time.Second, 100*time.Millisecond)
+	assert.Equal(float64(0), testutil.ToFloat64(jobConsecutiveFailures.WithLabelValues(tj.name)))
+
+	close(testDone)
+	close(jobCh)
+}
+
+func TestSchedulerCheckJobs(t *testing.T) {
+	// do not use t.Parallel() since it relies on the sys eventer
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iam.TestRepo(t, conn, wrapper)
+	testConfig := event.DefaultEventerConfig()
+	testLock := &sync.Mutex{}
+	testLogger := hclog.New(&hclog.LoggerOptions{
+		Mutex: testLock,
+	})
+	err := event.InitSysEventer(testLogger, testLock, "TestSchedulerCheckJobs", event.WithEventerConfig(testConfig))
+	require.NoError(err)
+
+	sched := TestScheduler(t, conn, wrapper, WithOverdueThreshold(time.Hour))
+	repo, err := job.NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	ctx := context.Background()
+
+	_, err = repo.UpsertJob(ctx, "overdue-job", "desc", job.WithNextRunIn(-2*time.Hour))
+	require.NoError(err)
+	_, err = repo.UpsertJob(ctx, "scheduled-job", "desc", job.WithNextRunIn(time.Hour))
+	require.NoError(err)
+
+	overdueJobs := make(map[string]bool)
+	require.NoError(sched.checkJobs(ctx, repo, overdueJobs))
+	assert.Equal(map[string]bool{"overdue-job": true}, overdueJobs)
+	assert.Equal(float64(1), testutil.ToFloat64(jobOverdue.WithLabelValues("overdue-job")))
+	assert.Equal(float64(0), testutil.ToFloat64(jobOverdue.WithLabelValues("scheduled-job")))
+
+	// Once one of its runs completes, the job is no longer overdue
+	runs, err := repo.RunJobs(ctx, sched.serverId)
+	require.NoError(err)
+	require.Len(runs, 1)
+	_, err = repo.CompleteRun(ctx, runs[0].PrivateId, time.Hour, 0, 0)
+	require.NoError(err)
+	require.NoError(sched.checkJobs(ctx, repo, overdueJobs))
+	assert.Empty(overdueJobs)
+	assert.Equal(float64(0), testutil.ToFloat64(jobOverdue.WithLabelValues("overdue-job")))
+}
+
 func waitForRunStatus(t *testing.T, repo *job.Repository, runId, status string) *job.Run {
 	t.Helper()
 	var run *job.Run
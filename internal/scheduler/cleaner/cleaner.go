@@ -12,8 +12,14 @@ import (
 	"github.com/hashicorp/boundary/internal/util"
 )
 
-// RegisterJob registers the cleaner job with the provided scheduler.
-func RegisterJob(ctx context.Context, s *scheduler.Scheduler, w db.Writer) error {
+// defaultRunHistoryLimit is the number of ended runs of each job kept by the
+// cleaner job when no limit is provided.
+const defaultRunHistoryLimit = 10
+
+// RegisterJob registers the cleaner job with the provided scheduler. The
+// cleaner job keeps the runHistoryLimit most recent ended runs of each job,
+// or the 10 most recent ones if runHistoryLimit is 0.
+func RegisterJob(ctx context.Context, s *scheduler.Scheduler, w db.Writer, runHistoryLimit int) error {
 	const op = "cleaner.RegisterJob"
 	if s == nil {
 		return errors.New(ctx, errors.Internal, "nil scheduler", op, errors.WithoutEvent())
@@ -21,8 +27,14 @@ func RegisterJob(ctx context.Context, s *scheduler.Scheduler, w db.Writer) error
 	if util.IsNil(w) {
 		return errors.New(ctx, errors.Internal, "nil DB writer", op, errors.WithoutEvent())
 	}
+	if runHistoryLimit < 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "negative run history limit", errors.WithoutEvent())
+	}
+	if runHistoryLimit == 0 {
+		runHistoryLimit = defaultRunHistoryLimit
+	}
 
-	if err := s.RegisterJob(ctx, newCleanerJob(w)); err != nil {
+	if err := s.RegisterJob(ctx, newCleanerJob(w, runHistoryLimit)); err != nil {
 		return errors.Wrap(ctx, err, op)
 	}
 
@@ -12,13 +12,36 @@ import (
 	"github.com/hashicorp/boundary/internal/scheduler"
 )
 
+// deleteEndedRunsQuery deletes the runs of each job that have ended, whether
+// completed, failed or interrupted, except for the most recent ones so the
+// outcome and errors of recent runs can be reported.
+const deleteEndedRunsQuery = `
+	delete from job_run
+	where private_id in (
+	  select private_id
+	  from (
+	    select
+	      private_id,
+	      row_number() over (
+	        partition by job_plugin_id, job_name
+	        order by end_time desc
+	      ) as run_number
+	    from job_run
+	    where status <> 'running'
+	  ) as ended_runs
+	  where run_number > ?
+	);
+`
+
 type cleanerJob struct {
-	w db.Writer
+	w               db.Writer
+	runHistoryLimit int
 }
 
-func newCleanerJob(w db.Writer) *cleanerJob {
+func newCleanerJob(w db.Writer, runHistoryLimit int) *cleanerJob {
 	return &cleanerJob{
-		w: w,
+		w:               w,
+		runHistoryLimit: runHistoryLimit,
 	}
 }
 
@@ -32,7 +55,7 @@ func (c *cleanerJob) Status() scheduler.JobStatus {
 func (c *cleanerJob) Run(ctx context.Context) error {
 	const op = "cleaner.(cleanerJob).Run"
 
-	if _, err := c.w.Exec(ctx, "delete from job_run where status='completed'", nil); err != nil {
+	if _, err := c.w.Exec(ctx, deleteEndedRunsQuery, []any{c.runHistoryLimit}); err != nil {
 		return errors.Wrap(ctx, err, op)
 	}
 
@@ -53,5 +76,5 @@ func (c *cleanerJob) Name() string {
 
 // Description is the human readable description of the job.
 func (c *cleanerJob) Description() string {
-	return "Cleans completed job runs"
+	return "Cleans ended job runs, keeping the most recent runs of each job"
 }
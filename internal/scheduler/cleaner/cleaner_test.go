@@ -21,7 +21,7 @@ func TestCleanerJob(t *testing.T) {
 	rw := db.New(conn)
 	wrapper := db.TestWrapper(t)
 	s := scheduler.TestScheduler(t, conn, wrapper, scheduler.WithMonitorInterval(10*time.Millisecond))
-	err := cleaner.RegisterJob(context.Background(), s, rw)
+	err := cleaner.RegisterJob(context.Background(), s, rw, 1)
 	require.NoError(t, err)
 	wg := &sync.WaitGroup{}
 	err = s.Start(context.Background(), wg)
@@ -50,15 +50,19 @@ func TestRegisterJob(t *testing.T) {
 	s := scheduler.TestScheduler(t, conn, wrapper)
 
 	t.Run("succeeds", func(t *testing.T) {
-		err := cleaner.RegisterJob(context.Background(), s, rw)
+		err := cleaner.RegisterJob(context.Background(), s, rw, 0)
 		require.NoError(t, err)
 	})
 	t.Run("fails-on-nil-scheduler", func(t *testing.T) {
-		err := cleaner.RegisterJob(context.Background(), nil, rw)
+		err := cleaner.RegisterJob(context.Background(), nil, rw, 0)
 		require.Error(t, err)
 	})
 	t.Run("fails-on-nil-db-writer", func(t *testing.T) {
-		err := cleaner.RegisterJob(context.Background(), s, nil)
+		err := cleaner.RegisterJob(context.Background(), s, nil, 0)
+		require.Error(t, err)
+	})
+	t.Run("fails-on-negative-run-history-limit", func(t *testing.T) {
+		err := cleaner.RegisterJob(context.Background(), s, rw, -1)
 		require.Error(t, err)
 	})
 }
@@ -31,6 +31,7 @@ type options struct {
 	withLimit        int
 	withName         string
 	withControllerId string
+	withErrorMessage string
 }
 
 func getDefaultOptions() options {
@@ -70,6 +71,7 @@ func WithLimit(l int) Option {
 }
 
 // WithName provides an option to provide the name to match when calling ListJobs
+// and ListLatestRuns
 func WithName(n string) Option {
 	return func(o *options) {
 		o.withName = n
@@ -82,3 +84,10 @@ func WithControllerId(id string) Option {
 		o.withControllerId = id
 	}
 }
+
+// WithErrorMessage provides an option to provide the error of a failed run when calling FailRun
+func WithErrorMessage(msg string) Option {
+	return func(o *options) {
+		o.withErrorMessage = msg
+	}
+}
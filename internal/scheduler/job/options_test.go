@@ -60,4 +60,12 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withControllerId = "controller_id"
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithErrorMessage", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithErrorMessage("error"))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.withErrorMessage = "error"
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -93,7 +93,8 @@ const failRunQuery = `
 	  completed_count = ?,
 	  total_count     = ?,
 	  status          = 'failed',
-	  end_time        = current_timestamp
+	  end_time        = current_timestamp,
+	  error_message   = nullif(?, '')
 	where
 	  private_id = ?
 	  and status = 'running'
@@ -113,6 +114,22 @@ const interruptRunsQuery = `
 	returning *;
 `
 
+const listLatestRunsQuery = `
+	select distinct on (job_plugin_id, job_name, status = 'running')
+	  *
+	from job_run
+	%s
+	order by job_plugin_id, job_name, status = 'running', create_time desc;
+`
+
+const listOverdueJobsQuery = `
+	select
+	  *
+	from job
+	where next_scheduled_run <= wt_add_seconds_to_now(?)
+	order by next_scheduled_run asc;
+`
+
 const deleteJobByName = `
 	delete 
 	from job 
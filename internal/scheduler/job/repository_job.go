@@ -24,6 +24,7 @@ import (
 // WithNextRunIn is the only valid options.
 func (r *Repository) UpsertJob(ctx context.Context, name, description string, opt ...Option) (*Job, error) {
 	const op = "job.(Repository).UpsertJob"
+	ctx = db.NewOpContext(ctx, op)
 	if name == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing name")
 	}
@@ -84,6 +85,7 @@ func (r *Repository) UpsertJob(ctx context.Context, name, description string, op
 // All options are ignored.
 func (r *Repository) UpdateJobNextRunInAtLeast(ctx context.Context, name string, nextRunInAtLeast time.Duration, _ ...Option) (*Job, error) {
 	const op = "job.(Repository).UpdateJobNextRunInAtLeast"
+	ctx = db.NewOpContext(ctx, op)
 	if name == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing name")
 	}
@@ -129,6 +131,7 @@ func (r *Repository) UpdateJobNextRunInAtLeast(ctx context.Context, name string,
 // All options are ignored.
 func (r *Repository) LookupJob(ctx context.Context, name string, _ ...Option) (*Job, error) {
 	const op = "job.(Repository).LookupJob"
+	ctx = db.NewOpContext(ctx, op)
 	if name == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing name")
 	}
@@ -148,6 +151,7 @@ func (r *Repository) LookupJob(ctx context.Context, name string, _ ...Option) (*
 // WithName and WithLimit are the only valid options.
 func (r *Repository) ListJobs(ctx context.Context, opt ...Option) ([]*Job, error) {
 	const op = "job.(Repository).ListJobs"
+	ctx = db.NewOpContext(ctx, op)
 	opts := getOpts(opt...)
 	limit := r.defaultLimit
 	if opts.withLimit != 0 {
@@ -168,12 +172,48 @@ func (r *Repository) ListJobs(ctx context.Context, opt ...Option) ([]*Job, error
 	return jobs, nil
 }
 
+// ListOverdueJobs returns the jobs whose NextScheduledRun is more than
+// overdueThreshold in the past. As completing a run sets the NextScheduledRun
+// of its job, these are the jobs that have been due to run for longer than
+// overdueThreshold without completing: jobs whose runs keep failing and jobs
+// whose run is stuck.
+//
+// All options are ignored.
+func (r *Repository) ListOverdueJobs(ctx context.Context, overdueThreshold time.Duration, _ ...Option) ([]*Job, error) {
+	const op = "job.(Repository).ListOverdueJobs"
+	ctx = db.NewOpContext(ctx, op)
+	if overdueThreshold < 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "negative overdue threshold")
+	}
+
+	// overdueThreshold is seconds in past so * -1
+	rows, err := r.reader.Query(ctx, listOverdueJobsQuery, []any{-1 * int(overdueThreshold.Round(time.Second).Seconds())})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	var jobs []*Job
+	for rows.Next() {
+		j := allocJob()
+		if err := r.reader.ScanRows(ctx, rows, j); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to scan rows for job"))
+		}
+		jobs = append(jobs, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return jobs, nil
+}
+
 // deleteJob deletes the job for the provided job name from the repository
 // returning a count of the number of records deleted.
 //
 // All options are ignored.
 func (r *Repository) deleteJob(ctx context.Context, name string, _ ...Option) (int, error) {
 	const op = "job.(Repository).deleteJob"
+	ctx = db.NewOpContext(ctx, op)
 	if name == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing name")
 	}
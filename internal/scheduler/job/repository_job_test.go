@@ -454,3 +454,33 @@ func TestRepository_UpdateJobNextRunInAtLeast(t *testing.T) {
 		assert.Equal("job.(Repository).UpdateJobNextRunInAtLeast: db.DoTx: job.(Repository).UpdateJobNextRunInAtLeast: job \"fake-name\" does not exist: search issue: error #1100", err.Error())
 	})
 }
+
+func TestRepository_ListOverdueJobs(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iam.TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	overdue := testJob(t, conn, "overdue", "description", wrapper, WithNextRunIn(-2*time.Hour))
+	due := testJob(t, conn, "due", "description", wrapper)
+	testJob(t, conn, "scheduled", "description", wrapper, WithNextRunIn(time.Hour))
+
+	_, err = repo.ListOverdueJobs(ctx, -time.Hour)
+	require.Error(t, err)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+
+	got, err := repo.ListOverdueJobs(ctx, time.Hour)
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff([]*Job{overdue}, got, protocmp.Transform()))
+
+	got, err = repo.ListOverdueJobs(ctx, 0)
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff([]*Job{overdue, due}, got, protocmp.Transform()))
+}
@@ -21,6 +21,7 @@ import (
 // The only valid option is WithRunJobsLimit, if not provided RunJobs will run only 1 job.
 func (r *Repository) RunJobs(ctx context.Context, serverId string, opt ...Option) ([]*Run, error) {
 	const op = "job.(Repository).RunJobs"
+	ctx = db.NewOpContext(ctx, op)
 	if serverId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing server id")
 	}
@@ -70,6 +71,7 @@ func (r *Repository) RunJobs(ctx context.Context, serverId string, opt ...Option
 // All options are ignored.
 func (r *Repository) UpdateProgress(ctx context.Context, runId string, completed, total int, _ ...Option) (*Run, error) {
 	const op = "job.(Repository).UpdateProgress"
+	ctx = db.NewOpContext(ctx, op)
 	if runId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing run id")
 	}
@@ -130,6 +132,7 @@ func (r *Repository) UpdateProgress(ctx context.Context, runId string, completed
 // All options are ignored.
 func (r *Repository) CompleteRun(ctx context.Context, runId string, nextRunIn time.Duration, completed, total int, _ ...Option) (*Run, error) {
 	const op = "job.(Repository).CompleteRun"
+	ctx = db.NewOpContext(ctx, op)
 	if runId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing run id")
 	}
@@ -203,17 +206,21 @@ func (r *Repository) CompleteRun(ctx context.Context, runId string, nextRunIn ti
 // FailRun updates the Run repository entry for the provided runId.
 // It sets the status to 'failed' and updates the run's EndTime to the current database
 // time, and sets the completed and total counts.
+// Failing a run increments the ConsecutiveFailures of the job associated with it,
+// which are reset when one of its runs completes.
 //
 // Once a run has been persisted with a final run status (completed, failed
 // or interrupted), any future calls to FailRun will return an error with Code
 // errors.InvalidJobRunState.
-// All options are ignored.
-func (r *Repository) FailRun(ctx context.Context, runId string, completed, total int, _ ...Option) (*Run, error) {
+// WithErrorMessage is the only valid option.
+func (r *Repository) FailRun(ctx context.Context, runId string, completed, total int, opt ...Option) (*Run, error) {
 	const op = "job.(Repository).FailRun"
+	ctx = db.NewOpContext(ctx, op)
 	if runId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing run id")
 	}
 
+	opts := getOpts(opt...)
 	run := allocRun()
 	run.PrivateId = runId
 	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
@@ -222,7 +229,7 @@ func (r *Repository) FailRun(ctx context.Context, runId string, completed, total
 			// persisted by the scheduler's monitor jobs loop.
 			// Add an on update sql trigger to protect the job_run table, once progress
 			// values are used in the critical path.
-			rows, err := w.Query(ctx, failRunQuery, []any{completed, total, runId})
+			rows, err := w.Query(ctx, failRunQuery, []any{completed, total, opts.withErrorMessage, runId})
 			if err != nil {
 				return errors.Wrap(ctx, err, op)
 			}
@@ -271,6 +278,7 @@ func (r *Repository) FailRun(ctx context.Context, runId string, completed, total
 // WithControllerId is the only valid option
 func (r *Repository) InterruptRuns(ctx context.Context, interruptThreshold time.Duration, opt ...Option) ([]*Run, error) {
 	const op = "job.(Repository).InterruptRuns"
+	ctx = db.NewOpContext(ctx, op)
 
 	opts := getOpts(opt...)
 
@@ -317,6 +325,7 @@ func (r *Repository) InterruptRuns(ctx context.Context, interruptThreshold time.
 // All options are ignored.
 func (r *Repository) LookupRun(ctx context.Context, runId string, _ ...Option) (*Run, error) {
 	const op = "job.(Repository).LookupRun"
+	ctx = db.NewOpContext(ctx, op)
 	if runId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing run id")
 	}
@@ -332,12 +341,48 @@ func (r *Repository) LookupRun(ctx context.Context, runId string, _ ...Option) (
 	return run, nil
 }
 
+// ListLatestRuns returns, for each job, the run that is in progress, if any,
+// and the most recent run that has ended. Runs that have ended may have been
+// removed by the job run cleaner.
+//
+// WithName is the only valid option.
+func (r *Repository) ListLatestRuns(ctx context.Context, opt ...Option) ([]*Run, error) {
+	const op = "job.(Repository).ListLatestRuns"
+	ctx = db.NewOpContext(ctx, op)
+	opts := getOpts(opt...)
+	var where string
+	var args []any
+	if opts.withName != "" {
+		where, args = "where job_name = ?", append(args, opts.withName)
+	}
+
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(listLatestRunsQuery, where), args)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	var runs []*Run
+	for rows.Next() {
+		run := allocRun()
+		if err := r.reader.ScanRows(ctx, rows, run); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to scan rows for job run"))
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return runs, nil
+}
+
 // deleteRun deletes the job for the provided runId from the repository
 // returning a count of the number of records deleted.
 //
 // All options are ignored.
 func (r *Repository) deleteRun(ctx context.Context, runId string, _ ...Option) (int, error) {
 	const op = "job.(Repository).deleteRun"
+	ctx = db.NewOpContext(ctx, op)
 	if runId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing run id")
 	}
@@ -642,6 +642,7 @@ func TestRepository_FailRun(t *testing.T) {
 		name        string
 		orig        *Run
 		args        args
+		opts        []Option
 		wantErr     bool
 		wantErrCode errors.Code
 		wantErrMsg  string
@@ -717,6 +718,18 @@ func TestRepository_FailRun(t *testing.T) {
 			},
 			args: args{completed: 10, total: 20},
 		},
+		{
+			name: "valid-with-error-message",
+			orig: &Run{
+				JobRun: &store.JobRun{
+					JobName:      job.Name,
+					JobPluginId:  job.PluginId,
+					ControllerId: server.PrivateId,
+					Status:       Running.string(),
+				},
+			},
+			opts: []Option{WithErrorMessage("test error")},
+		},
 	}
 
 	for _, tt := range tests {
@@ -735,7 +748,7 @@ func TestRepository_FailRun(t *testing.T) {
 				privateId = tt.orig.PrivateId
 			}
 
-			got, err := repo.FailRun(context.Background(), privateId, tt.args.completed, tt.args.total)
+			got, err := repo.FailRun(context.Background(), privateId, tt.args.completed, tt.args.total, tt.opts...)
 			if tt.wantErr {
 				require.Error(err)
 				assert.Truef(errors.Match(errors.T(tt.wantErrCode), err), "Unexpected error %s", err)
@@ -755,6 +768,7 @@ func TestRepository_FailRun(t *testing.T) {
 			assert.Equal(Failed.string(), got.Status)
 			assert.Equal(tt.args.completed, int(got.CompletedCount))
 			assert.Equal(tt.args.total, int(got.TotalCount))
+			assert.Equal(getOpts(tt.opts...).withErrorMessage, got.ErrorMessage)
 
 			// Delete job run so it does not clash with future runs
 			_, err = repo.deleteRun(context.Background(), privateId)
@@ -1268,3 +1282,97 @@ func TestRepository_deleteJobRun(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_ListLatestRuns(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iam.TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	job1 := testJob(t, conn, "job1", "description", wrapper)
+	job2 := testJob(t, conn, "job2", "description", wrapper)
+	server := testController(t, conn, wrapper)
+
+	// job1 has two failed runs and one running run, job2 has no runs.
+	var failed *Run
+	for i := 0; i < 2; i++ {
+		run, err := testRun(conn, job1.PluginId, job1.Name, server.PrivateId)
+		require.NoError(t, err)
+		failed, err = repo.FailRun(ctx, run.PrivateId, i, 10)
+		require.NoError(t, err)
+	}
+	running, err := testRun(conn, job1.PluginId, job1.Name, server.PrivateId)
+	require.NoError(t, err)
+
+	got, err := repo.ListLatestRuns(ctx)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	ids := []string{got[0].PrivateId, got[1].PrivateId}
+	assert.ElementsMatch(t, []string{failed.PrivateId, running.PrivateId}, ids)
+
+	got, err = repo.ListLatestRuns(ctx, WithName(job1.Name))
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+
+	got, err = repo.ListLatestRuns(ctx, WithName(job2.Name))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestRepository_ConsecutiveFailures(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iam.TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	job := testJob(t, conn, "name", "description", wrapper)
+	server := testController(t, conn, wrapper)
+	assert.Equal(t, uint32(0), job.ConsecutiveFailures)
+
+	consecutiveFailures := func() uint32 {
+		t.Helper()
+		got, err := repo.LookupJob(ctx, job.Name)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		return got.ConsecutiveFailures
+	}
+
+	// Failed and interrupted runs count as failures.
+	run, err := testRun(conn, job.PluginId, job.Name, server.PrivateId)
+	require.NoError(t, err)
+	_, err = repo.FailRun(ctx, run.PrivateId, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), consecutiveFailures())
+
+	run, err = testRun(conn, job.PluginId, job.Name, server.PrivateId)
+	require.NoError(t, err)
+	_, err = repo.InterruptRuns(ctx, 0, WithControllerId(server.PrivateId))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), consecutiveFailures())
+
+	// Progress updates leave them as is.
+	run, err = testRun(conn, job.PluginId, job.Name, server.PrivateId)
+	require.NoError(t, err)
+	_, err = repo.UpdateProgress(ctx, run.PrivateId, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), consecutiveFailures())
+
+	// A completed run resets them.
+	_, err = repo.CompleteRun(ctx, run.PrivateId, time.Hour, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), consecutiveFailures())
+}
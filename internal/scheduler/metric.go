@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package scheduler
+
+import (
+	"github.com/hashicorp/boundary/globals"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	schedulerSubsystem = "controller_scheduler"
+
+	labelJobName   = "job_name"
+	labelRunStatus = "status"
+)
+
+// jobRunDuration collects measurements of how long the runs of each job
+// took, by the status they ended with.
+var jobRunDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: globals.MetricNamespace,
+		Subsystem: schedulerSubsystem,
+		Name:      "job_run_duration_seconds",
+		Help:      "Histogram of the durations of job runs.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 4, 10),
+	},
+	[]string{labelJobName, labelRunStatus},
+)
+
+// jobConsecutiveFailures reports the number of runs of each job that have
+// failed or been interrupted since its last completed run.
+var jobConsecutiveFailures = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: globals.MetricNamespace,
+		Subsystem: schedulerSubsystem,
+		Name:      "job_consecutive_failures",
+		Help:      "Number of runs of a job that have failed or been interrupted since its last completed run.",
+	},
+	[]string{labelJobName},
+)
+
+// jobFailing reports whether the consecutive failures of each job have
+// reached the failure threshold of the scheduler.
+var jobFailing = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: globals.MetricNamespace,
+		Subsystem: schedulerSubsystem,
+		Name:      "job_failing",
+		Help:      "1 if the consecutive failures of a job have reached the configured failure threshold, 0 otherwise.",
+	},
+	[]string{labelJobName},
+)
+
+// jobOverdue reports whether each job has been due to run for longer than
+// the overdue threshold of the scheduler without completing.
+var jobOverdue = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: globals.MetricNamespace,
+		Subsystem: schedulerSubsystem,
+		Name:      "job_overdue",
+		Help:      "1 if a job has been due to run for longer than the configured overdue threshold without completing, 0 otherwise.",
+	},
+	[]string{labelJobName},
+)
+
+// InitializeCollectors registers the scheduler collectors with r.
+func InitializeCollectors(r prometheus.Registerer) {
+	if r == nil {
+		return
+	}
+	r.MustRegister(jobRunDuration, jobConsecutiveFailures, jobFailing, jobOverdue)
+}
+
+// setFailureMetrics sets the failure collectors of the job with the provided
+// name.
+func setFailureMetrics(name string, consecutiveFailures uint32, failureThreshold int) {
+	jobConsecutiveFailures.WithLabelValues(name).Set(float64(consecutiveFailures))
+	var failing float64
+	if int(consecutiveFailures) >= failureThreshold {
+		failing = 1
+	}
+	jobFailing.WithLabelValues(name).Set(failing)
+}
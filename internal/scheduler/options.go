@@ -10,6 +10,8 @@ const (
 	defaultRunJobsInterval    = time.Minute
 	defaultMonitorInterval    = 30 * time.Second
 	defaultInterruptThreshold = 5 * time.Minute
+	defaultFailureThreshold   = 3
+	defaultOverdueThreshold   = time.Hour
 )
 
 // getOpts - iterate the inbound Options and return a struct
@@ -31,6 +33,8 @@ type options struct {
 	withRunJobInterval     time.Duration
 	withMonitorInterval    time.Duration
 	withInterruptThreshold time.Duration
+	withFailureThreshold   int
+	withOverdueThreshold   time.Duration
 	withRunNow             bool
 }
 
@@ -40,6 +44,8 @@ func getDefaultOptions() options {
 		withRunJobInterval:     defaultRunJobsInterval,
 		withMonitorInterval:    defaultMonitorInterval,
 		withInterruptThreshold: defaultInterruptThreshold,
+		withFailureThreshold:   defaultFailureThreshold,
+		withOverdueThreshold:   defaultOverdueThreshold,
 	}
 }
 
@@ -92,6 +98,30 @@ func WithInterruptThreshold(l time.Duration) Option {
 	}
 }
 
+// WithFailureThreshold provides an option to provide the number of consecutive failed or
+// interrupted runs of a job after which the scheduler reports the job as failing.
+// If WithFailureThreshold == 0, then default threshold is used.
+func WithFailureThreshold(l int) Option {
+	return func(o *options) {
+		o.withFailureThreshold = l
+		if o.withFailureThreshold == 0 {
+			o.withFailureThreshold = defaultFailureThreshold
+		}
+	}
+}
+
+// WithOverdueThreshold provides an option to provide the duration after which the scheduler
+// reports a job that has been due to run without completing as overdue.
+// If WithOverdueThreshold == 0, then default duration is used.
+func WithOverdueThreshold(l time.Duration) Option {
+	return func(o *options) {
+		o.withOverdueThreshold = l
+		if o.withOverdueThreshold == 0 {
+			o.withOverdueThreshold = defaultOverdueThreshold
+		}
+	}
+}
+
 // WithNextRunIn provides an option to provide the duration until the next run is scheduled.
 // If this option is not provided the NextScheduledRun of the job will default to the
 // current database time, and be available to run immediately.
@@ -69,6 +69,34 @@ func Test_GetOpts(t *testing.T) {
 		testOpts := getDefaultOptions()
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithFailureThreshold", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithFailureThreshold(10))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.withFailureThreshold = 10
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithZeroFailureThreshold", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithFailureThreshold(0))
+		testOpts := getDefaultOptions()
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithOverdueThreshold", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithOverdueThreshold(time.Minute))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.withOverdueThreshold = time.Minute
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithZeroOverdueThreshold", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithOverdueThreshold(0))
+		testOpts := getDefaultOptions()
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithRunNow", func(t *testing.T) {
 		assert := assert.New(t)
 		opts := getOpts(WithRunNow(true))
@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/scheduler/job"
@@ -36,6 +37,8 @@ type Scheduler struct {
 	runJobsInterval    time.Duration
 	monitorInterval    time.Duration
 	interruptThreshold time.Duration
+	failureThreshold   int
+	overdueThreshold   time.Duration
 	runNow             chan struct{}
 }
 
@@ -45,8 +48,8 @@ type Scheduler struct {
 //
 // • jobRepoFn must be provided and is a function that returns the job repository
 //
-// WithRunJobsLimit, WithRunJobsInterval, WithMonitorInterval and WithInterruptThreshold are
-// the only valid options.
+// WithRunJobsLimit, WithRunJobsInterval, WithMonitorInterval, WithInterruptThreshold,
+// WithFailureThreshold and WithOverdueThreshold are the only valid options.
 func New(serverId string, jobRepoFn jobRepoFactory, opt ...Option) (*Scheduler, error) {
 	const op = "scheduler.New"
 	if serverId == "" {
@@ -66,6 +69,8 @@ func New(serverId string, jobRepoFn jobRepoFactory, opt ...Option) (*Scheduler,
 		runJobsInterval:    opts.withRunJobInterval,
 		monitorInterval:    opts.withMonitorInterval,
 		interruptThreshold: opts.withInterruptThreshold,
+		failureThreshold:   opts.withFailureThreshold,
+		overdueThreshold:   opts.withOverdueThreshold,
 		runNow:             make(chan struct{}, 1),
 	}, nil
 }
@@ -228,9 +233,11 @@ func (s *Scheduler) schedule(ctx context.Context, wg *sync.WaitGroup) {
 		err := s.runJob(ctx, wg, r)
 		if err != nil {
 			event.WriteError(ctx, op, err, event.WithInfoMsg("error starting job"))
-			if _, inner := repo.FailRun(ctx, r.PrivateId, 0, 0); inner != nil {
+			if _, inner := repo.FailRun(ctx, r.PrivateId, 0, 0, job.WithErrorMessage(err.Error())); inner != nil {
 				event.WriteError(ctx, op, inner, event.WithInfoMsg("error updating failed job run"))
+				continue
 			}
+			s.reportFailure(ctx, repo, r.JobName, err.Error())
 		}
 	}
 }
@@ -255,12 +262,17 @@ func (s *Scheduler) runJob(ctx context.Context, wg *sync.WaitGroup, r *job.Run)
 	}
 	var jobContext context.Context
 	jobContext, rj.cancelCtx = context.WithCancel(ctx)
+	// Attribute the database operations of the run that are not performed
+	// by a repository method to the job
+	jobContext = db.NewOpContext(jobContext, j.Name())
 
 	wg.Add(1)
 	go func() {
 		defer rj.cancelCtx()
 		defer wg.Done()
+		start := time.Now()
 		runErr := j.Run(jobContext)
+		duration := time.Since(start)
 
 		// Get final status report to update run progress with
 		status := j.Status()
@@ -274,9 +286,17 @@ func (s *Scheduler) runJob(ctx context.Context, wg *sync.WaitGroup, r *job.Run)
 				event.WriteError(ctx, op, inner, event.WithInfoMsg("error getting next run time", "name", j.Name()))
 			}
 			_, updateErr = repo.CompleteRun(ctx, r.PrivateId, nextRun, status.Completed, status.Total)
+			if updateErr == nil {
+				jobRunDuration.WithLabelValues(j.Name(), string(job.Completed)).Observe(duration.Seconds())
+				setFailureMetrics(j.Name(), 0, s.failureThreshold)
+			}
 		default:
 			event.WriteError(ctx, op, runErr, event.WithInfoMsg("job run failed", "run id", r.PrivateId, "name", j.Name()))
-			_, updateErr = repo.FailRun(ctx, r.PrivateId, status.Completed, status.Total)
+			_, updateErr = repo.FailRun(ctx, r.PrivateId, status.Completed, status.Total, job.WithErrorMessage(runErr.Error()))
+			if updateErr == nil {
+				jobRunDuration.WithLabelValues(j.Name(), string(job.Failed)).Observe(duration.Seconds())
+				s.reportFailure(ctx, repo, j.Name(), runErr.Error())
+			}
 		}
 
 		if updateErr != nil {
@@ -293,8 +313,12 @@ func (s *Scheduler) monitorJobs(ctx context.Context) {
 	event.WriteSysEvent(ctx, op, "monitor loop running",
 		"server id", s.serverId,
 		"monitor interval", s.monitorInterval.String(),
-		"interrupt threshold", s.interruptThreshold.String())
+		"interrupt threshold", s.interruptThreshold.String(),
+		"failure threshold", s.failureThreshold,
+		"overdue threshold", s.overdueThreshold.String())
 	timer := time.NewTimer(0)
+	// overdueJobs holds the names of the jobs reported as overdue by the last check
+	overdueJobs := make(map[string]bool)
 	for {
 		select {
 		case <-ctx.Done():
@@ -318,15 +342,91 @@ func (s *Scheduler) monitorJobs(ctx context.Context) {
 				break
 			}
 
-			_, err = repo.InterruptRuns(ctx, s.interruptThreshold)
+			runs, err := repo.InterruptRuns(ctx, s.interruptThreshold)
 			if err != nil {
 				event.WriteError(ctx, op, err, event.WithInfoMsg("error interrupting job runs"))
 			}
+			for _, r := range runs {
+				s.reportFailure(ctx, repo, r.JobName, "job run interrupted after not updating its progress")
+			}
+
+			// Check for failing and overdue jobs
+			if err := s.checkJobs(ctx, repo, overdueJobs); err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error checking jobs"))
+			}
 		}
 		timer.Reset(s.monitorInterval)
 	}
 }
 
+// reportFailure updates the failure collectors of the job with the provided name
+// after one of its runs failed or was interrupted, and writes a system event if
+// its consecutive failures reached the failure threshold.
+func (s *Scheduler) reportFailure(ctx context.Context, repo *job.Repository, name, errMsg string) {
+	const op = "scheduler.(Scheduler).reportFailure"
+	j, err := repo.LookupJob(ctx, name)
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error looking up job", "name", name))
+		return
+	}
+	if j == nil {
+		return
+	}
+	setFailureMetrics(name, j.ConsecutiveFailures, s.failureThreshold)
+	if int(j.ConsecutiveFailures) >= s.failureThreshold {
+		event.WriteSysEvent(ctx, op, "job failure threshold reached",
+			"name", name,
+			"consecutive failures", j.ConsecutiveFailures,
+			"failure threshold", s.failureThreshold,
+			"error", errMsg)
+	}
+}
+
+// checkJobs updates the failure and overdue collectors of all jobs, and writes a
+// system event for each job that became overdue or stopped being overdue since
+// the previous check. overdueJobs holds the names of the jobs that were overdue
+// at the previous check and is updated with the ones that are overdue now.
+func (s *Scheduler) checkJobs(ctx context.Context, repo *job.Repository, overdueJobs map[string]bool) error {
+	const op = "scheduler.(Scheduler).checkJobs"
+	jobs, err := repo.ListJobs(ctx, job.WithLimit(-1))
+	if err != nil {
+		return fmt.Errorf("error listing jobs: %w", err)
+	}
+	overdue, err := repo.ListOverdueJobs(ctx, s.overdueThreshold)
+	if err != nil {
+		return fmt.Errorf("error listing overdue jobs: %w", err)
+	}
+
+	isOverdue := make(map[string]bool, len(overdue))
+	for _, j := range overdue {
+		isOverdue[j.Name] = true
+		if !overdueJobs[j.Name] {
+			event.WriteSysEvent(ctx, op, "job overdue",
+				"name", j.Name,
+				"next scheduled run", j.GetNextScheduledRun().GetTimestamp().AsTime().String(),
+				"overdue threshold", s.overdueThreshold.String(),
+				"consecutive failures", j.ConsecutiveFailures)
+			overdueJobs[j.Name] = true
+		}
+	}
+	for name := range overdueJobs {
+		if !isOverdue[name] {
+			event.WriteSysEvent(ctx, op, "job no longer overdue", "name", name)
+			delete(overdueJobs, name)
+		}
+	}
+
+	for _, j := range jobs {
+		setFailureMetrics(j.Name, j.ConsecutiveFailures, s.failureThreshold)
+		var v float64
+		if isOverdue[j.Name] {
+			v = 1
+		}
+		jobOverdue.WithLabelValues(j.Name).Set(v)
+	}
+	return nil
+}
+
 func (s *Scheduler) updateRunningJobProgress(ctx context.Context, j *runningJob) error {
 	repo, err := s.jobRepoFn()
 	if err != nil {
@@ -13,6 +13,8 @@ import (
 )
 
 func (r *Repository) ListControllers(ctx context.Context, opt ...Option) ([]*store.Controller, error) {
+	const op = "server.(Repository).ListControllers"
+	ctx = db.NewOpContext(ctx, op)
 	return r.listControllersWithReader(ctx, r.reader, opt...)
 }
 
@@ -47,6 +49,7 @@ func (r *Repository) listControllersWithReader(ctx context.Context, reader db.Re
 
 func (r *Repository) UpsertController(ctx context.Context, controller *store.Controller) (int, error) {
 	const op = "server.UpsertController"
+	ctx = db.NewOpContext(ctx, op)
 
 	if controller == nil {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "controller is nil")
@@ -31,6 +31,7 @@ const (
 // AddNonce adds a nonce
 func (r *Repository) AddNonce(ctx context.Context, nonce, purpose string, opt ...Option) error {
 	const op = "server.AddNonce"
+	ctx = db.NewOpContext(ctx, op)
 	if nonce == "" {
 		return errors.New(ctx, errors.InvalidParameter, op, "empty nonce")
 	}
@@ -52,6 +53,8 @@ func (r *Repository) AddNonce(ctx context.Context, nonce, purpose string, opt ..
 
 // CleanupNonces removes nonces that no longer need to be stored
 func (r *Repository) CleanupNonces(ctx context.Context, opt ...Option) (int, error) {
+	const op = "server.(Repository).CleanupNonces"
+	ctx = db.NewOpContext(ctx, op)
 	// Use the largest validity period out of the various nonces we're looking at
 	maxDuration := globals.RecoveryTokenValidityPeriod
 	if globals.WorkerAuthNonceValidityPeriod > maxDuration {
@@ -62,16 +65,18 @@ func (r *Repository) CleanupNonces(ctx context.Context, opt ...Option) (int, err
 
 	rows, err := r.writer.Delete(ctx, &Nonce{}, db.WithWhere(deleteWhereCreateTimeSql, endTime))
 	if err != nil {
-		return db.NoRowsAffected, errors.Wrap(ctx, err, "server.CleanupNonces")
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
 	}
 	return rows, nil
 }
 
 // ListNonces lists nonces. Used only for tests at the moment.
 func (r *Repository) ListNonces(ctx context.Context, purpose string, opt ...Option) ([]*Nonce, error) {
+	const op = "server.(Repository).ListNonces"
+	ctx = db.NewOpContext(ctx, op)
 	var nonces []*Nonce
 	if err := r.reader.SearchWhere(ctx, &nonces, "purpose = ?", []any{purpose}, db.WithLimit(-1)); err != nil {
-		return nil, errors.Wrap(ctx, err, "server.ListNonces")
+		return nil, errors.Wrap(ctx, err, op)
 	}
 	return nonces, nil
 }
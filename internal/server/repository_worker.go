@@ -28,6 +28,7 @@ var ErrCannotUpdateKmsWorkerViaApi = stderrors.New("cannot update a kms worker's
 // DeleteWorker will delete a worker from the repository.
 func (r *Repository) DeleteWorker(ctx context.Context, publicId string, _ ...Option) (int, error) {
 	const op = "server.(Repository).DeleteWorker"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
 	}
@@ -69,6 +70,7 @@ func (r *Repository) DeleteWorker(ctx context.Context, publicId string, _ ...Opt
 // that no worker is found that matches then nil, nil will be returned.
 func (r *Repository) LookupWorkerByName(ctx context.Context, name string) (*Worker, error) {
 	const op = "server.(Repository).LookupWorkerByName"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case name == "":
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "name is empty")
@@ -106,6 +108,7 @@ func lookupWorkerByName(ctx context.Context, reader db.Reader, name string) (*Wo
 
 func (r *Repository) LookupWorkerIdByKeyId(ctx context.Context, keyId string) (string, error) {
 	const op = "server.(Repository).LookupWorkerIdByKeyId"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case keyId == "":
 		return "", errors.New(ctx, errors.InvalidParameter, op, "keyId is empty")
@@ -130,6 +133,7 @@ func (r *Repository) LookupWorkerIdByKeyId(ctx context.Context, keyId string) (s
 // nil nil in the situation where no worker can be found with that public id.
 func (r *Repository) LookupWorker(ctx context.Context, publicId string, _ ...Option) (*Worker, error) {
 	const op = "server.(Repository).LookupWorker"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case publicId == "":
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "publicId is empty")
@@ -175,6 +179,7 @@ func lookupWorker(ctx context.Context, reader db.Reader, id string) (*Worker, er
 // Also supports: WithWorkerType, WithActiveWorkers
 func (r *Repository) ListWorkers(ctx context.Context, scopeIds []string, opt ...Option) ([]*Worker, error) {
 	const op = "server.(Repository).ListWorkers"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case len(scopeIds) == 0:
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope ids set")
@@ -252,6 +257,7 @@ func (r *Repository) ListWorkers(ctx context.Context, scopeIds []string, opt ...
 // Workers are intentionally not oplogged.
 func (r *Repository) UpsertWorkerStatus(ctx context.Context, worker *Worker, opt ...Option) (*Worker, error) {
 	const op = "server.UpsertWorkerStatus"
+	ctx = db.NewOpContext(ctx, op)
 
 	opts := GetOpts(opt...)
 	switch {
@@ -435,6 +441,7 @@ func (r *Repository) UpdateWorker(ctx context.Context, worker *Worker, version u
 		descField = "description"
 	)
 	const op = "server.(Repository).UpdateWorker"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case worker == nil:
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "worker is nil")
@@ -538,6 +545,7 @@ func (r *Repository) UpdateWorker(ctx context.Context, worker *Worker, version u
 // exclusive.
 func (r *Repository) CreateWorker(ctx context.Context, worker *Worker, opt ...Option) (*Worker, error) {
 	const op = "server.CreateWorker"
+	ctx = db.NewOpContext(ctx, op)
 
 	opts := GetOpts(opt...)
 
@@ -648,6 +656,7 @@ func (r *Repository) CreateWorker(ctx context.Context, worker *Worker, opt ...Op
 // No options are currently supported.
 func (r *Repository) AddWorkerTags(ctx context.Context, workerId string, workerVersion uint32, tags []*Tag, _ ...Option) ([]*Tag, error) {
 	const op = "server.(Repository).AddWorkerTags"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case workerId == "":
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "worker public id is empty")
@@ -693,6 +702,7 @@ func (r *Repository) AddWorkerTags(ctx context.Context, workerId string, workerV
 // Returns the current repo worker tags. No options are currently supported.
 func (r *Repository) SetWorkerTags(ctx context.Context, workerId string, workerVersion uint32, tags []*Tag, _ ...Option) ([]*Tag, error) {
 	const op = "server.(Repository).SetWorkerTags"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case workerId == "":
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "worker public id is empty")
@@ -735,6 +745,7 @@ func (r *Repository) SetWorkerTags(ctx context.Context, workerId string, workerV
 // No options are currently supported.
 func (r *Repository) DeleteWorkerTags(ctx context.Context, workerId string, workerVersion uint32, tags []*Tag, _ ...Option) (int, error) {
 	const op = "server.(Repository).DeleteWorkerTags"
+	ctx = db.NewOpContext(ctx, op)
 	switch {
 	case workerId == "":
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "worker public id is empty")
@@ -19,6 +19,8 @@ const (
 	ConnectionCanceled     ClosedReason = "canceled"
 	ConnectionNetworkError ClosedReason = "network error"
 	ConnectionSystemError  ClosedReason = "system error"
+	ConnectionByteQuota    ClosedReason = "byte quota exceeded"
+	ConnectionIdleTimeout  ClosedReason = "idle timeout"
 )
 
 // String representation of the termination reason
@@ -41,6 +43,10 @@ func convertToClosedReason(s string) (ClosedReason, error) {
 		return ConnectionNetworkError, nil
 	case ConnectionSystemError.String():
 		return ConnectionSystemError, nil
+	case ConnectionByteQuota.String():
+		return ConnectionByteQuota, nil
+	case ConnectionIdleTimeout.String():
+		return ConnectionIdleTimeout, nil
 	default:
 		return "", errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("%s is not a valid reason", s))
 	}
@@ -171,6 +171,15 @@ where
       case 
         -- timed out sessions
         when now() > us.expiration_time then 'timed out'
+        -- sessions that exhausted their byte quota, which are canceled
+        -- once the quota is exceeded
+        when us.max_bytes > 0 and
+          (
+            select coalesce(sum(coalesce(sc.bytes_up, 0) + coalesce(sc.bytes_down, 0)), 0)
+              from session_connection sc
+            where
+              sc.session_id = us.public_id
+          ) >= us.max_bytes then 'byte quota exceeded'
         -- canceling sessions
         when us.public_id in(
           select 
@@ -211,6 +220,16 @@ where
             canceling_session cs
           where 
             us.public_id = cs.session_id 
+        ) or
+        -- byte quota exceeded sessions
+        (
+          us.max_bytes > 0 and
+          (
+            select coalesce(sum(coalesce(sc.bytes_up, 0) + coalesce(sc.bytes_down, 0)), 0)
+              from session_connection sc
+            where
+              sc.session_id = us.public_id
+          ) >= us.max_bytes
         )
       ) and 
       -- make sure there are no existing connections
@@ -236,6 +255,7 @@ where
 	//	* sessions that are expired and all their connections are closed.
 	// 	* sessions that are canceling and all their connections are closed
 	//  * sessions that have exhausted their connection limit and all their connections are closed.
+	//  * sessions that have exhausted their byte quota and all their connections are closed.
 	termSessionsUpdate = `
 with canceling_session(session_id) as
 (
@@ -252,6 +272,15 @@ update session us
 	case
 		-- timed out sessions
 		when now() > us.expiration_time then 'timed out'
+		-- sessions that exhausted their byte quota, which are canceled once
+		-- the quota is exceeded
+		when us.max_bytes > 0 and
+			(
+			select coalesce(sum(coalesce(sc.bytes_up, 0) + coalesce(sc.bytes_down, 0)), 0)
+				from session_connection sc
+			where
+				sc.session_id = us.public_id
+			) >= us.max_bytes then 'byte quota exceeded'
 		-- canceling sessions
 		when us.public_id in(
 			select
@@ -289,6 +318,16 @@ where
 				canceling_session cs
 			where
 				us.public_id = cs.session_id
+		) or
+		-- byte quota exceeded sessions
+		(
+			us.max_bytes > 0 and
+			(
+			select coalesce(sum(coalesce(sc.bytes_up, 0) + coalesce(sc.bytes_down, 0)), 0)
+				from session_connection sc
+			where
+				sc.session_id = us.public_id
+			) >= us.max_bytes
 		)
 	) and
 	-- make sure there are no existing connections
@@ -393,6 +432,31 @@ update session_connection
 	public_id in (select public_id from connections_to_close)
 returning public_id;
 `
+	// overByteQuota selects the pending and active sessions whose connections
+	// have proxied at least the session's max bytes, as last reported by the
+	// workers proxying them.
+	overByteQuota = `
+select
+	s.public_id,
+	s.version
+from
+	session s
+	join session_state ss on
+		ss.session_id = s.public_id and
+		ss.end_time is null
+where
+	s.max_bytes > 0 and
+	ss.state in ('pending', 'active') and
+	(
+		select coalesce(sum(coalesce(sc.bytes_up, 0) + coalesce(sc.bytes_down, 0)), 0)
+			from session_connection sc
+		where
+			sc.session_id = s.public_id
+	) >= s.max_bytes
+	%s
+;
+`
+
 	checkIfNotActive = `
 select session_id, state
 	from session_state ss
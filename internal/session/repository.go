@@ -105,6 +105,11 @@ func (r *Repository) listPermissionWhereClauses() ([]string, []any) {
 			args = append(args, sql.Named(fmt.Sprintf("public_id_%d", inClauseCnt), "{"+strings.Join(p.ResourceIds, ",")+"}"))
 		}
 
+		if len(p.ExcludedResourceIds) > 0 {
+			clauses = append(clauses, fmt.Sprintf("public_id != all(@excluded_public_id_%d)", inClauseCnt))
+			args = append(args, sql.Named(fmt.Sprintf("excluded_public_id_%d", inClauseCnt), "{"+strings.Join(p.ExcludedResourceIds, ",")+"}"))
+		}
+
 		if p.OnlySelf {
 			inClauseCnt++
 			clauses = append(clauses, fmt.Sprintf("user_id = @user_id_%d", inClauseCnt))
@@ -64,6 +64,7 @@ func NewConnectionRepository(ctx context.Context, r db.Reader, w db.Writer, kms
 // repo defaultLimit.  Supports WithOrder option.
 func (r *ConnectionRepository) list(ctx context.Context, resources any, where string, args []any, opt ...Option) error {
 	const op = "session.(ConnectionRepository).list"
+	ctx = db.NewOpContext(ctx, op)
 	opts := getOpts(opt...)
 	limit := r.defaultLimit
 	var dbOpts []db.Option
@@ -86,6 +87,7 @@ func (r *ConnectionRepository) list(ctx context.Context, resources any, where st
 
 func (r *ConnectionRepository) updateBytesUpBytesDown(ctx context.Context, conns ...*Connection) error {
 	const op = "session.(ConnectionRepository).updateBytesUpBytesDown"
+	ctx = db.NewOpContext(ctx, op)
 	if len(conns) == 0 {
 		return nil
 	}
@@ -136,6 +138,7 @@ func (r *ConnectionRepository) updateBytesUpBytesDown(ctx context.Context, conns
 // an error with Code InvalidSessionState.
 func (r *ConnectionRepository) AuthorizeConnection(ctx context.Context, sessionId, workerId string) (*Connection, []*ConnectionState, error) {
 	const op = "session.(ConnectionRepository).AuthorizeConnection"
+	ctx = db.NewOpContext(ctx, op)
 	if sessionId == "" {
 		return nil, nil, errors.Wrap(ctx, status.Error(codes.FailedPrecondition, "missing session id"), op, errors.WithCode(errors.InvalidParameter))
 	}
@@ -185,6 +188,7 @@ func (r *ConnectionRepository) AuthorizeConnection(ctx context.Context, sessionI
 // No options are currently supported.
 func (r *ConnectionRepository) LookupConnection(ctx context.Context, connectionId string, _ ...Option) (*Connection, []*ConnectionState, error) {
 	const op = "session.(ConnectionRepository).LookupConnection"
+	ctx = db.NewOpContext(ctx, op)
 	if connectionId == "" {
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing connectionId id")
 	}
@@ -233,6 +237,7 @@ func (r *ConnectionRepository) ListConnectionsBySessionId(ctx context.Context, s
 // ConnectConnection updates a connection in the repo with a state of "connected".
 func (r *ConnectionRepository) ConnectConnection(ctx context.Context, c ConnectWith) (*Connection, []*ConnectionState, error) {
 	const op = "session.(ConnectionRepository).ConnectConnection"
+	ctx = db.NewOpContext(ctx, op)
 	// ConnectWith.validate will check all the fields...
 	if err := c.validate(); err != nil {
 		return nil, nil, errors.Wrap(ctx, err, op)
@@ -298,6 +303,7 @@ type closeConnectionResp struct {
 // endpoint
 func (r *ConnectionRepository) closeConnections(ctx context.Context, closeWith []CloseWith, _ ...Option) ([]closeConnectionResp, error) {
 	const op = "session.(ConnectionRepository).closeConnections"
+	ctx = db.NewOpContext(ctx, op)
 	if len(closeWith) == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing connections")
 	}
@@ -354,6 +360,7 @@ func (r *ConnectionRepository) closeConnections(ctx context.Context, closeWith [
 // DeleteConnection will delete a connection from the repository.
 func (r *ConnectionRepository) DeleteConnection(ctx context.Context, publicId string, _ ...Option) (int, error) {
 	const op = "session.(ConnectionRepository).DeleteConnection"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
 	}
@@ -394,6 +401,7 @@ func (r *ConnectionRepository) DeleteConnection(ctx context.Context, publicId st
 // closeOrphanedConnections looks for connections that are still active, but where not reported by the worker.
 func (r *ConnectionRepository) closeOrphanedConnections(ctx context.Context, workerId string, reportedConnections []string) ([]string, error) {
 	const op = "session.(ConnectionRepository).closeOrphanedConnections"
+	ctx = db.NewOpContext(ctx, op)
 
 	var orphanedConns []string
 
@@ -17,6 +17,7 @@ import (
 // All options are ignored.
 func (r *Repository) AddSessionCredentials(ctx context.Context, sessProjectId, sessionId string, credData []Credential, _ ...Option) error {
 	const op = "session.(Repository).AddSessionCredentials"
+	ctx = db.NewOpContext(ctx, op)
 	if sessionId == "" {
 		return errors.New(ctx, errors.InvalidParameter, op, "missing session id")
 	}
@@ -64,6 +65,7 @@ func (r *Repository) AddSessionCredentials(ctx context.Context, sessProjectId, s
 // All options are ignored.
 func (r *Repository) ListSessionCredentials(ctx context.Context, sessProjectId, sessionId string, _ ...Option) ([]Credential, error) {
 	const op = "session.(Repository).ListSessionCredentials"
+	ctx = db.NewOpContext(ctx, op)
 	if sessionId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
 	}
@@ -14,8 +14,10 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/util"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 )
@@ -26,6 +28,7 @@ import (
 // currently supported.
 func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.Wrapper, newSession *Session, workerAddresses []string, _ ...Option) (*Session, error) {
 	const op = "session.(Repository).CreateSession"
+	ctx = db.NewOpContext(ctx, op)
 	if newSession == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session")
 	}
@@ -85,6 +88,9 @@ func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(read db.Reader, w db.Writer) error {
+			if err := iam.CheckQuota(ctx, read, newSession.ProjectId, resource.Session); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 			returnedSession = newSession.Clone().(*Session)
 			returnedSession.DynamicCredentials = nil
 			returnedSession.StaticCredentials = nil
@@ -182,6 +188,7 @@ func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.
 //   - WithIgnoreDecryptionFailures
 func (r *Repository) LookupSession(ctx context.Context, sessionId string, opt ...Option) (*Session, *AuthzSummary, error) {
 	const op = "session.(Repository).LookupSession"
+	ctx = db.NewOpContext(ctx, op)
 	if sessionId == "" {
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
 	}
@@ -262,6 +269,7 @@ func (r *Repository) LookupSession(ctx context.Context, sessionId string, opt ..
 // WithOrderByCreateTime options.
 func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Session, error) {
 	const op = "session.(Repository).ListSessions"
+	ctx = db.NewOpContext(ctx, op)
 	opts := getOpts(opt...)
 
 	where, args := r.listPermissionWhereClauses()
@@ -303,7 +311,7 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 	q := sessionList
 	query := fmt.Sprintf(q, whereClause, withOrder, limit, withOrder)
 
-	rows, err := r.reader.Query(ctx, query, args)
+	rows, err := r.reader.Query(ctx, query, args, db.WithReplicaRead(true))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -327,6 +335,7 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 // DeleteSession will delete a session from the repository.
 func (r *Repository) DeleteSession(ctx context.Context, publicId string, _ ...Option) (int, error) {
 	const op = "session.(Repository).DeleteSession"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
 	}
@@ -389,6 +398,7 @@ func (r *Repository) CancelSession(ctx context.Context, sessionId string, sessio
 
 // TerminateCompletedSessions will terminate sessions in the repo based on:
 //   - sessions that have exhausted their connection limit and all their connections are closed.
+//   - sessions that have exhausted their byte quota and all their connections are closed.
 //   - sessions that are expired and all their connections are closed.
 //   - sessions that are canceling and all their connections are closed
 //
@@ -396,6 +406,7 @@ func (r *Repository) CancelSession(ctx context.Context, sessionId string, sessio
 // "ticker" pattern.
 func (r *Repository) TerminateCompletedSessions(ctx context.Context) (int, error) {
 	const op = "session.(Repository).TerminateCompletedSessions"
+	ctx = db.NewOpContext(ctx, op)
 	var rowsAffected int
 	_, err := r.writer.DoTx(
 		ctx,
@@ -419,10 +430,12 @@ func (r *Repository) TerminateCompletedSessions(ctx context.Context) (int, error
 // terminateSessionIfPossible is called on connection close and will attempt to close the connection's
 // session if the following conditions are met:
 //   - sessions that have exhausted their connection limit and all their connections are closed.
+//   - sessions that have exhausted their byte quota and all their connections are closed.
 //   - sessions that are expired and all their connections are closed.
 //   - sessions that are canceling and all their connections are closed
 func (r *Repository) terminateSessionIfPossible(ctx context.Context, sessionId string) (int, error) {
 	const op = "session.(Repository).terminateSessionIfPossible"
+	ctx = db.NewOpContext(ctx, op)
 	rowsAffected := 0
 
 	_, err := r.writer.DoTx(
@@ -455,6 +468,7 @@ type AuthzSummary struct {
 
 func (r *Repository) sessionAuthzSummary(ctx context.Context, sessionId string) (*AuthzSummary, error) {
 	const op = "session.(Repository).sessionAuthzSummary"
+	ctx = db.NewOpContext(ctx, op)
 	rows, err := r.reader.Query(ctx, remainingConnectionsCte, []any{sql.Named("session_id", sessionId)})
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
@@ -510,6 +524,7 @@ func (r *Repository) fetchActivatedSessionStatesTx(ctx context.Context, reader d
 // It validates the tofu token matches and returns the session
 func (r *Repository) getActivatedSession(ctx context.Context, sessionId string, tofuToken []byte) (*Session, []*State, error) {
 	const op = "session.(Repository).getActivatedSession"
+	ctx = db.NewOpContext(ctx, op)
 
 	activatedSession := AllocSession()
 	activatedSession.PublicId = sessionId
@@ -545,6 +560,7 @@ func (r *Repository) getActivatedSession(ctx context.Context, sessionId string,
 // already active session if the tofu token is correct
 func (r *Repository) ActivateSession(ctx context.Context, sessionId string, sessionVersion uint32, tofuToken []byte) (*Session, []*State, error) {
 	const op = "session.(Repository).ActivateSession"
+	ctx = db.NewOpContext(ctx, op)
 	if sessionId == "" {
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
 	}
@@ -622,6 +638,7 @@ func (r *Repository) ActivateSession(ctx context.Context, sessionId string, sess
 //   - WithIgnoreDecryptionFailures
 func (r *Repository) updateState(ctx context.Context, sessionId string, sessionVersion uint32, s Status, opt ...Option) (*Session, []*State, error) {
 	const op = "session.(Repository).updateState"
+	ctx = db.NewOpContext(ctx, op)
 	if sessionId == "" {
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
 	}
@@ -696,6 +713,7 @@ func (r *Repository) updateState(ctx context.Context, sessionId string, sessionV
 // object for each session that is not active, with its current status.
 func (r *Repository) checkIfNoLongerActive(ctx context.Context, reportedSessions []string) ([]*StateReport, error) {
 	const op = "session.(Repository).checkIfNotActive"
+	ctx = db.NewOpContext(ctx, op)
 
 	notActive := make([]*StateReport, 0, len(reportedSessions))
 	args := make([]any, 0, len(reportedSessions))
@@ -744,8 +762,60 @@ func (r *Repository) checkIfNoLongerActive(ctx context.Context, reportedSessions
 	return notActive, nil
 }
 
+// cancelSessionsOverByteQuota cancels the given sessions that are pending or
+// active and whose connections have proxied at least the session's max bytes,
+// across all of the workers proxying them. The sessions are terminated with the
+// ByteQuotaExceeded reason once their connections are closed. It returns the
+// ids of the sessions it canceled.
+func (r *Repository) cancelSessionsOverByteQuota(ctx context.Context, sessionIds []string) ([]string, error) {
+	const op = "session.(Repository).cancelSessionsOverByteQuota"
+	ctx = db.NewOpContext(ctx, op)
+	if len(sessionIds) == 0 {
+		return nil, nil
+	}
+
+	params := make([]string, len(sessionIds))
+	args := make([]any, 0, len(sessionIds))
+	for i, sessId := range sessionIds {
+		params[i] = fmt.Sprintf("@%d", i)
+		args = append(args, sql.Named(fmt.Sprintf("%d", i), sessId))
+	}
+	inClause := fmt.Sprintf(`and s.public_id in (%s)`, strings.Join(params, ","))
+
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(overByteQuota, inClause), args)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	type overQuota struct {
+		sessionId string
+		version   uint32
+	}
+	var over []overQuota
+	for rows.Next() {
+		var o overQuota
+		if err := rows.Scan(&o.sessionId, &o.version); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		over = append(over, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	canceled := make([]string, 0, len(over))
+	for _, o := range over {
+		if _, err := r.CancelSession(ctx, o.sessionId, o.version); err != nil {
+			return canceled, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to cancel session %s", o.sessionId)))
+		}
+		canceled = append(canceled, o.sessionId)
+	}
+	return canceled, nil
+}
+
 func (r *Repository) deleteSessionsTerminatedBefore(ctx context.Context, threshold time.Duration) (int, error) {
 	const op = "session.(Repository).deleteTerminated"
+	ctx = db.NewOpContext(ctx, op)
 
 	args := []any{
 		sql.Named("threshold_seconds", threshold.Seconds()),
@@ -22,10 +22,13 @@ type StateReport struct {
 // WorkerStatusReport is a domain service function that, given a Worker's
 // session state reports, performs a few tasks:
 //  1. Updates the bytes up and down statistics for each reported connection.
-//  2. Compares the state of sessions and connections as reported by a Worker,
+//  2. Cancels the reported sessions whose connections, across all workers,
+//     have proxied at least the session's byte quota. They are terminated
+//     with the ByteQuotaExceeded reason once their connections are closed.
+//  3. Compares the state of sessions and connections as reported by a Worker,
 //     to the known state in the repositories. It returns a StateReport object
 //     for each session that is in the canceling or terminated state.
-//  3. Checks for any orphaned connections, which is defined as a connection
+//  4. Checks for any orphaned connections, which is defined as a connection
 //     that is in an active state, but was not reported by worker. Any orphaned
 //     connections will be marked as closed.
 func WorkerStatusReport(ctx context.Context, repo *Repository, connRepo *ConnectionRepository, workerId string, report []*StateReport) ([]*StateReport, error) {
@@ -48,6 +51,14 @@ func WorkerStatusReport(ctx context.Context, repo *Repository, connRepo *Connect
 		merr = multierror.Append(merr, errors.New(ctx, errors.Internal, op, fmt.Sprintf("failed to update bytes up and down for worker reported connections: %v", err)))
 	}
 
+	overQuota, err := repo.cancelSessionsOverByteQuota(ctx, reportedSessions)
+	if err != nil {
+		merr = multierror.Append(merr, errors.New(ctx, errors.Internal, op, fmt.Sprintf("Error canceling sessions over their byte quota for worker %s: %v", workerId, err)))
+	}
+	for _, id := range overQuota {
+		event.WriteSysEvent(ctx, op, "canceled session over its byte quota", "session_id", id, "termination_reason", ByteQuotaExceeded.String())
+	}
+
 	notActive, err := repo.checkIfNoLongerActive(ctx, reportedSessions)
 	if err != nil {
 		merr = multierror.Append(merr, errors.New(ctx, errors.Internal, op, fmt.Sprintf("Error checking session state for worker %s: %v", workerId, err)))
@@ -405,3 +405,88 @@ func TestWorkerStatusReport(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkerStatusReport_ByteQuota(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	org, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	serverRepo, _ := server.NewRepository(rw, rw, kms)
+	_, err := serverRepo.UpsertController(ctx, &store.Controller{
+		PrivateId: "test_controller1",
+		Address:   "127.0.0.1",
+	})
+	require.NoError(t, err)
+
+	repo, err := session.NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+	connRepo, err := session.NewConnectionRepository(ctx, rw, rw, kms, session.WithWorkerStateDelay(0))
+	require.NoError(t, err)
+
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	hc := static.TestCatalogs(t, conn, prj.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+	static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	tar := tcp.TestTarget(
+		ctx,
+		t, conn, prj.GetPublicId(), "test",
+		target.WithHostSources([]string{hs.GetPublicId()}),
+		target.WithSessionConnectionLimit(-1),
+	)
+
+	sess := session.TestSession(t, conn, wrapper, session.ComposedOf{
+		UserId:          at.GetIamUserId(),
+		HostId:          h.GetPublicId(),
+		TargetId:        tar.GetPublicId(),
+		HostSetId:       hs.GetPublicId(),
+		AuthTokenId:     at.GetPublicId(),
+		ProjectId:       prj.GetPublicId(),
+		Endpoint:        "tcp://127.0.0.1:22",
+		ConnectionLimit: -1,
+		MaxBytes:        100,
+	})
+	sess, _, err = repo.ActivateSession(ctx, sess.PublicId, sess.Version, session.TestTofu(t))
+	require.NoError(t, err)
+
+	// Each worker proxies less than the quota, but together they exceed it.
+	worker1 := server.TestKmsWorker(t, conn, wrapper)
+	worker2 := server.TestKmsWorker(t, conn, wrapper)
+	conn1, _, err := connRepo.AuthorizeConnection(ctx, sess.PublicId, worker1.PublicId)
+	require.NoError(t, err)
+	conn2, _, err := connRepo.AuthorizeConnection(ctx, sess.PublicId, worker2.PublicId)
+	require.NoError(t, err)
+
+	got, err := session.WorkerStatusReport(ctx, repo, connRepo, worker1.PublicId, []*session.StateReport{
+		{
+			SessionId:   sess.PublicId,
+			Status:      session.StatusActive,
+			Connections: []*session.Connection{{PublicId: conn1.PublicId, BytesUp: 30, BytesDown: 30}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = session.WorkerStatusReport(ctx, repo, connRepo, worker2.PublicId, []*session.StateReport{
+		{
+			SessionId:   sess.PublicId,
+			Status:      session.StatusActive,
+			Connections: []*session.Connection{{PublicId: conn2.PublicId, BytesUp: 20, BytesDown: 20}},
+		},
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*session.StateReport{{SessionId: sess.PublicId, Status: session.StatusCanceling}}, got)
+
+	_, err = session.CloseConnections(ctx, repo, connRepo, []session.CloseWith{
+		{ConnectionId: conn1.PublicId, BytesUp: 30, BytesDown: 30, ClosedReason: session.ConnectionCanceled},
+		{ConnectionId: conn2.PublicId, BytesUp: 20, BytesDown: 20, ClosedReason: session.ConnectionCanceled},
+	})
+	require.NoError(t, err)
+
+	s, _, err := repo.LookupSession(ctx, sess.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, session.ByteQuotaExceeded.String(), s.TerminationReason)
+}
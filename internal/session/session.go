@@ -50,6 +50,17 @@ type ComposedOf struct {
 	ExpirationTime *timestamp.Timestamp
 	// Max connections for the session
 	ConnectionLimit int32
+	// Max bytes that may be proxied across all of the session's connections
+	MaxBytes int64
+	// Max throughput of each of the session's connections
+	ConnectionMaxBytesPerSecond int64
+	// Number of seconds a connection may be idle before it is closed
+	ConnectionIdleTimeoutSeconds uint32
+	// Whether the worker establishes TLS with the endpoint, and the optional CA
+	// certificate and server name used to verify the endpoint's certificate
+	EndpointTlsEnabled    bool
+	EndpointTlsCaCert     string
+	EndpointTlsServerName string
 	// Ingress and egress worker filters. Active filters when the session was created, used to
 	// validate the session via the same set of rules at consumption time as
 	// existed at creation time. Round tripping it through here saves a lookup
@@ -104,6 +115,18 @@ type Session struct {
 	Endpoint string `json:"-" gorm:"default:null"`
 	// Maximum number of connections in a session
 	ConnectionLimit int32 `json:"connection_limit,omitempty" gorm:"default:null"`
+	// Maximum number of bytes proxied across all connections in a session
+	MaxBytes int64 `json:"max_bytes,omitempty" gorm:"default:null"`
+	// Maximum throughput of each connection in a session in bytes per second
+	ConnectionMaxBytesPerSecond int64 `json:"connection_max_bytes_per_second,omitempty" gorm:"default:null"`
+	// Number of seconds a connection in a session may be idle before it is closed
+	ConnectionIdleTimeoutSeconds uint32 `json:"connection_idle_timeout_seconds,omitempty" gorm:"default:null"`
+	// Whether the worker establishes TLS with the session's endpoint
+	EndpointTlsEnabled bool `json:"endpoint_tls_enabled,omitempty" gorm:"default:null"`
+	// PEM encoded CA certificate used to verify the endpoint's certificate
+	EndpointTlsCaCert string `json:"endpoint_tls_ca_cert,omitempty" gorm:"default:null"`
+	// Server name used to verify the endpoint's certificate
+	EndpointTlsServerName string `json:"endpoint_tls_server_name,omitempty" gorm:"default:null"`
 
 	// Worker filters
 	WorkerFilter        string `json:"-" gorm:"default:null"`
@@ -157,20 +180,26 @@ var (
 func New(c ComposedOf, _ ...Option) (*Session, error) {
 	const op = "session.New"
 	s := Session{
-		UserId:              c.UserId,
-		HostId:              c.HostId,
-		TargetId:            c.TargetId,
-		HostSetId:           c.HostSetId,
-		AuthTokenId:         c.AuthTokenId,
-		ProjectId:           c.ProjectId,
-		Endpoint:            c.Endpoint,
-		ExpirationTime:      c.ExpirationTime,
-		ConnectionLimit:     c.ConnectionLimit,
-		WorkerFilter:        c.WorkerFilter,
-		EgressWorkerFilter:  c.EgressWorkerFilter,
-		IngressWorkerFilter: c.IngressWorkerFilter,
-		DynamicCredentials:  c.DynamicCredentials,
-		StaticCredentials:   c.StaticCredentials,
+		UserId:                       c.UserId,
+		HostId:                       c.HostId,
+		TargetId:                     c.TargetId,
+		HostSetId:                    c.HostSetId,
+		AuthTokenId:                  c.AuthTokenId,
+		ProjectId:                    c.ProjectId,
+		Endpoint:                     c.Endpoint,
+		ExpirationTime:               c.ExpirationTime,
+		ConnectionLimit:              c.ConnectionLimit,
+		MaxBytes:                     c.MaxBytes,
+		ConnectionMaxBytesPerSecond:  c.ConnectionMaxBytesPerSecond,
+		ConnectionIdleTimeoutSeconds: c.ConnectionIdleTimeoutSeconds,
+		EndpointTlsEnabled:           c.EndpointTlsEnabled,
+		EndpointTlsCaCert:            c.EndpointTlsCaCert,
+		EndpointTlsServerName:        c.EndpointTlsServerName,
+		WorkerFilter:                 c.WorkerFilter,
+		EgressWorkerFilter:           c.EgressWorkerFilter,
+		IngressWorkerFilter:          c.IngressWorkerFilter,
+		DynamicCredentials:           c.DynamicCredentials,
+		StaticCredentials:            c.StaticCredentials,
 	}
 	if err := s.validateNewSession(); err != nil {
 		return nil, errors.WrapDeprecated(err, op)
@@ -186,21 +215,27 @@ func AllocSession() Session {
 // Clone creates a clone of the Session
 func (s *Session) Clone() any {
 	clone := &Session{
-		PublicId:            s.PublicId,
-		UserId:              s.UserId,
-		HostId:              s.HostId,
-		TargetId:            s.TargetId,
-		HostSetId:           s.HostSetId,
-		AuthTokenId:         s.AuthTokenId,
-		ProjectId:           s.ProjectId,
-		TerminationReason:   s.TerminationReason,
-		Version:             s.Version,
-		Endpoint:            s.Endpoint,
-		ConnectionLimit:     s.ConnectionLimit,
-		WorkerFilter:        s.WorkerFilter,
-		EgressWorkerFilter:  s.EgressWorkerFilter,
-		IngressWorkerFilter: s.IngressWorkerFilter,
-		KeyId:               s.KeyId,
+		PublicId:                     s.PublicId,
+		UserId:                       s.UserId,
+		HostId:                       s.HostId,
+		TargetId:                     s.TargetId,
+		HostSetId:                    s.HostSetId,
+		AuthTokenId:                  s.AuthTokenId,
+		ProjectId:                    s.ProjectId,
+		TerminationReason:            s.TerminationReason,
+		Version:                      s.Version,
+		Endpoint:                     s.Endpoint,
+		ConnectionLimit:              s.ConnectionLimit,
+		MaxBytes:                     s.MaxBytes,
+		ConnectionMaxBytesPerSecond:  s.ConnectionMaxBytesPerSecond,
+		ConnectionIdleTimeoutSeconds: s.ConnectionIdleTimeoutSeconds,
+		EndpointTlsEnabled:           s.EndpointTlsEnabled,
+		EndpointTlsCaCert:            s.EndpointTlsCaCert,
+		EndpointTlsServerName:        s.EndpointTlsServerName,
+		WorkerFilter:                 s.WorkerFilter,
+		EgressWorkerFilter:           s.EgressWorkerFilter,
+		IngressWorkerFilter:          s.IngressWorkerFilter,
+		KeyId:                        s.KeyId,
 	}
 	if len(s.States) > 0 {
 		clone.States = make([]*State, 0, len(s.States))
@@ -312,6 +347,18 @@ func (s *Session) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType
 			return errors.New(ctx, errors.InvalidParameter, op, "expiration time is immutable")
 		case contains(opts.WithFieldMaskPaths, "ConnectionLimit"):
 			return errors.New(ctx, errors.InvalidParameter, op, "connection limit is immutable")
+		case contains(opts.WithFieldMaskPaths, "MaxBytes"):
+			return errors.New(ctx, errors.InvalidParameter, op, "max bytes is immutable")
+		case contains(opts.WithFieldMaskPaths, "ConnectionMaxBytesPerSecond"):
+			return errors.New(ctx, errors.InvalidParameter, op, "connection max bytes per second is immutable")
+		case contains(opts.WithFieldMaskPaths, "ConnectionIdleTimeoutSeconds"):
+			return errors.New(ctx, errors.InvalidParameter, op, "connection idle timeout seconds is immutable")
+		case contains(opts.WithFieldMaskPaths, "EndpointTlsEnabled"):
+			return errors.New(ctx, errors.InvalidParameter, op, "endpoint tls enabled is immutable")
+		case contains(opts.WithFieldMaskPaths, "EndpointTlsCaCert"):
+			return errors.New(ctx, errors.InvalidParameter, op, "endpoint tls ca cert is immutable")
+		case contains(opts.WithFieldMaskPaths, "EndpointTlsServerName"):
+			return errors.New(ctx, errors.InvalidParameter, op, "endpoint tls server name is immutable")
 		case contains(opts.WithFieldMaskPaths, "WorkerFilter"):
 			return errors.New(ctx, errors.InvalidParameter, op, "worker filter is immutable")
 		case contains(opts.WithFieldMaskPaths, "EgressWorkerFilter"):
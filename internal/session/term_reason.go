@@ -21,6 +21,7 @@ const (
 	SystemError        TerminationReason = "system error"
 	ConnectionLimit    TerminationReason = "connection limit"
 	SessionCanceled    TerminationReason = "canceled"
+	ByteQuotaExceeded  TerminationReason = "byte quota exceeded"
 )
 
 // String representation of the termination reason
@@ -45,6 +46,8 @@ func convertToReason(s string) (TerminationReason, error) {
 		return SystemError, nil
 	case ConnectionLimit.String():
 		return ConnectionLimit, nil
+	case ByteQuotaExceeded.String():
+		return ByteQuotaExceeded, nil
 	default:
 		return "", errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("%s is not a valid reason", s))
 	}
@@ -24,51 +24,63 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	WithName                   string
-	WithDescription            string
-	WithDefaultPort            uint32
-	WithLimit                  int
-	WithProjectId              string
-	WithProjectIds             []string
-	WithProjectName            string
-	WithUserId                 string
-	WithType                   subtypes.Subtype
-	WithHostSources            []string
-	WithCredentialLibraries    []*CredentialLibrary
-	WithStaticCredentials      []*StaticCredential
-	WithSessionMaxSeconds      uint32
-	WithSessionConnectionLimit int32
-	WithPermissions            []perms.Permission
-	WithPublicId               string
-	WithWorkerFilter           string
-	WithEgressWorkerFilter     string
-	WithIngressWorkerFilter    string
-	WithTargetIds              []string
-	WithAddress                string
+	WithName                         string
+	WithDescription                  string
+	WithDefaultPort                  uint32
+	WithLimit                        int
+	WithProjectId                    string
+	WithProjectIds                   []string
+	WithProjectName                  string
+	WithUserId                       string
+	WithType                         subtypes.Subtype
+	WithHostSources                  []string
+	WithCredentialLibraries          []*CredentialLibrary
+	WithStaticCredentials            []*StaticCredential
+	WithSessionMaxSeconds            uint32
+	WithSessionConnectionLimit       int32
+	WithSessionMaxBytes              int64
+	WithConnectionMaxBytesPerSecond  int64
+	WithConnectionIdleTimeoutSeconds uint32
+	WithEndpointTlsEnabled           bool
+	WithEndpointTlsCaCert            string
+	WithEndpointTlsServerName        string
+	WithPermissions                  []perms.Permission
+	WithPublicId                     string
+	WithWorkerFilter                 string
+	WithEgressWorkerFilter           string
+	WithIngressWorkerFilter          string
+	WithTargetIds                    []string
+	WithAddress                      string
 }
 
 func getDefaultOptions() options {
 	return options{
-		WithName:                   "",
-		WithDescription:            "",
-		WithLimit:                  0,
-		WithDefaultPort:            0,
-		WithProjectId:              "",
-		WithProjectIds:             nil,
-		WithProjectName:            "",
-		WithUserId:                 "",
-		WithType:                   "",
-		WithHostSources:            nil,
-		WithCredentialLibraries:    nil,
-		WithStaticCredentials:      nil,
-		WithSessionMaxSeconds:      uint32((8 * time.Hour).Seconds()),
-		WithSessionConnectionLimit: -1,
-		WithPermissions:            nil,
-		WithPublicId:               "",
-		WithWorkerFilter:           "",
-		WithEgressWorkerFilter:     "",
-		WithIngressWorkerFilter:    "",
-		WithAddress:                "",
+		WithName:                         "",
+		WithDescription:                  "",
+		WithLimit:                        0,
+		WithDefaultPort:                  0,
+		WithProjectId:                    "",
+		WithProjectIds:                   nil,
+		WithProjectName:                  "",
+		WithUserId:                       "",
+		WithType:                         "",
+		WithHostSources:                  nil,
+		WithCredentialLibraries:          nil,
+		WithStaticCredentials:            nil,
+		WithSessionMaxSeconds:            uint32((8 * time.Hour).Seconds()),
+		WithSessionConnectionLimit:       -1,
+		WithSessionMaxBytes:              0,
+		WithConnectionMaxBytesPerSecond:  0,
+		WithConnectionIdleTimeoutSeconds: 0,
+		WithEndpointTlsEnabled:           false,
+		WithEndpointTlsCaCert:            "",
+		WithEndpointTlsServerName:        "",
+		WithPermissions:                  nil,
+		WithPublicId:                     "",
+		WithWorkerFilter:                 "",
+		WithEgressWorkerFilter:           "",
+		WithIngressWorkerFilter:          "",
+		WithAddress:                      "",
 	}
 }
 
@@ -170,6 +182,54 @@ func WithSessionConnectionLimit(limit int32) Option {
 	}
 }
 
+// WithSessionMaxBytes provides an optional limit on the total number of bytes
+// proxied across all of a session's connections. Zero means no limit.
+func WithSessionMaxBytes(limit int64) Option {
+	return func(o *options) {
+		o.WithSessionMaxBytes = limit
+	}
+}
+
+// WithConnectionMaxBytesPerSecond provides an optional limit on the throughput
+// of each of a session's connections. Zero means no limit.
+func WithConnectionMaxBytesPerSecond(limit int64) Option {
+	return func(o *options) {
+		o.WithConnectionMaxBytesPerSecond = limit
+	}
+}
+
+// WithConnectionIdleTimeoutSeconds provides an optional number of seconds a
+// session connection may be idle before it is closed. Zero means no timeout.
+func WithConnectionIdleTimeoutSeconds(s uint32) Option {
+	return func(o *options) {
+		o.WithConnectionIdleTimeoutSeconds = s
+	}
+}
+
+// WithEndpointTlsEnabled provides an optional flag indicating whether the
+// worker should establish TLS with the target's endpoint.
+func WithEndpointTlsEnabled(enabled bool) Option {
+	return func(o *options) {
+		o.WithEndpointTlsEnabled = enabled
+	}
+}
+
+// WithEndpointTlsCaCert provides an optional PEM encoded CA certificate used to
+// verify the certificate presented by the target's endpoint.
+func WithEndpointTlsCaCert(caCert string) Option {
+	return func(o *options) {
+		o.WithEndpointTlsCaCert = caCert
+	}
+}
+
+// WithEndpointTlsServerName provides an optional server name used to verify
+// the certificate presented by the target's endpoint.
+func WithEndpointTlsServerName(name string) Option {
+	return func(o *options) {
+		o.WithEndpointTlsServerName = name
+	}
+}
+
 // WithPublicId provides an optional public id
 func WithPublicId(id string) Option {
 	return func(o *options) {
@@ -126,6 +126,48 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.WithIngressWorkerFilter = `"/foo" == "bar"`
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithSessionMaxBytes", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithSessionMaxBytes(1024))
+		testOpts := getDefaultOptions()
+		testOpts.WithSessionMaxBytes = 1024
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithConnectionMaxBytesPerSecond", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithConnectionMaxBytesPerSecond(512))
+		testOpts := getDefaultOptions()
+		testOpts.WithConnectionMaxBytesPerSecond = 512
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithConnectionIdleTimeoutSeconds", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithConnectionIdleTimeoutSeconds(300))
+		testOpts := getDefaultOptions()
+		testOpts.WithConnectionIdleTimeoutSeconds = 300
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithEndpointTlsEnabled", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithEndpointTlsEnabled(true))
+		testOpts := getDefaultOptions()
+		testOpts.WithEndpointTlsEnabled = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithEndpointTlsCaCert", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithEndpointTlsCaCert("ca-cert"))
+		testOpts := getDefaultOptions()
+		testOpts.WithEndpointTlsCaCert = "ca-cert"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithEndpointTlsServerName", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithEndpointTlsServerName("db.example.com"))
+		testOpts := getDefaultOptions()
+		testOpts.WithEndpointTlsServerName = "db.example.com"
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithPermissions", func(t *testing.T) {
 		assert := assert.New(t)
 		opts := GetOpts(WithPermissions([]perms.Permission{{ScopeId: "test1"}, {ScopeId: "test2"}}))
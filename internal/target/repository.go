@@ -7,11 +7,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/boundary/internal/boundary"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/hashicorp/boundary/internal/perms"
@@ -89,6 +91,7 @@ func NewRepository(ctx context.Context, r db.Reader, w db.Writer, kms *kms.Kms,
 // found, it will return nil, nil, nil, nil. No options are currently supported.
 func (r *Repository) LookupTarget(ctx context.Context, publicIdOrName string, opt ...Option) (Target, error) {
 	const op = "target.(Repository).LookupTarget"
+	ctx = db.NewOpContext(ctx, op)
 	opts := GetOpts(opt...)
 
 	if publicIdOrName == "" {
@@ -183,6 +186,7 @@ func (r *Repository) LookupTarget(ctx context.Context, publicIdOrName string, op
 // FetchAuthzProtectedEntitiesByScope implements boundary.AuthzProtectedEntityProvider
 func (r *Repository) FetchAuthzProtectedEntitiesByScope(ctx context.Context, projectIds []string) (map[string][]boundary.AuthzProtectedEntity, error) {
 	const op = "target.(Repository).FetchAuthzProtectedEntitiesByScope"
+	ctx = db.NewOpContext(ctx, op)
 
 	var where string
 	var args []any
@@ -233,6 +237,7 @@ func (r *Repository) FetchAuthzProtectedEntitiesByScope(ctx context.Context, pro
 // Supports WithLimit which overrides the limit set in the Repository object.
 func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target, error) {
 	const op = "target.(Repository).ListTargets"
+	ctx = db.NewOpContext(ctx, op)
 
 	if len(r.permissions) == 0 {
 		return []Target{}, nil
@@ -249,9 +254,12 @@ func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target,
 		limit = opts.WithLimit
 	}
 
+	// The targets and their addresses are read from the same database, so an
+	// address isn't missed by reading it from a replica further behind.
+	reader := db.NewReplicaReader(r.reader)
 	var foundTargets []*targetView
-	err := r.reader.SearchWhere(ctx, &foundTargets, strings.Join(where, " or "), args,
-		db.WithLimit(limit))
+	err := reader.SearchWhere(ctx, &foundTargets, strings.Join(where, " or "), args,
+		db.WithLimit(limit), db.WithReplicaRead(true))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -263,7 +271,7 @@ func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target,
 
 	addresses := map[string]string{}
 	var foundAddresses []*Address
-	err = r.reader.SearchWhere(ctx, &foundAddresses, "target_id in (?)", []any{targetIds})
+	err = reader.SearchWhere(ctx, &foundAddresses, "target_id in (?)", []any{targetIds}, db.WithReplicaRead(true))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -308,10 +316,39 @@ func (r *Repository) listPermissionWhereClauses() ([]string, []any) {
 		clauses = append(clauses, fmt.Sprintf("project_id = @project_id_%d", inClauseCnt))
 		args = append(args, sql.Named(fmt.Sprintf("project_id_%d", inClauseCnt), p.ScopeId))
 
+		var matchClauses []string
 		if len(p.ResourceIds) > 0 {
-			clauses = append(clauses, fmt.Sprintf("public_id = any(@public_id_%d)", inClauseCnt))
+			matchClauses = append(matchClauses, fmt.Sprintf("public_id = any(@public_id_%d)", inClauseCnt))
 			args = append(args, sql.Named(fmt.Sprintf("public_id_%d", inClauseCnt), "{"+strings.Join(p.ResourceIds, ",")+"}"))
 		}
+		// A wildcard already matches every target that attributes would
+		if !p.All {
+			for i, attrs := range p.Attributes {
+				attrClauses, attrArgs := attributeWhereClauses(attrs, fmt.Sprintf("%d_%d", inClauseCnt, i+1))
+				matchClauses = append(matchClauses, fmt.Sprintf("(%s)", strings.Join(attrClauses, " and ")))
+				args = append(args, attrArgs...)
+			}
+		}
+		switch len(matchClauses) {
+		case 0:
+		case 1:
+			clauses = append(clauses, matchClauses[0])
+		default:
+			clauses = append(clauses, fmt.Sprintf("(%s)", strings.Join(matchClauses, " or ")))
+		}
+
+		if len(p.ExcludedResourceIds) > 0 {
+			clauses = append(clauses, fmt.Sprintf("public_id != all(@excluded_public_id_%d)", inClauseCnt))
+			args = append(args, sql.Named(fmt.Sprintf("excluded_public_id_%d", inClauseCnt), "{"+strings.Join(p.ExcludedResourceIds, ",")+"}"))
+		}
+		// Exclusions by name and tags apply whether the targets were granted
+		// by id, wildcard or attributes. A target without a name doesn't
+		// match a name pattern, so a null comparison must not exclude it.
+		for i, attrs := range p.ExcludedAttributes {
+			attrClauses, attrArgs := attributeWhereClauses(attrs, fmt.Sprintf("%d_excluded_%d", inClauseCnt, i+1))
+			clauses = append(clauses, fmt.Sprintf("(%s) is not true", strings.Join(attrClauses, " and ")))
+			args = append(args, attrArgs...)
+		}
 
 		where = append(where, fmt.Sprintf("(%s)", strings.Join(clauses, " and ")))
 	}
@@ -319,9 +356,48 @@ func (r *Repository) listPermissionWhereClauses() ([]string, []any) {
 	return where, args
 }
 
+// attributeWhereClauses returns the clauses matching targets by the name
+// pattern and tags of a grant. The suffix keeps argument names unique across
+// permissions.
+func attributeWhereClauses(attrs perms.ResourceAttributes, suffix string) ([]string, []any) {
+	var clauses []string
+	var args []any
+	if attrs.NamePattern != "" {
+		clauses = append(clauses, fmt.Sprintf("name like @name_%s escape '\\'", suffix))
+		args = append(args, sql.Named(fmt.Sprintf("name_%s", suffix), namePatternToLike(attrs.NamePattern)))
+	}
+	keys := make([]string, 0, len(attrs.Tags))
+	for k := range attrs.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	tagCnt := 0
+	for _, k := range keys {
+		for _, v := range attrs.Tags[k] {
+			tagCnt++
+			clauses = append(clauses, fmt.Sprintf(
+				"exists (select from resource_tag where resource_id = public_id and key = @tag_key_%s_%d and value = @tag_value_%s_%d)",
+				suffix, tagCnt, suffix, tagCnt))
+			args = append(args,
+				sql.Named(fmt.Sprintf("tag_key_%s_%d", suffix, tagCnt), k),
+				sql.Named(fmt.Sprintf("tag_value_%s_%d", suffix, tagCnt), v))
+		}
+	}
+	return clauses, args
+}
+
+// namePatternToLike converts a grant name pattern, in which "*" matches any
+// run of characters, into a pattern for like using backslash as the escape
+// character.
+func namePatternToLike(pattern string) string {
+	r := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`, "*", "%")
+	return r.Replace(pattern)
+}
+
 // DeleteTarget will delete a target from the repository.
 func (r *Repository) DeleteTarget(ctx context.Context, publicId string, _ ...Option) (int, error) {
 	const op = "target.(Repository).DeleteTarget"
+	ctx = db.NewOpContext(ctx, op)
 	if publicId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
 	}
@@ -380,6 +456,7 @@ func (r *Repository) DeleteTarget(ctx context.Context, publicId string, _ ...Opt
 // WithPublicId is the only supported option.
 func (r *Repository) CreateTarget(ctx context.Context, target Target, opt ...Option) (Target, error) {
 	const op = "target.(Repository).CreateTarget"
+	ctx = db.NewOpContext(ctx, op)
 	opts := GetOpts(opt...)
 	if target == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing target")
@@ -442,6 +519,9 @@ func (r *Repository) CreateTarget(ctx context.Context, target Target, opt ...Opt
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(read db.Reader, w db.Writer) error {
+			if err := iam.CheckQuota(ctx, read, t.GetProjectId(), resource.Target); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 			targetTicket, err := w.GetTicket(ctx, t)
 			if err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
@@ -483,6 +563,7 @@ func (r *Repository) CreateTarget(ctx context.Context, target Target, opt ...Opt
 // then an error is returned.
 func (r *Repository) UpdateTarget(ctx context.Context, target Target, version uint32, fieldMaskPaths []string, _ ...Option) (Target, int, error) {
 	const op = "target.(Repository).UpdateTarget"
+	ctx = db.NewOpContext(ctx, op)
 	if target == nil {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing target")
 	}
@@ -511,6 +592,12 @@ func (r *Repository) UpdateTarget(ctx context.Context, target Target, version ui
 		case strings.EqualFold("defaultport", f):
 		case strings.EqualFold("sessionmaxseconds", f):
 		case strings.EqualFold("sessionconnectionlimit", f):
+		case strings.EqualFold("sessionmaxbytes", f):
+		case strings.EqualFold("connectionmaxbytespersecond", f):
+		case strings.EqualFold("connectionidletimeoutseconds", f):
+		case strings.EqualFold("endpointtlsenabled", f):
+		case strings.EqualFold("endpointtlscacert", f):
+		case strings.EqualFold("endpointtlsservername", f):
 		case strings.EqualFold("workerfilter", f):
 		case strings.EqualFold("egressworkerfilter", f):
 		case strings.EqualFold("ingressworkerfilter", f):
@@ -525,18 +612,24 @@ func (r *Repository) UpdateTarget(ctx context.Context, target Target, version ui
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbw.BuildUpdatePaths(
 		map[string]any{
-			"Name":                   target.GetName(),
-			"Description":            target.GetDescription(),
-			"DefaultPort":            target.GetDefaultPort(),
-			"SessionMaxSeconds":      target.GetSessionMaxSeconds(),
-			"SessionConnectionLimit": target.GetSessionConnectionLimit(),
-			"WorkerFilter":           target.GetWorkerFilter(),
-			"EgressWorkerFilter":     target.GetEgressWorkerFilter(),
-			"IngressWorkerFilter":    target.GetIngressWorkerFilter(),
-			"Address":                target.GetAddress(),
+			"Name":                         target.GetName(),
+			"Description":                  target.GetDescription(),
+			"DefaultPort":                  target.GetDefaultPort(),
+			"SessionMaxSeconds":            target.GetSessionMaxSeconds(),
+			"SessionConnectionLimit":       target.GetSessionConnectionLimit(),
+			"SessionMaxBytes":              target.GetSessionMaxBytes(),
+			"ConnectionMaxBytesPerSecond":  target.GetConnectionMaxBytesPerSecond(),
+			"ConnectionIdleTimeoutSeconds": target.GetConnectionIdleTimeoutSeconds(),
+			"EndpointTlsEnabled":           target.GetEndpointTlsEnabled(),
+			"EndpointTlsCaCert":            target.GetEndpointTlsCaCert(),
+			"EndpointTlsServerName":        target.GetEndpointTlsServerName(),
+			"WorkerFilter":                 target.GetWorkerFilter(),
+			"EgressWorkerFilter":           target.GetEgressWorkerFilter(),
+			"IngressWorkerFilter":          target.GetIngressWorkerFilter(),
+			"Address":                      target.GetAddress(),
 		},
 		fieldMaskPaths,
-		[]string{"SessionMaxSeconds", "SessionConnectionLimit"},
+		[]string{"SessionMaxSeconds", "SessionConnectionLimit", "SessionMaxBytes", "ConnectionMaxBytesPerSecond", "ConnectionIdleTimeoutSeconds", "EndpointTlsEnabled"},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
@@ -24,6 +24,7 @@ import (
 // The targetVersion must match the current version of the targetId in the repository.
 func (r *Repository) AddTargetCredentialSources(ctx context.Context, targetId string, targetVersion uint32, idsByPurpose CredentialSources, _ ...Option) (Target, error) {
 	const op = "target.(Repository).AddTargetCredentialSources"
+	ctx = db.NewOpContext(ctx, op)
 	if targetId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing target id")
 	}
@@ -146,6 +147,7 @@ func (r *Repository) AddTargetCredentialSources(ctx context.Context, targetId st
 // The target's current db version must match the targetVersion or an error will be returned.
 func (r *Repository) DeleteTargetCredentialSources(ctx context.Context, targetId string, targetVersion uint32, idsByPurpose CredentialSources, _ ...Option) (int, error) {
 	const op = "target.(Repository).DeleteTargetCredentialSources"
+	ctx = db.NewOpContext(ctx, op)
 	if targetId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing target id")
 	}
@@ -260,6 +262,7 @@ func (r *Repository) DeleteTargetCredentialSources(ctx context.Context, targetId
 // with the request. If clIds is empty, all the credential sources will be cleared from the target.
 func (r *Repository) SetTargetCredentialSources(ctx context.Context, targetId string, targetVersion uint32, ids CredentialSources, _ ...Option) ([]HostSource, []CredentialSource, int, error) {
 	const op = "target.(Repository).SetTargetCredentialSources"
+	ctx = db.NewOpContext(ctx, op)
 	if targetId == "" {
 		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing target id")
 	}
@@ -465,6 +468,7 @@ func (r *Repository) changes(ctx context.Context, targetId string, ids []string,
 	err error,
 ) {
 	const op = "target.(Repository).changes"
+	ctx = db.NewOpContext(ctx, op)
 
 	// TODO ensure that all cls have the same purpose as the given purpose?
 
@@ -538,6 +542,7 @@ func fetchCredentialSources(ctx context.Context, r db.Reader, targetId string) (
 
 func (r *Repository) createSources(ctx context.Context, tId string, tSubtype subtypes.Subtype, credSources CredentialSources) ([]*CredentialLibrary, []*StaticCredential, error) {
 	const op = "target.(Repository).createSources"
+	ctx = db.NewOpContext(ctx, op)
 
 	// Get a list of unique ids being attached to the target, to be used for looking up the source type (library or static)
 	ids := strutil.MergeSlices(credSources.BrokeredCredentialIds, credSources.InjectedApplicationCredentialIds)
@@ -20,6 +20,7 @@ import (
 // WithVersion option and will return an error.
 func (r *Repository) AddTargetHostSources(ctx context.Context, targetId string, targetVersion uint32, hostSourceIds []string, _ ...Option) (Target, error) {
 	const op = "target.(Repository).AddTargetHostSources"
+	ctx = db.NewOpContext(ctx, op)
 	if targetId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing target id")
 	}
@@ -128,6 +129,7 @@ func (r *Repository) AddTargetHostSources(ctx context.Context, targetId string,
 // return an error.
 func (r *Repository) DeleteTargetHostSources(ctx context.Context, targetId string, targetVersion uint32, hostSourceIds []string, _ ...Option) (int, error) {
 	const op = "target.(Repository).DeleteTargetHostSources"
+	ctx = db.NewOpContext(ctx, op)
 	if targetId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing target id")
 	}
@@ -221,6 +223,7 @@ func (r *Repository) DeleteTargetHostSources(ctx context.Context, targetId strin
 // is not a valid value for the WithVersion option and will return an error.
 func (r *Repository) SetTargetHostSources(ctx context.Context, targetId string, targetVersion uint32, hostSourceIds []string, _ ...Option) ([]HostSource, []CredentialSource, int, error) {
 	const op = "target.(Repository).SetTargetHostSources"
+	ctx = db.NewOpContext(ctx, op)
 	if targetId == "" {
 		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing target id")
 	}
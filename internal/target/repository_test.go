@@ -222,6 +222,22 @@ func TestRepositoryListPermissionWhereClauses(t *testing.T) {
 				sql.Named("public_id_1", "{resourceid1,resourceid2}"),
 			},
 		},
+		{
+			name: "onePermissionExcludedResourceIds",
+			perms: []perms.Permission{
+				{
+					ScopeId:             "scope_a",
+					Action:              action.List,
+					All:                 true,
+					ExcludedResourceIds: []string{"resourceid1", "resourceid2"},
+				},
+			},
+			expWhere: []string{"(project_id = @project_id_1 and public_id != all(@excluded_public_id_1))"},
+			expArgs: []any{
+				sql.Named("project_id_1", "scope_a"),
+				sql.Named("excluded_public_id_1", "{resourceid1,resourceid2}"),
+			},
+		},
 		{
 			name: "multiplePermissionsAllResources",
 			perms: []perms.Permission{
@@ -299,6 +315,89 @@ func TestRepositoryListPermissionWhereClauses(t *testing.T) {
 				sql.Named("project_id_4", "scope_d"),
 			},
 		},
+		{
+			name: "attributes",
+			perms: []perms.Permission{
+				{
+					ScopeId: "scope_a",
+					Action:  action.List,
+					Attributes: []perms.ResourceAttributes{
+						{NamePattern: "web_*"},
+						{Tags: map[string][]string{"env": {"dev"}}},
+					},
+				},
+			},
+			expWhere: []string{
+				"(project_id = @project_id_1 and ((name like @name_1_1 escape '\\') or (exists (select from resource_tag where resource_id = public_id and key = @tag_key_1_2_1 and value = @tag_value_1_2_1))))",
+			},
+			expArgs: []any{
+				sql.Named("project_id_1", "scope_a"),
+				sql.Named("name_1_1", "web\\_%"),
+				sql.Named("tag_key_1_2_1", "env"),
+				sql.Named("tag_value_1_2_1", "dev"),
+			},
+		},
+		{
+			name: "attributesWithIds",
+			perms: []perms.Permission{
+				{
+					ScopeId:     "scope_a",
+					Action:      action.List,
+					ResourceIds: []string{"resourceid1"},
+					Attributes: []perms.ResourceAttributes{
+						{NamePattern: "db", Tags: map[string][]string{"env": {"dev", "test"}}},
+					},
+				},
+			},
+			expWhere: []string{
+				"(project_id = @project_id_1 and (public_id = any(@public_id_1) or (name like @name_1_1 escape '\\' and exists (select from resource_tag where resource_id = public_id and key = @tag_key_1_1_1 and value = @tag_value_1_1_1) and exists (select from resource_tag where resource_id = public_id and key = @tag_key_1_1_2 and value = @tag_value_1_1_2))))",
+			},
+			expArgs: []any{
+				sql.Named("project_id_1", "scope_a"),
+				sql.Named("public_id_1", "{resourceid1}"),
+				sql.Named("name_1_1", "db"),
+				sql.Named("tag_key_1_1_1", "env"),
+				sql.Named("tag_value_1_1_1", "dev"),
+				sql.Named("tag_key_1_1_2", "env"),
+				sql.Named("tag_value_1_1_2", "test"),
+			},
+		},
+		{
+			name: "attributesWithAll",
+			perms: []perms.Permission{
+				{
+					ScopeId: "scope_a",
+					Action:  action.List,
+					All:     true,
+					Attributes: []perms.ResourceAttributes{
+						{NamePattern: "web_*"},
+					},
+				},
+			},
+			expWhere: []string{"(project_id = @project_id_1)"},
+			expArgs:  []any{sql.Named("project_id_1", "scope_a")},
+		},
+		{
+			name: "excludedAttributesWithAll",
+			perms: []perms.Permission{
+				{
+					ScopeId: "scope_a",
+					Action:  action.List,
+					All:     true,
+					ExcludedAttributes: []perms.ResourceAttributes{
+						{Tags: map[string][]string{"env": {"prod"}}},
+					},
+				},
+			},
+			expWhere: []string{
+				"(project_id = @project_id_1 and (exists (select from resource_tag where resource_id = public_id and key = @tag_key_1_excluded_1_1 and value = @tag_value_1_excluded_1_1)) is not true)",
+			},
+			expArgs: []any{
+				sql.Named("project_id_1", "scope_a"),
+				sql.Named("tag_key_1_excluded_1_1", "env"),
+				sql.Named("tag_value_1_excluded_1_1", "prod"),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -29,6 +29,12 @@ type Target interface {
 	GetUpdateTime() *timestamp.Timestamp
 	GetSessionMaxSeconds() uint32
 	GetSessionConnectionLimit() int32
+	GetSessionMaxBytes() int64
+	GetConnectionMaxBytesPerSecond() int64
+	GetConnectionIdleTimeoutSeconds() uint32
+	GetEndpointTlsEnabled() bool
+	GetEndpointTlsCaCert() string
+	GetEndpointTlsServerName() string
 	GetWorkerFilter() string
 	GetEgressWorkerFilter() string
 	GetIngressWorkerFilter() string
@@ -46,6 +52,12 @@ type Target interface {
 	SetUpdateTime(*timestamp.Timestamp)
 	SetSessionMaxSeconds(uint32)
 	SetSessionConnectionLimit(int32)
+	SetSessionMaxBytes(int64)
+	SetConnectionMaxBytesPerSecond(int64)
+	SetConnectionIdleTimeoutSeconds(uint32)
+	SetEndpointTlsEnabled(bool)
+	SetEndpointTlsCaCert(string)
+	SetEndpointTlsServerName(string)
 	SetWorkerFilter(string)
 	SetEgressWorkerFilter(string)
 	SetIngressWorkerFilter(string)
@@ -165,6 +177,12 @@ func (t *targetView) targetSubtype(ctx context.Context, address string) (Target,
 	tt.SetUpdateTime(t.UpdateTime)
 	tt.SetSessionMaxSeconds(t.SessionMaxSeconds)
 	tt.SetSessionConnectionLimit(t.SessionConnectionLimit)
+	tt.SetSessionMaxBytes(t.SessionMaxBytes)
+	tt.SetConnectionMaxBytesPerSecond(t.ConnectionMaxBytesPerSecond)
+	tt.SetConnectionIdleTimeoutSeconds(t.ConnectionIdleTimeoutSeconds)
+	tt.SetEndpointTlsEnabled(t.EndpointTlsEnabled)
+	tt.SetEndpointTlsCaCert(t.EndpointTlsCaCert)
+	tt.SetEndpointTlsServerName(t.EndpointTlsServerName)
 	tt.SetWorkerFilter(t.WorkerFilter)
 	tt.SetEgressWorkerFilter(t.EgressWorkerFilter)
 	tt.SetIngressWorkerFilter(t.IngressWorkerFilter)
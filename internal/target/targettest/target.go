@@ -119,6 +119,30 @@ func (t *Target) GetSessionConnectionLimit() int32 {
 	return t.SessionConnectionLimit
 }
 
+func (t *Target) GetSessionMaxBytes() int64 {
+	return t.SessionMaxBytes
+}
+
+func (t *Target) GetConnectionMaxBytesPerSecond() int64 {
+	return t.ConnectionMaxBytesPerSecond
+}
+
+func (t *Target) GetConnectionIdleTimeoutSeconds() uint32 {
+	return t.ConnectionIdleTimeoutSeconds
+}
+
+func (t *Target) GetEndpointTlsEnabled() bool {
+	return t.EndpointTlsEnabled
+}
+
+func (t *Target) GetEndpointTlsCaCert() string {
+	return t.EndpointTlsCaCert
+}
+
+func (t *Target) GetEndpointTlsServerName() string {
+	return t.EndpointTlsServerName
+}
+
 func (t *Target) GetWorkerFilter() string {
 	return t.WorkerFilter
 }
@@ -194,6 +218,30 @@ func (t *Target) SetSessionConnectionLimit(l int32) {
 	t.SessionConnectionLimit = l
 }
 
+func (t *Target) SetSessionMaxBytes(l int64) {
+	t.SessionMaxBytes = l
+}
+
+func (t *Target) SetConnectionMaxBytesPerSecond(l int64) {
+	t.ConnectionMaxBytesPerSecond = l
+}
+
+func (t *Target) SetConnectionIdleTimeoutSeconds(s uint32) {
+	t.ConnectionIdleTimeoutSeconds = s
+}
+
+func (t *Target) SetEndpointTlsEnabled(enabled bool) {
+	t.EndpointTlsEnabled = enabled
+}
+
+func (t *Target) SetEndpointTlsCaCert(caCert string) {
+	t.EndpointTlsCaCert = caCert
+}
+
+func (t *Target) SetEndpointTlsServerName(name string) {
+	t.EndpointTlsServerName = name
+}
+
 func (t *Target) SetWorkerFilter(filter string) {
 	t.WorkerFilter = filter
 }
@@ -293,15 +341,21 @@ func New(projectId string, opt ...target.Option) (target.Target, error) {
 	}
 	t := &Target{
 		Target: &store.Target{
-			ProjectId:              projectId,
-			Name:                   opts.WithName,
-			Description:            opts.WithDescription,
-			DefaultPort:            opts.WithDefaultPort,
-			SessionConnectionLimit: opts.WithSessionConnectionLimit,
-			SessionMaxSeconds:      opts.WithSessionMaxSeconds,
-			WorkerFilter:           opts.WithWorkerFilter,
-			EgressWorkerFilter:     opts.WithEgressWorkerFilter,
-			IngressWorkerFilter:    opts.WithIngressWorkerFilter,
+			ProjectId:                    projectId,
+			Name:                         opts.WithName,
+			Description:                  opts.WithDescription,
+			DefaultPort:                  opts.WithDefaultPort,
+			SessionConnectionLimit:       opts.WithSessionConnectionLimit,
+			SessionMaxBytes:              opts.WithSessionMaxBytes,
+			ConnectionMaxBytesPerSecond:  opts.WithConnectionMaxBytesPerSecond,
+			ConnectionIdleTimeoutSeconds: opts.WithConnectionIdleTimeoutSeconds,
+			EndpointTlsEnabled:           opts.WithEndpointTlsEnabled,
+			EndpointTlsCaCert:            opts.WithEndpointTlsCaCert,
+			EndpointTlsServerName:        opts.WithEndpointTlsServerName,
+			SessionMaxSeconds:            opts.WithSessionMaxSeconds,
+			WorkerFilter:                 opts.WithWorkerFilter,
+			EgressWorkerFilter:           opts.WithEgressWorkerFilter,
+			IngressWorkerFilter:          opts.WithIngressWorkerFilter,
 		},
 	}
 	return t, nil
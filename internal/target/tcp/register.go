@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/boundary/internal/credential"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/types/subtypes"
 )
 
 type targetHooks struct{}
@@ -75,7 +76,10 @@ func (h targetHooks) VetForUpdate(ctx context.Context, t target.Target, paths []
 }
 
 // VetCredentialSources checks that all the provided credential sources have a CredentialPurpose
-// of BrokeredPurpose. Any other CredentialPurpose will result in an error.
+// of BrokeredPurpose. The only exception is static TLS certificate credentials,
+// which may also have a CredentialPurpose of InjectedApplicationPurpose so the
+// worker can present them to the endpoint. Any other CredentialPurpose will
+// result in an error.
 func (h targetHooks) VetCredentialSources(ctx context.Context, libs []*target.CredentialLibrary, creds []*target.StaticCredential) error {
 	const op = "tcp.VetCredentialSources"
 
@@ -85,6 +89,10 @@ func (h targetHooks) VetCredentialSources(ctx context.Context, libs []*target.Cr
 		}
 	}
 	for _, c := range creds {
+		if c.GetCredentialPurpose() == string(credential.InjectedApplicationPurpose) &&
+			subtypes.SubtypeFromId(credential.Domain, c.GetCredentialId()) == credential.TlsCertificateSubtype {
+			continue
+		}
 		if c.GetCredentialPurpose() != string(credential.BrokeredPurpose) {
 			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("tcp.Target only supports credential purpose: %q", credential.BrokeredPurpose))
 		}
@@ -54,15 +54,21 @@ func (h targetHooks) NewTarget(projectId string, opt ...target.Option) (target.T
 	}
 	t := &Target{
 		Target: &store.Target{
-			ProjectId:              projectId,
-			Name:                   opts.WithName,
-			Description:            opts.WithDescription,
-			DefaultPort:            opts.WithDefaultPort,
-			SessionConnectionLimit: opts.WithSessionConnectionLimit,
-			SessionMaxSeconds:      opts.WithSessionMaxSeconds,
-			WorkerFilter:           opts.WithWorkerFilter,
-			EgressWorkerFilter:     opts.WithEgressWorkerFilter,
-			IngressWorkerFilter:    opts.WithIngressWorkerFilter,
+			ProjectId:                    projectId,
+			Name:                         opts.WithName,
+			Description:                  opts.WithDescription,
+			DefaultPort:                  opts.WithDefaultPort,
+			SessionConnectionLimit:       opts.WithSessionConnectionLimit,
+			SessionMaxBytes:              opts.WithSessionMaxBytes,
+			ConnectionMaxBytesPerSecond:  opts.WithConnectionMaxBytesPerSecond,
+			ConnectionIdleTimeoutSeconds: opts.WithConnectionIdleTimeoutSeconds,
+			EndpointTlsEnabled:           opts.WithEndpointTlsEnabled,
+			EndpointTlsCaCert:            opts.WithEndpointTlsCaCert,
+			EndpointTlsServerName:        opts.WithEndpointTlsServerName,
+			SessionMaxSeconds:            opts.WithSessionMaxSeconds,
+			WorkerFilter:                 opts.WithWorkerFilter,
+			EgressWorkerFilter:           opts.WithEgressWorkerFilter,
+			IngressWorkerFilter:          opts.WithIngressWorkerFilter,
 		},
 		Address: opts.WithAddress,
 	}
@@ -193,6 +199,30 @@ func (t *Target) SetSessionConnectionLimit(limit int32) {
 	t.SessionConnectionLimit = limit
 }
 
+func (t *Target) SetSessionMaxBytes(limit int64) {
+	t.SessionMaxBytes = limit
+}
+
+func (t *Target) SetConnectionMaxBytesPerSecond(limit int64) {
+	t.ConnectionMaxBytesPerSecond = limit
+}
+
+func (t *Target) SetConnectionIdleTimeoutSeconds(s uint32) {
+	t.ConnectionIdleTimeoutSeconds = s
+}
+
+func (t *Target) SetEndpointTlsEnabled(enabled bool) {
+	t.EndpointTlsEnabled = enabled
+}
+
+func (t *Target) SetEndpointTlsCaCert(caCert string) {
+	t.EndpointTlsCaCert = caCert
+}
+
+func (t *Target) SetEndpointTlsServerName(name string) {
+	t.EndpointTlsServerName = name
+}
+
 func (t *Target) SetWorkerFilter(filter string) {
 	t.WorkerFilter = filter
 }
@@ -67,6 +67,21 @@ const (
 	RotateScopeKeys                    Type = 53
 	ListScopeKeyVersionDestructionJobs Type = 54
 	DestroyScopeKeyVersion             Type = 55
+	AddGrantScopes                     Type = 56
+	SetGrantScopes                     Type = 57
+	RemoveGrantScopes                  Type = 58
+	AddTags                            Type = 59
+	SetTags                            Type = 60
+	RemoveTags                         Type = 61
+	Explain                            Type = 62
+	SetQuotas                          Type = 63
+	ListHistory                        Type = 64
+	ListEffectiveGrants                Type = 65
+	AddApiKey                          Type = 66
+	RemoveApiKey                       Type = 67
+	ReadScopeKeyRotationPolicy         Type = 68
+	SetScopeKeyRotationPolicy          Type = 69
+	Run                                Type = 70
 
 	// When adding new actions, be sure to update:
 	//
@@ -130,6 +145,21 @@ var Map = map[string]Type{
 	RotateScopeKeys.String():                    RotateScopeKeys,
 	ListScopeKeyVersionDestructionJobs.String(): ListScopeKeyVersionDestructionJobs,
 	DestroyScopeKeyVersion.String():             DestroyScopeKeyVersion,
+	AddGrantScopes.String():                     AddGrantScopes,
+	SetGrantScopes.String():                     SetGrantScopes,
+	RemoveGrantScopes.String():                  RemoveGrantScopes,
+	AddTags.String():                            AddTags,
+	SetTags.String():                            SetTags,
+	RemoveTags.String():                         RemoveTags,
+	Explain.String():                            Explain,
+	SetQuotas.String():                          SetQuotas,
+	ListHistory.String():                        ListHistory,
+	ListEffectiveGrants.String():                ListEffectiveGrants,
+	AddApiKey.String():                          AddApiKey,
+	RemoveApiKey.String():                       RemoveApiKey,
+	ReadScopeKeyRotationPolicy.String():         ReadScopeKeyRotationPolicy,
+	SetScopeKeyRotationPolicy.String():          SetScopeKeyRotationPolicy,
+	Run.String():                                Run,
 }
 
 var DeprecatedMap = map[string]Type{
@@ -199,6 +229,21 @@ func (a Type) String() string {
 		"rotate-keys",
 		"list-key-version-destruction-jobs",
 		"destroy-key-version",
+		"add-grant-scopes",
+		"set-grant-scopes",
+		"remove-grant-scopes",
+		"add-tags",
+		"set-tags",
+		"remove-tags",
+		"explain",
+		"set-quotas",
+		"list-history",
+		"list-effective-grants",
+		"add-api-key",
+		"remove-api-key",
+		"read-key-rotation-policy",
+		"set-key-rotation-policy",
+		"run",
 	}[a]
 }
 
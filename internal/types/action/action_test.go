@@ -134,6 +134,66 @@ func TestAction(t *testing.T) {
 			action: DestroyScopeKeyVersion,
 			want:   "destroy-key-version",
 		},
+		{
+			action: AddGrantScopes,
+			want:   "add-grant-scopes",
+		},
+		{
+			action: SetGrantScopes,
+			want:   "set-grant-scopes",
+		},
+		{
+			action: RemoveGrantScopes,
+			want:   "remove-grant-scopes",
+		},
+		{
+			action: AddTags,
+			want:   "add-tags",
+		},
+		{
+			action: SetTags,
+			want:   "set-tags",
+		},
+		{
+			action: RemoveTags,
+			want:   "remove-tags",
+		},
+		{
+			action: Explain,
+			want:   "explain",
+		},
+		{
+			action: SetQuotas,
+			want:   "set-quotas",
+		},
+		{
+			action: ListHistory,
+			want:   "list-history",
+		},
+		{
+			action: ListEffectiveGrants,
+			want:   "list-effective-grants",
+		},
+		{
+			action: AddApiKey,
+			want:   "add-api-key",
+		},
+		{
+			action: RemoveApiKey,
+			want:   "remove-api-key",
+		},
+		{
+			action: ReadScopeKeyRotationPolicy,
+			want:   "read-key-rotation-policy",
+		},
+		{
+			action: SetScopeKeyRotationPolicy,
+			want:   "set-key-rotation-policy",
+		},
+		{
+			action: Run,
+			want:   "run",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
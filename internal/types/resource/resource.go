@@ -29,6 +29,8 @@ const (
 	CredentialStore
 	CredentialLibrary
 	Credential
+	ServiceAccount
+	Job
 	// NOTE: When adding a new type, be sure to update:
 	//
 	// * The Grant.validateType function and test
@@ -65,6 +67,8 @@ func (r Type) String() string {
 		"credential-store",
 		"credential-library",
 		"credential",
+		"service-account",
+		"job",
 	}[r]
 }
 
@@ -98,6 +102,8 @@ var Map = map[string]Type{
 	CredentialStore.String():   CredentialStore,
 	CredentialLibrary.String(): CredentialLibrary,
 	Credential.String():        Credential,
+	ServiceAccount.String():    ServiceAccount,
+	Job.String():               Job,
 }
 
 // Parent returns the parent type for a given type; if there is no parent, it
@@ -124,6 +130,18 @@ func HasChildTypes(in Type) bool {
 	return false
 }
 
+// Taggable indicates whether resources of this type can carry tags, which
+// grants can match on
+func Taggable(typ Type) bool {
+	switch typ {
+	case CredentialStore,
+		HostCatalog,
+		Target:
+		return true
+	}
+	return false
+}
+
 // TopLevelType indicates whether this is a type that supports collection
 // actions, e.g. Create/List
 func TopLevelType(typ Type) bool {
@@ -133,8 +151,10 @@ func TopLevelType(typ Type) bool {
 		CredentialStore,
 		Group,
 		HostCatalog,
+		Job,
 		Role,
 		Scope,
+		ServiceAccount,
 		Session,
 		Target,
 		User,
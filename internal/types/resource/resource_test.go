@@ -15,6 +15,7 @@ func Test_Resource(t *testing.T) {
 		want          Type
 		topLevelType  bool
 		hasChildTypes bool
+		taggable      bool
 		parent        Type
 	}{
 		{
@@ -66,6 +67,7 @@ func Test_Resource(t *testing.T) {
 			want:          HostCatalog,
 			topLevelType:  true,
 			hasChildTypes: true,
+			taggable:      true,
 		},
 		{
 			typeString: "host-set",
@@ -81,6 +83,7 @@ func Test_Resource(t *testing.T) {
 			typeString:   "target",
 			want:         Target,
 			topLevelType: true,
+			taggable:     true,
 		},
 		{
 			typeString: "controller",
@@ -106,6 +109,7 @@ func Test_Resource(t *testing.T) {
 			want:          CredentialStore,
 			topLevelType:  true,
 			hasChildTypes: true,
+			taggable:      true,
 		},
 		{
 			typeString: "credential-library",
@@ -117,6 +121,16 @@ func Test_Resource(t *testing.T) {
 			want:       Credential,
 			parent:     CredentialStore,
 		},
+		{
+			typeString:   "service-account",
+			want:         ServiceAccount,
+			topLevelType: true,
+		},
+		{
+			typeString:   "job",
+			want:         Job,
+			topLevelType: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.typeString, func(t *testing.T) {
@@ -124,6 +138,7 @@ func Test_Resource(t *testing.T) {
 			assert.Equalf(t, tt.typeString, tt.want.String(), "unexpected string for %s", tt.typeString)
 			assert.Equalf(t, tt.topLevelType, TopLevelType(tt.want), "unexpected top level type types for %s", tt.typeString)
 			assert.Equalf(t, tt.hasChildTypes, HasChildTypes(tt.want), "unexpected has child types for %s", tt.typeString)
+			assert.Equalf(t, tt.taggable, Taggable(tt.want), "unexpected taggable for %s", tt.typeString)
 			parent := Parent(tt.want)
 			if tt.parent == Unknown {
 				assert.Equal(t, tt.want, parent)
@@ -33,4 +33,8 @@ type Account struct {
 	LoginName *string
 	Subject   *string
 	Email     *string
+	FullName  *string
+	// Claims are the string valued claims of an OIDC account, from its ID
+	// token and userinfo, keyed by claim name
+	Claims map[string]string
 }
@@ -73,9 +73,11 @@ func main() {
 		host,
 		hostCatalog,
 		hostSet,
+		job,
 		managedGroup,
 		role,
 		scope,
+		serviceAccount,
 		session,
 		target,
 		user,
@@ -315,6 +317,32 @@ func rudActions(typ string, pin bool) []*Action {
 	return ret
 }
 
+func tagActions(typ string) []*Action {
+	return []*Action{
+		{
+			Name:        "add-tags",
+			Description: fmt.Sprintf("Add tags to %s", typ),
+			Examples: []string{
+				"id=<id>;actions=add-tags",
+			},
+		},
+		{
+			Name:        "set-tags",
+			Description: fmt.Sprintf("Set the full set of tags on %s", typ),
+			Examples: []string{
+				"id=<id>;actions=set-tags",
+			},
+		},
+		{
+			Name:        "remove-tags",
+			Description: fmt.Sprintf("Remove tags from %s", typ),
+			Examples: []string{
+				"id=<id>;actions=remove-tags",
+			},
+		},
+	}
+}
+
 var account = &Resource{
 	Type:   "Account",
 	Scopes: iamScopes,
@@ -472,6 +500,13 @@ var group = &Resource{
 						"id=<id>;actions=remove-members",
 					},
 				},
+				&Action{
+					Name:        "list-history",
+					Description: "List the changes made to a group, its members and its assignment to roles",
+					Examples: []string{
+						"id=<id>;actions=list-history",
+					},
+				},
 			),
 		},
 	},
@@ -517,7 +552,10 @@ var hostCatalog = &Resource{
 				"ID":   "<id>",
 				"Type": "host-catalog",
 			},
-			Actions: rudActions("a host catalog", false),
+			Actions: append(
+				rudActions("a host catalog", false),
+				tagActions("a host catalog")...,
+			),
 		},
 	},
 }
@@ -571,6 +609,43 @@ var hostSet = &Resource{
 	},
 }
 
+var job = &Resource{
+	Type:   "Job",
+	Scopes: []string{"Global"},
+	Endpoints: []*Endpoint{
+		{
+			Path: "/jobs",
+			Params: map[string]string{
+				"Type": "job",
+			},
+			Actions: lActions("a job"),
+		},
+		{
+			Path: "/jobs/<id>",
+			Params: map[string]string{
+				"ID":   "<id>",
+				"Type": "job",
+			},
+			Actions: []*Action{
+				{
+					Name:        "read",
+					Description: "Read a job",
+					Examples: []string{
+						"id=*;type=<type>;actions=read",
+					},
+				},
+				{
+					Name:        "run",
+					Description: "Run a job now",
+					Examples: []string{
+						"id=*;type=<type>;actions=run",
+					},
+				},
+			},
+		},
+	},
+}
+
 var managedGroup = &Resource{
 	Type:   "Managed Group",
 	Scopes: iamScopes,
@@ -603,7 +678,16 @@ var role = &Resource{
 			Params: map[string]string{
 				"Type": "role",
 			},
-			Actions: clActions("a role"),
+			Actions: append(
+				clActions("a role"),
+				&Action{
+					Name:        "explain",
+					Description: "Explain the permissions of principals other than the requester on resources in the scope",
+					Examples: []string{
+						"type=<type>;actions=explain",
+					},
+				},
+			),
 		},
 		{
 			Path: "/roles/<id>",
@@ -655,6 +739,13 @@ var role = &Resource{
 						"id=<id>;actions=remove-grants",
 					},
 				},
+				&Action{
+					Name:        "list-history",
+					Description: "List the changes made to a role, its grants, grant scopes and principals",
+					Examples: []string{
+						"id=<id>;actions=list-history",
+					},
+				},
 			),
 		},
 	},
@@ -677,7 +768,54 @@ var scope = &Resource{
 				"ID":   "<id>",
 				"Type": "scope",
 			},
-			Actions: rudActions("a scope", false),
+			Actions: append(
+				rudActions("a scope", false),
+				&Action{
+					Name:        "set-quotas",
+					Description: "Set the resource quotas of an org or project scope",
+					Examples: []string{
+						"id=<id>;actions=set-quotas",
+					},
+				},
+			),
+		},
+	},
+}
+
+var serviceAccount = &Resource{
+	Type:   "Service Account",
+	Scopes: iamScopes,
+	Endpoints: []*Endpoint{
+		{
+			Path: "/service-accounts",
+			Params: map[string]string{
+				"Type": "service-account",
+			},
+			Actions: clActions("a service account"),
+		},
+		{
+			Path: "/service-accounts/<id>",
+			Params: map[string]string{
+				"ID":   "<id>",
+				"Type": "service-account",
+			},
+			Actions: append(
+				rudActions("a service account", false),
+				&Action{
+					Name:        "add-api-key",
+					Description: "Add an API key to a service account",
+					Examples: []string{
+						"id=<id>;actions=add-api-key",
+					},
+				},
+				&Action{
+					Name:        "remove-api-key",
+					Description: "Remove an API key from a service account",
+					Examples: []string{
+						"id=<id>;actions=remove-api-key",
+					},
+				},
+			),
 		},
 	},
 }
@@ -802,11 +940,33 @@ var target = &Resource{
 						"id=<id>;actions=remove-credential-sources",
 					},
 				},
+				&Action{
+					Name:        "add-tags",
+					Description: "Add tags to a target",
+					Examples: []string{
+						"id=<id>;actions=add-tags",
+					},
+				},
+				&Action{
+					Name:        "set-tags",
+					Description: "Set the full set of tags on a target",
+					Examples: []string{
+						"id=<id>;actions=set-tags",
+					},
+				},
+				&Action{
+					Name:        "remove-tags",
+					Description: "Remove tags from a target",
+					Examples: []string{
+						"id=<id>;actions=remove-tags",
+					},
+				},
 				&Action{
 					Name:        "authorize-session",
 					Description: "Authorize a session via the target",
 					Examples: []string{
 						"id=<id>;actions=authorize-session",
+						"type=target;tags.env=dev;actions=authorize-session",
 					},
 				},
 			),
@@ -854,6 +1014,20 @@ var user = &Resource{
 						"id=<id>;actions=remove-accounts",
 					},
 				},
+				&Action{
+					Name:        "list-history",
+					Description: "List the changes made to a user, its accounts, group memberships and assignment to roles",
+					Examples: []string{
+						"id=<id>;actions=list-history",
+					},
+				},
+				&Action{
+					Name:        "list-effective-grants",
+					Description: "List the grants that apply to a user, now or at a past time",
+					Examples: []string{
+						"id=<id>;actions=list-effective-grants",
+					},
+				},
 			),
 		},
 	},
@@ -12,12 +12,18 @@ import (
 // Tags implements the encrypt.Taggable interface which allows
 // CredentialStore map fields to be classified for the encrypt filter.
 func (req *CredentialStore) Tags() ([]encrypt.PointerTag, error) {
-	tags := make([]encrypt.PointerTag, 0, len(req.AuthorizedCollectionActions))
+	tags := make([]encrypt.PointerTag, 0, len(req.AuthorizedCollectionActions)+len(req.ResourceTags))
 	for k := range req.AuthorizedCollectionActions {
 		tags = append(tags, encrypt.PointerTag{
 			Pointer:        fmt.Sprintf("/AuthorizedCollectionActions/%s", k),
 			Classification: encrypt.PublicClassification,
 		})
 	}
+	for k := range req.ResourceTags {
+		tags = append(tags, encrypt.PointerTag{
+			Pointer:        fmt.Sprintf("/ResourceTags/%s", k),
+			Classification: encrypt.PublicClassification,
+		})
+	}
 	return tags, nil
 }
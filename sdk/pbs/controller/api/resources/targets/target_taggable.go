@@ -0,0 +1,23 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package targets
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/eventlogger/filters/encrypt"
+)
+
+// Tags implements the encrypt.Taggable interface which allows
+// Target map fields to be classified for the encrypt filter.
+func (req *Target) Tags() ([]encrypt.PointerTag, error) {
+	tags := make([]encrypt.PointerTag, 0, len(req.ResourceTags))
+	for k := range req.ResourceTags {
+		tags = append(tags, encrypt.PointerTag{
+			Pointer:        fmt.Sprintf("/ResourceTags/%s", k),
+			Classification: encrypt.PublicClassification,
+		})
+	}
+	return tags, nil
+}
@@ -264,6 +264,81 @@ func (x *User) GetPrimaryAccountId() string {
 	return ""
 }
 
+type EffectiveGrant struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Output only. The ID of the Role providing the grant.
+	RoleId string `protobuf:"bytes,1,opt,name=role_id,proto3" json:"role_id,omitempty" class:"public"` // @gotags: `class:"public"`
+	// Output only. The ID of the Scope containing the Role.
+	RoleScopeId string `protobuf:"bytes,2,opt,name=role_scope_id,proto3" json:"role_scope_id,omitempty" class:"public"` // @gotags: `class:"public"`
+	// Output only. The scope the grant applies to.
+	GrantScopeId string `protobuf:"bytes,3,opt,name=grant_scope_id,proto3" json:"grant_scope_id,omitempty" class:"public"` // @gotags: `class:"public"`
+	// Output only. The canonical form of the grant.
+	Grant string `protobuf:"bytes,4,opt,name=grant,proto3" json:"grant,omitempty" class:"public"` // @gotags: `class:"public"`
+}
+
+func (x *EffectiveGrant) Reset() {
+	*x = EffectiveGrant{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_api_resources_users_v1_user_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EffectiveGrant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EffectiveGrant) ProtoMessage() {}
+
+func (x *EffectiveGrant) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_api_resources_users_v1_user_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EffectiveGrant.ProtoReflect.Descriptor instead.
+func (*EffectiveGrant) Descriptor() ([]byte, []int) {
+	return file_controller_api_resources_users_v1_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EffectiveGrant) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *EffectiveGrant) GetRoleScopeId() string {
+	if x != nil {
+		return x.RoleScopeId
+	}
+	return ""
+}
+
+func (x *EffectiveGrant) GetGrantScopeId() string {
+	if x != nil {
+		return x.GrantScopeId
+	}
+	return ""
+}
+
+func (x *EffectiveGrant) GetGrant() string {
+	if x != nil {
+		return x.Grant
+	}
+	return ""
+}
+
 var File_controller_api_resources_users_v1_user_proto protoreflect.FileDescriptor
 
 var file_controller_api_resources_users_v1_user_proto_rawDesc = []byte{
@@ -331,12 +406,21 @@ var file_controller_api_resources_users_v1_user_proto_rawDesc = []byte{
 	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x2f, 0x0a, 0x12, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79,
 	0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x8c, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x12, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x61, 0x63, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x42, 0x4c, 0x5a, 0x4a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70, 0x2f, 0x62,
-	0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x2f, 0x73, 0x64, 0x6b, 0x2f, 0x70, 0x62, 0x73, 0x2f,
-	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x72,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x3b, 0x75,
-	0x73, 0x65, 0x72, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x22, 0x8e, 0x01, 0x0a, 0x0e, 0x45, 0x66, 0x66, 0x65, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x6f, 0x6c,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x6f, 0x6c, 0x65,
+	0x5f, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x73, 0x63, 0x6f, 0x70,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x6f, 0x6c, 0x65,
+	0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x67, 0x72, 0x61,
+	0x6e, 0x74, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x5f, 0x69,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x42, 0x4c, 0x5a, 0x4a, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70, 0x2f,
+	0x62, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x2f, 0x73, 0x64, 0x6b, 0x2f, 0x70, 0x62, 0x73,
+	0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x3b,
+	0x75, 0x73, 0x65, 0x72, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -351,20 +435,21 @@ func file_controller_api_resources_users_v1_user_proto_rawDescGZIP() []byte {
 	return file_controller_api_resources_users_v1_user_proto_rawDescData
 }
 
-var file_controller_api_resources_users_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_controller_api_resources_users_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_controller_api_resources_users_v1_user_proto_goTypes = []interface{}{
 	(*Account)(nil),                // 0: controller.api.resources.users.v1.Account
 	(*User)(nil),                   // 1: controller.api.resources.users.v1.User
-	(*scopes.ScopeInfo)(nil),       // 2: controller.api.resources.scopes.v1.ScopeInfo
-	(*wrapperspb.StringValue)(nil), // 3: google.protobuf.StringValue
-	(*timestamppb.Timestamp)(nil),  // 4: google.protobuf.Timestamp
+	(*EffectiveGrant)(nil),         // 2: controller.api.resources.users.v1.EffectiveGrant
+	(*scopes.ScopeInfo)(nil),       // 3: controller.api.resources.scopes.v1.ScopeInfo
+	(*wrapperspb.StringValue)(nil), // 4: google.protobuf.StringValue
+	(*timestamppb.Timestamp)(nil),  // 5: google.protobuf.Timestamp
 }
 var file_controller_api_resources_users_v1_user_proto_depIdxs = []int32{
-	2, // 0: controller.api.resources.users.v1.User.scope:type_name -> controller.api.resources.scopes.v1.ScopeInfo
-	3, // 1: controller.api.resources.users.v1.User.name:type_name -> google.protobuf.StringValue
-	3, // 2: controller.api.resources.users.v1.User.description:type_name -> google.protobuf.StringValue
-	4, // 3: controller.api.resources.users.v1.User.created_time:type_name -> google.protobuf.Timestamp
-	4, // 4: controller.api.resources.users.v1.User.updated_time:type_name -> google.protobuf.Timestamp
+	3, // 0: controller.api.resources.users.v1.User.scope:type_name -> controller.api.resources.scopes.v1.ScopeInfo
+	4, // 1: controller.api.resources.users.v1.User.name:type_name -> google.protobuf.StringValue
+	4, // 2: controller.api.resources.users.v1.User.description:type_name -> google.protobuf.StringValue
+	5, // 3: controller.api.resources.users.v1.User.created_time:type_name -> google.protobuf.Timestamp
+	5, // 4: controller.api.resources.users.v1.User.updated_time:type_name -> google.protobuf.Timestamp
 	0, // 5: controller.api.resources.users.v1.User.accounts:type_name -> controller.api.resources.users.v1.Account
 	6, // [6:6] is the sub-list for method output_type
 	6, // [6:6] is the sub-list for method input_type
@@ -403,6 +488,18 @@ func file_controller_api_resources_users_v1_user_proto_init() {
 				return nil
 			}
 		}
+		file_controller_api_resources_users_v1_user_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EffectiveGrant); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -410,7 +507,7 @@ func file_controller_api_resources_users_v1_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_controller_api_resources_users_v1_user_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},